@@ -76,6 +76,10 @@ func NewServiceCatalogServerOptions() *ServiceCatalogServerOptions {
 	}
 	// register all admission plugins
 	registerAllAdmissionPlugins(opts.AdmissionOptions.Plugins)
+	// order the admission plugins, and only run them when enabled with
+	// --enable-admission-plugins
+	opts.AdmissionOptions.RecommendedPluginOrder = append(opts.AdmissionOptions.RecommendedPluginOrder, admissionPluginOrder...)
+	opts.AdmissionOptions.DefaultOffPlugins.Insert(admissionPluginOrder...)
 	// Set generated SSL cert path correctly
 	opts.SecureServingOptions.ServerCert.CertDirectory = certDirectory
 	return opts
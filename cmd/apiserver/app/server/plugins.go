@@ -25,7 +25,14 @@ import (
 	// Admission controllers
 	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/broker/authsarcheck"
 	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/namespace/lifecycle"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/parameters/defaultparameters"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/parameters/schemavalidator"
+	bindingsarcheck "github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/servicebindings/authsarcheck"
 	siclifecycle "github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/servicebindings/lifecycle"
+	instancesarcheck "github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceinstances/authsarcheck"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceinstances/deletionprotection"
+	instancequota "github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceinstances/quota"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceinstances/visibility"
 	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/changevalidator"
 	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/serviceplan/defaultserviceplan"
 )
@@ -37,4 +44,30 @@ func registerAllAdmissionPlugins(plugins *admission.Plugins) {
 	siclifecycle.Register(plugins)
 	changevalidator.Register(plugins)
 	authsarcheck.Register(plugins)
+	bindingsarcheck.Register(plugins)
+	instancesarcheck.Register(plugins)
+	defaultparameters.Register(plugins)
+	schemavalidator.Register(plugins)
+	deletionprotection.Register(plugins)
+	instancequota.Register(plugins)
+	visibility.Register(plugins)
+}
+
+// admissionPluginOrder lists the admission plugins registered by
+// registerAllAdmissionPlugins in the order they run in, when enabled. The
+// plugins that set defaults on objects run before the ones that validate
+// them.
+var admissionPluginOrder = []string{
+	lifecycle.PluginName,
+	defaultserviceplan.PluginName,
+	defaultparameters.PluginName,
+	siclifecycle.PluginName,
+	deletionprotection.PluginName,
+	visibility.PluginName,
+	instancequota.PluginName,
+	changevalidator.PluginName,
+	authsarcheck.PluginName,
+	bindingsarcheck.PluginName,
+	instancesarcheck.PluginName,
+	schemavalidator.PluginName,
 }
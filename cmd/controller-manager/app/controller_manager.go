@@ -27,6 +27,7 @@ import (
 	"strconv"
 	"time"
 
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	v1core "k8s.io/client-go/kubernetes/typed/core/v1"
 
@@ -37,6 +38,7 @@ import (
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/record"
 
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerlimits"
 	"github.com/kubernetes-incubator/service-catalog/pkg/kubernetes/pkg/util/configz"
 	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
 	"github.com/kubernetes-incubator/service-catalog/pkg/metrics/osbclientproxy"
@@ -366,21 +368,43 @@ func StartControllers(s *options.ControllerManagerServer,
 	// All shared informers are v1beta1 API level
 	serviceCatalogSharedInformers := informerFactory.Servicecatalog().V1beta1()
 
+	// Build the informer factory for core kubernetes resources
+	kubeInformerFactory := kubeinformers.NewSharedInformerFactory(coreClient, s.ResyncInterval)
+
 	glog.V(5).Infof("Creating controller; broker relist interval: %v", s.ServiceBrokerRelistInterval)
 	serviceCatalogController, err := controller.NewController(
 		coreClient,
 		serviceCatalogClientBuilder.ClientOrDie(controllerManagerAgentName).ServicecatalogV1beta1(),
+		serviceCatalogClientBuilder.ClientOrDie(controllerManagerAgentName).SettingsV1alpha1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
+		serviceCatalogSharedInformers.ServiceBrokers(),
 		serviceCatalogSharedInformers.ClusterServiceClasses(),
+		serviceCatalogSharedInformers.ServiceClasses(),
 		serviceCatalogSharedInformers.ServiceInstances(),
 		serviceCatalogSharedInformers.ServiceBindings(),
 		serviceCatalogSharedInformers.ClusterServicePlans(),
+		serviceCatalogSharedInformers.ServicePlans(),
+		serviceCatalogSharedInformers.ServiceInstanceQuotas(),
+		kubeInformerFactory.Core().V1().Secrets(),
+		kubeInformerFactory.Core().V1().ConfigMaps(),
+		informerFactory.Settings().V1alpha1().PodPresets(),
 		osbclientproxy.NewClient,
 		s.ServiceBrokerRelistInterval,
 		s.OSBAPIPreferredVersion,
 		recorder,
 		s.ReconciliationRetryDuration,
 		s.OperationPollingMaximumBackoffDuration,
+		s.ServiceBindingRotationGracePeriod,
+		s.ServiceBindingCredentialsSyncInterval,
+		s.ServiceInstanceSyncInterval,
+		brokerlimits.Limits{
+			MaxInFlightRequests:     s.BrokerMaxInFlightRequests,
+			RequestsPerSecond:       s.BrokerRequestsPerSecond,
+			MaxInFlightPollRequests: s.BrokerMaxInFlightPollRequests,
+			PollRequestsPerSecond:   s.BrokerPollRequestsPerSecond,
+		},
+		s.BrokerCircuitBreakerFailureThreshold,
+		s.BrokerCircuitBreakerOpenDuration,
 		s.ClusterIDConfigMapName,
 		s.ClusterIDConfigMapNamespace,
 	)
@@ -390,9 +414,11 @@ func StartControllers(s *options.ControllerManagerServer,
 
 	glog.V(1).Info("Starting shared informers")
 	informerFactory.Start(stop)
+	kubeInformerFactory.Start(stop)
 
 	glog.V(5).Info("Waiting for caches to sync")
 	informerFactory.WaitForCacheSync(stop)
+	kubeInformerFactory.WaitForCacheSync(stop)
 
 	glog.V(5).Info("Running controller")
 	go serviceCatalogController.Run(s.ConcurrentSyncs, stop)
@@ -60,6 +60,9 @@ const (
 	defaultLeaderElectionNamespace                = "kube-system"
 	defaultReconciliationRetryDuration            = 7 * 24 * time.Hour
 	defaultOperationPollingMaximumBackoffDuration = 20 * time.Minute
+	defaultServiceBindingRotationGracePeriod      = 10 * time.Minute
+	defaultBrokerCircuitBreakerFailureThreshold   = 5
+	defaultBrokerCircuitBreakerOpenDuration       = time.Minute
 )
 
 var defaultOSBAPIPreferredVersion = osb.LatestAPIVersion().HeaderValue()
@@ -85,6 +88,9 @@ func NewControllerManagerServer() *ControllerManagerServer {
 			EnableContentionProfiling:              false,
 			ReconciliationRetryDuration:            defaultReconciliationRetryDuration,
 			OperationPollingMaximumBackoffDuration: defaultOperationPollingMaximumBackoffDuration,
+			ServiceBindingRotationGracePeriod:      defaultServiceBindingRotationGracePeriod,
+			BrokerCircuitBreakerFailureThreshold:   defaultBrokerCircuitBreakerFailureThreshold,
+			BrokerCircuitBreakerOpenDuration:       defaultBrokerCircuitBreakerOpenDuration,
 			SecureServingOptions:                   genericoptions.NewSecureServingOptions(),
 		},
 	}
@@ -118,6 +124,15 @@ func (s *ControllerManagerServer) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&s.LeaderElectionNamespace, "leader-election-namespace", s.LeaderElectionNamespace, "Namespace to use for leader election lock")
 	fs.DurationVar(&s.ReconciliationRetryDuration, "reconciliation-retry-duration", s.ReconciliationRetryDuration, "The maximum amount of time to retry reconciliations on a resource before failing")
 	fs.DurationVar(&s.OperationPollingMaximumBackoffDuration, "operation-polling-maximum-backoff-duration", s.OperationPollingMaximumBackoffDuration, "The maximum amount of time to back-off while polling an OSB API operation")
+	fs.DurationVar(&s.ServiceBindingRotationGracePeriod, "binding-rotation-grace-period", s.ServiceBindingRotationGracePeriod, "The amount of time the credentials replaced by the rotation of a ServiceBinding remain bound before they are unbound")
+	fs.DurationVar(&s.ServiceBindingCredentialsSyncInterval, "binding-credentials-sync-interval", s.ServiceBindingCredentialsSyncInterval, "The interval on which the credentials of retrievable ServiceBindings are fetched from the broker to repair drift in their Secrets; 0 disables the check")
+	fs.DurationVar(&s.ServiceInstanceSyncInterval, "instance-sync-interval", s.ServiceInstanceSyncInterval, "The interval on which retrievable ServiceInstances are fetched from the broker to record their parameters and dashboard URL and detect drift in their parameters; 0 disables the check")
+	fs.IntVar(&s.BrokerMaxInFlightRequests, "broker-max-in-flight-requests", s.BrokerMaxInFlightRequests, "The default maximum number of requests, other than last operation polls, sent to a broker at the same time; 0 means no limit")
+	fs.IntVar(&s.BrokerRequestsPerSecond, "broker-requests-per-second", s.BrokerRequestsPerSecond, "The default maximum rate of requests, other than last operation polls, sent to a broker; 0 means no limit")
+	fs.IntVar(&s.BrokerMaxInFlightPollRequests, "broker-max-in-flight-poll-requests", s.BrokerMaxInFlightPollRequests, "The default maximum number of last operation polls sent to a broker at the same time; 0 means no limit")
+	fs.IntVar(&s.BrokerPollRequestsPerSecond, "broker-poll-requests-per-second", s.BrokerPollRequestsPerSecond, "The default maximum rate of last operation polls sent to a broker; 0 means no limit")
+	fs.IntVar(&s.BrokerCircuitBreakerFailureThreshold, "broker-circuit-breaker-failure-threshold", s.BrokerCircuitBreakerFailureThreshold, "The number of consecutive failed requests after which the work for the resources of a broker is paused until the broker responds again; 0 disables the circuit breaker")
+	fs.DurationVar(&s.BrokerCircuitBreakerOpenDuration, "broker-circuit-breaker-open-duration", s.BrokerCircuitBreakerOpenDuration, "The interval on which the work for the resources of an unresponsive broker is let through to probe the broker")
 	s.SecureServingOptions.AddFlags(fs)
 	utilfeature.DefaultFeatureGate.AddFlag(fs)
 	fs.StringVar(&s.ClusterIDConfigMapName, "cluster-id-configmap-name", controller.DefaultClusterIDConfigMapName, "k8s name for clusterid configmap")
@@ -0,0 +1,84 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package options contains the flags of the PodPreset admission webhook.
+package options
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+	genericoptions "k8s.io/apiserver/pkg/server/options"
+)
+
+const (
+	// Use the same SSL configuration as we use in Catalog API Server.
+	certDirectory = "/var/run/kubernetes-service-catalog"
+
+	defaultResyncInterval = 5 * time.Minute
+	defaultContentType    = "application/json"
+	defaultPort           = 8443
+)
+
+// PodPresetWebhookServer is the main context object for the PodPreset
+// admission webhook.
+type PodPresetWebhookServer struct {
+	// ContentType is the content type of requests sent to API servers.
+	ContentType string
+	// K8sAPIServerURL is the URL for the k8s API server.
+	K8sAPIServerURL string
+	// K8sKubeconfigPath is the path to the kubeconfig for the k8s API server.
+	K8sKubeconfigPath string
+	// ServiceCatalogAPIServerURL is the URL for the service-catalog API server.
+	ServiceCatalogAPIServerURL string
+	// ServiceCatalogKubeconfigPath is the path to the kubeconfig for the
+	// service-catalog API server.
+	ServiceCatalogKubeconfigPath string
+	// ServiceCatalogInsecureSkipVerify controls whether the TLS certificate
+	// of the service-catalog API server is verified.
+	ServiceCatalogInsecureSkipVerify bool
+	// ResyncInterval is the interval on which the PodPreset informer is
+	// resynced.
+	ResyncInterval time.Duration
+	// SecureServingOptions are the TLS settings of the webhook server.
+	SecureServingOptions *genericoptions.SecureServingOptions
+}
+
+// NewPodPresetWebhookServer creates a new PodPresetWebhookServer with a
+// default config.
+func NewPodPresetWebhookServer() *PodPresetWebhookServer {
+	s := PodPresetWebhookServer{
+		ContentType:          defaultContentType,
+		ResyncInterval:       defaultResyncInterval,
+		SecureServingOptions: genericoptions.NewSecureServingOptions(),
+	}
+	// set defaults, these will be overriden by user specified flags
+	s.SecureServingOptions.BindPort = defaultPort
+	s.SecureServingOptions.ServerCert.CertDirectory = certDirectory
+	return &s
+}
+
+// AddFlags adds flags for a PodPresetWebhookServer to the specified FlagSet.
+func (s *PodPresetWebhookServer) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&s.ContentType, "api-content-type", s.ContentType, "Content type of requests sent to API servers")
+	fs.StringVar(&s.K8sAPIServerURL, "k8s-api-server-url", "", "The URL for the k8s API server")
+	fs.StringVar(&s.K8sKubeconfigPath, "k8s-kubeconfig", "", "Path to k8s core kubeconfig")
+	fs.StringVar(&s.ServiceCatalogAPIServerURL, "service-catalog-api-server-url", "", "The URL for the service-catalog API server")
+	fs.StringVar(&s.ServiceCatalogKubeconfigPath, "service-catalog-kubeconfig", "", "Path to service-catalog kubeconfig")
+	fs.BoolVar(&s.ServiceCatalogInsecureSkipVerify, "service-catalog-insecure-skip-verify", s.ServiceCatalogInsecureSkipVerify, "Skip verification of the TLS certificate for the service-catalog API server")
+	fs.DurationVar(&s.ResyncInterval, "resync-interval", s.ResyncInterval, "The interval on which the webhook will resync its PodPreset informer")
+	s.SecureServingOptions.AddFlags(fs)
+}
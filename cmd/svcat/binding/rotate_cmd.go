@@ -0,0 +1,62 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package binding
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type rotateCmd struct {
+	*command.Namespaced
+	bindingName string
+}
+
+// NewRotateCmd builds a "svcat rotate binding" command.
+func NewRotateCmd(cxt *command.Context) *cobra.Command {
+	rotateCmd := &rotateCmd{Namespaced: command.NewNamespacedCommand(cxt)}
+	cmd := &cobra.Command{
+		Use:   "binding NAME",
+		Short: "Rotate the credentials of a binding",
+		Long: `Rotate binding will increment the rotationRequests field on the binding.
+Then, service catalog will bind new credentials and replace the old ones in the
+binding's secret. The old credentials are unbound once the grace period configured
+on the controller manager has elapsed.`,
+		Example: `svcat rotate binding wordpress-mysql-binding --namespace mynamespace`,
+		PreRunE: command.PreRunE(rotateCmd),
+		RunE:    command.RunE(rotateCmd),
+	}
+	command.AddNamespaceFlags(cmd.Flags(), false)
+
+	return cmd
+}
+
+func (c *rotateCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("a binding name is required")
+	}
+	c.bindingName = args[0]
+
+	return nil
+}
+
+func (c *rotateCmd) Run() error {
+	const retries = 3
+	return c.App.RotateBinding(c.Namespace, c.bindingName, retries)
+}
@@ -24,7 +24,7 @@ import (
 )
 
 type getCmd struct {
-	*command.Context
+	*command.Namespaced
 	lookupByUUID bool
 	uuid         string
 	name         string
@@ -37,13 +37,15 @@ func (c *getCmd) SetFormat(format string) {
 
 // NewGetCmd builds a "svcat get classes" command
 func NewGetCmd(cxt *command.Context) *cobra.Command {
-	getCmd := &getCmd{Context: cxt}
+	getCmd := &getCmd{Namespaced: command.NewNamespacedCommand(cxt)}
 	cmd := &cobra.Command{
 		Use:     "classes [name]",
 		Aliases: []string{"class", "cl"},
 		Short:   "List classes, optionally filtered by name",
 		Example: `
   svcat get classes
+  svcat get classes -n ci
+  svcat get classes --all-namespaces
   svcat get class mysqldb
   svcat get class --uuid 997b8372-8dac-40ac-ae65-758b4a5075a5
 `,
@@ -57,6 +59,7 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		false,
 		"Whether or not to get the class by UUID (the default is by name)",
 	)
+	command.AddNamespaceFlags(cmd.Flags(), true)
 	command.AddOutputFlags(cmd.Flags())
 	return cmd
 }
@@ -87,6 +90,15 @@ func (c *getCmd) getAll() error {
 		return err
 	}
 
+	if c.Namespace != "" {
+		// Only list the classes that may be used in the namespace.
+		visibility, err := c.App.RetrieveVisibility(c.Namespace)
+		if err != nil {
+			return err
+		}
+		classes = visibility.VisibleClasses(classes)
+	}
+
 	output.WriteClassList(c.Output, c.outputFormat, classes...)
 	return nil
 }
@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/output"
+	"github.com/spf13/cobra"
+)
+
+type adoptCmd struct {
+	*provisonCmd
+	verify bool
+}
+
+// NewAdoptCmd builds a "svcat adopt instance" command.
+func NewAdoptCmd(cxt *command.Context) *cobra.Command {
+	adoptCmd := &adoptCmd{provisonCmd: &provisonCmd{Namespaced: command.NewNamespacedCommand(cxt)}}
+	cmd := &cobra.Command{
+		Use:   "instance NAME --external-id ID --plan PLAN --class CLASS",
+		Short: "Adopt an instance that already exists at its broker",
+		Long: `Adopt instance will create an instance for the instance of the broker with the given
+external ID, without provisioning it. Unless --verify=false is set, service catalog will
+first fetch the instance from the broker to check that it exists and uses the given plan.`,
+		Example: `
+  svcat adopt instance wordpress-mysql-instance --external-id a7c00676-4398-11e8-842f-0ed5f89f718b --class mysqldb --plan free
+  svcat adopt instance wordpress-mysql-instance --external-id a7c00676-4398-11e8-842f-0ed5f89f718b --class mysqldb --plan free --verify=false
+`,
+		PreRunE: command.PreRunE(adoptCmd),
+		RunE:    command.RunE(adoptCmd),
+	}
+	command.AddNamespaceFlags(cmd.Flags(), false)
+	cmd.Flags().StringVar(&adoptCmd.externalID, "external-id", "",
+		"The ID of the instance for use with the OSB SB API (Required)")
+	cmd.MarkFlagRequired("external-id")
+	cmd.Flags().StringVar(&adoptCmd.className, "class", "",
+		"The class name (Required)")
+	cmd.MarkFlagRequired("class")
+	cmd.Flags().StringVar(&adoptCmd.planName, "plan", "",
+		"The plan name (Required)")
+	cmd.MarkFlagRequired("plan")
+	cmd.Flags().StringSliceVarP(&adoptCmd.rawParams, "param", "p", nil,
+		"Additional parameter the instance was provisioned with, format: NAME=VALUE. Cannot be combined with --params-json")
+	cmd.Flags().StringSliceVarP(&adoptCmd.rawSecrets, "secret", "s", nil,
+		"Additional parameter, whose value is stored in a secret, the instance was provisioned with, format: SECRET[KEY]")
+	cmd.Flags().StringVar(&adoptCmd.jsonParams, "params-json", "",
+		"Additional parameters the instance was provisioned with, provided as a JSON object. Cannot be combined with --param")
+	cmd.Flags().BoolVar(&adoptCmd.verify, "verify", true,
+		"Fetch the instance from the broker before adopting it. Requires a broker that supports fetching instances")
+	return cmd
+}
+
+func (c *adoptCmd) Run() error {
+	instance, err := c.App.Adopt(c.Namespace, c.instanceName, c.externalID, c.className, c.planName, c.params, c.secrets, c.verify)
+	if err != nil {
+		return err
+	}
+
+	output.WriteInstanceDetails(c.Output, instance)
+
+	return nil
+}
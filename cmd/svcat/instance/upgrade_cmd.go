@@ -0,0 +1,67 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package instance
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/cmd/svcat/command"
+	"github.com/spf13/cobra"
+)
+
+type upgradeInstanceCmd struct {
+	*command.Namespaced
+	name string
+}
+
+// NewUpgradeCmd builds a "svcat upgrade instance" command.
+func NewUpgradeCmd(cxt *command.Context) *cobra.Command {
+	upgradeInstanceCmd := &upgradeInstanceCmd{Namespaced: command.NewNamespacedCommand(cxt)}
+	cmd := &cobra.Command{
+		Use:   "instance",
+		Short: "Upgrade an instance to the version of its plan",
+		Long: `Upgrade instance will set the maintenanceInfoVersion field of the instance to the
+version of the maintenance information of its plan. Then, service catalog will ask the
+broker to upgrade the instance to that version.`,
+		Example: `svcat upgrade instance wordpress-mysql-instance --namespace mynamespace`,
+		PreRunE: command.PreRunE(upgradeInstanceCmd),
+		RunE:    command.RunE(upgradeInstanceCmd),
+	}
+	command.AddNamespaceFlags(cmd.Flags(), false)
+
+	return cmd
+}
+
+func (c *upgradeInstanceCmd) Validate(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("an instance name is required")
+	}
+	c.name = args[0]
+
+	return nil
+}
+
+func (c *upgradeInstanceCmd) Run() error {
+	const retries = 3
+	instance, err := c.App.UpgradeInstance(c.Namespace, c.name, retries)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.Output, "Upgrading instance %s/%s to version %s\n", instance.Namespace, instance.Name, instance.Spec.MaintenanceInfoVersion)
+	return nil
+}
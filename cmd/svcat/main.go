@@ -119,11 +119,14 @@ func buildRootCommand(cxt *command.Context) *cobra.Command {
 	cmd.AddCommand(newDescribeCmd(cxt))
 	cmd.AddCommand(instance.NewProvisionCmd(cxt))
 	cmd.AddCommand(instance.NewDeprovisionCmd(cxt))
+	cmd.AddCommand(newAdoptCmd(cxt))
 	cmd.AddCommand(binding.NewBindCmd(cxt))
 	cmd.AddCommand(binding.NewUnbindCmd(cxt))
 	cmd.AddCommand(newSyncCmd(cxt))
 	cmd.AddCommand(newInstallCmd(cxt))
 	cmd.AddCommand(newTouchCmd(cxt))
+	cmd.AddCommand(newRotateCmd(cxt))
+	cmd.AddCommand(newUpgradeCmd(cxt))
 	cmd.AddCommand(versions.NewVersionCmd(cxt))
 	cmd.AddCommand(newCompletionCmd(cxt))
 
@@ -187,6 +190,33 @@ func newTouchCmd(cxt *command.Context) *cobra.Command {
 	return cmd
 }
 
+func newRotateCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "rotate",
+		Short: "Replace the credentials of a resource with new ones",
+	}
+	cmd.AddCommand(binding.NewRotateCmd(cxt))
+	return cmd
+}
+
+func newUpgradeCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "upgrade",
+		Short: "Upgrade a resource to the version offered by its broker",
+	}
+	cmd.AddCommand(instance.NewUpgradeCmd(cxt))
+	return cmd
+}
+
+func newAdoptCmd(cxt *command.Context) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "adopt",
+		Short: "Adopt an existing resource of a broker",
+	}
+	cmd.AddCommand(instance.NewAdoptCmd(cxt))
+	return cmd
+}
+
 func newCompletionCmd(ctx *command.Context) *cobra.Command {
 	return completion.NewCompletionCmd(ctx)
 }
@@ -23,11 +23,21 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 )
 
+// getBrokerStatusCondition returns the condition to display for a broker: the
+// Reachable condition while the broker is unreachable, otherwise the last of
+// its other conditions.
 func getBrokerStatusCondition(status v1beta1.ClusterServiceBrokerStatus) v1beta1.ServiceBrokerCondition {
-	if len(status.Conditions) > 0 {
-		return status.Conditions[len(status.Conditions)-1]
+	var lastCond v1beta1.ServiceBrokerCondition
+	for _, cond := range status.Conditions {
+		if cond.Type == v1beta1.ServiceBrokerConditionReachable {
+			if cond.Status == v1beta1.ConditionFalse {
+				return cond
+			}
+			continue
+		}
+		lastCond = cond
 	}
-	return v1beta1.ServiceBrokerCondition{}
+	return lastCond
 }
 
 func getBrokerStatusShort(status v1beta1.ClusterServiceBrokerStatus) string {
@@ -19,6 +19,7 @@ package output
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 )
@@ -136,7 +137,21 @@ func WriteInstanceDetails(w io.Writer, instance *v1beta1.ServiceInstance) {
 		{"Class:", instance.Spec.GetSpecifiedClass()},
 		{"Plan:", instance.Spec.GetSpecifiedPlan()},
 	})
+	if defaulted, ok := instance.Annotations[v1beta1.DefaultedParametersAnnotation]; ok {
+		t.Append([]string{"Defaulted Parameters:", strings.Replace(defaulted, ",", ", ", -1)})
+	}
+	if props := instance.Status.ExternalProperties; props != nil && props.MaintenanceInfoVersion != "" {
+		t.Append([]string{"Version:", props.MaintenanceInfoVersion})
+	}
+	if props := instance.Status.BrokerProperties; props != nil && props.DashboardURL != nil {
+		t.Append([]string{"Dashboard URL:", *props.DashboardURL})
+	} else if instance.Status.DashboardURL != nil {
+		t.Append([]string{"Dashboard URL:", *instance.Status.DashboardURL})
+	}
 	t.Render()
 
 	writeParameters(w, instance.Spec.Parameters)
+	if props := instance.Status.BrokerProperties; props != nil {
+		writeNamedParameters(w, "Broker Parameters", props.Parameters)
+	}
 }
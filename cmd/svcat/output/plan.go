@@ -146,6 +146,9 @@ func WritePlanDetails(w io.Writer, plan *v1beta1.ClusterServicePlan, class *v1be
 		{"Free:", strconv.FormatBool(plan.Spec.Free)},
 		{"Class:", class.Spec.ExternalName},
 	})
+	if plan.Spec.MaintenanceInfo != nil {
+		t.Append([]string{"Version:", plan.Spec.MaintenanceInfo.Version})
+	}
 
 	t.Render()
 }
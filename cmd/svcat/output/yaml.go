@@ -45,11 +45,17 @@ func writeYAML(w io.Writer, obj interface{}, n int) {
 }
 
 func writeParameters(w io.Writer, parameters *runtime.RawExtension) {
+	writeNamedParameters(w, "Parameters", parameters)
+}
+
+// writeNamedParameters writes the given parameters as YAML under the given
+// title.
+func writeNamedParameters(w io.Writer, title string, parameters *runtime.RawExtension) {
 	if parameters == nil {
 		return
 	}
 
-	fmt.Fprintln(w, "\nParameters:")
+	fmt.Fprintf(w, "\n%s:\n", title)
 	var params map[string]interface{}
 	err := json.Unmarshal(parameters.Raw, &params)
 	if err != nil {
@@ -28,7 +28,7 @@ import (
 )
 
 type getCmd struct {
-	*command.Context
+	*command.Namespaced
 	lookupByUUID bool
 	uuid         string
 	name         string
@@ -45,13 +45,15 @@ func (c *getCmd) SetFormat(format string) {
 
 // NewGetCmd builds a "svcat get plans" command
 func NewGetCmd(cxt *command.Context) *cobra.Command {
-	getCmd := &getCmd{Context: cxt}
+	getCmd := &getCmd{Namespaced: command.NewNamespacedCommand(cxt)}
 	cmd := &cobra.Command{
 		Use:     "plans [name]",
 		Aliases: []string{"plan", "pl"},
 		Short:   "List plans, optionally filtered by name or class",
 		Example: `
   svcat get plans
+  svcat get plans -n ci
+  svcat get plans --all-namespaces
   svcat get plan PLAN_NAME
   svcat get plan CLASS_NAME/PLAN_NAME
   svcat get plan --uuid PLAN_UUID
@@ -77,6 +79,7 @@ func NewGetCmd(cxt *command.Context) *cobra.Command {
 		"",
 		"Filter plans based on class. When --uuid is specified, the class name is interpreted as a uuid.",
 	)
+	command.AddNamespaceFlags(cmd.Flags(), true)
 	command.AddOutputFlags(cmd.Flags())
 	return cmd
 }
@@ -145,6 +148,15 @@ func (c *getCmd) getAll() error {
 		return fmt.Errorf("unable to list plans (%s)", err)
 	}
 
+	if c.Namespace != "" {
+		// Only list the plans that may be used in the namespace.
+		visibility, err := c.App.RetrieveVisibility(c.Namespace)
+		if err != nil {
+			return err
+		}
+		plans = visibility.VisiblePlans(plans, classes)
+	}
+
 	output.WritePlanList(c.Output, c.outputFormat, plans, classes)
 	return nil
 }
@@ -103,6 +103,50 @@ type ControllerManagerConfiguration struct {
 	// backoff for polling OSB API operations will use.
 	OperationPollingMaximumBackoffDuration time.Duration
 
+	// ServiceBindingRotationGracePeriod is how long the credentials replaced
+	// by the rotation of a ServiceBinding remain bound before they are
+	// unbound.
+	ServiceBindingRotationGracePeriod time.Duration
+
+	// ServiceBindingCredentialsSyncInterval is the interval on which the
+	// credentials of ServiceBindings whose class is bindings-retrievable are
+	// fetched from the broker and compared against the injected Secret. Zero
+	// disables the check.
+	ServiceBindingCredentialsSyncInterval time.Duration
+
+	// ServiceInstanceSyncInterval is the interval on which ServiceInstances
+	// whose class is instances-retrievable are fetched from the broker to
+	// record the broker's view of them and detect drift in their parameters.
+	// Zero disables the check.
+	ServiceInstanceSyncInterval time.Duration
+
+	// BrokerMaxInFlightRequests is the default maximum number of requests,
+	// other than last operation polls, sent to a broker at the same time.
+	// Zero means no limit.
+	BrokerMaxInFlightRequests int
+
+	// BrokerRequestsPerSecond is the default maximum rate of requests, other
+	// than last operation polls, sent to a broker. Zero means no limit.
+	BrokerRequestsPerSecond int
+
+	// BrokerMaxInFlightPollRequests is the default maximum number of last
+	// operation polls sent to a broker at the same time. Zero means no limit.
+	BrokerMaxInFlightPollRequests int
+
+	// BrokerPollRequestsPerSecond is the default maximum rate of last
+	// operation polls sent to a broker. Zero means no limit.
+	BrokerPollRequestsPerSecond int
+
+	// BrokerCircuitBreakerFailureThreshold is the number of consecutive
+	// failed requests after which the circuit of a broker is opened and the
+	// work for its resources is paused. Zero disables the circuit breaker.
+	BrokerCircuitBreakerFailureThreshold int
+
+	// BrokerCircuitBreakerOpenDuration is the interval on which the work for
+	// the resources of a broker whose circuit is open is let through to probe
+	// whether the broker responds again.
+	BrokerCircuitBreakerOpenDuration time.Duration
+
 	SecureServingOptions *genericoptions.SecureServingOptions
 
 	// ClusterIDConfigMapName is the k8s name that the clusterid configmap will have
@@ -64,6 +64,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ServiceInstanceList{},
 		&ServiceBinding{},
 		&ServiceBindingList{},
+		&ClusterParameterDefault{},
+		&ClusterParameterDefaultList{},
+		&ServiceInstanceQuota{},
+		&ServiceInstanceQuotaList{},
+		&ClusterServiceVisibilityPolicy{},
+		&ClusterServiceVisibilityPolicyList{},
 	)
 	return nil
 }
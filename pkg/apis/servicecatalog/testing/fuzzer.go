@@ -136,6 +136,14 @@ func servicecatalogFuncs(codecs runtimeserializer.CodecFactory) []interface{} {
 			}
 			bs.Parameters = parameters
 		},
+		func(bs *servicecatalog.ServiceInstanceBrokerProperties, c fuzz.Continue) {
+			c.FuzzNoCustom(bs)
+			parameters, err := createParameter(c)
+			if err != nil {
+				panic(fmt.Sprintf("Failed to create parameter object: %v", err))
+			}
+			bs.Parameters = parameters
+		},
 		func(bs *servicecatalog.ServiceBindingPropertiesState, c fuzz.Continue) {
 			c.FuzzNoCustom(bs)
 			parameters, err := createParameter(c)
@@ -172,6 +180,22 @@ func servicecatalogFuncs(codecs runtimeserializer.CodecFactory) []interface{} {
 			csp.Spec.ServiceInstanceCreateParameterSchema = metadata
 			csp.Spec.ServiceInstanceUpdateParameterSchema = metadata
 		},
+		func(mp *servicecatalog.PlanMigrationPolicy, c fuzz.Continue) {
+			c.FuzzNoCustom(mp)
+			// The defaulter sets MaxInProgress when it is missing.
+			if mp.MaxInProgress == nil {
+				maxInProgress := int32(1)
+				mp.MaxInProgress = &maxInProgress
+			}
+		},
+		func(a *servicecatalog.ServiceInstanceAdoption, c fuzz.Continue) {
+			c.FuzzNoCustom(a)
+			// The defaulter sets Verify when it is missing.
+			if a.Verify == nil {
+				verify := true
+				a.Verify = &verify
+			}
+		},
 		func(sp *servicecatalog.ServicePlan, c fuzz.Continue) {
 			c.FuzzNoCustom(sp)
 			metadata, err := createPlanMetadata(c)
@@ -184,6 +208,14 @@ func servicecatalogFuncs(codecs runtimeserializer.CodecFactory) []interface{} {
 			sp.Spec.ServiceInstanceCreateParameterSchema = metadata
 			sp.Spec.ServiceInstanceUpdateParameterSchema = metadata
 		},
+		func(ds *servicecatalog.ClusterParameterDefaultSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(ds)
+			parameters, err := createParameter(c)
+			if err != nil {
+				panic(fmt.Sprintf("Failed to create parameter object: %v", err))
+			}
+			ds.Parameters = parameters
+		},
 	}
 }
 
@@ -105,6 +105,34 @@ type CommonServiceBrokerSpec struct {
 	// CatalogRestrictions is a set of restrictions on which of a broker's services
 	// and plans have resources created for them.
 	CatalogRestrictions *CatalogRestrictions
+
+	// RateLimits limits the requests that the controller sends to the broker.
+	// The limits that are not set default to the ones the controller manager
+	// is configured with.
+	RateLimits *ServiceBrokerRateLimits
+}
+
+// ServiceBrokerRateLimits limits the requests that the controller sends to a
+// broker. The requests polling the last operation of instances and bindings
+// have their own budget, so that polling the operations in progress does not
+// hold back the requests that start new ones, and vice versa. A limit of zero
+// means that the requests are not limited.
+type ServiceBrokerRateLimits struct {
+	// MaxInFlightRequests is the maximum number of requests, other than
+	// last operation polls, sent to the broker at the same time.
+	MaxInFlightRequests *int32
+
+	// RequestsPerSecond is the maximum rate of requests, other than last
+	// operation polls, sent to the broker.
+	RequestsPerSecond *int32
+
+	// MaxInFlightPollRequests is the maximum number of last operation polls
+	// sent to the broker at the same time.
+	MaxInFlightPollRequests *int32
+
+	// PollRequestsPerSecond is the maximum rate of last operation polls sent
+	// to the broker.
+	PollRequestsPerSecond *int32
 }
 
 // CatalogRestrictions is a set of restrictions on which of a broker's services
@@ -198,6 +226,14 @@ type ClusterServiceBrokerAuthInfo struct {
 	// The value is referenced from the 'token' field of the given secret.  This value should only
 	// contain the token value and not the `Bearer` scheme.
 	Bearer *ClusterBearerTokenAuthConfig
+	// ClusterTLSAuthConfig provides configuration to authenticate with a client
+	// certificate. The certificate and key are referenced from the 'tls.crt' and
+	// 'tls.key' fields of the given kubernetes.io/tls secret.
+	TLS *ClusterTLSAuthConfig
+	// ClusterOAuth2AuthConfig provides configuration to send an access token,
+	// obtained from an OAuth2 authorization server with the client credentials
+	// grant, as a bearer token.
+	OAuth2 *ClusterOAuth2AuthConfig
 }
 
 // ClusterBasicAuthConfig provides config for the basic authentication of
@@ -223,6 +259,34 @@ type ClusterBearerTokenAuthConfig struct {
 	SecretRef *ObjectReference
 }
 
+// ClusterTLSAuthConfig provides config for the client certificate
+// authentication of cluster scoped brokers.
+type ClusterTLSAuthConfig struct {
+	// SecretRef is a reference to a Secret containing information the
+	// catalog should use to authenticate to this ClusterServiceBroker.
+	//
+	// Required fields:
+	// - Secret.Data["tls.crt"] - PEM encoded client certificate
+	// - Secret.Data["tls.key"] - PEM encoded private key of the certificate
+	SecretRef *ObjectReference
+}
+
+// ClusterOAuth2AuthConfig provides config for the OAuth2 client
+// credentials authentication of cluster scoped brokers.
+type ClusterOAuth2AuthConfig struct {
+	// TokenURL is the URL of the token endpoint of the authorization server.
+	TokenURL string
+	// SecretRef is a reference to a Secret containing information the
+	// catalog should use to obtain access tokens for this ClusterServiceBroker.
+	//
+	// Required fields:
+	// - Secret.Data["clientID"] - client ID used to obtain access tokens
+	// - Secret.Data["clientSecret"] - client secret used to obtain access tokens
+	SecretRef *ObjectReference
+	// Scopes are the scopes to request for the access tokens.
+	Scopes []string
+}
+
 // ServiceBrokerAuthInfo is a union type that contains information on
 // one of the authentication methods the the service catalog and brokers may
 // support, according to the OpenServiceBroker API specification
@@ -234,6 +298,14 @@ type ServiceBrokerAuthInfo struct {
 	// The value is referenced from the 'token' field of the given secret.  This value should only
 	// contain the token value and not the `Bearer` scheme.
 	Bearer *BearerTokenAuthConfig
+	// TLSAuthConfig provides configuration to authenticate with a client
+	// certificate. The certificate and key are referenced from the 'tls.crt' and
+	// 'tls.key' fields of the given kubernetes.io/tls secret.
+	TLS *TLSAuthConfig
+	// OAuth2AuthConfig provides configuration to send an access token,
+	// obtained from an OAuth2 authorization server with the client credentials
+	// grant, as a bearer token.
+	OAuth2 *OAuth2AuthConfig
 }
 
 // BasicAuthConfig provides config for the basic authentication of
@@ -259,6 +331,34 @@ type BearerTokenAuthConfig struct {
 	SecretRef *LocalObjectReference
 }
 
+// TLSAuthConfig provides config for the client certificate
+// authentication of namespaced brokers.
+type TLSAuthConfig struct {
+	// SecretRef is a reference to a Secret containing information the
+	// catalog should use to authenticate to this ServiceBroker.
+	//
+	// Required fields:
+	// - Secret.Data["tls.crt"] - PEM encoded client certificate
+	// - Secret.Data["tls.key"] - PEM encoded private key of the certificate
+	SecretRef *LocalObjectReference
+}
+
+// OAuth2AuthConfig provides config for the OAuth2 client
+// credentials authentication of namespaced brokers.
+type OAuth2AuthConfig struct {
+	// TokenURL is the URL of the token endpoint of the authorization server.
+	TokenURL string
+	// SecretRef is a reference to a Secret containing information the
+	// catalog should use to obtain access tokens for this ServiceBroker.
+	//
+	// Required fields:
+	// - Secret.Data["clientID"] - client ID used to obtain access tokens
+	// - Secret.Data["clientSecret"] - client secret used to obtain access tokens
+	SecretRef *LocalObjectReference
+	// Scopes are the scopes to request for the access tokens.
+	Scopes []string
+}
+
 const (
 	// BasicAuthUsernameKey is the key of the username for SecretTypeBasicAuth secrets
 	BasicAuthUsernameKey = "username"
@@ -267,6 +367,11 @@ const (
 
 	// BearerTokenKey is the key of the bearer token for SecretTypeBearerTokenAuth secrets
 	BearerTokenKey = "token"
+
+	// OAuth2ClientIDKey is the key of the client ID for OAuth2 secrets
+	OAuth2ClientIDKey = "clientID"
+	// OAuth2ClientSecretKey is the key of the client secret for OAuth2 secrets
+	OAuth2ClientSecretKey = "clientSecret"
 )
 
 // CommonServiceBrokerStatus represents the current status of a ServiceBroker.
@@ -329,6 +434,12 @@ const (
 	// ServiceBrokerConditionFailed represents information about a final failure
 	// that should not be retried.
 	ServiceBrokerConditionFailed ServiceBrokerConditionType = "Failed"
+
+	// ServiceBrokerConditionReachable represents whether the broker responds
+	// to the requests of the controller. It is false while the circuit of the
+	// broker is open after repeated failures, during which the work for the
+	// resources of the broker is paused.
+	ServiceBrokerConditionReachable ServiceBrokerConditionType = "Reachable"
 )
 
 // ConditionStatus represents a condition's status.
@@ -441,6 +552,13 @@ type CommonServiceClassSpec struct {
 	// its endpoint is supported for all plans.
 	BindingRetrievable bool
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// InstancesRetrievable indicates whether fetching an instance via a GET
+	// on its endpoint is supported for all plans.
+	InstancesRetrievable bool
+
 	// PlanUpdatable indicates whether instances provisioned from this
 	// ServiceClass may change ServicePlans after being provisioned.
 	PlanUpdatable bool
@@ -572,6 +690,25 @@ type CommonServicePlanSpec struct {
 	// broker's response, which allows clients to see what the credentials
 	// will look like even before the binding operation is performed.
 	ServiceBindingCreateResponseSchema *runtime.RawExtension
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	// The MaintenanceInfo feature gate needs to be enabled for this field to
+	// be populated.
+	//
+	// MaintenanceInfo describes the version of the software that the
+	// instances of this plan run, as advertised by the broker.
+	MaintenanceInfo *MaintenanceInfo
+}
+
+// MaintenanceInfo describes the version of the software that the instances
+// of a plan run.
+type MaintenanceInfo struct {
+	// Version is the semantic version of the maintenance information.
+	Version string
+
+	// Description describes the changes of this version.
+	Description string
 }
 
 // ClusterServicePlanSpec represents details about the ClusterServicePlan
@@ -585,6 +722,31 @@ type ClusterServicePlanSpec struct {
 	// ClusterServiceClassRef is a reference to the service class that
 	// owns this plan.
 	ClusterServiceClassRef ClusterObjectReference
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// MigrationPolicy, if set, makes the controller move the
+	// ServiceInstances of this plan to a replacement plan once the broker
+	// has removed this plan from its catalog. It is set by cluster
+	// operators, and kept when the plan is updated from the catalog.
+	// Requires the PlanMigration feature.
+	MigrationPolicy *PlanMigrationPolicy
+}
+
+// PlanMigrationPolicy describes how the ServiceInstances of a plan that has
+// been removed from the catalog of its broker are moved to another plan of
+// the same class. Each instance is moved by updating its plan, which the
+// controller then sends to the broker as an update of the instance.
+type PlanMigrationPolicy struct {
+	// ReplacementPlanExternalName is the external name of the plan of the
+	// same class that the instances are moved to.
+	ReplacementPlanExternalName string
+
+	// MaxInProgress is the maximum number of instances that are being moved
+	// at the same time. An instance is being moved from the time its plan is
+	// updated until the broker has accepted the new plan. Defaults to 1.
+	MaxInProgress *int32
 }
 
 // ClusterServicePlanStatus represents status information about a
@@ -685,9 +847,15 @@ type ServiceInstance struct {
 //  - ClusterServiceClassExternalName and ClusterServicePlanExternalName
 //  - ClusterServiceClassExternalID and ClusterServicePlanExternalID
 //  - ClusterServiceClassName and ClusterServicePlanName
+//  - ServiceClassExternalName and ServicePlanExternalName
+//  - ServiceClassExternalID and ServicePlanExternalID
+//  - ServiceClassName and ServicePlanName
 //
 // For any of these ways, if a ClusterServiceClass only has one plan
 // then the corresponding service plan field is optional.
+//
+// The cluster-scoped fields and the namespaced fields are mutually
+// exclusive.
 type PlanReference struct {
 	// ClusterServiceClassExternalName is the human-readable name of the
 	// service as reported by the broker. Note that if the broker changes
@@ -719,6 +887,36 @@ type PlanReference struct {
 	ClusterServiceClassName string
 	// ClusterServicePlanName is kubernetes name of the ClusterServicePlan.
 	ClusterServicePlanName string
+
+	// ServiceClassExternalName is the human-readable name of the
+	// service as reported by the broker. Note that if the broker changes
+	// the name of the ServiceClass, it will not be reflected here,
+	// and to see the current name of the ServiceClass, you should
+	// follow the ServiceClassRef below.
+	//
+	// Immutable.
+	ServiceClassExternalName string
+	// ServicePlanExternalName is the human-readable name of the plan
+	// as reported by the broker. Note that if the broker changes the name
+	// of the ServicePlan, it will not be reflected here, and to see
+	// the current name of the ServicePlan, you should follow the
+	// ServicePlanRef below.
+	ServicePlanExternalName string
+
+	// ServiceClassExternalID is the broker's external id for the class.
+	//
+	// Immutable.
+	ServiceClassExternalID string
+
+	// ServicePlanExternalID is the broker's external id for the plan.
+	ServicePlanExternalID string
+
+	// ServiceClassName is the kubernetes name of the ServiceClass.
+	//
+	// Immutable.
+	ServiceClassName string
+	// ServicePlanName is kubernetes name of the ServicePlan.
+	ServicePlanName string
 }
 
 // ServiceInstanceSpec represents the desired state of an Instance.
@@ -734,6 +932,15 @@ type ServiceInstanceSpec struct {
 	// This is set by the controller based on ClusterServicePlanExternalName
 	ClusterServicePlanRef *ClusterObjectReference
 
+	// ServiceClassRef is a reference to the ServiceClass that the user
+	// selected. This is set by the controller based on
+	// ServiceClassExternalName.
+	ServiceClassRef *LocalObjectReference
+	// ServicePlanRef is a reference to the ServicePlan that the user
+	// selected. This is set by the controller based on
+	// ServicePlanExternalName.
+	ServicePlanRef *LocalObjectReference
+
 	// Parameters is a set of the parameters to be passed to the underlying
 	// broker. The inline YAML/JSON payload to be translated into equivalent
 	// JSON object. If a top-level parameter name exists in multiples sources
@@ -774,8 +981,71 @@ type ServiceInstanceSpec struct {
 	// allows for parameters to be updated with any out-of-band changes that have
 	// been made to the secrets from which the parameters are sourced.
 	UpdateRequests int64
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// MaintenanceInfoVersion is the version of the maintenance information
+	// of the plan that the instance is upgraded to. When it differs from the
+	// version that the instance runs, the maintenance information of the
+	// plan is sent to the broker in an update of the instance. It must be
+	// the version currently advertised by the plan.
+	// Requires the MaintenanceInfo feature.
+	MaintenanceInfoVersion string
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// DeletionPolicy is what happens to the instance at the broker when the
+	// ServiceInstance is deleted. It defaults to Deprovision when empty.
+	// Requires the DeletionPolicy feature.
+	DeletionPolicy ServiceInstanceDeletionPolicy
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// DeletionProtection rejects the deletion of the ServiceInstance while it
+	// is true. It also rejects the deletion of any collection of
+	// ServiceInstances that includes the namespace of the ServiceInstance,
+	// whatever the label and field selectors of the deletion.
+	// Requires the DeletionPolicy feature.
+	DeletionProtection bool
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// Adoption makes the controller adopt the instance that already exists
+	// at the broker under ExternalID, instead of provisioning a new one. The
+	// ServiceInstance is then managed as if it had provisioned the instance.
+	// Immutable.
+	// Requires the InstanceAdoption feature.
+	Adoption *ServiceInstanceAdoption
+}
+
+// ServiceInstanceAdoption describes how a ServiceInstance adopts the instance
+// that already exists at the broker.
+type ServiceInstanceAdoption struct {
+	// Verify makes the controller fetch the instance from the broker before
+	// adopting it, to check that it exists and runs the plan of the
+	// ServiceInstance. The broker must support fetching instances. Defaults
+	// to true.
+	Verify *bool
 }
 
+// ServiceInstanceDeletionPolicy is what happens to the instance at the broker
+// when a ServiceInstance is deleted.
+type ServiceInstanceDeletionPolicy string
+
+const (
+	// ServiceInstanceDeletionPolicyDeprovision means that the instance is
+	// deprovisioned at the broker when the ServiceInstance is deleted.
+	ServiceInstanceDeletionPolicyDeprovision ServiceInstanceDeletionPolicy = "Deprovision"
+
+	// ServiceInstanceDeletionPolicyRetain means that the instance is left
+	// at the broker when the ServiceInstance is deleted.
+	ServiceInstanceDeletionPolicyRetain ServiceInstanceDeletionPolicy = "Retain"
+)
+
 // ServiceInstanceStatus represents the current status of an Instance.
 type ServiceInstanceStatus struct {
 	// Conditions is an array of ServiceInstanceConditions capturing aspects of an
@@ -833,6 +1103,68 @@ type ServiceInstanceStatus struct {
 	// DeprovisionStatus describes what has been done to deprovision the
 	// ServiceInstance.
 	DeprovisionStatus ServiceInstanceDeprovisionStatus
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// PlanMigration describes the last move of the ServiceInstance off a
+	// plan that was removed from the catalog of its broker, according to the
+	// MigrationPolicy of that plan.
+	PlanMigration *ServiceInstancePlanMigration
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// BrokerProperties is the state of the ServiceInstance as last fetched
+	// from the broker. It is only fetched for instances whose class is
+	// instancesRetrievable, when the check is enabled on the
+	// controller-manager.
+	// +optional
+	BrokerProperties *ServiceInstanceBrokerProperties
+
+	// LastBrokerSyncTime is the last time the controller recorded the
+	// ServiceInstance as fetched from the broker: on the first fetch, and on
+	// the fetches where the broker reported a change.
+	// +optional
+	LastBrokerSyncTime *metav1.Time
+}
+
+// ServiceInstanceBrokerProperties is the state of a ServiceInstance as
+// reported by its broker.
+type ServiceInstanceBrokerProperties struct {
+	// Parameters is a blob of the parameters and their values that the
+	// broker reports for this ServiceInstance. If a parameter was sourced
+	// from a secret, its value will be "<redacted>" in this blob.
+	// +optional
+	Parameters *runtime.RawExtension
+
+	// DashboardURL is the URL of a web-based management user interface for
+	// the service instance, as reported by the broker.
+	// +optional
+	DashboardURL *string
+}
+
+// ServiceInstancePlanMigration describes the move of a ServiceInstance from a
+// plan that was removed from the catalog of its broker to its replacement.
+type ServiceInstancePlanMigration struct {
+	// FromClusterServicePlanName is the Kubernetes name of the
+	// ClusterServicePlan the instance is moved from.
+	FromClusterServicePlanName string
+
+	// ToClusterServicePlanName is the Kubernetes name of the
+	// ClusterServicePlan the instance is moved to.
+	ToClusterServicePlanName string
+
+	// StartTime is the time the plan of the instance was updated.
+	StartTime metav1.Time
+
+	// CompletionTime is the time the broker accepted or rejected the new
+	// plan. It is unset while the instance is being moved.
+	CompletionTime *metav1.Time
+
+	// FailureMessage is set when the broker rejected the new plan, to the
+	// reason it gave.
+	FailureMessage string
 }
 
 // ServiceInstanceCondition contains condition information about an Instance.
@@ -871,6 +1203,16 @@ const (
 	// ServiceInstanceConditionOrphanMitigation represents information about an
 	// orphan mitigation that is required after failed provisioning.
 	ServiceInstanceConditionOrphanMitigation ServiceInstanceConditionType = "OrphanMitigation"
+
+	// ServiceInstanceConditionUpgradeAvailable represents that the plan of
+	// the instance advertises a version of its maintenance information that
+	// the instance does not run yet.
+	ServiceInstanceConditionUpgradeAvailable ServiceInstanceConditionType = "UpgradeAvailable"
+
+	// ServiceInstanceConditionParametersDrifted represents whether the last
+	// fetch of a ServiceInstance from its broker found parameters that differ
+	// from the ones the broker was last sent.
+	ServiceInstanceConditionParametersDrifted ServiceInstanceConditionType = "ParametersDrifted"
 )
 
 // ServiceInstanceOperation represents a type of operation the controller can
@@ -901,6 +1243,15 @@ type ServiceInstancePropertiesState struct {
 	// broker knows this ServiceInstance to be on.
 	ClusterServicePlanExternalID string
 
+	// ServicePlanExternalName is the name of the namespaced plan that the
+	// broker knows this ServiceInstance to be on. It is set instead of
+	// ClusterServicePlanExternalName when the instance uses a ServicePlan.
+	ServicePlanExternalName string
+
+	// ServicePlanExternalID is the external ID of the namespaced plan that
+	// the broker knows this ServiceInstance to be on.
+	ServicePlanExternalID string
+
 	// Parameters is a blob of the parameters and their values that the broker
 	// knows about for this ServiceInstance.  If a parameter was sourced from
 	// a secret, its value will be "<redacted>" in this blob.
@@ -911,6 +1262,10 @@ type ServiceInstancePropertiesState struct {
 
 	// UserInfo is information about the user that made the request.
 	UserInfo *UserInfo
+
+	// MaintenanceInfoVersion is the version of the maintenance information
+	// of the plan that was sent to the broker.
+	MaintenanceInfoVersion string
 }
 
 // ServiceInstanceDeprovisionStatus is the status of deprovisioning a
@@ -1012,6 +1367,35 @@ type ServiceBindingSpec struct {
 	// by the broker before they are inserted into the Secret
 	SecretTransforms []SecretTransform
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// PodPresetTemplate, if set, makes the controller create a PodPreset
+	// that injects the Secret of the ServiceBinding into the selected pods.
+	// +optional
+	PodPresetTemplate *PodPresetTemplate
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// SecretCopyNamespaces is a list of namespaces, other than the namespace
+	// of the ServiceBinding, into which the controller copies the Secret.
+	// +optional
+	SecretCopyNamespaces []string
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// ConfigMapTemplate, if set, makes the controller create a ConfigMap
+	// holding a non-sensitive subset of the credentials.
+	// +optional
+	ConfigMapTemplate *ConfigMapTemplate
+
+	// RotationRequests is a strictly increasing, non-negative integer counter
+	// that can be manually incremented by a user to rotate the credentials of
+	// the ServiceBinding.
+	RotationRequests int64
+
 	// ExternalID is the identity of this object for use with the OSB API.
 	//
 	// Immutable.
@@ -1074,6 +1458,33 @@ type ServiceBindingStatus struct {
 
 	// UnbindStatus describes what has been done to unbind a ServiceBinding
 	UnbindStatus ServiceBindingUnbindStatus
+
+	// CurrentExternalID is the ExternalID of the binding whose credentials
+	// are currently stored in the Secret.
+	CurrentExternalID string
+
+	// PreviousExternalID is the ExternalID of the binding whose credentials
+	// were replaced by the last rotation, until they are unbound.
+	PreviousExternalID string
+
+	// PreviousCredentialsUnbindTime is the time after which the credentials
+	// of PreviousExternalID are unbound.
+	PreviousCredentialsUnbindTime *metav1.Time
+
+	// PreviousCredentialsUnbindOperation is set while the broker unbinds the
+	// credentials of PreviousExternalID asynchronously, to the operation key
+	// it returned, if any.
+	PreviousCredentialsUnbindOperation *string
+
+	// LastCredentialsSyncTime is the last time the controller fetched the
+	// credentials of the binding from the broker to check its Secret for
+	// drift.
+	LastCredentialsSyncTime *metav1.Time
+
+	// DeliveredSecretName is the name of the Secret the credentials of the
+	// binding were last injected in. The Secret is deleted once the
+	// credentials are injected under another SecretName.
+	DeliveredSecretName string
 }
 
 // ServiceBindingCondition condition information for a ServiceBinding.
@@ -1107,6 +1518,11 @@ const (
 	// ServiceBindingConditionFailed represents a ServiceBindingCondition that has failed
 	// completely and should not be retried.
 	ServiceBindingConditionFailed ServiceBindingConditionType = "Failed"
+
+	// ServiceBindingConditionCredentialsDrifted represents whether the last
+	// check of the credentials of a ServiceBinding found its Secret to differ
+	// from the credentials returned by the broker.
+	ServiceBindingConditionCredentialsDrifted ServiceBindingConditionType = "CredentialsDrifted"
 )
 
 // ServiceBindingOperation represents a type of operation
@@ -1127,6 +1543,18 @@ const (
 	FinalizerServiceCatalog string = "kubernetes-incubator/service-catalog"
 )
 
+// DisableParametersFromSyncAnnotation is the annotation that opts a
+// ServiceInstance out of being updated at the broker when the values of the
+// Secrets referenced by its ParametersFrom change. It only applies when the
+// ParametersFromSync feature is enabled.
+const DisableParametersFromSyncAnnotation string = "servicecatalog.k8s.io/disable-parameters-from-sync"
+
+// DefaultedParametersAnnotation is the annotation set by the
+// DefaultParameters admission plugin on the ServiceInstances it sets default
+// parameters on. Its value is the comma-separated list of the names of the
+// top-level parameters that were defaulted.
+const DefaultedParametersAnnotation string = "servicecatalog.k8s.io/defaulted-parameters"
+
 // ServiceBindingPropertiesState is the state of a
 // ServiceBinding that the ServiceBroker knows about.
 type ServiceBindingPropertiesState struct {
@@ -1169,8 +1597,72 @@ type ParametersFromSource struct {
 	// The value must be a JSON object.
 	// +optional
 	SecretKeyRef *SecretKeyReference
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// The key of the Secret of a ServiceBinding to select from. The
+	// parameters are only resolved once the ServiceBinding is Ready.
+	//
+	// Requires the CrossInstanceReferences feature.
+	// +optional
+	ServiceBindingSecretKeyRef *ServiceBindingSecretKeyReference
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// The field of a ServiceInstance to select from. The parameters are only
+	// resolved once the ServiceInstance is Ready.
+	//
+	// Requires the CrossInstanceReferences feature.
+	// +optional
+	ServiceInstanceFieldRef *ServiceInstanceFieldReference
+}
+
+// ServiceBindingSecretKeyReference references a key of the Secret of a
+// ServiceBinding.
+type ServiceBindingSecretKeyReference struct {
+	// The name of the ServiceBinding in the namespace of the resource.
+	Name string
+	// The key of the Secret of the ServiceBinding to select from.
+	Key string
+	// The name of the parameter to set to the value of the key. If empty,
+	// the value must be a JSON object, whose properties are added to the
+	// parameters.
+	// +optional
+	Parameter string
+}
+
+// ServiceInstanceFieldReference references a field of a ServiceInstance.
+type ServiceInstanceFieldReference struct {
+	// The name of the ServiceInstance in the namespace of the resource.
+	Name string
+	// The path of the field to select, one of "spec.externalID",
+	// "status.dashboardURL", "status.externalProperties.parameters.NAME" or
+	// "status.brokerProperties.parameters.NAME". Parameters that were
+	// sourced from a Secret cannot be selected.
+	FieldPath string
+	// The name of the parameter to set to the value of the field.
+	Parameter string
 }
 
+// The fields of a ServiceInstance that a ServiceInstanceFieldReference can
+// select.
+const (
+	// ServiceInstanceFieldPathExternalID selects the external ID of the
+	// ServiceInstance.
+	ServiceInstanceFieldPathExternalID = "spec.externalID"
+	// ServiceInstanceFieldPathDashboardURL selects the dashboard URL of the
+	// ServiceInstance.
+	ServiceInstanceFieldPathDashboardURL = "status.dashboardURL"
+	// ServiceInstanceFieldPathExternalParameterPrefix is followed by the name
+	// of a parameter that was last sent to the broker.
+	ServiceInstanceFieldPathExternalParameterPrefix = "status.externalProperties.parameters."
+	// ServiceInstanceFieldPathBrokerParameterPrefix is followed by the name of
+	// a parameter that the broker reports.
+	ServiceInstanceFieldPathBrokerParameterPrefix = "status.brokerProperties.parameters."
+)
+
 // SecretKeyReference references a key of a Secret.
 type SecretKeyReference struct {
 	// The name of the secret in the pod's namespace to select from.
@@ -1209,6 +1701,7 @@ type SecretTransform struct {
 	AddKey      *AddKeyTransform
 	AddKeysFrom *AddKeysFromTransform
 	RemoveKey   *RemoveKeyTransform
+	TemplateKey *TemplateKeyTransform
 }
 
 // RenameKeyTransform specifies that one of the credentials keys returned
@@ -1238,3 +1731,215 @@ type AddKeysFromTransform struct {
 type RemoveKeyTransform struct {
 	Key string
 }
+
+// TemplateKeyTransform specifies that Service Catalog should add an entry
+// built from a template evaluated against the credentials to the Secret
+// associated with the ServiceBinding.
+type TemplateKeyTransform struct {
+	Key      string
+	Template string
+}
+
+// ConfigMapTemplate describes the ConfigMap that the controller creates, in
+// the namespace and under the name of the ServiceBinding, to expose a subset
+// of the credentials.
+type ConfigMapTemplate struct {
+	// Keys are the keys of the credentials copied into the ConfigMap.
+	Keys []string
+}
+
+// PodPresetTemplate describes the PodPreset that the controller creates, in
+// the namespace and under the name of the ServiceBinding, to inject the
+// credentials Secret into pods.
+type PodPresetTemplate struct {
+	// Selector is a label query over the pods the PodPreset applies to.
+	Selector metav1.LabelSelector
+
+	// EnvPrefix, if set, injects every key of the Secret as an environment
+	// variable whose name is the key prefixed with EnvPrefix. The prefix
+	// may be empty.
+	// +optional
+	EnvPrefix *string
+
+	// MountPath, if set, mounts the Secret as a volume at the given path.
+	// +optional
+	MountPath string
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterParameterDefaultList is a list of ClusterParameterDefaults.
+type ClusterParameterDefaultList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []ClusterParameterDefault
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterParameterDefault holds default values for the parameters of the
+// ServiceInstances of a ClusterServiceClass, or of one of its
+// ClusterServicePlans. The defaults are set on the instances that are created
+// without these parameters by the DefaultParameters admission plugin.
+type ClusterParameterDefault struct {
+	metav1.TypeMeta
+
+	// Non-namespaced.  The name of this resource in etcd is in ObjectMeta.Name.
+	metav1.ObjectMeta
+
+	// Spec defines the parameters to default, and the instances they are
+	// defaulted on.
+	Spec ClusterParameterDefaultSpec
+}
+
+// ClusterParameterDefaultSpec represents the default parameters of the
+// instances of a class or plan.
+type ClusterParameterDefaultSpec struct {
+	// ClusterServiceClassExternalName is the external name of the
+	// ClusterServiceClass whose instances are defaulted.
+	ClusterServiceClassExternalName string
+
+	// ClusterServicePlanExternalName is the external name of the
+	// ClusterServicePlan whose instances are defaulted. When it is empty, the
+	// instances of every plan of the class are defaulted.
+	ClusterServicePlanExternalName string
+
+	// Parameters is a JSON object holding the default value of each
+	// top-level parameter. The defaults of a plan take precedence over the
+	// defaults of its class, which take precedence over the defaults
+	// declared in the schemas of the plan.
+	//
+	// The Parameters field is NOT secret or secured in any way and should
+	// NEVER be used to hold sensitive information.
+	Parameters *runtime.RawExtension
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceInstanceQuotaList is a list of ServiceInstanceQuotas.
+type ServiceInstanceQuotaList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []ServiceInstanceQuota
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceInstanceQuota limits the number of ServiceInstances of a namespace.
+// The limits are enforced when instances are created, or change plans, by the
+// ServiceInstanceQuota admission plugin, which charges every instance it
+// admits to the usage reported in the status of the quota.
+type ServiceInstanceQuota struct {
+	metav1.TypeMeta
+
+	// The name of this resource in etcd is in ObjectMeta.Name.
+	metav1.ObjectMeta
+
+	// Spec defines the limits of the quota.
+	Spec ServiceInstanceQuotaSpec
+
+	// Status represents the number of instances counted against each limit.
+	Status ServiceInstanceQuotaStatus
+}
+
+// ServiceInstanceQuotaSpec represents the limits of a ServiceInstanceQuota.
+type ServiceInstanceQuotaSpec struct {
+	// Limits are the limits on the number of instances of the namespace. An
+	// instance is only admitted when it stays within every limit that
+	// selects it.
+	Limits []ServiceInstanceQuotaLimit
+}
+
+// ServiceInstanceQuotaLimit limits the number of instances selected by their
+// class and plan. A limit without selectors applies to every instance of the
+// namespace.
+type ServiceInstanceQuotaLimit struct {
+	// Name identifies the limit within the quota.
+	Name string
+
+	// ServiceClassExternalName selects the instances of the
+	// ClusterServiceClasses and ServiceClasses with this external name.
+	ServiceClassExternalName string
+
+	// ServicePlanExternalName selects the instances of the plans with this
+	// external name. It requires ServiceClassExternalName.
+	ServicePlanExternalName string
+
+	// Free selects the instances of the plans whose Free field has this
+	// value.
+	Free *bool
+
+	// MaxInstances is the maximum number of instances the limit selects.
+	MaxInstances int64
+}
+
+// ServiceInstanceQuotaStatus represents the usage of a ServiceInstanceQuota.
+type ServiceInstanceQuotaStatus struct {
+	// Used is the number of instances each limit selects.
+	Used []ServiceInstanceQuotaUsage
+}
+
+// ServiceInstanceQuotaUsage is the number of instances a limit selects.
+type ServiceInstanceQuotaUsage struct {
+	// Name is the name of the limit.
+	Name string
+
+	// Instances is the number of instances the limit selects.
+	Instances int64
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterServiceVisibilityPolicyList is a list of
+// ClusterServiceVisibilityPolicies.
+type ClusterServiceVisibilityPolicyList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+
+	Items []ClusterServiceVisibilityPolicy
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterServiceVisibilityPolicy restricts the namespaces that a
+// ClusterServiceClass, or one of its ClusterServicePlans, is visible in. A
+// class or plan selected by policies is only visible in the namespaces that
+// one of them selects; the others are visible in every namespace. The
+// ServiceVisibilityPolicy admission plugin rejects the ServiceInstances of
+// the classes and plans that are not visible in their namespace.
+type ClusterServiceVisibilityPolicy struct {
+	metav1.TypeMeta
+
+	// Non-namespaced.  The name of this resource in etcd is in ObjectMeta.Name.
+	metav1.ObjectMeta
+
+	// Spec defines the class or plan whose visibility is restricted, and the
+	// namespaces it is visible in.
+	Spec ClusterServiceVisibilityPolicySpec
+}
+
+// ClusterServiceVisibilityPolicySpec represents the namespaces a class or
+// plan is visible in.
+type ClusterServiceVisibilityPolicySpec struct {
+	// ClusterServiceClassExternalName is the external name of the
+	// ClusterServiceClass whose visibility is restricted.
+	ClusterServiceClassExternalName string
+
+	// ClusterServicePlanExternalName is the external name of the
+	// ClusterServicePlan whose visibility is restricted. When it is empty,
+	// the visibility of the class itself, and so of all its plans, is
+	// restricted.
+	ClusterServicePlanExternalName string
+
+	// NamespaceSelector selects the namespaces, by their labels, that the
+	// class or plan is visible in. An empty selector selects every
+	// namespace.
+	NamespaceSelector metav1.LabelSelector
+}
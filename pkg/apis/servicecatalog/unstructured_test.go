@@ -81,10 +81,18 @@ func doUnstructuredRoundTrip(t *testing.T, group testapi.TestGroup, kind string)
 			c.FuzzNoCustom(ps)
 			ps.Parameters = nil
 		},
+		func(ps *servicecatalog.ServiceInstanceBrokerProperties, c fuzz.Continue) {
+			c.FuzzNoCustom(ps)
+			ps.Parameters = nil
+		},
 		func(ps *servicecatalog.ServiceBindingPropertiesState, c fuzz.Continue) {
 			c.FuzzNoCustom(ps)
 			ps.Parameters = nil
 		},
+		func(ds *servicecatalog.ClusterParameterDefaultSpec, c fuzz.Continue) {
+			c.FuzzNoCustom(ds)
+			ds.Parameters = nil
+		},
 	).Fuzz(internalObj)
 
 	item, err := api.Scheme.New(group.GroupVersion().WithKind(kind))
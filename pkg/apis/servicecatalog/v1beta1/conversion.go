@@ -84,7 +84,9 @@ func ClusterServiceClassFieldLabelConversionFunc(label, value string) (string, s
 func ServiceInstanceFieldLabelConversionFunc(label, value string) (string, string, error) {
 	switch label {
 	case "spec.clusterServiceClassRef.name",
-		"spec.clusterServicePlanRef.name":
+		"spec.clusterServicePlanRef.name",
+		"spec.serviceClassRef.name",
+		"spec.servicePlanRef.name":
 		return label, value, nil
 	default:
 		return "", "", fmt.Errorf("field label not supported: %s", label)
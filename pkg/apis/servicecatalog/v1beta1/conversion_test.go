@@ -196,6 +196,22 @@ func TestServiceInstanceFieldLabelConversionFunc(t *testing.T) {
 			outValue: "someref",
 			success:  true,
 		},
+		{
+			name:     "spec.serviceClassRef.name works",
+			inLabel:  "spec.serviceClassRef.name",
+			inValue:  "someref",
+			outLabel: "spec.serviceClassRef.name",
+			outValue: "someref",
+			success:  true,
+		},
+		{
+			name:     "spec.servicePlanRef.name works",
+			inLabel:  "spec.servicePlanRef.name",
+			inValue:  "someref",
+			outLabel: "spec.servicePlanRef.name",
+			outValue: "someref",
+			success:  true,
+		},
 		{
 			name:          "random fails",
 			inLabel:       "spec.random",
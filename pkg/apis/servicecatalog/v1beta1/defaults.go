@@ -46,12 +46,27 @@ func setCommonServiceBrokerDefaults(spec *CommonServiceBrokerSpec) {
 	}
 }
 
+func SetDefaults_PlanMigrationPolicy(policy *PlanMigrationPolicy) {
+	if policy.MaxInProgress == nil {
+		maxInProgress := int32(1)
+		policy.MaxInProgress = &maxInProgress
+	}
+}
+
 func SetDefaults_ServiceInstanceSpec(spec *ServiceInstanceSpec) {
-	if spec.ExternalID == "" {
+	// An adopted instance must name the instance of the broker it adopts.
+	if spec.ExternalID == "" && spec.Adoption == nil {
 		spec.ExternalID = string(uuid.NewUUID())
 	}
 }
 
+func SetDefaults_ServiceInstanceAdoption(adoption *ServiceInstanceAdoption) {
+	if adoption.Verify == nil {
+		verify := true
+		adoption.Verify = &verify
+	}
+}
+
 func SetDefaults_ServiceBindingSpec(spec *ServiceBindingSpec) {
 	if spec.ExternalID == "" {
 		spec.ExternalID = string(uuid.NewUUID())
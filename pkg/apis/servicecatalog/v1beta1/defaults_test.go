@@ -136,6 +136,15 @@ func TestSetDefaultServiceInstance(t *testing.T) {
 	if i2.Spec.ExternalID == "" {
 		t.Error("Expected a default ExternalID, but got none")
 	}
+
+	i = &versioned.ServiceInstance{}
+	i.Spec.Adoption = &versioned.ServiceInstanceAdoption{}
+	obj2 = roundTrip(t, runtime.Object(i))
+	i2 = obj2.(*versioned.ServiceInstance)
+
+	if i2.Spec.ExternalID != "" {
+		t.Errorf("Expected no default ExternalID for an adopted instance, got %q", i2.Spec.ExternalID)
+	}
 }
 
 func TestSetDefaultServiceBinding(t *testing.T) {
@@ -147,3 +156,17 @@ func TestSetDefaultServiceBinding(t *testing.T) {
 		t.Error("Expected a default ExternalID, but got none")
 	}
 }
+
+func TestSetDefaultClusterServicePlan(t *testing.T) {
+	p := &versioned.ClusterServicePlan{
+		Spec: versioned.ClusterServicePlanSpec{
+			MigrationPolicy: &versioned.PlanMigrationPolicy{ReplacementPlanExternalName: "replacement"},
+		},
+	}
+	obj2 := roundTrip(t, runtime.Object(p))
+	p2 := obj2.(*versioned.ClusterServicePlan)
+
+	if maxInProgress := p2.Spec.MigrationPolicy.MaxInProgress; maxInProgress == nil || *maxInProgress != 1 {
+		t.Errorf("Expected a default MaxInProgress of 1, got %v", maxInProgress)
+	}
+}
@@ -21,7 +21,7 @@ import (
 	"k8s.io/apimachinery/pkg/labels"
 )
 
-// These are functions to support filtering and are class specific for the ClusterServiceClass and ClusterServicePlan
+// These are functions to support filtering and are class specific for the [Cluster]ServiceClass and [Cluster]ServicePlan
 // This is where we can add more fields to the labels.Set to support other kinds of catalog filtering.
 
 // ConvertClusterServiceClassToProperties takes a Service Class and pulls out the
@@ -52,3 +52,32 @@ func ConvertClusterServicePlanToProperties(servicePlan *ClusterServicePlan) filt
 		FilterSpecClusterServiceClassName: servicePlan.Spec.ClusterServiceClassRef.Name,
 	}
 }
+
+// ConvertServiceClassToProperties takes a namespaced Service Class and pulls
+// out the properties we support for filtering, converting them into a map in
+// the expected format.
+func ConvertServiceClassToProperties(serviceClass *ServiceClass) filter.Properties {
+	if serviceClass == nil {
+		return labels.Set{}
+	}
+	return labels.Set{
+		FilterName:             serviceClass.Name,
+		FilterSpecExternalName: serviceClass.Spec.ExternalName,
+		FilterSpecExternalID:   serviceClass.Spec.ExternalID,
+	}
+}
+
+// ConvertServicePlanToProperties takes a namespaced Service Plan and pulls
+// out the properties we support for filtering, converting them into a map in
+// the expected format.
+func ConvertServicePlanToProperties(servicePlan *ServicePlan) filter.Properties {
+	if servicePlan == nil {
+		return labels.Set{}
+	}
+	return labels.Set{
+		FilterName:                 servicePlan.Name,
+		FilterSpecExternalName:     servicePlan.Spec.ExternalName,
+		FilterSpecExternalID:       servicePlan.Spec.ExternalID,
+		FilterSpecServiceClassName: servicePlan.Spec.ServiceClassRef.Name,
+	}
+}
@@ -109,3 +109,48 @@ func TestConvertClusterServicePlanToProperties(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertServicePlanToProperties(t *testing.T) {
+	cases := []struct {
+		name string
+		sp   *ServicePlan
+		json string
+	}{
+		{
+			name: "nil object",
+			json: "{}",
+		},
+		{
+			name: "normal object",
+			sp: &ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "service-plan", Namespace: "test-ns"},
+				Spec: ServicePlanSpec{
+					CommonServicePlanSpec: CommonServicePlanSpec{
+						ExternalName: "external-plan-name",
+						ExternalID:   "external-id",
+					},
+					ServiceClassRef: LocalObjectReference{
+						Name: "service-class-name",
+					},
+				},
+			},
+			json: `{"name":"service-plan","spec.externalID":"external-id","spec.externalName":"external-plan-name","spec.serviceClass.name":"service-class-name"}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			p := ConvertServicePlanToProperties(tc.sp)
+			if p == nil {
+				t.Fatalf("Failed to create Properties object from %+v", tc.sp)
+			}
+			b, err := json.Marshal(p)
+			if err != nil {
+				t.Fatalf("Unexpected error with json marchal, %v", err)
+			}
+			js := string(b)
+			if js != tc.json {
+				t.Fatalf("Failed to create expected Properties object,\n\texpected: \t%q,\n \tgot: \t\t%q", tc.json, js)
+			}
+		})
+	}
+}
@@ -21,24 +21,55 @@ import (
 	"strings"
 )
 
-// ClassSpecified checks that at least one class field is set.
-func (pr PlanReference) ClassSpecified() bool {
+// ClusterServiceClassSpecified checks that at least one cluster-scoped
+// class field is set.
+func (pr PlanReference) ClusterServiceClassSpecified() bool {
 	return pr.ClusterServiceClassExternalName != "" ||
 		pr.ClusterServiceClassExternalID != "" ||
 		pr.ClusterServiceClassName != ""
 }
 
-// PlanSpecified checks that at least one plan field is set.
-func (pr PlanReference) PlanSpecified() bool {
+// ClusterServicePlanSpecified checks that at least one cluster-scoped plan
+// field is set.
+func (pr PlanReference) ClusterServicePlanSpecified() bool {
 	return pr.ClusterServicePlanExternalName != "" ||
 		pr.ClusterServicePlanExternalID != "" ||
 		pr.ClusterServicePlanName != ""
 }
 
+// ServiceClassSpecified checks that at least one namespaced class field is
+// set.
+func (pr PlanReference) ServiceClassSpecified() bool {
+	return pr.ServiceClassExternalName != "" ||
+		pr.ServiceClassExternalID != "" ||
+		pr.ServiceClassName != ""
+}
+
+// ServicePlanSpecified checks that at least one namespaced plan field is
+// set.
+func (pr PlanReference) ServicePlanSpecified() bool {
+	return pr.ServicePlanExternalName != "" ||
+		pr.ServicePlanExternalID != "" ||
+		pr.ServicePlanName != ""
+}
+
+// ClassSpecified checks that at least one class field is set.
+func (pr PlanReference) ClassSpecified() bool {
+	return pr.ClusterServiceClassSpecified() || pr.ServiceClassSpecified()
+}
+
+// PlanSpecified checks that at least one plan field is set.
+func (pr PlanReference) PlanSpecified() bool {
+	return pr.ClusterServicePlanSpecified() || pr.ServicePlanSpecified()
+}
+
 // GetSpecifiedClass returns the user-specified class value from either:
 // * ClusterServiceClassExternalName
 // * ClusterServiceClassExternalID
 // * ClusterServiceClassName
+// * ServiceClassExternalName
+// * ServiceClassExternalID
+// * ServiceClassName
 func (pr PlanReference) GetSpecifiedClass() string {
 	if pr.ClusterServiceClassExternalName != "" {
 		return pr.ClusterServiceClassExternalName
@@ -52,6 +83,18 @@ func (pr PlanReference) GetSpecifiedClass() string {
 		return pr.ClusterServiceClassName
 	}
 
+	if pr.ServiceClassExternalName != "" {
+		return pr.ServiceClassExternalName
+	}
+
+	if pr.ServiceClassExternalID != "" {
+		return pr.ServiceClassExternalID
+	}
+
+	if pr.ServiceClassName != "" {
+		return pr.ServiceClassName
+	}
+
 	return ""
 }
 
@@ -59,6 +102,9 @@ func (pr PlanReference) GetSpecifiedClass() string {
 // * ClusterServicePlanExternalName
 // * ClusterServicePlanExternalID
 // * ClusterServicePlanName
+// * ServicePlanExternalName
+// * ServicePlanExternalID
+// * ServicePlanName
 func (pr PlanReference) GetSpecifiedPlan() string {
 	if pr.ClusterServicePlanExternalName != "" {
 		return pr.ClusterServicePlanExternalName
@@ -72,17 +118,29 @@ func (pr PlanReference) GetSpecifiedPlan() string {
 		return pr.ClusterServicePlanName
 	}
 
+	if pr.ServicePlanExternalName != "" {
+		return pr.ServicePlanExternalName
+	}
+
+	if pr.ServicePlanExternalID != "" {
+		return pr.ServicePlanExternalID
+	}
+
+	if pr.ServicePlanName != "" {
+		return pr.ServicePlanName
+	}
+
 	return ""
 }
 
 // GetClassFilterFieldName returns the appropriate field name for filtering
 // a list of service catalog classes by the PlanReference.
 func (pr PlanReference) GetClassFilterFieldName() string {
-	if pr.ClusterServiceClassExternalName != "" {
+	if pr.ClusterServiceClassExternalName != "" || pr.ServiceClassExternalName != "" {
 		return "spec.externalName"
 	}
 
-	if pr.ClusterServiceClassExternalID != "" {
+	if pr.ClusterServiceClassExternalID != "" || pr.ServiceClassExternalID != "" {
 		return "spec.externalID"
 	}
 
@@ -92,11 +150,11 @@ func (pr PlanReference) GetClassFilterFieldName() string {
 // GetPlanFilterFieldName returns the appropriate field name for filtering
 // a list of service catalog plans by the PlanReference.
 func (pr PlanReference) GetPlanFilterFieldName() string {
-	if pr.ClusterServicePlanExternalName != "" {
+	if pr.ClusterServicePlanExternalName != "" || pr.ServicePlanExternalName != "" {
 		return "spec.externalName"
 	}
 
-	if pr.ClusterServicePlanExternalID != "" {
+	if pr.ClusterServicePlanExternalID != "" || pr.ServicePlanExternalID != "" {
 		return "spec.externalID"
 	}
 
@@ -131,27 +189,29 @@ func (pr PlanReference) String() string {
 //     {ClassExternalName:"foo", PlanExternalName:"bar"}
 //     {ClassExternalID:"foo123", PlanExternalID:"bar456"}
 //     {ClassName:"k8s-foo123", PlanName:"k8s-bar456"}
+// Namespaced fields are printed with the same labels as their
+// cluster-scoped counterparts.
 func (pr PlanReference) Format(s fmt.State, verb rune) {
 	var classFields []string
-	if pr.ClusterServiceClassExternalName != "" {
-		classFields = append(classFields, fmt.Sprintf("ClassExternalName:%q", pr.ClusterServiceClassExternalName))
+	if externalName := pr.ClusterServiceClassExternalName + pr.ServiceClassExternalName; externalName != "" {
+		classFields = append(classFields, fmt.Sprintf("ClassExternalName:%q", externalName))
 	}
-	if pr.ClusterServiceClassExternalID != "" {
-		classFields = append(classFields, fmt.Sprintf("ClassExternalID:%q", pr.ClusterServiceClassExternalID))
+	if externalID := pr.ClusterServiceClassExternalID + pr.ServiceClassExternalID; externalID != "" {
+		classFields = append(classFields, fmt.Sprintf("ClassExternalID:%q", externalID))
 	}
-	if pr.ClusterServiceClassName != "" {
-		classFields = append(classFields, fmt.Sprintf("ClassName:%q", pr.ClusterServiceClassName))
+	if name := pr.ClusterServiceClassName + pr.ServiceClassName; name != "" {
+		classFields = append(classFields, fmt.Sprintf("ClassName:%q", name))
 	}
 
 	var planFields []string
-	if pr.ClusterServicePlanExternalName != "" {
-		planFields = append(planFields, fmt.Sprintf("PlanExternalName:%q", pr.ClusterServicePlanExternalName))
+	if externalName := pr.ClusterServicePlanExternalName + pr.ServicePlanExternalName; externalName != "" {
+		planFields = append(planFields, fmt.Sprintf("PlanExternalName:%q", externalName))
 	}
-	if pr.ClusterServicePlanExternalID != "" {
-		planFields = append(planFields, fmt.Sprintf("PlanExternalID:%q", pr.ClusterServicePlanExternalID))
+	if externalID := pr.ClusterServicePlanExternalID + pr.ServicePlanExternalID; externalID != "" {
+		planFields = append(planFields, fmt.Sprintf("PlanExternalID:%q", externalID))
 	}
-	if pr.ClusterServicePlanName != "" {
-		planFields = append(planFields, fmt.Sprintf("PlanName:%q", pr.ClusterServicePlanName))
+	if name := pr.ClusterServicePlanName + pr.ServicePlanName; name != "" {
+		planFields = append(planFields, fmt.Sprintf("PlanName:%q", name))
 	}
 
 	switch verb {
@@ -159,6 +219,8 @@ func (pr PlanReference) Format(s fmt.State, verb rune) {
 		fmt.Fprintf(s, "{%s}", strings.Join(classFields, ", "))
 	case 'b':
 		fmt.Fprintf(s, "{%s}", strings.Join(planFields, ", "))
+	case 's':
+		fmt.Fprint(s, pr.String())
 	case 'v':
 		fmt.Fprintf(s, "{%s}", strings.Join(append(classFields, planFields...), ", "))
 	}
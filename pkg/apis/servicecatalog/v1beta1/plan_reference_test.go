@@ -52,6 +52,12 @@ func TestPlanReference_Format(t *testing.T) {
 			ClusterServiceClassExternalID: "foo-abc123", ClusterServicePlanExternalID: "bar-def456"}},
 		{"plan: cluster-name", "%b", `{PlanName:"k8s-bar456"}`, PlanReference{
 			ClusterServiceClassName: "k8s-foo1232", ClusterServicePlanName: "k8s-bar456"}},
+		{"all: namespaced external-name", "%v", `{ClassExternalName:"foo", PlanExternalName:"bar"}`, PlanReference{
+			ServiceClassExternalName: "foo", ServicePlanExternalName: "bar"}},
+		{"short: namespaced name", "%s", `k8s-foo1232/k8s-bar456`, PlanReference{
+			ServiceClassName: "k8s-foo1232", ServicePlanName: "k8s-bar456"}},
+		{"plan: namespaced external-id", "%b", `{PlanExternalID:"bar-def456"}`, PlanReference{
+			ServiceClassExternalID: "foo-abc123", ServicePlanExternalID: "bar-def456"}},
 	}
 
 	for _, tc := range testcases {
@@ -0,0 +1,34 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// Selects returns whether the limit selects the instances of the plan with
+// the given external name and Free field, of the class with the given
+// external name. An empty name or a nil free stands for a class or plan that
+// is not known, which only the limits that do not select on it select.
+func (l ServiceInstanceQuotaLimit) Selects(classExternalName, planExternalName string, free *bool) bool {
+	if l.ServiceClassExternalName != "" && l.ServiceClassExternalName != classExternalName {
+		return false
+	}
+	if l.ServicePlanExternalName != "" && l.ServicePlanExternalName != planExternalName {
+		return false
+	}
+	if l.Free != nil && (free == nil || *l.Free != *free) {
+		return false
+	}
+	return true
+}
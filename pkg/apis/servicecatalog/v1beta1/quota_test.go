@@ -0,0 +1,98 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+)
+
+func TestServiceInstanceQuotaLimitSelects(t *testing.T) {
+	free, notFree := true, false
+	testcases := []struct {
+		name      string
+		limit     ServiceInstanceQuotaLimit
+		className string
+		planName  string
+		free      *bool
+		want      bool
+	}{
+		{
+			name:      "no selectors",
+			className: "db",
+			planName:  "small",
+			free:      &free,
+			want:      true,
+		},
+		{
+			name:  "no selectors, unknown plan",
+			limit: ServiceInstanceQuotaLimit{},
+			want:  true,
+		},
+		{
+			name:      "class",
+			limit:     ServiceInstanceQuotaLimit{ServiceClassExternalName: "db"},
+			className: "db",
+			planName:  "small",
+			want:      true,
+		},
+		{
+			name:      "other class",
+			limit:     ServiceInstanceQuotaLimit{ServiceClassExternalName: "db"},
+			className: "queue",
+			planName:  "small",
+		},
+		{
+			name:      "plan",
+			limit:     ServiceInstanceQuotaLimit{ServiceClassExternalName: "db", ServicePlanExternalName: "small"},
+			className: "db",
+			planName:  "small",
+			want:      true,
+		},
+		{
+			name:      "other plan",
+			limit:     ServiceInstanceQuotaLimit{ServiceClassExternalName: "db", ServicePlanExternalName: "small"},
+			className: "db",
+			planName:  "large",
+		},
+		{
+			name:      "not free",
+			limit:     ServiceInstanceQuotaLimit{Free: &notFree},
+			className: "db",
+			planName:  "large",
+			free:      &notFree,
+			want:      true,
+		},
+		{
+			name:      "free",
+			limit:     ServiceInstanceQuotaLimit{Free: &notFree},
+			className: "db",
+			planName:  "small",
+			free:      &free,
+		},
+		{
+			name:  "not free, unknown plan",
+			limit: ServiceInstanceQuotaLimit{Free: &notFree},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.limit.Selects(tc.className, tc.planName, tc.free); got != tc.want {
+				t.Errorf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -66,6 +66,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&ServiceInstanceList{},
 		&ServiceBinding{},
 		&ServiceBindingList{},
+		&ClusterParameterDefault{},
+		&ClusterParameterDefaultList{},
+		&ServiceInstanceQuota{},
+		&ServiceInstanceQuotaList{},
+		&ClusterServiceVisibilityPolicy{},
+		&ClusterServiceVisibilityPolicyList{},
 	)
 	metav1.AddToGroupVersion(scheme, SchemeGroupVersion)
 	scheme.AddKnownTypes(schema.GroupVersion{Version: "v1"}, &metav1.Status{})
@@ -126,6 +126,39 @@ type CommonServiceBrokerSpec struct {
 	// and plans have resources created for them.
 	// +optional
 	CatalogRestrictions *CatalogRestrictions `json:"catalogRestrictions,omitempty"`
+
+	// RateLimits limits the requests that the controller sends to the broker.
+	// The limits that are not set default to the ones the controller manager
+	// is configured with.
+	// +optional
+	RateLimits *ServiceBrokerRateLimits `json:"rateLimits,omitempty"`
+}
+
+// ServiceBrokerRateLimits limits the requests that the controller sends to a
+// broker. The requests polling the last operation of instances and bindings
+// have their own budget, so that polling the operations in progress does not
+// hold back the requests that start new ones, and vice versa. A limit of zero
+// means that the requests are not limited.
+type ServiceBrokerRateLimits struct {
+	// MaxInFlightRequests is the maximum number of requests, other than
+	// last operation polls, sent to the broker at the same time.
+	// +optional
+	MaxInFlightRequests *int32 `json:"maxInFlightRequests,omitempty"`
+
+	// RequestsPerSecond is the maximum rate of requests, other than last
+	// operation polls, sent to the broker.
+	// +optional
+	RequestsPerSecond *int32 `json:"requestsPerSecond,omitempty"`
+
+	// MaxInFlightPollRequests is the maximum number of last operation polls
+	// sent to the broker at the same time.
+	// +optional
+	MaxInFlightPollRequests *int32 `json:"maxInFlightPollRequests,omitempty"`
+
+	// PollRequestsPerSecond is the maximum rate of last operation polls sent
+	// to the broker.
+	// +optional
+	PollRequestsPerSecond *int32 `json:"pollRequestsPerSecond,omitempty"`
 }
 
 // CatalogRestrictions is a set of restrictions on which of a broker's services
@@ -219,6 +252,14 @@ type ClusterServiceBrokerAuthInfo struct {
 	// The value is referenced from the 'token' field of the given secret.  This value should only
 	// contain the token value and not the `Bearer` scheme.
 	Bearer *ClusterBearerTokenAuthConfig `json:"bearer,omitempty"`
+	// ClusterTLSAuthConfig provides configuration to authenticate with a client
+	// certificate. The certificate and key are referenced from the 'tls.crt' and
+	// 'tls.key' fields of the given kubernetes.io/tls secret.
+	TLS *ClusterTLSAuthConfig `json:"tls,omitempty"`
+	// ClusterOAuth2AuthConfig provides configuration to send an access token,
+	// obtained from an OAuth2 authorization server with the client credentials
+	// grant, as a bearer token.
+	OAuth2 *ClusterOAuth2AuthConfig `json:"oauth2,omitempty"`
 }
 
 // ClusterBasicAuthConfig provides config for the basic authentication of
@@ -244,6 +285,34 @@ type ClusterBearerTokenAuthConfig struct {
 	SecretRef *ObjectReference `json:"secretRef,omitempty"`
 }
 
+// ClusterTLSAuthConfig provides config for the client certificate
+// authentication of cluster scoped brokers.
+type ClusterTLSAuthConfig struct {
+	// SecretRef is a reference to a Secret containing information the
+	// catalog should use to authenticate to this ServiceBroker.
+	//
+	// Required fields:
+	// - Secret.Data["tls.crt"] - PEM encoded client certificate
+	// - Secret.Data["tls.key"] - PEM encoded private key of the certificate
+	SecretRef *ObjectReference `json:"secretRef,omitempty"`
+}
+
+// ClusterOAuth2AuthConfig provides config for the OAuth2 client
+// credentials authentication of cluster scoped brokers.
+type ClusterOAuth2AuthConfig struct {
+	// TokenURL is the URL of the token endpoint of the authorization server.
+	TokenURL string `json:"tokenURL"`
+	// SecretRef is a reference to a Secret containing information the
+	// catalog should use to obtain access tokens for this ServiceBroker.
+	//
+	// Required fields:
+	// - Secret.Data["clientID"] - client ID used to obtain access tokens
+	// - Secret.Data["clientSecret"] - client secret used to obtain access tokens
+	SecretRef *ObjectReference `json:"secretRef,omitempty"`
+	// Scopes are the scopes to request for the access tokens.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
 // ServiceBrokerAuthInfo is a union type that contains information on
 // one of the authentication methods the the service catalog and brokers may
 // support, according to the OpenServiceBroker API specification
@@ -255,6 +324,14 @@ type ServiceBrokerAuthInfo struct {
 	// The value is referenced from the 'token' field of the given secret.  This value should only
 	// contain the token value and not the `Bearer` scheme.
 	Bearer *BearerTokenAuthConfig `json:"bearer,omitempty"`
+	// TLSAuthConfig provides configuration to authenticate with a client
+	// certificate. The certificate and key are referenced from the 'tls.crt' and
+	// 'tls.key' fields of the given kubernetes.io/tls secret.
+	TLS *TLSAuthConfig `json:"tls,omitempty"`
+	// OAuth2AuthConfig provides configuration to send an access token,
+	// obtained from an OAuth2 authorization server with the client credentials
+	// grant, as a bearer token.
+	OAuth2 *OAuth2AuthConfig `json:"oauth2,omitempty"`
 }
 
 // BasicAuthConfig provides config for the basic authentication of
@@ -280,6 +357,34 @@ type BearerTokenAuthConfig struct {
 	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
 }
 
+// TLSAuthConfig provides config for the client certificate
+// authentication of namespaced brokers.
+type TLSAuthConfig struct {
+	// SecretRef is a reference to a Secret containing information the
+	// catalog should use to authenticate to this ServiceBroker.
+	//
+	// Required fields:
+	// - Secret.Data["tls.crt"] - PEM encoded client certificate
+	// - Secret.Data["tls.key"] - PEM encoded private key of the certificate
+	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+}
+
+// OAuth2AuthConfig provides config for the OAuth2 client
+// credentials authentication of namespaced brokers.
+type OAuth2AuthConfig struct {
+	// TokenURL is the URL of the token endpoint of the authorization server.
+	TokenURL string `json:"tokenURL"`
+	// SecretRef is a reference to a Secret containing information the
+	// catalog should use to obtain access tokens for this ServiceBroker.
+	//
+	// Required fields:
+	// - Secret.Data["clientID"] - client ID used to obtain access tokens
+	// - Secret.Data["clientSecret"] - client secret used to obtain access tokens
+	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+	// Scopes are the scopes to request for the access tokens.
+	Scopes []string `json:"scopes,omitempty"`
+}
+
 const (
 	// BasicAuthUsernameKey is the key of the username for SecretTypeBasicAuth secrets
 	BasicAuthUsernameKey = "username"
@@ -288,6 +393,11 @@ const (
 
 	// BearerTokenKey is the key of the bearer token for SecretTypeBearerTokenAuth secrets
 	BearerTokenKey = "token"
+
+	// OAuth2ClientIDKey is the key of the client ID for OAuth2 secrets
+	OAuth2ClientIDKey = "clientID"
+	// OAuth2ClientSecretKey is the key of the client secret for OAuth2 secrets
+	OAuth2ClientSecretKey = "clientSecret"
 )
 
 // CommonServiceBrokerStatus represents the current status of a Broker.
@@ -350,6 +460,12 @@ const (
 	// ServiceBrokerConditionFailed represents information about a final failure
 	// that should not be retried.
 	ServiceBrokerConditionFailed ServiceBrokerConditionType = "Failed"
+
+	// ServiceBrokerConditionReachable represents whether the broker responds
+	// to the requests of the controller. It is false while the circuit of the
+	// broker is open after repeated failures, during which the work for the
+	// resources of the broker is paused.
+	ServiceBrokerConditionReachable ServiceBrokerConditionType = "Reachable"
 )
 
 // ConditionStatus represents a condition's status.
@@ -481,6 +597,13 @@ type CommonServiceClassSpec struct {
 	// its endpoint is supported for all plans.
 	BindingRetrievable bool `json:"bindingRetrievable"`
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// InstancesRetrievable indicates whether fetching an instance via a GET
+	// on its endpoint is supported for all plans.
+	InstancesRetrievable bool `json:"instancesRetrievable,omitempty"`
+
 	// PlanUpdatable indicates whether instances provisioned from this
 	// ServiceClass may change ServicePlans after being
 	// provisioned.
@@ -630,6 +753,27 @@ type CommonServicePlanSpec struct {
 	// broker's response, which allows clients to see what the credentials
 	// will look like even before the binding operation is performed.
 	ServiceBindingCreateResponseSchema *runtime.RawExtension `json:"serviceBindingCreateResponseSchema,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	// The MaintenanceInfo feature gate needs to be enabled for this field to
+	// be populated.
+	//
+	// MaintenanceInfo describes the version of the software that the
+	// instances of this plan run, as advertised by the broker.
+	// +optional
+	MaintenanceInfo *MaintenanceInfo `json:"maintenanceInfo,omitempty"`
+}
+
+// MaintenanceInfo describes the version of the software that the instances
+// of a plan run.
+type MaintenanceInfo struct {
+	// Version is the semantic version of the maintenance information.
+	Version string `json:"version"`
+
+	// Description describes the changes of this version.
+	// +optional
+	Description string `json:"description,omitempty"`
 }
 
 // ClusterServicePlanSpec represents details about a ClusterServicePlan.
@@ -644,6 +788,33 @@ type ClusterServicePlanSpec struct {
 	// ClusterServiceClassRef is a reference to the service class that
 	// owns this plan.
 	ClusterServiceClassRef ClusterObjectReference `json:"clusterServiceClassRef"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// MigrationPolicy, if set, makes the controller move the
+	// ServiceInstances of this plan to a replacement plan once the broker
+	// has removed this plan from its catalog. It is set by cluster
+	// operators, and kept when the plan is updated from the catalog.
+	// Requires the PlanMigration feature.
+	// +optional
+	MigrationPolicy *PlanMigrationPolicy `json:"migrationPolicy,omitempty"`
+}
+
+// PlanMigrationPolicy describes how the ServiceInstances of a plan that has
+// been removed from the catalog of its broker are moved to another plan of
+// the same class. Each instance is moved by updating its plan, which the
+// controller then sends to the broker as an update of the instance.
+type PlanMigrationPolicy struct {
+	// ReplacementPlanExternalName is the external name of the plan of the
+	// same class that the instances are moved to.
+	ReplacementPlanExternalName string `json:"replacementPlanExternalName"`
+
+	// MaxInProgress is the maximum number of instances that are being moved
+	// at the same time. An instance is being moved from the time its plan is
+	// updated until the broker has accepted the new plan. Defaults to 1.
+	// +optional
+	MaxInProgress *int32 `json:"maxInProgress,omitempty"`
 }
 
 // ClusterServicePlanStatus represents status information about a
@@ -771,9 +942,15 @@ type ServiceInstance struct {
 //  - ClusterServiceClassExternalName and ClusterServicePlanExternalName
 //  - ClusterServiceClassExternalID and ClusterServicePlanExternalID
 //  - ClusterServiceClassName and ClusterServicePlanName
+//  - ServiceClassExternalName and ServicePlanExternalName
+//  - ServiceClassExternalID and ServicePlanExternalID
+//  - ServiceClassName and ServicePlanName
 //
 // For any of these ways, if a ClusterServiceClass only has one plan
 // then the corresponding service plan field is optional.
+//
+// The cluster-scoped fields and the namespaced fields are mutually
+// exclusive.
 type PlanReference struct {
 	// ClusterServiceClassExternalName is the human-readable name of the
 	// service as reported by the broker. Note that if the broker changes
@@ -805,6 +982,36 @@ type PlanReference struct {
 	ClusterServiceClassName string `json:"clusterServiceClassName,omitempty"`
 	// ClusterServicePlanName is kubernetes name of the ClusterServicePlan.
 	ClusterServicePlanName string `json:"clusterServicePlanName,omitempty"`
+
+	// ServiceClassExternalName is the human-readable name of the
+	// service as reported by the broker. Note that if the broker changes
+	// the name of the ServiceClass, it will not be reflected here,
+	// and to see the current name of the ServiceClass, you should
+	// follow the ServiceClassRef below.
+	//
+	// Immutable.
+	ServiceClassExternalName string `json:"serviceClassExternalName,omitempty"`
+	// ServicePlanExternalName is the human-readable name of the plan
+	// as reported by the broker. Note that if the broker changes the name
+	// of the ServicePlan, it will not be reflected here, and to see
+	// the current name of the ServicePlan, you should follow the
+	// ServicePlanRef below.
+	ServicePlanExternalName string `json:"servicePlanExternalName,omitempty"`
+
+	// ServiceClassExternalID is the broker's external id for the class.
+	//
+	// Immutable.
+	ServiceClassExternalID string `json:"serviceClassExternalID,omitempty"`
+
+	// ServicePlanExternalID is the broker's external id for the plan.
+	ServicePlanExternalID string `json:"servicePlanExternalID,omitempty"`
+
+	// ServiceClassName is the kubernetes name of the ServiceClass.
+	//
+	// Immutable.
+	ServiceClassName string `json:"serviceClassName,omitempty"`
+	// ServicePlanName is kubernetes name of the ServicePlan.
+	ServicePlanName string `json:"servicePlanName,omitempty"`
 }
 
 // ServiceInstanceSpec represents the desired state of an Instance.
@@ -823,6 +1030,15 @@ type ServiceInstanceSpec struct {
 	// ClusterServicePlanExternalName
 	ClusterServicePlanRef *ClusterObjectReference `json:"clusterServicePlanRef,omitempty"`
 
+	// ServiceClassRef is a reference to the ServiceClass that the user
+	// selected. This is set by the controller based on
+	// ServiceClassExternalName.
+	ServiceClassRef *LocalObjectReference `json:"serviceClassRef,omitempty"`
+	// ServicePlanRef is a reference to the ServicePlan that the user
+	// selected. This is set by the controller based on
+	// ServicePlanExternalName.
+	ServicePlanRef *LocalObjectReference `json:"servicePlanRef,omitempty"`
+
 	// Parameters is a set of the parameters to be passed to the underlying
 	// broker. The inline YAML/JSON payload to be translated into equivalent
 	// JSON object. If a top-level parameter name exists in multiples sources
@@ -865,8 +1081,76 @@ type ServiceInstanceSpec struct {
 	// been made to the secrets from which the parameters are sourced.
 	// +optional
 	UpdateRequests int64 `json:"updateRequests"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// MaintenanceInfoVersion is the version of the maintenance information
+	// of the plan that the instance is upgraded to. When it differs from the
+	// version that the instance runs, the maintenance information of the
+	// plan is sent to the broker in an update of the instance. It must be
+	// the version currently advertised by the plan.
+	// Requires the MaintenanceInfo feature.
+	// +optional
+	MaintenanceInfoVersion string `json:"maintenanceInfoVersion,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// DeletionPolicy is what happens to the instance at the broker when the
+	// ServiceInstance is deleted. It defaults to Deprovision when empty.
+	// Requires the DeletionPolicy feature.
+	// +optional
+	DeletionPolicy ServiceInstanceDeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// DeletionProtection rejects the deletion of the ServiceInstance while it
+	// is true. It also rejects the deletion of any collection of
+	// ServiceInstances that includes the namespace of the ServiceInstance,
+	// whatever the label and field selectors of the deletion.
+	// Requires the DeletionPolicy feature.
+	// +optional
+	DeletionProtection bool `json:"deletionProtection,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// Adoption makes the controller adopt the instance that already exists
+	// at the broker under ExternalID, instead of provisioning a new one. The
+	// ServiceInstance is then managed as if it had provisioned the instance.
+	// Immutable.
+	// Requires the InstanceAdoption feature.
+	// +optional
+	Adoption *ServiceInstanceAdoption `json:"adoption,omitempty"`
 }
 
+// ServiceInstanceAdoption describes how a ServiceInstance adopts the instance
+// that already exists at the broker.
+type ServiceInstanceAdoption struct {
+	// Verify makes the controller fetch the instance from the broker before
+	// adopting it, to check that it exists and runs the plan of the
+	// ServiceInstance. The broker must support fetching instances. Defaults
+	// to true.
+	// +optional
+	Verify *bool `json:"verify,omitempty"`
+}
+
+// ServiceInstanceDeletionPolicy is what happens to the instance at the broker
+// when a ServiceInstance is deleted.
+type ServiceInstanceDeletionPolicy string
+
+const (
+	// ServiceInstanceDeletionPolicyDeprovision means that the instance is
+	// deprovisioned at the broker when the ServiceInstance is deleted.
+	ServiceInstanceDeletionPolicyDeprovision ServiceInstanceDeletionPolicy = "Deprovision"
+
+	// ServiceInstanceDeletionPolicyRetain means that the instance is left
+	// at the broker when the ServiceInstance is deleted.
+	ServiceInstanceDeletionPolicyRetain ServiceInstanceDeletionPolicy = "Retain"
+)
+
 // ServiceInstanceStatus represents the current status of an Instance.
 type ServiceInstanceStatus struct {
 	// Conditions is an array of ServiceInstanceConditions capturing aspects of an
@@ -924,6 +1208,71 @@ type ServiceInstanceStatus struct {
 	// DeprovisionStatus describes what has been done to deprovision the
 	// ServiceInstance.
 	DeprovisionStatus ServiceInstanceDeprovisionStatus `json:"deprovisionStatus"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// PlanMigration describes the last move of the ServiceInstance off a
+	// plan that was removed from the catalog of its broker, according to the
+	// MigrationPolicy of that plan.
+	// +optional
+	PlanMigration *ServiceInstancePlanMigration `json:"planMigration,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// BrokerProperties is the state of the ServiceInstance as last fetched
+	// from the broker. It is only fetched for instances whose class is
+	// instancesRetrievable, when the check is enabled on the
+	// controller-manager.
+	// +optional
+	BrokerProperties *ServiceInstanceBrokerProperties `json:"brokerProperties,omitempty"`
+
+	// LastBrokerSyncTime is the last time the controller recorded the
+	// ServiceInstance as fetched from the broker: on the first fetch, and on
+	// the fetches where the broker reported a change.
+	// +optional
+	LastBrokerSyncTime *metav1.Time `json:"lastBrokerSyncTime,omitempty"`
+}
+
+// ServiceInstanceBrokerProperties is the state of a ServiceInstance as
+// reported by its broker.
+type ServiceInstanceBrokerProperties struct {
+	// Parameters is a blob of the parameters and their values that the
+	// broker reports for this ServiceInstance. If a parameter was sourced
+	// from a secret, its value will be "<redacted>" in this blob.
+	// +optional
+	Parameters *runtime.RawExtension `json:"parameters,omitempty"`
+
+	// DashboardURL is the URL of a web-based management user interface for
+	// the service instance, as reported by the broker.
+	// +optional
+	DashboardURL *string `json:"dashboardURL,omitempty"`
+}
+
+// ServiceInstancePlanMigration describes the move of a ServiceInstance from a
+// plan that was removed from the catalog of its broker to its replacement.
+type ServiceInstancePlanMigration struct {
+	// FromClusterServicePlanName is the Kubernetes name of the
+	// ClusterServicePlan the instance is moved from.
+	FromClusterServicePlanName string `json:"fromClusterServicePlanName"`
+
+	// ToClusterServicePlanName is the Kubernetes name of the
+	// ClusterServicePlan the instance is moved to.
+	ToClusterServicePlanName string `json:"toClusterServicePlanName"`
+
+	// StartTime is the time the plan of the instance was updated.
+	StartTime metav1.Time `json:"startTime"`
+
+	// CompletionTime is the time the broker accepted or rejected the new
+	// plan. It is unset while the instance is being moved.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// FailureMessage is set when the broker rejected the new plan, to the
+	// reason it gave.
+	// +optional
+	FailureMessage string `json:"failureMessage,omitempty"`
 }
 
 // ServiceInstanceCondition contains condition information about an Instance.
@@ -962,6 +1311,16 @@ const (
 	// ServiceInstanceConditionOrphanMitigation represents information about an
 	// orphan mitigation that is required after failed provisioning.
 	ServiceInstanceConditionOrphanMitigation ServiceInstanceConditionType = "OrphanMitigation"
+
+	// ServiceInstanceConditionUpgradeAvailable represents that the plan of
+	// the instance advertises a version of its maintenance information that
+	// the instance does not run yet.
+	ServiceInstanceConditionUpgradeAvailable ServiceInstanceConditionType = "UpgradeAvailable"
+
+	// ServiceInstanceConditionParametersDrifted represents whether the last
+	// fetch of a ServiceInstance from its broker found parameters that differ
+	// from the ones the broker was last sent.
+	ServiceInstanceConditionParametersDrifted ServiceInstanceConditionType = "ParametersDrifted"
 )
 
 // ServiceInstanceOperation represents a type of operation the controller can
@@ -992,6 +1351,15 @@ type ServiceInstancePropertiesState struct {
 	// broker knows this ServiceInstance to be on.
 	ClusterServicePlanExternalID string `json:"clusterServicePlanExternalID"`
 
+	// ServicePlanExternalName is the name of the namespaced plan that the
+	// broker knows this ServiceInstance to be on. It is set instead of
+	// ClusterServicePlanExternalName when the instance uses a ServicePlan.
+	ServicePlanExternalName string `json:"servicePlanExternalName,omitempty"`
+
+	// ServicePlanExternalID is the external ID of the namespaced plan that
+	// the broker knows this ServiceInstance to be on.
+	ServicePlanExternalID string `json:"servicePlanExternalID,omitempty"`
+
 	// Parameters is a blob of the parameters and their values that the broker
 	// knows about for this ServiceInstance.  If a parameter was sourced from
 	// a secret, its value will be "<redacted>" in this blob.
@@ -1002,6 +1370,11 @@ type ServiceInstancePropertiesState struct {
 
 	// UserInfo is information about the user that made the request.
 	UserInfo *UserInfo `json:"userInfo,omitempty"`
+
+	// MaintenanceInfoVersion is the version of the maintenance information
+	// of the plan that was sent to the broker.
+	// +optional
+	MaintenanceInfoVersion string `json:"maintenanceInfoVersion,omitempty"`
 }
 
 // ServiceInstanceDeprovisionStatus is the status of deprovisioning a
@@ -1113,6 +1486,50 @@ type ServiceBindingSpec struct {
 	// associated with the ServiceBinding before they are inserted into the Secret.
 	SecretTransforms []SecretTransform `json:"secretTransforms,omitempty"`
 
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// PodPresetTemplate, if set, makes the controller create and own a
+	// PodPreset that injects the Secret of the ServiceBinding into the pods
+	// matching its selector. The PodPreset is kept in sync with the
+	// ServiceBinding and removed when the ServiceBinding is unbound. Requires
+	// the PodPreset feature.
+	// +optional
+	PodPresetTemplate *PodPresetTemplate `json:"podPresetTemplate,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// SecretCopyNamespaces is a list of namespaces, other than the namespace
+	// of the ServiceBinding, into which the controller copies the Secret of
+	// the ServiceBinding under the same name. The user creating or updating
+	// the ServiceBinding must be allowed to create Secrets in each of these
+	// namespaces. The copies are kept in sync with the Secret, removed when
+	// their namespace is removed from this list, and removed when the
+	// ServiceBinding is unbound. Requires the BindingTargets feature.
+	// +optional
+	SecretCopyNamespaces []string `json:"secretCopyNamespaces,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// ConfigMapTemplate, if set, makes the controller create and own a
+	// ConfigMap, in the namespace and under the name of the ServiceBinding,
+	// that holds the subset of the credentials listed in the template. Only
+	// credentials that are not sensitive should be exposed this way. The
+	// ConfigMap is removed when the template is removed or the ServiceBinding
+	// is unbound. Requires the BindingTargets feature.
+	// +optional
+	ConfigMapTemplate *ConfigMapTemplate `json:"configMapTemplate,omitempty"`
+
+	// RotationRequests is a strictly increasing, non-negative integer counter
+	// that can be manually incremented by a user to rotate the credentials of
+	// the ServiceBinding. Each increment makes the API server assign a new
+	// ExternalID, and the controller binds it, replaces the contents of the
+	// Secret and unbinds the previous credentials after a grace period.
+	// +optional
+	RotationRequests int64 `json:"rotationRequests"`
+
 	// ExternalID is the identity of this object for use with the OSB API.
 	//
 	// Immutable.
@@ -1176,6 +1593,35 @@ type ServiceBindingStatus struct {
 
 	// UnbindStatus describes what has been done to unbind the ServiceBinding.
 	UnbindStatus ServiceBindingUnbindStatus `json:"unbindStatus"`
+
+	// CurrentExternalID is the ExternalID of the binding whose credentials
+	// are currently stored in the Secret.
+	CurrentExternalID string `json:"currentExternalID,omitempty"`
+
+	// PreviousExternalID is the ExternalID of the binding whose credentials
+	// were replaced by the last rotation. It is cleared once those
+	// credentials have been unbound.
+	PreviousExternalID string `json:"previousExternalID,omitempty"`
+
+	// PreviousCredentialsUnbindTime is the time after which the credentials
+	// of PreviousExternalID are unbound.
+	PreviousCredentialsUnbindTime *metav1.Time `json:"previousCredentialsUnbindTime,omitempty"`
+
+	// PreviousCredentialsUnbindOperation is set while the broker unbinds the
+	// credentials of PreviousExternalID asynchronously, to the operation key
+	// it returned, if any.
+	PreviousCredentialsUnbindOperation *string `json:"previousCredentialsUnbindOperation,omitempty"`
+
+	// LastCredentialsSyncTime is the last time the controller fetched the
+	// credentials of the binding from the broker to check its Secret for
+	// drift. The check only runs for bindings whose class is
+	// bindingRetrievable, when it is enabled on the controller-manager.
+	LastCredentialsSyncTime *metav1.Time `json:"lastCredentialsSyncTime,omitempty"`
+
+	// DeliveredSecretName is the name of the Secret the credentials of the
+	// binding were last injected in. The Secret is deleted once the
+	// credentials are injected under another SecretName.
+	DeliveredSecretName string `json:"deliveredSecretName,omitempty"`
 }
 
 // ServiceBindingCondition condition information for a ServiceBinding.
@@ -1209,6 +1655,12 @@ const (
 	// ServiceBindingConditionFailed represents a ServiceBindingCondition that has failed
 	// completely and should not be retried.
 	ServiceBindingConditionFailed ServiceBindingConditionType = "Failed"
+
+	// ServiceBindingConditionCredentialsDrifted represents whether the last
+	// check of the credentials of a ServiceBinding found its Secret to differ
+	// from the credentials returned by the broker. The Secret is repaired
+	// when drift is found.
+	ServiceBindingConditionCredentialsDrifted ServiceBindingConditionType = "CredentialsDrifted"
 )
 
 // ServiceBindingOperation represents a type of operation
@@ -1250,6 +1702,18 @@ const (
 	FinalizerServiceCatalog string = "kubernetes-incubator/service-catalog"
 )
 
+// DisableParametersFromSyncAnnotation is the annotation that opts a
+// ServiceInstance out of being updated at the broker when the values of the
+// Secrets referenced by its ParametersFrom change. It only applies when the
+// ParametersFromSync feature is enabled.
+const DisableParametersFromSyncAnnotation string = "servicecatalog.k8s.io/disable-parameters-from-sync"
+
+// DefaultedParametersAnnotation is the annotation set by the
+// DefaultParameters admission plugin on the ServiceInstances it sets default
+// parameters on. Its value is the comma-separated list of the names of the
+// top-level parameters that were defaulted.
+const DefaultedParametersAnnotation string = "servicecatalog.k8s.io/defaulted-parameters"
+
 // ServiceBindingPropertiesState is the state of a
 // ServiceBinding that the ClusterServiceBroker knows about.
 type ServiceBindingPropertiesState struct {
@@ -1271,8 +1735,72 @@ type ParametersFromSource struct {
 	// The value must be a JSON object.
 	// +optional
 	SecretKeyRef *SecretKeyReference `json:"secretKeyRef,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// The key of the Secret of a ServiceBinding to select from. The
+	// parameters are only resolved once the ServiceBinding is Ready.
+	//
+	// Requires the CrossInstanceReferences feature.
+	// +optional
+	ServiceBindingSecretKeyRef *ServiceBindingSecretKeyReference `json:"serviceBindingSecretKeyRef,omitempty"`
+
+	// Currently, this field is ALPHA: it may change or disappear at any time
+	// and its data will not be migrated.
+	//
+	// The field of a ServiceInstance to select from. The parameters are only
+	// resolved once the ServiceInstance is Ready.
+	//
+	// Requires the CrossInstanceReferences feature.
+	// +optional
+	ServiceInstanceFieldRef *ServiceInstanceFieldReference `json:"serviceInstanceFieldRef,omitempty"`
+}
+
+// ServiceBindingSecretKeyReference references a key of the Secret of a
+// ServiceBinding.
+type ServiceBindingSecretKeyReference struct {
+	// The name of the ServiceBinding in the namespace of the resource.
+	Name string `json:"name"`
+	// The key of the Secret of the ServiceBinding to select from.
+	Key string `json:"key"`
+	// The name of the parameter to set to the value of the key. If empty,
+	// the value must be a JSON object, whose properties are added to the
+	// parameters.
+	// +optional
+	Parameter string `json:"parameter,omitempty"`
+}
+
+// ServiceInstanceFieldReference references a field of a ServiceInstance.
+type ServiceInstanceFieldReference struct {
+	// The name of the ServiceInstance in the namespace of the resource.
+	Name string `json:"name"`
+	// The path of the field to select, one of "spec.externalID",
+	// "status.dashboardURL", "status.externalProperties.parameters.NAME" or
+	// "status.brokerProperties.parameters.NAME". Parameters that were
+	// sourced from a Secret cannot be selected.
+	FieldPath string `json:"fieldPath"`
+	// The name of the parameter to set to the value of the field.
+	Parameter string `json:"parameter"`
 }
 
+// The fields of a ServiceInstance that a ServiceInstanceFieldReference can
+// select.
+const (
+	// ServiceInstanceFieldPathExternalID selects the external ID of the
+	// ServiceInstance.
+	ServiceInstanceFieldPathExternalID = "spec.externalID"
+	// ServiceInstanceFieldPathDashboardURL selects the dashboard URL of the
+	// ServiceInstance.
+	ServiceInstanceFieldPathDashboardURL = "status.dashboardURL"
+	// ServiceInstanceFieldPathExternalParameterPrefix is followed by the name
+	// of a parameter that was last sent to the broker.
+	ServiceInstanceFieldPathExternalParameterPrefix = "status.externalProperties.parameters."
+	// ServiceInstanceFieldPathBrokerParameterPrefix is followed by the name of
+	// a parameter that the broker reports.
+	ServiceInstanceFieldPathBrokerParameterPrefix = "status.brokerProperties.parameters."
+)
+
 // SecretKeyReference references a key of a Secret.
 type SecretKeyReference struct {
 	// The name of the secret in the pod's namespace to select from.
@@ -1314,6 +1842,8 @@ const (
 	FilterSpecExternalID = "spec.externalID"
 	// SpecClusterServiceClassName is only used for plans, the parent service class name.
 	FilterSpecClusterServiceClassName = "spec.clusterServiceClass.name"
+	// SpecServiceClassName is only used for plans, the parent service class name.
+	FilterSpecServiceClassName = "spec.serviceClass.name"
 )
 
 // SecretTransform is a single transformation that is applied to the
@@ -1340,6 +1870,9 @@ type SecretTransform struct {
 	AddKeysFrom *AddKeysFromTransform `json:"addKeysFrom,omitempty"`
 	// RemoveKey represents a transform that removes a credentials Secret entry
 	RemoveKey *RemoveKeyTransform `json:"removeKey,omitempty"`
+	// TemplateKey represents a transform that adds a key whose value is built
+	// from a template evaluated against the credentials
+	TemplateKey *TemplateKeyTransform `json:"templateKey,omitempty"`
 }
 
 // RenameKeyTransform specifies that one of the credentials keys returned
@@ -1400,3 +1933,268 @@ type RemoveKeyTransform struct {
 	// The key to remove from the Secret
 	Key string `json:"key"`
 }
+
+// TemplateKeyTransform specifies that Service Catalog should add an entry
+// to the Secret associated with the ServiceBinding, whose value is the
+// result of a template evaluated against the credentials.
+// Templates use the Go text/template syntax, and each credentials entry is
+// available as a field of the template's data.
+// For example, given the following credentials:
+//     {"username": "johndoe", "password": "secret", "host": "db", "port": 5432}
+// and the following TemplateKeyTransform:
+//     {"key": "DATABASE_URL", "template": "postgres://{{.username}}:{{.password | queryescape}}@{{.host}}:{{.port}}/orders"}
+// the following entry will appear in the Secret:
+//     "DATABASE_URL": "postgres://johndoe:secret@db:5432/orders"
+// Besides the text/template builtins, templates may use the b64enc, b64dec,
+// queryescape, pathescape, upper, lower, trim, quote, toJson, toEnv and
+// toProperties functions. toEnv and toProperties render a map of entries,
+// such as all the credentials ("{{toProperties .}}"), as the content of a
+// .env or .properties file. A template referencing a credentials entry that
+// does not exist fails. Templates may not invoke other templates, and may
+// only range, without nesting, over the credentials or one of their fields.
+// The template is evaluated against the credentials as transformed by the
+// transforms that precede it.
+type TemplateKeyTransform struct {
+	// The name of the key to add
+	Key string `json:"key"`
+	// The template whose result is stored in the Secret under the specified key
+	Template string `json:"template"`
+}
+
+// ConfigMapTemplate describes the ConfigMap that the controller creates, in
+// the namespace and under the name of the ServiceBinding, to expose a
+// non-sensitive subset of the credentials.
+// For example, given the following credentials:
+//     {"host": "db.example.com", "port": 5432, "password": "secret"}
+// and the following ConfigMapTemplate:
+//     {"keys": ["host", "port"]}
+// the ConfigMap will hold the following entries:
+//     "host": "db.example.com"
+//     "port": "5432"
+// Keys that are not present in the credentials are ignored.
+type ConfigMapTemplate struct {
+	// Keys are the keys of the credentials, after the SecretTransforms have
+	// been applied, that are copied into the ConfigMap.
+	Keys []string `json:"keys"`
+}
+
+// PodPresetTemplate describes the PodPreset that the controller creates, in
+// the namespace and under the name of the ServiceBinding, to inject the
+// credentials Secret into pods. At least one of EnvPrefix and MountPath must
+// be set.
+// For example, given the following PodPresetTemplate:
+//     {"selector": {"matchLabels": {"app": "web"}}, "envPrefix": "DB_"}
+// every key of the credentials Secret is exposed to the pods labeled
+// "app=web" as an environment variable prefixed with "DB_".
+type PodPresetTemplate struct {
+	// Selector is a label query over the pods the PodPreset applies to.
+	Selector metav1.LabelSelector `json:"selector"`
+
+	// EnvPrefix, if set, injects every key of the Secret as an environment
+	// variable whose name is the key prefixed with EnvPrefix. The prefix
+	// may be empty.
+	// +optional
+	EnvPrefix *string `json:"envPrefix,omitempty"`
+
+	// MountPath, if set, mounts the Secret as a volume at the given path.
+	// +optional
+	MountPath string `json:"mountPath,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterParameterDefaultList is a list of ClusterParameterDefaults.
+type ClusterParameterDefaultList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterParameterDefault `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterParameterDefault holds default values for the parameters of the
+// ServiceInstances of a ClusterServiceClass, or of one of its
+// ClusterServicePlans. The defaults are set on the instances that are created
+// without these parameters by the DefaultParameters admission plugin.
+// +k8s:openapi-gen=x-kubernetes-print-columns:custom-columns=NAME:.metadata.name,CLASS:.spec.clusterServiceClassExternalName,PLAN:.spec.clusterServicePlanExternalName
+type ClusterParameterDefault struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Non-namespaced.  The name of this resource in etcd is in ObjectMeta.Name.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the parameters to default, and the instances they are
+	// defaulted on.
+	// +optional
+	Spec ClusterParameterDefaultSpec `json:"spec,omitempty"`
+}
+
+// ClusterParameterDefaultSpec represents the default parameters of the
+// instances of a class or plan.
+type ClusterParameterDefaultSpec struct {
+	// ClusterServiceClassExternalName is the external name of the
+	// ClusterServiceClass whose instances are defaulted.
+	ClusterServiceClassExternalName string `json:"clusterServiceClassExternalName"`
+
+	// ClusterServicePlanExternalName is the external name of the
+	// ClusterServicePlan whose instances are defaulted. When it is empty, the
+	// instances of every plan of the class are defaulted.
+	// +optional
+	ClusterServicePlanExternalName string `json:"clusterServicePlanExternalName,omitempty"`
+
+	// Parameters is a JSON object holding the default value of each
+	// top-level parameter. The defaults of a plan take precedence over the
+	// defaults of its class, which take precedence over the defaults
+	// declared in the schemas of the plan.
+	//
+	// The Parameters field is NOT secret or secured in any way and should
+	// NEVER be used to hold sensitive information.
+	Parameters *runtime.RawExtension `json:"parameters"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceInstanceQuotaList is a list of ServiceInstanceQuotas.
+type ServiceInstanceQuotaList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ServiceInstanceQuota `json:"items"`
+}
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ServiceInstanceQuota limits the number of ServiceInstances of a namespace.
+// The limits are enforced when instances are created, or change plans, by the
+// ServiceInstanceQuota admission plugin, which charges every instance it
+// admits to the usage reported in the status of the quota.
+// +k8s:openapi-gen=x-kubernetes-print-columns:custom-columns=NAME:.metadata.name
+type ServiceInstanceQuota struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// The name of this resource in etcd is in ObjectMeta.Name.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the limits of the quota.
+	// +optional
+	Spec ServiceInstanceQuotaSpec `json:"spec,omitempty"`
+
+	// Status represents the number of instances counted against each limit.
+	// +optional
+	Status ServiceInstanceQuotaStatus `json:"status,omitempty"`
+}
+
+// ServiceInstanceQuotaSpec represents the limits of a ServiceInstanceQuota.
+type ServiceInstanceQuotaSpec struct {
+	// Limits are the limits on the number of instances of the namespace. An
+	// instance is only admitted when it stays within every limit that
+	// selects it.
+	Limits []ServiceInstanceQuotaLimit `json:"limits"`
+}
+
+// ServiceInstanceQuotaLimit limits the number of instances selected by their
+// class and plan. A limit without selectors applies to every instance of the
+// namespace.
+type ServiceInstanceQuotaLimit struct {
+	// Name identifies the limit within the quota.
+	Name string `json:"name"`
+
+	// ServiceClassExternalName selects the instances of the
+	// ClusterServiceClasses and ServiceClasses with this external name.
+	// +optional
+	ServiceClassExternalName string `json:"serviceClassExternalName,omitempty"`
+
+	// ServicePlanExternalName selects the instances of the plans with this
+	// external name. It requires ServiceClassExternalName.
+	// +optional
+	ServicePlanExternalName string `json:"servicePlanExternalName,omitempty"`
+
+	// Free selects the instances of the plans whose Free field has this
+	// value.
+	// +optional
+	Free *bool `json:"free,omitempty"`
+
+	// MaxInstances is the maximum number of instances the limit selects.
+	MaxInstances int64 `json:"maxInstances"`
+}
+
+// ServiceInstanceQuotaStatus represents the usage of a ServiceInstanceQuota.
+type ServiceInstanceQuotaStatus struct {
+	// Used is the number of instances each limit selects.
+	// +optional
+	Used []ServiceInstanceQuotaUsage `json:"used,omitempty"`
+}
+
+// ServiceInstanceQuotaUsage is the number of instances a limit selects.
+type ServiceInstanceQuotaUsage struct {
+	// Name is the name of the limit.
+	Name string `json:"name"`
+
+	// Instances is the number of instances the limit selects.
+	Instances int64 `json:"instances"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterServiceVisibilityPolicyList is a list of
+// ClusterServiceVisibilityPolicies.
+type ClusterServiceVisibilityPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ClusterServiceVisibilityPolicy `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// ClusterServiceVisibilityPolicy restricts the namespaces that a
+// ClusterServiceClass, or one of its ClusterServicePlans, is visible in. A
+// class or plan selected by policies is only visible in the namespaces that
+// one of them selects; the others are visible in every namespace. The
+// ServiceVisibilityPolicy admission plugin rejects the ServiceInstances of
+// the classes and plans that are not visible in their namespace.
+// +k8s:openapi-gen=x-kubernetes-print-columns:custom-columns=NAME:.metadata.name,CLASS:.spec.clusterServiceClassExternalName,PLAN:.spec.clusterServicePlanExternalName
+type ClusterServiceVisibilityPolicy struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// Non-namespaced.  The name of this resource in etcd is in ObjectMeta.Name.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the class or plan whose visibility is restricted, and the
+	// namespaces it is visible in.
+	// +optional
+	Spec ClusterServiceVisibilityPolicySpec `json:"spec,omitempty"`
+}
+
+// ClusterServiceVisibilityPolicySpec represents the namespaces a class or
+// plan is visible in.
+type ClusterServiceVisibilityPolicySpec struct {
+	// ClusterServiceClassExternalName is the external name of the
+	// ClusterServiceClass whose visibility is restricted.
+	ClusterServiceClassExternalName string `json:"clusterServiceClassExternalName"`
+
+	// ClusterServicePlanExternalName is the external name of the
+	// ClusterServicePlan whose visibility is restricted. When it is empty,
+	// the visibility of the class itself, and so of all its plans, is
+	// restricted.
+	// +optional
+	ClusterServicePlanExternalName string `json:"clusterServicePlanExternalName,omitempty"`
+
+	// NamespaceSelector selects the namespaces, by their labels, that the
+	// class or plan is visible in. An empty selector selects every
+	// namespace.
+	// +optional
+	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+}
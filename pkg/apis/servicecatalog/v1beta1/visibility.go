@@ -0,0 +1,55 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// ServiceVisible returns whether the plan with the given external name, of
+// the class with the given external name, is visible under the given policies
+// in a namespace with the given labels. An empty plan name stands for the
+// class itself.
+func ServiceVisible(policies []*ClusterServiceVisibilityPolicy, classExternalName, planExternalName string, namespaceLabels labels.Labels) bool {
+	var classRestricted, classVisible, planRestricted, planVisible bool
+	for _, policy := range policies {
+		if policy.Spec.ClusterServiceClassExternalName != classExternalName {
+			continue
+		}
+		switch policy.Spec.ClusterServicePlanExternalName {
+		case "":
+			classRestricted = true
+			classVisible = classVisible || policy.SelectsNamespace(namespaceLabels)
+		case planExternalName:
+			planRestricted = true
+			planVisible = planVisible || policy.SelectsNamespace(namespaceLabels)
+		}
+	}
+	return (!classRestricted || classVisible) && (!planRestricted || planVisible)
+}
+
+// SelectsNamespace returns whether the namespace selector of the policy
+// selects a namespace with the given labels. An invalid selector selects no
+// namespace.
+func (p *ClusterServiceVisibilityPolicy) SelectsNamespace(namespaceLabels labels.Labels) bool {
+	selector, err := metav1.LabelSelectorAsSelector(&p.Spec.NamespaceSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(namespaceLabels)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func newVisibilityPolicy(class, plan, env string) *ClusterServiceVisibilityPolicy {
+	return &ClusterServiceVisibilityPolicy{
+		Spec: ClusterServiceVisibilityPolicySpec{
+			ClusterServiceClassExternalName: class,
+			ClusterServicePlanExternalName:  plan,
+			NamespaceSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": env},
+			},
+		},
+	}
+}
+
+func TestServiceVisible(t *testing.T) {
+	prod := labels.Set{"env": "prod"}
+	dev := labels.Set{"env": "dev"}
+
+	testcases := []struct {
+		name      string
+		policies  []*ClusterServiceVisibilityPolicy
+		className string
+		planName  string
+		labels    labels.Set
+		want      bool
+	}{
+		{
+			name:      "no policies",
+			className: "db",
+			planName:  "small",
+			labels:    dev,
+			want:      true,
+		},
+		{
+			name:      "policy of another class",
+			policies:  []*ClusterServiceVisibilityPolicy{newVisibilityPolicy("queue", "", "prod")},
+			className: "db",
+			planName:  "small",
+			labels:    dev,
+			want:      true,
+		},
+		{
+			name:      "class visible",
+			policies:  []*ClusterServiceVisibilityPolicy{newVisibilityPolicy("db", "", "prod")},
+			className: "db",
+			labels:    prod,
+			want:      true,
+		},
+		{
+			name:      "class hidden",
+			policies:  []*ClusterServiceVisibilityPolicy{newVisibilityPolicy("db", "", "prod")},
+			className: "db",
+			labels:    dev,
+		},
+		{
+			name:      "plans of a hidden class are hidden",
+			policies:  []*ClusterServiceVisibilityPolicy{newVisibilityPolicy("db", "", "prod")},
+			className: "db",
+			planName:  "small",
+			labels:    dev,
+		},
+		{
+			name: "class visible through one of its policies",
+			policies: []*ClusterServiceVisibilityPolicy{
+				newVisibilityPolicy("db", "", "prod"),
+				newVisibilityPolicy("db", "", "dev"),
+			},
+			className: "db",
+			labels:    dev,
+			want:      true,
+		},
+		{
+			name:      "plan hidden",
+			policies:  []*ClusterServiceVisibilityPolicy{newVisibilityPolicy("db", "large", "prod")},
+			className: "db",
+			planName:  "large",
+			labels:    dev,
+		},
+		{
+			name:      "class of a hidden plan is visible",
+			policies:  []*ClusterServiceVisibilityPolicy{newVisibilityPolicy("db", "large", "prod")},
+			className: "db",
+			labels:    dev,
+			want:      true,
+		},
+		{
+			name:      "other plans of the class are visible",
+			policies:  []*ClusterServiceVisibilityPolicy{newVisibilityPolicy("db", "large", "prod")},
+			className: "db",
+			planName:  "small",
+			labels:    dev,
+			want:      true,
+		},
+		{
+			name: "plan visible in a namespace the class is hidden in",
+			policies: []*ClusterServiceVisibilityPolicy{
+				newVisibilityPolicy("db", "", "prod"),
+				newVisibilityPolicy("db", "small", "dev"),
+			},
+			className: "db",
+			planName:  "small",
+			labels:    dev,
+		},
+		{
+			name: "empty selector",
+			policies: []*ClusterServiceVisibilityPolicy{
+				{Spec: ClusterServiceVisibilityPolicySpec{ClusterServiceClassExternalName: "db"}},
+			},
+			className: "db",
+			planName:  "small",
+			labels:    dev,
+			want:      true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := ServiceVisible(tc.policies, tc.className, tc.planName, tc.labels); got != tc.want {
+				t.Fatalf("expected %v, got %v", tc.want, got)
+			}
+		})
+	}
+}
@@ -51,8 +51,16 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ClusterBasicAuthConfig_To_v1beta1_ClusterBasicAuthConfig,
 		Convert_v1beta1_ClusterBearerTokenAuthConfig_To_servicecatalog_ClusterBearerTokenAuthConfig,
 		Convert_servicecatalog_ClusterBearerTokenAuthConfig_To_v1beta1_ClusterBearerTokenAuthConfig,
+		Convert_v1beta1_ClusterOAuth2AuthConfig_To_servicecatalog_ClusterOAuth2AuthConfig,
+		Convert_servicecatalog_ClusterOAuth2AuthConfig_To_v1beta1_ClusterOAuth2AuthConfig,
 		Convert_v1beta1_ClusterObjectReference_To_servicecatalog_ClusterObjectReference,
 		Convert_servicecatalog_ClusterObjectReference_To_v1beta1_ClusterObjectReference,
+		Convert_v1beta1_ClusterParameterDefault_To_servicecatalog_ClusterParameterDefault,
+		Convert_servicecatalog_ClusterParameterDefault_To_v1beta1_ClusterParameterDefault,
+		Convert_v1beta1_ClusterParameterDefaultList_To_servicecatalog_ClusterParameterDefaultList,
+		Convert_servicecatalog_ClusterParameterDefaultList_To_v1beta1_ClusterParameterDefaultList,
+		Convert_v1beta1_ClusterParameterDefaultSpec_To_servicecatalog_ClusterParameterDefaultSpec,
+		Convert_servicecatalog_ClusterParameterDefaultSpec_To_v1beta1_ClusterParameterDefaultSpec,
 		Convert_v1beta1_ClusterServiceBroker_To_servicecatalog_ClusterServiceBroker,
 		Convert_servicecatalog_ClusterServiceBroker_To_v1beta1_ClusterServiceBroker,
 		Convert_v1beta1_ClusterServiceBrokerAuthInfo_To_servicecatalog_ClusterServiceBrokerAuthInfo,
@@ -79,6 +87,14 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ClusterServicePlanSpec_To_v1beta1_ClusterServicePlanSpec,
 		Convert_v1beta1_ClusterServicePlanStatus_To_servicecatalog_ClusterServicePlanStatus,
 		Convert_servicecatalog_ClusterServicePlanStatus_To_v1beta1_ClusterServicePlanStatus,
+		Convert_v1beta1_ClusterServiceVisibilityPolicy_To_servicecatalog_ClusterServiceVisibilityPolicy,
+		Convert_servicecatalog_ClusterServiceVisibilityPolicy_To_v1beta1_ClusterServiceVisibilityPolicy,
+		Convert_v1beta1_ClusterServiceVisibilityPolicyList_To_servicecatalog_ClusterServiceVisibilityPolicyList,
+		Convert_servicecatalog_ClusterServiceVisibilityPolicyList_To_v1beta1_ClusterServiceVisibilityPolicyList,
+		Convert_v1beta1_ClusterServiceVisibilityPolicySpec_To_servicecatalog_ClusterServiceVisibilityPolicySpec,
+		Convert_servicecatalog_ClusterServiceVisibilityPolicySpec_To_v1beta1_ClusterServiceVisibilityPolicySpec,
+		Convert_v1beta1_ClusterTLSAuthConfig_To_servicecatalog_ClusterTLSAuthConfig,
+		Convert_servicecatalog_ClusterTLSAuthConfig_To_v1beta1_ClusterTLSAuthConfig,
 		Convert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonServiceBrokerSpec,
 		Convert_servicecatalog_CommonServiceBrokerSpec_To_v1beta1_CommonServiceBrokerSpec,
 		Convert_v1beta1_CommonServiceBrokerStatus_To_servicecatalog_CommonServiceBrokerStatus,
@@ -91,14 +107,24 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_CommonServicePlanSpec_To_v1beta1_CommonServicePlanSpec,
 		Convert_v1beta1_CommonServicePlanStatus_To_servicecatalog_CommonServicePlanStatus,
 		Convert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlanStatus,
+		Convert_v1beta1_ConfigMapTemplate_To_servicecatalog_ConfigMapTemplate,
+		Convert_servicecatalog_ConfigMapTemplate_To_v1beta1_ConfigMapTemplate,
 		Convert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference,
 		Convert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference,
+		Convert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo,
+		Convert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo,
+		Convert_v1beta1_OAuth2AuthConfig_To_servicecatalog_OAuth2AuthConfig,
+		Convert_servicecatalog_OAuth2AuthConfig_To_v1beta1_OAuth2AuthConfig,
 		Convert_v1beta1_ObjectReference_To_servicecatalog_ObjectReference,
 		Convert_servicecatalog_ObjectReference_To_v1beta1_ObjectReference,
 		Convert_v1beta1_ParametersFromSource_To_servicecatalog_ParametersFromSource,
 		Convert_servicecatalog_ParametersFromSource_To_v1beta1_ParametersFromSource,
+		Convert_v1beta1_PlanMigrationPolicy_To_servicecatalog_PlanMigrationPolicy,
+		Convert_servicecatalog_PlanMigrationPolicy_To_v1beta1_PlanMigrationPolicy,
 		Convert_v1beta1_PlanReference_To_servicecatalog_PlanReference,
 		Convert_servicecatalog_PlanReference_To_v1beta1_PlanReference,
+		Convert_v1beta1_PodPresetTemplate_To_servicecatalog_PodPresetTemplate,
+		Convert_servicecatalog_PodPresetTemplate_To_v1beta1_PodPresetTemplate,
 		Convert_v1beta1_RemoveKeyTransform_To_servicecatalog_RemoveKeyTransform,
 		Convert_servicecatalog_RemoveKeyTransform_To_v1beta1_RemoveKeyTransform,
 		Convert_v1beta1_RenameKeyTransform_To_servicecatalog_RenameKeyTransform,
@@ -115,6 +141,8 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ServiceBindingList_To_v1beta1_ServiceBindingList,
 		Convert_v1beta1_ServiceBindingPropertiesState_To_servicecatalog_ServiceBindingPropertiesState,
 		Convert_servicecatalog_ServiceBindingPropertiesState_To_v1beta1_ServiceBindingPropertiesState,
+		Convert_v1beta1_ServiceBindingSecretKeyReference_To_servicecatalog_ServiceBindingSecretKeyReference,
+		Convert_servicecatalog_ServiceBindingSecretKeyReference_To_v1beta1_ServiceBindingSecretKeyReference,
 		Convert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec,
 		Convert_servicecatalog_ServiceBindingSpec_To_v1beta1_ServiceBindingSpec,
 		Convert_v1beta1_ServiceBindingStatus_To_servicecatalog_ServiceBindingStatus,
@@ -127,6 +155,8 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ServiceBrokerCondition_To_v1beta1_ServiceBrokerCondition,
 		Convert_v1beta1_ServiceBrokerList_To_servicecatalog_ServiceBrokerList,
 		Convert_servicecatalog_ServiceBrokerList_To_v1beta1_ServiceBrokerList,
+		Convert_v1beta1_ServiceBrokerRateLimits_To_servicecatalog_ServiceBrokerRateLimits,
+		Convert_servicecatalog_ServiceBrokerRateLimits_To_v1beta1_ServiceBrokerRateLimits,
 		Convert_v1beta1_ServiceBrokerSpec_To_servicecatalog_ServiceBrokerSpec,
 		Convert_servicecatalog_ServiceBrokerSpec_To_v1beta1_ServiceBrokerSpec,
 		Convert_v1beta1_ServiceBrokerStatus_To_servicecatalog_ServiceBrokerStatus,
@@ -141,12 +171,32 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ServiceClassStatus_To_v1beta1_ServiceClassStatus,
 		Convert_v1beta1_ServiceInstance_To_servicecatalog_ServiceInstance,
 		Convert_servicecatalog_ServiceInstance_To_v1beta1_ServiceInstance,
+		Convert_v1beta1_ServiceInstanceAdoption_To_servicecatalog_ServiceInstanceAdoption,
+		Convert_servicecatalog_ServiceInstanceAdoption_To_v1beta1_ServiceInstanceAdoption,
+		Convert_v1beta1_ServiceInstanceBrokerProperties_To_servicecatalog_ServiceInstanceBrokerProperties,
+		Convert_servicecatalog_ServiceInstanceBrokerProperties_To_v1beta1_ServiceInstanceBrokerProperties,
 		Convert_v1beta1_ServiceInstanceCondition_To_servicecatalog_ServiceInstanceCondition,
 		Convert_servicecatalog_ServiceInstanceCondition_To_v1beta1_ServiceInstanceCondition,
+		Convert_v1beta1_ServiceInstanceFieldReference_To_servicecatalog_ServiceInstanceFieldReference,
+		Convert_servicecatalog_ServiceInstanceFieldReference_To_v1beta1_ServiceInstanceFieldReference,
 		Convert_v1beta1_ServiceInstanceList_To_servicecatalog_ServiceInstanceList,
 		Convert_servicecatalog_ServiceInstanceList_To_v1beta1_ServiceInstanceList,
+		Convert_v1beta1_ServiceInstancePlanMigration_To_servicecatalog_ServiceInstancePlanMigration,
+		Convert_servicecatalog_ServiceInstancePlanMigration_To_v1beta1_ServiceInstancePlanMigration,
 		Convert_v1beta1_ServiceInstancePropertiesState_To_servicecatalog_ServiceInstancePropertiesState,
 		Convert_servicecatalog_ServiceInstancePropertiesState_To_v1beta1_ServiceInstancePropertiesState,
+		Convert_v1beta1_ServiceInstanceQuota_To_servicecatalog_ServiceInstanceQuota,
+		Convert_servicecatalog_ServiceInstanceQuota_To_v1beta1_ServiceInstanceQuota,
+		Convert_v1beta1_ServiceInstanceQuotaLimit_To_servicecatalog_ServiceInstanceQuotaLimit,
+		Convert_servicecatalog_ServiceInstanceQuotaLimit_To_v1beta1_ServiceInstanceQuotaLimit,
+		Convert_v1beta1_ServiceInstanceQuotaList_To_servicecatalog_ServiceInstanceQuotaList,
+		Convert_servicecatalog_ServiceInstanceQuotaList_To_v1beta1_ServiceInstanceQuotaList,
+		Convert_v1beta1_ServiceInstanceQuotaSpec_To_servicecatalog_ServiceInstanceQuotaSpec,
+		Convert_servicecatalog_ServiceInstanceQuotaSpec_To_v1beta1_ServiceInstanceQuotaSpec,
+		Convert_v1beta1_ServiceInstanceQuotaStatus_To_servicecatalog_ServiceInstanceQuotaStatus,
+		Convert_servicecatalog_ServiceInstanceQuotaStatus_To_v1beta1_ServiceInstanceQuotaStatus,
+		Convert_v1beta1_ServiceInstanceQuotaUsage_To_servicecatalog_ServiceInstanceQuotaUsage,
+		Convert_servicecatalog_ServiceInstanceQuotaUsage_To_v1beta1_ServiceInstanceQuotaUsage,
 		Convert_v1beta1_ServiceInstanceSpec_To_servicecatalog_ServiceInstanceSpec,
 		Convert_servicecatalog_ServiceInstanceSpec_To_v1beta1_ServiceInstanceSpec,
 		Convert_v1beta1_ServiceInstanceStatus_To_servicecatalog_ServiceInstanceStatus,
@@ -159,6 +209,10 @@ func RegisterConversions(scheme *runtime.Scheme) error {
 		Convert_servicecatalog_ServicePlanSpec_To_v1beta1_ServicePlanSpec,
 		Convert_v1beta1_ServicePlanStatus_To_servicecatalog_ServicePlanStatus,
 		Convert_servicecatalog_ServicePlanStatus_To_v1beta1_ServicePlanStatus,
+		Convert_v1beta1_TLSAuthConfig_To_servicecatalog_TLSAuthConfig,
+		Convert_servicecatalog_TLSAuthConfig_To_v1beta1_TLSAuthConfig,
+		Convert_v1beta1_TemplateKeyTransform_To_servicecatalog_TemplateKeyTransform,
+		Convert_servicecatalog_TemplateKeyTransform_To_v1beta1_TemplateKeyTransform,
 		Convert_v1beta1_UserInfo_To_servicecatalog_UserInfo,
 		Convert_servicecatalog_UserInfo_To_v1beta1_UserInfo,
 	)
@@ -312,6 +366,30 @@ func Convert_servicecatalog_ClusterBearerTokenAuthConfig_To_v1beta1_ClusterBeare
 	return autoConvert_servicecatalog_ClusterBearerTokenAuthConfig_To_v1beta1_ClusterBearerTokenAuthConfig(in, out, s)
 }
 
+func autoConvert_v1beta1_ClusterOAuth2AuthConfig_To_servicecatalog_ClusterOAuth2AuthConfig(in *ClusterOAuth2AuthConfig, out *servicecatalog.ClusterOAuth2AuthConfig, s conversion.Scope) error {
+	out.TokenURL = in.TokenURL
+	out.SecretRef = (*servicecatalog.ObjectReference)(unsafe.Pointer(in.SecretRef))
+	out.Scopes = *(*[]string)(unsafe.Pointer(&in.Scopes))
+	return nil
+}
+
+// Convert_v1beta1_ClusterOAuth2AuthConfig_To_servicecatalog_ClusterOAuth2AuthConfig is an autogenerated conversion function.
+func Convert_v1beta1_ClusterOAuth2AuthConfig_To_servicecatalog_ClusterOAuth2AuthConfig(in *ClusterOAuth2AuthConfig, out *servicecatalog.ClusterOAuth2AuthConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterOAuth2AuthConfig_To_servicecatalog_ClusterOAuth2AuthConfig(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterOAuth2AuthConfig_To_v1beta1_ClusterOAuth2AuthConfig(in *servicecatalog.ClusterOAuth2AuthConfig, out *ClusterOAuth2AuthConfig, s conversion.Scope) error {
+	out.TokenURL = in.TokenURL
+	out.SecretRef = (*ObjectReference)(unsafe.Pointer(in.SecretRef))
+	out.Scopes = *(*[]string)(unsafe.Pointer(&in.Scopes))
+	return nil
+}
+
+// Convert_servicecatalog_ClusterOAuth2AuthConfig_To_v1beta1_ClusterOAuth2AuthConfig is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterOAuth2AuthConfig_To_v1beta1_ClusterOAuth2AuthConfig(in *servicecatalog.ClusterOAuth2AuthConfig, out *ClusterOAuth2AuthConfig, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterOAuth2AuthConfig_To_v1beta1_ClusterOAuth2AuthConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_ClusterObjectReference_To_servicecatalog_ClusterObjectReference(in *ClusterObjectReference, out *servicecatalog.ClusterObjectReference, s conversion.Scope) error {
 	out.Name = in.Name
 	return nil
@@ -332,6 +410,78 @@ func Convert_servicecatalog_ClusterObjectReference_To_v1beta1_ClusterObjectRefer
 	return autoConvert_servicecatalog_ClusterObjectReference_To_v1beta1_ClusterObjectReference(in, out, s)
 }
 
+func autoConvert_v1beta1_ClusterParameterDefault_To_servicecatalog_ClusterParameterDefault(in *ClusterParameterDefault, out *servicecatalog.ClusterParameterDefault, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_ClusterParameterDefaultSpec_To_servicecatalog_ClusterParameterDefaultSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ClusterParameterDefault_To_servicecatalog_ClusterParameterDefault is an autogenerated conversion function.
+func Convert_v1beta1_ClusterParameterDefault_To_servicecatalog_ClusterParameterDefault(in *ClusterParameterDefault, out *servicecatalog.ClusterParameterDefault, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterParameterDefault_To_servicecatalog_ClusterParameterDefault(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterParameterDefault_To_v1beta1_ClusterParameterDefault(in *servicecatalog.ClusterParameterDefault, out *ClusterParameterDefault, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_servicecatalog_ClusterParameterDefaultSpec_To_v1beta1_ClusterParameterDefaultSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_servicecatalog_ClusterParameterDefault_To_v1beta1_ClusterParameterDefault is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterParameterDefault_To_v1beta1_ClusterParameterDefault(in *servicecatalog.ClusterParameterDefault, out *ClusterParameterDefault, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterParameterDefault_To_v1beta1_ClusterParameterDefault(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterParameterDefaultList_To_servicecatalog_ClusterParameterDefaultList(in *ClusterParameterDefaultList, out *servicecatalog.ClusterParameterDefaultList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]servicecatalog.ClusterParameterDefault)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_ClusterParameterDefaultList_To_servicecatalog_ClusterParameterDefaultList is an autogenerated conversion function.
+func Convert_v1beta1_ClusterParameterDefaultList_To_servicecatalog_ClusterParameterDefaultList(in *ClusterParameterDefaultList, out *servicecatalog.ClusterParameterDefaultList, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterParameterDefaultList_To_servicecatalog_ClusterParameterDefaultList(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterParameterDefaultList_To_v1beta1_ClusterParameterDefaultList(in *servicecatalog.ClusterParameterDefaultList, out *ClusterParameterDefaultList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]ClusterParameterDefault)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_servicecatalog_ClusterParameterDefaultList_To_v1beta1_ClusterParameterDefaultList is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterParameterDefaultList_To_v1beta1_ClusterParameterDefaultList(in *servicecatalog.ClusterParameterDefaultList, out *ClusterParameterDefaultList, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterParameterDefaultList_To_v1beta1_ClusterParameterDefaultList(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterParameterDefaultSpec_To_servicecatalog_ClusterParameterDefaultSpec(in *ClusterParameterDefaultSpec, out *servicecatalog.ClusterParameterDefaultSpec, s conversion.Scope) error {
+	out.ClusterServiceClassExternalName = in.ClusterServiceClassExternalName
+	out.ClusterServicePlanExternalName = in.ClusterServicePlanExternalName
+	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
+	return nil
+}
+
+// Convert_v1beta1_ClusterParameterDefaultSpec_To_servicecatalog_ClusterParameterDefaultSpec is an autogenerated conversion function.
+func Convert_v1beta1_ClusterParameterDefaultSpec_To_servicecatalog_ClusterParameterDefaultSpec(in *ClusterParameterDefaultSpec, out *servicecatalog.ClusterParameterDefaultSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterParameterDefaultSpec_To_servicecatalog_ClusterParameterDefaultSpec(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterParameterDefaultSpec_To_v1beta1_ClusterParameterDefaultSpec(in *servicecatalog.ClusterParameterDefaultSpec, out *ClusterParameterDefaultSpec, s conversion.Scope) error {
+	out.ClusterServiceClassExternalName = in.ClusterServiceClassExternalName
+	out.ClusterServicePlanExternalName = in.ClusterServicePlanExternalName
+	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
+	return nil
+}
+
+// Convert_servicecatalog_ClusterParameterDefaultSpec_To_v1beta1_ClusterParameterDefaultSpec is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterParameterDefaultSpec_To_v1beta1_ClusterParameterDefaultSpec(in *servicecatalog.ClusterParameterDefaultSpec, out *ClusterParameterDefaultSpec, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterParameterDefaultSpec_To_v1beta1_ClusterParameterDefaultSpec(in, out, s)
+}
+
 func autoConvert_v1beta1_ClusterServiceBroker_To_servicecatalog_ClusterServiceBroker(in *ClusterServiceBroker, out *servicecatalog.ClusterServiceBroker, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1beta1_ClusterServiceBrokerSpec_To_servicecatalog_ClusterServiceBrokerSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -367,6 +517,8 @@ func Convert_servicecatalog_ClusterServiceBroker_To_v1beta1_ClusterServiceBroker
 func autoConvert_v1beta1_ClusterServiceBrokerAuthInfo_To_servicecatalog_ClusterServiceBrokerAuthInfo(in *ClusterServiceBrokerAuthInfo, out *servicecatalog.ClusterServiceBrokerAuthInfo, s conversion.Scope) error {
 	out.Basic = (*servicecatalog.ClusterBasicAuthConfig)(unsafe.Pointer(in.Basic))
 	out.Bearer = (*servicecatalog.ClusterBearerTokenAuthConfig)(unsafe.Pointer(in.Bearer))
+	out.TLS = (*servicecatalog.ClusterTLSAuthConfig)(unsafe.Pointer(in.TLS))
+	out.OAuth2 = (*servicecatalog.ClusterOAuth2AuthConfig)(unsafe.Pointer(in.OAuth2))
 	return nil
 }
 
@@ -378,6 +530,8 @@ func Convert_v1beta1_ClusterServiceBrokerAuthInfo_To_servicecatalog_ClusterServi
 func autoConvert_servicecatalog_ClusterServiceBrokerAuthInfo_To_v1beta1_ClusterServiceBrokerAuthInfo(in *servicecatalog.ClusterServiceBrokerAuthInfo, out *ClusterServiceBrokerAuthInfo, s conversion.Scope) error {
 	out.Basic = (*ClusterBasicAuthConfig)(unsafe.Pointer(in.Basic))
 	out.Bearer = (*ClusterBearerTokenAuthConfig)(unsafe.Pointer(in.Bearer))
+	out.TLS = (*ClusterTLSAuthConfig)(unsafe.Pointer(in.TLS))
+	out.OAuth2 = (*ClusterOAuth2AuthConfig)(unsafe.Pointer(in.OAuth2))
 	return nil
 }
 
@@ -624,6 +778,7 @@ func autoConvert_v1beta1_ClusterServicePlanSpec_To_servicecatalog_ClusterService
 	if err := Convert_v1beta1_ClusterObjectReference_To_servicecatalog_ClusterObjectReference(&in.ClusterServiceClassRef, &out.ClusterServiceClassRef, s); err != nil {
 		return err
 	}
+	out.MigrationPolicy = (*servicecatalog.PlanMigrationPolicy)(unsafe.Pointer(in.MigrationPolicy))
 	return nil
 }
 
@@ -640,6 +795,7 @@ func autoConvert_servicecatalog_ClusterServicePlanSpec_To_v1beta1_ClusterService
 	if err := Convert_servicecatalog_ClusterObjectReference_To_v1beta1_ClusterObjectReference(&in.ClusterServiceClassRef, &out.ClusterServiceClassRef, s); err != nil {
 		return err
 	}
+	out.MigrationPolicy = (*PlanMigrationPolicy)(unsafe.Pointer(in.MigrationPolicy))
 	return nil
 }
 
@@ -672,6 +828,98 @@ func Convert_servicecatalog_ClusterServicePlanStatus_To_v1beta1_ClusterServicePl
 	return autoConvert_servicecatalog_ClusterServicePlanStatus_To_v1beta1_ClusterServicePlanStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_ClusterServiceVisibilityPolicy_To_servicecatalog_ClusterServiceVisibilityPolicy(in *ClusterServiceVisibilityPolicy, out *servicecatalog.ClusterServiceVisibilityPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_ClusterServiceVisibilityPolicySpec_To_servicecatalog_ClusterServiceVisibilityPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ClusterServiceVisibilityPolicy_To_servicecatalog_ClusterServiceVisibilityPolicy is an autogenerated conversion function.
+func Convert_v1beta1_ClusterServiceVisibilityPolicy_To_servicecatalog_ClusterServiceVisibilityPolicy(in *ClusterServiceVisibilityPolicy, out *servicecatalog.ClusterServiceVisibilityPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterServiceVisibilityPolicy_To_servicecatalog_ClusterServiceVisibilityPolicy(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterServiceVisibilityPolicy_To_v1beta1_ClusterServiceVisibilityPolicy(in *servicecatalog.ClusterServiceVisibilityPolicy, out *ClusterServiceVisibilityPolicy, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_servicecatalog_ClusterServiceVisibilityPolicySpec_To_v1beta1_ClusterServiceVisibilityPolicySpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_servicecatalog_ClusterServiceVisibilityPolicy_To_v1beta1_ClusterServiceVisibilityPolicy is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterServiceVisibilityPolicy_To_v1beta1_ClusterServiceVisibilityPolicy(in *servicecatalog.ClusterServiceVisibilityPolicy, out *ClusterServiceVisibilityPolicy, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterServiceVisibilityPolicy_To_v1beta1_ClusterServiceVisibilityPolicy(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterServiceVisibilityPolicyList_To_servicecatalog_ClusterServiceVisibilityPolicyList(in *ClusterServiceVisibilityPolicyList, out *servicecatalog.ClusterServiceVisibilityPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]servicecatalog.ClusterServiceVisibilityPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_ClusterServiceVisibilityPolicyList_To_servicecatalog_ClusterServiceVisibilityPolicyList is an autogenerated conversion function.
+func Convert_v1beta1_ClusterServiceVisibilityPolicyList_To_servicecatalog_ClusterServiceVisibilityPolicyList(in *ClusterServiceVisibilityPolicyList, out *servicecatalog.ClusterServiceVisibilityPolicyList, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterServiceVisibilityPolicyList_To_servicecatalog_ClusterServiceVisibilityPolicyList(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterServiceVisibilityPolicyList_To_v1beta1_ClusterServiceVisibilityPolicyList(in *servicecatalog.ClusterServiceVisibilityPolicyList, out *ClusterServiceVisibilityPolicyList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]ClusterServiceVisibilityPolicy)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_servicecatalog_ClusterServiceVisibilityPolicyList_To_v1beta1_ClusterServiceVisibilityPolicyList is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterServiceVisibilityPolicyList_To_v1beta1_ClusterServiceVisibilityPolicyList(in *servicecatalog.ClusterServiceVisibilityPolicyList, out *ClusterServiceVisibilityPolicyList, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterServiceVisibilityPolicyList_To_v1beta1_ClusterServiceVisibilityPolicyList(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterServiceVisibilityPolicySpec_To_servicecatalog_ClusterServiceVisibilityPolicySpec(in *ClusterServiceVisibilityPolicySpec, out *servicecatalog.ClusterServiceVisibilityPolicySpec, s conversion.Scope) error {
+	out.ClusterServiceClassExternalName = in.ClusterServiceClassExternalName
+	out.ClusterServicePlanExternalName = in.ClusterServicePlanExternalName
+	out.NamespaceSelector = in.NamespaceSelector
+	return nil
+}
+
+// Convert_v1beta1_ClusterServiceVisibilityPolicySpec_To_servicecatalog_ClusterServiceVisibilityPolicySpec is an autogenerated conversion function.
+func Convert_v1beta1_ClusterServiceVisibilityPolicySpec_To_servicecatalog_ClusterServiceVisibilityPolicySpec(in *ClusterServiceVisibilityPolicySpec, out *servicecatalog.ClusterServiceVisibilityPolicySpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterServiceVisibilityPolicySpec_To_servicecatalog_ClusterServiceVisibilityPolicySpec(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterServiceVisibilityPolicySpec_To_v1beta1_ClusterServiceVisibilityPolicySpec(in *servicecatalog.ClusterServiceVisibilityPolicySpec, out *ClusterServiceVisibilityPolicySpec, s conversion.Scope) error {
+	out.ClusterServiceClassExternalName = in.ClusterServiceClassExternalName
+	out.ClusterServicePlanExternalName = in.ClusterServicePlanExternalName
+	out.NamespaceSelector = in.NamespaceSelector
+	return nil
+}
+
+// Convert_servicecatalog_ClusterServiceVisibilityPolicySpec_To_v1beta1_ClusterServiceVisibilityPolicySpec is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterServiceVisibilityPolicySpec_To_v1beta1_ClusterServiceVisibilityPolicySpec(in *servicecatalog.ClusterServiceVisibilityPolicySpec, out *ClusterServiceVisibilityPolicySpec, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterServiceVisibilityPolicySpec_To_v1beta1_ClusterServiceVisibilityPolicySpec(in, out, s)
+}
+
+func autoConvert_v1beta1_ClusterTLSAuthConfig_To_servicecatalog_ClusterTLSAuthConfig(in *ClusterTLSAuthConfig, out *servicecatalog.ClusterTLSAuthConfig, s conversion.Scope) error {
+	out.SecretRef = (*servicecatalog.ObjectReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_v1beta1_ClusterTLSAuthConfig_To_servicecatalog_ClusterTLSAuthConfig is an autogenerated conversion function.
+func Convert_v1beta1_ClusterTLSAuthConfig_To_servicecatalog_ClusterTLSAuthConfig(in *ClusterTLSAuthConfig, out *servicecatalog.ClusterTLSAuthConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_ClusterTLSAuthConfig_To_servicecatalog_ClusterTLSAuthConfig(in, out, s)
+}
+
+func autoConvert_servicecatalog_ClusterTLSAuthConfig_To_v1beta1_ClusterTLSAuthConfig(in *servicecatalog.ClusterTLSAuthConfig, out *ClusterTLSAuthConfig, s conversion.Scope) error {
+	out.SecretRef = (*ObjectReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_servicecatalog_ClusterTLSAuthConfig_To_v1beta1_ClusterTLSAuthConfig is an autogenerated conversion function.
+func Convert_servicecatalog_ClusterTLSAuthConfig_To_v1beta1_ClusterTLSAuthConfig(in *servicecatalog.ClusterTLSAuthConfig, out *ClusterTLSAuthConfig, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ClusterTLSAuthConfig_To_v1beta1_ClusterTLSAuthConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonServiceBrokerSpec(in *CommonServiceBrokerSpec, out *servicecatalog.CommonServiceBrokerSpec, s conversion.Scope) error {
 	out.URL = in.URL
 	out.InsecureSkipTLSVerify = in.InsecureSkipTLSVerify
@@ -680,6 +928,7 @@ func autoConvert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonService
 	out.RelistDuration = (*v1.Duration)(unsafe.Pointer(in.RelistDuration))
 	out.RelistRequests = in.RelistRequests
 	out.CatalogRestrictions = (*servicecatalog.CatalogRestrictions)(unsafe.Pointer(in.CatalogRestrictions))
+	out.RateLimits = (*servicecatalog.ServiceBrokerRateLimits)(unsafe.Pointer(in.RateLimits))
 	return nil
 }
 
@@ -696,6 +945,7 @@ func autoConvert_servicecatalog_CommonServiceBrokerSpec_To_v1beta1_CommonService
 	out.RelistDuration = (*v1.Duration)(unsafe.Pointer(in.RelistDuration))
 	out.RelistRequests = in.RelistRequests
 	out.CatalogRestrictions = (*CatalogRestrictions)(unsafe.Pointer(in.CatalogRestrictions))
+	out.RateLimits = (*ServiceBrokerRateLimits)(unsafe.Pointer(in.RateLimits))
 	return nil
 }
 
@@ -736,6 +986,7 @@ func autoConvert_v1beta1_CommonServiceClassSpec_To_servicecatalog_CommonServiceC
 	out.Description = in.Description
 	out.Bindable = in.Bindable
 	out.BindingRetrievable = in.BindingRetrievable
+	out.InstancesRetrievable = in.InstancesRetrievable
 	out.PlanUpdatable = in.PlanUpdatable
 	out.ExternalMetadata = (*runtime.RawExtension)(unsafe.Pointer(in.ExternalMetadata))
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
@@ -754,6 +1005,7 @@ func autoConvert_servicecatalog_CommonServiceClassSpec_To_v1beta1_CommonServiceC
 	out.Description = in.Description
 	out.Bindable = in.Bindable
 	out.BindingRetrievable = in.BindingRetrievable
+	out.InstancesRetrievable = in.InstancesRetrievable
 	out.PlanUpdatable = in.PlanUpdatable
 	out.ExternalMetadata = (*runtime.RawExtension)(unsafe.Pointer(in.ExternalMetadata))
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
@@ -797,6 +1049,7 @@ func autoConvert_v1beta1_CommonServicePlanSpec_To_servicecatalog_CommonServicePl
 	out.ServiceInstanceUpdateParameterSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceInstanceUpdateParameterSchema))
 	out.ServiceBindingCreateParameterSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateParameterSchema))
 	out.ServiceBindingCreateResponseSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateResponseSchema))
+	out.MaintenanceInfo = (*servicecatalog.MaintenanceInfo)(unsafe.Pointer(in.MaintenanceInfo))
 	return nil
 }
 
@@ -816,6 +1069,7 @@ func autoConvert_servicecatalog_CommonServicePlanSpec_To_v1beta1_CommonServicePl
 	out.ServiceInstanceUpdateParameterSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceInstanceUpdateParameterSchema))
 	out.ServiceBindingCreateParameterSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateParameterSchema))
 	out.ServiceBindingCreateResponseSchema = (*runtime.RawExtension)(unsafe.Pointer(in.ServiceBindingCreateResponseSchema))
+	out.MaintenanceInfo = (*MaintenanceInfo)(unsafe.Pointer(in.MaintenanceInfo))
 	return nil
 }
 
@@ -844,6 +1098,26 @@ func Convert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlan
 	return autoConvert_servicecatalog_CommonServicePlanStatus_To_v1beta1_CommonServicePlanStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_ConfigMapTemplate_To_servicecatalog_ConfigMapTemplate(in *ConfigMapTemplate, out *servicecatalog.ConfigMapTemplate, s conversion.Scope) error {
+	out.Keys = *(*[]string)(unsafe.Pointer(&in.Keys))
+	return nil
+}
+
+// Convert_v1beta1_ConfigMapTemplate_To_servicecatalog_ConfigMapTemplate is an autogenerated conversion function.
+func Convert_v1beta1_ConfigMapTemplate_To_servicecatalog_ConfigMapTemplate(in *ConfigMapTemplate, out *servicecatalog.ConfigMapTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_ConfigMapTemplate_To_servicecatalog_ConfigMapTemplate(in, out, s)
+}
+
+func autoConvert_servicecatalog_ConfigMapTemplate_To_v1beta1_ConfigMapTemplate(in *servicecatalog.ConfigMapTemplate, out *ConfigMapTemplate, s conversion.Scope) error {
+	out.Keys = *(*[]string)(unsafe.Pointer(&in.Keys))
+	return nil
+}
+
+// Convert_servicecatalog_ConfigMapTemplate_To_v1beta1_ConfigMapTemplate is an autogenerated conversion function.
+func Convert_servicecatalog_ConfigMapTemplate_To_v1beta1_ConfigMapTemplate(in *servicecatalog.ConfigMapTemplate, out *ConfigMapTemplate, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ConfigMapTemplate_To_v1beta1_ConfigMapTemplate(in, out, s)
+}
+
 func autoConvert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference(in *LocalObjectReference, out *servicecatalog.LocalObjectReference, s conversion.Scope) error {
 	out.Name = in.Name
 	return nil
@@ -864,6 +1138,52 @@ func Convert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference
 	return autoConvert_servicecatalog_LocalObjectReference_To_v1beta1_LocalObjectReference(in, out, s)
 }
 
+func autoConvert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo(in *MaintenanceInfo, out *servicecatalog.MaintenanceInfo, s conversion.Scope) error {
+	out.Version = in.Version
+	out.Description = in.Description
+	return nil
+}
+
+// Convert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo is an autogenerated conversion function.
+func Convert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo(in *MaintenanceInfo, out *servicecatalog.MaintenanceInfo, s conversion.Scope) error {
+	return autoConvert_v1beta1_MaintenanceInfo_To_servicecatalog_MaintenanceInfo(in, out, s)
+}
+
+func autoConvert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo(in *servicecatalog.MaintenanceInfo, out *MaintenanceInfo, s conversion.Scope) error {
+	out.Version = in.Version
+	out.Description = in.Description
+	return nil
+}
+
+// Convert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo is an autogenerated conversion function.
+func Convert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo(in *servicecatalog.MaintenanceInfo, out *MaintenanceInfo, s conversion.Scope) error {
+	return autoConvert_servicecatalog_MaintenanceInfo_To_v1beta1_MaintenanceInfo(in, out, s)
+}
+
+func autoConvert_v1beta1_OAuth2AuthConfig_To_servicecatalog_OAuth2AuthConfig(in *OAuth2AuthConfig, out *servicecatalog.OAuth2AuthConfig, s conversion.Scope) error {
+	out.TokenURL = in.TokenURL
+	out.SecretRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	out.Scopes = *(*[]string)(unsafe.Pointer(&in.Scopes))
+	return nil
+}
+
+// Convert_v1beta1_OAuth2AuthConfig_To_servicecatalog_OAuth2AuthConfig is an autogenerated conversion function.
+func Convert_v1beta1_OAuth2AuthConfig_To_servicecatalog_OAuth2AuthConfig(in *OAuth2AuthConfig, out *servicecatalog.OAuth2AuthConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_OAuth2AuthConfig_To_servicecatalog_OAuth2AuthConfig(in, out, s)
+}
+
+func autoConvert_servicecatalog_OAuth2AuthConfig_To_v1beta1_OAuth2AuthConfig(in *servicecatalog.OAuth2AuthConfig, out *OAuth2AuthConfig, s conversion.Scope) error {
+	out.TokenURL = in.TokenURL
+	out.SecretRef = (*LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	out.Scopes = *(*[]string)(unsafe.Pointer(&in.Scopes))
+	return nil
+}
+
+// Convert_servicecatalog_OAuth2AuthConfig_To_v1beta1_OAuth2AuthConfig is an autogenerated conversion function.
+func Convert_servicecatalog_OAuth2AuthConfig_To_v1beta1_OAuth2AuthConfig(in *servicecatalog.OAuth2AuthConfig, out *OAuth2AuthConfig, s conversion.Scope) error {
+	return autoConvert_servicecatalog_OAuth2AuthConfig_To_v1beta1_OAuth2AuthConfig(in, out, s)
+}
+
 func autoConvert_v1beta1_ObjectReference_To_servicecatalog_ObjectReference(in *ObjectReference, out *servicecatalog.ObjectReference, s conversion.Scope) error {
 	out.Namespace = in.Namespace
 	out.Name = in.Name
@@ -888,6 +1208,8 @@ func Convert_servicecatalog_ObjectReference_To_v1beta1_ObjectReference(in *servi
 
 func autoConvert_v1beta1_ParametersFromSource_To_servicecatalog_ParametersFromSource(in *ParametersFromSource, out *servicecatalog.ParametersFromSource, s conversion.Scope) error {
 	out.SecretKeyRef = (*servicecatalog.SecretKeyReference)(unsafe.Pointer(in.SecretKeyRef))
+	out.ServiceBindingSecretKeyRef = (*servicecatalog.ServiceBindingSecretKeyReference)(unsafe.Pointer(in.ServiceBindingSecretKeyRef))
+	out.ServiceInstanceFieldRef = (*servicecatalog.ServiceInstanceFieldReference)(unsafe.Pointer(in.ServiceInstanceFieldRef))
 	return nil
 }
 
@@ -898,6 +1220,8 @@ func Convert_v1beta1_ParametersFromSource_To_servicecatalog_ParametersFromSource
 
 func autoConvert_servicecatalog_ParametersFromSource_To_v1beta1_ParametersFromSource(in *servicecatalog.ParametersFromSource, out *ParametersFromSource, s conversion.Scope) error {
 	out.SecretKeyRef = (*SecretKeyReference)(unsafe.Pointer(in.SecretKeyRef))
+	out.ServiceBindingSecretKeyRef = (*ServiceBindingSecretKeyReference)(unsafe.Pointer(in.ServiceBindingSecretKeyRef))
+	out.ServiceInstanceFieldRef = (*ServiceInstanceFieldReference)(unsafe.Pointer(in.ServiceInstanceFieldRef))
 	return nil
 }
 
@@ -906,6 +1230,28 @@ func Convert_servicecatalog_ParametersFromSource_To_v1beta1_ParametersFromSource
 	return autoConvert_servicecatalog_ParametersFromSource_To_v1beta1_ParametersFromSource(in, out, s)
 }
 
+func autoConvert_v1beta1_PlanMigrationPolicy_To_servicecatalog_PlanMigrationPolicy(in *PlanMigrationPolicy, out *servicecatalog.PlanMigrationPolicy, s conversion.Scope) error {
+	out.ReplacementPlanExternalName = in.ReplacementPlanExternalName
+	out.MaxInProgress = (*int32)(unsafe.Pointer(in.MaxInProgress))
+	return nil
+}
+
+// Convert_v1beta1_PlanMigrationPolicy_To_servicecatalog_PlanMigrationPolicy is an autogenerated conversion function.
+func Convert_v1beta1_PlanMigrationPolicy_To_servicecatalog_PlanMigrationPolicy(in *PlanMigrationPolicy, out *servicecatalog.PlanMigrationPolicy, s conversion.Scope) error {
+	return autoConvert_v1beta1_PlanMigrationPolicy_To_servicecatalog_PlanMigrationPolicy(in, out, s)
+}
+
+func autoConvert_servicecatalog_PlanMigrationPolicy_To_v1beta1_PlanMigrationPolicy(in *servicecatalog.PlanMigrationPolicy, out *PlanMigrationPolicy, s conversion.Scope) error {
+	out.ReplacementPlanExternalName = in.ReplacementPlanExternalName
+	out.MaxInProgress = (*int32)(unsafe.Pointer(in.MaxInProgress))
+	return nil
+}
+
+// Convert_servicecatalog_PlanMigrationPolicy_To_v1beta1_PlanMigrationPolicy is an autogenerated conversion function.
+func Convert_servicecatalog_PlanMigrationPolicy_To_v1beta1_PlanMigrationPolicy(in *servicecatalog.PlanMigrationPolicy, out *PlanMigrationPolicy, s conversion.Scope) error {
+	return autoConvert_servicecatalog_PlanMigrationPolicy_To_v1beta1_PlanMigrationPolicy(in, out, s)
+}
+
 func autoConvert_v1beta1_PlanReference_To_servicecatalog_PlanReference(in *PlanReference, out *servicecatalog.PlanReference, s conversion.Scope) error {
 	out.ClusterServiceClassExternalName = in.ClusterServiceClassExternalName
 	out.ClusterServicePlanExternalName = in.ClusterServicePlanExternalName
@@ -913,6 +1259,12 @@ func autoConvert_v1beta1_PlanReference_To_servicecatalog_PlanReference(in *PlanR
 	out.ClusterServicePlanExternalID = in.ClusterServicePlanExternalID
 	out.ClusterServiceClassName = in.ClusterServiceClassName
 	out.ClusterServicePlanName = in.ClusterServicePlanName
+	out.ServiceClassExternalName = in.ServiceClassExternalName
+	out.ServicePlanExternalName = in.ServicePlanExternalName
+	out.ServiceClassExternalID = in.ServiceClassExternalID
+	out.ServicePlanExternalID = in.ServicePlanExternalID
+	out.ServiceClassName = in.ServiceClassName
+	out.ServicePlanName = in.ServicePlanName
 	return nil
 }
 
@@ -928,6 +1280,12 @@ func autoConvert_servicecatalog_PlanReference_To_v1beta1_PlanReference(in *servi
 	out.ClusterServicePlanExternalID = in.ClusterServicePlanExternalID
 	out.ClusterServiceClassName = in.ClusterServiceClassName
 	out.ClusterServicePlanName = in.ClusterServicePlanName
+	out.ServiceClassExternalName = in.ServiceClassExternalName
+	out.ServicePlanExternalName = in.ServicePlanExternalName
+	out.ServiceClassExternalID = in.ServiceClassExternalID
+	out.ServicePlanExternalID = in.ServicePlanExternalID
+	out.ServiceClassName = in.ServiceClassName
+	out.ServicePlanName = in.ServicePlanName
 	return nil
 }
 
@@ -936,6 +1294,30 @@ func Convert_servicecatalog_PlanReference_To_v1beta1_PlanReference(in *serviceca
 	return autoConvert_servicecatalog_PlanReference_To_v1beta1_PlanReference(in, out, s)
 }
 
+func autoConvert_v1beta1_PodPresetTemplate_To_servicecatalog_PodPresetTemplate(in *PodPresetTemplate, out *servicecatalog.PodPresetTemplate, s conversion.Scope) error {
+	out.Selector = in.Selector
+	out.EnvPrefix = (*string)(unsafe.Pointer(in.EnvPrefix))
+	out.MountPath = in.MountPath
+	return nil
+}
+
+// Convert_v1beta1_PodPresetTemplate_To_servicecatalog_PodPresetTemplate is an autogenerated conversion function.
+func Convert_v1beta1_PodPresetTemplate_To_servicecatalog_PodPresetTemplate(in *PodPresetTemplate, out *servicecatalog.PodPresetTemplate, s conversion.Scope) error {
+	return autoConvert_v1beta1_PodPresetTemplate_To_servicecatalog_PodPresetTemplate(in, out, s)
+}
+
+func autoConvert_servicecatalog_PodPresetTemplate_To_v1beta1_PodPresetTemplate(in *servicecatalog.PodPresetTemplate, out *PodPresetTemplate, s conversion.Scope) error {
+	out.Selector = in.Selector
+	out.EnvPrefix = (*string)(unsafe.Pointer(in.EnvPrefix))
+	out.MountPath = in.MountPath
+	return nil
+}
+
+// Convert_servicecatalog_PodPresetTemplate_To_v1beta1_PodPresetTemplate is an autogenerated conversion function.
+func Convert_servicecatalog_PodPresetTemplate_To_v1beta1_PodPresetTemplate(in *servicecatalog.PodPresetTemplate, out *PodPresetTemplate, s conversion.Scope) error {
+	return autoConvert_servicecatalog_PodPresetTemplate_To_v1beta1_PodPresetTemplate(in, out, s)
+}
+
 func autoConvert_v1beta1_RemoveKeyTransform_To_servicecatalog_RemoveKeyTransform(in *RemoveKeyTransform, out *servicecatalog.RemoveKeyTransform, s conversion.Scope) error {
 	out.Key = in.Key
 	return nil
@@ -1005,6 +1387,7 @@ func autoConvert_v1beta1_SecretTransform_To_servicecatalog_SecretTransform(in *S
 	out.AddKey = (*servicecatalog.AddKeyTransform)(unsafe.Pointer(in.AddKey))
 	out.AddKeysFrom = (*servicecatalog.AddKeysFromTransform)(unsafe.Pointer(in.AddKeysFrom))
 	out.RemoveKey = (*servicecatalog.RemoveKeyTransform)(unsafe.Pointer(in.RemoveKey))
+	out.TemplateKey = (*servicecatalog.TemplateKeyTransform)(unsafe.Pointer(in.TemplateKey))
 	return nil
 }
 
@@ -1018,6 +1401,7 @@ func autoConvert_servicecatalog_SecretTransform_To_v1beta1_SecretTransform(in *s
 	out.AddKey = (*AddKeyTransform)(unsafe.Pointer(in.AddKey))
 	out.AddKeysFrom = (*AddKeysFromTransform)(unsafe.Pointer(in.AddKeysFrom))
 	out.RemoveKey = (*RemoveKeyTransform)(unsafe.Pointer(in.RemoveKey))
+	out.TemplateKey = (*TemplateKeyTransform)(unsafe.Pointer(in.TemplateKey))
 	return nil
 }
 
@@ -1132,6 +1516,30 @@ func Convert_servicecatalog_ServiceBindingPropertiesState_To_v1beta1_ServiceBind
 	return autoConvert_servicecatalog_ServiceBindingPropertiesState_To_v1beta1_ServiceBindingPropertiesState(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceBindingSecretKeyReference_To_servicecatalog_ServiceBindingSecretKeyReference(in *ServiceBindingSecretKeyReference, out *servicecatalog.ServiceBindingSecretKeyReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	out.Parameter = in.Parameter
+	return nil
+}
+
+// Convert_v1beta1_ServiceBindingSecretKeyReference_To_servicecatalog_ServiceBindingSecretKeyReference is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBindingSecretKeyReference_To_servicecatalog_ServiceBindingSecretKeyReference(in *ServiceBindingSecretKeyReference, out *servicecatalog.ServiceBindingSecretKeyReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBindingSecretKeyReference_To_servicecatalog_ServiceBindingSecretKeyReference(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBindingSecretKeyReference_To_v1beta1_ServiceBindingSecretKeyReference(in *servicecatalog.ServiceBindingSecretKeyReference, out *ServiceBindingSecretKeyReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Key = in.Key
+	out.Parameter = in.Parameter
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBindingSecretKeyReference_To_v1beta1_ServiceBindingSecretKeyReference is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBindingSecretKeyReference_To_v1beta1_ServiceBindingSecretKeyReference(in *servicecatalog.ServiceBindingSecretKeyReference, out *ServiceBindingSecretKeyReference, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBindingSecretKeyReference_To_v1beta1_ServiceBindingSecretKeyReference(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec(in *ServiceBindingSpec, out *servicecatalog.ServiceBindingSpec, s conversion.Scope) error {
 	if err := Convert_v1beta1_LocalObjectReference_To_servicecatalog_LocalObjectReference(&in.ServiceInstanceRef, &out.ServiceInstanceRef, s); err != nil {
 		return err
@@ -1140,6 +1548,10 @@ func autoConvert_v1beta1_ServiceBindingSpec_To_servicecatalog_ServiceBindingSpec
 	out.ParametersFrom = *(*[]servicecatalog.ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.SecretName = in.SecretName
 	out.SecretTransforms = *(*[]servicecatalog.SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
+	out.PodPresetTemplate = (*servicecatalog.PodPresetTemplate)(unsafe.Pointer(in.PodPresetTemplate))
+	out.SecretCopyNamespaces = *(*[]string)(unsafe.Pointer(&in.SecretCopyNamespaces))
+	out.ConfigMapTemplate = (*servicecatalog.ConfigMapTemplate)(unsafe.Pointer(in.ConfigMapTemplate))
+	out.RotationRequests = in.RotationRequests
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
 	return nil
@@ -1158,6 +1570,10 @@ func autoConvert_servicecatalog_ServiceBindingSpec_To_v1beta1_ServiceBindingSpec
 	out.ParametersFrom = *(*[]ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.SecretName = in.SecretName
 	out.SecretTransforms = *(*[]SecretTransform)(unsafe.Pointer(&in.SecretTransforms))
+	out.PodPresetTemplate = (*PodPresetTemplate)(unsafe.Pointer(in.PodPresetTemplate))
+	out.SecretCopyNamespaces = *(*[]string)(unsafe.Pointer(&in.SecretCopyNamespaces))
+	out.ConfigMapTemplate = (*ConfigMapTemplate)(unsafe.Pointer(in.ConfigMapTemplate))
+	out.RotationRequests = in.RotationRequests
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
 	return nil
@@ -1179,6 +1595,12 @@ func autoConvert_v1beta1_ServiceBindingStatus_To_servicecatalog_ServiceBindingSt
 	out.ExternalProperties = (*servicecatalog.ServiceBindingPropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.UnbindStatus = servicecatalog.ServiceBindingUnbindStatus(in.UnbindStatus)
+	out.CurrentExternalID = in.CurrentExternalID
+	out.PreviousExternalID = in.PreviousExternalID
+	out.PreviousCredentialsUnbindTime = (*v1.Time)(unsafe.Pointer(in.PreviousCredentialsUnbindTime))
+	out.PreviousCredentialsUnbindOperation = (*string)(unsafe.Pointer(in.PreviousCredentialsUnbindOperation))
+	out.LastCredentialsSyncTime = (*v1.Time)(unsafe.Pointer(in.LastCredentialsSyncTime))
+	out.DeliveredSecretName = in.DeliveredSecretName
 	return nil
 }
 
@@ -1198,6 +1620,12 @@ func autoConvert_servicecatalog_ServiceBindingStatus_To_v1beta1_ServiceBindingSt
 	out.ExternalProperties = (*ServiceBindingPropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.OrphanMitigationInProgress = in.OrphanMitigationInProgress
 	out.UnbindStatus = ServiceBindingUnbindStatus(in.UnbindStatus)
+	out.CurrentExternalID = in.CurrentExternalID
+	out.PreviousExternalID = in.PreviousExternalID
+	out.PreviousCredentialsUnbindTime = (*v1.Time)(unsafe.Pointer(in.PreviousCredentialsUnbindTime))
+	out.PreviousCredentialsUnbindOperation = (*string)(unsafe.Pointer(in.PreviousCredentialsUnbindOperation))
+	out.LastCredentialsSyncTime = (*v1.Time)(unsafe.Pointer(in.LastCredentialsSyncTime))
+	out.DeliveredSecretName = in.DeliveredSecretName
 	return nil
 }
 
@@ -1241,6 +1669,8 @@ func Convert_servicecatalog_ServiceBroker_To_v1beta1_ServiceBroker(in *serviceca
 func autoConvert_v1beta1_ServiceBrokerAuthInfo_To_servicecatalog_ServiceBrokerAuthInfo(in *ServiceBrokerAuthInfo, out *servicecatalog.ServiceBrokerAuthInfo, s conversion.Scope) error {
 	out.Basic = (*servicecatalog.BasicAuthConfig)(unsafe.Pointer(in.Basic))
 	out.Bearer = (*servicecatalog.BearerTokenAuthConfig)(unsafe.Pointer(in.Bearer))
+	out.TLS = (*servicecatalog.TLSAuthConfig)(unsafe.Pointer(in.TLS))
+	out.OAuth2 = (*servicecatalog.OAuth2AuthConfig)(unsafe.Pointer(in.OAuth2))
 	return nil
 }
 
@@ -1252,6 +1682,8 @@ func Convert_v1beta1_ServiceBrokerAuthInfo_To_servicecatalog_ServiceBrokerAuthIn
 func autoConvert_servicecatalog_ServiceBrokerAuthInfo_To_v1beta1_ServiceBrokerAuthInfo(in *servicecatalog.ServiceBrokerAuthInfo, out *ServiceBrokerAuthInfo, s conversion.Scope) error {
 	out.Basic = (*BasicAuthConfig)(unsafe.Pointer(in.Basic))
 	out.Bearer = (*BearerTokenAuthConfig)(unsafe.Pointer(in.Bearer))
+	out.TLS = (*TLSAuthConfig)(unsafe.Pointer(in.TLS))
+	out.OAuth2 = (*OAuth2AuthConfig)(unsafe.Pointer(in.OAuth2))
 	return nil
 }
 
@@ -1310,6 +1742,32 @@ func Convert_servicecatalog_ServiceBrokerList_To_v1beta1_ServiceBrokerList(in *s
 	return autoConvert_servicecatalog_ServiceBrokerList_To_v1beta1_ServiceBrokerList(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceBrokerRateLimits_To_servicecatalog_ServiceBrokerRateLimits(in *ServiceBrokerRateLimits, out *servicecatalog.ServiceBrokerRateLimits, s conversion.Scope) error {
+	out.MaxInFlightRequests = (*int32)(unsafe.Pointer(in.MaxInFlightRequests))
+	out.RequestsPerSecond = (*int32)(unsafe.Pointer(in.RequestsPerSecond))
+	out.MaxInFlightPollRequests = (*int32)(unsafe.Pointer(in.MaxInFlightPollRequests))
+	out.PollRequestsPerSecond = (*int32)(unsafe.Pointer(in.PollRequestsPerSecond))
+	return nil
+}
+
+// Convert_v1beta1_ServiceBrokerRateLimits_To_servicecatalog_ServiceBrokerRateLimits is an autogenerated conversion function.
+func Convert_v1beta1_ServiceBrokerRateLimits_To_servicecatalog_ServiceBrokerRateLimits(in *ServiceBrokerRateLimits, out *servicecatalog.ServiceBrokerRateLimits, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceBrokerRateLimits_To_servicecatalog_ServiceBrokerRateLimits(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceBrokerRateLimits_To_v1beta1_ServiceBrokerRateLimits(in *servicecatalog.ServiceBrokerRateLimits, out *ServiceBrokerRateLimits, s conversion.Scope) error {
+	out.MaxInFlightRequests = (*int32)(unsafe.Pointer(in.MaxInFlightRequests))
+	out.RequestsPerSecond = (*int32)(unsafe.Pointer(in.RequestsPerSecond))
+	out.MaxInFlightPollRequests = (*int32)(unsafe.Pointer(in.MaxInFlightPollRequests))
+	out.PollRequestsPerSecond = (*int32)(unsafe.Pointer(in.PollRequestsPerSecond))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceBrokerRateLimits_To_v1beta1_ServiceBrokerRateLimits is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceBrokerRateLimits_To_v1beta1_ServiceBrokerRateLimits(in *servicecatalog.ServiceBrokerRateLimits, out *ServiceBrokerRateLimits, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceBrokerRateLimits_To_v1beta1_ServiceBrokerRateLimits(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceBrokerSpec_To_servicecatalog_ServiceBrokerSpec(in *ServiceBrokerSpec, out *servicecatalog.ServiceBrokerSpec, s conversion.Scope) error {
 	if err := Convert_v1beta1_CommonServiceBrokerSpec_To_servicecatalog_CommonServiceBrokerSpec(&in.CommonServiceBrokerSpec, &out.CommonServiceBrokerSpec, s); err != nil {
 		return err
@@ -1496,6 +1954,48 @@ func Convert_servicecatalog_ServiceInstance_To_v1beta1_ServiceInstance(in *servi
 	return autoConvert_servicecatalog_ServiceInstance_To_v1beta1_ServiceInstance(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceInstanceAdoption_To_servicecatalog_ServiceInstanceAdoption(in *ServiceInstanceAdoption, out *servicecatalog.ServiceInstanceAdoption, s conversion.Scope) error {
+	out.Verify = (*bool)(unsafe.Pointer(in.Verify))
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceAdoption_To_servicecatalog_ServiceInstanceAdoption is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceAdoption_To_servicecatalog_ServiceInstanceAdoption(in *ServiceInstanceAdoption, out *servicecatalog.ServiceInstanceAdoption, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceAdoption_To_servicecatalog_ServiceInstanceAdoption(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceAdoption_To_v1beta1_ServiceInstanceAdoption(in *servicecatalog.ServiceInstanceAdoption, out *ServiceInstanceAdoption, s conversion.Scope) error {
+	out.Verify = (*bool)(unsafe.Pointer(in.Verify))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceAdoption_To_v1beta1_ServiceInstanceAdoption is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceAdoption_To_v1beta1_ServiceInstanceAdoption(in *servicecatalog.ServiceInstanceAdoption, out *ServiceInstanceAdoption, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceAdoption_To_v1beta1_ServiceInstanceAdoption(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceInstanceBrokerProperties_To_servicecatalog_ServiceInstanceBrokerProperties(in *ServiceInstanceBrokerProperties, out *servicecatalog.ServiceInstanceBrokerProperties, s conversion.Scope) error {
+	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
+	out.DashboardURL = (*string)(unsafe.Pointer(in.DashboardURL))
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceBrokerProperties_To_servicecatalog_ServiceInstanceBrokerProperties is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceBrokerProperties_To_servicecatalog_ServiceInstanceBrokerProperties(in *ServiceInstanceBrokerProperties, out *servicecatalog.ServiceInstanceBrokerProperties, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceBrokerProperties_To_servicecatalog_ServiceInstanceBrokerProperties(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceBrokerProperties_To_v1beta1_ServiceInstanceBrokerProperties(in *servicecatalog.ServiceInstanceBrokerProperties, out *ServiceInstanceBrokerProperties, s conversion.Scope) error {
+	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
+	out.DashboardURL = (*string)(unsafe.Pointer(in.DashboardURL))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceBrokerProperties_To_v1beta1_ServiceInstanceBrokerProperties is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceBrokerProperties_To_v1beta1_ServiceInstanceBrokerProperties(in *servicecatalog.ServiceInstanceBrokerProperties, out *ServiceInstanceBrokerProperties, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceBrokerProperties_To_v1beta1_ServiceInstanceBrokerProperties(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceInstanceCondition_To_servicecatalog_ServiceInstanceCondition(in *ServiceInstanceCondition, out *servicecatalog.ServiceInstanceCondition, s conversion.Scope) error {
 	out.Type = servicecatalog.ServiceInstanceConditionType(in.Type)
 	out.Status = servicecatalog.ConditionStatus(in.Status)
@@ -1524,6 +2024,30 @@ func Convert_servicecatalog_ServiceInstanceCondition_To_v1beta1_ServiceInstanceC
 	return autoConvert_servicecatalog_ServiceInstanceCondition_To_v1beta1_ServiceInstanceCondition(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceInstanceFieldReference_To_servicecatalog_ServiceInstanceFieldReference(in *ServiceInstanceFieldReference, out *servicecatalog.ServiceInstanceFieldReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.FieldPath = in.FieldPath
+	out.Parameter = in.Parameter
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceFieldReference_To_servicecatalog_ServiceInstanceFieldReference is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceFieldReference_To_servicecatalog_ServiceInstanceFieldReference(in *ServiceInstanceFieldReference, out *servicecatalog.ServiceInstanceFieldReference, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceFieldReference_To_servicecatalog_ServiceInstanceFieldReference(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceFieldReference_To_v1beta1_ServiceInstanceFieldReference(in *servicecatalog.ServiceInstanceFieldReference, out *ServiceInstanceFieldReference, s conversion.Scope) error {
+	out.Name = in.Name
+	out.FieldPath = in.FieldPath
+	out.Parameter = in.Parameter
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceFieldReference_To_v1beta1_ServiceInstanceFieldReference is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceFieldReference_To_v1beta1_ServiceInstanceFieldReference(in *servicecatalog.ServiceInstanceFieldReference, out *ServiceInstanceFieldReference, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceFieldReference_To_v1beta1_ServiceInstanceFieldReference(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceInstanceList_To_servicecatalog_ServiceInstanceList(in *ServiceInstanceList, out *servicecatalog.ServiceInstanceList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	out.Items = *(*[]servicecatalog.ServiceInstance)(unsafe.Pointer(&in.Items))
@@ -1546,12 +2070,43 @@ func Convert_servicecatalog_ServiceInstanceList_To_v1beta1_ServiceInstanceList(i
 	return autoConvert_servicecatalog_ServiceInstanceList_To_v1beta1_ServiceInstanceList(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceInstancePlanMigration_To_servicecatalog_ServiceInstancePlanMigration(in *ServiceInstancePlanMigration, out *servicecatalog.ServiceInstancePlanMigration, s conversion.Scope) error {
+	out.FromClusterServicePlanName = in.FromClusterServicePlanName
+	out.ToClusterServicePlanName = in.ToClusterServicePlanName
+	out.StartTime = in.StartTime
+	out.CompletionTime = (*v1.Time)(unsafe.Pointer(in.CompletionTime))
+	out.FailureMessage = in.FailureMessage
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstancePlanMigration_To_servicecatalog_ServiceInstancePlanMigration is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstancePlanMigration_To_servicecatalog_ServiceInstancePlanMigration(in *ServiceInstancePlanMigration, out *servicecatalog.ServiceInstancePlanMigration, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstancePlanMigration_To_servicecatalog_ServiceInstancePlanMigration(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstancePlanMigration_To_v1beta1_ServiceInstancePlanMigration(in *servicecatalog.ServiceInstancePlanMigration, out *ServiceInstancePlanMigration, s conversion.Scope) error {
+	out.FromClusterServicePlanName = in.FromClusterServicePlanName
+	out.ToClusterServicePlanName = in.ToClusterServicePlanName
+	out.StartTime = in.StartTime
+	out.CompletionTime = (*v1.Time)(unsafe.Pointer(in.CompletionTime))
+	out.FailureMessage = in.FailureMessage
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstancePlanMigration_To_v1beta1_ServiceInstancePlanMigration is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstancePlanMigration_To_v1beta1_ServiceInstancePlanMigration(in *servicecatalog.ServiceInstancePlanMigration, out *ServiceInstancePlanMigration, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstancePlanMigration_To_v1beta1_ServiceInstancePlanMigration(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceInstancePropertiesState_To_servicecatalog_ServiceInstancePropertiesState(in *ServiceInstancePropertiesState, out *servicecatalog.ServiceInstancePropertiesState, s conversion.Scope) error {
 	out.ClusterServicePlanExternalName = in.ClusterServicePlanExternalName
 	out.ClusterServicePlanExternalID = in.ClusterServicePlanExternalID
+	out.ServicePlanExternalName = in.ServicePlanExternalName
+	out.ServicePlanExternalID = in.ServicePlanExternalID
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersChecksum = in.ParametersChecksum
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
+	out.MaintenanceInfoVersion = in.MaintenanceInfoVersion
 	return nil
 }
 
@@ -1563,9 +2118,12 @@ func Convert_v1beta1_ServiceInstancePropertiesState_To_servicecatalog_ServiceIns
 func autoConvert_servicecatalog_ServiceInstancePropertiesState_To_v1beta1_ServiceInstancePropertiesState(in *servicecatalog.ServiceInstancePropertiesState, out *ServiceInstancePropertiesState, s conversion.Scope) error {
 	out.ClusterServicePlanExternalName = in.ClusterServicePlanExternalName
 	out.ClusterServicePlanExternalID = in.ClusterServicePlanExternalID
+	out.ServicePlanExternalName = in.ServicePlanExternalName
+	out.ServicePlanExternalID = in.ServicePlanExternalID
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersChecksum = in.ParametersChecksum
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
+	out.MaintenanceInfoVersion = in.MaintenanceInfoVersion
 	return nil
 }
 
@@ -1574,17 +2132,167 @@ func Convert_servicecatalog_ServiceInstancePropertiesState_To_v1beta1_ServiceIns
 	return autoConvert_servicecatalog_ServiceInstancePropertiesState_To_v1beta1_ServiceInstancePropertiesState(in, out, s)
 }
 
+func autoConvert_v1beta1_ServiceInstanceQuota_To_servicecatalog_ServiceInstanceQuota(in *ServiceInstanceQuota, out *servicecatalog.ServiceInstanceQuota, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_v1beta1_ServiceInstanceQuotaSpec_To_servicecatalog_ServiceInstanceQuotaSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_v1beta1_ServiceInstanceQuotaStatus_To_servicecatalog_ServiceInstanceQuotaStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceQuota_To_servicecatalog_ServiceInstanceQuota is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceQuota_To_servicecatalog_ServiceInstanceQuota(in *ServiceInstanceQuota, out *servicecatalog.ServiceInstanceQuota, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceQuota_To_servicecatalog_ServiceInstanceQuota(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceQuota_To_v1beta1_ServiceInstanceQuota(in *servicecatalog.ServiceInstanceQuota, out *ServiceInstanceQuota, s conversion.Scope) error {
+	out.ObjectMeta = in.ObjectMeta
+	if err := Convert_servicecatalog_ServiceInstanceQuotaSpec_To_v1beta1_ServiceInstanceQuotaSpec(&in.Spec, &out.Spec, s); err != nil {
+		return err
+	}
+	if err := Convert_servicecatalog_ServiceInstanceQuotaStatus_To_v1beta1_ServiceInstanceQuotaStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceQuota_To_v1beta1_ServiceInstanceQuota is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceQuota_To_v1beta1_ServiceInstanceQuota(in *servicecatalog.ServiceInstanceQuota, out *ServiceInstanceQuota, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceQuota_To_v1beta1_ServiceInstanceQuota(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceInstanceQuotaLimit_To_servicecatalog_ServiceInstanceQuotaLimit(in *ServiceInstanceQuotaLimit, out *servicecatalog.ServiceInstanceQuotaLimit, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ServiceClassExternalName = in.ServiceClassExternalName
+	out.ServicePlanExternalName = in.ServicePlanExternalName
+	out.Free = (*bool)(unsafe.Pointer(in.Free))
+	out.MaxInstances = in.MaxInstances
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceQuotaLimit_To_servicecatalog_ServiceInstanceQuotaLimit is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceQuotaLimit_To_servicecatalog_ServiceInstanceQuotaLimit(in *ServiceInstanceQuotaLimit, out *servicecatalog.ServiceInstanceQuotaLimit, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceQuotaLimit_To_servicecatalog_ServiceInstanceQuotaLimit(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceQuotaLimit_To_v1beta1_ServiceInstanceQuotaLimit(in *servicecatalog.ServiceInstanceQuotaLimit, out *ServiceInstanceQuotaLimit, s conversion.Scope) error {
+	out.Name = in.Name
+	out.ServiceClassExternalName = in.ServiceClassExternalName
+	out.ServicePlanExternalName = in.ServicePlanExternalName
+	out.Free = (*bool)(unsafe.Pointer(in.Free))
+	out.MaxInstances = in.MaxInstances
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceQuotaLimit_To_v1beta1_ServiceInstanceQuotaLimit is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceQuotaLimit_To_v1beta1_ServiceInstanceQuotaLimit(in *servicecatalog.ServiceInstanceQuotaLimit, out *ServiceInstanceQuotaLimit, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceQuotaLimit_To_v1beta1_ServiceInstanceQuotaLimit(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceInstanceQuotaList_To_servicecatalog_ServiceInstanceQuotaList(in *ServiceInstanceQuotaList, out *servicecatalog.ServiceInstanceQuotaList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]servicecatalog.ServiceInstanceQuota)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceQuotaList_To_servicecatalog_ServiceInstanceQuotaList is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceQuotaList_To_servicecatalog_ServiceInstanceQuotaList(in *ServiceInstanceQuotaList, out *servicecatalog.ServiceInstanceQuotaList, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceQuotaList_To_servicecatalog_ServiceInstanceQuotaList(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceQuotaList_To_v1beta1_ServiceInstanceQuotaList(in *servicecatalog.ServiceInstanceQuotaList, out *ServiceInstanceQuotaList, s conversion.Scope) error {
+	out.ListMeta = in.ListMeta
+	out.Items = *(*[]ServiceInstanceQuota)(unsafe.Pointer(&in.Items))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceQuotaList_To_v1beta1_ServiceInstanceQuotaList is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceQuotaList_To_v1beta1_ServiceInstanceQuotaList(in *servicecatalog.ServiceInstanceQuotaList, out *ServiceInstanceQuotaList, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceQuotaList_To_v1beta1_ServiceInstanceQuotaList(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceInstanceQuotaSpec_To_servicecatalog_ServiceInstanceQuotaSpec(in *ServiceInstanceQuotaSpec, out *servicecatalog.ServiceInstanceQuotaSpec, s conversion.Scope) error {
+	out.Limits = *(*[]servicecatalog.ServiceInstanceQuotaLimit)(unsafe.Pointer(&in.Limits))
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceQuotaSpec_To_servicecatalog_ServiceInstanceQuotaSpec is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceQuotaSpec_To_servicecatalog_ServiceInstanceQuotaSpec(in *ServiceInstanceQuotaSpec, out *servicecatalog.ServiceInstanceQuotaSpec, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceQuotaSpec_To_servicecatalog_ServiceInstanceQuotaSpec(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceQuotaSpec_To_v1beta1_ServiceInstanceQuotaSpec(in *servicecatalog.ServiceInstanceQuotaSpec, out *ServiceInstanceQuotaSpec, s conversion.Scope) error {
+	out.Limits = *(*[]ServiceInstanceQuotaLimit)(unsafe.Pointer(&in.Limits))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceQuotaSpec_To_v1beta1_ServiceInstanceQuotaSpec is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceQuotaSpec_To_v1beta1_ServiceInstanceQuotaSpec(in *servicecatalog.ServiceInstanceQuotaSpec, out *ServiceInstanceQuotaSpec, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceQuotaSpec_To_v1beta1_ServiceInstanceQuotaSpec(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceInstanceQuotaStatus_To_servicecatalog_ServiceInstanceQuotaStatus(in *ServiceInstanceQuotaStatus, out *servicecatalog.ServiceInstanceQuotaStatus, s conversion.Scope) error {
+	out.Used = *(*[]servicecatalog.ServiceInstanceQuotaUsage)(unsafe.Pointer(&in.Used))
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceQuotaStatus_To_servicecatalog_ServiceInstanceQuotaStatus is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceQuotaStatus_To_servicecatalog_ServiceInstanceQuotaStatus(in *ServiceInstanceQuotaStatus, out *servicecatalog.ServiceInstanceQuotaStatus, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceQuotaStatus_To_servicecatalog_ServiceInstanceQuotaStatus(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceQuotaStatus_To_v1beta1_ServiceInstanceQuotaStatus(in *servicecatalog.ServiceInstanceQuotaStatus, out *ServiceInstanceQuotaStatus, s conversion.Scope) error {
+	out.Used = *(*[]ServiceInstanceQuotaUsage)(unsafe.Pointer(&in.Used))
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceQuotaStatus_To_v1beta1_ServiceInstanceQuotaStatus is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceQuotaStatus_To_v1beta1_ServiceInstanceQuotaStatus(in *servicecatalog.ServiceInstanceQuotaStatus, out *ServiceInstanceQuotaStatus, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceQuotaStatus_To_v1beta1_ServiceInstanceQuotaStatus(in, out, s)
+}
+
+func autoConvert_v1beta1_ServiceInstanceQuotaUsage_To_servicecatalog_ServiceInstanceQuotaUsage(in *ServiceInstanceQuotaUsage, out *servicecatalog.ServiceInstanceQuotaUsage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Instances = in.Instances
+	return nil
+}
+
+// Convert_v1beta1_ServiceInstanceQuotaUsage_To_servicecatalog_ServiceInstanceQuotaUsage is an autogenerated conversion function.
+func Convert_v1beta1_ServiceInstanceQuotaUsage_To_servicecatalog_ServiceInstanceQuotaUsage(in *ServiceInstanceQuotaUsage, out *servicecatalog.ServiceInstanceQuotaUsage, s conversion.Scope) error {
+	return autoConvert_v1beta1_ServiceInstanceQuotaUsage_To_servicecatalog_ServiceInstanceQuotaUsage(in, out, s)
+}
+
+func autoConvert_servicecatalog_ServiceInstanceQuotaUsage_To_v1beta1_ServiceInstanceQuotaUsage(in *servicecatalog.ServiceInstanceQuotaUsage, out *ServiceInstanceQuotaUsage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Instances = in.Instances
+	return nil
+}
+
+// Convert_servicecatalog_ServiceInstanceQuotaUsage_To_v1beta1_ServiceInstanceQuotaUsage is an autogenerated conversion function.
+func Convert_servicecatalog_ServiceInstanceQuotaUsage_To_v1beta1_ServiceInstanceQuotaUsage(in *servicecatalog.ServiceInstanceQuotaUsage, out *ServiceInstanceQuotaUsage, s conversion.Scope) error {
+	return autoConvert_servicecatalog_ServiceInstanceQuotaUsage_To_v1beta1_ServiceInstanceQuotaUsage(in, out, s)
+}
+
 func autoConvert_v1beta1_ServiceInstanceSpec_To_servicecatalog_ServiceInstanceSpec(in *ServiceInstanceSpec, out *servicecatalog.ServiceInstanceSpec, s conversion.Scope) error {
 	if err := Convert_v1beta1_PlanReference_To_servicecatalog_PlanReference(&in.PlanReference, &out.PlanReference, s); err != nil {
 		return err
 	}
 	out.ClusterServiceClassRef = (*servicecatalog.ClusterObjectReference)(unsafe.Pointer(in.ClusterServiceClassRef))
 	out.ClusterServicePlanRef = (*servicecatalog.ClusterObjectReference)(unsafe.Pointer(in.ClusterServicePlanRef))
+	out.ServiceClassRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.ServiceClassRef))
+	out.ServicePlanRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.ServicePlanRef))
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersFrom = *(*[]servicecatalog.ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*servicecatalog.UserInfo)(unsafe.Pointer(in.UserInfo))
 	out.UpdateRequests = in.UpdateRequests
+	out.MaintenanceInfoVersion = in.MaintenanceInfoVersion
+	out.DeletionPolicy = servicecatalog.ServiceInstanceDeletionPolicy(in.DeletionPolicy)
+	out.DeletionProtection = in.DeletionProtection
+	out.Adoption = (*servicecatalog.ServiceInstanceAdoption)(unsafe.Pointer(in.Adoption))
 	return nil
 }
 
@@ -1599,11 +2307,17 @@ func autoConvert_servicecatalog_ServiceInstanceSpec_To_v1beta1_ServiceInstanceSp
 	}
 	out.ClusterServiceClassRef = (*ClusterObjectReference)(unsafe.Pointer(in.ClusterServiceClassRef))
 	out.ClusterServicePlanRef = (*ClusterObjectReference)(unsafe.Pointer(in.ClusterServicePlanRef))
+	out.ServiceClassRef = (*LocalObjectReference)(unsafe.Pointer(in.ServiceClassRef))
+	out.ServicePlanRef = (*LocalObjectReference)(unsafe.Pointer(in.ServicePlanRef))
 	out.Parameters = (*runtime.RawExtension)(unsafe.Pointer(in.Parameters))
 	out.ParametersFrom = *(*[]ParametersFromSource)(unsafe.Pointer(&in.ParametersFrom))
 	out.ExternalID = in.ExternalID
 	out.UserInfo = (*UserInfo)(unsafe.Pointer(in.UserInfo))
 	out.UpdateRequests = in.UpdateRequests
+	out.MaintenanceInfoVersion = in.MaintenanceInfoVersion
+	out.DeletionPolicy = ServiceInstanceDeletionPolicy(in.DeletionPolicy)
+	out.DeletionProtection = in.DeletionProtection
+	out.Adoption = (*ServiceInstanceAdoption)(unsafe.Pointer(in.Adoption))
 	return nil
 }
 
@@ -1626,6 +2340,9 @@ func autoConvert_v1beta1_ServiceInstanceStatus_To_servicecatalog_ServiceInstance
 	out.ExternalProperties = (*servicecatalog.ServiceInstancePropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.ProvisionStatus = servicecatalog.ServiceInstanceProvisionStatus(in.ProvisionStatus)
 	out.DeprovisionStatus = servicecatalog.ServiceInstanceDeprovisionStatus(in.DeprovisionStatus)
+	out.PlanMigration = (*servicecatalog.ServiceInstancePlanMigration)(unsafe.Pointer(in.PlanMigration))
+	out.BrokerProperties = (*servicecatalog.ServiceInstanceBrokerProperties)(unsafe.Pointer(in.BrokerProperties))
+	out.LastBrokerSyncTime = (*v1.Time)(unsafe.Pointer(in.LastBrokerSyncTime))
 	return nil
 }
 
@@ -1648,6 +2365,9 @@ func autoConvert_servicecatalog_ServiceInstanceStatus_To_v1beta1_ServiceInstance
 	out.ExternalProperties = (*ServiceInstancePropertiesState)(unsafe.Pointer(in.ExternalProperties))
 	out.ProvisionStatus = ServiceInstanceProvisionStatus(in.ProvisionStatus)
 	out.DeprovisionStatus = ServiceInstanceDeprovisionStatus(in.DeprovisionStatus)
+	out.PlanMigration = (*ServiceInstancePlanMigration)(unsafe.Pointer(in.PlanMigration))
+	out.BrokerProperties = (*ServiceInstanceBrokerProperties)(unsafe.Pointer(in.BrokerProperties))
+	out.LastBrokerSyncTime = (*v1.Time)(unsafe.Pointer(in.LastBrokerSyncTime))
 	return nil
 }
 
@@ -1766,6 +2486,48 @@ func Convert_servicecatalog_ServicePlanStatus_To_v1beta1_ServicePlanStatus(in *s
 	return autoConvert_servicecatalog_ServicePlanStatus_To_v1beta1_ServicePlanStatus(in, out, s)
 }
 
+func autoConvert_v1beta1_TLSAuthConfig_To_servicecatalog_TLSAuthConfig(in *TLSAuthConfig, out *servicecatalog.TLSAuthConfig, s conversion.Scope) error {
+	out.SecretRef = (*servicecatalog.LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_v1beta1_TLSAuthConfig_To_servicecatalog_TLSAuthConfig is an autogenerated conversion function.
+func Convert_v1beta1_TLSAuthConfig_To_servicecatalog_TLSAuthConfig(in *TLSAuthConfig, out *servicecatalog.TLSAuthConfig, s conversion.Scope) error {
+	return autoConvert_v1beta1_TLSAuthConfig_To_servicecatalog_TLSAuthConfig(in, out, s)
+}
+
+func autoConvert_servicecatalog_TLSAuthConfig_To_v1beta1_TLSAuthConfig(in *servicecatalog.TLSAuthConfig, out *TLSAuthConfig, s conversion.Scope) error {
+	out.SecretRef = (*LocalObjectReference)(unsafe.Pointer(in.SecretRef))
+	return nil
+}
+
+// Convert_servicecatalog_TLSAuthConfig_To_v1beta1_TLSAuthConfig is an autogenerated conversion function.
+func Convert_servicecatalog_TLSAuthConfig_To_v1beta1_TLSAuthConfig(in *servicecatalog.TLSAuthConfig, out *TLSAuthConfig, s conversion.Scope) error {
+	return autoConvert_servicecatalog_TLSAuthConfig_To_v1beta1_TLSAuthConfig(in, out, s)
+}
+
+func autoConvert_v1beta1_TemplateKeyTransform_To_servicecatalog_TemplateKeyTransform(in *TemplateKeyTransform, out *servicecatalog.TemplateKeyTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_v1beta1_TemplateKeyTransform_To_servicecatalog_TemplateKeyTransform is an autogenerated conversion function.
+func Convert_v1beta1_TemplateKeyTransform_To_servicecatalog_TemplateKeyTransform(in *TemplateKeyTransform, out *servicecatalog.TemplateKeyTransform, s conversion.Scope) error {
+	return autoConvert_v1beta1_TemplateKeyTransform_To_servicecatalog_TemplateKeyTransform(in, out, s)
+}
+
+func autoConvert_servicecatalog_TemplateKeyTransform_To_v1beta1_TemplateKeyTransform(in *servicecatalog.TemplateKeyTransform, out *TemplateKeyTransform, s conversion.Scope) error {
+	out.Key = in.Key
+	out.Template = in.Template
+	return nil
+}
+
+// Convert_servicecatalog_TemplateKeyTransform_To_v1beta1_TemplateKeyTransform is an autogenerated conversion function.
+func Convert_servicecatalog_TemplateKeyTransform_To_v1beta1_TemplateKeyTransform(in *servicecatalog.TemplateKeyTransform, out *TemplateKeyTransform, s conversion.Scope) error {
+	return autoConvert_servicecatalog_TemplateKeyTransform_To_v1beta1_TemplateKeyTransform(in, out, s)
+}
+
 func autoConvert_v1beta1_UserInfo_To_servicecatalog_UserInfo(in *UserInfo, out *servicecatalog.UserInfo, s conversion.Scope) error {
 	out.Username = in.Username
 	out.UID = in.UID
@@ -215,6 +215,36 @@ func (in *ClusterBearerTokenAuthConfig) DeepCopy() *ClusterBearerTokenAuthConfig
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterOAuth2AuthConfig) DeepCopyInto(out *ClusterOAuth2AuthConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ObjectReference)
+			**out = **in
+		}
+	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterOAuth2AuthConfig.
+func (in *ClusterOAuth2AuthConfig) DeepCopy() *ClusterOAuth2AuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterOAuth2AuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterObjectReference) DeepCopyInto(out *ClusterObjectReference) {
 	*out = *in
@@ -231,6 +261,91 @@ func (in *ClusterObjectReference) DeepCopy() *ClusterObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterParameterDefault) DeepCopyInto(out *ClusterParameterDefault) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterParameterDefault.
+func (in *ClusterParameterDefault) DeepCopy() *ClusterParameterDefault {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterParameterDefault)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterParameterDefault) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterParameterDefaultList) DeepCopyInto(out *ClusterParameterDefaultList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterParameterDefault, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterParameterDefaultList.
+func (in *ClusterParameterDefaultList) DeepCopy() *ClusterParameterDefaultList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterParameterDefaultList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterParameterDefaultList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterParameterDefaultSpec) DeepCopyInto(out *ClusterParameterDefaultSpec) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(runtime.RawExtension)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterParameterDefaultSpec.
+func (in *ClusterParameterDefaultSpec) DeepCopy() *ClusterParameterDefaultSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterParameterDefaultSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterServiceBroker) DeepCopyInto(out *ClusterServiceBroker) {
 	*out = *in
@@ -280,6 +395,24 @@ func (in *ClusterServiceBrokerAuthInfo) DeepCopyInto(out *ClusterServiceBrokerAu
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ClusterTLSAuthConfig)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ClusterOAuth2AuthConfig)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -530,6 +663,15 @@ func (in *ClusterServicePlanSpec) DeepCopyInto(out *ClusterServicePlanSpec) {
 	*out = *in
 	in.CommonServicePlanSpec.DeepCopyInto(&out.CommonServicePlanSpec)
 	out.ClusterServiceClassRef = in.ClusterServiceClassRef
+	if in.MigrationPolicy != nil {
+		in, out := &in.MigrationPolicy, &out.MigrationPolicy
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PlanMigrationPolicy)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -560,6 +702,108 @@ func (in *ClusterServicePlanStatus) DeepCopy() *ClusterServicePlanStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterServiceVisibilityPolicy) DeepCopyInto(out *ClusterServiceVisibilityPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterServiceVisibilityPolicy.
+func (in *ClusterServiceVisibilityPolicy) DeepCopy() *ClusterServiceVisibilityPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterServiceVisibilityPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterServiceVisibilityPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterServiceVisibilityPolicyList) DeepCopyInto(out *ClusterServiceVisibilityPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterServiceVisibilityPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterServiceVisibilityPolicyList.
+func (in *ClusterServiceVisibilityPolicyList) DeepCopy() *ClusterServiceVisibilityPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterServiceVisibilityPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterServiceVisibilityPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterServiceVisibilityPolicySpec) DeepCopyInto(out *ClusterServiceVisibilityPolicySpec) {
+	*out = *in
+	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterServiceVisibilityPolicySpec.
+func (in *ClusterServiceVisibilityPolicySpec) DeepCopy() *ClusterServiceVisibilityPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterServiceVisibilityPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterTLSAuthConfig) DeepCopyInto(out *ClusterTLSAuthConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ObjectReference)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterTLSAuthConfig.
+func (in *ClusterTLSAuthConfig) DeepCopy() *ClusterTLSAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterTLSAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonServiceBrokerSpec) DeepCopyInto(out *CommonServiceBrokerSpec) {
 	*out = *in
@@ -586,6 +830,15 @@ func (in *CommonServiceBrokerSpec) DeepCopyInto(out *CommonServiceBrokerSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.RateLimits != nil {
+		in, out := &in.RateLimits, &out.RateLimits
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceBrokerRateLimits)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -746,6 +999,15 @@ func (in *CommonServicePlanSpec) DeepCopyInto(out *CommonServicePlanSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.MaintenanceInfo != nil {
+		in, out := &in.MaintenanceInfo, &out.MaintenanceInfo
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(MaintenanceInfo)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -775,6 +1037,27 @@ func (in *CommonServicePlanStatus) DeepCopy() *CommonServicePlanStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapTemplate) DeepCopyInto(out *ConfigMapTemplate) {
+	*out = *in
+	if in.Keys != nil {
+		in, out := &in.Keys, &out.Keys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapTemplate.
+func (in *ConfigMapTemplate) DeepCopy() *ConfigMapTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in ExtraValue) DeepCopyInto(out *ExtraValue) {
 	{
@@ -812,73 +1095,188 @@ func (in *LocalObjectReference) DeepCopy() *LocalObjectReference {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
+func (in *MaintenanceInfo) DeepCopyInto(out *MaintenanceInfo) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
-func (in *ObjectReference) DeepCopy() *ObjectReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MaintenanceInfo.
+func (in *MaintenanceInfo) DeepCopy() *MaintenanceInfo {
 	if in == nil {
 		return nil
 	}
-	out := new(ObjectReference)
+	out := new(MaintenanceInfo)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ParametersFromSource) DeepCopyInto(out *ParametersFromSource) {
+func (in *OAuth2AuthConfig) DeepCopyInto(out *OAuth2AuthConfig) {
 	*out = *in
-	if in.SecretKeyRef != nil {
-		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
 		if *in == nil {
 			*out = nil
 		} else {
-			*out = new(SecretKeyReference)
+			*out = new(LocalObjectReference)
 			**out = **in
 		}
 	}
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParametersFromSource.
-func (in *ParametersFromSource) DeepCopy() *ParametersFromSource {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2AuthConfig.
+func (in *OAuth2AuthConfig) DeepCopy() *OAuth2AuthConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(ParametersFromSource)
+	out := new(OAuth2AuthConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PlanReference) DeepCopyInto(out *PlanReference) {
+func (in *ObjectReference) DeepCopyInto(out *ObjectReference) {
 	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanReference.
-func (in *PlanReference) DeepCopy() *PlanReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectReference.
+func (in *ObjectReference) DeepCopy() *ObjectReference {
 	if in == nil {
 		return nil
 	}
-	out := new(PlanReference)
+	out := new(ObjectReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RemoveKeyTransform) DeepCopyInto(out *RemoveKeyTransform) {
+func (in *ParametersFromSource) DeepCopyInto(out *ParametersFromSource) {
 	*out = *in
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoveKeyTransform.
-func (in *RemoveKeyTransform) DeepCopy() *RemoveKeyTransform {
-	if in == nil {
-		return nil
-	}
+	if in.SecretKeyRef != nil {
+		in, out := &in.SecretKeyRef, &out.SecretKeyRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(SecretKeyReference)
+			**out = **in
+		}
+	}
+	if in.ServiceBindingSecretKeyRef != nil {
+		in, out := &in.ServiceBindingSecretKeyRef, &out.ServiceBindingSecretKeyRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceBindingSecretKeyReference)
+			**out = **in
+		}
+	}
+	if in.ServiceInstanceFieldRef != nil {
+		in, out := &in.ServiceInstanceFieldRef, &out.ServiceInstanceFieldRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceInstanceFieldReference)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParametersFromSource.
+func (in *ParametersFromSource) DeepCopy() *ParametersFromSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ParametersFromSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanMigrationPolicy) DeepCopyInto(out *PlanMigrationPolicy) {
+	*out = *in
+	if in.MaxInProgress != nil {
+		in, out := &in.MaxInProgress, &out.MaxInProgress
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int32)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanMigrationPolicy.
+func (in *PlanMigrationPolicy) DeepCopy() *PlanMigrationPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanMigrationPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PlanReference) DeepCopyInto(out *PlanReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlanReference.
+func (in *PlanReference) DeepCopy() *PlanReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PlanReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodPresetTemplate) DeepCopyInto(out *PodPresetTemplate) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.EnvPrefix != nil {
+		in, out := &in.EnvPrefix, &out.EnvPrefix
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPresetTemplate.
+func (in *PodPresetTemplate) DeepCopy() *PodPresetTemplate {
+	if in == nil {
+		return nil
+	}
+	out := new(PodPresetTemplate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemoveKeyTransform) DeepCopyInto(out *RemoveKeyTransform) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemoveKeyTransform.
+func (in *RemoveKeyTransform) DeepCopy() *RemoveKeyTransform {
+	if in == nil {
+		return nil
+	}
 	out := new(RemoveKeyTransform)
 	in.DeepCopyInto(out)
 	return out
@@ -955,6 +1353,15 @@ func (in *SecretTransform) DeepCopyInto(out *SecretTransform) {
 			**out = **in
 		}
 	}
+	if in.TemplateKey != nil {
+		in, out := &in.TemplateKey, &out.TemplateKey
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TemplateKeyTransform)
+			**out = **in
+		}
+	}
 	return
 }
 
@@ -1080,6 +1487,22 @@ func (in *ServiceBindingPropertiesState) DeepCopy() *ServiceBindingPropertiesSta
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBindingSecretKeyReference) DeepCopyInto(out *ServiceBindingSecretKeyReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBindingSecretKeyReference.
+func (in *ServiceBindingSecretKeyReference) DeepCopy() *ServiceBindingSecretKeyReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBindingSecretKeyReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBindingSpec) DeepCopyInto(out *ServiceBindingSpec) {
 	*out = *in
@@ -1107,6 +1530,29 @@ func (in *ServiceBindingSpec) DeepCopyInto(out *ServiceBindingSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.PodPresetTemplate != nil {
+		in, out := &in.PodPresetTemplate, &out.PodPresetTemplate
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(PodPresetTemplate)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.SecretCopyNamespaces != nil {
+		in, out := &in.SecretCopyNamespaces, &out.SecretCopyNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigMapTemplate != nil {
+		in, out := &in.ConfigMapTemplate, &out.ConfigMapTemplate
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ConfigMapTemplate)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	if in.UserInfo != nil {
 		in, out := &in.UserInfo, &out.UserInfo
 		if *in == nil {
@@ -1174,6 +1620,31 @@ func (in *ServiceBindingStatus) DeepCopyInto(out *ServiceBindingStatus) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.PreviousCredentialsUnbindTime != nil {
+		in, out := &in.PreviousCredentialsUnbindTime, &out.PreviousCredentialsUnbindTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = (*in).DeepCopy()
+		}
+	}
+	if in.PreviousCredentialsUnbindOperation != nil {
+		in, out := &in.PreviousCredentialsUnbindOperation, &out.PreviousCredentialsUnbindOperation
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	if in.LastCredentialsSyncTime != nil {
+		in, out := &in.LastCredentialsSyncTime, &out.LastCredentialsSyncTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = (*in).DeepCopy()
+		}
+	}
 	return
 }
 
@@ -1236,6 +1707,24 @@ func (in *ServiceBrokerAuthInfo) DeepCopyInto(out *ServiceBrokerAuthInfo) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(TLSAuthConfig)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(OAuth2AuthConfig)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -1299,6 +1788,58 @@ func (in *ServiceBrokerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceBrokerRateLimits) DeepCopyInto(out *ServiceBrokerRateLimits) {
+	*out = *in
+	if in.MaxInFlightRequests != nil {
+		in, out := &in.MaxInFlightRequests, &out.MaxInFlightRequests
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int32)
+			**out = **in
+		}
+	}
+	if in.RequestsPerSecond != nil {
+		in, out := &in.RequestsPerSecond, &out.RequestsPerSecond
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int32)
+			**out = **in
+		}
+	}
+	if in.MaxInFlightPollRequests != nil {
+		in, out := &in.MaxInFlightPollRequests, &out.MaxInFlightPollRequests
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int32)
+			**out = **in
+		}
+	}
+	if in.PollRequestsPerSecond != nil {
+		in, out := &in.PollRequestsPerSecond, &out.PollRequestsPerSecond
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(int32)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceBrokerRateLimits.
+func (in *ServiceBrokerRateLimits) DeepCopy() *ServiceBrokerRateLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceBrokerRateLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceBrokerSpec) DeepCopyInto(out *ServiceBrokerSpec) {
 	*out = *in
@@ -1465,6 +2006,65 @@ func (in *ServiceInstance) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceAdoption) DeepCopyInto(out *ServiceInstanceAdoption) {
+	*out = *in
+	if in.Verify != nil {
+		in, out := &in.Verify, &out.Verify
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(bool)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceAdoption.
+func (in *ServiceInstanceAdoption) DeepCopy() *ServiceInstanceAdoption {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceAdoption)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceBrokerProperties) DeepCopyInto(out *ServiceInstanceBrokerProperties) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(runtime.RawExtension)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.DashboardURL != nil {
+		in, out := &in.DashboardURL, &out.DashboardURL
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(string)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceBrokerProperties.
+func (in *ServiceInstanceBrokerProperties) DeepCopy() *ServiceInstanceBrokerProperties {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceBrokerProperties)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceInstanceCondition) DeepCopyInto(out *ServiceInstanceCondition) {
 	*out = *in
@@ -1482,6 +2082,22 @@ func (in *ServiceInstanceCondition) DeepCopy() *ServiceInstanceCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceFieldReference) DeepCopyInto(out *ServiceInstanceFieldReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceFieldReference.
+func (in *ServiceInstanceFieldReference) DeepCopy() *ServiceInstanceFieldReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceFieldReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceInstanceList) DeepCopyInto(out *ServiceInstanceList) {
 	*out = *in
@@ -1515,6 +2131,31 @@ func (in *ServiceInstanceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstancePlanMigration) DeepCopyInto(out *ServiceInstancePlanMigration) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = (*in).DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstancePlanMigration.
+func (in *ServiceInstancePlanMigration) DeepCopy() *ServiceInstancePlanMigration {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstancePlanMigration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceInstancePropertiesState) DeepCopyInto(out *ServiceInstancePropertiesState) {
 	*out = *in
@@ -1549,6 +2190,152 @@ func (in *ServiceInstancePropertiesState) DeepCopy() *ServiceInstancePropertiesS
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceQuota) DeepCopyInto(out *ServiceInstanceQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceQuota.
+func (in *ServiceInstanceQuota) DeepCopy() *ServiceInstanceQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceInstanceQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceQuotaLimit) DeepCopyInto(out *ServiceInstanceQuotaLimit) {
+	*out = *in
+	if in.Free != nil {
+		in, out := &in.Free, &out.Free
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(bool)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceQuotaLimit.
+func (in *ServiceInstanceQuotaLimit) DeepCopy() *ServiceInstanceQuotaLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceQuotaLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceQuotaList) DeepCopyInto(out *ServiceInstanceQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServiceInstanceQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceQuotaList.
+func (in *ServiceInstanceQuotaList) DeepCopy() *ServiceInstanceQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ServiceInstanceQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceQuotaSpec) DeepCopyInto(out *ServiceInstanceQuotaSpec) {
+	*out = *in
+	if in.Limits != nil {
+		in, out := &in.Limits, &out.Limits
+		*out = make([]ServiceInstanceQuotaLimit, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceQuotaSpec.
+func (in *ServiceInstanceQuotaSpec) DeepCopy() *ServiceInstanceQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceQuotaStatus) DeepCopyInto(out *ServiceInstanceQuotaStatus) {
+	*out = *in
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make([]ServiceInstanceQuotaUsage, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceQuotaStatus.
+func (in *ServiceInstanceQuotaStatus) DeepCopy() *ServiceInstanceQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceInstanceQuotaUsage) DeepCopyInto(out *ServiceInstanceQuotaUsage) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceInstanceQuotaUsage.
+func (in *ServiceInstanceQuotaUsage) DeepCopy() *ServiceInstanceQuotaUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceInstanceQuotaUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ServiceInstanceSpec) DeepCopyInto(out *ServiceInstanceSpec) {
 	*out = *in
@@ -1571,6 +2358,24 @@ func (in *ServiceInstanceSpec) DeepCopyInto(out *ServiceInstanceSpec) {
 			**out = **in
 		}
 	}
+	if in.ServiceClassRef != nil {
+		in, out := &in.ServiceClassRef, &out.ServiceClassRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(LocalObjectReference)
+			**out = **in
+		}
+	}
+	if in.ServicePlanRef != nil {
+		in, out := &in.ServicePlanRef, &out.ServicePlanRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(LocalObjectReference)
+			**out = **in
+		}
+	}
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
 		if *in == nil {
@@ -1596,6 +2401,15 @@ func (in *ServiceInstanceSpec) DeepCopyInto(out *ServiceInstanceSpec) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceInstanceAdoption)
+			(*in).DeepCopyInto(*out)
+		}
+	}
 	return
 }
 
@@ -1663,6 +2477,32 @@ func (in *ServiceInstanceStatus) DeepCopyInto(out *ServiceInstanceStatus) {
 			(*in).DeepCopyInto(*out)
 		}
 	}
+	if in.PlanMigration != nil {
+		in, out := &in.PlanMigration, &out.PlanMigration
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceInstancePlanMigration)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.BrokerProperties != nil {
+		in, out := &in.BrokerProperties, &out.BrokerProperties
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(ServiceInstanceBrokerProperties)
+			(*in).DeepCopyInto(*out)
+		}
+	}
+	if in.LastBrokerSyncTime != nil {
+		in, out := &in.LastBrokerSyncTime, &out.LastBrokerSyncTime
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = (*in).DeepCopy()
+		}
+	}
 	return
 }
 
@@ -1772,6 +2612,47 @@ func (in *ServicePlanStatus) DeepCopy() *ServicePlanStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSAuthConfig) DeepCopyInto(out *TLSAuthConfig) {
+	*out = *in
+	if in.SecretRef != nil {
+		in, out := &in.SecretRef, &out.SecretRef
+		if *in == nil {
+			*out = nil
+		} else {
+			*out = new(LocalObjectReference)
+			**out = **in
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSAuthConfig.
+func (in *TLSAuthConfig) DeepCopy() *TLSAuthConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSAuthConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateKeyTransform) DeepCopyInto(out *TemplateKeyTransform) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateKeyTransform.
+func (in *TemplateKeyTransform) DeepCopy() *TemplateKeyTransform {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateKeyTransform)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserInfo) DeepCopyInto(out *UserInfo) {
 	*out = *in
@@ -30,6 +30,8 @@ import (
 func RegisterDefaults(scheme *runtime.Scheme) error {
 	scheme.AddTypeDefaultingFunc(&ClusterServiceBroker{}, func(obj interface{}) { SetObjectDefaults_ClusterServiceBroker(obj.(*ClusterServiceBroker)) })
 	scheme.AddTypeDefaultingFunc(&ClusterServiceBrokerList{}, func(obj interface{}) { SetObjectDefaults_ClusterServiceBrokerList(obj.(*ClusterServiceBrokerList)) })
+	scheme.AddTypeDefaultingFunc(&ClusterServicePlan{}, func(obj interface{}) { SetObjectDefaults_ClusterServicePlan(obj.(*ClusterServicePlan)) })
+	scheme.AddTypeDefaultingFunc(&ClusterServicePlanList{}, func(obj interface{}) { SetObjectDefaults_ClusterServicePlanList(obj.(*ClusterServicePlanList)) })
 	scheme.AddTypeDefaultingFunc(&ServiceBinding{}, func(obj interface{}) { SetObjectDefaults_ServiceBinding(obj.(*ServiceBinding)) })
 	scheme.AddTypeDefaultingFunc(&ServiceBindingList{}, func(obj interface{}) { SetObjectDefaults_ServiceBindingList(obj.(*ServiceBindingList)) })
 	scheme.AddTypeDefaultingFunc(&ServiceBroker{}, func(obj interface{}) { SetObjectDefaults_ServiceBroker(obj.(*ServiceBroker)) })
@@ -50,6 +52,19 @@ func SetObjectDefaults_ClusterServiceBrokerList(in *ClusterServiceBrokerList) {
 	}
 }
 
+func SetObjectDefaults_ClusterServicePlan(in *ClusterServicePlan) {
+	if in.Spec.MigrationPolicy != nil {
+		SetDefaults_PlanMigrationPolicy(in.Spec.MigrationPolicy)
+	}
+}
+
+func SetObjectDefaults_ClusterServicePlanList(in *ClusterServicePlanList) {
+	for i := range in.Items {
+		a := &in.Items[i]
+		SetObjectDefaults_ClusterServicePlan(a)
+	}
+}
+
 func SetObjectDefaults_ServiceBinding(in *ServiceBinding) {
 	SetDefaults_ServiceBinding(in)
 	SetDefaults_ServiceBindingSpec(&in.Spec)
@@ -75,6 +90,9 @@ func SetObjectDefaults_ServiceBrokerList(in *ServiceBrokerList) {
 
 func SetObjectDefaults_ServiceInstance(in *ServiceInstance) {
 	SetDefaults_ServiceInstanceSpec(&in.Spec)
+	if in.Spec.Adoption != nil {
+		SetDefaults_ServiceInstanceAdoption(in.Spec.Adoption)
+	}
 }
 
 func SetObjectDefaults_ServiceInstanceList(in *ServiceInstanceList) {
@@ -17,10 +17,15 @@ limitations under the License.
 package validation
 
 import (
+	"path"
+
 	"github.com/ghodss/yaml"
 	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/secrettemplate"
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 )
@@ -72,6 +77,13 @@ func internalValidateServiceBinding(binding *sc.ServiceBinding, create bool) fie
 		validateServiceBindingName,
 		field.NewPath("metadata"))...)
 	allErrs = append(allErrs, validateServiceBindingSpec(&binding.Spec, field.NewPath("spec"), create)...)
+	if len(binding.Spec.SecretCopyNamespaces) > 0 {
+		if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingTargets) {
+			allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "secretCopyNamespaces"), "secretCopyNamespaces is forbidden when the BindingTargets feature is disabled"))
+		} else {
+			allErrs = append(allErrs, validateSecretCopyNamespaces(binding.Spec.SecretCopyNamespaces, binding.Namespace, field.NewPath("spec", "secretCopyNamespaces"))...)
+		}
+	}
 	allErrs = append(allErrs, validateServiceBindingStatus(&binding.Status, field.NewPath("status"), create)...)
 	if create {
 		allErrs = append(allErrs, validateServiceBindingCreate(binding)...)
@@ -96,6 +108,108 @@ func validateServiceBindingSpec(spec *sc.ServiceBindingSpec, fldPath *field.Path
 		allErrs = append(allErrs, validateParametersFromSource(spec.ParametersFrom, fldPath)...)
 	}
 
+	for i, transform := range spec.SecretTransforms {
+		if transform.TemplateKey != nil {
+			allErrs = append(allErrs, validateTemplateKeyTransform(transform.TemplateKey, fldPath.Child("secretTransforms").Index(i).Child("templateKey"))...)
+		}
+	}
+
+	allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(spec.RotationRequests, fldPath.Child("rotationRequests"))...)
+
+	if spec.PodPresetTemplate != nil {
+		if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.PodPreset) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("podPresetTemplate"), "podPresetTemplate is forbidden when the PodPreset feature is disabled"))
+		} else {
+			allErrs = append(allErrs, validatePodPresetTemplate(spec.PodPresetTemplate, fldPath.Child("podPresetTemplate"))...)
+		}
+	}
+
+	if spec.ConfigMapTemplate != nil {
+		if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingTargets) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("configMapTemplate"), "configMapTemplate is forbidden when the BindingTargets feature is disabled"))
+		} else {
+			allErrs = append(allErrs, validateConfigMapTemplate(spec.ConfigMapTemplate, fldPath.Child("configMapTemplate"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validateTemplateKeyTransform(transform *sc.TemplateKeyTransform, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if transform.Key == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("key"), "key is required"))
+	} else {
+		for _, msg := range utilvalidation.IsConfigMapKey(transform.Key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("key"), transform.Key, msg))
+		}
+	}
+
+	if transform.Template == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("template"), "template is required"))
+	} else if _, err := secrettemplate.Parse(transform.Template); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("template"), transform.Template, err.Error()))
+	}
+
+	return allErrs
+}
+
+// validateSecretCopyNamespaces validates the namespaces into which the Secret
+// of a ServiceBinding in the given namespace is copied.
+func validateSecretCopyNamespaces(namespaces []string, bindingNamespace string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	seen := make(map[string]bool, len(namespaces))
+	for i, namespace := range namespaces {
+		idxPath := fldPath.Index(i)
+		for _, msg := range apivalidation.ValidateNamespaceName(namespace, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(idxPath, namespace, msg))
+		}
+		if namespace == bindingNamespace {
+			allErrs = append(allErrs, field.Invalid(idxPath, namespace, "the Secret cannot be copied into the namespace of the ServiceBinding"))
+		}
+		if seen[namespace] {
+			allErrs = append(allErrs, field.Duplicate(idxPath, namespace))
+		}
+		seen[namespace] = true
+	}
+
+	return allErrs
+}
+
+func validateConfigMapTemplate(template *sc.ConfigMapTemplate, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(template.Keys) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("keys"), "at least one key must be specified"))
+	}
+	for i, key := range template.Keys {
+		for _, msg := range utilvalidation.IsConfigMapKey(key) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("keys").Index(i), key, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func validatePodPresetTemplate(template *sc.PodPresetTemplate, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&template.Selector, fldPath.Child("selector"))...)
+
+	if template.EnvPrefix == nil && template.MountPath == "" {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one of envPrefix or mountPath must be specified"))
+	}
+	if template.EnvPrefix != nil && *template.EnvPrefix != "" {
+		for _, msg := range utilvalidation.IsEnvVarName(*template.EnvPrefix) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("envPrefix"), *template.EnvPrefix, msg))
+		}
+	}
+	if template.MountPath != "" && !path.IsAbs(template.MountPath) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("mountPath"), template.MountPath, "mountPath must be an absolute path"))
+	}
+
 	return allErrs
 }
 
@@ -149,6 +263,14 @@ func validateServiceBindingStatus(status *sc.ServiceBindingStatus, fldPath *fiel
 		allErrs = append(allErrs, validateServiceBindingPropertiesState(status.ExternalProperties, fldPath.Child("externalProperties"), create)...)
 	}
 
+	if status.PreviousExternalID == "" {
+		if status.PreviousCredentialsUnbindTime != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("previousCredentialsUnbindTime"), "previousCredentialsUnbindTime must not be present when previousExternalID is not present"))
+		}
+	} else if status.PreviousCredentialsUnbindTime == nil {
+		allErrs = append(allErrs, field.Required(fldPath.Child("previousCredentialsUnbindTime"), "previousCredentialsUnbindTime is required when previousExternalID is present"))
+	}
+
 	if create {
 		if status.UnbindStatus != sc.ServiceBindingUnbindStatusNotRequired {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("unbindStatus"), status.UnbindStatus, `unbindStatus must be "NotRequired" on create`))
@@ -232,10 +354,33 @@ func internalValidateServiceBindingUpdateAllowed(new *sc.ServiceBinding, old *sc
 	return errors
 }
 
+// validateServiceBindingRotation ensures that the rotationRequests counter
+// only increases, and that credentials are not rotated while a previous
+// operation or rotation is still being processed.
+func validateServiceBindingRotation(new *sc.ServiceBinding, old *sc.ServiceBinding) field.ErrorList {
+	allErrs := field.ErrorList{}
+	fldPath := field.NewPath("spec").Child("rotationRequests")
+
+	if new.Spec.RotationRequests < old.Spec.RotationRequests {
+		allErrs = append(allErrs, field.Invalid(fldPath, new.Spec.RotationRequests, "new rotationRequests value must not be less than the old one"))
+	}
+	if new.Spec.RotationRequests > old.Spec.RotationRequests {
+		if old.Status.CurrentOperation != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "credentials cannot be rotated while an operation is in progress"))
+		}
+		if old.Status.PreviousExternalID != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath, "credentials cannot be rotated until the credentials replaced by the previous rotation have been unbound"))
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateServiceBindingUpdate checks that when changing from an older binding to a newer binding is okay.
 func ValidateServiceBindingUpdate(new *sc.ServiceBinding, old *sc.ServiceBinding) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, internalValidateServiceBindingUpdateAllowed(new, old)...)
+	allErrs = append(allErrs, validateServiceBindingRotation(new, old)...)
 	allErrs = append(allErrs, internalValidateServiceBinding(new, false)...)
 	return allErrs
 }
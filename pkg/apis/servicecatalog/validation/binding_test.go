@@ -17,12 +17,15 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 )
 
 func validServiceBinding() *servicecatalog.ServiceBinding {
@@ -554,6 +557,34 @@ func TestValidateServiceBinding(t *testing.T) {
 			}(),
 			valid: true,
 		},
+		{
+			name: "previous external ID without unbind time",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Status.PreviousExternalID = "previous-id"
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "unbind time without previous external ID",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				now := metav1.Now()
+				b.Status.PreviousCredentialsUnbindTime = &now
+				return b
+			}(),
+			valid: false,
+		},
+		{
+			name: "negative rotation requests",
+			binding: func() *servicecatalog.ServiceBinding {
+				b := validServiceBinding()
+				b.Spec.RotationRequests = -1
+				return b
+			}(),
+			valid: false,
+		},
 		{
 			name: "failed unbind status on update",
 			binding: func() *servicecatalog.ServiceBinding {
@@ -635,3 +666,356 @@ func TestInternalValidateServiceBindingUpdateAllowed(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateServiceBindingPodPresetTemplate(t *testing.T) {
+	emptyPrefix := ""
+	validPrefix := "DB_"
+	invalidPrefix := "1=DB"
+
+	cases := []struct {
+		name        string
+		template    *servicecatalog.PodPresetTemplate
+		gateEnabled bool
+		valid       bool
+	}{
+		{
+			name:        "no template",
+			template:    nil,
+			gateEnabled: false,
+			valid:       true,
+		},
+		{
+			name: "feature disabled",
+			template: &servicecatalog.PodPresetTemplate{
+				EnvPrefix: &validPrefix,
+			},
+			gateEnabled: false,
+			valid:       false,
+		},
+		{
+			name: "valid env prefix",
+			template: &servicecatalog.PodPresetTemplate{
+				Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+				EnvPrefix: &validPrefix,
+			},
+			gateEnabled: true,
+			valid:       true,
+		},
+		{
+			name: "empty env prefix",
+			template: &servicecatalog.PodPresetTemplate{
+				EnvPrefix: &emptyPrefix,
+			},
+			gateEnabled: true,
+			valid:       true,
+		},
+		{
+			name: "invalid env prefix",
+			template: &servicecatalog.PodPresetTemplate{
+				EnvPrefix: &invalidPrefix,
+			},
+			gateEnabled: true,
+			valid:       false,
+		},
+		{
+			name: "valid mount path",
+			template: &servicecatalog.PodPresetTemplate{
+				MountPath: "/var/run/credentials",
+			},
+			gateEnabled: true,
+			valid:       true,
+		},
+		{
+			name: "relative mount path",
+			template: &servicecatalog.PodPresetTemplate{
+				MountPath: "credentials",
+			},
+			gateEnabled: true,
+			valid:       false,
+		},
+		{
+			name:        "neither env prefix nor mount path",
+			template:    &servicecatalog.PodPresetTemplate{},
+			gateEnabled: true,
+			valid:       false,
+		},
+		{
+			name: "invalid selector",
+			template: &servicecatalog.PodPresetTemplate{
+				Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "-"}},
+				EnvPrefix: &validPrefix,
+			},
+			gateEnabled: true,
+			valid:       false,
+		},
+	}
+
+	for _, tc := range cases {
+		err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=%v", scfeatures.PodPreset, tc.gateEnabled))
+		if err != nil {
+			t.Fatalf("Failed to set PodPreset feature: %v", err)
+		}
+
+		binding := validServiceBinding()
+		binding.Spec.PodPresetTemplate = tc.template
+		errs := internalValidateServiceBinding(binding, false)
+		if len(errs) != 0 && tc.valid {
+			t.Errorf("%v: unexpected error: %v", tc.name, errs)
+		} else if len(errs) == 0 && !tc.valid {
+			t.Errorf("%v: unexpected success", tc.name)
+		}
+	}
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.PodPreset))
+}
+
+func TestValidateServiceBindingTemplateKeyTransform(t *testing.T) {
+	cases := []struct {
+		name      string
+		transform *servicecatalog.TemplateKeyTransform
+		valid     bool
+	}{
+		{
+			name: "valid",
+			transform: &servicecatalog.TemplateKeyTransform{
+				Key:      "DATABASE_URL",
+				Template: "postgres://{{.username}}:{{.password | queryescape}}@{{.host}}:{{.port}}/{{.database}}",
+			},
+			valid: true,
+		},
+		{
+			name: "valid file key",
+			transform: &servicecatalog.TemplateKeyTransform{
+				Key:      "application.properties",
+				Template: "{{toProperties .}}",
+			},
+			valid: true,
+		},
+		{
+			name: "missing key",
+			transform: &servicecatalog.TemplateKeyTransform{
+				Template: "{{.username}}",
+			},
+			valid: false,
+		},
+		{
+			name: "invalid key",
+			transform: &servicecatalog.TemplateKeyTransform{
+				Key:      "database url",
+				Template: "{{.username}}",
+			},
+			valid: false,
+		},
+		{
+			name: "missing template",
+			transform: &servicecatalog.TemplateKeyTransform{
+				Key: "DATABASE_URL",
+			},
+			valid: false,
+		},
+		{
+			name: "malformed template",
+			transform: &servicecatalog.TemplateKeyTransform{
+				Key:      "DATABASE_URL",
+				Template: "{{.username",
+			},
+			valid: false,
+		},
+		{
+			name: "unknown function",
+			transform: &servicecatalog.TemplateKeyTransform{
+				Key:      "HOME",
+				Template: `{{env "HOME"}}`,
+			},
+			valid: false,
+		},
+		{
+			name: "unbounded range",
+			transform: &servicecatalog.TemplateKeyTransform{
+				Key:      "URL",
+				Template: "{{range 2000000000}}{{end}}",
+			},
+			valid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		binding := validServiceBinding()
+		binding.Spec.SecretTransforms = []servicecatalog.SecretTransform{
+			{RemoveKey: &servicecatalog.RemoveKeyTransform{Key: "unused"}},
+			{TemplateKey: tc.transform},
+		}
+		errs := internalValidateServiceBinding(binding, false)
+		if len(errs) != 0 && tc.valid {
+			t.Errorf("%v: unexpected error: %v", tc.name, errs)
+		} else if len(errs) == 0 && !tc.valid {
+			t.Errorf("%v: unexpected success", tc.name)
+		}
+	}
+}
+
+func TestValidateServiceBindingTargets(t *testing.T) {
+	cases := []struct {
+		name        string
+		namespaces  []string
+		template    *servicecatalog.ConfigMapTemplate
+		gateEnabled bool
+		valid       bool
+	}{
+		{
+			name:        "no targets",
+			gateEnabled: false,
+			valid:       true,
+		},
+		{
+			name:        "namespaces with feature disabled",
+			namespaces:  []string{"ns-a"},
+			gateEnabled: false,
+			valid:       false,
+		},
+		{
+			name:        "valid namespaces",
+			namespaces:  []string{"ns-a", "ns-b"},
+			gateEnabled: true,
+			valid:       true,
+		},
+		{
+			name:        "invalid namespace",
+			namespaces:  []string{"NS_A"},
+			gateEnabled: true,
+			valid:       false,
+		},
+		{
+			name:        "namespace of the binding",
+			namespaces:  []string{"test-ns"},
+			gateEnabled: true,
+			valid:       false,
+		},
+		{
+			name:        "duplicate namespace",
+			namespaces:  []string{"ns-a", "ns-a"},
+			gateEnabled: true,
+			valid:       false,
+		},
+		{
+			name: "config map template with feature disabled",
+			template: &servicecatalog.ConfigMapTemplate{
+				Keys: []string{"host"},
+			},
+			gateEnabled: false,
+			valid:       false,
+		},
+		{
+			name: "valid config map template",
+			template: &servicecatalog.ConfigMapTemplate{
+				Keys: []string{"host", "port"},
+			},
+			gateEnabled: true,
+			valid:       true,
+		},
+		{
+			name:        "no config map keys",
+			template:    &servicecatalog.ConfigMapTemplate{},
+			gateEnabled: true,
+			valid:       false,
+		},
+		{
+			name: "invalid config map key",
+			template: &servicecatalog.ConfigMapTemplate{
+				Keys: []string{"db host"},
+			},
+			gateEnabled: true,
+			valid:       false,
+		},
+	}
+
+	for _, tc := range cases {
+		err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=%v", scfeatures.BindingTargets, tc.gateEnabled))
+		if err != nil {
+			t.Fatalf("Failed to set BindingTargets feature: %v", err)
+		}
+
+		binding := validServiceBinding()
+		binding.Spec.SecretCopyNamespaces = tc.namespaces
+		binding.Spec.ConfigMapTemplate = tc.template
+		errs := internalValidateServiceBinding(binding, false)
+		if len(errs) != 0 && tc.valid {
+			t.Errorf("%v: unexpected error: %v", tc.name, errs)
+		} else if len(errs) == 0 && !tc.valid {
+			t.Errorf("%v: unexpected success", tc.name)
+		}
+	}
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.BindingTargets))
+}
+
+func TestValidateServiceBindingRotation(t *testing.T) {
+	now := metav1.Now()
+
+	cases := []struct {
+		name  string
+		old   func(*servicecatalog.ServiceBinding)
+		new   func(*servicecatalog.ServiceBinding)
+		valid bool
+	}{
+		{
+			name:  "no rotation",
+			valid: true,
+		},
+		{
+			name: "rotation requested",
+			new: func(b *servicecatalog.ServiceBinding) {
+				b.Spec.RotationRequests = 1
+			},
+			valid: true,
+		},
+		{
+			name: "rotation requests decreased",
+			old: func(b *servicecatalog.ServiceBinding) {
+				b.Spec.RotationRequests = 2
+			},
+			new: func(b *servicecatalog.ServiceBinding) {
+				b.Spec.RotationRequests = 1
+			},
+			valid: false,
+		},
+		{
+			name: "rotation requested during an operation",
+			old: func(b *servicecatalog.ServiceBinding) {
+				b.Status.CurrentOperation = servicecatalog.ServiceBindingOperationBind
+			},
+			new: func(b *servicecatalog.ServiceBinding) {
+				b.Spec.RotationRequests = 1
+			},
+			valid: false,
+		},
+		{
+			name: "rotation requested before previous credentials are unbound",
+			old: func(b *servicecatalog.ServiceBinding) {
+				b.Spec.RotationRequests = 1
+				b.Status.PreviousExternalID = "previous-id"
+				b.Status.PreviousCredentialsUnbindTime = &now
+			},
+			new: func(b *servicecatalog.ServiceBinding) {
+				b.Spec.RotationRequests = 2
+			},
+			valid: false,
+		},
+	}
+
+	for _, tc := range cases {
+		oldBinding := validServiceBinding()
+		if tc.old != nil {
+			tc.old(oldBinding)
+		}
+		newBinding := oldBinding.DeepCopy()
+		if tc.new != nil {
+			tc.new(newBinding)
+		}
+
+		errs := validateServiceBindingRotation(newBinding, oldBinding)
+		if len(errs) != 0 && tc.valid {
+			t.Errorf("%v: unexpected error: %v", tc.name, errs)
+		} else if len(errs) == 0 && !tc.valid {
+			t.Errorf("%v: unexpected success", tc.name)
+		}
+	}
+}
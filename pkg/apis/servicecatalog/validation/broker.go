@@ -17,6 +17,8 @@ limitations under the License.
 package validation
 
 import (
+	"net/url"
+
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
@@ -79,6 +81,38 @@ func validateClusterServiceBrokerSpec(spec *sc.ClusterServiceBrokerSpec, fldPath
 					field.Required(fldPath.Child("authInfo", "bearer", "secretRef"), "a basic auth secret is required"),
 				)
 			}
+		} else if spec.AuthInfo.TLS != nil {
+			secretRef := spec.AuthInfo.TLS.SecretRef
+			if secretRef != nil {
+				for _, msg := range apivalidation.ValidateNamespaceName(secretRef.Namespace, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "tls", "secretRef", "namespace"), secretRef.Namespace, msg))
+				}
+				for _, msg := range apivalidation.NameIsDNSSubdomain(secretRef.Name, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "tls", "secretRef", "name"), secretRef.Name, msg))
+				}
+			} else {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "tls", "secretRef"), "a tls secret is required"),
+				)
+			}
+		} else if spec.AuthInfo.OAuth2 != nil {
+			oauth2 := spec.AuthInfo.OAuth2
+			allErrs = append(allErrs, validateOAuth2TokenRequest(oauth2.TokenURL, oauth2.Scopes, fldPath.Child("authInfo", "oauth2"))...)
+			secretRef := oauth2.SecretRef
+			if secretRef != nil {
+				for _, msg := range apivalidation.ValidateNamespaceName(secretRef.Namespace, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "oauth2", "secretRef", "namespace"), secretRef.Namespace, msg))
+				}
+				for _, msg := range apivalidation.NameIsDNSSubdomain(secretRef.Name, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "oauth2", "secretRef", "name"), secretRef.Name, msg))
+				}
+			} else {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "oauth2", "secretRef"), "an oauth2 client secret is required"),
+				)
+			}
 		} else {
 			// Authentication
 			allErrs = append(
@@ -141,6 +175,32 @@ func validateServiceBrokerSpec(spec *sc.ServiceBrokerSpec, fldPath *field.Path)
 					field.Required(fldPath.Child("authInfo", "bearer", "secretRef"), "a basic auth secret is required"),
 				)
 			}
+		} else if spec.AuthInfo.TLS != nil {
+			secretRef := spec.AuthInfo.TLS.SecretRef
+			if secretRef != nil {
+				for _, msg := range apivalidation.NameIsDNSSubdomain(secretRef.Name, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "tls", "secretRef", "name"), secretRef.Name, msg))
+				}
+			} else {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "tls", "secretRef"), "a tls secret is required"),
+				)
+			}
+		} else if spec.AuthInfo.OAuth2 != nil {
+			oauth2 := spec.AuthInfo.OAuth2
+			allErrs = append(allErrs, validateOAuth2TokenRequest(oauth2.TokenURL, oauth2.Scopes, fldPath.Child("authInfo", "oauth2"))...)
+			secretRef := oauth2.SecretRef
+			if secretRef != nil {
+				for _, msg := range apivalidation.NameIsDNSSubdomain(secretRef.Name, false /* prefix */) {
+					allErrs = append(allErrs, field.Invalid(fldPath.Child("authInfo", "oauth2", "secretRef", "name"), secretRef.Name, msg))
+				}
+			} else {
+				allErrs = append(
+					allErrs,
+					field.Required(fldPath.Child("authInfo", "oauth2", "secretRef"), "an oauth2 client secret is required"),
+				)
+			}
 		} else {
 			// Authentication
 			allErrs = append(
@@ -159,6 +219,25 @@ func validateServiceBrokerSpec(spec *sc.ServiceBrokerSpec, fldPath *field.Path)
 	return allErrs
 }
 
+// validateOAuth2TokenRequest validates the token URL and the scopes that the
+// access tokens of a broker are requested with.
+func validateOAuth2TokenRequest(tokenURL string, scopes []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if tokenURL == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("tokenURL"), "a token url is required"))
+	} else if u, err := url.Parse(tokenURL); err != nil || u.Scheme == "" || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("tokenURL"), tokenURL, "must be an absolute url"))
+	}
+	for i, scope := range scopes {
+		if scope == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("scopes").Index(i), scope, "scopes cannot be empty"))
+		}
+	}
+
+	return allErrs
+}
+
 func validateCommonServiceBrokerSpec(spec *sc.CommonServiceBrokerSpec, fldPath *field.Path) field.ErrorList {
 	commonErrs := field.ErrorList{}
 
@@ -240,9 +319,35 @@ func validateCommonServiceBrokerSpec(spec *sc.CommonServiceBrokerSpec, fldPath *
 		}
 	}
 
+	if spec.RateLimits != nil {
+		commonErrs = append(commonErrs, validateServiceBrokerRateLimits(spec.RateLimits, fldPath.Child("rateLimits"))...)
+	}
+
 	return commonErrs
 }
 
+// validateServiceBrokerRateLimits checks that the limits that are set are not
+// negative.
+func validateServiceBrokerRateLimits(limits *sc.ServiceBrokerRateLimits, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for _, l := range []struct {
+		name  string
+		limit *int32
+	}{
+		{"maxInFlightRequests", limits.MaxInFlightRequests},
+		{"requestsPerSecond", limits.RequestsPerSecond},
+		{"maxInFlightPollRequests", limits.MaxInFlightPollRequests},
+		{"pollRequestsPerSecond", limits.PollRequestsPerSecond},
+	} {
+		if l.limit != nil && *l.limit < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(l.name), *l.limit, "must be greater than or equal to zero"))
+		}
+	}
+
+	return allErrs
+}
+
 // ValidateClusterServiceBrokerUpdate checks that when changing from an older broker to a newer broker is okay ?
 func ValidateClusterServiceBrokerUpdate(new *sc.ClusterServiceBroker, old *sc.ClusterServiceBroker) field.ErrorList {
 	allErrs := validateCommonServiceBrokerUpdate(&new.Spec.CommonServiceBrokerSpec, &old.Spec.CommonServiceBrokerSpec)
@@ -206,6 +206,175 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid clusterservicebroker - tls auth - secret",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						TLS: &servicecatalog.ClusterTLSAuthConfig{
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - tls auth - secret missing namespace",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						TLS: &servicecatalog.ClusterTLSAuthConfig{
+							SecretRef: &servicecatalog.ObjectReference{
+								Name: "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - tls auth - secret missing name",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						TLS: &servicecatalog.ClusterTLSAuthConfig{
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid clusterservicebroker - oauth2 auth - secret",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.ClusterOAuth2AuthConfig{
+							TokenURL: "https://auth.example.com/token",
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - oauth2 auth - secret missing name",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.ClusterOAuth2AuthConfig{
+							TokenURL: "https://auth.example.com/token",
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - oauth2 auth - missing token url",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.ClusterOAuth2AuthConfig{
+							TokenURL: "",
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid clusterservicebroker - oauth2 auth - relative token url",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.ClusterOAuth2AuthConfig{
+							TokenURL: "auth.example.com/token",
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
 		{
 			name: "invalid clusterservicebroker - CABundle present with InsecureSkipTLSVerify",
 			broker: &servicecatalog.ClusterServiceBroker{
@@ -353,6 +522,46 @@ func TestValidateClusterServiceBroker(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid clusterservicebroker - rate limits",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+						RateLimits: &servicecatalog.ServiceBrokerRateLimits{
+							MaxInFlightRequests:   int32Ptr(10),
+							RequestsPerSecond:     int32Ptr(5),
+							PollRequestsPerSecond: int32Ptr(0),
+						},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid clusterservicebroker - negative rate limit",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-clusterservicebroker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+						RateLimits: &servicecatalog.ServiceBrokerRateLimits{
+							MaxInFlightPollRequests: int32Ptr(-1),
+						},
+					},
+				},
+			},
+			valid: false,
+		},
 		{
 			name: "invalid clusterservicebroker - negative relistDuration value",
 			broker: &servicecatalog.ClusterServiceBroker{
@@ -769,6 +978,125 @@ func TestValidateServiceBroker(t *testing.T) {
 			},
 			valid: false,
 		},
+		{
+			name: "valid servicebroker - tls auth - secret",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						TLS: &servicecatalog.TLSAuthConfig{
+							SecretRef: &servicecatalog.LocalObjectReference{
+								Name: "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid servicebroker - tls auth - secret missing name",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						TLS: &servicecatalog.TLSAuthConfig{
+							SecretRef: &servicecatalog.LocalObjectReference{},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "valid servicebroker - oauth2 auth - secret",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.OAuth2AuthConfig{
+							TokenURL: "https://auth.example.com/token",
+							SecretRef: &servicecatalog.LocalObjectReference{
+								Name: "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: true,
+		},
+		{
+			name: "invalid servicebroker - oauth2 auth - secret missing name",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.OAuth2AuthConfig{
+							TokenURL:  "https://auth.example.com/token",
+							SecretRef: &servicecatalog.LocalObjectReference{},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
+		{
+			name: "invalid servicebroker - oauth2 auth - missing token url",
+			broker: &servicecatalog.ServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-clusterservicebroker",
+					Namespace: "test-ns",
+				},
+				Spec: servicecatalog.ServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.OAuth2AuthConfig{
+							TokenURL: "",
+							SecretRef: &servicecatalog.LocalObjectReference{
+								Name: "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: servicecatalog.ServiceBrokerRelistBehaviorDuration,
+						RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+					},
+				},
+			},
+			valid: false,
+		},
 		{
 			name: "invalid servicebroker - CABundle present with InsecureSkipTLSVerify",
 			broker: &servicecatalog.ServiceBroker{
@@ -1067,3 +1395,7 @@ func TestValidateServiceBroker(t *testing.T) {
 		}
 	}
 }
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
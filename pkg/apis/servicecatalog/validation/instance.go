@@ -63,6 +63,22 @@ var validServiceInstanceDeprovisionStatusValues = func() []string {
 	return validValues
 }()
 
+var validServiceInstanceDeletionPolicies = map[sc.ServiceInstanceDeletionPolicy]bool{
+	sc.ServiceInstanceDeletionPolicy(""):        true,
+	sc.ServiceInstanceDeletionPolicyDeprovision: true,
+	sc.ServiceInstanceDeletionPolicyRetain:      true,
+}
+
+var validServiceInstanceDeletionPolicyValues = func() []string {
+	validValues := make([]string, len(validServiceInstanceDeletionPolicies))
+	i := 0
+	for policy := range validServiceInstanceDeletionPolicies {
+		validValues[i] = string(policy)
+		i++
+	}
+	return validValues
+}()
+
 // ValidateServiceInstance validates an Instance and returns a list of errors.
 func ValidateServiceInstance(instance *sc.ServiceInstance) field.ErrorList {
 	return internalValidateServiceInstance(instance, true)
@@ -74,6 +90,11 @@ func internalValidateServiceInstance(instance *sc.ServiceInstance, create bool)
 		validateServiceInstanceName,
 		field.NewPath("metadata"))...)
 	allErrs = append(allErrs, validateServiceInstanceSpec(&instance.Spec, field.NewPath("spec"), create)...)
+	for _, paramsFrom := range instance.Spec.ParametersFrom {
+		if ref := paramsFrom.ServiceInstanceFieldRef; ref != nil && ref.Name == instance.Name {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec").Child("parametersFrom.serviceInstanceFieldRef.name"), ref.Name, "an instance cannot reference itself"))
+		}
+	}
 	allErrs = append(allErrs, validateServiceInstanceStatus(&instance.Status, field.NewPath("status"), create)...)
 	if create {
 		allErrs = append(allErrs, validateServiceInstanceCreate(instance)...)
@@ -102,6 +123,29 @@ func validateServiceInstanceSpec(spec *sc.ServiceInstanceSpec, fldPath *field.Pa
 
 	allErrs = append(allErrs, apivalidation.ValidateNonnegativeField(spec.UpdateRequests, fldPath.Child("updateRequests"))...)
 
+	if spec.MaintenanceInfoVersion != "" && !utilfeature.DefaultFeatureGate.Enabled(scfeatures.MaintenanceInfo) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("maintenanceInfoVersion"), "maintenanceInfoVersion is forbidden when the MaintenanceInfo feature is disabled"))
+	}
+
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.DeletionPolicy) {
+		if spec.DeletionPolicy != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("deletionPolicy"), "deletionPolicy is forbidden when the DeletionPolicy feature is disabled"))
+		}
+		if spec.DeletionProtection {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("deletionProtection"), "deletionProtection is forbidden when the DeletionPolicy feature is disabled"))
+		}
+	} else if !validServiceInstanceDeletionPolicies[spec.DeletionPolicy] {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("deletionPolicy"), spec.DeletionPolicy, validServiceInstanceDeletionPolicyValues))
+	}
+
+	if spec.Adoption != nil {
+		if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.InstanceAdoption) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("adoption"), "adoption is forbidden when the InstanceAdoption feature is disabled"))
+		} else if spec.ExternalID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("externalID"), "externalID is required to adopt an instance"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -175,12 +219,27 @@ func validateServiceInstanceStatus(status *sc.ServiceInstanceStatus, fldPath *fi
 func validateServiceInstancePropertiesState(propertiesState *sc.ServiceInstancePropertiesState, fldPath *field.Path, create bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 
-	if propertiesState.ClusterServicePlanExternalName == "" {
-		allErrs = append(allErrs, field.Required(fldPath.Child("clusterServicePlanExternalName"), "clusterServicePlanExternalName is required"))
-	}
+	clusterPlanSet := propertiesState.ClusterServicePlanExternalName != "" || propertiesState.ClusterServicePlanExternalID != ""
+	namespacedPlanSet := propertiesState.ServicePlanExternalName != "" || propertiesState.ServicePlanExternalID != ""
 
-	if propertiesState.ClusterServicePlanExternalID == "" {
-		allErrs = append(allErrs, field.Required(fldPath.Child("clusterServicePlanExternalID"), "clusterServicePlanExternalID is required"))
+	if clusterPlanSet && namespacedPlanSet {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("servicePlanExternalName"), "servicePlanExternalName and servicePlanExternalID must not be set together with clusterServicePlanExternalName and clusterServicePlanExternalID"))
+	} else if namespacedPlanSet {
+		if propertiesState.ServicePlanExternalName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("servicePlanExternalName"), "servicePlanExternalName is required"))
+		}
+
+		if propertiesState.ServicePlanExternalID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("servicePlanExternalID"), "servicePlanExternalID is required"))
+		}
+	} else {
+		if propertiesState.ClusterServicePlanExternalName == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("clusterServicePlanExternalName"), "clusterServicePlanExternalName is required"))
+		}
+
+		if propertiesState.ClusterServicePlanExternalID == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("clusterServicePlanExternalID"), "clusterServicePlanExternalID is required"))
+		}
 	}
 
 	if propertiesState.Parameters == nil {
@@ -224,6 +283,12 @@ func validateServiceInstanceCreate(instance *sc.ServiceInstance) field.ErrorList
 	if instance.Spec.ClusterServicePlanRef != nil {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("clusterServicePlanRef"), "clusterServicePlanRef must not be present on create"))
 	}
+	if instance.Spec.ServiceClassRef != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("serviceClassRef"), "serviceClassRef must not be present on create"))
+	}
+	if instance.Spec.ServicePlanRef != nil {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec").Child("servicePlanRef"), "servicePlanRef must not be present on create"))
+	}
 	return allErrs
 }
 
@@ -236,7 +301,21 @@ func validateServiceInstanceUpdate(instance *sc.ServiceInstance) field.ErrorList
 	} else if instance.Status.ReconciledGeneration > instance.Generation {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("status").Child("reconciledGeneration"), instance.Status.ReconciledGeneration, "reconciledGeneration must not be greater than generation"))
 	}
-	if instance.Status.CurrentOperation != "" {
+	if instance.Status.CurrentOperation != "" && instance.Spec.ServiceClassSpecified() {
+		if instance.Spec.ServiceClassRef == nil {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("serviceClassRef"), "serviceClassRef is required when currentOperation is present"))
+		}
+		if instance.Status.CurrentOperation != sc.ServiceInstanceOperationDeprovision {
+			if instance.Spec.ServicePlanRef == nil {
+				allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("servicePlanRef"), "servicePlanRef is required when currentOperation is present and not Deprovision"))
+			}
+		} else {
+			if instance.Spec.ServicePlanRef == nil &&
+				(instance.Status.ExternalProperties == nil || instance.Status.ExternalProperties.ServicePlanExternalID == "") {
+				allErrs = append(allErrs, field.Invalid(field.NewPath("status").Child("currentOperation"), instance.Status.CurrentOperation, "spec.servicePlanRef or status.externalProperties.servicePlanExternalID is required when currentOperation is Deprovision"))
+			}
+		}
+	} else if instance.Status.CurrentOperation != "" {
 		if instance.Spec.ClusterServiceClassRef == nil {
 			allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("clusterServiceClassRef"), "serviceClassRef is required when currentOperation is present"))
 		}
@@ -278,6 +357,13 @@ func internalValidateServiceInstanceUpdateAllowed(new *sc.ServiceInstance, old *
 	if planUpdated && new.Spec.ClusterServicePlanRef != nil {
 		errors = append(errors, field.Forbidden(field.NewPath("spec").Child("clusterServicePlanRef"), "clusterServicePlanRef must not be present when the plan is being changed"))
 	}
+
+	namespacedPlanUpdated := old.Spec.ServicePlanExternalName != new.Spec.ServicePlanExternalName
+	namespacedPlanUpdated = namespacedPlanUpdated || old.Spec.ServicePlanExternalID != new.Spec.ServicePlanExternalID
+	namespacedPlanUpdated = namespacedPlanUpdated || old.Spec.ServicePlanName != new.Spec.ServicePlanName
+	if namespacedPlanUpdated && new.Spec.ServicePlanRef != nil {
+		errors = append(errors, field.Forbidden(field.NewPath("spec").Child("servicePlanRef"), "servicePlanRef must not be present when the plan is being changed"))
+	}
 	return errors
 }
 
@@ -292,6 +378,7 @@ func ValidateServiceInstanceUpdate(new *sc.ServiceInstance, old *sc.ServiceInsta
 	allErrs = append(allErrs, internalValidateServiceInstance(new, false)...)
 
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(new.Spec.ExternalID, old.Spec.ExternalID, specFieldPath.Child("externalID"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(new.Spec.Adoption, old.Spec.Adoption, specFieldPath.Child("adoption"))...)
 
 	if new.Spec.UpdateRequests < old.Spec.UpdateRequests {
 		allErrs = append(allErrs, field.Invalid(specFieldPath.Child("updateRequests"), new.Spec.UpdateRequests, "new updateRequests value must not be less than the old one"))
@@ -312,6 +399,21 @@ func internalValidateServiceInstanceReferencesUpdateAllowed(new *sc.ServiceInsta
 	if new.Status.CurrentOperation != "" {
 		allErrs = append(allErrs, field.Forbidden(field.NewPath("status").Child("currentOperation"), "cannot update references when currentOperation is present"))
 	}
+	if new.Spec.ServiceClassSpecified() {
+		if new.Spec.ServiceClassRef == nil {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("serviceClassRef"), "serviceClassRef is required when updating references"))
+		}
+		if new.Spec.ServicePlanRef == nil {
+			allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("servicePlanRef"), "servicePlanRef is required when updating references"))
+		}
+		if old.Spec.ServiceClassRef != nil {
+			allErrs = append(allErrs, apivalidation.ValidateImmutableField(new.Spec.ServiceClassRef, old.Spec.ServiceClassRef, field.NewPath("spec").Child("serviceClassRef"))...)
+		}
+		if old.Spec.ServicePlanRef != nil {
+			allErrs = append(allErrs, apivalidation.ValidateImmutableField(new.Spec.ServicePlanRef, old.Spec.ServicePlanRef, field.NewPath("spec").Child("servicePlanRef"))...)
+		}
+		return allErrs
+	}
 	if new.Spec.ClusterServiceClassRef == nil {
 		allErrs = append(allErrs, field.Required(field.NewPath("spec").Child("clusterServiceClassRef"), "clusterServiceClassRef is required when updating references"))
 	}
@@ -345,17 +447,38 @@ func ValidateServiceInstanceReferencesUpdate(new *sc.ServiceInstance, old *sc.Se
 	return allErrs
 }
 
+// b2i is a helper function to test that exactly one set of plan references
+// are set.
+func b2i(b bool) int8 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 func validatePlanReference(p *sc.PlanReference, fldPath *field.Path) field.ErrorList {
+	if !p.ServiceClassSpecified() && !p.ServicePlanSpecified() {
+		return validateClusterPlanReference(p, fldPath)
+	}
+
 	allErrs := field.ErrorList{}
 
-	// helper function to test that exactly one set of plan references are set
-	b2i := func(b bool) int8 {
-		if b {
-			return 1
-		}
-		return 0
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("serviceClassExternalName"), "namespaced ServiceClasses and ServicePlans require the NamespacedServiceBroker feature to be enabled"))
+		return allErrs
 	}
 
+	if p.ClusterServiceClassSpecified() || p.ClusterServicePlanSpecified() {
+		allErrs = append(allErrs, field.Forbidden(fldPath, "cluster-scoped and namespaced class and plan references must not be mixed"))
+		return allErrs
+	}
+
+	return append(allErrs, validateNamespacedPlanReference(p, fldPath)...)
+}
+
+func validateClusterPlanReference(p *sc.PlanReference, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
 	// Just to make reading of the conditionals in the code easier.
 	externalClassNameSet := p.ClusterServiceClassExternalName != ""
 	externalPlanNameSet := p.ClusterServicePlanExternalName != ""
@@ -421,6 +544,74 @@ func validatePlanReference(p *sc.PlanReference, fldPath *field.Path) field.Error
 	return allErrs
 }
 
+func validateNamespacedPlanReference(p *sc.PlanReference, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	// Just to make reading of the conditionals in the code easier.
+	externalClassNameSet := p.ServiceClassExternalName != ""
+	externalPlanNameSet := p.ServicePlanExternalName != ""
+	externalClassIDSet := p.ServiceClassExternalID != ""
+	externalPlanIDSet := p.ServicePlanExternalID != ""
+	k8sClassSet := p.ServiceClassName != ""
+	k8sPlanSet := p.ServicePlanName != ""
+
+	// Must specify exactly one source of the class: external id, external name, k8s name.
+	if (b2i(externalClassNameSet) + b2i(externalClassIDSet) + b2i(k8sClassSet)) != 1 {
+		classSetErrMsg := "exactly one of serviceClassExternalName, serviceClassExternalID, or serviceClassName required"
+		allErrs = append(allErrs, field.Required(fldPath.Child("serviceClassExternalName"), classSetErrMsg))
+		allErrs = append(allErrs, field.Required(fldPath.Child("serviceClassExternalID"), classSetErrMsg))
+		allErrs = append(allErrs, field.Required(fldPath.Child("serviceClassName"), classSetErrMsg))
+	}
+	// Must specify exactly one source of the plan: external id, external name, k8s name.
+	if (b2i(externalPlanNameSet) + b2i(externalPlanIDSet) + b2i(k8sPlanSet)) != 1 {
+		planSetErrMsg := "exactly one of servicePlanExternalName, servicePlanExternalID, or servicePlanName required"
+		allErrs = append(allErrs, field.Required(fldPath.Child("servicePlanExternalName"), planSetErrMsg))
+		allErrs = append(allErrs, field.Required(fldPath.Child("servicePlanExternalID"), planSetErrMsg))
+		allErrs = append(allErrs, field.Required(fldPath.Child("servicePlanName"), planSetErrMsg))
+	}
+
+	if externalClassNameSet {
+		for _, msg := range validateCommonServiceClassName(p.ServiceClassExternalName, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceClassExternalName"), p.ServiceClassExternalName, msg))
+		}
+
+		// If ServiceClassExternalName given, must use ServicePlanExternalName
+		if !externalPlanNameSet {
+			allErrs = append(allErrs, field.Required(fldPath.Child("servicePlanExternalName"), "must specify servicePlanExternalName with serviceClassExternalName"))
+		}
+
+		for _, msg := range validateCommonServicePlanName(p.ServicePlanExternalName, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("servicePlanExternalName"), p.ServicePlanExternalName, msg))
+		}
+	} else if externalClassIDSet {
+		for _, msg := range validateExternalID(p.ServiceClassExternalID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceClassExternalID"), p.ServiceClassExternalID, msg))
+		}
+
+		// If ServiceClassExternalID given, must use ServicePlanExternalID
+		if !externalPlanIDSet {
+			allErrs = append(allErrs, field.Required(fldPath.Child("servicePlanExternalID"), "must specify servicePlanExternalID with serviceClassExternalID"))
+		}
+
+		for _, msg := range validateExternalID(p.ServicePlanExternalID) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("servicePlanExternalID"), p.ServicePlanExternalID, msg))
+		}
+	} else if k8sClassSet {
+		for _, msg := range validateCommonServiceClassName(p.ServiceClassName, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("serviceClassName"), p.ServiceClassName, msg))
+		}
+
+		// If ServiceClassName given, must use ServicePlanName
+		if !k8sPlanSet {
+			allErrs = append(allErrs, field.Required(fldPath.Child("servicePlanName"), "must specify servicePlanName with serviceClassName"))
+		}
+		for _, msg := range validateCommonServicePlanName(p.ServicePlanName, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("servicePlanName"), p.ServicePlanName, msg))
+		}
+	}
+	return allErrs
+}
+
 func validatePlanReferenceUpdate(pOld *sc.PlanReference, pNew *sc.PlanReference, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validatePlanReference(pOld, fldPath)...)
@@ -428,5 +619,8 @@ func validatePlanReferenceUpdate(pOld *sc.PlanReference, pNew *sc.PlanReference,
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ClusterServiceClassExternalName, pOld.ClusterServiceClassExternalName, field.NewPath("spec").Child("clusterServiceClassExternalName"))...)
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ClusterServiceClassExternalID, pOld.ClusterServiceClassExternalID, field.NewPath("spec").Child("clusterServiceClassExternalID"))...)
 	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ClusterServiceClassName, pOld.ClusterServiceClassName, field.NewPath("spec").Child("clusterServiceClassName"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ServiceClassExternalName, pOld.ServiceClassExternalName, field.NewPath("spec").Child("serviceClassExternalName"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ServiceClassExternalID, pOld.ServiceClassExternalID, field.NewPath("spec").Child("serviceClassExternalID"))...)
+	allErrs = append(allErrs, apivalidation.ValidateImmutableField(pNew.ServiceClassName, pOld.ServiceClassName, field.NewPath("spec").Child("serviceClassName"))...)
 	return allErrs
 }
@@ -17,6 +17,7 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
 	"strings"
 	"testing"
 
@@ -24,7 +25,10 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 )
 
 const (
@@ -34,6 +38,8 @@ const (
 	clusterServicePlanExternalID    = "test-clusterserviceplan-ext-id"
 	clusterServiceClassName         = "test-k8s-serviceclass"
 	clusterServicePlanName          = "test-k8s-plan-name"
+	serviceClassExternalName        = "test-serviceclass"
+	servicePlanExternalName         = "test-serviceplan"
 )
 
 func validPlanReferenceExternalName() servicecatalog.PlanReference {
@@ -57,6 +63,13 @@ func validPlanReferenceK8S() servicecatalog.PlanReference {
 	}
 }
 
+func validPlanReferenceNamespacedExternalName() servicecatalog.PlanReference {
+	return servicecatalog.PlanReference{
+		ServiceClassExternalName: serviceClassExternalName,
+		ServicePlanExternalName:  servicePlanExternalName,
+	}
+}
+
 func validServiceInstanceForCreate() *servicecatalog.ServiceInstance {
 	return &servicecatalog.ServiceInstance{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1355,6 +1368,266 @@ func TestValidatePlanReference(t *testing.T) {
 	}
 }
 
+func TestValidateNamespacedPlanReference(t *testing.T) {
+	cases := []struct {
+		name          string
+		ref           servicecatalog.PlanReference
+		enableFeature bool
+		valid         bool
+		expectedError string
+	}{
+		{
+			name:          "valid -- namespaced external names",
+			ref:           validPlanReferenceNamespacedExternalName(),
+			enableFeature: true,
+			valid:         true,
+		},
+		{
+			name: "valid -- namespaced k8s names",
+			ref: servicecatalog.PlanReference{
+				ServiceClassName: clusterServiceClassName,
+				ServicePlanName:  clusterServicePlanName,
+			},
+			enableFeature: true,
+			valid:         true,
+		},
+		{
+			name:          "invalid -- feature disabled",
+			ref:           validPlanReferenceNamespacedExternalName(),
+			enableFeature: false,
+			valid:         false,
+			expectedError: "NamespacedServiceBroker feature",
+		},
+		{
+			name: "invalid -- namespaced class, cluster plan",
+			ref: servicecatalog.PlanReference{
+				ServiceClassExternalName:       serviceClassExternalName,
+				ClusterServicePlanExternalName: clusterServicePlanExternalName,
+			},
+			enableFeature: true,
+			valid:         false,
+			expectedError: "must not be mixed",
+		},
+		{
+			name: "invalid -- namespaced external class name, k8s plan",
+			ref: servicecatalog.PlanReference{
+				ServiceClassExternalName: serviceClassExternalName,
+				ServicePlanName:          clusterServicePlanName,
+			},
+			enableFeature: true,
+			valid:         false,
+			expectedError: "must specify servicePlanExternalName",
+		},
+		{
+			name: "invalid -- namespaced plan only",
+			ref: servicecatalog.PlanReference{
+				ServicePlanExternalName: servicePlanExternalName,
+			},
+			enableFeature: true,
+			valid:         false,
+			expectedError: "exactly one of serviceClassExternalName",
+		},
+	}
+	for _, tc := range cases {
+		func() {
+			if tc.enableFeature {
+				err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.NamespacedServiceBroker))
+				if err != nil {
+					t.Fatalf("Failed to enable namespaced service broker feature: %v", err)
+				}
+				defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.NamespacedServiceBroker))
+			}
+
+			errs := validatePlanReference(&tc.ref, field.NewPath("spec"))
+			if len(errs) != 0 {
+				if tc.valid {
+					t.Errorf("%v: unexpected error: %v", tc.name, errs)
+					return
+				}
+				found := false
+				for _, e := range errs {
+					if strings.Contains(e.Error(), tc.expectedError) {
+						found = true
+					}
+				}
+				if !found {
+					t.Errorf("%v: did not find expected error %q in errors: %v", tc.name, tc.expectedError, errs)
+				}
+			} else if !tc.valid {
+				t.Errorf("%v: unexpected success", tc.name)
+			}
+		}()
+	}
+}
+
+func TestValidateServiceInstanceMaintenanceInfoVersion(t *testing.T) {
+	cases := []struct {
+		name          string
+		enableFeature bool
+		valid         bool
+	}{
+		{
+			name:          "feature enabled",
+			enableFeature: true,
+			valid:         true,
+		},
+		{
+			name:          "feature disabled",
+			enableFeature: false,
+			valid:         false,
+		},
+	}
+	for _, tc := range cases {
+		err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=%v", scfeatures.MaintenanceInfo, tc.enableFeature))
+		if err != nil {
+			t.Fatalf("Failed to set MaintenanceInfo feature: %v", err)
+		}
+
+		instance := validServiceInstance()
+		instance.Spec.MaintenanceInfoVersion = "2.0.0"
+		errs := validateServiceInstanceSpec(&instance.Spec, field.NewPath("spec"), false)
+		if len(errs) != 0 && tc.valid {
+			t.Errorf("%v: unexpected error: %v", tc.name, errs)
+		} else if len(errs) == 0 && !tc.valid {
+			t.Errorf("%v: unexpected success", tc.name)
+		}
+	}
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.MaintenanceInfo))
+}
+
+func TestValidateServiceInstanceDeletionPolicy(t *testing.T) {
+	cases := []struct {
+		name               string
+		enableFeature      bool
+		deletionPolicy     servicecatalog.ServiceInstanceDeletionPolicy
+		deletionProtection bool
+		valid              bool
+	}{
+		{
+			name:           "retain",
+			enableFeature:  true,
+			deletionPolicy: servicecatalog.ServiceInstanceDeletionPolicyRetain,
+			valid:          true,
+		},
+		{
+			name:           "deprovision",
+			enableFeature:  true,
+			deletionPolicy: servicecatalog.ServiceInstanceDeletionPolicyDeprovision,
+			valid:          true,
+		},
+		{
+			name:               "deletion protection",
+			enableFeature:      true,
+			deletionProtection: true,
+			valid:              true,
+		},
+		{
+			name:           "unknown policy",
+			enableFeature:  true,
+			deletionPolicy: "Orphan",
+			valid:          false,
+		},
+		{
+			name:           "policy with feature disabled",
+			enableFeature:  false,
+			deletionPolicy: servicecatalog.ServiceInstanceDeletionPolicyRetain,
+			valid:          false,
+		},
+		{
+			name:               "deletion protection with feature disabled",
+			enableFeature:      false,
+			deletionProtection: true,
+			valid:              false,
+		},
+	}
+	for _, tc := range cases {
+		err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=%v", scfeatures.DeletionPolicy, tc.enableFeature))
+		if err != nil {
+			t.Fatalf("Failed to set DeletionPolicy feature: %v", err)
+		}
+
+		instance := validServiceInstance()
+		instance.Spec.DeletionPolicy = tc.deletionPolicy
+		instance.Spec.DeletionProtection = tc.deletionProtection
+		errs := validateServiceInstanceSpec(&instance.Spec, field.NewPath("spec"), false)
+		if len(errs) != 0 && tc.valid {
+			t.Errorf("%v: unexpected error: %v", tc.name, errs)
+		} else if len(errs) == 0 && !tc.valid {
+			t.Errorf("%v: unexpected success", tc.name)
+		}
+	}
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.DeletionPolicy))
+}
+
+func TestValidateServiceInstanceAdoption(t *testing.T) {
+	cases := []struct {
+		name          string
+		enableFeature bool
+		externalID    string
+		valid         bool
+	}{
+		{
+			name:          "adoption",
+			enableFeature: true,
+			externalID:    "existing-instance-id",
+			valid:         true,
+		},
+		{
+			name:          "adoption without external ID",
+			enableFeature: true,
+			valid:         false,
+		},
+		{
+			name:          "adoption with feature disabled",
+			enableFeature: false,
+			externalID:    "existing-instance-id",
+			valid:         false,
+		},
+	}
+	for _, tc := range cases {
+		err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=%v", scfeatures.InstanceAdoption, tc.enableFeature))
+		if err != nil {
+			t.Fatalf("Failed to set InstanceAdoption feature: %v", err)
+		}
+
+		instance := validServiceInstance()
+		instance.Spec.ExternalID = tc.externalID
+		verify := true
+		instance.Spec.Adoption = &servicecatalog.ServiceInstanceAdoption{Verify: &verify}
+		errs := validateServiceInstanceSpec(&instance.Spec, field.NewPath("spec"), true)
+		if len(errs) != 0 && tc.valid {
+			t.Errorf("%v: unexpected error: %v", tc.name, errs)
+		} else if len(errs) == 0 && !tc.valid {
+			t.Errorf("%v: unexpected success", tc.name)
+		}
+	}
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.InstanceAdoption))
+}
+
+func TestValidateServiceInstanceAdoptionUpdate(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.InstanceAdoption))
+	if err != nil {
+		t.Fatalf("Failed to enable InstanceAdoption feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.InstanceAdoption))
+
+	old := validServiceInstance()
+	old.Spec.ExternalID = "existing-instance-id"
+	old.Spec.Adoption = &servicecatalog.ServiceInstanceAdoption{}
+
+	unchanged := old.DeepCopy()
+	if errs := ValidateServiceInstanceUpdate(unchanged, old); len(errs) != 0 {
+		t.Errorf("unexpected error: %v", errs)
+	}
+
+	changed := old.DeepCopy()
+	verify := false
+	changed.Spec.Adoption.Verify = &verify
+	if errs := ValidateServiceInstanceUpdate(changed, old); len(errs) == 0 {
+		t.Error("expected an error changing the adoption of an instance")
+	}
+}
+
 func TestValidatePlanReferenceUpdate(t *testing.T) {
 	cases := []struct {
 		name          string
@@ -1455,3 +1728,94 @@ func TestValidatePlanReferenceUpdate(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateServiceInstanceParametersFromReferences(t *testing.T) {
+	cases := []struct {
+		name           string
+		enableFeature  bool
+		parametersFrom servicecatalog.ParametersFromSource
+		valid          bool
+	}{
+		{
+			name:          "binding secret key",
+			enableFeature: true,
+			parametersFrom: servicecatalog.ParametersFromSource{
+				ServiceBindingSecretKeyRef: &servicecatalog.ServiceBindingSecretKeyReference{Name: "vpc-binding", Key: "vpcID", Parameter: "vpcID"},
+			},
+			valid: true,
+		},
+		{
+			name:          "binding secret key without key",
+			enableFeature: true,
+			parametersFrom: servicecatalog.ParametersFromSource{
+				ServiceBindingSecretKeyRef: &servicecatalog.ServiceBindingSecretKeyReference{Name: "vpc-binding"},
+			},
+			valid: false,
+		},
+		{
+			name:          "instance field",
+			enableFeature: true,
+			parametersFrom: servicecatalog.ParametersFromSource{
+				ServiceInstanceFieldRef: &servicecatalog.ServiceInstanceFieldReference{Name: "database", FieldPath: "status.brokerProperties.parameters.host", Parameter: "host"},
+			},
+			valid: true,
+		},
+		{
+			name:          "unsupported instance field",
+			enableFeature: true,
+			parametersFrom: servicecatalog.ParametersFromSource{
+				ServiceInstanceFieldRef: &servicecatalog.ServiceInstanceFieldReference{Name: "database", FieldPath: "status.externalProperties.parameters.", Parameter: "host"},
+			},
+			valid: false,
+		},
+		{
+			name:          "instance field without parameter",
+			enableFeature: true,
+			parametersFrom: servicecatalog.ParametersFromSource{
+				ServiceInstanceFieldRef: &servicecatalog.ServiceInstanceFieldReference{Name: "database", FieldPath: "spec.externalID"},
+			},
+			valid: false,
+		},
+		{
+			name:          "self reference",
+			enableFeature: true,
+			parametersFrom: servicecatalog.ParametersFromSource{
+				ServiceInstanceFieldRef: &servicecatalog.ServiceInstanceFieldReference{Name: "test-instance", FieldPath: "spec.externalID", Parameter: "id"},
+			},
+			valid: false,
+		},
+		{
+			name:          "several sources",
+			enableFeature: true,
+			parametersFrom: servicecatalog.ParametersFromSource{
+				SecretKeyRef:            &servicecatalog.SecretKeyReference{Name: "params", Key: "json"},
+				ServiceInstanceFieldRef: &servicecatalog.ServiceInstanceFieldReference{Name: "database", FieldPath: "spec.externalID", Parameter: "id"},
+			},
+			valid: false,
+		},
+		{
+			name:          "references with feature disabled",
+			enableFeature: false,
+			parametersFrom: servicecatalog.ParametersFromSource{
+				ServiceBindingSecretKeyRef: &servicecatalog.ServiceBindingSecretKeyReference{Name: "vpc-binding", Key: "vpcID", Parameter: "vpcID"},
+			},
+			valid: false,
+		},
+	}
+	for _, tc := range cases {
+		err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=%v", scfeatures.CrossInstanceReferences, tc.enableFeature))
+		if err != nil {
+			t.Fatalf("Failed to set CrossInstanceReferences feature: %v", err)
+		}
+
+		instance := validServiceInstanceForCreate()
+		instance.Spec.ParametersFrom = []servicecatalog.ParametersFromSource{tc.parametersFrom}
+		errs := ValidateServiceInstance(instance)
+		if len(errs) != 0 && tc.valid {
+			t.Errorf("%v: unexpected error: %v", tc.name, errs)
+		} else if len(errs) == 0 && !tc.valid {
+			t.Errorf("%v: unexpected success", tc.name)
+		}
+	}
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.CrossInstanceReferences))
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/controller"
+)
+
+// validateClusterParameterDefaultName is the validation function for
+// ClusterParameterDefault names.
+var validateClusterParameterDefaultName = apivalidation.NameIsDNSSubdomain
+
+// ValidateClusterParameterDefault validates a ClusterParameterDefault and
+// returns a list of errors.
+func ValidateClusterParameterDefault(parameterDefault *sc.ClusterParameterDefault) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs,
+		apivalidation.ValidateObjectMeta(
+			&parameterDefault.ObjectMeta,
+			false, /* namespace required */
+			validateClusterParameterDefaultName,
+			field.NewPath("metadata"))...)
+
+	allErrs = append(allErrs, validateClusterParameterDefaultSpec(&parameterDefault.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateClusterParameterDefaultUpdate checks that an update to a
+// ClusterParameterDefault is valid.
+func ValidateClusterParameterDefaultUpdate(new *sc.ClusterParameterDefault, old *sc.ClusterParameterDefault) field.ErrorList {
+	return ValidateClusterParameterDefault(new)
+}
+
+func validateClusterParameterDefaultSpec(spec *sc.ClusterParameterDefaultSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.ClusterServiceClassExternalName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("clusterServiceClassExternalName"), "clusterServiceClassExternalName is required"))
+	}
+	for _, msg := range validateCommonServiceClassName(spec.ClusterServiceClassExternalName, false /* prefix */) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterServiceClassExternalName"), spec.ClusterServiceClassExternalName, msg))
+	}
+
+	if spec.ClusterServicePlanExternalName != "" {
+		for _, msg := range validateCommonServicePlanName(spec.ClusterServicePlanExternalName, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterServicePlanExternalName"), spec.ClusterServicePlanExternalName, msg))
+		}
+	}
+
+	if spec.Parameters == nil || len(spec.Parameters.Raw) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("parameters"), "parameters are required"))
+	} else if _, err := controller.UnmarshalRawParameters(spec.Parameters.Raw); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("parameters"), "", "parameters must be a JSON object"))
+	}
+
+	return allErrs
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+)
+
+func validClusterParameterDefault() *servicecatalog.ClusterParameterDefault {
+	return &servicecatalog.ClusterParameterDefault{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-clusterparameterdefault",
+		},
+		Spec: servicecatalog.ClusterParameterDefaultSpec{
+			ClusterServiceClassExternalName: "test-serviceclass",
+			ClusterServicePlanExternalName:  "test-plan",
+			Parameters:                      &runtime.RawExtension{Raw: []byte(`{"region": "eu"}`)},
+		},
+	}
+}
+
+func TestValidateClusterParameterDefault(t *testing.T) {
+	testCases := []struct {
+		name             string
+		parameterDefault *servicecatalog.ClusterParameterDefault
+		valid            bool
+	}{
+		{
+			name:             "valid ClusterParameterDefault",
+			parameterDefault: validClusterParameterDefault(),
+			valid:            true,
+		},
+		{
+			name: "valid ClusterParameterDefault - no plan",
+			parameterDefault: func() *servicecatalog.ClusterParameterDefault {
+				d := validClusterParameterDefault()
+				d.Spec.ClusterServicePlanExternalName = ""
+				return d
+			}(),
+			valid: true,
+		},
+		{
+			name: "bad name",
+			parameterDefault: func() *servicecatalog.ClusterParameterDefault {
+				d := validClusterParameterDefault()
+				d.Name = "#"
+				return d
+			}(),
+			valid: false,
+		},
+		{
+			name: "missing clusterServiceClassExternalName",
+			parameterDefault: func() *servicecatalog.ClusterParameterDefault {
+				d := validClusterParameterDefault()
+				d.Spec.ClusterServiceClassExternalName = ""
+				return d
+			}(),
+			valid: false,
+		},
+		{
+			name: "bad clusterServicePlanExternalName",
+			parameterDefault: func() *servicecatalog.ClusterParameterDefault {
+				d := validClusterParameterDefault()
+				d.Spec.ClusterServicePlanExternalName = "#"
+				return d
+			}(),
+			valid: false,
+		},
+		{
+			name: "missing parameters",
+			parameterDefault: func() *servicecatalog.ClusterParameterDefault {
+				d := validClusterParameterDefault()
+				d.Spec.Parameters = nil
+				return d
+			}(),
+			valid: false,
+		},
+		{
+			name: "parameters not an object",
+			parameterDefault: func() *servicecatalog.ClusterParameterDefault {
+				d := validClusterParameterDefault()
+				d.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`["eu"]`)}
+				return d
+			}(),
+			valid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			errs := ValidateClusterParameterDefault(tc.parameterDefault)
+			t.Log(errs)
+			if len(errs) != 0 && tc.valid {
+				t.Errorf("%v: unexpected error: %v", tc.name, errs)
+			} else if len(errs) == 0 && !tc.valid {
+				t.Errorf("%v: unexpected success", tc.name)
+			}
+		})
+	}
+}
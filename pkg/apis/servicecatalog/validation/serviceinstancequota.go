@@ -0,0 +1,113 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+)
+
+// validateServiceInstanceQuotaName is the validation function for
+// ServiceInstanceQuota names.
+var validateServiceInstanceQuotaName = apivalidation.NameIsDNSSubdomain
+
+// ValidateServiceInstanceQuota validates a ServiceInstanceQuota and returns a
+// list of errors.
+func ValidateServiceInstanceQuota(quota *sc.ServiceInstanceQuota) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs,
+		apivalidation.ValidateObjectMeta(
+			&quota.ObjectMeta,
+			true, /* namespace required */
+			validateServiceInstanceQuotaName,
+			field.NewPath("metadata"))...)
+
+	allErrs = append(allErrs, validateServiceInstanceQuotaSpec(&quota.Spec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateServiceInstanceQuotaStatus(&quota.Status, field.NewPath("status"))...)
+	return allErrs
+}
+
+// ValidateServiceInstanceQuotaUpdate checks that an update to a
+// ServiceInstanceQuota is valid.
+func ValidateServiceInstanceQuotaUpdate(new *sc.ServiceInstanceQuota, old *sc.ServiceInstanceQuota) field.ErrorList {
+	return ValidateServiceInstanceQuota(new)
+}
+
+// ValidateServiceInstanceQuotaStatusUpdate checks that an update to the
+// status of a ServiceInstanceQuota is valid.
+func ValidateServiceInstanceQuotaStatusUpdate(new *sc.ServiceInstanceQuota, old *sc.ServiceInstanceQuota) field.ErrorList {
+	return ValidateServiceInstanceQuotaUpdate(new, old)
+}
+
+func validateServiceInstanceQuotaSpec(spec *sc.ServiceInstanceQuotaSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if len(spec.Limits) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("limits"), "at least one limit is required"))
+	}
+
+	names := map[string]bool{}
+	for i, limit := range spec.Limits {
+		limitPath := fldPath.Child("limits").Index(i)
+
+		if limit.Name == "" {
+			allErrs = append(allErrs, field.Required(limitPath.Child("name"), "name is required"))
+		} else if names[limit.Name] {
+			allErrs = append(allErrs, field.Duplicate(limitPath.Child("name"), limit.Name))
+		}
+		for _, msg := range utilvalidation.IsDNS1123Label(limit.Name) {
+			allErrs = append(allErrs, field.Invalid(limitPath.Child("name"), limit.Name, msg))
+		}
+		names[limit.Name] = true
+
+		if limit.ServiceClassExternalName != "" {
+			for _, msg := range validateCommonServiceClassName(limit.ServiceClassExternalName, false /* prefix */) {
+				allErrs = append(allErrs, field.Invalid(limitPath.Child("serviceClassExternalName"), limit.ServiceClassExternalName, msg))
+			}
+		}
+		if limit.ServicePlanExternalName != "" {
+			if limit.ServiceClassExternalName == "" {
+				allErrs = append(allErrs, field.Required(limitPath.Child("serviceClassExternalName"), "serviceClassExternalName is required when servicePlanExternalName is set"))
+			}
+			for _, msg := range validateCommonServicePlanName(limit.ServicePlanExternalName, false /* prefix */) {
+				allErrs = append(allErrs, field.Invalid(limitPath.Child("servicePlanExternalName"), limit.ServicePlanExternalName, msg))
+			}
+		}
+
+		if limit.MaxInstances < 0 {
+			allErrs = append(allErrs, field.Invalid(limitPath.Child("maxInstances"), limit.MaxInstances, "maxInstances must not be negative"))
+		}
+	}
+
+	return allErrs
+}
+
+func validateServiceInstanceQuotaStatus(status *sc.ServiceInstanceQuotaStatus, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	for i, used := range status.Used {
+		if used.Instances < 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("used").Index(i).Child("instances"), used.Instances, "instances must not be negative"))
+		}
+	}
+
+	return allErrs
+}
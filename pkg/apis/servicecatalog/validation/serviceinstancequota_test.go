@@ -0,0 +1,143 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+)
+
+func validServiceInstanceQuota() *servicecatalog.ServiceInstanceQuota {
+	notFree := false
+	return &servicecatalog.ServiceInstanceQuota{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-serviceinstancequota",
+			Namespace: "test-ns",
+		},
+		Spec: servicecatalog.ServiceInstanceQuotaSpec{
+			Limits: []servicecatalog.ServiceInstanceQuotaLimit{
+				{Name: "all", MaxInstances: 10},
+				{Name: "databases", ServiceClassExternalName: "test-serviceclass", MaxInstances: 3},
+				{Name: "large-databases", ServiceClassExternalName: "test-serviceclass", ServicePlanExternalName: "large", MaxInstances: 1},
+				{Name: "non-free", Free: &notFree, MaxInstances: 0},
+			},
+		},
+	}
+}
+
+func TestValidateServiceInstanceQuota(t *testing.T) {
+	testCases := []struct {
+		name  string
+		quota *servicecatalog.ServiceInstanceQuota
+		valid bool
+	}{
+		{
+			name:  "valid ServiceInstanceQuota",
+			quota: validServiceInstanceQuota(),
+			valid: true,
+		},
+		{
+			name: "missing namespace",
+			quota: func() *servicecatalog.ServiceInstanceQuota {
+				q := validServiceInstanceQuota()
+				q.Namespace = ""
+				return q
+			}(),
+			valid: false,
+		},
+		{
+			name: "no limits",
+			quota: func() *servicecatalog.ServiceInstanceQuota {
+				q := validServiceInstanceQuota()
+				q.Spec.Limits = nil
+				return q
+			}(),
+			valid: false,
+		},
+		{
+			name: "missing limit name",
+			quota: func() *servicecatalog.ServiceInstanceQuota {
+				q := validServiceInstanceQuota()
+				q.Spec.Limits[0].Name = ""
+				return q
+			}(),
+			valid: false,
+		},
+		{
+			name: "duplicate limit name",
+			quota: func() *servicecatalog.ServiceInstanceQuota {
+				q := validServiceInstanceQuota()
+				q.Spec.Limits[1].Name = "all"
+				return q
+			}(),
+			valid: false,
+		},
+		{
+			name: "plan without class",
+			quota: func() *servicecatalog.ServiceInstanceQuota {
+				q := validServiceInstanceQuota()
+				q.Spec.Limits[2].ServiceClassExternalName = ""
+				return q
+			}(),
+			valid: false,
+		},
+		{
+			name: "bad servicePlanExternalName",
+			quota: func() *servicecatalog.ServiceInstanceQuota {
+				q := validServiceInstanceQuota()
+				q.Spec.Limits[2].ServicePlanExternalName = "#"
+				return q
+			}(),
+			valid: false,
+		},
+		{
+			name: "negative maxInstances",
+			quota: func() *servicecatalog.ServiceInstanceQuota {
+				q := validServiceInstanceQuota()
+				q.Spec.Limits[0].MaxInstances = -1
+				return q
+			}(),
+			valid: false,
+		},
+		{
+			name: "negative usage",
+			quota: func() *servicecatalog.ServiceInstanceQuota {
+				q := validServiceInstanceQuota()
+				q.Status.Used = []servicecatalog.ServiceInstanceQuotaUsage{{Name: "all", Instances: -1}}
+				return q
+			}(),
+			valid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			errs := ValidateServiceInstanceQuota(tc.quota)
+			t.Log(errs)
+			if len(errs) != 0 && tc.valid {
+				t.Errorf("%v: unexpected error: %v", tc.name, errs)
+			} else if len(errs) == 0 && !tc.valid {
+				t.Errorf("%v: unexpected success", tc.name)
+			}
+		})
+	}
+}
@@ -23,8 +23,10 @@ import (
 	apivalidation "k8s.io/apimachinery/pkg/api/validation"
 	utilvalidation "k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 )
 
 const commonServicePlanNameFmt string = `[-.a-zA-Z0-9]+`
@@ -123,6 +125,35 @@ func validateClusterServicePlanSpec(spec *sc.ClusterServicePlanSpec, fldPath *fi
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterServiceClassRef", "name"), spec.ClusterServiceClassRef.Name, msg))
 	}
 
+	if spec.MigrationPolicy != nil {
+		if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.PlanMigration) {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("migrationPolicy"), "migrationPolicy is forbidden when the PlanMigration feature is disabled"))
+		} else {
+			allErrs = append(allErrs, validatePlanMigrationPolicy(spec.MigrationPolicy, spec.ExternalName, fldPath.Child("migrationPolicy"))...)
+		}
+	}
+
+	return allErrs
+}
+
+func validatePlanMigrationPolicy(policy *sc.PlanMigrationPolicy, planExternalName string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if policy.ReplacementPlanExternalName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("replacementPlanExternalName"), "replacementPlanExternalName is required"))
+	} else {
+		for _, msg := range validateCommonServicePlanName(policy.ReplacementPlanExternalName, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("replacementPlanExternalName"), policy.ReplacementPlanExternalName, msg))
+		}
+		if policy.ReplacementPlanExternalName == planExternalName {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("replacementPlanExternalName"), policy.ReplacementPlanExternalName, "a plan cannot be replaced by itself"))
+		}
+	}
+
+	if policy.MaxInProgress != nil && *policy.MaxInProgress < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxInProgress"), *policy.MaxInProgress, "maxInProgress must be at least 1"))
+	}
+
 	return allErrs
 }
 
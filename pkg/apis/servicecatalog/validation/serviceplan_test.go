@@ -17,11 +17,14 @@ limitations under the License.
 package validation
 
 import (
+	"fmt"
 	"testing"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 )
 
 func validClusterServicePlan() *servicecatalog.ClusterServicePlan {
@@ -210,6 +213,77 @@ func TestValidateClusterServicePlan(t *testing.T) {
 	}
 }
 
+func TestValidateClusterServicePlanMigrationPolicy(t *testing.T) {
+	zero := int32(0)
+	two := int32(2)
+
+	cases := []struct {
+		name        string
+		policy      *servicecatalog.PlanMigrationPolicy
+		gateEnabled bool
+		valid       bool
+	}{
+		{
+			name:        "no policy",
+			gateEnabled: false,
+			valid:       true,
+		},
+		{
+			name:        "policy with feature disabled",
+			policy:      &servicecatalog.PlanMigrationPolicy{ReplacementPlanExternalName: "replacement"},
+			gateEnabled: false,
+			valid:       false,
+		},
+		{
+			name:        "valid policy",
+			policy:      &servicecatalog.PlanMigrationPolicy{ReplacementPlanExternalName: "replacement", MaxInProgress: &two},
+			gateEnabled: true,
+			valid:       true,
+		},
+		{
+			name:        "missing replacement plan",
+			policy:      &servicecatalog.PlanMigrationPolicy{},
+			gateEnabled: true,
+			valid:       false,
+		},
+		{
+			name:        "bad replacement plan",
+			policy:      &servicecatalog.PlanMigrationPolicy{ReplacementPlanExternalName: "#"},
+			gateEnabled: true,
+			valid:       false,
+		},
+		{
+			name:        "plan replaced by itself",
+			policy:      &servicecatalog.PlanMigrationPolicy{ReplacementPlanExternalName: "test-clusterserviceplan"},
+			gateEnabled: true,
+			valid:       false,
+		},
+		{
+			name:        "no instances in progress",
+			policy:      &servicecatalog.PlanMigrationPolicy{ReplacementPlanExternalName: "replacement", MaxInProgress: &zero},
+			gateEnabled: true,
+			valid:       false,
+		},
+	}
+
+	for _, tc := range cases {
+		err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=%v", scfeatures.PlanMigration, tc.gateEnabled))
+		if err != nil {
+			t.Fatalf("Failed to set PlanMigration feature: %v", err)
+		}
+
+		plan := validClusterServicePlan()
+		plan.Spec.MigrationPolicy = tc.policy
+		errs := ValidateClusterServicePlan(plan)
+		if len(errs) != 0 && tc.valid {
+			t.Errorf("%v: unexpected error: %v", tc.name, errs)
+		} else if len(errs) == 0 && !tc.valid {
+			t.Errorf("%v: unexpected success", tc.name)
+		}
+	}
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.PlanMigration))
+}
+
 func TestValidateServicePlan(t *testing.T) {
 	testCases := []struct {
 		name        string
@@ -17,9 +17,13 @@ limitations under the License.
 package validation
 
 import (
+	"regexp"
+	"strings"
+
 	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	"k8s.io/apimachinery/pkg/util/validation/field"
-	"regexp"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 )
 
 var hexademicalStringRegexp = regexp.MustCompile("^[[:xdigit:]]*$")
@@ -32,17 +36,65 @@ func validateParametersFromSource(parametersFrom []sc.ParametersFromSource, fldP
 	allErrs := field.ErrorList{}
 
 	for _, paramsFrom := range parametersFrom {
+		sources := 0
 		if paramsFrom.SecretKeyRef != nil {
+			sources++
 			if paramsFrom.SecretKeyRef.Name == "" {
 				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.secretKeyRef.name"), "name is required"))
 			}
 			if paramsFrom.SecretKeyRef.Key == "" {
 				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.secretKeyRef.key"), "key is required"))
 			}
-		} else {
+		}
+		if ref := paramsFrom.ServiceBindingSecretKeyRef; ref != nil {
+			sources++
+			if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.CrossInstanceReferences) {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("parametersFrom.serviceBindingSecretKeyRef"), "serviceBindingSecretKeyRef is forbidden when the CrossInstanceReferences feature is disabled"))
+			}
+			if ref.Name == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.serviceBindingSecretKeyRef.name"), "name is required"))
+			}
+			if ref.Key == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.serviceBindingSecretKeyRef.key"), "key is required"))
+			}
+		}
+		if ref := paramsFrom.ServiceInstanceFieldRef; ref != nil {
+			sources++
+			if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.CrossInstanceReferences) {
+				allErrs = append(allErrs, field.Forbidden(fldPath.Child("parametersFrom.serviceInstanceFieldRef"), "serviceInstanceFieldRef is forbidden when the CrossInstanceReferences feature is disabled"))
+			}
+			if ref.Name == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.serviceInstanceFieldRef.name"), "name is required"))
+			}
+			if !isValidServiceInstanceFieldPath(ref.FieldPath) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("parametersFrom.serviceInstanceFieldRef.fieldPath"), ref.FieldPath, "fieldPath is not a supported field of a ServiceInstance"))
+			}
+			if ref.Parameter == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom.serviceInstanceFieldRef.parameter"), "parameter is required"))
+			}
+		}
+		switch {
+		case sources == 0:
 			allErrs = append(allErrs, field.Required(fldPath.Child("parametersFrom"), "source must not be empty if present"))
+		case sources > 1:
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("parametersFrom"), paramsFrom, "only one source may be set"))
 		}
 	}
 
 	return allErrs
 }
+
+// isValidServiceInstanceFieldPath returns whether the given path is a field of
+// a ServiceInstance that a ServiceInstanceFieldReference can select.
+func isValidServiceInstanceFieldPath(fieldPath string) bool {
+	switch fieldPath {
+	case sc.ServiceInstanceFieldPathExternalID, sc.ServiceInstanceFieldPathDashboardURL:
+		return true
+	}
+	for _, prefix := range []string{sc.ServiceInstanceFieldPathExternalParameterPrefix, sc.ServiceInstanceFieldPathBrokerParameterPrefix} {
+		if strings.HasPrefix(fieldPath, prefix) && len(fieldPath) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+)
+
+// validateClusterServiceVisibilityPolicyName is the validation function for
+// ClusterServiceVisibilityPolicy names.
+var validateClusterServiceVisibilityPolicyName = apivalidation.NameIsDNSSubdomain
+
+// ValidateClusterServiceVisibilityPolicy validates a
+// ClusterServiceVisibilityPolicy and returns a list of errors.
+func ValidateClusterServiceVisibilityPolicy(policy *sc.ClusterServiceVisibilityPolicy) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	allErrs = append(allErrs,
+		apivalidation.ValidateObjectMeta(
+			&policy.ObjectMeta,
+			false, /* namespace required */
+			validateClusterServiceVisibilityPolicyName,
+			field.NewPath("metadata"))...)
+
+	allErrs = append(allErrs, validateClusterServiceVisibilityPolicySpec(&policy.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateClusterServiceVisibilityPolicyUpdate checks that an update to a
+// ClusterServiceVisibilityPolicy is valid.
+func ValidateClusterServiceVisibilityPolicyUpdate(new *sc.ClusterServiceVisibilityPolicy, old *sc.ClusterServiceVisibilityPolicy) field.ErrorList {
+	return ValidateClusterServiceVisibilityPolicy(new)
+}
+
+func validateClusterServiceVisibilityPolicySpec(spec *sc.ClusterServiceVisibilityPolicySpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if spec.ClusterServiceClassExternalName == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("clusterServiceClassExternalName"), "clusterServiceClassExternalName is required"))
+	}
+	for _, msg := range validateCommonServiceClassName(spec.ClusterServiceClassExternalName, false /* prefix */) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterServiceClassExternalName"), spec.ClusterServiceClassExternalName, msg))
+	}
+
+	if spec.ClusterServicePlanExternalName != "" {
+		for _, msg := range validateCommonServicePlanName(spec.ClusterServicePlanExternalName, false /* prefix */) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("clusterServicePlanExternalName"), spec.ClusterServicePlanExternalName, msg))
+		}
+	}
+
+	allErrs = append(allErrs, metav1validation.ValidateLabelSelector(&spec.NamespaceSelector, fldPath.Child("namespaceSelector"))...)
+
+	return allErrs
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+)
+
+func validClusterServiceVisibilityPolicy() *servicecatalog.ClusterServiceVisibilityPolicy {
+	return &servicecatalog.ClusterServiceVisibilityPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-clusterservicevisibilitypolicy",
+		},
+		Spec: servicecatalog.ClusterServiceVisibilityPolicySpec{
+			ClusterServiceClassExternalName: "test-serviceclass",
+			ClusterServicePlanExternalName:  "test-plan",
+			NamespaceSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": "prod"},
+			},
+		},
+	}
+}
+
+func TestValidateClusterServiceVisibilityPolicy(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy *servicecatalog.ClusterServiceVisibilityPolicy
+		valid  bool
+	}{
+		{
+			name:   "valid ClusterServiceVisibilityPolicy",
+			policy: validClusterServiceVisibilityPolicy(),
+			valid:  true,
+		},
+		{
+			name: "valid ClusterServiceVisibilityPolicy - no plan",
+			policy: func() *servicecatalog.ClusterServiceVisibilityPolicy {
+				p := validClusterServiceVisibilityPolicy()
+				p.Spec.ClusterServicePlanExternalName = ""
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "valid ClusterServiceVisibilityPolicy - empty selector",
+			policy: func() *servicecatalog.ClusterServiceVisibilityPolicy {
+				p := validClusterServiceVisibilityPolicy()
+				p.Spec.NamespaceSelector = metav1.LabelSelector{}
+				return p
+			}(),
+			valid: true,
+		},
+		{
+			name: "bad name",
+			policy: func() *servicecatalog.ClusterServiceVisibilityPolicy {
+				p := validClusterServiceVisibilityPolicy()
+				p.Name = "#"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "missing clusterServiceClassExternalName",
+			policy: func() *servicecatalog.ClusterServiceVisibilityPolicy {
+				p := validClusterServiceVisibilityPolicy()
+				p.Spec.ClusterServiceClassExternalName = ""
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "bad clusterServicePlanExternalName",
+			policy: func() *servicecatalog.ClusterServiceVisibilityPolicy {
+				p := validClusterServiceVisibilityPolicy()
+				p.Spec.ClusterServicePlanExternalName = "#"
+				return p
+			}(),
+			valid: false,
+		},
+		{
+			name: "bad namespaceSelector",
+			policy: func() *servicecatalog.ClusterServiceVisibilityPolicy {
+				p := validClusterServiceVisibilityPolicy()
+				p.Spec.NamespaceSelector.MatchExpressions = []metav1.LabelSelectorRequirement{
+					{Key: "env", Operator: metav1.LabelSelectorOpIn},
+				}
+				return p
+			}(),
+			valid: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			errs := ValidateClusterServiceVisibilityPolicy(tc.policy)
+			t.Log(errs)
+			if len(errs) != 0 && tc.valid {
+				t.Errorf("%v: unexpected error: %v", tc.name, errs)
+			} else if len(errs) == 0 && !tc.valid {
+				t.Errorf("%v: unexpected success", tc.name)
+			}
+		})
+	}
+}
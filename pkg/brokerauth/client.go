@@ -0,0 +1,256 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerauth
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/golang/glog"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
+)
+
+// oauth2Client provides an implementation of the OSB V2 Client interface
+// that authenticates with an access token obtained with the client
+// credentials grant. A request that the broker rejects as unauthorized is
+// retried once with a new token, since the broker may revoke a token before
+// it expires.
+type oauth2Client struct {
+	credentials *ClientCredentials
+	tokens      *TokenCache
+	config      osb.ClientConfiguration
+	createFunc  brokerclient.CreateFunc
+
+	mu     sync.Mutex
+	token  string
+	client brokerclient.Client
+}
+
+// NewOAuth2Client creates a client for the broker with the given
+// configuration that authenticates with access tokens obtained with the
+// given credentials. The tokens are cached in the given cache, by the name
+// of the client configuration, and requested with the TLS settings of the
+// client configuration. The underlying clients are created with the given
+// createFunc.
+func NewOAuth2Client(tokens *TokenCache, credentials *ClientCredentials, config *osb.ClientConfiguration, createFunc brokerclient.CreateFunc) (brokerclient.Client, error) {
+	tokenCredentials := *credentials
+	tokenCredentials.Insecure = config.Insecure
+	tokenCredentials.CAData = config.CAData
+	c := &oauth2Client{
+		credentials: &tokenCredentials,
+		tokens:      tokens,
+		config:      *config,
+		createFunc:  createFunc,
+	}
+	// Create the underlying client right away, so that an unreachable token
+	// endpoint is reported like any other error creating a client.
+	if _, err := c.getClient(false); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// getClient returns the underlying client for the current access token,
+// which is requested again first if refresh is true.
+func (c *oauth2Client) getClient(refresh bool) (brokerclient.Client, error) {
+	if refresh {
+		c.tokens.Invalidate(c.config.Name)
+	}
+	token, err := c.tokens.Token(c.config.Name, c.credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.client != nil && c.token == token {
+		return c.client, nil
+	}
+	config := c.config
+	config.AuthConfig = &osb.AuthConfig{
+		BearerConfig: &osb.BearerConfig{Token: token},
+	}
+	client, err := c.createFunc(&config)
+	if err != nil {
+		return nil, err
+	}
+	c.token = token
+	c.client = client
+	return client, nil
+}
+
+// do calls the given request function with the underlying client, and once
+// more with a new access token if the broker responds 401 Unauthorized.
+func (c *oauth2Client) do(request func(client brokerclient.Client) error) error {
+	client, err := c.getClient(false)
+	if err != nil {
+		return err
+	}
+	err = request(client)
+	if httpErr, ok := osb.IsHTTPError(err); !ok || httpErr.StatusCode != http.StatusUnauthorized {
+		return err
+	}
+
+	glog.V(4).Infof("Broker %q rejected its access token, retrying with a new one", c.config.Name)
+	client, err = c.getClient(true)
+	if err != nil {
+		return err
+	}
+	return request(client)
+}
+
+var _ brokerclient.Client = &oauth2Client{}
+
+// GetCatalog implements go-open-service-broker-client/v2/Client.GetCatalog.
+func (c *oauth2Client) GetCatalog() (*osb.CatalogResponse, error) {
+	var response *osb.CatalogResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.GetCatalog()
+		return err
+	})
+	return response, err
+}
+
+// GetCatalogWithExtensions implements
+// brokerclient.Client.GetCatalogWithExtensions.
+func (c *oauth2Client) GetCatalogWithExtensions() (*brokerclient.CatalogResponse, error) {
+	var response *brokerclient.CatalogResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.GetCatalogWithExtensions()
+		return err
+	})
+	return response, err
+}
+
+// ProvisionInstance implements
+// go-open-service-broker-client/v2/Client.ProvisionInstance.
+func (c *oauth2Client) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	var response *osb.ProvisionResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.ProvisionInstance(r)
+		return err
+	})
+	return response, err
+}
+
+// ProvisionInstanceWithExtensions implements
+// brokerclient.Client.ProvisionInstanceWithExtensions.
+func (c *oauth2Client) ProvisionInstanceWithExtensions(r *brokerclient.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	var response *osb.ProvisionResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.ProvisionInstanceWithExtensions(r)
+		return err
+	})
+	return response, err
+}
+
+// UpdateInstance implements
+// go-open-service-broker-client/v2/Client.UpdateInstance.
+func (c *oauth2Client) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	var response *osb.UpdateInstanceResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.UpdateInstance(r)
+		return err
+	})
+	return response, err
+}
+
+// UpdateInstanceWithExtensions implements
+// brokerclient.Client.UpdateInstanceWithExtensions.
+func (c *oauth2Client) UpdateInstanceWithExtensions(r *brokerclient.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	var response *osb.UpdateInstanceResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.UpdateInstanceWithExtensions(r)
+		return err
+	})
+	return response, err
+}
+
+// DeprovisionInstance implements
+// go-open-service-broker-client/v2/Client.DeprovisionInstance.
+func (c *oauth2Client) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	var response *osb.DeprovisionResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.DeprovisionInstance(r)
+		return err
+	})
+	return response, err
+}
+
+// GetInstance implements brokerclient.Client.GetInstance.
+func (c *oauth2Client) GetInstance(r *brokerclient.GetInstanceRequest) (*brokerclient.GetInstanceResponse, error) {
+	var response *brokerclient.GetInstanceResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.GetInstance(r)
+		return err
+	})
+	return response, err
+}
+
+// PollLastOperation implements
+// go-open-service-broker-client/v2/Client.PollLastOperation.
+func (c *oauth2Client) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	var response *osb.LastOperationResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.PollLastOperation(r)
+		return err
+	})
+	return response, err
+}
+
+// PollBindingLastOperation implements
+// go-open-service-broker-client/v2/Client.PollBindingLastOperation.
+func (c *oauth2Client) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	var response *osb.LastOperationResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.PollBindingLastOperation(r)
+		return err
+	})
+	return response, err
+}
+
+// Bind implements go-open-service-broker-client/v2/Client.Bind.
+func (c *oauth2Client) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	var response *osb.BindResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.Bind(r)
+		return err
+	})
+	return response, err
+}
+
+// Unbind implements go-open-service-broker-client/v2/Client.Unbind.
+func (c *oauth2Client) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	var response *osb.UnbindResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.Unbind(r)
+		return err
+	})
+	return response, err
+}
+
+// GetBinding implements go-open-service-broker-client/v2/Client.GetBinding.
+func (c *oauth2Client) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	var response *osb.GetBindingResponse
+	err := c.do(func(client brokerclient.Client) (err error) {
+		response, err = client.GetBinding(r)
+		return err
+	})
+	return response, err
+}
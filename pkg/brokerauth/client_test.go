@@ -0,0 +1,116 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerauth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
+)
+
+// newFakeBrokerServer returns a broker that serves an empty catalog to the
+// requests whose bearer token is not revoked, and the authorization headers
+// of the requests it received.
+func newFakeBrokerServer(revoked ...string) (*httptest.Server, *[]string) {
+	var authorizations []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		authorizations = append(authorizations, authorization)
+		for _, token := range revoked {
+			if authorization == "Bearer "+token {
+				w.WriteHeader(http.StatusUnauthorized)
+				fmt.Fprint(w, `{}`)
+				return
+			}
+		}
+		fmt.Fprint(w, `{"services":[]}`)
+	}))
+	return server, &authorizations
+}
+
+func TestOAuth2Client(t *testing.T) {
+	cases := []struct {
+		name                   string
+		revoked                []string
+		expectedError          bool
+		expectedAuthorizations []string
+	}{
+		{
+			name:                   "token accepted",
+			expectedAuthorizations: []string{"Bearer token-1"},
+		},
+		{
+			name:                   "token revoked",
+			revoked:                []string{"token-1"},
+			expectedAuthorizations: []string{"Bearer token-1", "Bearer token-2"},
+		},
+		{
+			name:                   "new token revoked",
+			revoked:                []string{"token-1", "token-2"},
+			expectedError:          true,
+			expectedAuthorizations: []string{"Bearer token-1", "Bearer token-2"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tokenServer := newFakeTokenServer(t, 3600)
+			defer tokenServer.Close()
+			brokerServer, authorizations := newFakeBrokerServer(tc.revoked...)
+			defer brokerServer.Close()
+
+			config := osb.DefaultClientConfiguration()
+			config.Name = "broker"
+			config.URL = brokerServer.URL
+			credentials := &ClientCredentials{TokenURL: tokenServer.URL, ClientID: "client", ClientSecret: "secret"}
+
+			client, err := NewOAuth2Client(NewTokenCache(), credentials, config, brokerclient.NewClient)
+			if err != nil {
+				t.Fatalf("unexpected error creating client: %v", err)
+			}
+			_, err = client.GetCatalog()
+			if tc.expectedError {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := fmt.Sprint(tc.expectedAuthorizations), fmt.Sprint(*authorizations); e != a {
+				t.Fatalf("unexpected authorizations: expected %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestOAuth2ClientTokenError(t *testing.T) {
+	tokenServer := newFakeTokenServer(t, 3600)
+	defer tokenServer.Close()
+
+	config := osb.DefaultClientConfiguration()
+	config.Name = "broker"
+	credentials := &ClientCredentials{TokenURL: tokenServer.URL, ClientID: "client", ClientSecret: "wrong"}
+
+	if _, err := NewOAuth2Client(NewTokenCache(), credentials, config, brokerclient.NewClient); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,233 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package brokerauth obtains the OAuth2 access tokens that the controller
+// authenticates to brokers with, and retries the requests that a broker
+// rejects because their token has been revoked.
+package brokerauth
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang/glog"
+)
+
+const (
+	// tokenExpiryDelta is how long before their expiry access tokens are
+	// refreshed, so that a token does not expire while a request is in flight.
+	tokenExpiryDelta = time.Minute
+
+	// tokenRequestTimeout is the timeout of the requests to token endpoints.
+	tokenRequestTimeout = 30 * time.Second
+)
+
+// ClientCredentials are the credentials of an OAuth2 client that obtains
+// access tokens with the client credentials grant.
+type ClientCredentials struct {
+	// TokenURL is the URL of the token endpoint of the authorization server.
+	TokenURL string
+	// ClientID is the ID of the client.
+	ClientID string
+	// ClientSecret is the secret of the client.
+	ClientSecret string
+	// Scopes are the scopes to request for the access tokens.
+	Scopes []string
+	// Insecure is whether the certificate of the token endpoint is not
+	// verified, like that of the broker.
+	Insecure bool
+	// CAData holds the PEM-encoded certificates of the CAs to verify the
+	// certificate of the token endpoint with, like that of the broker.
+	CAData []byte
+}
+
+// tokenResponse is the successful response of a token endpoint, as described
+// in https://tools.ietf.org/html/rfc6749#section-5.1.
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int64  `json:"expires_in"`
+}
+
+// cachedToken is an access token along with the credentials it was obtained
+// with.
+type cachedToken struct {
+	credentials ClientCredentials
+	accessToken string
+	// expiry is the time the token expires at, or zero if the token endpoint
+	// did not say.
+	expiry time.Time
+}
+
+// TokenCache obtains access tokens with the client credentials grant and
+// caches them until shortly before they expire. Tokens are cached by key,
+// which is usually the name of the broker they are used for.
+type TokenCache struct {
+	now func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]*cachedToken
+	// requestLocks serialize the token requests for each key, so that the
+	// workers reconciling the resources of a broker at once do not all
+	// request a new token.
+	requestLocks map[string]*sync.Mutex
+}
+
+// NewTokenCache creates an empty TokenCache.
+func NewTokenCache() *TokenCache {
+	return &TokenCache{
+		now:          time.Now,
+		tokens:       make(map[string]*cachedToken),
+		requestLocks: make(map[string]*sync.Mutex),
+	}
+}
+
+// Token returns an access token for the given key. The cached token is
+// returned unless it is about to expire or was obtained with different
+// credentials, in which case a new token is requested from the token
+// endpoint. Concurrent calls for the same key wait for a single request.
+func (c *TokenCache) Token(key string, credentials *ClientCredentials) (string, error) {
+	requestLock := c.requestLock(key)
+	requestLock.Lock()
+	defer requestLock.Unlock()
+
+	c.mu.Lock()
+	cached, ok := c.tokens[key]
+	c.mu.Unlock()
+	if ok && reflect.DeepEqual(cached.credentials, *credentials) &&
+		(cached.expiry.IsZero() || c.now().Add(tokenExpiryDelta).Before(cached.expiry)) {
+		return cached.accessToken, nil
+	}
+
+	token, err := c.requestToken(credentials)
+	if err != nil {
+		return "", err
+	}
+	glog.V(4).Infof("Obtained a new access token for %q from %v", key, credentials.TokenURL)
+
+	c.mu.Lock()
+	c.tokens[key] = token
+	c.mu.Unlock()
+	return token.accessToken, nil
+}
+
+// requestLock returns the lock that serializes the token requests for the
+// given key.
+func (c *TokenCache) requestLock(key string) *sync.Mutex {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	requestLock, ok := c.requestLocks[key]
+	if !ok {
+		requestLock = &sync.Mutex{}
+		c.requestLocks[key] = requestLock
+	}
+	return requestLock
+}
+
+// Invalidate drops the cached token for the given key, so that the next call
+// to Token requests a new one.
+func (c *TokenCache) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.tokens, key)
+}
+
+// requestToken requests an access token from the token endpoint with the
+// client credentials grant, as described in
+// https://tools.ietf.org/html/rfc6749#section-4.4.
+func (c *TokenCache) requestToken(credentials *ClientCredentials) (*cachedToken, error) {
+	form := url.Values{"grant_type": {"client_credentials"}}
+	if len(credentials.Scopes) > 0 {
+		form.Set("scope", strings.Join(credentials.Scopes, " "))
+	}
+	request, err := http.NewRequest(http.MethodPost, credentials.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.SetBasicAuth(url.QueryEscape(credentials.ClientID), url.QueryEscape(credentials.ClientSecret))
+
+	httpClient, err := newTokenHTTPClient(credentials)
+	if err != nil {
+		return nil, err
+	}
+	requestTime := c.now()
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("error requesting access token: %v", err)
+	}
+	defer response.Body.Close()
+
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading access token response: %v", err)
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error requesting access token: status %d: %s", response.StatusCode, body)
+	}
+
+	var tokenResponse tokenResponse
+	if err := json.Unmarshal(body, &tokenResponse); err != nil {
+		return nil, fmt.Errorf("error unmarshalling access token response: %v", err)
+	}
+	if tokenResponse.AccessToken == "" {
+		return nil, fmt.Errorf("access token response didn't contain an access token")
+	}
+	if tokenResponse.TokenType != "" && !strings.EqualFold(tokenResponse.TokenType, "bearer") {
+		return nil, fmt.Errorf("unsupported access token type %q", tokenResponse.TokenType)
+	}
+
+	token := &cachedToken{
+		credentials: *credentials,
+		accessToken: tokenResponse.AccessToken,
+	}
+	if tokenResponse.ExpiresIn > 0 {
+		token.expiry = requestTime.Add(time.Duration(tokenResponse.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// newTokenHTTPClient creates the client that requests access tokens with the
+// given credentials, which verifies the certificate of the token endpoint
+// the way the client of the broker verifies that of the broker. Token
+// requests are rare, so connections are not kept alive between them.
+func newTokenHTTPClient(credentials *ClientCredentials) (*http.Client, error) {
+	tlsConfig := &tls.Config{InsecureSkipVerify: credentials.Insecure}
+	if len(credentials.CAData) != 0 {
+		if credentials.Insecure {
+			return nil, errors.New("Cannot specify root CAs and to skip TLS verification")
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AppendCertsFromPEM(credentials.CAData)
+	}
+	return &http.Client{
+		Timeout: tokenRequestTimeout,
+		Transport: &http.Transport{
+			TLSClientConfig:   tlsConfig,
+			DisableKeepAlives: true,
+		},
+	}, nil
+}
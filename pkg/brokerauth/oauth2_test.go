@@ -0,0 +1,221 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerauth
+
+import (
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeTokenServer is a token endpoint that issues the access tokens
+// "token-1", "token-2"... to the client "client" with the secret "secret".
+type fakeTokenServer struct {
+	*httptest.Server
+	expiresIn int
+	requests  int
+	scopes    []string
+}
+
+func newFakeTokenServer(t *testing.T, expiresIn int) *fakeTokenServer {
+	s := newUnstartedFakeTokenServer(t, expiresIn)
+	s.Start()
+	return s
+}
+
+func newUnstartedFakeTokenServer(t *testing.T, expiresIn int) *fakeTokenServer {
+	s := &fakeTokenServer{expiresIn: expiresIn}
+	s.Server = httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Errorf("unexpected error parsing token request: %v", err)
+		}
+		if e, a := "client_credentials", r.PostForm.Get("grant_type"); e != a {
+			t.Errorf("unexpected grant type: expected %q, got %q", e, a)
+		}
+		s.scopes = strings.Fields(r.PostForm.Get("scope"))
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "client" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"error":"invalid_client"}`)
+			return
+		}
+		s.requests++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"access_token":"token-%d","token_type":"bearer","expires_in":%d}`, s.requests, s.expiresIn)
+	}))
+	return s
+}
+
+func TestTokenCache(t *testing.T) {
+	server := newFakeTokenServer(t, 3600)
+	defer server.Close()
+
+	now := time.Now()
+	cache := NewTokenCache()
+	cache.now = func() time.Time { return now }
+	credentials := &ClientCredentials{
+		TokenURL:     server.URL,
+		ClientID:     "client",
+		ClientSecret: "secret",
+		Scopes:       []string{"read", "write"},
+	}
+
+	expectToken := func(name, expected string) {
+		token, err := cache.Token("broker", credentials)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", name, err)
+		}
+		if token != expected {
+			t.Fatalf("%v: unexpected token: expected %q, got %q", name, expected, token)
+		}
+	}
+
+	expectToken("first request", "token-1")
+	if e, a := "read write", strings.Join(server.scopes, " "); e != a {
+		t.Fatalf("unexpected scopes: expected %q, got %q", e, a)
+	}
+	expectToken("cached", "token-1")
+
+	now = now.Add(time.Hour - tokenExpiryDelta)
+	expectToken("about to expire", "token-2")
+
+	cache.Invalidate("broker")
+	expectToken("invalidated", "token-3")
+
+	credentials.Scopes = []string{"read"}
+	expectToken("credentials changed", "token-4")
+}
+
+func TestTokenCacheNoExpiry(t *testing.T) {
+	server := newFakeTokenServer(t, 0)
+	defer server.Close()
+
+	now := time.Now()
+	cache := NewTokenCache()
+	cache.now = func() time.Time { return now }
+	credentials := &ClientCredentials{TokenURL: server.URL, ClientID: "client", ClientSecret: "secret"}
+
+	for i := 0; i < 2; i++ {
+		token, err := cache.Token("broker", credentials)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if e, a := "token-1", token; e != a {
+			t.Fatalf("unexpected token: expected %q, got %q", e, a)
+		}
+		now = now.Add(24 * time.Hour)
+	}
+}
+
+func TestTokenCacheError(t *testing.T) {
+	server := newFakeTokenServer(t, 3600)
+	defer server.Close()
+
+	cache := NewTokenCache()
+	credentials := &ClientCredentials{TokenURL: server.URL, ClientID: "client", ClientSecret: "wrong"}
+
+	_, err := cache.Token("broker", credentials)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "invalid_client") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestTokenCacheTLS(t *testing.T) {
+	server := newUnstartedFakeTokenServer(t, 3600)
+	server.StartTLS()
+	defer server.Close()
+	caData := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	cases := []struct {
+		name     string
+		insecure bool
+		caData   []byte
+		err      string
+	}{
+		{
+			name: "unknown authority",
+			err:  "certificate",
+		},
+		{
+			name:   "CA bundle",
+			caData: caData,
+		},
+		{
+			name:     "insecure",
+			insecure: true,
+		},
+		{
+			name:     "CA bundle and insecure",
+			insecure: true,
+			caData:   caData,
+			err:      "Cannot specify root CAs and to skip TLS verification",
+		},
+	}
+
+	for _, tc := range cases {
+		credentials := &ClientCredentials{
+			TokenURL:     server.URL,
+			ClientID:     "client",
+			ClientSecret: "secret",
+			Insecure:     tc.insecure,
+			CAData:       tc.caData,
+		}
+		_, err := NewTokenCache().Token("broker", credentials)
+		if tc.err == "" {
+			if err != nil {
+				t.Errorf("%v: unexpected error: %v", tc.name, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%v: expected an error containing %q, got %v", tc.name, tc.err, err)
+		}
+	}
+}
+
+func TestTokenCacheConcurrentRequests(t *testing.T) {
+	server := newFakeTokenServer(t, 3600)
+	defer server.Close()
+
+	cache := NewTokenCache()
+	credentials := &ClientCredentials{TokenURL: server.URL, ClientID: "client", ClientSecret: "secret"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			token, err := cache.Token("broker", credentials)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+			} else if e, a := "token-1", token; e != a {
+				t.Errorf("unexpected token: expected %q, got %q", e, a)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if e, a := 1, server.requests; e != a {
+		t.Fatalf("unexpected number of token requests: expected %d, got %d", e, a)
+	}
+}
@@ -0,0 +1,487 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerclient
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"unsafe"
+
+	"github.com/golang/glog"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+// client sends the requests that osb.Client implements with the vendored
+// client, and the others itself, the way the vendored client would.
+type client struct {
+	osb.Client
+
+	config     osb.ClientConfiguration
+	url        string
+	httpClient *http.Client
+}
+
+// NewClient creates a Client with the given configuration. The requests that
+// the vendored client does not implement are sent with its HTTP client, so
+// that they share its connections, timeout and TLS settings.
+func NewClient(config *osb.ClientConfiguration) (Client, error) {
+	// The vendored client sets up the TLS configuration it is given in
+	// place, so it is given a copy of the configuration of the caller.
+	clientConfig := *config
+	if config.TLSConfig != nil {
+		clientConfig.TLSConfig = config.TLSConfig.Clone()
+	}
+	osbClient, err := osb.NewClient(&clientConfig)
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := vendoredHTTPClient(osbClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client{
+		Client:     osbClient,
+		config:     clientConfig,
+		url:        strings.TrimRight(config.URL, "/"),
+		httpClient: httpClient,
+	}, nil
+}
+
+// vendoredHTTPClient returns the HTTP client of a client created by
+// osb.NewClient. The vendored client does not export it, so it is read from
+// its unexported httpClient field; an update of the vendored client that
+// renames the field makes NewClient fail rather than open other connections.
+func vendoredHTTPClient(osbClient osb.Client) (*http.Client, error) {
+	value := reflect.ValueOf(osbClient)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("unexpected type of the vendored OSB client: %T", osbClient)
+	}
+	field := value.Elem().FieldByName("httpClient")
+	if !field.IsValid() || field.Type() != reflect.TypeOf(&http.Client{}) {
+		return nil, fmt.Errorf("the vendored OSB client %T has no httpClient field", osbClient)
+	}
+	httpClient := *(**http.Client)(unsafe.Pointer(field.UnsafeAddr()))
+	if httpClient == nil {
+		return nil, fmt.Errorf("the vendored OSB client %T has no HTTP client", osbClient)
+	}
+	return httpClient, nil
+}
+
+var _ CreateFunc = NewClient
+
+const (
+	catalogURLFmt         = "%s/v2/catalog"
+	serviceInstanceURLFmt = "%s/v2/service_instances/%s"
+
+	serviceIDKey = "service_id"
+	planIDKey    = "plan_id"
+)
+
+// catalogExtensionsBody decodes the fields of a catalog that osb.CatalogResponse
+// does not hold.
+type catalogExtensionsBody struct {
+	Services []struct {
+		ID string `json:"id"`
+		ServiceExtensions
+		Plans []struct {
+			ID string `json:"id"`
+			PlanExtensions
+		} `json:"plans"`
+	} `json:"services"`
+}
+
+// GetCatalogWithExtensions implements Client.GetCatalogWithExtensions.
+func (c *client) GetCatalogWithExtensions() (*CatalogResponse, error) {
+	fullURL := fmt.Sprintf(catalogURLFmt, c.url)
+
+	response, err := c.prepareAndDo(http.MethodGet, fullURL, nil /* params */, nil /* request body */, nil /* originating identity */)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, c.handleFailureResponse(response)
+	}
+
+	body, err := c.readResponse(response)
+	if err != nil {
+		return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+	}
+	catalogResponse := &CatalogResponse{}
+	if err := json.Unmarshal(body, &catalogResponse.CatalogResponse); err != nil {
+		return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+	}
+	extensions := &catalogExtensionsBody{}
+	if err := json.Unmarshal(body, extensions); err != nil {
+		return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+	}
+
+	// Drop the schemas that the vendored client would drop for the API
+	// version and alpha features of the client.
+	for i := range catalogResponse.Services {
+		for j := range catalogResponse.Services[i].Plans {
+			plan := &catalogResponse.Services[i].Plans[j]
+			if !c.config.APIVersion.AtLeast(osb.Version2_13()) {
+				plan.Schemas = nil
+			} else if !c.config.EnableAlphaFeatures && plan.Schemas != nil && plan.Schemas.ServiceBinding != nil && plan.Schemas.ServiceBinding.Create != nil {
+				plan.Schemas.ServiceBinding.Create.Response = nil
+			}
+		}
+	}
+
+	catalogResponse.ServiceExtensions = make(map[string]ServiceExtensions, len(extensions.Services))
+	for _, service := range extensions.Services {
+		serviceExtensions := service.ServiceExtensions
+		serviceExtensions.PlanExtensions = make(map[string]PlanExtensions, len(service.Plans))
+		for _, plan := range service.Plans {
+			serviceExtensions.PlanExtensions[plan.ID] = plan.PlanExtensions
+		}
+		catalogResponse.ServiceExtensions[service.ID] = serviceExtensions
+	}
+
+	return catalogResponse, nil
+}
+
+type provisionRequestBody struct {
+	ServiceID        string                 `json:"service_id"`
+	PlanID           string                 `json:"plan_id"`
+	OrganizationGUID string                 `json:"organization_guid"`
+	SpaceGUID        string                 `json:"space_guid"`
+	Parameters       map[string]interface{} `json:"parameters,omitempty"`
+	Context          map[string]interface{} `json:"context,omitempty"`
+	MaintenanceInfo  *MaintenanceInfo       `json:"maintenance_info,omitempty"`
+}
+
+// instanceOperationResponseBody is the body of the responses to provision
+// and update requests.
+type instanceOperationResponseBody struct {
+	DashboardURL *string `json:"dashboard_url"`
+	Operation    *string `json:"operation"`
+}
+
+// ProvisionInstanceWithExtensions implements
+// Client.ProvisionInstanceWithExtensions. Requests without maintenance
+// information are sent by the vendored client.
+func (c *client) ProvisionInstanceWithExtensions(r *ProvisionRequest) (*osb.ProvisionResponse, error) {
+	if r.MaintenanceInfo == nil {
+		return c.ProvisionInstance(&r.ProvisionRequest)
+	}
+
+	switch {
+	case r.InstanceID == "":
+		return nil, required("instanceID")
+	case r.ServiceID == "":
+		return nil, required("serviceID")
+	case r.PlanID == "":
+		return nil, required("planID")
+	case r.OrganizationGUID == "":
+		return nil, required("organizationGUID")
+	case r.SpaceGUID == "":
+		return nil, required("spaceGUID")
+	}
+
+	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.url, r.InstanceID)
+	requestBody := &provisionRequestBody{
+		ServiceID:        r.ServiceID,
+		PlanID:           r.PlanID,
+		OrganizationGUID: r.OrganizationGUID,
+		SpaceGUID:        r.SpaceGUID,
+		Parameters:       r.Parameters,
+		MaintenanceInfo:  r.MaintenanceInfo,
+	}
+	if c.config.APIVersion.AtLeast(osb.Version2_12()) {
+		requestBody.Context = r.Context
+	}
+
+	response, err := c.prepareAndDo(http.MethodPut, fullURL, acceptsIncompleteParams(r.AcceptsIncomplete), requestBody, r.OriginatingIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode {
+	case http.StatusCreated, http.StatusOK:
+		userResponse := &osb.ProvisionResponse{}
+		if err := c.unmarshalResponse(response, userResponse); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+		if !c.config.APIVersion.AtLeast(osb.Version2_13()) || !c.config.EnableAlphaFeatures {
+			userResponse.ExtensionAPIs = nil
+		}
+		return userResponse, nil
+	case http.StatusAccepted:
+		if !r.AcceptsIncomplete {
+			return nil, c.handleFailureResponse(response)
+		}
+		responseBody := &instanceOperationResponseBody{}
+		if err := c.unmarshalResponse(response, responseBody); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+		return &osb.ProvisionResponse{
+			Async:        true,
+			DashboardURL: responseBody.DashboardURL,
+			OperationKey: operationKey(responseBody.Operation),
+		}, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
+
+type updateInstanceRequestBody struct {
+	ServiceID       string                 `json:"service_id"`
+	PlanID          *string                `json:"plan_id,omitempty"`
+	Parameters      map[string]interface{} `json:"parameters,omitempty"`
+	Context         map[string]interface{} `json:"context,omitempty"`
+	PreviousValues  *osb.PreviousValues    `json:"previous_values,omitempty"`
+	MaintenanceInfo *MaintenanceInfo       `json:"maintenance_info,omitempty"`
+}
+
+// UpdateInstanceWithExtensions implements Client.UpdateInstanceWithExtensions.
+// Requests without maintenance information are sent by the vendored client.
+func (c *client) UpdateInstanceWithExtensions(r *UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	if r.MaintenanceInfo == nil {
+		return c.UpdateInstance(&r.UpdateInstanceRequest)
+	}
+
+	switch {
+	case r.InstanceID == "":
+		return nil, required("instanceID")
+	case r.ServiceID == "":
+		return nil, required("serviceID")
+	}
+
+	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.url, r.InstanceID)
+	requestBody := &updateInstanceRequestBody{
+		ServiceID:       r.ServiceID,
+		PlanID:          r.PlanID,
+		Parameters:      r.Parameters,
+		PreviousValues:  r.PreviousValues,
+		MaintenanceInfo: r.MaintenanceInfo,
+	}
+	if c.config.APIVersion.AtLeast(osb.Version2_12()) {
+		requestBody.Context = r.Context
+	}
+
+	response, err := c.prepareAndDo(http.MethodPatch, fullURL, acceptsIncompleteParams(r.AcceptsIncomplete), requestBody, r.OriginatingIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK, http.StatusAccepted:
+		async := response.StatusCode == http.StatusAccepted
+		if async && !r.AcceptsIncomplete {
+			return nil, c.handleFailureResponse(response)
+		}
+		responseBody := &instanceOperationResponseBody{}
+		if err := c.unmarshalResponse(response, responseBody); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+		userResponse := &osb.UpdateInstanceResponse{Async: async}
+		if async {
+			userResponse.OperationKey = operationKey(responseBody.Operation)
+		}
+		if c.validateAlphaAPIMethodsAllowed() == nil {
+			userResponse.DashboardURL = responseBody.DashboardURL
+		}
+		return userResponse, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
+
+// GetInstance implements Client.GetInstance. The IDs of the service and plan,
+// when set, are sent as the service_id and plan_id query parameters.
+func (c *client) GetInstance(r *GetInstanceRequest) (*GetInstanceResponse, error) {
+	if err := c.validateAlphaAPIMethodsAllowed(); err != nil {
+		return nil, GetInstanceNotAllowedError{reason: err.Error()}
+	}
+
+	fullURL := fmt.Sprintf(serviceInstanceURLFmt, c.url, r.InstanceID)
+	params := map[string]string{}
+	if r.ServiceID != "" {
+		params[serviceIDKey] = r.ServiceID
+	}
+	if r.PlanID != "" {
+		params[planIDKey] = r.PlanID
+	}
+
+	response, err := c.prepareAndDo(http.MethodGet, fullURL, params, nil /* request body */, nil /* originating identity */)
+	if err != nil {
+		return nil, err
+	}
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		userResponse := &GetInstanceResponse{}
+		if err := c.unmarshalResponse(response, userResponse); err != nil {
+			return nil, osb.HTTPStatusCodeError{StatusCode: response.StatusCode, ResponseError: err}
+		}
+		return userResponse, nil
+	default:
+		return nil, c.handleFailureResponse(response)
+	}
+}
+
+func required(name string) error {
+	return fmt.Errorf("%v is required", name)
+}
+
+func acceptsIncompleteParams(acceptsIncomplete bool) map[string]string {
+	params := map[string]string{}
+	if acceptsIncomplete {
+		params[osb.AcceptsIncomplete] = "true"
+	}
+	return params
+}
+
+func operationKey(operation *string) *osb.OperationKey {
+	if operation == nil {
+		return nil
+	}
+	key := osb.OperationKey(*operation)
+	return &key
+}
+
+// prepareAndDo sends a request with the headers that the vendored client
+// sends.
+func (c *client) prepareAndDo(method, URL string, params map[string]string, body interface{}, originatingIdentity *osb.OriginatingIdentity) (*http.Response, error) {
+	var bodyReader io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		bodyReader = bytes.NewReader(bodyBytes)
+	}
+
+	request, err := http.NewRequest(method, URL, bodyReader)
+	if err != nil {
+		return nil, err
+	}
+
+	request.Header.Set(osb.APIVersionHeader, c.config.APIVersion.HeaderValue())
+	if bodyReader != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	if authConfig := c.config.AuthConfig; authConfig != nil {
+		if authConfig.BasicAuthConfig != nil {
+			request.SetBasicAuth(authConfig.BasicAuthConfig.Username, authConfig.BasicAuthConfig.Password)
+		} else if authConfig.BearerConfig != nil {
+			request.Header.Set("Authorization", "Bearer "+authConfig.BearerConfig.Token)
+		}
+	}
+
+	if c.config.APIVersion.AtLeast(osb.Version2_13()) && originatingIdentity != nil {
+		headerValue, err := originatingIdentityHeaderValue(originatingIdentity)
+		if err != nil {
+			return nil, err
+		}
+		request.Header.Set(osb.OriginatingIdentityHeader, headerValue)
+	}
+
+	if len(params) > 0 {
+		q := request.URL.Query()
+		for k, v := range params {
+			q.Set(k, v)
+		}
+		request.URL.RawQuery = q.Encode()
+	}
+
+	if c.config.Verbose {
+		glog.Infof("broker %q: doing request to %q", c.config.Name, URL)
+	}
+
+	return c.httpClient.Do(request)
+}
+
+func (c *client) readResponse(response *http.Response) ([]byte, error) {
+	defer response.Body.Close()
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if c.config.Verbose {
+		glog.Infof("broker %q: response body: %v", c.config.Name, string(body))
+	}
+	return body, nil
+}
+
+func (c *client) unmarshalResponse(response *http.Response, obj interface{}) error {
+	body, err := c.readResponse(response)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(body, obj)
+}
+
+// handleFailureResponse returns the error that the vendored client returns
+// for the given response.
+func (c *client) handleFailureResponse(response *http.Response) error {
+	httpErr := osb.HTTPStatusCodeError{
+		StatusCode: response.StatusCode,
+	}
+
+	brokerResponse := make(map[string]interface{})
+	if err := c.unmarshalResponse(response, &brokerResponse); err != nil {
+		httpErr.ResponseError = err
+		return httpErr
+	}
+	if errorMessage, ok := brokerResponse["error"].(string); ok {
+		httpErr.ErrorMessage = &errorMessage
+	}
+	if description, ok := brokerResponse["description"].(string); ok {
+		httpErr.Description = &description
+	}
+	return httpErr
+}
+
+func (c *client) validateAlphaAPIMethodsAllowed() error {
+	if !c.config.EnableAlphaFeatures {
+		return errors.New("alpha API methods not allowed: alpha features must be enabled")
+	}
+	if !c.config.APIVersion.AtLeast(osb.LatestAPIVersion()) {
+		return fmt.Errorf(
+			"alpha API methods not allowed: must have latest API Version. Current: %s, Expected: %s",
+			c.config.APIVersion.HeaderValue(),
+			osb.LatestAPIVersion().HeaderValue(),
+		)
+	}
+	return nil
+}
+
+func originatingIdentityHeaderValue(i *osb.OriginatingIdentity) (string, error) {
+	if i.Platform == "" {
+		return "", errors.New("originating identity platform must not be empty")
+	}
+	if i.Value == "" {
+		return "", errors.New("originating identity value must not be empty")
+	}
+	var js json.RawMessage
+	if err := json.Unmarshal([]byte(i.Value), &js); err != nil {
+		return "", fmt.Errorf("originating identity value must be valid JSON: %v", err)
+	}
+	return fmt.Sprintf("%v %v", i.Platform, base64.StdEncoding.EncodeToString([]byte(i.Value))), nil
+}
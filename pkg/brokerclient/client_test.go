@@ -0,0 +1,303 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerclient
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"testing"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+// fakeBroker is a broker that answers every request with the same status and
+// body, and records the requests it received.
+type fakeBroker struct {
+	*httptest.Server
+
+	requests []string
+	queries  []url.Values
+	bodies   []map[string]interface{}
+}
+
+func newFakeBroker(t *testing.T, status int, body string) *fakeBroker {
+	broker := &fakeBroker{}
+	broker.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		broker.requests = append(broker.requests, fmt.Sprintf("%s %s", r.Method, r.URL.Path))
+		broker.queries = append(broker.queries, r.URL.Query())
+		requestBody, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("unexpected error reading the request body: %v", err)
+		}
+		var decoded map[string]interface{}
+		if len(requestBody) > 0 {
+			if err := json.Unmarshal(requestBody, &decoded); err != nil {
+				t.Errorf("unexpected error decoding the request body: %v", err)
+			}
+		}
+		broker.bodies = append(broker.bodies, decoded)
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	return broker
+}
+
+func newTestClient(t *testing.T, url string, enableAlpha bool) Client {
+	config := osb.DefaultClientConfiguration()
+	config.URL = url
+	config.EnableAlphaFeatures = enableAlpha
+	client, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	broker := newFakeBroker(t, http.StatusOK, `{"services":[]}`)
+	defer broker.Close()
+
+	tlsConfig := &tls.Config{}
+	config := osb.DefaultClientConfiguration()
+	config.URL = broker.URL
+	config.Insecure = true
+	config.TLSConfig = tlsConfig
+	c, err := NewClient(config)
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+
+	if tlsConfig.InsecureSkipVerify {
+		t.Fatal("expected the TLS configuration of the caller to be left as it was")
+	}
+	httpClient := c.(*client).httpClient
+	transport, ok := httpClient.Transport.(*http.Transport)
+	if !ok || transport.TLSClientConfig == tlsConfig || !transport.TLSClientConfig.InsecureSkipVerify {
+		t.Fatalf("expected the HTTP client of the vendored client, got %+v", httpClient)
+	}
+
+	// Both the vendored requests and the others are sent with it.
+	if _, err := c.GetCatalog(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := c.GetCatalogWithExtensions(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := []string{"GET /v2/catalog", "GET /v2/catalog"}, broker.requests; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected requests: expected %v, got %v", e, a)
+	}
+}
+
+func TestGetCatalogWithExtensions(t *testing.T) {
+	broker := newFakeBroker(t, http.StatusOK, `{"services":[
+		{"id":"retrievable","name":"retrievable","bindable":true,"instances_retrievable":true,"plans":[
+			{"id":"versioned","name":"versioned","maintenance_info":{"version":"2.0.0","description":"OS upgrade"}},
+			{"id":"unversioned","name":"unversioned"}
+		]},
+		{"id":"not-retrievable","name":"not-retrievable","bindable":true,"plans":[{"id":"plan","name":"plan"}]}
+	]}`)
+	defer broker.Close()
+
+	response, err := newTestClient(t, broker.URL, false).GetCatalogWithExtensions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if e, a := 2, len(response.Services); e != a {
+		t.Fatalf("expected %d services, got %d", e, a)
+	}
+	version, description := "2.0.0", "OS upgrade"
+	expected := map[string]ServiceExtensions{
+		"retrievable": {
+			InstancesRetrievable: true,
+			PlanExtensions: map[string]PlanExtensions{
+				"versioned":   {MaintenanceInfo: &MaintenanceInfo{Version: &version, Description: &description}},
+				"unversioned": {},
+			},
+		},
+		"not-retrievable": {
+			PlanExtensions: map[string]PlanExtensions{"plan": {}},
+		},
+	}
+	if !reflect.DeepEqual(expected, response.ServiceExtensions) {
+		t.Fatalf("unexpected extensions: expected %+v, got %+v", expected, response.ServiceExtensions)
+	}
+}
+
+func TestProvisionInstanceWithExtensions(t *testing.T) {
+	version := "2.0.0"
+	cases := []struct {
+		name                    string
+		maintenanceInfo         *MaintenanceInfo
+		expectedMaintenanceInfo interface{}
+	}{
+		{
+			name: "without maintenance info",
+		},
+		{
+			name:                    "with maintenance info",
+			maintenanceInfo:         &MaintenanceInfo{Version: &version},
+			expectedMaintenanceInfo: map[string]interface{}{"version": version},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			broker := newFakeBroker(t, http.StatusAccepted, `{"operation":"provisioning"}`)
+			defer broker.Close()
+
+			response, err := newTestClient(t, broker.URL, false).ProvisionInstanceWithExtensions(&ProvisionRequest{
+				ProvisionRequest: osb.ProvisionRequest{
+					InstanceID:        "instance-id",
+					AcceptsIncomplete: true,
+					ServiceID:         "service-id",
+					PlanID:            "plan-id",
+					OrganizationGUID:  "organization-guid",
+					SpaceGUID:         "space-guid",
+				},
+				MaintenanceInfo: tc.maintenanceInfo,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			operation := osb.OperationKey("provisioning")
+			if e, a := (&osb.ProvisionResponse{Async: true, OperationKey: &operation}), response; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected response: expected %+v, got %+v", e, a)
+			}
+
+			if e, a := []string{"PUT /v2/service_instances/instance-id"}, broker.requests; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected requests: expected %v, got %v", e, a)
+			}
+			if e, a := tc.expectedMaintenanceInfo, broker.bodies[0]["maintenance_info"]; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected maintenance_info: expected %v, got %v", e, a)
+			}
+			if e, a := "plan-id", broker.bodies[0]["plan_id"]; e != a {
+				t.Fatalf("unexpected plan_id: expected %v, got %v", e, a)
+			}
+		})
+	}
+}
+
+func TestUpdateInstanceWithExtensions(t *testing.T) {
+	broker := newFakeBroker(t, http.StatusBadRequest, `{"error":"MaintenanceInfoConflict","description":"version 2.0.0 is not offered"}`)
+	defer broker.Close()
+
+	version := "2.0.0"
+	_, err := newTestClient(t, broker.URL, false).UpdateInstanceWithExtensions(&UpdateInstanceRequest{
+		UpdateInstanceRequest: osb.UpdateInstanceRequest{
+			InstanceID:        "instance-id",
+			AcceptsIncomplete: true,
+			ServiceID:         "service-id",
+		},
+		MaintenanceInfo: &MaintenanceInfo{Version: &version},
+	})
+	httpErr, ok := osb.IsHTTPError(err)
+	if !ok {
+		t.Fatalf("expected an HTTP error, got %v", err)
+	}
+	if httpErr.StatusCode != http.StatusBadRequest || httpErr.ErrorMessage == nil || *httpErr.ErrorMessage != "MaintenanceInfoConflict" {
+		t.Fatalf("unexpected error: %v", httpErr)
+	}
+
+	if e, a := []string{"PATCH /v2/service_instances/instance-id"}, broker.requests; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected requests: expected %v, got %v", e, a)
+	}
+	if e, a := map[string]interface{}{"version": version}, broker.bodies[0]["maintenance_info"]; !reflect.DeepEqual(e, a) {
+		t.Fatalf("unexpected maintenance_info: expected %v, got %v", e, a)
+	}
+	if _, ok := broker.bodies[0]["plan_id"]; ok {
+		t.Fatalf("expected no plan_id in the request, got %v", broker.bodies[0])
+	}
+}
+
+func TestGetInstance(t *testing.T) {
+	dashboardURL := "https://dashboard.example.com"
+	cases := []struct {
+		name             string
+		enableAlpha      bool
+		status           int
+		body             string
+		expectedResponse *GetInstanceResponse
+		expectedErrorFmt string
+	}{
+		{
+			name:        "success",
+			enableAlpha: true,
+			status:      http.StatusOK,
+			body:        `{"service_id":"service-id","plan_id":"plan-id","dashboard_url":"https://dashboard.example.com","parameters":{"size":"large"}}`,
+			expectedResponse: &GetInstanceResponse{
+				ServiceID:    "service-id",
+				PlanID:       "plan-id",
+				DashboardURL: &dashboardURL,
+				Parameters:   map[string]interface{}{"size": "large"},
+			},
+		},
+		{
+			name:             "not found",
+			enableAlpha:      true,
+			status:           http.StatusNotFound,
+			body:             `{}`,
+			expectedErrorFmt: "Status: 404; ErrorMessage: <nil>; Description: <nil>; ResponseError: <nil>",
+		},
+		{
+			name:             "alpha features disabled",
+			expectedErrorFmt: "GetInstance not allowed: alpha API methods not allowed: alpha features must be enabled",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			broker := newFakeBroker(t, tc.status, tc.body)
+			defer broker.Close()
+
+			response, err := newTestClient(t, broker.URL, tc.enableAlpha).GetInstance(&GetInstanceRequest{
+				InstanceID: "instance-id",
+				ServiceID:  "service-id",
+				PlanID:     "plan-id",
+			})
+			if tc.expectedErrorFmt != "" {
+				if err == nil || err.Error() != tc.expectedErrorFmt {
+					t.Fatalf("expected error %q, got %v", tc.expectedErrorFmt, err)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(tc.expectedResponse, response) {
+				t.Fatalf("unexpected response: expected %+v, got %+v", tc.expectedResponse, response)
+			}
+
+			if !tc.enableAlpha {
+				if len(broker.requests) != 0 {
+					t.Fatalf("expected no request to the broker, got %v", broker.requests)
+				}
+				return
+			}
+			if e, a := []string{"GET /v2/service_instances/instance-id"}, broker.requests; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected requests: expected %v, got %v", e, a)
+			}
+			if e, a := (url.Values{"service_id": {"service-id"}, "plan_id": {"plan-id"}}), broker.queries[0]; !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected query parameters: expected %v, got %v", e, a)
+			}
+		})
+	}
+}
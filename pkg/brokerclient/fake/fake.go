@@ -0,0 +1,221 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fake provides a fake brokerclient.Client built on the fake client
+// vendored from go-open-service-broker-client.
+package fake
+
+import (
+	"sync"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
+)
+
+// GetInstance is the type of the actions recorded for GetInstance calls.
+const GetInstance fakeosb.ActionType = "GetInstance"
+
+// NewFakeClientFunc returns a brokerclient.CreateFunc that returns a
+// FakeClient with the given FakeClientConfiguration.
+func NewFakeClientFunc(config FakeClientConfiguration) brokerclient.CreateFunc {
+	return func(_ *osb.ClientConfiguration) (brokerclient.Client, error) {
+		return NewFakeClient(config), nil
+	}
+}
+
+// ReturnFakeClientFunc returns a brokerclient.CreateFunc that returns the
+// given FakeClient.
+func ReturnFakeClientFunc(c *FakeClient) brokerclient.CreateFunc {
+	return func(_ *osb.ClientConfiguration) (brokerclient.Client, error) {
+		return c, nil
+	}
+}
+
+// NewFakeClient returns a new fake Client with the given
+// FakeClientConfiguration.
+func NewFakeClient(config FakeClientConfiguration) *FakeClient {
+	return &FakeClient{
+		FakeClient:          fakeosb.NewFakeClient(config.FakeClientConfiguration),
+		ServiceExtensions:   config.ServiceExtensions,
+		GetInstanceReaction: config.GetInstanceReaction,
+	}
+}
+
+// FakeClientConfiguration models the configuration of a FakeClient.
+type FakeClientConfiguration struct {
+	fakeosb.FakeClientConfiguration
+
+	ServiceExtensions   map[string]brokerclient.ServiceExtensions
+	GetInstanceReaction GetInstanceReactionInterface
+}
+
+// FakeClient is a fake implementation of the brokerclient.Client interface.
+// Reactions to the methods of osb.Client are those of the embedded
+// fakeosb.FakeClient; catalogs fetched with GetCatalogWithExtensions carry
+// ServiceExtensions. FakeClient records the actions taken on it, in order,
+// and is threadsafe.
+type FakeClient struct {
+	*fakeosb.FakeClient
+
+	ServiceExtensions   map[string]brokerclient.ServiceExtensions
+	GetInstanceReaction GetInstanceReactionInterface
+
+	mu      sync.Mutex
+	actions []fakeosb.Action
+}
+
+var _ brokerclient.Client = &FakeClient{}
+
+// Actions returns the actions taken on the FakeClient.
+func (c *FakeClient) Actions() []fakeosb.Action {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.actions
+}
+
+func (c *FakeClient) record(actionType fakeosb.ActionType, request interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.actions = append(c.actions, fakeosb.Action{Type: actionType, Request: request})
+}
+
+// GetCatalog implements the Client.GetCatalog method for the FakeClient.
+func (c *FakeClient) GetCatalog() (*osb.CatalogResponse, error) {
+	c.record(fakeosb.GetCatalog, nil)
+	return c.FakeClient.GetCatalog()
+}
+
+// GetCatalogWithExtensions implements the Client.GetCatalogWithExtensions
+// method for the FakeClient.
+func (c *FakeClient) GetCatalogWithExtensions() (*brokerclient.CatalogResponse, error) {
+	c.record(fakeosb.GetCatalog, nil)
+	response, err := c.FakeClient.GetCatalog()
+	if err != nil {
+		return nil, err
+	}
+	return &brokerclient.CatalogResponse{
+		CatalogResponse:   *response,
+		ServiceExtensions: c.ServiceExtensions,
+	}, nil
+}
+
+// ProvisionInstance implements the Client.ProvisionInstance method for the
+// FakeClient.
+func (c *FakeClient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	c.record(fakeosb.ProvisionInstance, r)
+	return c.FakeClient.ProvisionInstance(r)
+}
+
+// ProvisionInstanceWithExtensions implements the
+// Client.ProvisionInstanceWithExtensions method for the FakeClient. The
+// recorded request is the embedded osb.ProvisionRequest.
+func (c *FakeClient) ProvisionInstanceWithExtensions(r *brokerclient.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	return c.ProvisionInstance(&r.ProvisionRequest)
+}
+
+// UpdateInstance implements the Client.UpdateInstance method for the
+// FakeClient.
+func (c *FakeClient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	c.record(fakeosb.UpdateInstance, r)
+	return c.FakeClient.UpdateInstance(r)
+}
+
+// UpdateInstanceWithExtensions implements the
+// Client.UpdateInstanceWithExtensions method for the FakeClient. The recorded
+// request is the embedded osb.UpdateInstanceRequest.
+func (c *FakeClient) UpdateInstanceWithExtensions(r *brokerclient.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	return c.UpdateInstance(&r.UpdateInstanceRequest)
+}
+
+// DeprovisionInstance implements the Client.DeprovisionInstance method on the
+// FakeClient.
+func (c *FakeClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	c.record(fakeosb.DeprovisionInstance, r)
+	return c.FakeClient.DeprovisionInstance(r)
+}
+
+// PollLastOperation implements the Client.PollLastOperation method on the
+// FakeClient.
+func (c *FakeClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	c.record(fakeosb.PollLastOperation, r)
+	return c.FakeClient.PollLastOperation(r)
+}
+
+// PollBindingLastOperation implements the Client.PollBindingLastOperation
+// method on the FakeClient.
+func (c *FakeClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	c.record(fakeosb.PollBindingLastOperation, r)
+	return c.FakeClient.PollBindingLastOperation(r)
+}
+
+// Bind implements the Client.Bind method on the FakeClient.
+func (c *FakeClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	c.record(fakeosb.Bind, r)
+	return c.FakeClient.Bind(r)
+}
+
+// Unbind implements the Client.Unbind method on the FakeClient.
+func (c *FakeClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	c.record(fakeosb.Unbind, r)
+	return c.FakeClient.Unbind(r)
+}
+
+// GetBinding implements the Client.GetBinding method for the FakeClient.
+func (c *FakeClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	c.record(fakeosb.GetBinding, r)
+	return c.FakeClient.GetBinding(r)
+}
+
+// GetInstance implements the Client.GetInstance method for the FakeClient.
+func (c *FakeClient) GetInstance(r *brokerclient.GetInstanceRequest) (*brokerclient.GetInstanceResponse, error) {
+	c.record(GetInstance, r)
+
+	if c.GetInstanceReaction != nil {
+		return c.GetInstanceReaction.react(r)
+	}
+
+	return nil, fakeosb.UnexpectedActionError()
+}
+
+// GetInstanceReactionInterface defines the reaction to GetInstance requests.
+type GetInstanceReactionInterface interface {
+	react(*brokerclient.GetInstanceRequest) (*brokerclient.GetInstanceResponse, error)
+}
+
+// GetInstanceReaction sets the reaction to GetInstance requests.
+type GetInstanceReaction struct {
+	Response *brokerclient.GetInstanceResponse
+	Error    error
+}
+
+func (r *GetInstanceReaction) react(_ *brokerclient.GetInstanceRequest) (*brokerclient.GetInstanceResponse, error) {
+	if r == nil {
+		return nil, fakeosb.UnexpectedActionError()
+	}
+	return r.Response, r.Error
+}
+
+// DynamicGetInstanceReaction sets the reaction to GetInstance requests with a
+// function of the request.
+type DynamicGetInstanceReaction func(*brokerclient.GetInstanceRequest) (*brokerclient.GetInstanceResponse, error)
+
+func (r DynamicGetInstanceReaction) react(req *brokerclient.GetInstanceRequest) (*brokerclient.GetInstanceResponse, error) {
+	return r(req)
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package brokerclient extends the Open Service Broker client vendored from
+// go-open-service-broker-client with the parts of the API that the vendored
+// version does not implement: fetching instances, the instances_retrievable
+// field of services, and the maintenance_info of plans and of provision and
+// update requests. Only the requests that need them are sent by this package;
+// all other requests are sent by the vendored client.
+package brokerclient
+
+import (
+	"fmt"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+// Client is an OSB V2 client that also implements the parts of the API that
+// osb.Client does not.
+type Client interface {
+	osb.Client
+
+	// GetCatalogWithExtensions gets the catalog of the broker, along with
+	// the fields of its services and plans that osb.CatalogResponse does
+	// not hold.
+	GetCatalogWithExtensions() (*CatalogResponse, error)
+	// ProvisionInstanceWithExtensions provisions an instance, also sending
+	// the fields of the request that osb.ProvisionRequest does not hold.
+	ProvisionInstanceWithExtensions(r *ProvisionRequest) (*osb.ProvisionResponse, error)
+	// UpdateInstanceWithExtensions updates an instance, also sending the
+	// fields of the request that osb.UpdateInstanceRequest does not hold.
+	UpdateInstanceWithExtensions(r *UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error)
+	// GetInstance returns information about an existing instance, with a
+	// GET on the endpoint of the instance
+	// (/v2/service_instances/instance-id). Like GetBinding, it is an alpha
+	// API method that requires alpha features and the latest API version.
+	GetInstance(r *GetInstanceRequest) (*GetInstanceResponse, error)
+}
+
+// CreateFunc creates a Client with the given configuration.
+type CreateFunc func(config *osb.ClientConfiguration) (Client, error)
+
+// MaintenanceInfo describes the version of the software that the instances of
+// a plan run.
+type MaintenanceInfo struct {
+	// Version is the semantic version of the maintenance information.
+	Version *string `json:"version,omitempty"`
+	// Description describes the changes of this version.  Optional.
+	Description *string `json:"description,omitempty"`
+}
+
+// CatalogResponse is the catalog of a broker, along with the fields of its
+// services and plans that osb.CatalogResponse does not hold.
+type CatalogResponse struct {
+	osb.CatalogResponse
+
+	// ServiceExtensions holds the fields of the services of the catalog
+	// that osb.Service does not hold, by service ID.
+	ServiceExtensions map[string]ServiceExtensions
+}
+
+// ServiceExtensions holds the fields of a service that osb.Service does not
+// hold.
+type ServiceExtensions struct {
+	// InstancesRetrievable represents whether fetching a service instance
+	// via a GET on the instance resource's endpoint
+	// (/v2/service_instances/instance-id) is supported for all plans.
+	InstancesRetrievable bool `json:"instances_retrievable,omitempty"`
+	// PlanExtensions holds the fields of the plans of the service that
+	// osb.Plan does not hold, by plan ID.
+	PlanExtensions map[string]PlanExtensions `json:"-"`
+}
+
+// PlanExtensions holds the fields of a plan that osb.Plan does not hold.
+type PlanExtensions struct {
+	// MaintenanceInfo is the maintenance information of the plan.  Optional.
+	MaintenanceInfo *MaintenanceInfo `json:"maintenance_info,omitempty"`
+}
+
+// ProvisionRequest is a request to provision an instance, along with the
+// fields that osb.ProvisionRequest does not hold.
+type ProvisionRequest struct {
+	osb.ProvisionRequest
+
+	// MaintenanceInfo is the maintenance information of the plan the
+	// instance is provisioned to.  Optional.
+	MaintenanceInfo *MaintenanceInfo
+}
+
+// UpdateInstanceRequest is a request to update an instance, along with the
+// fields that osb.UpdateInstanceRequest does not hold.
+type UpdateInstanceRequest struct {
+	osb.UpdateInstanceRequest
+
+	// MaintenanceInfo is the maintenance information of the plan the
+	// instance is updated to.  Optional.
+	MaintenanceInfo *MaintenanceInfo
+}
+
+// GetInstanceRequest represents a request to do a GET on a particular
+// instance.
+type GetInstanceRequest struct {
+	// InstanceID is the ID of the instance.
+	InstanceID string `json:"instance_id"`
+	// ServiceID is the ID of the service the instance was provisioned from.
+	ServiceID string `json:"service_id"`
+	// PlanID is the ID of the plan the instance was provisioned from.
+	PlanID string `json:"plan_id"`
+}
+
+// GetInstanceResponse is sent as the response to doing a GET on a particular
+// instance.
+type GetInstanceResponse struct {
+	// ServiceID is the ID of the service the instance was provisioned from.
+	ServiceID string `json:"service_id"`
+	// PlanID is the ID of the plan the instance runs.
+	PlanID string `json:"plan_id"`
+	// DashboardURL is the URL of a web-based management user interface for
+	// the service instance.
+	DashboardURL *string `json:"dashboard_url,omitempty"`
+	// Parameters is the configuration parameters of the instance.
+	Parameters map[string]interface{} `json:"parameters,omitempty"`
+}
+
+// GetInstanceNotAllowedError is an error type signifying that doing a GET to
+// fetch an instance is not allowed for this client.
+type GetInstanceNotAllowedError struct {
+	reason string
+}
+
+func (e GetInstanceNotAllowedError) Error() string {
+	return fmt.Sprintf("GetInstance not allowed: %s", e.reason)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerhealth
+
+import (
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
+)
+
+// trackingClient provides an implementation of the OSB V2 Client interface
+// that proxies the requests to the underlying client and records their
+// results in a Tracker.
+type trackingClient struct {
+	client  brokerclient.Client
+	broker  string
+	tracker *Tracker
+}
+
+// NewClient returns a client that records the results of the requests of the
+// given client in the given tracker, as the results of requests sent to the
+// broker with the given name.
+func NewClient(client brokerclient.Client, brokerName string, tracker *Tracker) brokerclient.Client {
+	return &trackingClient{
+		client:  client,
+		broker:  brokerName,
+		tracker: tracker,
+	}
+}
+
+var _ brokerclient.Client = &trackingClient{}
+
+// GetCatalog implements go-open-service-broker-client/v2/Client.GetCatalog.
+func (c *trackingClient) GetCatalog() (*osb.CatalogResponse, error) {
+	response, err := c.client.GetCatalog()
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
+
+// GetCatalogWithExtensions implements
+// brokerclient.Client.GetCatalogWithExtensions.
+func (c *trackingClient) GetCatalogWithExtensions() (*brokerclient.CatalogResponse, error) {
+	response, err := c.client.GetCatalogWithExtensions()
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
+
+// ProvisionInstance implements
+// go-open-service-broker-client/v2/Client.ProvisionInstance.
+func (c *trackingClient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	response, err := c.client.ProvisionInstance(r)
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
+
+// ProvisionInstanceWithExtensions implements
+// brokerclient.Client.ProvisionInstanceWithExtensions.
+func (c *trackingClient) ProvisionInstanceWithExtensions(r *brokerclient.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	response, err := c.client.ProvisionInstanceWithExtensions(r)
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
+
+// UpdateInstance implements
+// go-open-service-broker-client/v2/Client.UpdateInstance.
+func (c *trackingClient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	response, err := c.client.UpdateInstance(r)
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
+
+// UpdateInstanceWithExtensions implements
+// brokerclient.Client.UpdateInstanceWithExtensions.
+func (c *trackingClient) UpdateInstanceWithExtensions(r *brokerclient.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	response, err := c.client.UpdateInstanceWithExtensions(r)
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
+
+// DeprovisionInstance implements
+// go-open-service-broker-client/v2/Client.DeprovisionInstance.
+func (c *trackingClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	response, err := c.client.DeprovisionInstance(r)
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
+
+// GetInstance implements brokerclient.Client.GetInstance.
+func (c *trackingClient) GetInstance(r *brokerclient.GetInstanceRequest) (*brokerclient.GetInstanceResponse, error) {
+	response, err := c.client.GetInstance(r)
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
+
+// PollLastOperation implements
+// go-open-service-broker-client/v2/Client.PollLastOperation.
+func (c *trackingClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	response, err := c.client.PollLastOperation(r)
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
+
+// PollBindingLastOperation implements
+// go-open-service-broker-client/v2/Client.PollBindingLastOperation.
+func (c *trackingClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	response, err := c.client.PollBindingLastOperation(r)
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
+
+// Bind implements go-open-service-broker-client/v2/Client.Bind.
+func (c *trackingClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	response, err := c.client.Bind(r)
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
+
+// Unbind implements go-open-service-broker-client/v2/Client.Unbind.
+func (c *trackingClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	response, err := c.client.Unbind(r)
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
+
+// GetBinding implements go-open-service-broker-client/v2/Client.GetBinding.
+func (c *trackingClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	response, err := c.client.GetBinding(r)
+	c.tracker.RecordResult(c.broker, err)
+	return response, err
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerhealth
+
+import (
+	"testing"
+	"time"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+
+	fakebrokerclient "github.com/kubernetes-incubator/service-catalog/pkg/brokerclient/fake"
+)
+
+func TestClient(t *testing.T) {
+	tracker := NewTracker(2, time.Minute, time.Hour, nil)
+	fakeClient := fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{
+		FakeClientConfiguration: fakeosb.FakeClientConfiguration{
+			CatalogReaction:           &fakeosb.CatalogReaction{Error: errUnreachable},
+			PollLastOperationReaction: &fakeosb.PollLastOperationReaction{Error: errServer},
+			BindReaction:              &fakeosb.BindReaction{Response: &osb.BindResponse{}},
+		},
+	})
+	client := NewClient(fakeClient, "broker", tracker)
+
+	if _, err := client.GetCatalog(); err != errUnreachable {
+		t.Fatalf("expected the error of the underlying client, got %v", err)
+	}
+	client.PollLastOperation(&osb.LastOperationRequest{})
+	if status, _ := tracker.Status("broker"); !status.Open {
+		t.Fatalf("expected the circuit to be open, got %+v", status)
+	}
+
+	if _, err := client.Bind(&osb.BindRequest{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if status, _ := tracker.Status("broker"); status.Open {
+		t.Fatalf("expected the circuit to be closed, got %+v", status)
+	}
+}
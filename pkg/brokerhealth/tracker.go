@@ -0,0 +1,286 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package brokerhealth tracks the health of the brokers from the results of
+// the requests that the controller sends to them. After repeated failures the
+// circuit of a broker is opened, so that the work for the resources of the
+// broker can be paused until the broker responds again.
+package brokerhealth
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+// Tracker tracks the health of brokers, identified by their names. It is safe
+// for concurrent use.
+type Tracker struct {
+	failureThreshold int
+	openDuration     time.Duration
+	retention        time.Duration
+	onChange         func(broker string)
+	now              func() time.Time
+
+	mu      sync.Mutex
+	brokers map[string]*brokerState
+}
+
+// Status is the health of a broker.
+type Status struct {
+	// Open is whether the circuit of the broker is open.
+	Open bool
+	// ConsecutiveFailures is the number of requests that failed since the
+	// last request that did not.
+	ConsecutiveFailures int
+	// LastError is the error of the last failed request, if
+	// ConsecutiveFailures is not zero.
+	LastError string
+}
+
+// interval is a period during which the circuit of a broker was open. The
+// end of the ongoing period is zero.
+type interval struct {
+	start, end time.Time
+}
+
+type brokerState struct {
+	Status
+	// retryAt is the time after which work for the broker may proceed again
+	// to probe it, while its circuit is open.
+	retryAt time.Time
+	// probing is whether work proceeded to probe the broker and no result
+	// was recorded since.
+	probing bool
+	// openIntervals are the periods during which the circuit was open, the
+	// last one being ongoing while the circuit is open.
+	openIntervals []interval
+}
+
+// NewTracker returns a Tracker that opens the circuit of a broker after the
+// given number of consecutive failed requests. While the circuit is open,
+// work for the broker is let through once every openDuration to probe
+// whether the broker recovered; the circuit is closed again by the first
+// request that does not fail. The periods during which the circuits were
+// open are remembered for the given retention.
+//
+// onChange, if not nil, is called whenever the circuit of a broker is opened
+// or closed, and when the first result is recorded for a broker.
+func NewTracker(failureThreshold int, openDuration, retention time.Duration, onChange func(broker string)) *Tracker {
+	return &Tracker{
+		failureThreshold: failureThreshold,
+		openDuration:     openDuration,
+		retention:        retention,
+		onChange:         onChange,
+		now:              time.Now,
+		brokers:          make(map[string]*brokerState),
+	}
+}
+
+// RecordResult records the result of a request sent to the given broker.
+func (t *Tracker) RecordResult(broker string, err error) {
+	if t.recordResult(broker, err) && t.onChange != nil {
+		t.onChange(broker)
+	}
+}
+
+// recordResult records the result of a request and returns whether onChange
+// should be called.
+func (t *Tracker) recordResult(broker string, err error) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	state, known := t.brokers[broker]
+	if !known {
+		state = &brokerState{}
+		t.brokers[broker] = state
+		metrics.BrokerCircuitOpen.WithLabelValues(broker).Set(0)
+	}
+
+	state.probing = false
+	if !isFailure(err) {
+		state.ConsecutiveFailures = 0
+		state.LastError = ""
+		if state.Open {
+			state.Open = false
+			state.openIntervals[len(state.openIntervals)-1].end = now
+			metrics.BrokerCircuitOpen.WithLabelValues(broker).Set(0)
+			return true
+		}
+		return !known
+	}
+
+	state.ConsecutiveFailures++
+	state.LastError = err.Error()
+	if state.Open {
+		// The probe failed, wait for another period before the next one.
+		state.retryAt = now.Add(t.openDuration)
+		return false
+	}
+	if state.ConsecutiveFailures < t.failureThreshold {
+		return !known
+	}
+
+	state.Open = true
+	state.retryAt = now.Add(t.openDuration)
+	var openIntervals []interval
+	for _, i := range state.openIntervals {
+		if i.end.After(now.Add(-t.retention)) {
+			openIntervals = append(openIntervals, i)
+		}
+	}
+	state.openIntervals = append(openIntervals, interval{start: now})
+	metrics.BrokerCircuitOpen.WithLabelValues(broker).Set(1)
+	return true
+}
+
+// Allow returns whether work that sends requests to the given broker may
+// proceed. While the circuit of the broker is open, it returns false and how
+// long to wait before trying again, except once every openDuration when the
+// work may proceed to probe the broker, in which case probe is true. Work that
+// proceeds to probe the broker must call CancelProbe if it ends up not sending
+// any request to the broker.
+func (t *Tracker) Allow(broker string) (allowed, probe bool, retryAfter time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.brokers[broker]
+	if !ok || !state.Open {
+		return true, false, 0
+	}
+	now := t.now()
+	if now.Before(state.retryAt) {
+		return false, false, state.retryAt.Sub(now)
+	}
+	state.retryAt = now.Add(t.openDuration)
+	state.probing = true
+	return true, true, 0
+}
+
+// CancelProbe lets other work probe the given broker right away if the work
+// that Allow let through to probe it did not send any request to the broker.
+func (t *Tracker) CancelProbe(broker string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.brokers[broker]
+	if !ok || !state.probing {
+		return
+	}
+	state.probing = false
+	state.retryAt = t.now()
+}
+
+// Restore opens the circuit of the given broker, which has been open since
+// the given time with the given last error, unless results were already
+// recorded for the broker. It lets a new Tracker resume the pause of a broker
+// recorded by a previous one, for example in the status of the broker, so
+// that the time during which the circuit was open before still does not count
+// as time spent retrying. The broker may be probed right away.
+func (t *Tracker) Restore(broker string, openSince time.Time, lastError string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, known := t.brokers[broker]; known {
+		return
+	}
+	t.brokers[broker] = &brokerState{
+		Status: Status{
+			Open:                true,
+			ConsecutiveFailures: t.failureThreshold,
+			LastError:           lastError,
+		},
+		retryAt:       t.now(),
+		openIntervals: []interval{{start: openSince}},
+	}
+	metrics.BrokerCircuitOpen.WithLabelValues(broker).Set(1)
+}
+
+// Status returns the health of the given broker, and false if no request to
+// the broker was recorded.
+func (t *Tracker) Status(broker string) (Status, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.brokers[broker]
+	if !ok {
+		return Status{}, false
+	}
+	return state.Status, true
+}
+
+// OpenDurationSince returns for how long the circuit of the given broker was
+// open since the given time, as far as the retention of the tracker goes.
+func (t *Tracker) OpenDurationSince(broker string, since time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	state, ok := t.brokers[broker]
+	if !ok {
+		return 0
+	}
+	now := t.now()
+	var d time.Duration
+	for _, i := range state.openIntervals {
+		start, end := i.start, i.end
+		if end.IsZero() {
+			end = now
+		}
+		if start.Before(since) {
+			start = since
+		}
+		if end.After(start) {
+			d += end.Sub(start)
+		}
+	}
+	return d
+}
+
+// Forget drops the health of the given broker.
+func (t *Tracker) Forget(broker string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.brokers[broker]; ok {
+		delete(t.brokers, broker)
+		metrics.BrokerCircuitOpen.DeleteLabelValues(broker)
+	}
+}
+
+// isFailure returns whether the given result of a request shows that the
+// broker is unhealthy: the request could not be sent, or the broker failed to
+// process it or asked to slow down. The errors the broker returns for the
+// requests it rejects, and the errors of the client for the requests it
+// refuses to send, do not count as failures.
+func isFailure(err error) bool {
+	if err == nil {
+		return false
+	}
+	if httpErr, ok := osb.IsHTTPError(err); ok {
+		return httpErr.StatusCode >= http.StatusInternalServerError || httpErr.StatusCode == http.StatusTooManyRequests
+	}
+	switch err.(type) {
+	case *url.Error, net.Error:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,209 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerhealth
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+)
+
+var (
+	errUnreachable = &url.Error{Op: "Get", URL: "https://broker.example.com/v2/catalog", Err: errors.New("connection refused")}
+	errServer      = osb.HTTPStatusCodeError{StatusCode: http.StatusBadGateway}
+	errRejected    = osb.HTTPStatusCodeError{StatusCode: http.StatusBadRequest}
+)
+
+func TestIsFailure(t *testing.T) {
+	cases := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{name: "success"},
+		{name: "unreachable", err: errUnreachable, expected: true},
+		{name: "server error", err: errServer, expected: true},
+		{name: "too many requests", err: osb.HTTPStatusCodeError{StatusCode: http.StatusTooManyRequests}, expected: true},
+		{name: "rejected", err: errRejected},
+		{name: "timeout", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("i/o timeout")}, expected: true},
+		{name: "instance not retrievable", err: brokerclient.GetInstanceNotAllowedError{}},
+		{name: "binding not retrievable", err: osb.GetBindingNotAllowedError{}},
+		{name: "async binding not allowed", err: osb.AsyncBindingOperationsNotAllowedError{}},
+		{name: "missing field", err: errors.New("instanceID is required")},
+	}
+	for _, tc := range cases {
+		if e, a := tc.expected, isFailure(tc.err); e != a {
+			t.Errorf("%v: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
+
+func TestTracker(t *testing.T) {
+	now := time.Now()
+	var changes []string
+	tracker := NewTracker(3, time.Minute, time.Hour, func(broker string) {
+		changes = append(changes, broker)
+	})
+	tracker.now = func() time.Time { return now }
+
+	expectAllowed := func(name string, expected bool, expectedRetryAfter time.Duration) {
+		allowed, _, retryAfter := tracker.Allow("broker")
+		if allowed != expected || retryAfter != expectedRetryAfter {
+			t.Fatalf("%v: expected allowed=%v retryAfter=%v, got allowed=%v retryAfter=%v", name, expected, expectedRetryAfter, allowed, retryAfter)
+		}
+	}
+	expectOpen := func(name string, expected bool) {
+		status, ok := tracker.Status("broker")
+		if !ok {
+			t.Fatalf("%v: expected the broker to be tracked", name)
+		}
+		if status.Open != expected {
+			t.Fatalf("%v: expected open=%v, got %+v", name, expected, status)
+		}
+	}
+	expectChanges := func(name string, expected int) {
+		if e, a := expected, len(changes); e != a {
+			t.Fatalf("%v: unexpected number of changes: expected %d, got %d", name, e, a)
+		}
+	}
+
+	if _, ok := tracker.Status("broker"); ok {
+		t.Fatal("expected an unknown broker not to be tracked")
+	}
+	expectAllowed("unknown broker", true, 0)
+
+	tracker.RecordResult("broker", nil)
+	expectChanges("first result", 1)
+	tracker.RecordResult("broker", errUnreachable)
+	tracker.RecordResult("broker", errRejected)
+	tracker.RecordResult("broker", errServer)
+	tracker.RecordResult("broker", errServer)
+	expectOpen("below the threshold", false)
+	expectChanges("below the threshold", 1)
+
+	tracker.RecordResult("broker", errUnreachable)
+	expectOpen("threshold reached", true)
+	expectChanges("threshold reached", 2)
+	if status, _ := tracker.Status("broker"); status.ConsecutiveFailures != 3 || status.LastError != errUnreachable.Error() {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	expectAllowed("open", false, time.Minute)
+
+	now = now.Add(time.Minute)
+	expectAllowed("probe", true, 0)
+	expectAllowed("probe in progress", false, time.Minute)
+	tracker.RecordResult("broker", errUnreachable)
+	expectOpen("probe failed", true)
+	expectChanges("probe failed", 2)
+
+	now = now.Add(30 * time.Second)
+	expectAllowed("after a failed probe", false, 30*time.Second)
+
+	tracker.RecordResult("broker", nil)
+	expectOpen("recovered", false)
+	expectChanges("recovered", 3)
+	expectAllowed("recovered", true, 0)
+
+	if e, a := 90*time.Second, tracker.OpenDurationSince("broker", now.Add(-time.Hour)); e != a {
+		t.Fatalf("unexpected open duration: expected %v, got %v", e, a)
+	}
+	if e, a := 30*time.Second, tracker.OpenDurationSince("broker", now.Add(-30*time.Second)); e != a {
+		t.Fatalf("unexpected open duration since the circuit opened: expected %v, got %v", e, a)
+	}
+
+	tracker.Forget("broker")
+	if _, ok := tracker.Status("broker"); ok {
+		t.Fatal("expected a forgotten broker not to be tracked")
+	}
+}
+
+func TestTrackerOpenDurationRetention(t *testing.T) {
+	now := time.Now()
+	tracker := NewTracker(1, time.Minute, time.Hour, nil)
+	tracker.now = func() time.Time { return now }
+
+	tracker.RecordResult("broker", errUnreachable)
+	now = now.Add(time.Minute)
+	tracker.RecordResult("broker", nil)
+
+	now = now.Add(2 * time.Hour)
+	tracker.RecordResult("broker", errUnreachable)
+	now = now.Add(time.Minute)
+
+	// The first period is past the retention of the tracker.
+	if e, a := time.Minute, tracker.OpenDurationSince("broker", time.Time{}); e != a {
+		t.Fatalf("unexpected open duration: expected %v, got %v", e, a)
+	}
+}
+
+func TestTrackerCancelProbe(t *testing.T) {
+	now := time.Now()
+	tracker := NewTracker(1, time.Minute, time.Hour, nil)
+	tracker.now = func() time.Time { return now }
+
+	tracker.RecordResult("broker", errUnreachable)
+	now = now.Add(time.Minute)
+	if allowed, probe, _ := tracker.Allow("broker"); !allowed || !probe {
+		t.Fatalf("expected the work to proceed to probe the broker, got allowed=%v probe=%v", allowed, probe)
+	}
+	if allowed, _, _ := tracker.Allow("broker"); allowed {
+		t.Fatal("expected no other work to proceed while the probe is in progress")
+	}
+
+	// The probe did not send any request.
+	tracker.CancelProbe("broker")
+	if allowed, probe, _ := tracker.Allow("broker"); !allowed || !probe {
+		t.Fatalf("expected other work to probe the broker right away, got allowed=%v probe=%v", allowed, probe)
+	}
+
+	// The probe sent a request, which failed.
+	tracker.RecordResult("broker", errUnreachable)
+	tracker.CancelProbe("broker")
+	if allowed, _, retryAfter := tracker.Allow("broker"); allowed || retryAfter != time.Minute {
+		t.Fatalf("expected the work to wait for the next probe, got allowed=%v retryAfter=%v", allowed, retryAfter)
+	}
+}
+
+func TestTrackerRestore(t *testing.T) {
+	now := time.Now()
+	tracker := NewTracker(3, time.Minute, time.Hour, nil)
+	tracker.now = func() time.Time { return now }
+
+	tracker.Restore("broker", now.Add(-10*time.Minute), errUnreachable.Error())
+	status, ok := tracker.Status("broker")
+	if !ok || !status.Open || status.ConsecutiveFailures != 3 || status.LastError != errUnreachable.Error() {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+	if e, a := 10*time.Minute, tracker.OpenDurationSince("broker", now.Add(-time.Hour)); e != a {
+		t.Fatalf("unexpected open duration: expected %v, got %v", e, a)
+	}
+	if allowed, probe, _ := tracker.Allow("broker"); !allowed || !probe {
+		t.Fatalf("expected the restored broker to be probed right away, got allowed=%v probe=%v", allowed, probe)
+	}
+
+	tracker.RecordResult("other-broker", nil)
+	tracker.Restore("other-broker", now.Add(-10*time.Minute), errUnreachable.Error())
+	if status, _ := tracker.Status("other-broker"); status.Open {
+		t.Fatal("expected a known broker not to be restored")
+	}
+}
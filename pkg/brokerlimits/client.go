@@ -0,0 +1,139 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerlimits
+
+import (
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
+)
+
+// limitingClient provides an implementation of the OSB V2 Client interface
+// that proxies the requests to the underlying client once they fit within
+// the limits of the broker.
+type limitingClient struct {
+	client  brokerclient.Client
+	broker  string
+	limits  Limits
+	limiter *Limiter
+}
+
+// NewClient returns a client that sends the requests of the given client
+// within the given limits, sharing the budgets held by the given limiter for
+// the broker with the given name.
+func NewClient(client brokerclient.Client, brokerName string, limits Limits, limiter *Limiter) brokerclient.Client {
+	return &limitingClient{
+		client:  client,
+		broker:  brokerName,
+		limits:  limits,
+		limiter: limiter,
+	}
+}
+
+var _ brokerclient.Client = &limitingClient{}
+
+// acquire waits until a request, or a last operation poll if poll is true,
+// fits within the limits of the broker.
+func (c *limitingClient) acquire(poll bool) func() {
+	return c.limiter.Acquire(c.broker, c.limits, poll)
+}
+
+// GetCatalog implements go-open-service-broker-client/v2/Client.GetCatalog.
+func (c *limitingClient) GetCatalog() (*osb.CatalogResponse, error) {
+	defer c.acquire(false)()
+	return c.client.GetCatalog()
+}
+
+// GetCatalogWithExtensions implements
+// brokerclient.Client.GetCatalogWithExtensions.
+func (c *limitingClient) GetCatalogWithExtensions() (*brokerclient.CatalogResponse, error) {
+	defer c.acquire(false)()
+	return c.client.GetCatalogWithExtensions()
+}
+
+// ProvisionInstance implements
+// go-open-service-broker-client/v2/Client.ProvisionInstance.
+func (c *limitingClient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	defer c.acquire(false)()
+	return c.client.ProvisionInstance(r)
+}
+
+// ProvisionInstanceWithExtensions implements
+// brokerclient.Client.ProvisionInstanceWithExtensions.
+func (c *limitingClient) ProvisionInstanceWithExtensions(r *brokerclient.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	defer c.acquire(false)()
+	return c.client.ProvisionInstanceWithExtensions(r)
+}
+
+// UpdateInstance implements
+// go-open-service-broker-client/v2/Client.UpdateInstance.
+func (c *limitingClient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	defer c.acquire(false)()
+	return c.client.UpdateInstance(r)
+}
+
+// UpdateInstanceWithExtensions implements
+// brokerclient.Client.UpdateInstanceWithExtensions.
+func (c *limitingClient) UpdateInstanceWithExtensions(r *brokerclient.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	defer c.acquire(false)()
+	return c.client.UpdateInstanceWithExtensions(r)
+}
+
+// DeprovisionInstance implements
+// go-open-service-broker-client/v2/Client.DeprovisionInstance.
+func (c *limitingClient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.DeprovisionResponse, error) {
+	defer c.acquire(false)()
+	return c.client.DeprovisionInstance(r)
+}
+
+// GetInstance implements brokerclient.Client.GetInstance.
+func (c *limitingClient) GetInstance(r *brokerclient.GetInstanceRequest) (*brokerclient.GetInstanceResponse, error) {
+	defer c.acquire(false)()
+	return c.client.GetInstance(r)
+}
+
+// PollLastOperation implements
+// go-open-service-broker-client/v2/Client.PollLastOperation.
+func (c *limitingClient) PollLastOperation(r *osb.LastOperationRequest) (*osb.LastOperationResponse, error) {
+	defer c.acquire(true)()
+	return c.client.PollLastOperation(r)
+}
+
+// PollBindingLastOperation implements
+// go-open-service-broker-client/v2/Client.PollBindingLastOperation.
+func (c *limitingClient) PollBindingLastOperation(r *osb.BindingLastOperationRequest) (*osb.LastOperationResponse, error) {
+	defer c.acquire(true)()
+	return c.client.PollBindingLastOperation(r)
+}
+
+// Bind implements go-open-service-broker-client/v2/Client.Bind.
+func (c *limitingClient) Bind(r *osb.BindRequest) (*osb.BindResponse, error) {
+	defer c.acquire(false)()
+	return c.client.Bind(r)
+}
+
+// Unbind implements go-open-service-broker-client/v2/Client.Unbind.
+func (c *limitingClient) Unbind(r *osb.UnbindRequest) (*osb.UnbindResponse, error) {
+	defer c.acquire(false)()
+	return c.client.Unbind(r)
+}
+
+// GetBinding implements go-open-service-broker-client/v2/Client.GetBinding.
+func (c *limitingClient) GetBinding(r *osb.GetBindingRequest) (*osb.GetBindingResponse, error) {
+	defer c.acquire(false)()
+	return c.client.GetBinding(r)
+}
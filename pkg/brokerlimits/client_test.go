@@ -0,0 +1,80 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerlimits
+
+import (
+	"testing"
+	"time"
+
+	osb "github.com/pmorie/go-open-service-broker-client/v2"
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+
+	fakebrokerclient "github.com/kubernetes-incubator/service-catalog/pkg/brokerclient/fake"
+)
+
+func TestClient(t *testing.T) {
+	limiter := NewLimiter()
+	limits := Limits{MaxInFlightRequests: 1, MaxInFlightPollRequests: 1}
+	fakeClient := fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{
+		FakeClientConfiguration: fakeosb.FakeClientConfiguration{
+			CatalogReaction:           &fakeosb.CatalogReaction{Response: &osb.CatalogResponse{}},
+			PollLastOperationReaction: &fakeosb.PollLastOperationReaction{Response: &osb.LastOperationResponse{}},
+		},
+	})
+	client := NewClient(fakeClient, "broker", limits, limiter)
+
+	release, _, ok := limiter.TryAcquire("broker", limits, false)
+	if !ok {
+		t.Fatal("expected the budget of the broker to be acquired")
+	}
+
+	// Polls have their own budget.
+	if _, err := client.PollLastOperation(&osb.LastOperationRequest{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := client.GetCatalog(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}()
+	select {
+	case <-done:
+		t.Fatal("expected the request to wait while the broker is at its limits")
+	case <-time.After(3 * inFlightRetryDelay):
+	}
+	if e, a := 1, len(fakeClient.Actions()); e != a {
+		t.Fatalf("expected %d requests to be sent, got %d", e, a)
+	}
+
+	release()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("expected the request to be sent once the broker is within its limits")
+	}
+	if e, a := 2, len(fakeClient.Actions()); e != a {
+		t.Fatalf("expected %d requests to be sent, got %d", e, a)
+	}
+
+	// The budget is released once the request completes.
+	if _, _, ok := limiter.TryAcquire("broker", limits, false); !ok {
+		t.Fatal("expected the budget of the broker to be released")
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package brokerlimits limits the number and the rate of the requests that
+// the controller sends to a broker, so that a burst of reconciles cannot
+// overload the broker. The limits are enforced by a client wrapping the
+// client of the broker: a request that does not fit within the limits of its
+// broker waits for its turn before it is sent.
+package brokerlimits
+
+import (
+	"sync"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// Limits are the limits on the requests sent to a broker. The last operation
+// polls are limited separately from the other requests. A limit of zero means
+// that the requests are not limited.
+type Limits struct {
+	// MaxInFlightRequests is the maximum number of requests, other than last
+	// operation polls, sent to the broker at the same time.
+	MaxInFlightRequests int
+	// RequestsPerSecond is the maximum rate of requests, other than last
+	// operation polls, sent to the broker.
+	RequestsPerSecond int
+	// MaxInFlightPollRequests is the maximum number of last operation polls
+	// sent to the broker at the same time.
+	MaxInFlightPollRequests int
+	// PollRequestsPerSecond is the maximum rate of last operation polls sent
+	// to the broker.
+	PollRequestsPerSecond int
+}
+
+const (
+	requestsBudget = "requests"
+	pollsBudget    = "polls"
+
+	// inFlightRetryDelay is how long to wait before trying again when the
+	// maximum number of requests are in flight, since there is no telling
+	// when one of them completes.
+	inFlightRetryDelay = 100 * time.Millisecond
+
+	// retryJitter is the maximum factor by which the delay before trying
+	// again is extended, so that the requests that waited together do not
+	// all try again at once.
+	retryJitter = 1.0
+)
+
+// budget limits the number and the rate of the requests of one kind sent to
+// a broker.
+type budget struct {
+	// inFlight holds a token for each request in flight; it is nil if the
+	// number of requests is not limited.
+	inFlight chan struct{}
+	// rateLimiter is nil if the rate of requests is not limited.
+	rateLimiter flowcontrol.RateLimiter
+	// rateRetryDelay is how long to wait for the rate limiter to let another
+	// request through.
+	rateRetryDelay time.Duration
+}
+
+func newBudget(maxInFlight, perSecond int) *budget {
+	b := &budget{}
+	if maxInFlight > 0 {
+		b.inFlight = make(chan struct{}, maxInFlight)
+	}
+	if perSecond > 0 {
+		b.rateLimiter = flowcontrol.NewTokenBucketRateLimiter(float32(perSecond), perSecond)
+		b.rateRetryDelay = time.Second / time.Duration(perSecond)
+	}
+	return b
+}
+
+// tryAcquire returns whether a request can be sent within the budget right
+// away. If it can, the returned function must be called once the request
+// completes; otherwise the returned duration is how long to wait before
+// trying again.
+func (b *budget) tryAcquire() (func(), time.Duration, bool) {
+	if b.inFlight != nil {
+		select {
+		case b.inFlight <- struct{}{}:
+		default:
+			return nil, inFlightRetryDelay, false
+		}
+	}
+	if b.rateLimiter != nil && !b.rateLimiter.TryAccept() {
+		b.release()
+		return nil, b.rateRetryDelay, false
+	}
+	return b.release, 0, true
+}
+
+func (b *budget) release() {
+	if b.inFlight != nil {
+		<-b.inFlight
+	}
+}
+
+// brokerBudgets are the budgets of a broker and the limits they enforce.
+type brokerBudgets struct {
+	limits   Limits
+	requests *budget
+	polls    *budget
+}
+
+// Limiter holds the budgets of brokers, identified by their names, so that
+// all the work for the resources of a broker shares them. It is safe for
+// concurrent use.
+type Limiter struct {
+	mu      sync.Mutex
+	brokers map[string]*brokerBudgets
+}
+
+// NewLimiter returns a Limiter without any budget.
+func NewLimiter() *Limiter {
+	return &Limiter{
+		brokers: make(map[string]*brokerBudgets),
+	}
+}
+
+// TryAcquire returns whether a request, or a last operation poll if poll is
+// true, can be sent to the given broker right away within the given limits of
+// the broker. If it can, the returned function must be called once the
+// request completes; otherwise the returned duration is how long to wait
+// before trying again. The budgets of a broker are created again when its
+// limits change.
+func (l *Limiter) TryAcquire(broker string, limits Limits, poll bool) (func(), time.Duration, bool) {
+	if limits == (Limits{}) {
+		return func() {}, 0, true
+	}
+	budgets := l.budgets(broker, limits)
+	if poll {
+		return budgets.polls.tryAcquire()
+	}
+	return budgets.requests.tryAcquire()
+}
+
+// Acquire waits until a request, or a last operation poll if poll is true,
+// can be sent to the given broker within the given limits of the broker. The
+// returned function must be called once the request completes.
+func (l *Limiter) Acquire(broker string, limits Limits, poll bool) func() {
+	release, retryAfter, ok := l.TryAcquire(broker, limits, poll)
+	if ok {
+		return release
+	}
+
+	budget := requestsBudget
+	if poll {
+		budget = pollsBudget
+	}
+	metrics.BrokerPostponedRequests.WithLabelValues(broker, budget).Inc()
+	start := time.Now()
+	for !ok {
+		time.Sleep(wait.Jitter(retryAfter, retryJitter))
+		release, retryAfter, ok = l.TryAcquire(broker, limits, poll)
+	}
+	metrics.BrokerRequestWaitSeconds.WithLabelValues(broker, budget).Observe(time.Since(start).Seconds())
+	return release
+}
+
+// budgets returns the budgets of the given broker for the given limits.
+func (l *Limiter) budgets(broker string, limits Limits) *brokerBudgets {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	budgets, ok := l.brokers[broker]
+	if !ok || budgets.limits != limits {
+		budgets = &brokerBudgets{
+			limits:   limits,
+			requests: newBudget(limits.MaxInFlightRequests, limits.RequestsPerSecond),
+			polls:    newBudget(limits.MaxInFlightPollRequests, limits.PollRequestsPerSecond),
+		}
+		l.brokers[broker] = budgets
+	}
+	return budgets
+}
+
+// Forget drops the budgets of the given broker.
+func (l *Limiter) Forget(broker string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.brokers, broker)
+}
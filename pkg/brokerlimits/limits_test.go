@@ -0,0 +1,118 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package brokerlimits
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLimiterUnlimited(t *testing.T) {
+	limiter := NewLimiter()
+	for i := 0; i < 100; i++ {
+		if _, _, ok := limiter.TryAcquire("broker", Limits{}, false); !ok {
+			t.Fatal("expected unlimited requests to be sent right away")
+		}
+	}
+	if e, a := 0, len(limiter.brokers); e != a {
+		t.Fatalf("expected no budget for unlimited brokers, got %d", a)
+	}
+}
+
+func TestLimiterMaxInFlightRequests(t *testing.T) {
+	limiter := NewLimiter()
+	limits := Limits{MaxInFlightRequests: 2}
+
+	release1, _, ok := limiter.TryAcquire("broker", limits, false)
+	if !ok {
+		t.Fatal("expected the first request to be sent")
+	}
+	if _, _, ok := limiter.TryAcquire("broker", limits, false); !ok {
+		t.Fatal("expected the second request to be sent")
+	}
+	_, retryAfter, ok := limiter.TryAcquire("broker", limits, false)
+	if ok {
+		t.Fatal("expected the third request to be postponed")
+	}
+	if e, a := inFlightRetryDelay, retryAfter; e != a {
+		t.Fatalf("unexpected retry delay: expected %v, got %v", e, a)
+	}
+	if _, _, ok := limiter.TryAcquire("other-broker", limits, false); !ok {
+		t.Fatal("expected the requests of another broker not to be limited")
+	}
+
+	release1()
+	if _, _, ok := limiter.TryAcquire("broker", limits, false); !ok {
+		t.Fatal("expected a request to be sent once another one completed")
+	}
+}
+
+func TestLimiterPollBudget(t *testing.T) {
+	limiter := NewLimiter()
+	limits := Limits{MaxInFlightRequests: 1, MaxInFlightPollRequests: 1}
+
+	if _, _, ok := limiter.TryAcquire("broker", limits, false); !ok {
+		t.Fatal("expected the request to be sent")
+	}
+	if _, _, ok := limiter.TryAcquire("broker", limits, true); !ok {
+		t.Fatal("expected the poll not to wait for the request in flight")
+	}
+	if _, _, ok := limiter.TryAcquire("broker", limits, true); ok {
+		t.Fatal("expected the second poll to be postponed")
+	}
+}
+
+func TestLimiterRequestsPerSecond(t *testing.T) {
+	limiter := NewLimiter()
+	limits := Limits{RequestsPerSecond: 10}
+
+	// The first 10 requests are sent right away, the next ones at 10 per
+	// second.
+	for i := 0; i < 10; i++ {
+		release, _, ok := limiter.TryAcquire("broker", limits, false)
+		if !ok {
+			t.Fatalf("expected request %d to be sent right away", i)
+		}
+		release()
+	}
+	_, retryAfter, ok := limiter.TryAcquire("broker", limits, false)
+	if ok {
+		t.Fatal("expected the request to be postponed")
+	}
+	if e, a := 100*time.Millisecond, retryAfter; e != a {
+		t.Fatalf("unexpected retry delay: expected %v, got %v", e, a)
+	}
+}
+
+func TestLimiterLimitsChanged(t *testing.T) {
+	limiter := NewLimiter()
+
+	if _, _, ok := limiter.TryAcquire("broker", Limits{MaxInFlightRequests: 1}, false); !ok {
+		t.Fatal("expected the first request to be sent")
+	}
+	if _, _, ok := limiter.TryAcquire("broker", Limits{MaxInFlightRequests: 1}, false); ok {
+		t.Fatal("expected the second request to be postponed")
+	}
+	if _, _, ok := limiter.TryAcquire("broker", Limits{MaxInFlightRequests: 2}, false); !ok {
+		t.Fatal("expected the request to be sent within the new limits")
+	}
+
+	limiter.Forget("broker")
+	if _, ok := limiter.brokers["broker"]; ok {
+		t.Fatal("expected the budgets of the broker to be dropped")
+	}
+}
@@ -28,6 +28,10 @@ type FakeServicecatalogV1beta1 struct {
 	*testing.Fake
 }
 
+func (c *FakeServicecatalogV1beta1) ClusterParameterDefaults() v1beta1.ClusterParameterDefaultInterface {
+	return &FakeClusterParameterDefaults{c}
+}
+
 func (c *FakeServicecatalogV1beta1) ClusterServiceBrokers() v1beta1.ClusterServiceBrokerInterface {
 	return &FakeClusterServiceBrokers{c}
 }
@@ -40,6 +44,10 @@ func (c *FakeServicecatalogV1beta1) ClusterServicePlans() v1beta1.ClusterService
 	return &FakeClusterServicePlans{c}
 }
 
+func (c *FakeServicecatalogV1beta1) ClusterServiceVisibilityPolicies() v1beta1.ClusterServiceVisibilityPolicyInterface {
+	return &FakeClusterServiceVisibilityPolicies{c}
+}
+
 func (c *FakeServicecatalogV1beta1) ServiceBindings(namespace string) v1beta1.ServiceBindingInterface {
 	return &FakeServiceBindings{c, namespace}
 }
@@ -56,6 +64,10 @@ func (c *FakeServicecatalogV1beta1) ServiceInstances(namespace string) v1beta1.S
 	return &FakeServiceInstances{c, namespace}
 }
 
+func (c *FakeServicecatalogV1beta1) ServiceInstanceQuotas(namespace string) v1beta1.ServiceInstanceQuotaInterface {
+	return &FakeServiceInstanceQuotas{c, namespace}
+}
+
 func (c *FakeServicecatalogV1beta1) ServicePlans(namespace string) v1beta1.ServicePlanInterface {
 	return &FakeServicePlans{c, namespace}
 }
@@ -18,16 +18,22 @@ limitations under the License.
 
 package v1beta1
 
+type ClusterParameterDefaultExpansion interface{}
+
 type ClusterServiceBrokerExpansion interface{}
 
 type ClusterServiceClassExpansion interface{}
 
 type ClusterServicePlanExpansion interface{}
 
+type ClusterServiceVisibilityPolicyExpansion interface{}
+
 type ServiceBindingExpansion interface{}
 
 type ServiceBrokerExpansion interface{}
 
 type ServiceClassExpansion interface{}
 
+type ServiceInstanceQuotaExpansion interface{}
+
 type ServicePlanExpansion interface{}
@@ -27,13 +27,16 @@ import (
 
 type ServicecatalogV1beta1Interface interface {
 	RESTClient() rest.Interface
+	ClusterParameterDefaultsGetter
 	ClusterServiceBrokersGetter
 	ClusterServiceClassesGetter
 	ClusterServicePlansGetter
+	ClusterServiceVisibilityPoliciesGetter
 	ServiceBindingsGetter
 	ServiceBrokersGetter
 	ServiceClassesGetter
 	ServiceInstancesGetter
+	ServiceInstanceQuotasGetter
 	ServicePlansGetter
 }
 
@@ -42,6 +45,10 @@ type ServicecatalogV1beta1Client struct {
 	restClient rest.Interface
 }
 
+func (c *ServicecatalogV1beta1Client) ClusterParameterDefaults() ClusterParameterDefaultInterface {
+	return newClusterParameterDefaults(c)
+}
+
 func (c *ServicecatalogV1beta1Client) ClusterServiceBrokers() ClusterServiceBrokerInterface {
 	return newClusterServiceBrokers(c)
 }
@@ -54,6 +61,10 @@ func (c *ServicecatalogV1beta1Client) ClusterServicePlans() ClusterServicePlanIn
 	return newClusterServicePlans(c)
 }
 
+func (c *ServicecatalogV1beta1Client) ClusterServiceVisibilityPolicies() ClusterServiceVisibilityPolicyInterface {
+	return newClusterServiceVisibilityPolicies(c)
+}
+
 func (c *ServicecatalogV1beta1Client) ServiceBindings(namespace string) ServiceBindingInterface {
 	return newServiceBindings(c, namespace)
 }
@@ -70,6 +81,10 @@ func (c *ServicecatalogV1beta1Client) ServiceInstances(namespace string) Service
 	return newServiceInstances(c, namespace)
 }
 
+func (c *ServicecatalogV1beta1Client) ServiceInstanceQuotas(namespace string) ServiceInstanceQuotaInterface {
+	return newServiceInstanceQuotas(c, namespace)
+}
+
 func (c *ServicecatalogV1beta1Client) ServicePlans(namespace string) ServicePlanInterface {
 	return newServicePlans(c, namespace)
 }
@@ -28,6 +28,10 @@ type FakeServicecatalog struct {
 	*testing.Fake
 }
 
+func (c *FakeServicecatalog) ClusterParameterDefaults() internalversion.ClusterParameterDefaultInterface {
+	return &FakeClusterParameterDefaults{c}
+}
+
 func (c *FakeServicecatalog) ClusterServiceBrokers() internalversion.ClusterServiceBrokerInterface {
 	return &FakeClusterServiceBrokers{c}
 }
@@ -40,6 +44,10 @@ func (c *FakeServicecatalog) ClusterServicePlans() internalversion.ClusterServic
 	return &FakeClusterServicePlans{c}
 }
 
+func (c *FakeServicecatalog) ClusterServiceVisibilityPolicies() internalversion.ClusterServiceVisibilityPolicyInterface {
+	return &FakeClusterServiceVisibilityPolicies{c}
+}
+
 func (c *FakeServicecatalog) ServiceBindings(namespace string) internalversion.ServiceBindingInterface {
 	return &FakeServiceBindings{c, namespace}
 }
@@ -56,6 +64,10 @@ func (c *FakeServicecatalog) ServiceInstances(namespace string) internalversion.
 	return &FakeServiceInstances{c, namespace}
 }
 
+func (c *FakeServicecatalog) ServiceInstanceQuotas(namespace string) internalversion.ServiceInstanceQuotaInterface {
+	return &FakeServiceInstanceQuotas{c, namespace}
+}
+
 func (c *FakeServicecatalog) ServicePlans(namespace string) internalversion.ServicePlanInterface {
 	return &FakeServicePlans{c, namespace}
 }
@@ -18,12 +18,16 @@ limitations under the License.
 
 package internalversion
 
+type ClusterParameterDefaultExpansion interface{}
+
 type ClusterServiceBrokerExpansion interface{}
 
 type ClusterServiceClassExpansion interface{}
 
 type ClusterServicePlanExpansion interface{}
 
+type ClusterServiceVisibilityPolicyExpansion interface{}
+
 type ServiceBindingExpansion interface{}
 
 type ServiceBrokerExpansion interface{}
@@ -32,4 +36,6 @@ type ServiceClassExpansion interface{}
 
 type ServiceInstanceExpansion interface{}
 
+type ServiceInstanceQuotaExpansion interface{}
+
 type ServicePlanExpansion interface{}
@@ -25,13 +25,16 @@ import (
 
 type ServicecatalogInterface interface {
 	RESTClient() rest.Interface
+	ClusterParameterDefaultsGetter
 	ClusterServiceBrokersGetter
 	ClusterServiceClassesGetter
 	ClusterServicePlansGetter
+	ClusterServiceVisibilityPoliciesGetter
 	ServiceBindingsGetter
 	ServiceBrokersGetter
 	ServiceClassesGetter
 	ServiceInstancesGetter
+	ServiceInstanceQuotasGetter
 	ServicePlansGetter
 }
 
@@ -40,6 +43,10 @@ type ServicecatalogClient struct {
 	restClient rest.Interface
 }
 
+func (c *ServicecatalogClient) ClusterParameterDefaults() ClusterParameterDefaultInterface {
+	return newClusterParameterDefaults(c)
+}
+
 func (c *ServicecatalogClient) ClusterServiceBrokers() ClusterServiceBrokerInterface {
 	return newClusterServiceBrokers(c)
 }
@@ -52,6 +59,10 @@ func (c *ServicecatalogClient) ClusterServicePlans() ClusterServicePlanInterface
 	return newClusterServicePlans(c)
 }
 
+func (c *ServicecatalogClient) ClusterServiceVisibilityPolicies() ClusterServiceVisibilityPolicyInterface {
+	return newClusterServiceVisibilityPolicies(c)
+}
+
 func (c *ServicecatalogClient) ServiceBindings(namespace string) ServiceBindingInterface {
 	return newServiceBindings(c, namespace)
 }
@@ -68,6 +79,10 @@ func (c *ServicecatalogClient) ServiceInstances(namespace string) ServiceInstanc
 	return newServiceInstances(c, namespace)
 }
 
+func (c *ServicecatalogClient) ServiceInstanceQuotas(namespace string) ServiceInstanceQuotaInterface {
+	return newServiceInstanceQuotas(c, namespace)
+}
+
 func (c *ServicecatalogClient) ServicePlans(namespace string) ServicePlanInterface {
 	return newServicePlans(c, namespace)
 }
@@ -54,12 +54,16 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=servicecatalog.k8s.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("clusterparameterdefaults"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Servicecatalog().V1beta1().ClusterParameterDefaults().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("clusterservicebrokers"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Servicecatalog().V1beta1().ClusterServiceBrokers().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("clusterserviceclasses"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Servicecatalog().V1beta1().ClusterServiceClasses().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("clusterserviceplans"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Servicecatalog().V1beta1().ClusterServicePlans().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("clusterservicevisibilitypolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Servicecatalog().V1beta1().ClusterServiceVisibilityPolicies().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("servicebindings"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Servicecatalog().V1beta1().ServiceBindings().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("servicebrokers"):
@@ -68,6 +72,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Servicecatalog().V1beta1().ServiceClasses().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("serviceinstances"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Servicecatalog().V1beta1().ServiceInstances().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("serviceinstancequotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Servicecatalog().V1beta1().ServiceInstanceQuotas().Informer()}, nil
 	case v1beta1.SchemeGroupVersion.WithResource("serviceplans"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Servicecatalog().V1beta1().ServicePlans().Informer()}, nil
 
@@ -24,12 +24,16 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// ClusterParameterDefaults returns a ClusterParameterDefaultInformer.
+	ClusterParameterDefaults() ClusterParameterDefaultInformer
 	// ClusterServiceBrokers returns a ClusterServiceBrokerInformer.
 	ClusterServiceBrokers() ClusterServiceBrokerInformer
 	// ClusterServiceClasses returns a ClusterServiceClassInformer.
 	ClusterServiceClasses() ClusterServiceClassInformer
 	// ClusterServicePlans returns a ClusterServicePlanInformer.
 	ClusterServicePlans() ClusterServicePlanInformer
+	// ClusterServiceVisibilityPolicies returns a ClusterServiceVisibilityPolicyInformer.
+	ClusterServiceVisibilityPolicies() ClusterServiceVisibilityPolicyInformer
 	// ServiceBindings returns a ServiceBindingInformer.
 	ServiceBindings() ServiceBindingInformer
 	// ServiceBrokers returns a ServiceBrokerInformer.
@@ -38,6 +42,8 @@ type Interface interface {
 	ServiceClasses() ServiceClassInformer
 	// ServiceInstances returns a ServiceInstanceInformer.
 	ServiceInstances() ServiceInstanceInformer
+	// ServiceInstanceQuotas returns a ServiceInstanceQuotaInformer.
+	ServiceInstanceQuotas() ServiceInstanceQuotaInformer
 	// ServicePlans returns a ServicePlanInformer.
 	ServicePlans() ServicePlanInformer
 }
@@ -53,6 +59,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// ClusterParameterDefaults returns a ClusterParameterDefaultInformer.
+func (v *version) ClusterParameterDefaults() ClusterParameterDefaultInformer {
+	return &clusterParameterDefaultInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ClusterServiceBrokers returns a ClusterServiceBrokerInformer.
 func (v *version) ClusterServiceBrokers() ClusterServiceBrokerInformer {
 	return &clusterServiceBrokerInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
@@ -68,6 +79,11 @@ func (v *version) ClusterServicePlans() ClusterServicePlanInformer {
 	return &clusterServicePlanInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
 
+// ClusterServiceVisibilityPolicies returns a ClusterServiceVisibilityPolicyInformer.
+func (v *version) ClusterServiceVisibilityPolicies() ClusterServiceVisibilityPolicyInformer {
+	return &clusterServiceVisibilityPolicyInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
 // ServiceBindings returns a ServiceBindingInformer.
 func (v *version) ServiceBindings() ServiceBindingInformer {
 	return &serviceBindingInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -88,6 +104,11 @@ func (v *version) ServiceInstances() ServiceInstanceInformer {
 	return &serviceInstanceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// ServiceInstanceQuotas returns a ServiceInstanceQuotaInformer.
+func (v *version) ServiceInstanceQuotas() ServiceInstanceQuotaInformer {
+	return &serviceInstanceQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // ServicePlans returns a ServicePlanInformer.
 func (v *version) ServicePlans() ServicePlanInformer {
 	return &servicePlanInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -18,6 +18,10 @@ limitations under the License.
 
 package internalversion
 
+// ClusterParameterDefaultListerExpansion allows custom methods to be added to
+// ClusterParameterDefaultLister.
+type ClusterParameterDefaultListerExpansion interface{}
+
 // ClusterServiceBrokerListerExpansion allows custom methods to be added to
 // ClusterServiceBrokerLister.
 type ClusterServiceBrokerListerExpansion interface{}
@@ -30,6 +34,10 @@ type ClusterServiceClassListerExpansion interface{}
 // ClusterServicePlanLister.
 type ClusterServicePlanListerExpansion interface{}
 
+// ClusterServiceVisibilityPolicyListerExpansion allows custom methods to be added to
+// ClusterServiceVisibilityPolicyLister.
+type ClusterServiceVisibilityPolicyListerExpansion interface{}
+
 // ServiceBindingListerExpansion allows custom methods to be added to
 // ServiceBindingLister.
 type ServiceBindingListerExpansion interface{}
@@ -62,6 +70,14 @@ type ServiceInstanceListerExpansion interface{}
 // ServiceInstanceNamespaceLister.
 type ServiceInstanceNamespaceListerExpansion interface{}
 
+// ServiceInstanceQuotaListerExpansion allows custom methods to be added to
+// ServiceInstanceQuotaLister.
+type ServiceInstanceQuotaListerExpansion interface{}
+
+// ServiceInstanceQuotaNamespaceListerExpansion allows custom methods to be added to
+// ServiceInstanceQuotaNamespaceLister.
+type ServiceInstanceQuotaNamespaceListerExpansion interface{}
+
 // ServicePlanListerExpansion allows custom methods to be added to
 // ServicePlanLister.
 type ServicePlanListerExpansion interface{}
@@ -0,0 +1,106 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
+)
+
+// brokerClientCache holds the clients that the controller created for the
+// brokers, so that their auth secrets are not read and their connections are
+// not set up again on every reconcile. A client is only reused for the
+// version of the broker and of its auth secret it was created for, so that a
+// client created with credentials that changed in the meantime, or for a
+// broker that was deleted and created again, is never reused. The controller
+// also drops the client of a broker when the broker is deleted or when the
+// secret holding its auth credentials changes.
+type brokerClientCache struct {
+	mu      sync.Mutex
+	clients map[string]*cachedBrokerClient
+}
+
+// brokerClientVersion identifies the version of a broker and of its auth
+// secret that a client was created for.
+type brokerClientVersion struct {
+	uid        types.UID
+	generation int64
+	// secretResourceVersion is the resource version of the secret holding
+	// the auth credentials of the broker, or empty if the broker has no such
+	// secret.
+	secretResourceVersion string
+}
+
+// cachedBrokerClient is a client and the version of the broker it was
+// created for.
+type cachedBrokerClient struct {
+	version brokerClientVersion
+	client  brokerclient.Client
+}
+
+// newBrokerClientCache returns an empty brokerClientCache.
+func newBrokerClientCache() *brokerClientCache {
+	return &brokerClientCache{
+		clients: make(map[string]*cachedBrokerClient),
+	}
+}
+
+// brokerKey returns the key of the broker with the given metadata: its name
+// for a ClusterServiceBroker and "namespace/name" for a ServiceBroker.
+func brokerKey(meta metav1.ObjectMeta) string {
+	if meta.Namespace != "" {
+		return meta.Namespace + "/" + meta.Name
+	}
+	return meta.Name
+}
+
+// Get returns the client of the broker with the given key, if one was
+// created for the given version of the broker.
+func (c *brokerClientCache) Get(key string, version brokerClientVersion) (brokerclient.Client, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.clients[key]
+	if !ok || cached.version != version {
+		return nil, false
+	}
+	return cached.client, true
+}
+
+// Add caches the client created for the given version of the broker with
+// the given key, replacing any client previously cached for the broker.
+func (c *brokerClientCache) Add(key string, version brokerClientVersion, client brokerclient.Client) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clients[key] = &cachedBrokerClient{
+		version: version,
+		client:  client,
+	}
+}
+
+// Invalidate drops the client of the broker with the given key, if any.
+func (c *brokerClientCache) Invalidate(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.clients, key)
+}
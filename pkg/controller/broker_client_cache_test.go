@@ -0,0 +1,83 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	fakebrokerclient "github.com/kubernetes-incubator/service-catalog/pkg/brokerclient/fake"
+)
+
+func TestBrokerClientCache(t *testing.T) {
+	cache := newBrokerClientCache()
+	client := fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{})
+
+	version := brokerClientVersion{uid: types.UID("uid"), generation: 1, secretResourceVersion: "10"}
+
+	if _, ok := cache.Get("broker", version); ok {
+		t.Fatal("expected no client in an empty cache")
+	}
+
+	cache.Add("broker", version, client)
+	if cached, ok := cache.Get("broker", version); !ok || cached != client {
+		t.Fatal("expected the added client to be returned")
+	}
+	if _, ok := cache.Get("broker", brokerClientVersion{uid: "uid", generation: 2, secretResourceVersion: "10"}); ok {
+		t.Fatal("expected no client for another generation of the broker")
+	}
+	if _, ok := cache.Get("broker", brokerClientVersion{uid: "other-uid", generation: 1, secretResourceVersion: "10"}); ok {
+		t.Fatal("expected no client for a broker created again with the same name")
+	}
+	if _, ok := cache.Get("broker", brokerClientVersion{uid: "uid", generation: 1, secretResourceVersion: "11"}); ok {
+		t.Fatal("expected no client for another version of the auth secret")
+	}
+	if _, ok := cache.Get("ns/broker", version); ok {
+		t.Fatal("expected no client for another broker")
+	}
+
+	cache.Invalidate("broker")
+	if _, ok := cache.Get("broker", version); ok {
+		t.Fatal("expected no client after the client was dropped")
+	}
+}
+
+func TestBrokerKey(t *testing.T) {
+	cases := []struct {
+		name     string
+		meta     metav1.ObjectMeta
+		expected string
+	}{
+		{
+			name:     "cluster broker",
+			meta:     metav1.ObjectMeta{Name: "broker"},
+			expected: "broker",
+		},
+		{
+			name:     "namespaced broker",
+			meta:     metav1.ObjectMeta{Namespace: "ns", Name: "broker"},
+			expected: "ns/broker",
+		},
+	}
+	for _, tc := range cases {
+		if e, a := tc.expected, brokerKey(tc.meta); e != a {
+			t.Errorf("%v: unexpected key: %s", tc.name, expectedGot(e, a))
+		}
+	}
+}
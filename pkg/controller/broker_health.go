@@ -0,0 +1,253 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// Broker health. The results of the requests sent to the brokers are tracked
+// by c.brokerHealth, which opens the circuit of a broker after repeated
+// failures. While the circuit of a broker is open, the reconciliation of its
+// instances and bindings is postponed instead of failing, and the time during
+// which the circuit is open does not count towards their reconciliation retry
+// duration. The Reachable condition of a broker records since when its circuit
+// is open, so that the pause of the broker is resumed if the controller
+// manager restarts meanwhile.
+
+const (
+	successBrokerReachableReason  string = "BrokerReachable"
+	successBrokerReachableMessage string = "The broker responds to requests."
+	errorBrokerUnreachableReason  string = "BrokerUnreachable"
+	errorBrokerUnreachableMessage string = "The work for the resources of the broker is paused after %d consecutive failed requests. The last one failed with: %v"
+)
+
+// brokerHealthChanged adds the broker with the given key to its work queue,
+// so that its Reachable condition is updated.
+func (c *controller) brokerHealthChanged(key string) {
+	if strings.Contains(key, "/") {
+		c.serviceBrokerQueue.Add(key)
+	} else {
+		c.brokerQueue.Add(key)
+	}
+}
+
+// serviceInstanceBrokerKey returns the key of the broker of the given
+// instance, or "" if it cannot be determined.
+func (c *controller) serviceInstanceBrokerKey(instance *v1beta1.ServiceInstance) string {
+	if instance.Spec.ClusterServiceClassRef != nil {
+		class, err := c.clusterServiceClassLister.Get(instance.Spec.ClusterServiceClassRef.Name)
+		if err != nil {
+			return ""
+		}
+		return class.Spec.ClusterServiceBrokerName
+	}
+	if instance.Spec.ServiceClassRef != nil && c.serviceClassLister != nil {
+		class, err := c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name)
+		if err != nil {
+			return ""
+		}
+		return instance.Namespace + "/" + class.Spec.ServiceBrokerName
+	}
+	return ""
+}
+
+// serviceBindingBrokerKey returns the key of the broker of the instance of
+// the given binding, or "" if it cannot be determined.
+func (c *controller) serviceBindingBrokerKey(binding *v1beta1.ServiceBinding) string {
+	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.ServiceInstanceRef.Name)
+	if err != nil {
+		return ""
+	}
+	return c.serviceInstanceBrokerKey(instance)
+}
+
+// lookupBroker returns the spec and the status of the broker with the given
+// key, and false if the broker is not in the informer cache.
+func (c *controller) lookupBroker(brokerKey string) (*v1beta1.CommonServiceBrokerSpec, *v1beta1.CommonServiceBrokerStatus, bool) {
+	if !strings.Contains(brokerKey, "/") {
+		broker, err := c.brokerLister.Get(brokerKey)
+		if err != nil {
+			return nil, nil, false
+		}
+		return &broker.Spec.CommonServiceBrokerSpec, &broker.Status.CommonServiceBrokerStatus, true
+	}
+	if c.serviceBrokerLister == nil {
+		return nil, nil, false
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(brokerKey)
+	if err != nil {
+		return nil, nil, false
+	}
+	broker, err := c.serviceBrokerLister.ServiceBrokers(namespace).Get(name)
+	if err != nil {
+		return nil, nil, false
+	}
+	return &broker.Spec.CommonServiceBrokerSpec, &broker.Status.CommonServiceBrokerStatus, true
+}
+
+// restoreBrokerHealth resumes the pause of the broker with the given key and
+// status if its Reachable condition records that its circuit is open and no
+// request to the broker was tracked since the controller manager started.
+func (c *controller) restoreBrokerHealth(brokerKey string, brokerStatus *v1beta1.CommonServiceBrokerStatus) {
+	for _, condition := range brokerStatus.Conditions {
+		if condition.Type == v1beta1.ServiceBrokerConditionReachable && condition.Status == v1beta1.ConditionFalse && condition.Reason == errorBrokerUnreachableReason {
+			c.brokerHealth.Restore(brokerKey, condition.LastTransitionTime.Time, condition.Message)
+		}
+	}
+}
+
+// restoreBrokerHealthForKey is restoreBrokerHealth for the broker with the
+// given key in the informer cache.
+func (c *controller) restoreBrokerHealthForKey(brokerKey string) {
+	if _, status, ok := c.lookupBroker(brokerKey); ok {
+		c.restoreBrokerHealth(brokerKey, status)
+	}
+}
+
+// postponeForBroker returns whether the reconciliation of the resource with
+// the given key must be postponed because the circuit of the broker with the
+// given key is open, in which case the resource is added back to the given
+// queue once the broker may be probed again. Postponing a resource does not
+// count as a failed reconciliation. Otherwise the returned function must be
+// called once the reconciliation completes, so that the broker may be probed
+// by other work right away if the reconciliation was let through to probe it
+// but did not send any request to it.
+func (c *controller) postponeForBroker(pcb *pretty.ContextBuilder, queue workqueue.RateLimitingInterface, key, brokerKey string) (func(), bool) {
+	if c.brokerHealth == nil || brokerKey == "" {
+		return func() {}, false
+	}
+	c.restoreBrokerHealthForKey(brokerKey)
+	allowed, probe, retryAfter := c.brokerHealth.Allow(brokerKey)
+	if !allowed {
+		glog.V(4).Info(pcb.Messagef("Postponing reconciliation for %v because the circuit of broker %q is open", retryAfter, brokerKey))
+		queue.AddAfter(key, retryAfter)
+		return nil, true
+	}
+	if !probe {
+		return func() {}, false
+	}
+	glog.V(4).Info(pcb.Messagef("Probing broker %q whose circuit is open", brokerKey))
+	return func() { c.brokerHealth.CancelProbe(brokerKey) }, false
+}
+
+// serviceInstanceRetryDurationExceeded returns whether the operation on the
+// given instance has exceeded the reconciliation retry duration, not counting
+// the time during which the circuit of its broker was open.
+func (c *controller) serviceInstanceRetryDurationExceeded(instance *v1beta1.ServiceInstance) bool {
+	return c.reconciliationRetryDurationExceeded(c.withoutBrokerOpenDuration(instance.Status.OperationStartTime, c.serviceInstanceBrokerKey(instance)))
+}
+
+// serviceBindingRetryDurationExceeded is the binding counterpart of
+// serviceInstanceRetryDurationExceeded.
+func (c *controller) serviceBindingRetryDurationExceeded(binding *v1beta1.ServiceBinding) bool {
+	return c.reconciliationRetryDurationExceeded(c.withoutBrokerOpenDuration(binding.Status.OperationStartTime, c.serviceBindingBrokerKey(binding)))
+}
+
+// withoutBrokerOpenDuration returns the given operation start time moved
+// forward by the time during which the circuit of the broker with the given
+// key was open since then.
+func (c *controller) withoutBrokerOpenDuration(operationStartTime *metav1.Time, brokerKey string) *metav1.Time {
+	if c.brokerHealth == nil || brokerKey == "" || operationStartTime == nil {
+		return operationStartTime
+	}
+	c.restoreBrokerHealthForKey(brokerKey)
+	openDuration := c.brokerHealth.OpenDurationSince(brokerKey, operationStartTime.Time)
+	if openDuration == 0 {
+		return operationStartTime
+	}
+	t := metav1.NewTime(operationStartTime.Time.Add(openDuration))
+	return &t
+}
+
+// brokerReachableCondition returns the Reachable condition that the broker
+// with the given key should have, and false if no request to the broker was
+// tracked yet.
+func (c *controller) brokerReachableCondition(brokerKey string) (v1beta1.ServiceBrokerCondition, bool) {
+	if c.brokerHealth == nil {
+		return v1beta1.ServiceBrokerCondition{}, false
+	}
+	status, ok := c.brokerHealth.Status(brokerKey)
+	if !ok {
+		return v1beta1.ServiceBrokerCondition{}, false
+	}
+	if status.Open {
+		return v1beta1.ServiceBrokerCondition{
+			Type:    v1beta1.ServiceBrokerConditionReachable,
+			Status:  v1beta1.ConditionFalse,
+			Reason:  errorBrokerUnreachableReason,
+			Message: fmt.Sprintf(errorBrokerUnreachableMessage, status.ConsecutiveFailures, status.LastError),
+		}, true
+	}
+	return v1beta1.ServiceBrokerCondition{
+		Type:    v1beta1.ServiceBrokerConditionReachable,
+		Status:  v1beta1.ConditionTrue,
+		Reason:  successBrokerReachableReason,
+		Message: successBrokerReachableMessage,
+	}, true
+}
+
+// brokerReachableConditionOutdated returns the Reachable condition that a
+// broker with the given key and status should have, if it differs from the
+// one it has.
+func (c *controller) brokerReachableConditionOutdated(brokerKey string, brokerStatus *v1beta1.CommonServiceBrokerStatus) (v1beta1.ServiceBrokerCondition, bool) {
+	if c.brokerHealth != nil {
+		c.restoreBrokerHealth(brokerKey, brokerStatus)
+	}
+	condition, ok := c.brokerReachableCondition(brokerKey)
+	if !ok {
+		return condition, false
+	}
+	for _, existing := range brokerStatus.Conditions {
+		if existing.Type == condition.Type {
+			// The message changes with every failed probe; only the status
+			// is kept up to date to avoid an update per request.
+			return condition, existing.Status != condition.Status
+		}
+	}
+	return condition, true
+}
+
+// updateClusterServiceBrokerReachableCondition updates the Reachable
+// condition of the given broker if the health of the broker changed, and
+// returns whether it did.
+func (c *controller) updateClusterServiceBrokerReachableCondition(broker *v1beta1.ClusterServiceBroker) (bool, error) {
+	condition, outdated := c.brokerReachableConditionOutdated(brokerKey(broker.ObjectMeta), &broker.Status.CommonServiceBrokerStatus)
+	if !outdated {
+		return false, nil
+	}
+	return true, c.updateClusterServiceBrokerCondition(broker, condition.Type, condition.Status, condition.Reason, condition.Message)
+}
+
+// updateServiceBrokerReachableCondition is the namespaced counterpart of
+// updateClusterServiceBrokerReachableCondition.
+func (c *controller) updateServiceBrokerReachableCondition(broker *v1beta1.ServiceBroker) (bool, error) {
+	condition, outdated := c.brokerReachableConditionOutdated(brokerKey(broker.ObjectMeta), &broker.Status.CommonServiceBrokerStatus)
+	if !outdated {
+		return false, nil
+	}
+	return true, c.updateServiceBrokerCondition(broker, condition.Type, condition.Status, condition.Reason, condition.Message)
+}
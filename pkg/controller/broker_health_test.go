@@ -0,0 +1,313 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerhealth"
+	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+var errTestBrokerUnreachable = &url.Error{Op: "Get", URL: "https://example.com/v2/catalog", Err: errors.New("connection refused")}
+
+// TestReconcileServiceInstanceKeyBrokerCircuitOpen tests that the
+// reconciliation of an instance is postponed, without sending any request to
+// the broker, while the circuit of its broker is open.
+func TestReconcileServiceInstanceKeyBrokerCircuitOpen(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.brokerHealth = brokerhealth.NewTracker(1, time.Minute, time.Hour, nil)
+	testController.brokerHealth.RecordResult(testClusterServiceBrokerName, errTestBrokerUnreachable)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	instance := getTestServiceInstanceWithRefs()
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(instance)
+
+	if err := testController.reconcileServiceInstanceKey(testNamespace + "/" + testServiceInstanceName); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfClusterServiceBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+	if e, a := 0, testController.instanceQueue.NumRequeues(testNamespace+"/"+testServiceInstanceName); e != a {
+		t.Fatalf("expected the postponed instance not to be requeued as a failure: expected %d requeues, got %d", e, a)
+	}
+}
+
+// TestReconcileServiceBindingKeyBrokerCircuitOpen tests that the
+// reconciliation of a binding is postponed, without sending any request to
+// the broker, while the circuit of the broker of its instance is open.
+func TestReconcileServiceBindingKeyBrokerCircuitOpen(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.brokerHealth = brokerhealth.NewTracker(1, time.Minute, time.Hour, nil)
+	testController.brokerHealth.RecordResult(testClusterServiceBrokerName, errTestBrokerUnreachable)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithRefs())
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(getTestServiceBinding())
+
+	if err := testController.reconcileServiceBindingKey(testNamespace + "/" + testServiceBindingName); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfClusterServiceBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+	assertNumberOfActions(t, fakeCatalogClient.Actions(), 0)
+}
+
+// TestServiceInstanceRetryDurationExceededBrokerCircuitOpen tests that the
+// time during which the circuit of the broker of an instance was open does not
+// count towards the reconciliation retry duration of the instance.
+func TestServiceInstanceRetryDurationExceededBrokerCircuitOpen(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.reconciliationRetryDuration = time.Hour
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+
+	testController.brokerHealth = brokerhealth.NewTracker(1, time.Minute, 2*time.Hour, nil)
+	testController.brokerHealth.RecordResult(testClusterServiceBrokerName, errTestBrokerUnreachable)
+	time.Sleep(200 * time.Millisecond)
+	testController.brokerHealth.RecordResult(testClusterServiceBrokerName, nil)
+
+	// The operation started just over the retry duration ago, but before the
+	// circuit of the broker was opened.
+	instance := getTestServiceInstanceWithRefs()
+	startTime := metav1.NewTime(time.Now().Add(-time.Hour - 10*time.Millisecond))
+	instance.Status.OperationStartTime = &startTime
+
+	if testController.serviceInstanceRetryDurationExceeded(instance) {
+		t.Fatal("expected the time during which the circuit was open not to count towards the retry duration")
+	}
+
+	testController.brokerHealth = nil
+	if !testController.serviceInstanceRetryDurationExceeded(instance) {
+		t.Fatal("expected the retry duration to be exceeded without a circuit breaker")
+	}
+}
+
+// TestPostponeForBroker tests that work is postponed only while the circuit
+// of the broker is open.
+func TestPostponeForBroker(t *testing.T) {
+	_, _, _, testController, _ := newTestController(t, noFakeActions())
+	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, testNamespace, testServiceInstanceName)
+	key := testNamespace + "/" + testServiceInstanceName
+	postponeForBroker := func(brokerKey string) bool {
+		_, postponed := testController.postponeForBroker(pcb, testController.instanceQueue, key, brokerKey)
+		return postponed
+	}
+
+	if postponeForBroker(testClusterServiceBrokerName) {
+		t.Fatal("expected no work to be postponed without a circuit breaker")
+	}
+
+	testController.brokerHealth = brokerhealth.NewTracker(1, time.Minute, time.Hour, nil)
+	if postponeForBroker(testClusterServiceBrokerName) {
+		t.Fatal("expected no work to be postponed for an unknown broker")
+	}
+	testController.brokerHealth.RecordResult(testClusterServiceBrokerName, errTestBrokerUnreachable)
+	if !postponeForBroker(testClusterServiceBrokerName) {
+		t.Fatal("expected the work to be postponed while the circuit is open")
+	}
+	if postponeForBroker("") {
+		t.Fatal("expected no work to be postponed when the broker is unknown")
+	}
+	testController.brokerHealth.RecordResult(testClusterServiceBrokerName, nil)
+	if postponeForBroker(testClusterServiceBrokerName) {
+		t.Fatal("expected no work to be postponed once the circuit is closed")
+	}
+}
+
+// TestReconcileClusterServiceBrokerReachableCondition tests that the Reachable
+// condition of a broker follows the state of its circuit, even when the broker
+// would not be relisted.
+func TestReconcileClusterServiceBrokerReachableCondition(t *testing.T) {
+	cases := []struct {
+		name             string
+		result           error
+		existing         *v1beta1.ConditionStatus
+		expectedUpdate   bool
+		expectedStatus   v1beta1.ConditionStatus
+		expectedReason   string
+		disabledTracking bool
+	}{
+		{
+			name:             "circuit breaker disabled",
+			disabledTracking: true,
+		},
+		{
+			name:           "broker responds",
+			expectedUpdate: true,
+			expectedStatus: v1beta1.ConditionTrue,
+			expectedReason: successBrokerReachableReason,
+		},
+		{
+			name:           "circuit opened",
+			result:         errTestBrokerUnreachable,
+			existing:       conditionStatusPtr(v1beta1.ConditionTrue),
+			expectedUpdate: true,
+			expectedStatus: v1beta1.ConditionFalse,
+			expectedReason: errorBrokerUnreachableReason,
+		},
+		{
+			name:     "circuit still open",
+			result:   errTestBrokerUnreachable,
+			existing: conditionStatusPtr(v1beta1.ConditionFalse),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+			if !tc.disabledTracking {
+				testController.brokerHealth = brokerhealth.NewTracker(1, time.Minute, time.Hour, nil)
+				testController.brokerHealth.RecordResult(testClusterServiceBrokerName, tc.result)
+			}
+
+			broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionTrue)
+			if tc.existing != nil {
+				broker.Status.Conditions = append(broker.Status.Conditions, v1beta1.ServiceBrokerCondition{
+					Type:   v1beta1.ServiceBrokerConditionReachable,
+					Status: *tc.existing,
+				})
+			}
+
+			if err := reconcileClusterServiceBroker(t, testController, broker); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfClusterServiceBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+			actions := fakeCatalogClient.Actions()
+			if !tc.expectedUpdate {
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+			assertNumberOfActions(t, actions, 1)
+			updatedBroker := assertUpdateStatus(t, actions[0], broker)
+			assertClusterServiceBrokerReachableCondition(t, updatedBroker, tc.expectedStatus, tc.expectedReason)
+			assertClusterServiceBrokerReadyTrue(t, updatedBroker)
+		})
+	}
+}
+
+// TestSetCommonServiceBrokerConditionAppends tests that a condition of a new
+// type is added next to the existing conditions of a broker.
+func TestSetCommonServiceBrokerConditionAppends(t *testing.T) {
+	broker := getTestClusterServiceBrokerWithStatus(v1beta1.ConditionTrue)
+	pcb := pretty.NewContextBuilder(pretty.ClusterServiceBroker, "", broker.Name)
+
+	setCommonServiceBrokerCondition(pcb, &broker.ObjectMeta, &broker.Status.CommonServiceBrokerStatus, v1beta1.ServiceBrokerConditionReachable, v1beta1.ConditionFalse, errorBrokerUnreachableReason, "", time.Now())
+
+	if e, a := 2, len(broker.Status.Conditions); e != a {
+		t.Fatalf("unexpected number of conditions: expected %d, got %d", e, a)
+	}
+	assertClusterServiceBrokerReadyTrue(t, broker)
+	assertClusterServiceBrokerReachableCondition(t, broker, v1beta1.ConditionFalse, errorBrokerUnreachableReason)
+}
+
+func conditionStatusPtr(status v1beta1.ConditionStatus) *v1beta1.ConditionStatus {
+	return &status
+}
+
+func assertClusterServiceBrokerReachableCondition(t *testing.T, obj runtime.Object, status v1beta1.ConditionStatus, reason string) {
+	broker, ok := obj.(*v1beta1.ClusterServiceBroker)
+	if !ok {
+		fatalf(t, "Couldn't convert object %+v into a *v1beta1.ClusterServiceBroker", obj)
+	}
+	for _, condition := range broker.Status.Conditions {
+		if condition.Type != v1beta1.ServiceBrokerConditionReachable {
+			continue
+		}
+		if condition.Status != status || condition.Reason != reason {
+			fatalf(t, "Reachable condition had unexpected status and reason; expected %v %v, got %v %v", status, reason, condition.Status, condition.Reason)
+		}
+		return
+	}
+	fatalf(t, "Reachable condition not found in %+v", broker.Status.Conditions)
+}
+
+// getTestClusterServiceBrokerUnreachableSince returns the test broker, whose
+// Reachable condition records that its circuit has been open since the given
+// time.
+func getTestClusterServiceBrokerUnreachableSince(since time.Time) *v1beta1.ClusterServiceBroker {
+	broker := getTestClusterServiceBroker()
+	broker.Status.Conditions = []v1beta1.ServiceBrokerCondition{{
+		Type:               v1beta1.ServiceBrokerConditionReachable,
+		Status:             v1beta1.ConditionFalse,
+		LastTransitionTime: metav1.NewTime(since),
+		Reason:             errorBrokerUnreachableReason,
+		Message:            "unreachable",
+	}}
+	return broker
+}
+
+// TestPostponeForBrokerCancelProbe tests that the broker may be probed again
+// right away when the work let through to probe it did not send any request.
+func TestPostponeForBrokerCancelProbe(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.brokerHealth = brokerhealth.NewTracker(1, time.Minute, time.Hour, nil)
+	// The restored circuit may be probed right away.
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBrokerUnreachableSince(time.Now().Add(-time.Minute)))
+	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, testNamespace, testServiceInstanceName)
+	key := testNamespace + "/" + testServiceInstanceName
+
+	cancelProbe, postponed := testController.postponeForBroker(pcb, testController.instanceQueue, key, testClusterServiceBrokerName)
+	if postponed {
+		t.Fatal("expected the work to proceed to probe the broker")
+	}
+	if _, postponed := testController.postponeForBroker(pcb, testController.instanceQueue, key, testClusterServiceBrokerName); !postponed {
+		t.Fatal("expected other work to be postponed while the broker is probed")
+	}
+	cancelProbe()
+	if _, postponed := testController.postponeForBroker(pcb, testController.instanceQueue, key, testClusterServiceBrokerName); postponed {
+		t.Fatal("expected other work to probe the broker once the probe was canceled")
+	}
+}
+
+// TestServiceInstanceRetryDurationExceededBrokerUnreachableCondition tests
+// that the time during which the circuit of the broker of an instance has
+// been open, as recorded in the Reachable condition of the broker, does not
+// count towards the reconciliation retry duration of the instance after the
+// controller manager restarted.
+func TestServiceInstanceRetryDurationExceededBrokerUnreachableCondition(t *testing.T) {
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+	testController.reconciliationRetryDuration = time.Hour
+	testController.brokerHealth = brokerhealth.NewTracker(1, time.Minute, 2*time.Hour, nil)
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBrokerUnreachableSince(time.Now().Add(-30 * time.Minute)))
+
+	// The operation started over the retry duration ago, but the broker has
+	// been unreachable for half of it.
+	instance := getTestServiceInstanceWithRefs()
+	startTime := metav1.NewTime(time.Now().Add(-time.Hour - time.Minute))
+	instance.Status.OperationStartTime = &startTime
+
+	if testController.serviceInstanceRetryDurationExceeded(instance) {
+		t.Fatal("expected the time during which the circuit was open not to count towards the retry duration")
+	}
+	if status, ok := testController.brokerHealth.Status(testClusterServiceBrokerName); !ok || !status.Open {
+		t.Fatalf("expected the circuit of the broker to be restored open, got %+v", status)
+	}
+}
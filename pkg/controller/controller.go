@@ -17,7 +17,9 @@ limitations under the License.
 package controller
 
 import (
+	"crypto/tls"
 	"encoding/json"
+	stderrors "errors"
 	"fmt"
 	"net/http"
 	"sync"
@@ -29,21 +31,31 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	runtimeutil "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	corev1 "k8s.io/api/core/v1"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	coreinformers "k8s.io/client-go/informers/core/v1"
 	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/util/workqueue"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerauth"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerhealth"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerlimits"
 	servicecatalogclientset "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
+	settingsclientset "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/typed/settings/v1alpha1"
 	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions/servicecatalog/v1beta1"
+	settingsinformers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions/settings/v1alpha1"
 	listers "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
+	settingslisters "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/settings/v1alpha1"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	"github.com/kubernetes-incubator/service-catalog/pkg/filter"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
@@ -58,6 +70,9 @@ const (
 	maxRetries = 15
 	// pollingStartInterval is the initial interval to use when polling async OSB operations.
 	pollingStartInterval = 1 * time.Second
+	// secretCopyCollectionInterval is the interval at which the copies of the
+	// Secrets of bindings that are no longer wanted are removed.
+	secretCopyCollectionInterval = 5 * time.Minute
 
 	// ContextProfilePlatformKubernetes is the platform name sent in the OSB
 	// ContextProfile for requests coming from Kubernetes.
@@ -72,37 +87,71 @@ const (
 func NewController(
 	kubeClient kubernetes.Interface,
 	serviceCatalogClient servicecatalogclientset.ServicecatalogV1beta1Interface,
+	settingsClient settingsclientset.SettingsV1alpha1Interface,
 	brokerInformer informers.ClusterServiceBrokerInformer,
+	serviceBrokerInformer informers.ServiceBrokerInformer,
 	clusterServiceClassInformer informers.ClusterServiceClassInformer,
+	serviceClassInformer informers.ServiceClassInformer,
 	instanceInformer informers.ServiceInstanceInformer,
 	bindingInformer informers.ServiceBindingInformer,
 	clusterServicePlanInformer informers.ClusterServicePlanInformer,
-	brokerClientCreateFunc osb.CreateFunc,
+	servicePlanInformer informers.ServicePlanInformer,
+	serviceInstanceQuotaInformer informers.ServiceInstanceQuotaInformer,
+	secretInformer coreinformers.SecretInformer,
+	configMapInformer coreinformers.ConfigMapInformer,
+	podPresetInformer settingsinformers.PodPresetInformer,
+	brokerClientCreateFunc brokerclient.CreateFunc,
 	brokerRelistInterval time.Duration,
 	osbAPIPreferredVersion string,
 	recorder record.EventRecorder,
 	reconciliationRetryDuration time.Duration,
 	operationPollingMaximumBackoffDuration time.Duration,
+	bindingRotationGracePeriod time.Duration,
+	bindingCredentialsSyncInterval time.Duration,
+	instanceSyncInterval time.Duration,
+	brokerRateLimits brokerlimits.Limits,
+	brokerFailureThreshold int,
+	brokerCircuitOpenDuration time.Duration,
 	clusterIDConfigMapName string,
 	clusterIDConfigMapNamespace string,
 ) (Controller, error) {
 	controller := &controller{
-		kubeClient:                  kubeClient,
-		serviceCatalogClient:        serviceCatalogClient,
-		brokerClientCreateFunc:      brokerClientCreateFunc,
-		brokerRelistInterval:        brokerRelistInterval,
-		OSBAPIPreferredVersion:      osbAPIPreferredVersion,
-		recorder:                    recorder,
-		reconciliationRetryDuration: reconciliationRetryDuration,
-		brokerQueue:                 workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-broker"),
-		clusterServiceClassQueue:    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cluster-service-class"),
-		clusterServicePlanQueue:     workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cluster-service-plan"),
-		instanceQueue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-instance"),
-		bindingQueue:                workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-binding"),
-		instancePollingQueue:        workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "instance-poller"),
-		bindingPollingQueue:         workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "binding-poller"),
-		clusterIDConfigMapName:      clusterIDConfigMapName,
-		clusterIDConfigMapNamespace: clusterIDConfigMapNamespace,
+		kubeClient:                     kubeClient,
+		serviceCatalogClient:           serviceCatalogClient,
+		settingsClient:                 settingsClient,
+		brokerClientCreateFunc:         brokerClientCreateFunc,
+		brokerTokens:                   brokerauth.NewTokenCache(),
+		brokerClients:                  newBrokerClientCache(),
+		brokerRelistInterval:           brokerRelistInterval,
+		OSBAPIPreferredVersion:         osbAPIPreferredVersion,
+		recorder:                       recorder,
+		reconciliationRetryDuration:    reconciliationRetryDuration,
+		bindingRotationGracePeriod:     bindingRotationGracePeriod,
+		bindingCredentialsSyncInterval: bindingCredentialsSyncInterval,
+		instanceSyncInterval:           instanceSyncInterval,
+		brokerRateLimits:               brokerRateLimits,
+		brokerLimiter:                  brokerlimits.NewLimiter(),
+		brokerQueue:                    workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-broker"),
+		clusterServiceClassQueue:       workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cluster-service-class"),
+		clusterServicePlanQueue:        workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "cluster-service-plan"),
+		serviceBrokerQueue:             workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "namespaced-service-broker"),
+		serviceClassQueue:              workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "namespaced-service-class"),
+		servicePlanQueue:               workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "namespaced-service-plan"),
+		instanceQueue:                  workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-instance"),
+		bindingQueue:                   workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-binding"),
+		instancePollingQueue:           workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "instance-poller"),
+		bindingPollingQueue:            workqueue.NewNamedRateLimitingQueue(workqueue.NewItemExponentialFailureRateLimiter(pollingStartInterval, operationPollingMaximumBackoffDuration), "binding-poller"),
+		serviceInstanceQuotaQueue:      workqueue.NewNamedRateLimitingQueue(workqueue.DefaultControllerRateLimiter(), "service-instance-quota"),
+		clusterIDConfigMapName:         clusterIDConfigMapName,
+		clusterIDConfigMapNamespace:    clusterIDConfigMapNamespace,
+		instanceFetchTimes:             make(map[types.UID]time.Time),
+	}
+
+	// The circuit breaker is disabled by a zero failure threshold. The open
+	// periods are retained as long as they can extend the retry duration of
+	// an operation.
+	if brokerFailureThreshold > 0 {
+		controller.brokerHealth = brokerhealth.NewTracker(brokerFailureThreshold, brokerCircuitOpenDuration, reconciliationRetryDuration, controller.brokerHealthChanged)
 	}
 
 	controller.brokerLister = brokerInformer.Lister()
@@ -126,7 +175,38 @@ func NewController(
 		DeleteFunc: controller.clusterServicePlanDelete,
 	})
 
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
+		controller.serviceBrokerLister = serviceBrokerInformer.Lister()
+		serviceBrokerInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.serviceBrokerAdd,
+			UpdateFunc: controller.serviceBrokerUpdate,
+			DeleteFunc: controller.serviceBrokerDelete,
+		})
+
+		controller.serviceClassLister = serviceClassInformer.Lister()
+		serviceClassInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.serviceClassAdd,
+			UpdateFunc: controller.serviceClassUpdate,
+			DeleteFunc: controller.serviceClassDelete,
+		})
+
+		controller.servicePlanLister = servicePlanInformer.Lister()
+		servicePlanInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.servicePlanAdd,
+			UpdateFunc: controller.servicePlanUpdate,
+			DeleteFunc: controller.servicePlanDelete,
+		})
+	}
+
 	controller.instanceLister = instanceInformer.Lister()
+	controller.instanceIndexer = instanceInformer.Informer().GetIndexer()
+	err := instanceInformer.Informer().AddIndexers(cache.Indexers{
+		instancePlanMigrationIndex: serviceInstancePlanMigrationSources,
+		instanceExternalIDIndex:    serviceInstanceExternalIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
 	instanceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    controller.instanceAdd,
 		UpdateFunc: controller.instanceUpdate,
@@ -134,12 +214,53 @@ func NewController(
 	})
 
 	controller.bindingLister = bindingInformer.Lister()
+	controller.bindingIndexer = bindingInformer.Informer().GetIndexer()
+	err = bindingInformer.Informer().AddIndexers(cache.Indexers{
+		bindingSecretTransformSourceIndex: serviceBindingSecretTransformSources,
+	})
+	if err != nil {
+		return nil, err
+	}
 	bindingInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
 		AddFunc:    controller.bindingAdd,
 		UpdateFunc: controller.bindingUpdate,
 		DeleteFunc: controller.bindingDelete,
 	})
 
+	// Secrets are only watched when changes to them are acted upon.
+	// Otherwise, they are read from the API server when they are needed.
+	if secretsWatched() {
+		controller.secretLister = secretInformer.Lister()
+		secretInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.secretAdd,
+			UpdateFunc: controller.secretUpdate,
+			DeleteFunc: controller.secretDelete,
+		})
+	}
+
+	// The ConfigMaps of bindings are only read to check them for drift.
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingTargets) {
+		controller.configMapLister = configMapInformer.Lister()
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.PodPreset) {
+		controller.podPresetLister = podPresetInformer.Lister()
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServiceInstanceQuota) {
+		controller.serviceInstanceQuotaLister = serviceInstanceQuotaInformer.Lister()
+		serviceInstanceQuotaInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.serviceInstanceQuotaAdd,
+			UpdateFunc: controller.serviceInstanceQuotaUpdate,
+		})
+
+		instanceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    controller.serviceInstanceQuotaInstanceAdd,
+			UpdateFunc: controller.serviceInstanceQuotaInstanceUpdate,
+			DeleteFunc: controller.serviceInstanceQuotaInstanceDelete,
+		})
+	}
+
 	return controller, nil
 }
 
@@ -154,25 +275,47 @@ type Controller interface {
 
 // controller is a concrete Controller.
 type controller struct {
-	kubeClient                  kubernetes.Interface
-	serviceCatalogClient        servicecatalogclientset.ServicecatalogV1beta1Interface
-	brokerClientCreateFunc      osb.CreateFunc
-	brokerLister                listers.ClusterServiceBrokerLister
-	clusterServiceClassLister   listers.ClusterServiceClassLister
-	instanceLister              listers.ServiceInstanceLister
-	bindingLister               listers.ServiceBindingLister
-	clusterServicePlanLister    listers.ClusterServicePlanLister
-	brokerRelistInterval        time.Duration
-	OSBAPIPreferredVersion      string
-	recorder                    record.EventRecorder
-	reconciliationRetryDuration time.Duration
-	brokerQueue                 workqueue.RateLimitingInterface
-	clusterServiceClassQueue    workqueue.RateLimitingInterface
-	clusterServicePlanQueue     workqueue.RateLimitingInterface
-	instanceQueue               workqueue.RateLimitingInterface
-	bindingQueue                workqueue.RateLimitingInterface
-	instancePollingQueue        workqueue.RateLimitingInterface
-	bindingPollingQueue         workqueue.RateLimitingInterface
+	kubeClient                     kubernetes.Interface
+	serviceCatalogClient           servicecatalogclientset.ServicecatalogV1beta1Interface
+	settingsClient                 settingsclientset.SettingsV1alpha1Interface
+	brokerClientCreateFunc         brokerclient.CreateFunc
+	brokerTokens                   *brokerauth.TokenCache
+	brokerClients                  *brokerClientCache
+	brokerLister                   listers.ClusterServiceBrokerLister
+	clusterServiceClassLister      listers.ClusterServiceClassLister
+	instanceLister                 listers.ServiceInstanceLister
+	instanceIndexer                cache.Indexer
+	bindingLister                  listers.ServiceBindingLister
+	bindingIndexer                 cache.Indexer
+	clusterServicePlanLister       listers.ClusterServicePlanLister
+	serviceBrokerLister            listers.ServiceBrokerLister
+	serviceClassLister             listers.ServiceClassLister
+	servicePlanLister              listers.ServicePlanLister
+	serviceInstanceQuotaLister     listers.ServiceInstanceQuotaLister
+	secretLister                   corelisters.SecretLister
+	configMapLister                corelisters.ConfigMapLister
+	podPresetLister                settingslisters.PodPresetLister
+	brokerRelistInterval           time.Duration
+	OSBAPIPreferredVersion         string
+	recorder                       record.EventRecorder
+	reconciliationRetryDuration    time.Duration
+	bindingRotationGracePeriod     time.Duration
+	bindingCredentialsSyncInterval time.Duration
+	instanceSyncInterval           time.Duration
+	brokerRateLimits               brokerlimits.Limits
+	brokerLimiter                  *brokerlimits.Limiter
+	brokerHealth                   *brokerhealth.Tracker
+	brokerQueue                    workqueue.RateLimitingInterface
+	clusterServiceClassQueue       workqueue.RateLimitingInterface
+	clusterServicePlanQueue        workqueue.RateLimitingInterface
+	serviceBrokerQueue             workqueue.RateLimitingInterface
+	serviceClassQueue              workqueue.RateLimitingInterface
+	servicePlanQueue               workqueue.RateLimitingInterface
+	instanceQueue                  workqueue.RateLimitingInterface
+	bindingQueue                   workqueue.RateLimitingInterface
+	instancePollingQueue           workqueue.RateLimitingInterface
+	bindingPollingQueue            workqueue.RateLimitingInterface
+	serviceInstanceQuotaQueue      workqueue.RateLimitingInterface
 	// clusterIDConfigMapName is the k8s name that the clusterid
 	// configmap will have.
 	clusterIDConfigMapName string
@@ -188,6 +331,13 @@ type controller struct {
 	// monitor writing the value from the configmap, and any
 	// readers passing the clusterID to a broker.
 	clusterIDLock sync.RWMutex
+	// instanceFetchTimes holds the last time each instance, by UID, was
+	// fetched from its broker. The status of an instance is only updated
+	// when the broker reports a change, so it does not record every fetch.
+	instanceFetchTimes map[types.UID]time.Time
+	// instanceFetchTimesLock protects access to instanceFetchTimes between
+	// the workers.
+	instanceFetchTimesLock sync.Mutex
 }
 
 // Run runs the controller until the given stop channel can be read from.
@@ -202,6 +352,11 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) {
 		createWorker(c.brokerQueue, "ClusterServiceBroker", maxRetries, true, c.reconcileClusterServiceBrokerKey, stopCh, &waitGroup)
 		createWorker(c.clusterServiceClassQueue, "ClusterServiceClass", maxRetries, true, c.reconcileClusterServiceClassKey, stopCh, &waitGroup)
 		createWorker(c.clusterServicePlanQueue, "ClusterServicePlan", maxRetries, true, c.reconcileClusterServicePlanKey, stopCh, &waitGroup)
+		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
+			createWorker(c.serviceBrokerQueue, "ServiceBroker", maxRetries, true, c.reconcileServiceBrokerKey, stopCh, &waitGroup)
+			createWorker(c.serviceClassQueue, "ServiceClass", maxRetries, true, c.reconcileServiceClassKey, stopCh, &waitGroup)
+			createWorker(c.servicePlanQueue, "ServicePlan", maxRetries, true, c.reconcileServicePlanKey, stopCh, &waitGroup)
+		}
 		createWorker(c.instanceQueue, "ServiceInstance", maxRetries, true, c.reconcileServiceInstanceKey, stopCh, &waitGroup)
 		createWorker(c.bindingQueue, "ServiceBinding", maxRetries, true, c.reconcileServiceBindingKey, stopCh, &waitGroup)
 		createWorker(c.instancePollingQueue, "InstancePoller", maxRetries, false, c.requeueServiceInstanceForPoll, stopCh, &waitGroup)
@@ -209,6 +364,10 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) {
 		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.AsyncBindingOperations) {
 			createWorker(c.bindingPollingQueue, "BindingPoller", maxRetries, false, c.requeueServiceBindingForPoll, stopCh, &waitGroup)
 		}
+
+		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServiceInstanceQuota) {
+			createWorker(c.serviceInstanceQuotaQueue, "ServiceInstanceQuota", maxRetries, true, c.reconcileServiceInstanceQuotaKey, stopCh, &waitGroup)
+		}
 	}
 
 	// this creates a worker specifically for monitoring
@@ -217,16 +376,24 @@ func (c *controller) Run(workers int, stopCh <-chan struct{}) {
 	// simple polling based worker
 	c.createConfigMapMonitorWorker(stopCh, &waitGroup)
 
+	// copies of binding secrets in other namespaces cannot be owned by
+	// their bindings, so the ones leaked are collected periodically.
+	c.createSecretCopyCollectorWorker(stopCh, &waitGroup)
+
 	<-stopCh
 	glog.Info("Shutting down service-catalog controller")
 
 	c.brokerQueue.ShutDown()
 	c.clusterServiceClassQueue.ShutDown()
 	c.clusterServicePlanQueue.ShutDown()
+	c.serviceBrokerQueue.ShutDown()
+	c.serviceClassQueue.ShutDown()
+	c.servicePlanQueue.ShutDown()
 	c.instanceQueue.ShutDown()
 	c.bindingQueue.ShutDown()
 	c.instancePollingQueue.ShutDown()
 	c.bindingPollingQueue.ShutDown()
+	c.serviceInstanceQuotaQueue.ShutDown()
 
 	waitGroup.Wait()
 	glog.Info("Shutdown service-catalog controller")
@@ -251,6 +418,14 @@ func (c *controller) createConfigMapMonitorWorker(stopCh <-chan struct{}, waitGr
 	}()
 }
 
+func (c *controller) createSecretCopyCollectorWorker(stopCh <-chan struct{}, waitGroup *sync.WaitGroup) {
+	waitGroup.Add(1)
+	go func() {
+		wait.Until(c.collectServiceBindingSecretCopies, secretCopyCollectionInterval, stopCh)
+		waitGroup.Done()
+	}()
+}
+
 func (c *controller) monitorConfigMap() {
 	// Cannot wait for the informer to push something into a queue.
 	// What we're waiting on may never exist without us configuring
@@ -349,7 +524,7 @@ func (e *operationError) Error() string { return e.message }
 // The ClusterServicePlan returned will be nil if the ClusterServicePlanRef
 // is nil. This will happen when deleting a ServiceInstance that previously
 // had an update to a non-existent plan.
-func (c *controller) getClusterServiceClassPlanAndClusterServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, string, osb.Client, error) {
+func (c *controller) getClusterServiceClassPlanAndClusterServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, string, brokerclient.Client, error) {
 	serviceClass, brokerName, brokerClient, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
 	if err != nil {
 		return nil, nil, "", nil, err
@@ -375,8 +550,7 @@ func (c *controller) getClusterServiceClassPlanAndClusterServiceBroker(instance
 // getClusterServiceClassAndClusterServiceBroker is a sequence of operations that's done in couple of
 // places so this method fetches the Service Class and creates
 // a brokerClient to use for that method given an ServiceInstance.
-func (c *controller) getClusterServiceClassAndClusterServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ClusterServiceClass, string, osb.Client, error) {
-	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, instance.Namespace, instance.Name)
+func (c *controller) getClusterServiceClassAndClusterServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ClusterServiceClass, string, brokerclient.Client, error) {
 	serviceClass, err := c.clusterServiceClassLister.Get(instance.Spec.ClusterServiceClassRef.Name)
 	if err != nil {
 		return nil, "", nil, &operationError{
@@ -400,8 +574,8 @@ func (c *controller) getClusterServiceClassAndClusterServiceBroker(instance *v1b
 
 	}
 
-	authConfig, err := getAuthCredentialsFromClusterServiceBroker(c.kubeClient, broker)
-	if err != nil {
+	brokerClient, err := c.getClusterServiceBrokerClient(broker)
+	if isAuthCredentialsError(err) {
 		return nil, "", nil, &operationError{
 			reason: errorAuthCredentialsReason,
 			message: fmt.Sprintf(
@@ -410,10 +584,6 @@ func (c *controller) getClusterServiceClassAndClusterServiceBroker(instance *v1b
 			),
 		}
 	}
-
-	clientConfig := NewClientConfigurationForBroker(broker, authConfig)
-	glog.V(4).Info(pcb.Messagef("Creating client for ClusterServiceBroker %v, URL: %v", broker.Name, broker.Spec.URL))
-	brokerClient, err := c.brokerClientCreateFunc(clientConfig)
 	if err != nil {
 		return nil, "", nil, err
 	}
@@ -425,7 +595,7 @@ func (c *controller) getClusterServiceClassAndClusterServiceBroker(instance *v1b
 // done to validate service plan, service class exist, and handles creating
 // a brokerclient to use for a given ServiceInstance.
 // Sets ClusterServiceClassRef and/or ClusterServicePlanRef if they haven't been already set.
-func (c *controller) getClusterServiceClassPlanAndClusterServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, string, osb.Client, error) {
+func (c *controller) getClusterServiceClassPlanAndClusterServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ClusterServiceClass, *v1beta1.ClusterServicePlan, string, brokerclient.Client, error) {
 	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, instance.Namespace, instance.Name)
 	serviceClass, err := c.clusterServiceClassLister.Get(instance.Spec.ClusterServiceClassRef.Name)
 	if err != nil {
@@ -478,8 +648,8 @@ func (c *controller) getClusterServiceClassPlanAndClusterServiceBrokerForService
 		return nil, nil, "", nil, err
 	}
 
-	authConfig, err := getAuthCredentialsFromClusterServiceBroker(c.kubeClient, broker)
-	if err != nil {
+	brokerClient, err := c.getClusterServiceBrokerClient(broker)
+	if isAuthCredentialsError(err) {
 		s := fmt.Sprintf("Error getting broker auth credentials for broker %q: %s", broker.Name, err)
 		glog.Warning(pcb.Message(s))
 		c.updateServiceBindingCondition(
@@ -492,11 +662,150 @@ func (c *controller) getClusterServiceClassPlanAndClusterServiceBrokerForService
 		c.recorder.Event(binding, corev1.EventTypeWarning, errorAuthCredentialsReason, s)
 		return nil, nil, "", nil, err
 	}
+	if err != nil {
+		return nil, nil, "", nil, err
+	}
 
-	clientConfig := NewClientConfigurationForBroker(broker, authConfig)
+	return serviceClass, servicePlan, broker.Name, brokerClient, nil
+}
 
-	glog.V(4).Infof("Creating client for ClusterServiceBroker %v, URL: %v", broker.Name, broker.Spec.URL)
-	brokerClient, err := c.brokerClientCreateFunc(clientConfig)
+// getServiceClassPlanAndServiceBroker is the namespaced counterpart of
+// getClusterServiceClassPlanAndClusterServiceBroker.
+func (c *controller) getServiceClassPlanAndServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ServiceClass, *v1beta1.ServicePlan, string, brokerclient.Client, error) {
+	serviceClass, brokerName, brokerClient, err := c.getServiceClassAndServiceBroker(instance)
+	if err != nil {
+		return nil, nil, "", nil, err
+	}
+
+	var servicePlan *v1beta1.ServicePlan
+	if instance.Spec.ServicePlanRef != nil {
+		var err error
+		servicePlan, err = c.servicePlanLister.ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanRef.Name)
+		if nil != err {
+			return nil, nil, "", nil, &operationError{
+				reason: errorNonexistentServicePlanReason,
+				message: fmt.Sprintf(
+					"The instance references a non-existent ServicePlan %q - %v",
+					instance.Spec.ServicePlanRef.Name, instance.Spec.PlanReference,
+				),
+			}
+		}
+	}
+	return serviceClass, servicePlan, brokerName, brokerClient, nil
+}
+
+// getServiceClassAndServiceBroker is the namespaced counterpart of
+// getClusterServiceClassAndClusterServiceBroker. The ServiceClass and the
+// ServiceBroker are always looked up in the instance's namespace.
+func (c *controller) getServiceClassAndServiceBroker(instance *v1beta1.ServiceInstance) (*v1beta1.ServiceClass, string, brokerclient.Client, error) {
+	serviceClass, err := c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name)
+	if err != nil {
+		return nil, "", nil, &operationError{
+			reason: errorNonexistentServiceClassReason,
+			message: fmt.Sprintf(
+				"The instance references a non-existent ServiceClass (K8S: %q ExternalName: %q)",
+				instance.Spec.ServiceClassRef.Name, instance.Spec.ServiceClassExternalName,
+			),
+		}
+	}
+
+	broker, err := c.serviceBrokerLister.ServiceBrokers(instance.Namespace).Get(serviceClass.Spec.ServiceBrokerName)
+	if err != nil {
+		return nil, "", nil, &operationError{
+			reason: errorNonexistentServiceBrokerReason,
+			message: fmt.Sprintf(
+				"The instance references a non-existent broker %q",
+				serviceClass.Spec.ServiceBrokerName,
+			),
+		}
+	}
+
+	brokerClient, err := c.getServiceBrokerClient(broker)
+	if isAuthCredentialsError(err) {
+		return nil, "", nil, &operationError{
+			reason: errorAuthCredentialsReason,
+			message: fmt.Sprintf(
+				"Error getting broker auth credentials for broker %q: %s",
+				broker.Name, err,
+			),
+		}
+	}
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return serviceClass, broker.Name, brokerClient, nil
+}
+
+// getServiceClassPlanAndServiceBrokerForServiceBinding is the namespaced
+// counterpart of getClusterServiceClassPlanAndClusterServiceBrokerForServiceBinding.
+func (c *controller) getServiceClassPlanAndServiceBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.ServiceClass, *v1beta1.ServicePlan, string, brokerclient.Client, error) {
+	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, instance.Namespace, instance.Name)
+	serviceClass, err := c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name)
+	if err != nil {
+		s := fmt.Sprintf(
+			"References a non-existent ServiceClass %q - %c",
+			instance.Spec.ServiceClassRef.Name, instance.Spec.PlanReference,
+		)
+		glog.Warning(pcb.Message(s))
+		c.updateServiceBindingCondition(
+			binding,
+			v1beta1.ServiceBindingConditionReady,
+			v1beta1.ConditionFalse,
+			errorNonexistentServiceClassReason,
+			"The binding references a ServiceClass that does not exist. "+s,
+		)
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorNonexistentServiceClassReason, s)
+		return nil, nil, "", nil, err
+	}
+
+	servicePlan, err := c.servicePlanLister.ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanRef.Name)
+	if nil != err {
+		s := fmt.Sprintf(
+			"References a non-existent ServicePlan %q - %v",
+			instance.Spec.ServicePlanRef.Name, instance.Spec.PlanReference,
+		)
+		glog.Warning(pcb.Message(s))
+		c.updateServiceBindingCondition(
+			binding,
+			v1beta1.ServiceBindingConditionReady,
+			v1beta1.ConditionFalse,
+			errorNonexistentServicePlanReason,
+			"The ServiceBinding references an ServiceInstance which references ServicePlan that does not exist. "+s,
+		)
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorNonexistentServicePlanReason, s)
+		return nil, nil, "", nil, stderrors.New(s)
+	}
+
+	broker, err := c.serviceBrokerLister.ServiceBrokers(instance.Namespace).Get(serviceClass.Spec.ServiceBrokerName)
+	if err != nil {
+		s := fmt.Sprintf("References a non-existent ServiceBroker %q", serviceClass.Spec.ServiceBrokerName)
+		glog.Warning(pcb.Message(s))
+		c.updateServiceBindingCondition(
+			binding,
+			v1beta1.ServiceBindingConditionReady,
+			v1beta1.ConditionFalse,
+			errorNonexistentServiceBrokerReason,
+			"The binding references a ServiceBroker that does not exist. "+s,
+		)
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorNonexistentServiceBrokerReason, s)
+		return nil, nil, "", nil, err
+	}
+
+	brokerClient, err := c.getServiceBrokerClient(broker)
+	if isAuthCredentialsError(err) {
+		s := fmt.Sprintf("Error getting broker auth credentials for broker %q: %s", broker.Name, err)
+		glog.Warning(pcb.Message(s))
+		c.updateServiceBindingCondition(
+			binding,
+			v1beta1.ServiceBindingConditionReady,
+			v1beta1.ConditionFalse,
+			errorAuthCredentialsReason,
+			"Error getting auth credentials. "+s,
+		)
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorAuthCredentialsReason, s)
+		return nil, nil, "", nil, err
+	}
 	if err != nil {
 		return nil, nil, "", nil, err
 	}
@@ -505,12 +814,25 @@ func (c *controller) getClusterServiceClassPlanAndClusterServiceBrokerForService
 }
 
 // Broker utility methods - move?
+
+// brokerCredentials are the credentials that the controller authenticates to
+// a broker with. At most one of the fields is set.
+type brokerCredentials struct {
+	// authConfig holds the basic auth or bearer token credentials.
+	authConfig *osb.AuthConfig
+	// clientCert is the client certificate presented during the TLS
+	// handshake.
+	clientCert *tls.Certificate
+	// oauth2 holds the credentials that the bearer tokens are obtained with.
+	oauth2 *brokerauth.ClientCredentials
+}
+
 // getAuthCredentialsFromClusterServiceBroker returns the auth credentials, if any, or
 // returns an error. If the AuthInfo field is nil, empty values are
 // returned.
-func getAuthCredentialsFromClusterServiceBroker(client kubernetes.Interface, broker *v1beta1.ClusterServiceBroker) (*osb.AuthConfig, error) {
+func getAuthCredentialsFromClusterServiceBroker(client kubernetes.Interface, broker *v1beta1.ClusterServiceBroker) (*brokerCredentials, error) {
 	if broker.Spec.AuthInfo == nil {
-		return nil, nil
+		return &brokerCredentials{}, nil
 	}
 
 	authInfo := broker.Spec.AuthInfo
@@ -524,8 +846,10 @@ func getAuthCredentialsFromClusterServiceBroker(client kubernetes.Interface, bro
 		if err != nil {
 			return nil, err
 		}
-		return &osb.AuthConfig{
-			BasicAuthConfig: basicAuthConfig,
+		return &brokerCredentials{
+			authConfig: &osb.AuthConfig{
+				BasicAuthConfig: basicAuthConfig,
+			},
 		}, nil
 	} else if authInfo.Bearer != nil {
 		secretRef := authInfo.Bearer.SecretRef
@@ -537,13 +861,267 @@ func getAuthCredentialsFromClusterServiceBroker(client kubernetes.Interface, bro
 		if err != nil {
 			return nil, err
 		}
-		return &osb.AuthConfig{
-			BearerConfig: bearerConfig,
+		return &brokerCredentials{
+			authConfig: &osb.AuthConfig{
+				BearerConfig: bearerConfig,
+			},
+		}, nil
+	} else if authInfo.TLS != nil {
+		secretRef := authInfo.TLS.SecretRef
+		secret, err := client.CoreV1().Secrets(secretRef.Namespace).Get(secretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		clientCert, err := getClientCertificate(secret)
+		if err != nil {
+			return nil, err
+		}
+		return &brokerCredentials{
+			clientCert: clientCert,
+		}, nil
+	} else if authInfo.OAuth2 != nil {
+		secretRef := authInfo.OAuth2.SecretRef
+		secret, err := client.CoreV1().Secrets(secretRef.Namespace).Get(secretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		oauth2Credentials, err := getOAuth2Credentials(secret, authInfo.OAuth2.TokenURL, authInfo.OAuth2.Scopes)
+		if err != nil {
+			return nil, err
+		}
+		return &brokerCredentials{
+			oauth2: oauth2Credentials,
 		}, nil
 	}
 	return nil, fmt.Errorf("empty auth info or unsupported auth mode: %s", authInfo)
 }
 
+// getAuthCredentialsFromServiceBroker returns the auth credentials, if any, or
+// returns an error. If the AuthInfo field is nil, empty values are returned.
+// The referenced Secrets are always resolved from the broker's own namespace.
+func getAuthCredentialsFromServiceBroker(client kubernetes.Interface, broker *v1beta1.ServiceBroker) (*brokerCredentials, error) {
+	if broker.Spec.AuthInfo == nil {
+		return &brokerCredentials{}, nil
+	}
+
+	authInfo := broker.Spec.AuthInfo
+	if authInfo.Basic != nil {
+		secretRef := authInfo.Basic.SecretRef
+		secret, err := client.CoreV1().Secrets(broker.Namespace).Get(secretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		basicAuthConfig, err := getBasicAuthConfig(secret)
+		if err != nil {
+			return nil, err
+		}
+		return &brokerCredentials{
+			authConfig: &osb.AuthConfig{
+				BasicAuthConfig: basicAuthConfig,
+			},
+		}, nil
+	} else if authInfo.Bearer != nil {
+		secretRef := authInfo.Bearer.SecretRef
+		secret, err := client.CoreV1().Secrets(broker.Namespace).Get(secretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		bearerConfig, err := getBearerConfig(secret)
+		if err != nil {
+			return nil, err
+		}
+		return &brokerCredentials{
+			authConfig: &osb.AuthConfig{
+				BearerConfig: bearerConfig,
+			},
+		}, nil
+	} else if authInfo.TLS != nil {
+		secretRef := authInfo.TLS.SecretRef
+		secret, err := client.CoreV1().Secrets(broker.Namespace).Get(secretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		clientCert, err := getClientCertificate(secret)
+		if err != nil {
+			return nil, err
+		}
+		return &brokerCredentials{
+			clientCert: clientCert,
+		}, nil
+	} else if authInfo.OAuth2 != nil {
+		secretRef := authInfo.OAuth2.SecretRef
+		secret, err := client.CoreV1().Secrets(broker.Namespace).Get(secretRef.Name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		oauth2Credentials, err := getOAuth2Credentials(secret, authInfo.OAuth2.TokenURL, authInfo.OAuth2.Scopes)
+		if err != nil {
+			return nil, err
+		}
+		return &brokerCredentials{
+			oauth2: oauth2Credentials,
+		}, nil
+	}
+	return nil, fmt.Errorf("empty auth info or unsupported auth mode: %v", authInfo)
+}
+
+// newBrokerClient creates a client with the given configuration that
+// authenticates to the broker with the given credentials. Clients that
+// authenticate with OAuth2 share the access tokens cached by the controller.
+func (c *controller) newBrokerClient(clientConfig *osb.ClientConfiguration, credentials *brokerCredentials) (brokerclient.Client, error) {
+	clientConfig.EnableAlphaFeatures = c.brokerAlphaFeaturesEnabled()
+	if credentials.oauth2 != nil {
+		return brokerauth.NewOAuth2Client(c.brokerTokens, credentials.oauth2, clientConfig, c.brokerClientCreateFunc)
+	}
+	return c.brokerClientCreateFunc(clientConfig)
+}
+
+// brokerAlphaFeaturesEnabled returns whether the enabled features of the
+// controller send alpha OSB API requests, such as fetching bindings and
+// instances, or read alpha fields of the responses of the broker.
+func (c *controller) brokerAlphaFeaturesEnabled() bool {
+	return utilfeature.DefaultFeatureGate.Enabled(scfeatures.AsyncBindingOperations) ||
+		utilfeature.DefaultFeatureGate.Enabled(scfeatures.ResponseSchema) ||
+		utilfeature.DefaultFeatureGate.Enabled(scfeatures.UpdateDashboardURL) ||
+		utilfeature.DefaultFeatureGate.Enabled(scfeatures.InstanceAdoption) ||
+		c.bindingCredentialsSyncInterval > 0 ||
+		c.instanceSyncInterval > 0
+}
+
+// authCredentialsError is returned when a client cannot be created for a
+// broker because its auth credentials cannot be retrieved.
+type authCredentialsError struct {
+	err error
+}
+
+func (e *authCredentialsError) Error() string {
+	return e.err.Error()
+}
+
+// isAuthCredentialsError returns whether the given error was returned because
+// the auth credentials of a broker cannot be retrieved.
+func isAuthCredentialsError(err error) bool {
+	_, ok := err.(*authCredentialsError)
+	return ok
+}
+
+// clusterServiceBrokerClientVersion returns the version of the given broker
+// and of its auth secret that its client is cached for. It is computed before
+// the auth credentials are read, so that a secret changing while the client
+// is created only causes the client to be created again. When Secrets are not
+// watched, the secret is read from the API server, so that rotated
+// credentials are picked up whatever the feature gates.
+func (c *controller) clusterServiceBrokerClientVersion(broker *v1beta1.ClusterServiceBroker) (brokerClientVersion, error) {
+	version := brokerClientVersion{uid: broker.UID, generation: broker.Generation}
+	if secretRef := clusterServiceBrokerAuthSecretRef(broker.Spec.AuthInfo); secretRef != nil {
+		secret, err := c.getSecret(secretRef.Namespace, secretRef.Name)
+		if err != nil {
+			return version, err
+		}
+		version.secretResourceVersion = secret.ResourceVersion
+	}
+	return version, nil
+}
+
+// serviceBrokerClientVersion is the namespaced counterpart of
+// clusterServiceBrokerClientVersion.
+func (c *controller) serviceBrokerClientVersion(broker *v1beta1.ServiceBroker) (brokerClientVersion, error) {
+	version := brokerClientVersion{uid: broker.UID, generation: broker.Generation}
+	if secretRef := serviceBrokerAuthSecretRef(broker.Spec.AuthInfo); secretRef != nil {
+		secret, err := c.getSecret(broker.Namespace, secretRef.Name)
+		if err != nil {
+			return version, err
+		}
+		version.secretResourceVersion = secret.ResourceVersion
+	}
+	return version, nil
+}
+
+// getClusterServiceBrokerClient returns the client for the given broker. The
+// client cached for the current version of the broker and of its auth secret
+// is reused; otherwise a new client is created with the broker's auth
+// credentials and rate limits, and cached.
+func (c *controller) getClusterServiceBrokerClient(broker *v1beta1.ClusterServiceBroker) (brokerclient.Client, error) {
+	key := brokerKey(broker.ObjectMeta)
+	version, err := c.clusterServiceBrokerClientVersion(broker)
+	if err != nil {
+		return nil, &authCredentialsError{err}
+	}
+	if brokerClient, ok := c.brokerClients.Get(key, version); ok {
+		return brokerClient, nil
+	}
+
+	credentials, err := getAuthCredentialsFromClusterServiceBroker(c.kubeClient, broker)
+	if err != nil {
+		return nil, &authCredentialsError{err}
+	}
+
+	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, credentials.authConfig, credentials.clientCert)
+	glog.V(4).Infof("Creating client for ClusterServiceBroker %v, URL: %v", broker.Name, broker.Spec.URL)
+	brokerClient, err := c.newBrokerClient(clientConfig, credentials)
+	if err != nil {
+		return nil, err
+	}
+	brokerClient = brokerlimits.NewClient(brokerClient, key, mergeBrokerRateLimits(c.brokerRateLimits, broker.Spec.RateLimits), c.brokerLimiter)
+	if c.brokerHealth != nil {
+		brokerClient = brokerhealth.NewClient(brokerClient, key, c.brokerHealth)
+	}
+	c.brokerClients.Add(key, version, brokerClient)
+	return brokerClient, nil
+}
+
+// getServiceBrokerClient is the namespaced counterpart of
+// getClusterServiceBrokerClient.
+func (c *controller) getServiceBrokerClient(broker *v1beta1.ServiceBroker) (brokerclient.Client, error) {
+	key := brokerKey(broker.ObjectMeta)
+	version, err := c.serviceBrokerClientVersion(broker)
+	if err != nil {
+		return nil, &authCredentialsError{err}
+	}
+	if brokerClient, ok := c.brokerClients.Get(key, version); ok {
+		return brokerClient, nil
+	}
+
+	credentials, err := getAuthCredentialsFromServiceBroker(c.kubeClient, broker)
+	if err != nil {
+		return nil, &authCredentialsError{err}
+	}
+
+	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, credentials.authConfig, credentials.clientCert)
+	glog.V(4).Infof("Creating client for ServiceBroker %v/%v, URL: %v", broker.Namespace, broker.Name, broker.Spec.URL)
+	brokerClient, err := c.newBrokerClient(clientConfig, credentials)
+	if err != nil {
+		return nil, err
+	}
+	brokerClient = brokerlimits.NewClient(brokerClient, key, mergeBrokerRateLimits(c.brokerRateLimits, broker.Spec.RateLimits), c.brokerLimiter)
+	if c.brokerHealth != nil {
+		brokerClient = brokerhealth.NewClient(brokerClient, key, c.brokerHealth)
+	}
+	c.brokerClients.Add(key, version, brokerClient)
+	return brokerClient, nil
+}
+
+// mergeBrokerRateLimits returns the given default limits overridden by the
+// limits set on a broker.
+func mergeBrokerRateLimits(defaults brokerlimits.Limits, limits *v1beta1.ServiceBrokerRateLimits) brokerlimits.Limits {
+	if limits == nil {
+		return defaults
+	}
+	if limits.MaxInFlightRequests != nil {
+		defaults.MaxInFlightRequests = int(*limits.MaxInFlightRequests)
+	}
+	if limits.RequestsPerSecond != nil {
+		defaults.RequestsPerSecond = int(*limits.RequestsPerSecond)
+	}
+	if limits.MaxInFlightPollRequests != nil {
+		defaults.MaxInFlightPollRequests = int(*limits.MaxInFlightPollRequests)
+	}
+	if limits.PollRequestsPerSecond != nil {
+		defaults.PollRequestsPerSecond = int(*limits.PollRequestsPerSecond)
+	}
+	return defaults
+}
+
 func getBasicAuthConfig(secret *corev1.Secret) (*osb.BasicAuthConfig, error) {
 	usernameBytes, ok := secret.Data["username"]
 	if !ok {
@@ -572,63 +1150,127 @@ func getBearerConfig(secret *corev1.Secret) (*osb.BearerConfig, error) {
 	}, nil
 }
 
+func getClientCertificate(secret *corev1.Secret) (*tls.Certificate, error) {
+	certBytes, ok := secret.Data[corev1.TLSCertKey]
+	if !ok {
+		return nil, fmt.Errorf("auth secret didn't contain %s", corev1.TLSCertKey)
+	}
+
+	keyBytes, ok := secret.Data[corev1.TLSPrivateKeyKey]
+	if !ok {
+		return nil, fmt.Errorf("auth secret didn't contain %s", corev1.TLSPrivateKeyKey)
+	}
+
+	cert, err := tls.X509KeyPair(certBytes, keyBytes)
+	if err != nil {
+		return nil, fmt.Errorf("auth secret didn't contain a valid certificate and key: %v", err)
+	}
+	return &cert, nil
+}
+
+func getOAuth2Credentials(secret *corev1.Secret, tokenURL string, scopes []string) (*brokerauth.ClientCredentials, error) {
+	clientIDBytes, ok := secret.Data[v1beta1.OAuth2ClientIDKey]
+	if !ok {
+		return nil, fmt.Errorf("auth secret didn't contain %s", v1beta1.OAuth2ClientIDKey)
+	}
+
+	clientSecretBytes, ok := secret.Data[v1beta1.OAuth2ClientSecretKey]
+	if !ok {
+		return nil, fmt.Errorf("auth secret didn't contain %s", v1beta1.OAuth2ClientSecretKey)
+	}
+
+	return &brokerauth.ClientCredentials{
+		TokenURL:     tokenURL,
+		ClientID:     string(clientIDBytes),
+		ClientSecret: string(clientSecretBytes),
+		Scopes:       scopes,
+	}, nil
+}
+
 // convertAndFilterCatalog converts a service broker catalog into an array of
 // ClusterServiceClasses and an array of ClusterServicePlans and filters these
 // through the restrictions provided. The ClusterServiceClasses and
 // ClusterServicePlans returned by this method are named in K8S with the OSB ID.
-func convertAndFilterCatalog(in *osb.CatalogResponse, restrictions *v1beta1.CatalogRestrictions) ([]*v1beta1.ClusterServiceClass, []*v1beta1.ClusterServicePlan, error) {
-	var predicate filter.Predicate
-	var err error
-	if restrictions != nil && len(restrictions.ServiceClass) > 0 {
-		predicate, err = filter.CreatePredicate(restrictions.ServiceClass)
-		if err != nil {
-			return nil, nil, err
-		}
-	} else {
-		predicate = filter.NewPredicate()
+func convertAndFilterCatalog(in *brokerclient.CatalogResponse, restrictions *v1beta1.CatalogRestrictions) ([]*v1beta1.ClusterServiceClass, []*v1beta1.ClusterServicePlan, error) {
+	predicate, err := createClassPredicate(restrictions)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	serviceClasses := []*v1beta1.ClusterServiceClass(nil)
 	servicePlans := []*v1beta1.ClusterServicePlan(nil)
 	for _, svc := range in.Services {
+		extensions := in.ServiceExtensions[svc.ID]
+		commonSpec, err := convertCommonServiceClassSpec(svc, extensions)
+		if err != nil {
+			return nil, nil, err
+		}
 		serviceClass := &v1beta1.ClusterServiceClass{
 			Spec: v1beta1.ClusterServiceClassSpec{
-				CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
-					Bindable:      svc.Bindable,
-					PlanUpdatable: svc.PlanUpdatable != nil && *svc.PlanUpdatable,
-					ExternalID:    svc.ID,
-					ExternalName:  svc.Name,
-					Tags:          svc.Tags,
-					Description:   svc.Description,
-					Requires:      svc.Requires,
-				},
+				CommonServiceClassSpec: commonSpec,
 			},
 		}
+		serviceClass.SetName(svc.ID)
 
-		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.AsyncBindingOperations) {
-			serviceClass.Spec.BindingRetrievable = svc.BindingsRetrievable
-		}
+		// If this service class passes the predicate, process the plans for the class.
+		if fields := v1beta1.ConvertClusterServiceClassToProperties(serviceClass); predicate.Accepts(fields) {
+			// set up the plans using the ClusterServiceClass Name
+			plans, err := convertClusterServicePlans(svc.Plans, extensions.PlanExtensions, serviceClass.Name)
+			if err != nil {
+				return nil, nil, err
+			}
 
-		if svc.Metadata != nil {
-			metadata, err := json.Marshal(svc.Metadata)
+			acceptedPlans, _, err := filterServicePlans(restrictions, plans)
 			if err != nil {
-				err = fmt.Errorf("Failed to marshal metadata\n%+v\n %v", svc.Metadata, err)
-				glog.Error(err)
 				return nil, nil, err
 			}
-			serviceClass.Spec.ExternalMetadata = &runtime.RawExtension{Raw: metadata}
+
+			// If there are accepted plans, then append the class and all of the accepted plans to the master list.
+			if len(acceptedPlans) > 0 {
+				serviceClasses = append(serviceClasses, serviceClass)
+				servicePlans = append(servicePlans, acceptedPlans...)
+			}
+		}
+	}
+	return serviceClasses, servicePlans, nil
+}
+
+// convertAndFilterCatalogToNamespacedTypes converts a service broker catalog
+// into an array of ServiceClasses and an array of ServicePlans in the given
+// namespace and filters these through the restrictions provided. The
+// ServiceClasses and ServicePlans returned by this method are named in K8S
+// with the OSB ID.
+func convertAndFilterCatalogToNamespacedTypes(namespace string, in *brokerclient.CatalogResponse, restrictions *v1beta1.CatalogRestrictions) ([]*v1beta1.ServiceClass, []*v1beta1.ServicePlan, error) {
+	predicate, err := createClassPredicate(restrictions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	serviceClasses := []*v1beta1.ServiceClass(nil)
+	servicePlans := []*v1beta1.ServicePlan(nil)
+	for _, svc := range in.Services {
+		extensions := in.ServiceExtensions[svc.ID]
+		commonSpec, err := convertCommonServiceClassSpec(svc, extensions)
+		if err != nil {
+			return nil, nil, err
+		}
+		serviceClass := &v1beta1.ServiceClass{
+			Spec: v1beta1.ServiceClassSpec{
+				CommonServiceClassSpec: commonSpec,
+			},
 		}
 		serviceClass.SetName(svc.ID)
+		serviceClass.SetNamespace(namespace)
 
 		// If this service class passes the predicate, process the plans for the class.
-		if fields := v1beta1.ConvertClusterServiceClassToProperties(serviceClass); predicate.Accepts(fields) {
-			// set up the plans using the ClusterServiceClass Name
-			plans, err := convertClusterServicePlans(svc.Plans, serviceClass.Name)
+		if fields := v1beta1.ConvertServiceClassToProperties(serviceClass); predicate.Accepts(fields) {
+			// set up the plans using the ServiceClass Name
+			plans, err := convertServicePlans(namespace, svc.Plans, extensions.PlanExtensions, serviceClass.Name)
 			if err != nil {
 				return nil, nil, err
 			}
 
-			acceptedPlans, _, err := filterServicePlans(restrictions, plans)
+			acceptedPlans, _, err := filterNamespacedServicePlans(restrictions, plans)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -643,16 +1285,60 @@ func convertAndFilterCatalog(in *osb.CatalogResponse, restrictions *v1beta1.Cata
 	return serviceClasses, servicePlans, nil
 }
 
-func filterServicePlans(restrictions *v1beta1.CatalogRestrictions, servicePlans []*v1beta1.ClusterServicePlan) ([]*v1beta1.ClusterServicePlan, []*v1beta1.ClusterServicePlan, error) {
-	var predicate filter.Predicate
-	var err error
+// createClassPredicate returns the predicate used to filter the classes of a
+// broker's catalog.
+func createClassPredicate(restrictions *v1beta1.CatalogRestrictions) (filter.Predicate, error) {
+	if restrictions != nil && len(restrictions.ServiceClass) > 0 {
+		return filter.CreatePredicate(restrictions.ServiceClass)
+	}
+	return filter.NewPredicate(), nil
+}
+
+// createPlanPredicate returns the predicate used to filter the plans of a
+// broker's catalog.
+func createPlanPredicate(restrictions *v1beta1.CatalogRestrictions) (filter.Predicate, error) {
 	if restrictions != nil && len(restrictions.ServicePlan) > 0 {
-		predicate, err = filter.CreatePredicate(restrictions.ServicePlan)
+		return filter.CreatePredicate(restrictions.ServicePlan)
+	}
+	return filter.NewPredicate(), nil
+}
+
+// convertCommonServiceClassSpec converts a service from a broker's catalog,
+// along with the fields that osb.Service does not hold, into the spec shared
+// by ClusterServiceClasses and ServiceClasses.
+func convertCommonServiceClassSpec(svc osb.Service, extensions brokerclient.ServiceExtensions) (v1beta1.CommonServiceClassSpec, error) {
+	spec := v1beta1.CommonServiceClassSpec{
+		Bindable:             svc.Bindable,
+		InstancesRetrievable: extensions.InstancesRetrievable,
+		PlanUpdatable:        svc.PlanUpdatable != nil && *svc.PlanUpdatable,
+		ExternalID:           svc.ID,
+		ExternalName:         svc.Name,
+		Tags:                 svc.Tags,
+		Description:          svc.Description,
+		Requires:             svc.Requires,
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.AsyncBindingOperations) {
+		spec.BindingRetrievable = svc.BindingsRetrievable
+	}
+
+	if svc.Metadata != nil {
+		metadata, err := json.Marshal(svc.Metadata)
 		if err != nil {
-			return nil, nil, err
+			err = fmt.Errorf("Failed to marshal metadata\n%+v\n %v", svc.Metadata, err)
+			glog.Error(err)
+			return spec, err
 		}
-	} else {
-		predicate = filter.NewPredicate()
+		spec.ExternalMetadata = &runtime.RawExtension{Raw: metadata}
+	}
+
+	return spec, nil
+}
+
+func filterServicePlans(restrictions *v1beta1.CatalogRestrictions, servicePlans []*v1beta1.ClusterServicePlan) ([]*v1beta1.ClusterServicePlan, []*v1beta1.ClusterServicePlan, error) {
+	predicate, err := createPlanPredicate(restrictions)
+	if err != nil {
+		return nil, nil, err
 	}
 
 	// If the predicate is empty, all plans will pass. No need to run through the list.
@@ -674,86 +1360,155 @@ func filterServicePlans(restrictions *v1beta1.CatalogRestrictions, servicePlans
 	return accepted, rejected, nil
 }
 
-func convertClusterServicePlans(plans []osb.Plan, serviceClassID string) ([]*v1beta1.ClusterServicePlan, error) {
+func filterNamespacedServicePlans(restrictions *v1beta1.CatalogRestrictions, servicePlans []*v1beta1.ServicePlan) ([]*v1beta1.ServicePlan, []*v1beta1.ServicePlan, error) {
+	predicate, err := createPlanPredicate(restrictions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// If the predicate is empty, all plans will pass. No need to run through the list.
+	if predicate.Empty() {
+		return servicePlans, []*v1beta1.ServicePlan(nil), nil
+	}
+
+	accepted := []*v1beta1.ServicePlan(nil)
+	rejected := []*v1beta1.ServicePlan(nil)
+	for _, sp := range servicePlans {
+		fields := v1beta1.ConvertServicePlanToProperties(sp)
+		if predicate.Accepts(fields) {
+			accepted = append(accepted, sp)
+		} else {
+			rejected = append(rejected, sp)
+		}
+	}
+
+	return accepted, rejected, nil
+}
+
+func convertClusterServicePlans(plans []osb.Plan, planExtensions map[string]brokerclient.PlanExtensions, serviceClassID string) ([]*v1beta1.ClusterServicePlan, error) {
 	if 0 == len(plans) {
 		return nil, fmt.Errorf("ClusterServiceClass (K8S: %q) must have at least one plan", serviceClassID)
 	}
 	servicePlans := make([]*v1beta1.ClusterServicePlan, len(plans))
 	for i, plan := range plans {
+		commonSpec, err := convertCommonServicePlanSpec(plan, planExtensions[plan.ID])
+		if err != nil {
+			return nil, err
+		}
 		servicePlans[i] = &v1beta1.ClusterServicePlan{
 			Spec: v1beta1.ClusterServicePlanSpec{
-				CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
-					ExternalName: plan.Name,
-					ExternalID:   plan.ID,
-					Free:         plan.Free != nil && *plan.Free,
-					Description:  plan.Description,
-				},
+				CommonServicePlanSpec:  commonSpec,
 				ClusterServiceClassRef: v1beta1.ClusterObjectReference{Name: serviceClassID},
 			},
 		}
 		servicePlans[i].SetName(plan.ID)
+	}
+	return servicePlans, nil
+}
 
-		if plan.Bindable != nil {
-			b := *plan.Bindable
-			servicePlans[i].Spec.Bindable = &b
+func convertServicePlans(namespace string, plans []osb.Plan, planExtensions map[string]brokerclient.PlanExtensions, serviceClassID string) ([]*v1beta1.ServicePlan, error) {
+	if 0 == len(plans) {
+		return nil, fmt.Errorf("ServiceClass (K8S: %q) must have at least one plan", serviceClassID)
+	}
+	servicePlans := make([]*v1beta1.ServicePlan, len(plans))
+	for i, plan := range plans {
+		commonSpec, err := convertCommonServicePlanSpec(plan, planExtensions[plan.ID])
+		if err != nil {
+			return nil, err
+		}
+		servicePlans[i] = &v1beta1.ServicePlan{
+			Spec: v1beta1.ServicePlanSpec{
+				CommonServicePlanSpec: commonSpec,
+				ServiceClassRef:       v1beta1.LocalObjectReference{Name: serviceClassID},
+			},
 		}
+		servicePlans[i].SetName(plan.ID)
+		servicePlans[i].SetNamespace(namespace)
+	}
+	return servicePlans, nil
+}
 
-		if plan.Metadata != nil {
-			metadata, err := json.Marshal(plan.Metadata)
-			if err != nil {
-				err = fmt.Errorf("Failed to marshal metadata\n%+v\n %v", plan.Metadata, err)
-				glog.Error(err)
-				return nil, err
-			}
-			servicePlans[i].Spec.ExternalMetadata = &runtime.RawExtension{Raw: metadata}
+// convertCommonServicePlanSpec converts a plan from a broker's catalog, along
+// with the fields that osb.Plan does not hold, into the spec shared by
+// ClusterServicePlans and ServicePlans.
+func convertCommonServicePlanSpec(plan osb.Plan, extensions brokerclient.PlanExtensions) (v1beta1.CommonServicePlanSpec, error) {
+	spec := v1beta1.CommonServicePlanSpec{
+		ExternalName: plan.Name,
+		ExternalID:   plan.ID,
+		Free:         plan.Free != nil && *plan.Free,
+		Description:  plan.Description,
+	}
+
+	if plan.Bindable != nil {
+		b := *plan.Bindable
+		spec.Bindable = &b
+	}
+
+	if plan.Metadata != nil {
+		metadata, err := json.Marshal(plan.Metadata)
+		if err != nil {
+			err = fmt.Errorf("Failed to marshal metadata\n%+v\n %v", plan.Metadata, err)
+			glog.Error(err)
+			return spec, err
 		}
+		spec.ExternalMetadata = &runtime.RawExtension{Raw: metadata}
+	}
 
-		if schemas := plan.Schemas; schemas != nil {
-			if instanceSchemas := schemas.ServiceInstance; instanceSchemas != nil {
-				if instanceCreateSchema := instanceSchemas.Create; instanceCreateSchema != nil && instanceCreateSchema.Parameters != nil {
-					schema, err := json.Marshal(instanceCreateSchema.Parameters)
+	if schemas := plan.Schemas; schemas != nil {
+		if instanceSchemas := schemas.ServiceInstance; instanceSchemas != nil {
+			if instanceCreateSchema := instanceSchemas.Create; instanceCreateSchema != nil && instanceCreateSchema.Parameters != nil {
+				schema, err := json.Marshal(instanceCreateSchema.Parameters)
+				if err != nil {
+					err = fmt.Errorf("Failed to marshal instance create schema \n%+v\n %v", instanceCreateSchema.Parameters, err)
+					glog.Error(err)
+					return spec, err
+				}
+				spec.ServiceInstanceCreateParameterSchema = &runtime.RawExtension{Raw: schema}
+			}
+			if instanceUpdateSchema := instanceSchemas.Update; instanceUpdateSchema != nil && instanceUpdateSchema.Parameters != nil {
+				schema, err := json.Marshal(instanceUpdateSchema.Parameters)
+				if err != nil {
+					err = fmt.Errorf("Failed to marshal instance update schema \n%+v\n %v", instanceUpdateSchema.Parameters, err)
+					glog.Error(err)
+					return spec, err
+				}
+				spec.ServiceInstanceUpdateParameterSchema = &runtime.RawExtension{Raw: schema}
+			}
+		}
+		if bindingSchemas := schemas.ServiceBinding; bindingSchemas != nil {
+			if bindingCreateSchema := bindingSchemas.Create; bindingCreateSchema != nil {
+				if bindingCreateSchema.Parameters != nil {
+					schema, err := json.Marshal(bindingCreateSchema.Parameters)
 					if err != nil {
-						err = fmt.Errorf("Failed to marshal instance create schema \n%+v\n %v", instanceCreateSchema.Parameters, err)
+						err = fmt.Errorf("Failed to marshal binding create schema \n%+v\n %v", bindingCreateSchema.Parameters, err)
 						glog.Error(err)
-						return nil, err
+						return spec, err
 					}
-					servicePlans[i].Spec.ServiceInstanceCreateParameterSchema = &runtime.RawExtension{Raw: schema}
+					spec.ServiceBindingCreateParameterSchema = &runtime.RawExtension{Raw: schema}
 				}
-				if instanceUpdateSchema := instanceSchemas.Update; instanceUpdateSchema != nil && instanceUpdateSchema.Parameters != nil {
-					schema, err := json.Marshal(instanceUpdateSchema.Parameters)
+				if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ResponseSchema) && bindingCreateSchema.Response != nil {
+					schema, err := json.Marshal(bindingCreateSchema.Response)
 					if err != nil {
-						err = fmt.Errorf("Failed to marshal instance update schema \n%+v\n %v", instanceUpdateSchema.Parameters, err)
+						err = fmt.Errorf("Failed to marshal binding create response schema \n%+v\n %v", bindingCreateSchema.Response, err)
 						glog.Error(err)
-						return nil, err
-					}
-					servicePlans[i].Spec.ServiceInstanceUpdateParameterSchema = &runtime.RawExtension{Raw: schema}
-				}
-			}
-			if bindingSchemas := schemas.ServiceBinding; bindingSchemas != nil {
-				if bindingCreateSchema := bindingSchemas.Create; bindingCreateSchema != nil {
-					if bindingCreateSchema.Parameters != nil {
-						schema, err := json.Marshal(bindingCreateSchema.Parameters)
-						if err != nil {
-							err = fmt.Errorf("Failed to marshal binding create schema \n%+v\n %v", bindingCreateSchema.Parameters, err)
-							glog.Error(err)
-							return nil, err
-						}
-						servicePlans[i].Spec.ServiceBindingCreateParameterSchema = &runtime.RawExtension{Raw: schema}
-					}
-					if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ResponseSchema) && bindingCreateSchema.Response != nil {
-						schema, err := json.Marshal(bindingCreateSchema.Response)
-						if err != nil {
-							err = fmt.Errorf("Failed to marshal binding create response schema \n%+v\n %v", bindingCreateSchema.Response, err)
-							glog.Error(err)
-							return nil, err
-						}
-						servicePlans[i].Spec.ServiceBindingCreateResponseSchema = &runtime.RawExtension{Raw: schema}
+						return spec, err
 					}
+					spec.ServiceBindingCreateResponseSchema = &runtime.RawExtension{Raw: schema}
 				}
 			}
 		}
 	}
-	return servicePlans, nil
+
+	if maintenanceInfo := extensions.MaintenanceInfo; utilfeature.DefaultFeatureGate.Enabled(scfeatures.MaintenanceInfo) && maintenanceInfo != nil && maintenanceInfo.Version != nil {
+		spec.MaintenanceInfo = &v1beta1.MaintenanceInfo{
+			Version: *maintenanceInfo.Version,
+		}
+		if maintenanceInfo.Description != nil {
+			spec.MaintenanceInfo.Description = *maintenanceInfo.Description
+		}
+	}
+
+	return spec, nil
 }
 
 // isServiceInstanceConditionTrue returns whether the given instance has a given condition
@@ -787,15 +1542,25 @@ func isServiceInstanceOrphanMitigation(instance *v1beta1.ServiceInstance) bool {
 }
 
 // NewClientConfigurationForBroker creates a new ClientConfiguration for connecting
-// to the specified Broker
-func NewClientConfigurationForBroker(broker *v1beta1.ClusterServiceBroker, authConfig *osb.AuthConfig) *osb.ClientConfiguration {
+// to the specified Broker. The meta and commonSpec may come from either a
+// ClusterServiceBroker or a ServiceBroker; namespaced brokers are named
+// "<namespace>/<name>" so that they can be told apart from cluster brokers.
+// The clientCert, if any, is presented to the broker during the TLS handshake.
+func NewClientConfigurationForBroker(meta metav1.ObjectMeta, commonSpec *v1beta1.CommonServiceBrokerSpec, authConfig *osb.AuthConfig, clientCert *tls.Certificate) *osb.ClientConfiguration {
 	clientConfig := osb.DefaultClientConfiguration()
-	clientConfig.Name = broker.Name
-	clientConfig.URL = broker.Spec.URL
+	clientConfig.Name = meta.Name
+	if meta.Namespace != "" {
+		clientConfig.Name = meta.Namespace + "/" + meta.Name
+	}
+	clientConfig.URL = commonSpec.URL
 	clientConfig.AuthConfig = authConfig
-	clientConfig.EnableAlphaFeatures = true
-	clientConfig.Insecure = broker.Spec.InsecureSkipTLSVerify
-	clientConfig.CAData = broker.Spec.CABundle
+	clientConfig.Insecure = commonSpec.InsecureSkipTLSVerify
+	clientConfig.CAData = commonSpec.CABundle
+	if clientCert != nil {
+		clientConfig.TLSConfig = &tls.Config{
+			Certificates: []tls.Certificate{*clientCert},
+		}
+	}
 	return clientConfig
 }
 
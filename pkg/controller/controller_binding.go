@@ -17,8 +17,14 @@ limitations under the License.
 package controller
 
 import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
+	"reflect"
+	"strings"
+	"time"
 
 	"github.com/golang/glog"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
@@ -26,13 +32,19 @@ import (
 
 	"bytes"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
+	"github.com/kubernetes-incubator/service-catalog/pkg/secrettemplate"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/jsonpath"
 )
@@ -48,6 +60,9 @@ const (
 	errorServiceBindingOrphanMitigation       string = "ServiceBindingNeedsOrphanMitigation"
 	errorFetchingBindingFailedReason          string = "FetchingBindingFailed"
 	errorAsyncOpTimeoutReason                 string = "AsyncOperationTimeout"
+	errorUnbindPreviousCredentialsReason      string = "UnbindPreviousCredentialsFailed"
+	errorUnbindPreviousCredentialsTimeout     string = "UnbindPreviousCredentialsTimeout"
+	errorSyncingCredentialsReason             string = "SyncingCredentialsFailed"
 
 	successInjectedBindResultReason  string = "InjectedBindResult"
 	successInjectedBindResultMessage string = "Injected bind result"
@@ -60,11 +75,52 @@ const (
 	bindingInFlightMessage           string = "Binding request for ServiceBinding in-flight to Broker"
 	unbindingInFlightReason          string = "UnbindingRequestInFlight"
 	unbindingInFlightMessage         string = "Unbind request for ServiceBinding in-flight to Broker"
+	successRotatedCredentialsReason  string = "RotatedCredentials"
+	successUnboundPreviousReason     string = "UnboundPreviousCredentials"
+	asyncUnbindingPreviousReason     string = "UnbindingPreviousCredentials"
+	credentialsDriftedReason         string = "CredentialsDrifted"
+	credentialsInSyncReason          string = "CredentialsInSync"
+	credentialsInSyncMessage         string = "The Secret holds the credentials returned by the broker"
+
+	waitingForSecretTransformSourceReason string = "WaitingForSecretTransformSource"
+
+	// bindingCredentialsSecretPrefix is prepended to the UID of a binding
+	// to name the Secret in which the credentials returned by the broker are
+	// kept, so that they can be transformed and delivered again. The UID is
+	// only known once the binding exists, so a Secret created by a user
+	// cannot take the name beforehand.
+	bindingCredentialsSecretPrefix string = "service-binding-credentials-"
+	// bindingCredentialsSecretKey is the key of that Secret holding the
+	// credentials, encoded as JSON.
+	bindingCredentialsSecretKey string = "credentials"
+	// bindingCredentialsSecretType is the type of that Secret, which tells
+	// it apart from the Secrets the credentials are delivered in.
+	bindingCredentialsSecretType corev1.SecretType = "servicecatalog.k8s.io/broker-credentials"
+
+	// bindingSecretCopyLabel is set to the UID of a binding on the copies of
+	// its Secret in other namespaces. Owner references cannot cross
+	// namespaces, so the copies are found through this label instead.
+	bindingSecretCopyLabel string = "servicecatalog.k8s.io/service-binding-uid"
+	// bindingSecretCopyAnnotation records the namespace and name of the
+	// binding a copy of a Secret belongs to.
+	bindingSecretCopyAnnotation string = "servicecatalog.k8s.io/service-binding"
 )
 
 // bindingControllerKind contains the schema.GroupVersionKind for this controller type.
 var bindingControllerKind = v1beta1.SchemeGroupVersion.WithKind("ServiceBinding")
 
+// secretTransformSourceNotFoundError is returned when the Secret referenced
+// by an AddKeysFrom transform of a binding does not exist yet. The
+// credentials are injected once it does.
+type secretTransformSourceNotFoundError struct {
+	namespace string
+	name      string
+}
+
+func (e *secretTransformSourceNotFoundError) Error() string {
+	return fmt.Sprintf(`Waiting for Secret "%s/%s" referenced by an AddKeysFrom transform to exist`, e.namespace, e.name)
+}
+
 // ServiceBinding handlers and control-loop
 
 func (c *controller) bindingAdd(obj interface{}) {
@@ -98,6 +154,17 @@ func (c *controller) bindingUpdate(oldObj, newObj interface{}) {
 	if !binding.Status.AsyncOpInProgress {
 		c.bindingAdd(newObj)
 	}
+
+	// Instances referring to the Secret of this binding are processed again
+	// once it is Ready, and whenever it changes while it is.
+	oldBinding := oldObj.(*v1beta1.ServiceBinding)
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.CrossInstanceReferences) &&
+		isServiceBindingReady(binding) &&
+		!reflect.DeepEqual(oldBinding.Status, binding.Status) {
+		c.enqueueServiceInstancesReferencing(binding.Namespace, func(from *v1beta1.ParametersFromSource) bool {
+			return from.ServiceBindingSecretKeyRef != nil && from.ServiceBindingSecretKeyRef.Name == binding.Name
+		})
+	}
 }
 
 func (c *controller) bindingDelete(obj interface{}) {
@@ -126,6 +193,13 @@ func (c *controller) reconcileServiceBindingKey(key string) error {
 		return err
 	}
 
+	brokerKey := c.serviceBindingBrokerKey(binding)
+	cancelProbe, postponed := c.postponeForBroker(pcb, c.bindingQueue, key, brokerKey)
+	if postponed {
+		return nil
+	}
+	defer cancelProbe()
+
 	return c.reconcileServiceBinding(binding)
 }
 
@@ -138,6 +212,17 @@ func isServiceBindingFailed(binding *v1beta1.ServiceBinding) bool {
 	return false
 }
 
+// isServiceBindingReady returns whether the given binding has a ready
+// condition with status true.
+func isServiceBindingReady(binding *v1beta1.ServiceBinding) bool {
+	for _, condition := range binding.Status.Conditions {
+		if condition.Type == v1beta1.ServiceBindingConditionReady && condition.Status == v1beta1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
+
 // getReconciliationActionForServiceBinding gets the action the reconciler
 // should be taking on the given binding.
 func getReconciliationActionForServiceBinding(binding *v1beta1.ServiceBinding) ReconciliationAction {
@@ -182,10 +267,34 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	}
 
 	if binding.Status.ReconciledGeneration == binding.Generation {
+		if serviceBindingKeepsCredentials(binding) && serviceBindingHasAddKeysFromTransform(binding) {
+			updated, err := c.reconcileServiceBindingSecretTransforms(binding)
+			if err != nil || updated {
+				return err
+			}
+		}
+		if binding.Status.PreviousExternalID != "" {
+			return c.reconcilePreviousServiceBindingCredentials(binding)
+		}
+		if c.bindingCredentialsSyncInterval > 0 && isServiceBindingReady(binding) {
+			return c.reconcileServiceBindingCredentials(binding)
+		}
 		glog.V(4).Info(pcb.Message("Not processing event; reconciled generation showed there is no work to do"))
 		return nil
 	}
 
+	if serviceBindingCredentialsBound(binding) && serviceBindingKeepsCredentials(binding) {
+		credentials, err := c.loadServiceBindingCredentials(binding)
+		if err == nil {
+			return c.redeliverServiceBinding(binding, credentials)
+		}
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		// The credentials were injected before the broker credentials were
+		// kept aside, so they are requested from the broker again.
+	}
+
 	glog.V(4).Info(pcb.Message("Processing"))
 
 	binding = binding.DeepCopy()
@@ -197,21 +306,45 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 		return c.processServiceBindingOperationError(binding, readyCond)
 	}
 
-	if instance.Spec.ClusterServiceClassRef == nil || instance.Spec.ClusterServicePlanRef == nil {
+	if !serviceInstanceReferencesResolved(instance) {
 		// retry later
-		return fmt.Errorf("ClusterServiceClass or ClusterServicePlan references for Instance have not been resolved yet")
+		return fmt.Errorf("ServiceClass or ServicePlan references for Instance have not been resolved yet")
 	}
 
-	serviceClass, servicePlan, brokerName, brokerClient, err := c.getClusterServiceClassPlanAndClusterServiceBrokerForServiceBinding(instance, binding)
-	if err != nil {
-		return c.handleServiceBindingReconciliationError(binding, err)
-	}
+	var (
+		serviceClass   *v1beta1.CommonServiceClassSpec
+		servicePlan    *v1beta1.CommonServicePlanSpec
+		brokerClient   brokerclient.Client
+		instanceOfName string
+	)
+	if instance.Spec.ClusterServiceClassSpecified() {
+		clusterServiceClass, clusterServicePlan, brokerName, client, err := c.getClusterServiceClassPlanAndClusterServiceBrokerForServiceBinding(instance, binding)
+		if err != nil {
+			return c.handleServiceBindingReconciliationError(binding, err)
+		}
+
+		if !isPlanBindable(clusterServiceClass, clusterServicePlan) {
+			msg := fmt.Sprintf(`References a non-bindable %s and Plan (%q) combination`, pretty.ClusterServiceClassName(clusterServiceClass), instance.Spec.ClusterServicePlanExternalName)
+			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorNonbindableClusterServiceClassReason, msg)
+			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorNonbindableClusterServiceClassReason, msg)
+			return c.processBindFailure(binding, readyCond, failedCond, false)
+		}
+		serviceClass, servicePlan, brokerClient = &clusterServiceClass.Spec.CommonServiceClassSpec, &clusterServicePlan.Spec.CommonServicePlanSpec, client
+		instanceOfName = pretty.FromServiceInstanceOfClusterServiceClassAtBrokerName(instance, clusterServiceClass, brokerName)
+	} else {
+		namespacedServiceClass, namespacedServicePlan, brokerName, client, err := c.getServiceClassPlanAndServiceBrokerForServiceBinding(instance, binding)
+		if err != nil {
+			return c.handleServiceBindingReconciliationError(binding, err)
+		}
 
-	if !isPlanBindable(serviceClass, servicePlan) {
-		msg := fmt.Sprintf(`References a non-bindable %s and Plan (%q) combination`, pretty.ClusterServiceClassName(serviceClass), instance.Spec.ClusterServicePlanExternalName)
-		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorNonbindableClusterServiceClassReason, msg)
-		failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorNonbindableClusterServiceClassReason, msg)
-		return c.processBindFailure(binding, readyCond, failedCond, false)
+		if !isServicePlanBindable(namespacedServiceClass, namespacedServicePlan) {
+			msg := fmt.Sprintf(`References a non-bindable %s and Plan (%q) combination`, pretty.ServiceClassName(namespacedServiceClass), instance.Spec.ServicePlanExternalName)
+			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorNonbindableClusterServiceClassReason, msg)
+			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorNonbindableClusterServiceClassReason, msg)
+			return c.processBindFailure(binding, readyCond, failedCond, false)
+		}
+		serviceClass, servicePlan, brokerClient = &namespacedServiceClass.Spec.CommonServiceClassSpec, &namespacedServicePlan.Spec.CommonServicePlanSpec, client
+		instanceOfName = pretty.FromServiceInstanceOfServiceClassAtBrokerName(instance, namespacedServiceClass, brokerName)
 	}
 
 	if !isServiceInstanceReady(instance) {
@@ -224,6 +357,13 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 
 	request, inProgressProperties, err := c.prepareBindRequest(binding, instance, serviceClass, servicePlan)
 	if err != nil {
+		// A binding that cannot be created before a dependency that will
+		// never be Ready is not retried.
+		if opErr, ok := err.(*operationError); ok && opErr.reason == errorDependencyFailedReason {
+			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, opErr.reason, opErr.message)
+			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, opErr.reason, opErr.message)
+			return c.processBindFailure(binding, readyCond, failedCond, false)
+		}
 		return c.handleServiceBindingReconciliationError(binding, err)
 	}
 
@@ -255,11 +395,11 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 
 		msg := fmt.Sprintf(
 			`Error creating ServiceBinding for %s: %s`,
-			pretty.FromServiceInstanceOfClusterServiceClassAtBrokerName(instance, serviceClass, brokerName), err,
+			instanceOfName, err,
 		)
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorBindCallReason, msg)
 
-		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
+		if c.serviceBindingRetryDurationExceeded(binding) {
 			msg := "Stopping reconciliation retries, too much time has elapsed"
 			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorReconciliationRetryTimeoutReason, msg)
 			return c.processBindFailure(binding, readyCond, failedCond, false)
@@ -279,11 +419,14 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	binding.Status.ExternalProperties = binding.Status.InProgressProperties
 
 	err = c.injectServiceBinding(binding, response.Credentials)
+	if sourceErr, ok := err.(*secretTransformSourceNotFoundError); ok {
+		return c.processBindWaitingForSecretTransformSource(binding, sourceErr)
+	}
 	if err != nil {
 		msg := fmt.Sprintf(`Error injecting bind result: %s`, err)
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorInjectingBindResultReason, msg)
 
-		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
+		if c.serviceBindingRetryDurationExceeded(binding) {
 			msg := "Stopping reconciliation retries, too much time has elapsed"
 			failedCond := newServiceBindingFailedCondition(v1beta1.ConditionTrue, errorReconciliationRetryTimeoutReason, msg)
 			return c.processBindFailure(binding, readyCond, failedCond, true)
@@ -297,6 +440,37 @@ func (c *controller) reconcileServiceBindingAdd(binding *v1beta1.ServiceBinding)
 	return c.processBindSuccess(binding)
 }
 
+// serviceBindingCredentialsBound returns whether the broker has already
+// returned the credentials of the current ExternalID of the given binding, in
+// which case only how they are delivered can have changed since.
+func serviceBindingCredentialsBound(binding *v1beta1.ServiceBinding) bool {
+	return binding.Status.CurrentOperation == "" &&
+		!binding.Status.OrphanMitigationInProgress &&
+		binding.Status.ExternalProperties != nil &&
+		binding.Status.CurrentExternalID == binding.Spec.ExternalID
+}
+
+// redeliverServiceBinding injects the given credentials, kept aside when the
+// given binding was bound, according to the current spec of the binding. No
+// request is sent to the broker.
+func (c *controller) redeliverServiceBinding(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) error {
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	glog.V(4).Info(pcb.Message("Injecting the bound credentials again as the way they are delivered changed"))
+
+	binding = binding.DeepCopy()
+	err := c.injectServiceBinding(binding, credentials)
+	if sourceErr, ok := err.(*secretTransformSourceNotFoundError); ok {
+		return c.processBindWaitingForSecretTransformSource(binding, sourceErr)
+	}
+	if err != nil {
+		msg := fmt.Sprintf(`Error injecting bind result: %s`, err)
+		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorInjectingBindResultReason, msg)
+		return c.processServiceBindingOperationError(binding, readyCond)
+	}
+
+	return c.processBindSuccess(binding)
+}
+
 func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBinding) error {
 	var err error
 	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
@@ -327,10 +501,14 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 		return c.processServiceBindingGracefulDeletionSuccess(binding)
 	}
 
-	if err := c.ejectServiceBinding(binding); err != nil {
-		msg := fmt.Sprintf(`Error ejecting binding. Error deleting secret: %s`, err)
-		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorEjectingBindReason, msg)
-		return c.processServiceBindingOperationError(binding, readyCond)
+	// When mitigating the orphan left by a failed rotation, only the new
+	// credentials are unbound. The current credentials are still delivered.
+	if binding.DeletionTimestamp != nil || !serviceBindingRotationInProgress(binding) {
+		if err := c.ejectServiceBinding(binding); err != nil {
+			msg := fmt.Sprintf(`Error ejecting binding. Error deleting secret: %s`, err)
+			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionFalse, errorEjectingBindReason, msg)
+			return c.processServiceBindingOperationError(binding, readyCond)
+		}
 	}
 
 	if binding.DeletionTimestamp == nil {
@@ -370,18 +548,50 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 		return c.processServiceBindingOperationError(binding, readyCond)
 	}
 
-	if instance.Spec.ClusterServiceClassRef == nil || instance.Spec.ClusterServicePlanRef == nil {
+	if !serviceInstanceReferencesResolved(instance) {
 		// TODO(#1562): ultimately here we need to use logic similar to what is done to determine the plan ID for
 		// deprovisioning. We need to allow a ServiceBinding to be deleted, with an unbind request sent to the broker,
 		// even if the ServiceInstance has been changed to a non-existent plan.
-		return fmt.Errorf("ClusterServiceClass or ClusterServicePlan references for Instance have not been resolved yet")
+		return fmt.Errorf("ServiceClass or ServicePlan references for Instance have not been resolved yet")
 	}
 
-	serviceClass, servicePlan, brokerName, brokerClient, err := c.getClusterServiceClassPlanAndClusterServiceBrokerForServiceBinding(instance, binding)
+	serviceClass, servicePlan, instanceOfName, brokerClient, err := c.getCommonServiceClassPlanAndBrokerForServiceBinding(instance, binding)
 	if err != nil {
 		return c.handleServiceBindingReconciliationError(binding, err)
 	}
 
+	// Credentials replaced by a rotation that are still waiting for their
+	// grace period to elapse are unbound along with the current ones.
+	// If the broker unbinds them asynchronously, it is left to complete
+	// the operation on its own: there is no binding left to poll it for.
+	if binding.DeletionTimestamp != nil && binding.Status.PreviousExternalID != "" {
+		if binding.Status.PreviousCredentialsUnbindOperation == nil {
+			if _, err := c.unbindReplacedServiceBindingCredentials(binding, binding.Status.PreviousExternalID, instance, serviceClass, servicePlan, brokerClient); err != nil {
+				msg := fmt.Sprintf(
+					`Error unbinding previous credentials %q from %s: %s`,
+					binding.Status.PreviousExternalID, instanceOfName, err,
+				)
+				readyCond := newServiceBindingReadyCondition(v1beta1.ConditionUnknown, errorUnbindPreviousCredentialsReason, msg)
+				return c.processServiceBindingOperationError(binding, readyCond)
+			}
+		}
+		clearPreviousServiceBindingCredentials(binding)
+	}
+
+	// So are the credentials still delivered while a rotation is in
+	// progress, or after it failed.
+	if binding.DeletionTimestamp != nil && serviceBindingRotationInProgress(binding) {
+		if _, err := c.unbindReplacedServiceBindingCredentials(binding, binding.Status.CurrentExternalID, instance, serviceClass, servicePlan, brokerClient); err != nil {
+			msg := fmt.Sprintf(
+				`Error unbinding previous credentials %q from %s: %s`,
+				binding.Status.CurrentExternalID, instanceOfName, err,
+			)
+			readyCond := newServiceBindingReadyCondition(v1beta1.ConditionUnknown, errorUnbindPreviousCredentialsReason, msg)
+			return c.processServiceBindingOperationError(binding, readyCond)
+		}
+		binding.Status.CurrentExternalID = ""
+	}
+
 	request, err := c.prepareUnbindRequest(binding, instance, serviceClass, servicePlan)
 	if err != nil {
 		return c.handleServiceBindingReconciliationError(binding, err)
@@ -391,11 +601,11 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 	if err != nil {
 		msg := fmt.Sprintf(
 			`Error unbinding from %s: %s`,
-			pretty.FromServiceInstanceOfClusterServiceClassAtBrokerName(instance, serviceClass, brokerName), err,
+			instanceOfName, err,
 		)
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionUnknown, errorUnbindCallReason, msg)
 
-		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
+		if c.serviceBindingRetryDurationExceeded(binding) {
 			msg := "Stopping reconciliation retries, too much time has elapsed"
 			failedCond := newServiceBindingReadyCondition(v1beta1.ConditionTrue, errorReconciliationRetryTimeoutReason, msg)
 			return c.processUnbindFailure(binding, readyCond, failedCond)
@@ -411,6 +621,437 @@ func (c *controller) reconcileServiceBindingDelete(binding *v1beta1.ServiceBindi
 	return c.processUnbindSuccess(binding)
 }
 
+// reconcileServiceBindingSecretTransforms applies the transforms of the given
+// binding again to the credentials returned by the broker, so that its Secret
+// is injected once the Secrets referenced by its AddKeysFrom transforms exist,
+// and is kept up to date when they change. Returns true if the status of the
+// binding was updated.
+func (c *controller) reconcileServiceBindingSecretTransforms(binding *v1beta1.ServiceBinding) (bool, error) {
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+
+	credentials, err := c.loadServiceBindingCredentials(binding)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			// The credentials were injected before the broker credentials
+			// were kept aside, so there is nothing to apply the transforms to.
+			glog.V(4).Info(pcb.Message("Not applying secret transforms because the broker credentials were not kept"))
+			return false, nil
+		}
+		return false, err
+	}
+
+	binding = binding.DeepCopy()
+	status, reason, message := v1beta1.ConditionTrue, successInjectedBindResultReason, successInjectedBindResultMessage
+	err = c.injectServiceBinding(binding, credentials)
+	if sourceErr, ok := err.(*secretTransformSourceNotFoundError); ok {
+		status, reason, message = v1beta1.ConditionFalse, waitingForSecretTransformSourceReason, sourceErr.Error()
+	} else if err != nil {
+		msg := fmt.Sprintf(`Error injecting bind result: %s`, err)
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorInjectingBindResultReason, msg)
+		return false, errors.New(msg)
+	}
+
+	for _, cond := range binding.Status.Conditions {
+		if cond.Type == v1beta1.ServiceBindingConditionReady && cond.Status == status && cond.Reason == reason {
+			return false, nil
+		}
+	}
+
+	glog.V(4).Info(pcb.Message(message))
+	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, status, reason, message)
+	if _, err := c.updateServiceBindingStatus(binding); err != nil {
+		return false, err
+	}
+	c.recorder.Event(binding, corev1.EventTypeNormal, reason, message)
+	return true, nil
+}
+
+// reconcilePreviousServiceBindingCredentials unbinds the credentials replaced
+// by the last rotation of the given binding once their grace period has
+// elapsed. Until then, the binding is requeued for when it will have. If the
+// broker unbinds them asynchronously, the operation is polled for like the
+// ones on the binding itself. The unbind is retried until the reconciliation
+// retry duration has elapsed since the end of the grace period, after which
+// the credentials are given up on, so that the binding can be rotated again.
+func (c *controller) reconcilePreviousServiceBindingCredentials(binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	previousExternalID := binding.Status.PreviousExternalID
+
+	unbindTime := binding.Status.PreviousCredentialsUnbindTime
+	if unbindTime != nil {
+		if remaining := unbindTime.Time.Sub(time.Now()); remaining > 0 {
+			glog.V(4).Info(pcb.Messagef("Previous credentials %q will be unbound in %v", previousExternalID, remaining))
+			c.enqueueServiceBindingAfter(binding, remaining)
+			return nil
+		}
+	}
+
+	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.ServiceInstanceRef.Name)
+	if err != nil {
+		return err
+	}
+	if !serviceInstanceReferencesResolved(instance) {
+		return fmt.Errorf("ServiceClass or ServicePlan references for Instance have not been resolved yet")
+	}
+
+	serviceClass, servicePlan, instanceOfName, brokerClient, err := c.getCommonServiceClassPlanAndBrokerForServiceBinding(instance, binding)
+	if err != nil {
+		return err
+	}
+
+	if binding.Status.PreviousCredentialsUnbindOperation != nil {
+		return c.pollPreviousServiceBindingCredentials(binding, instance, serviceClass, servicePlan, instanceOfName, brokerClient)
+	}
+
+	glog.V(4).Info(pcb.Messagef("Unbinding previous credentials %q", previousExternalID))
+
+	response, err := c.unbindReplacedServiceBindingCredentials(binding, previousExternalID, instance, serviceClass, servicePlan, brokerClient)
+	if err != nil {
+		msg := fmt.Sprintf(`Error unbinding previous credentials %q from %s: %s`, previousExternalID, instanceOfName, err)
+		return c.processPreviousServiceBindingCredentialsError(binding, msg)
+	}
+
+	if response.Async {
+		binding = binding.DeepCopy()
+		operation := ""
+		if response.OperationKey != nil {
+			operation = string(*response.OperationKey)
+		}
+		binding.Status.PreviousCredentialsUnbindOperation = &operation
+		if _, err := c.updateServiceBindingStatus(binding); err != nil {
+			return err
+		}
+
+		msg := fmt.Sprintf("Unbinding previous credentials %q asynchronously", previousExternalID)
+		glog.V(4).Info(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeNormal, asyncUnbindingPreviousReason, msg)
+		return c.beginPollingServiceBinding(binding)
+	}
+
+	return c.processPreviousServiceBindingCredentialsUnbound(binding)
+}
+
+// pollPreviousServiceBindingCredentials polls the broker for the asynchronous
+// unbind of the credentials replaced by the last rotation of the given
+// binding. If the broker failed to unbind them, the unbind is sent again.
+func (c *controller) pollPreviousServiceBindingCredentials(binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, serviceClass *v1beta1.CommonServiceClassSpec, servicePlan *v1beta1.CommonServicePlanSpec, instanceOfName string, brokerClient brokerclient.Client) error {
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	previousExternalID := binding.Status.PreviousExternalID
+
+	request := &osb.BindingLastOperationRequest{
+		InstanceID: instance.Spec.ExternalID,
+		BindingID:  previousExternalID,
+		ServiceID:  &serviceClass.ExternalID,
+		PlanID:     &servicePlan.ExternalID,
+	}
+	if operation := *binding.Status.PreviousCredentialsUnbindOperation; operation != "" {
+		key := osb.OperationKey(operation)
+		request.OperationKey = &key
+	}
+
+	glog.V(5).Info(pcb.Messagef("Polling last operation of previous credentials %q", previousExternalID))
+
+	response, err := brokerClient.PollBindingLastOperation(request)
+	if err != nil {
+		// The credentials are gone, which is what was asked for.
+		if osb.IsGoneError(err) {
+			if err := c.processPreviousServiceBindingCredentialsUnbound(binding); err != nil {
+				return err
+			}
+			return c.finishPollingServiceBinding(binding)
+		}
+
+		msg := fmt.Sprintf("Error polling last operation of previous credentials %q: %v", previousExternalID, err)
+		glog.V(4).Info(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorPollingLastOperationReason, msg)
+		if c.reconciliationRetryDurationExceeded(binding.Status.PreviousCredentialsUnbindTime) {
+			if err := c.processPreviousServiceBindingCredentialsTimeout(binding); err != nil {
+				return err
+			}
+			return c.finishPollingServiceBinding(binding)
+		}
+		return c.continuePollingServiceBinding(binding)
+	}
+
+	glog.V(4).Info(pcb.Messagef("Poll of previous credentials %q returned %q", previousExternalID, response.State))
+
+	switch response.State {
+	case osb.StateSucceeded:
+		if err := c.processPreviousServiceBindingCredentialsUnbound(binding); err != nil {
+			return err
+		}
+		return c.finishPollingServiceBinding(binding)
+	case osb.StateFailed:
+		description := "(no description provided)"
+		if response.Description != nil {
+			description = *response.Description
+		}
+		if err := c.finishPollingServiceBinding(binding); err != nil {
+			return err
+		}
+
+		binding = binding.DeepCopy()
+		binding.Status.PreviousCredentialsUnbindOperation = nil
+		if _, err := c.updateServiceBindingStatus(binding); err != nil {
+			return err
+		}
+		msg := fmt.Sprintf(`Error unbinding previous credentials %q from %s: %s`, previousExternalID, instanceOfName, description)
+		return c.processPreviousServiceBindingCredentialsError(binding, msg)
+	default:
+		if c.reconciliationRetryDurationExceeded(binding.Status.PreviousCredentialsUnbindTime) {
+			if err := c.processPreviousServiceBindingCredentialsTimeout(binding); err != nil {
+				return err
+			}
+			return c.finishPollingServiceBinding(binding)
+		}
+		glog.V(4).Info(pcb.Messagef("Unbind of previous credentials %q not completed (still in progress)", previousExternalID))
+		return c.continuePollingServiceBinding(binding)
+	}
+}
+
+// processPreviousServiceBindingCredentialsError records that the credentials
+// replaced by the last rotation of the given binding could not be unbound,
+// and returns an error so that the unbind is retried, unless the
+// reconciliation retry duration has elapsed.
+func (c *controller) processPreviousServiceBindingCredentialsError(binding *v1beta1.ServiceBinding, msg string) error {
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	glog.Warning(pcb.Message(msg))
+	c.recorder.Event(binding, corev1.EventTypeWarning, errorUnbindPreviousCredentialsReason, msg)
+
+	if c.reconciliationRetryDurationExceeded(binding.Status.PreviousCredentialsUnbindTime) {
+		return c.processPreviousServiceBindingCredentialsTimeout(binding)
+	}
+	return errors.New(msg)
+}
+
+// processPreviousServiceBindingCredentialsUnbound clears the credentials
+// replaced by the last rotation of the given binding from its status once
+// the broker has unbound them.
+func (c *controller) processPreviousServiceBindingCredentialsUnbound(binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	previousExternalID := binding.Status.PreviousExternalID
+
+	binding = binding.DeepCopy()
+	clearPreviousServiceBindingCredentials(binding)
+	if _, err := c.updateServiceBindingStatus(binding); err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf("Unbound previous credentials %q", previousExternalID)
+	glog.V(4).Info(pcb.Message(msg))
+	c.recorder.Event(binding, corev1.EventTypeNormal, successUnboundPreviousReason, msg)
+	return nil
+}
+
+// processPreviousServiceBindingCredentialsTimeout gives up on unbinding the
+// credentials replaced by the last rotation of the given binding, which are
+// left bound at the broker, so that the binding can be rotated again.
+func (c *controller) processPreviousServiceBindingCredentialsTimeout(binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	previousExternalID := binding.Status.PreviousExternalID
+
+	binding = binding.DeepCopy()
+	clearPreviousServiceBindingCredentials(binding)
+	if _, err := c.updateServiceBindingStatus(binding); err != nil {
+		return err
+	}
+
+	msg := fmt.Sprintf(
+		"Stopping retries to unbind previous credentials %q, too much time has elapsed; they remain bound at the broker",
+		previousExternalID,
+	)
+	glog.Warning(pcb.Message(msg))
+	c.recorder.Event(binding, corev1.EventTypeWarning, errorUnbindPreviousCredentialsTimeout, msg)
+	return nil
+}
+
+// clearPreviousServiceBindingCredentials removes the credentials replaced by
+// the last rotation from the status of the given binding.
+func clearPreviousServiceBindingCredentials(binding *v1beta1.ServiceBinding) {
+	binding.Status.PreviousExternalID = ""
+	binding.Status.PreviousCredentialsUnbindTime = nil
+	binding.Status.PreviousCredentialsUnbindOperation = nil
+}
+
+// unbindReplacedServiceBindingCredentials sends an unbind request for the
+// credentials of the given binding bound under the given ExternalID, which a
+// rotation replaced or is replacing. The broker may unbind them
+// asynchronously when the binding operations of its class are.
+func (c *controller) unbindReplacedServiceBindingCredentials(binding *v1beta1.ServiceBinding, externalID string, instance *v1beta1.ServiceInstance, serviceClass *v1beta1.CommonServiceClassSpec, servicePlan *v1beta1.CommonServicePlanSpec, brokerClient brokerclient.Client) (*osb.UnbindResponse, error) {
+	request, err := c.prepareUnbindRequest(binding, instance, serviceClass, servicePlan)
+	if err != nil {
+		return nil, err
+	}
+	request.BindingID = externalID
+
+	return brokerClient.Unbind(request)
+}
+
+// reconcileServiceBindingCredentials fetches the credentials of the given
+// binding from the broker, if its class is bindingRetrievable, and repairs
+// its Secret when it no longer holds them. The credentials are fetched again
+// once the credentials sync interval has elapsed; until then, the binding is
+// requeued for when it will have.
+func (c *controller) reconcileServiceBindingCredentials(binding *v1beta1.ServiceBinding) error {
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+
+	if lastSyncTime := binding.Status.LastCredentialsSyncTime; lastSyncTime != nil {
+		if remaining := lastSyncTime.Add(c.bindingCredentialsSyncInterval).Sub(time.Now()); remaining > 0 {
+			glog.V(6).Info(pcb.Messagef("Credentials will be synced in %v", remaining))
+			c.enqueueServiceBindingAfter(binding, remaining)
+			return nil
+		}
+	}
+
+	instance, err := c.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.ServiceInstanceRef.Name)
+	if err != nil {
+		return err
+	}
+	if !serviceInstanceReferencesResolved(instance) {
+		return fmt.Errorf("ServiceClass or ServicePlan references for Instance have not been resolved yet")
+	}
+
+	serviceClass, _, instanceOfName, brokerClient, err := c.getCommonServiceClassPlanAndBrokerForServiceBinding(instance, binding)
+	if err != nil {
+		return err
+	}
+	if !serviceClass.BindingRetrievable {
+		glog.V(6).Info(pcb.Message("Not syncing credentials because the class does not allow bindings to be retrieved"))
+		return nil
+	}
+
+	glog.V(4).Info(pcb.Message("Syncing credentials"))
+
+	toUpdate := binding.DeepCopy()
+	now := metav1.Now()
+	toUpdate.Status.LastCredentialsSyncTime = &now
+
+	response, err := brokerClient.GetBinding(&osb.GetBindingRequest{
+		InstanceID: instance.Spec.ExternalID,
+		BindingID:  binding.Spec.ExternalID,
+	})
+	if err != nil {
+		// The binding is neither failed nor retried with backoff: the
+		// credentials it delivered are not known to be wrong, so the check
+		// simply waits for the next sync.
+		msg := fmt.Sprintf("Error fetching credentials from %s: %s", instanceOfName, err)
+		glog.Warning(pcb.Message(msg))
+		c.recorder.Event(binding, corev1.EventTypeWarning, errorSyncingCredentialsReason, msg)
+		if _, err := c.updateServiceBindingStatus(toUpdate); err != nil {
+			return err
+		}
+		c.enqueueServiceBindingAfter(binding, c.bindingCredentialsSyncInterval)
+		return nil
+	}
+
+	drifted, err := c.serviceBindingTargetsDrifted(binding, response.Credentials)
+	if err != nil {
+		return err
+	}
+
+	if len(drifted) > 0 {
+		msg := fmt.Sprintf(`%s did not hold the credentials returned by the broker`, strings.Join(drifted, ", "))
+		glog.Warning(pcb.Message(msg))
+		if err := c.injectServiceBinding(toUpdate, response.Credentials); err != nil {
+			msg := fmt.Sprintf(`Error injecting bind result: %s`, err)
+			c.recorder.Event(binding, corev1.EventTypeWarning, errorInjectingBindResultReason, msg)
+			return errors.New(msg)
+		}
+		msg += " and was repaired"
+		setServiceBindingCondition(toUpdate, v1beta1.ServiceBindingConditionCredentialsDrifted, v1beta1.ConditionTrue, credentialsDriftedReason, msg)
+		c.recorder.Event(binding, corev1.EventTypeWarning, credentialsDriftedReason, msg)
+	} else {
+		// A CredentialsDrifted condition set by an earlier repair is kept
+		// and marked resolved, rather than removed, so that the repair
+		// remains visible on the binding.
+		for _, cond := range toUpdate.Status.Conditions {
+			if cond.Type == v1beta1.ServiceBindingConditionCredentialsDrifted {
+				setServiceBindingCondition(toUpdate, v1beta1.ServiceBindingConditionCredentialsDrifted, v1beta1.ConditionFalse, credentialsInSyncReason, credentialsInSyncMessage)
+				break
+			}
+		}
+	}
+
+	if _, err := c.updateServiceBindingStatus(toUpdate); err != nil {
+		return err
+	}
+	c.enqueueServiceBindingAfter(binding, c.bindingCredentialsSyncInterval)
+	return nil
+}
+
+// serviceBindingTargetsDrifted returns descriptions of the Secret of the
+// given binding, its copies and its ConfigMap that are missing or hold
+// different data than the given credentials, as returned by the broker, are
+// injected as.
+func (c *controller) serviceBindingTargetsDrifted(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) ([]string, error) {
+	// The transforms modify the credentials in place, and the credentials
+	// are injected as returned by the broker if the Secret has drifted.
+	expected := make(map[string]interface{}, len(credentials))
+	for k, v := range credentials {
+		expected[k] = v
+	}
+	secretData, err := c.serviceBindingSecretData(binding, expected)
+	if err != nil {
+		return nil, err
+	}
+
+	var drifted []string
+	secretNamespaces := []string{binding.Namespace}
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingTargets) {
+		secretNamespaces = append(secretNamespaces, binding.Spec.SecretCopyNamespaces...)
+	}
+	for _, namespace := range secretNamespaces {
+		secret, err := c.getSecret(namespace, binding.Spec.SecretName)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err != nil || !secretDataEqual(secret.Data, secretData) {
+			drifted = append(drifted, fmt.Sprintf(`Secret "%s/%s"`, namespace, binding.Spec.SecretName))
+		}
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingTargets) && binding.Spec.ConfigMapTemplate != nil {
+		configMap, err := c.configMapLister.ConfigMaps(binding.Namespace).Get(binding.Name)
+		if err != nil && !apierrors.IsNotFound(err) {
+			return nil, err
+		}
+		if err != nil || !configMapDataEqual(configMap.Data, serviceBindingConfigMapData(binding, secretData)) {
+			drifted = append(drifted, fmt.Sprintf(`ConfigMap "%s/%s"`, binding.Namespace, binding.Name))
+		}
+	}
+	return drifted, nil
+}
+
+// secretDataEqual returns whether the given Secret data are equal, treating nil and
+// empty data alike.
+func secretDataEqual(a, b map[string][]byte) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// configMapDataEqual returns whether the given ConfigMap data are equal,
+// treating nil and empty data alike.
+func configMapDataEqual(a, b map[string]string) bool {
+	if len(a) == 0 && len(b) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// enqueueServiceBindingAfter adds the given binding to the binding queue once
+// the given duration has passed.
+func (c *controller) enqueueServiceBindingAfter(binding *v1beta1.ServiceBinding, d time.Duration) {
+	key, err := cache.MetaNamespaceKeyFunc(binding)
+	if err != nil {
+		pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+		glog.Error(pcb.Messagef("Couldn't create a key for object: %v", err))
+		return
+	}
+	c.bindingQueue.AddAfter(key, d)
+}
+
 // isPlanBindable returns whether the given ClusterServiceClass and ClusterServicePlan
 // combination is bindable.  Plans may override the service-level bindable
 // attribute, so if the plan provides a value, return that value.  Otherwise,
@@ -426,15 +1067,98 @@ func isPlanBindable(serviceClass *v1beta1.ClusterServiceClass, plan *v1beta1.Clu
 	return serviceClass.Spec.Bindable
 }
 
+// isServicePlanBindable is the namespaced counterpart of isPlanBindable.
+func isServicePlanBindable(serviceClass *v1beta1.ServiceClass, plan *v1beta1.ServicePlan) bool {
+	if plan.Spec.Bindable != nil {
+		return *plan.Spec.Bindable
+	}
+
+	return serviceClass.Spec.Bindable
+}
+
+// serviceInstanceReferencesResolved returns whether the class and plan
+// references of the given instance have been resolved by the controller.
+func serviceInstanceReferencesResolved(instance *v1beta1.ServiceInstance) bool {
+	if instance.Spec.ServiceClassSpecified() {
+		return instance.Spec.ServiceClassRef != nil && instance.Spec.ServicePlanRef != nil
+	}
+	return instance.Spec.ClusterServiceClassRef != nil && instance.Spec.ClusterServicePlanRef != nil
+}
+
+// getCommonServiceClassPlanAndBrokerForServiceBinding returns the specs of the
+// class and plan of the instance the binding refers to, a description of the
+// instance for logging, and a client for the broker offering the class. It
+// handles instances of both ClusterServiceClasses and ServiceClasses.
+func (c *controller) getCommonServiceClassPlanAndBrokerForServiceBinding(instance *v1beta1.ServiceInstance, binding *v1beta1.ServiceBinding) (*v1beta1.CommonServiceClassSpec, *v1beta1.CommonServicePlanSpec, string, brokerclient.Client, error) {
+	if instance.Spec.ClusterServiceClassSpecified() {
+		serviceClass, servicePlan, brokerName, brokerClient, err := c.getClusterServiceClassPlanAndClusterServiceBrokerForServiceBinding(instance, binding)
+		if err != nil {
+			return nil, nil, "", nil, err
+		}
+		return &serviceClass.Spec.CommonServiceClassSpec, &servicePlan.Spec.CommonServicePlanSpec,
+			pretty.FromServiceInstanceOfClusterServiceClassAtBrokerName(instance, serviceClass, brokerName), brokerClient, nil
+	}
+
+	serviceClass, servicePlan, brokerName, brokerClient, err := c.getServiceClassPlanAndServiceBrokerForServiceBinding(instance, binding)
+	if err != nil {
+		return nil, nil, "", nil, err
+	}
+	return &serviceClass.Spec.CommonServiceClassSpec, &servicePlan.Spec.CommonServicePlanSpec,
+		pretty.FromServiceInstanceOfServiceClassAtBrokerName(instance, serviceClass, brokerName), brokerClient, nil
+}
+
 func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) error {
 	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
 	glog.V(5).Info(pcb.Messagef(`Creating/updating Secret "%s/%s" with %d keys`,
 		binding.Namespace, binding.Spec.SecretName, len(credentials),
 	))
 
+	// The transforms of bindings whose Secret depends on other Secrets or
+	// on templates are applied to a copy of the credentials kept aside, so
+	// that they can be applied again when the Secrets referenced by their
+	// AddKeysFrom transforms are created or change, and so that the
+	// credentials can be delivered again without binding when only the way
+	// they are delivered changes.
+	if serviceBindingKeepsCredentials(binding) {
+		if err := c.storeServiceBindingCredentials(binding, credentials); err != nil {
+			return err
+		}
+	}
+
+	secretData, err := c.serviceBindingSecretData(binding, credentials)
+	if err != nil {
+		return err
+	}
+
+	if err := c.createOrUpdateServiceBindingSecret(binding, binding.Spec.SecretName, corev1.SecretTypeOpaque, secretData); err != nil {
+		return err
+	}
+
+	if err := c.deleteRenamedServiceBindingSecret(binding); err != nil {
+		return err
+	}
+
+	if err := c.syncServiceBindingSecretCopies(binding, secretData); err != nil {
+		return err
+	}
+
+	if err := c.syncServiceBindingConfigMap(binding, secretData); err != nil {
+		return err
+	}
+
+	return c.syncServiceBindingPodPreset(binding)
+}
+
+// serviceBindingSecretData applies the transforms of the given binding to the
+// given credentials, modifying them, and returns the data of the Secret they
+// are injected in.
+func (c *controller) serviceBindingSecretData(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) (map[string][]byte, error) {
 	err := c.transformCredentials(binding.Spec.SecretTransforms, credentials)
 	if err != nil {
-		return fmt.Errorf(`Unexpected error while transforming credentials for ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
+		if _, ok := err.(*secretTransformSourceNotFoundError); ok {
+			return nil, err
+		}
+		return nil, fmt.Errorf(`Unexpected error while transforming credentials for ServiceBinding "%s/%s": %v`, binding.Namespace, binding.Name, err)
 	}
 
 	secretData := make(map[string][]byte)
@@ -442,19 +1166,27 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 		var err error
 		secretData[k], err = serialize(v)
 		if err != nil {
-			return fmt.Errorf("Unable to serialize value for credential key %q (value is intentionally not logged): %s", k, err)
+			return nil, fmt.Errorf("Unable to serialize value for credential key %q (value is intentionally not logged): %s", k, err)
 		}
 	}
+	return secretData, nil
+}
 
-	// Creating/updating the Secret
+// createOrUpdateServiceBindingSecret creates the Secret with the given name,
+// type and data, owned by the given binding, or updates it if it already
+// exists.
+func (c *controller) createOrUpdateServiceBindingSecret(binding *v1beta1.ServiceBinding, secretName string, secretType corev1.SecretType, secretData map[string][]byte) error {
 	secretClient := c.kubeClient.CoreV1().Secrets(binding.Namespace)
-	existingSecret, err := secretClient.Get(binding.Spec.SecretName, metav1.GetOptions{})
+	existingSecret, err := secretClient.Get(secretName, metav1.GetOptions{})
 	if err == nil {
 		// Update existing secret
 		if !metav1.IsControlledBy(existingSecret, binding) {
 			controllerRef := metav1.GetControllerOf(existingSecret)
 			return fmt.Errorf(`Secret "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, existingSecret.Name, controllerRef)
 		}
+		if reflect.DeepEqual(existingSecret.Data, secretData) {
+			return nil
+		}
 		existingSecret.Data = secretData
 		_, err = secretClient.Update(existingSecret)
 		if err != nil {
@@ -471,30 +1203,125 @@ func (c *controller) injectServiceBinding(binding *v1beta1.ServiceBinding, crede
 		}
 		err = nil
 
-		// Create new secret
-		secret := &corev1.Secret{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      binding.Spec.SecretName,
-				Namespace: binding.Namespace,
-				OwnerReferences: []metav1.OwnerReference{
-					*metav1.NewControllerRef(binding, bindingControllerKind),
-				},
-			},
-			Data: secretData,
+		// Create new secret
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      secretName,
+				Namespace: binding.Namespace,
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(binding, bindingControllerKind),
+				},
+			},
+			Type: secretType,
+			Data: secretData,
+		}
+		_, err = secretClient.Create(secret)
+		if err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				// Concurrent controller has created secret under the same name,
+				// Update the secret at the next retry iteration
+				return fmt.Errorf(`Conflicting Secret "%s/%s" creation detected`, binding.Namespace, secret.Name)
+			}
+			// Terminal error
+			return fmt.Errorf(`Unexpected error creating Secret "%s/%s": %v`, binding.Namespace, secret.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// deleteRenamedServiceBindingSecret deletes the Secret the credentials of the
+// given binding were last injected in, if that was under a previous
+// SecretName, and records the current SecretName as the one they are
+// delivered in.
+func (c *controller) deleteRenamedServiceBindingSecret(binding *v1beta1.ServiceBinding) error {
+	previousSecretName := binding.Status.DeliveredSecretName
+	binding.Status.DeliveredSecretName = binding.Spec.SecretName
+	if previousSecretName == "" || previousSecretName == binding.Spec.SecretName {
+		return nil
+	}
+
+	secretClient := c.kubeClient.CoreV1().Secrets(binding.Namespace)
+	secret, err := secretClient.Get(previousSecretName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !metav1.IsControlledBy(secret, binding) {
+		return nil
+	}
+
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	glog.V(5).Info(pcb.Messagef(`Deleting Secret "%s/%s"`, secret.Namespace, secret.Name))
+	err = secretClient.Delete(secret.Name, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// serviceBindingHasAddKeysFromTransform returns whether any of the transforms
+// of the given binding is an AddKeysFrom transform.
+func serviceBindingHasAddKeysFromTransform(binding *v1beta1.ServiceBinding) bool {
+	for _, t := range binding.Spec.SecretTransforms {
+		if t.AddKeysFrom != nil {
+			return true
 		}
-		_, err = secretClient.Create(secret)
-		if err != nil {
-			if apierrors.IsAlreadyExists(err) {
-				// Concurrent controller has created secret under the same name,
-				// Update the secret at the next retry iteration
-				return fmt.Errorf(`Conflicting Secret "%s/%s" creation detected`, binding.Namespace, secret.Name)
-			}
-			// Terminal error
-			return fmt.Errorf(`Unexpected error creating Secret "%s/%s": %v`, binding.Namespace, secret.Name, err)
+	}
+	return false
+}
+
+// serviceBindingKeepsCredentials returns whether the credentials returned by
+// the broker for the given binding are kept aside, which is only done when
+// SecretTransformSync is enabled and its Secret depends on other Secrets or
+// on templates.
+func serviceBindingKeepsCredentials(binding *v1beta1.ServiceBinding) bool {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.SecretTransformSync) {
+		return false
+	}
+	for _, t := range binding.Spec.SecretTransforms {
+		if t.AddKeysFrom != nil || t.TemplateKey != nil {
+			return true
 		}
 	}
+	return false
+}
 
-	return err
+// serviceBindingCredentialsSecretName returns the name of the Secret in which
+// the credentials returned by the broker for the given binding are kept.
+func serviceBindingCredentialsSecretName(binding *v1beta1.ServiceBinding) string {
+	return bindingCredentialsSecretPrefix + string(binding.UID)
+}
+
+// storeServiceBindingCredentials keeps the given credentials, as returned by
+// the broker, in a Secret owned by the given binding.
+func (c *controller) storeServiceBindingCredentials(binding *v1beta1.ServiceBinding, credentials map[string]interface{}) error {
+	data, err := json.Marshal(credentials)
+	if err != nil {
+		return fmt.Errorf("Unable to serialize the credentials (value is intentionally not logged): %s", err)
+	}
+	secretData := map[string][]byte{bindingCredentialsSecretKey: data}
+	return c.createOrUpdateServiceBindingSecret(binding, serviceBindingCredentialsSecretName(binding), bindingCredentialsSecretType, secretData)
+}
+
+// loadServiceBindingCredentials returns the credentials kept for the given
+// binding by storeServiceBindingCredentials.
+func (c *controller) loadServiceBindingCredentials(binding *v1beta1.ServiceBinding) (map[string]interface{}, error) {
+	secret, err := c.kubeClient.CoreV1().Secrets(binding.Namespace).Get(serviceBindingCredentialsSecretName(binding), metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if !metav1.IsControlledBy(secret, binding) {
+		controllerRef := metav1.GetControllerOf(secret)
+		return nil, fmt.Errorf(`Secret "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, secret.Name, controllerRef)
+	}
+	credentials := make(map[string]interface{})
+	if err := json.Unmarshal(secret.Data[bindingCredentialsSecretKey], &credentials); err != nil {
+		return nil, fmt.Errorf(`Unable to deserialize the credentials of Secret "%s/%s": %s`, binding.Namespace, secret.Name, err)
+	}
+	return credentials, nil
 }
 
 func (c *controller) transformCredentials(transforms []v1beta1.SecretTransform, credentials map[string]interface{}) error {
@@ -522,13 +1349,25 @@ func (c *controller) transformCredentials(transforms []v1beta1.SecretTransform,
 				Secrets(t.AddKeysFrom.SecretRef.Namespace).
 				Get(t.AddKeysFrom.SecretRef.Name, metav1.GetOptions{})
 			if err != nil {
-				return err // TODO: if the Secret doesn't exist yet, can we perform the transform when it does?
+				if apierrors.IsNotFound(err) {
+					return &secretTransformSourceNotFoundError{
+						namespace: t.AddKeysFrom.SecretRef.Namespace,
+						name:      t.AddKeysFrom.SecretRef.Name,
+					}
+				}
+				return err
 			}
 			for k, v := range secret.Data {
 				credentials[k] = v
 			}
 		case t.RemoveKey != nil:
 			delete(credentials, t.RemoveKey.Key)
+		case t.TemplateKey != nil:
+			value, err := secrettemplate.Render(t.TemplateKey.Template, credentials)
+			if err != nil {
+				return fmt.Errorf("failed to evaluate the template of key %q: %v", t.TemplateKey.Key, err)
+			}
+			credentials[t.TemplateKey.Key] = value
 		}
 	}
 	return nil
@@ -557,9 +1396,415 @@ func (c *controller) ejectServiceBinding(binding *v1beta1.ServiceBinding) error
 		return err
 	}
 
+	// The Secret the credentials are kept aside in is owned by the binding,
+	// so it is also garbage collected if the binding stopped keeping them.
+	if serviceBindingKeepsCredentials(binding) {
+		err = c.kubeClient.CoreV1().Secrets(binding.Namespace).Delete(serviceBindingCredentialsSecretName(binding), &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	if err := c.deleteServiceBindingSecretCopies(binding, sets.NewString()); err != nil {
+		return err
+	}
+
+	if err := c.deleteServiceBindingConfigMap(binding); err != nil {
+		return err
+	}
+
+	return c.deleteServiceBindingPodPreset(binding)
+}
+
+// syncServiceBindingSecretCopies copies the Secret of the binding, holding the
+// given data, into the namespaces listed in its SecretCopyNamespaces, and
+// removes the copies that are no longer listed.
+func (c *controller) syncServiceBindingSecretCopies(binding *v1beta1.ServiceBinding, secretData map[string][]byte) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingTargets) {
+		return nil
+	}
+
+	for _, namespace := range binding.Spec.SecretCopyNamespaces {
+		if err := c.createOrUpdateServiceBindingSecretCopy(binding, namespace, secretData); err != nil {
+			return err
+		}
+	}
+
+	return c.deleteServiceBindingSecretCopies(binding, sets.NewString(binding.Spec.SecretCopyNamespaces...))
+}
+
+// createOrUpdateServiceBindingSecretCopy creates the copy of the Secret of
+// the binding, holding the given data, in the given namespace, or updates it
+// if it already exists.
+func (c *controller) createOrUpdateServiceBindingSecretCopy(binding *v1beta1.ServiceBinding, namespace string, secretData map[string][]byte) error {
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	glog.V(5).Info(pcb.Messagef(`Creating/updating Secret "%s/%s" copied from Secret "%s/%s"`,
+		namespace, binding.Spec.SecretName, binding.Namespace, binding.Spec.SecretName,
+	))
+
+	secretClient := c.kubeClient.CoreV1().Secrets(namespace)
+	existingSecret, err := secretClient.Get(binding.Spec.SecretName, metav1.GetOptions{})
+	if err == nil {
+		if existingSecret.Labels[bindingSecretCopyLabel] != string(binding.UID) {
+			return fmt.Errorf(`Secret "%s/%s" is not a copy of the Secret of ServiceBinding "%s/%s"`, namespace, existingSecret.Name, binding.Namespace, binding.Name)
+		}
+		if reflect.DeepEqual(existingSecret.Data, secretData) {
+			return nil
+		}
+		existingSecret.Data = secretData
+		if _, err := secretClient.Update(existingSecret); err != nil {
+			if apierrors.IsConflict(err) {
+				return fmt.Errorf(`Conflicting Secret "%s/%s" update detected`, namespace, existingSecret.Name)
+			}
+			return fmt.Errorf(`Unexpected error updating Secret "%s/%s": %v`, namespace, existingSecret.Name, err)
+		}
+		return nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return fmt.Errorf(`Unexpected error getting Secret "%s/%s": %v`, namespace, binding.Spec.SecretName, err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      binding.Spec.SecretName,
+			Namespace: namespace,
+			Labels: map[string]string{
+				bindingSecretCopyLabel: string(binding.UID),
+			},
+			Annotations: map[string]string{
+				bindingSecretCopyAnnotation: binding.Namespace + "/" + binding.Name,
+			},
+		},
+		Data: secretData,
+	}
+	if _, err := secretClient.Create(secret); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf(`Conflicting Secret "%s/%s" creation detected`, namespace, secret.Name)
+		}
+		return fmt.Errorf(`Unexpected error creating Secret "%s/%s": %v`, namespace, secret.Name, err)
+	}
+	return nil
+}
+
+// deleteServiceBindingSecretCopies removes the copies of the Secret of the
+// binding, except the copies in the given namespaces that are still named
+// after its Secret.
+func (c *controller) deleteServiceBindingSecretCopies(binding *v1beta1.ServiceBinding, keepNamespaces sets.String) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingTargets) {
+		return nil
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{bindingSecretCopyLabel: string(binding.UID)})
+	copies, err := c.listSecrets(selector)
+	if err != nil {
+		return err
+	}
+
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	for _, secret := range copies {
+		if keepNamespaces.Has(secret.Namespace) && secret.Name == binding.Spec.SecretName {
+			continue
+		}
+		glog.V(5).Info(pcb.Messagef(`Deleting Secret "%s/%s"`, secret.Namespace, secret.Name))
+		err := c.kubeClient.CoreV1().Secrets(secret.Namespace).Delete(secret.Name, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// collectServiceBindingSecretCopies removes the copies of Secrets that no
+// binding wants anymore. Copies are not garbage collected through owner
+// references, so the copies of a binding that went away without being
+// ejected, for instance while the BindingTargets feature was disabled, or
+// whose namespace was unlisted while the binding was not reconciled, would
+// otherwise be leaked.
+func (c *controller) collectServiceBindingSecretCopies() {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingTargets) {
+		return
+	}
+
+	isCopy, err := labels.NewRequirement(bindingSecretCopyLabel, selection.Exists, nil)
+	if err != nil {
+		glog.Errorf("Couldn't select copies of ServiceBinding Secrets: %v", err)
+		return
+	}
+	copies, err := c.listSecrets(labels.NewSelector().Add(*isCopy))
+	if err != nil {
+		glog.Errorf("Couldn't list copies of ServiceBinding Secrets: %v", err)
+		return
+	}
+
+	for _, secret := range copies {
+		if c.serviceBindingSecretCopyWanted(secret) {
+			continue
+		}
+		glog.V(4).Infof(`Deleting Secret "%s/%s" copied for ServiceBinding %q, which no longer wants it`,
+			secret.Namespace, secret.Name, secret.Annotations[bindingSecretCopyAnnotation])
+		err := c.kubeClient.CoreV1().Secrets(secret.Namespace).Delete(secret.Name, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			glog.Errorf(`Couldn't delete Secret "%s/%s": %v`, secret.Namespace, secret.Name, err)
+		}
+	}
+}
+
+// serviceBindingSecretCopyWanted returns whether the binding the given copy
+// of a Secret was made for still exists and still copies its Secret, under
+// that name, into the namespace of the copy. A copy is kept when its binding
+// cannot be looked up.
+func (c *controller) serviceBindingSecretCopyWanted(secret *corev1.Secret) bool {
+	namespace, name, err := cache.SplitMetaNamespaceKey(secret.Annotations[bindingSecretCopyAnnotation])
+	if err != nil || namespace == "" || name == "" {
+		return false
+	}
+	binding, err := c.bindingLister.ServiceBindings(namespace).Get(name)
+	if err != nil {
+		return !apierrors.IsNotFound(err)
+	}
+	if string(binding.UID) != secret.Labels[bindingSecretCopyLabel] || binding.Spec.SecretName != secret.Name {
+		return false
+	}
+	for _, copyNamespace := range binding.Spec.SecretCopyNamespaces {
+		if copyNamespace == secret.Namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// syncServiceBindingConfigMap creates or updates the ConfigMap described by
+// the ConfigMapTemplate of the binding from the given Secret data. If the
+// binding has no ConfigMapTemplate, a ConfigMap previously created for it is
+// deleted.
+func (c *controller) syncServiceBindingConfigMap(binding *v1beta1.ServiceBinding, secretData map[string][]byte) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingTargets) {
+		return nil
+	}
+	if binding.Spec.ConfigMapTemplate == nil {
+		return c.deleteServiceBindingConfigMap(binding)
+	}
+
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	glog.V(5).Info(pcb.Messagef(`Creating/updating ConfigMap "%s/%s"`, binding.Namespace, binding.Name))
+
+	configMapData := serviceBindingConfigMapData(binding, secretData)
+
+	configMapClient := c.kubeClient.CoreV1().ConfigMaps(binding.Namespace)
+	existingConfigMap, err := configMapClient.Get(binding.Name, metav1.GetOptions{})
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf(`Unexpected error getting ConfigMap "%s/%s": %v`, binding.Namespace, binding.Name, err)
+		}
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      binding.Name,
+				Namespace: binding.Namespace,
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(binding, bindingControllerKind),
+				},
+			},
+			Data: configMapData,
+		}
+		if _, err := configMapClient.Create(configMap); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf(`Conflicting ConfigMap "%s/%s" creation detected`, binding.Namespace, configMap.Name)
+			}
+			return fmt.Errorf(`Unexpected error creating ConfigMap "%s/%s": %v`, binding.Namespace, configMap.Name, err)
+		}
+		return nil
+	}
+
+	if !metav1.IsControlledBy(existingConfigMap, binding) {
+		controllerRef := metav1.GetControllerOf(existingConfigMap)
+		return fmt.Errorf(`ConfigMap "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, existingConfigMap.Name, controllerRef)
+	}
+	if reflect.DeepEqual(existingConfigMap.Data, configMapData) {
+		return nil
+	}
+	existingConfigMap.Data = configMapData
+	if _, err := configMapClient.Update(existingConfigMap); err != nil {
+		if apierrors.IsConflict(err) {
+			return fmt.Errorf(`Conflicting ConfigMap "%s/%s" update detected`, binding.Namespace, existingConfigMap.Name)
+		}
+		return fmt.Errorf(`Unexpected error updating ConfigMap "%s/%s": %v`, binding.Namespace, existingConfigMap.Name, err)
+	}
+	return nil
+}
+
+// serviceBindingConfigMapData returns the data of the ConfigMap described by
+// the ConfigMapTemplate of the given binding, from the given Secret data.
+func serviceBindingConfigMapData(binding *v1beta1.ServiceBinding, secretData map[string][]byte) map[string]string {
+	configMapData := make(map[string]string)
+	for _, key := range binding.Spec.ConfigMapTemplate.Keys {
+		if value, ok := secretData[key]; ok {
+			configMapData[key] = string(value)
+		}
+	}
+	return configMapData
+}
+
+func (c *controller) deleteServiceBindingConfigMap(binding *v1beta1.ServiceBinding) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.BindingTargets) {
+		return nil
+	}
+
+	configMapClient := c.kubeClient.CoreV1().ConfigMaps(binding.Namespace)
+	existingConfigMap, err := configMapClient.Get(binding.Name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf(`Unexpected error getting ConfigMap "%s/%s": %v`, binding.Namespace, binding.Name, err)
+	}
+	// Never remove a ConfigMap the binding does not own
+	if !metav1.IsControlledBy(existingConfigMap, binding) {
+		return nil
+	}
+
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	glog.V(5).Info(pcb.Messagef(`Deleting ConfigMap "%s/%s"`, binding.Namespace, existingConfigMap.Name))
+	err = configMapClient.Delete(existingConfigMap.Name, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// syncServiceBindingPodPreset creates or updates the PodPreset described by
+// the PodPresetTemplate of the binding. If the binding has no
+// PodPresetTemplate, a PodPreset previously created for it is deleted.
+func (c *controller) syncServiceBindingPodPreset(binding *v1beta1.ServiceBinding) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.PodPreset) {
+		return nil
+	}
+	if binding.Spec.PodPresetTemplate == nil {
+		return c.deleteServiceBindingPodPreset(binding)
+	}
+
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	glog.V(5).Info(pcb.Messagef(`Creating/updating PodPreset "%s/%s"`, binding.Namespace, binding.Name))
+
+	podPreset := newServiceBindingPodPreset(binding)
+	podPresetClient := c.settingsClient.PodPresets(binding.Namespace)
+	existingPodPreset, err := c.podPresetLister.PodPresets(binding.Namespace).Get(podPreset.Name)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf(`Unexpected error getting PodPreset "%s/%s": %v`, binding.Namespace, podPreset.Name, err)
+		}
+		if _, err := podPresetClient.Create(podPreset); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf(`Conflicting PodPreset "%s/%s" creation detected`, binding.Namespace, podPreset.Name)
+			}
+			return fmt.Errorf(`Unexpected error creating PodPreset "%s/%s": %v`, binding.Namespace, podPreset.Name, err)
+		}
+		return nil
+	}
+
+	if !metav1.IsControlledBy(existingPodPreset, binding) {
+		controllerRef := metav1.GetControllerOf(existingPodPreset)
+		return fmt.Errorf(`PodPreset "%s/%s" is not owned by ServiceBinding, controllerRef: %v`, binding.Namespace, existingPodPreset.Name, controllerRef)
+	}
+	if reflect.DeepEqual(existingPodPreset.Spec, podPreset.Spec) {
+		return nil
+	}
+	toUpdate := existingPodPreset.DeepCopy()
+	toUpdate.Spec = podPreset.Spec
+	if _, err := podPresetClient.Update(toUpdate); err != nil {
+		if apierrors.IsConflict(err) {
+			return fmt.Errorf(`Conflicting PodPreset "%s/%s" update detected`, binding.Namespace, existingPodPreset.Name)
+		}
+		return fmt.Errorf(`Unexpected error updating PodPreset "%s/%s": %v`, binding.Namespace, existingPodPreset.Name, err)
+	}
+	return nil
+}
+
+// deleteServiceBindingPodPreset deletes the PodPreset created for the
+// binding, if there is one.
+func (c *controller) deleteServiceBindingPodPreset(binding *v1beta1.ServiceBinding) error {
+	if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.PodPreset) {
+		return nil
+	}
+
+	podPresetClient := c.settingsClient.PodPresets(binding.Namespace)
+	existingPodPreset, err := c.podPresetLister.PodPresets(binding.Namespace).Get(binding.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf(`Unexpected error getting PodPreset "%s/%s": %v`, binding.Namespace, binding.Name, err)
+	}
+	// Never remove a PodPreset the binding does not own
+	if !metav1.IsControlledBy(existingPodPreset, binding) {
+		return nil
+	}
+
+	pcb := pretty.NewContextBuilder(pretty.ServiceBinding, binding.Namespace, binding.Name)
+	glog.V(5).Info(pcb.Messagef(`Deleting PodPreset "%s/%s"`, binding.Namespace, existingPodPreset.Name))
+	err = podPresetClient.Delete(existingPodPreset.Name, &metav1.DeleteOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
 	return nil
 }
 
+// newServiceBindingPodPreset returns the PodPreset described by the
+// PodPresetTemplate of the binding. The PodPreset has the name of the binding
+// and is owned by it.
+func newServiceBindingPodPreset(binding *v1beta1.ServiceBinding) *settingsv1alpha1.PodPreset {
+	template := binding.Spec.PodPresetTemplate
+	podPreset := &settingsv1alpha1.PodPreset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      binding.Name,
+			Namespace: binding.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(binding, bindingControllerKind),
+			},
+		},
+		Spec: settingsv1alpha1.PodPresetSpec{
+			Selector: template.Selector,
+		},
+	}
+
+	if template.EnvPrefix != nil {
+		podPreset.Spec.EnvFrom = []corev1.EnvFromSource{{
+			Prefix: *template.EnvPrefix,
+			SecretRef: &corev1.SecretEnvSource{
+				LocalObjectReference: corev1.LocalObjectReference{Name: binding.Spec.SecretName},
+			},
+		}}
+	}
+	if template.MountPath != "" {
+		volumeName := podPresetVolumeName(binding)
+		podPreset.Spec.Volumes = []corev1.Volume{{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{SecretName: binding.Spec.SecretName},
+			},
+		}}
+		podPreset.Spec.VolumeMounts = []corev1.VolumeMount{{
+			Name:      volumeName,
+			MountPath: template.MountPath,
+			ReadOnly:  true,
+		}}
+	}
+
+	return podPreset
+}
+
+// podPresetVolumeName returns the name of the volume of the binding's Secret
+// in its PodPreset. Volume names must be DNS labels, while binding names may
+// contain dots and be longer than a label, so a name that is too long is
+// truncated and suffixed with a hash of the binding name to keep it unique.
+func podPresetVolumeName(binding *v1beta1.ServiceBinding) string {
+	name := strings.Replace(binding.Name, ".", "-", -1)
+	if len(name) <= validation.DNS1123LabelMaxLength {
+		return name
+	}
+	hash := fmt.Sprintf("%x", sha256.Sum256([]byte(binding.Name)))[:8]
+	return name[:validation.DNS1123LabelMaxLength-len(hash)-1] + "-" + hash
+}
+
 // setServiceBindingCondition sets a single condition on a ServiceBinding's
 // status: if the condition already exists in the status, it is mutated; if the
 // condition does not already exist in the status, it is added. Other
@@ -796,7 +2041,7 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 		return c.processServiceBindingOperationError(binding, readyCond)
 	}
 
-	serviceClass, servicePlan, _, brokerClient, err := c.getClusterServiceClassPlanAndClusterServiceBrokerForServiceBinding(instance, binding)
+	serviceClass, servicePlan, _, brokerClient, err := c.getCommonServiceClassPlanAndBrokerForServiceBinding(instance, binding)
 	if err != nil {
 		return c.handleServiceBindingReconciliationError(binding, err)
 	}
@@ -834,7 +2079,7 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 		glog.V(4).Info(pcb.Message(s))
 		c.recorder.Event(binding, corev1.EventTypeWarning, errorPollingLastOperationReason, s)
 
-		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
+		if c.serviceBindingRetryDurationExceeded(binding) {
 			return c.processServiceBindingPollingFailureRetryTimeout(binding, nil)
 		}
 
@@ -845,7 +2090,7 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 
 	switch response.State {
 	case osb.StateInProgress:
-		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
+		if c.serviceBindingRetryDurationExceeded(binding) {
 			return c.processServiceBindingPollingFailureRetryTimeout(binding, nil)
 		}
 
@@ -901,7 +2146,15 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 			return c.finishPollingServiceBinding(binding)
 		}
 
-		if err := c.injectServiceBinding(binding, getBindingResponse.Credentials); err != nil {
+		err = c.injectServiceBinding(binding, getBindingResponse.Credentials)
+		if sourceErr, ok := err.(*secretTransformSourceNotFoundError); ok {
+			if err := c.processBindWaitingForSecretTransformSource(binding, sourceErr); err != nil {
+				return err
+			}
+
+			return c.finishPollingServiceBinding(binding)
+		}
+		if err != nil {
 			reason := errorInjectingBindResultReason
 			msg := fmt.Sprintf("Error injecting bind results: %v", err)
 
@@ -940,7 +2193,7 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 		msg := "Unbind call failed: " + description
 		readyCond := newServiceBindingReadyCondition(v1beta1.ConditionUnknown, errorUnbindCallReason, msg)
 
-		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
+		if c.serviceBindingRetryDurationExceeded(binding) {
 			return c.processServiceBindingPollingFailureRetryTimeout(binding, readyCond)
 		}
 
@@ -963,7 +2216,7 @@ func (c *controller) pollServiceBinding(binding *v1beta1.ServiceBinding) error {
 	default:
 		glog.Warning(pcb.Messagef("Got invalid state in LastOperationResponse: %q", response.State))
 
-		if c.reconciliationRetryDurationExceeded(binding.Status.OperationStartTime) {
+		if c.serviceBindingRetryDurationExceeded(binding) {
 			return c.processServiceBindingPollingFailureRetryTimeout(binding, nil)
 		}
 
@@ -1047,7 +2300,7 @@ func setServiceBindingLastOperation(binding *v1beta1.ServiceBinding, operationKe
 // prepareBindRequest creates a bind request object to be passed to the broker
 // client to create the given binding.
 func (c *controller) prepareBindRequest(
-	binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, serviceClass *v1beta1.ClusterServiceClass, servicePlan *v1beta1.ClusterServicePlan) (
+	binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, serviceClass *v1beta1.CommonServiceClassSpec, servicePlan *v1beta1.CommonServicePlanSpec) (
 	*osb.BindRequest, *v1beta1.ServiceBindingPropertiesState, error) {
 
 	ns, err := c.kubeClient.CoreV1().Namespaces().Get(instance.Namespace, metav1.GetOptions{})
@@ -1058,12 +2311,25 @@ func (c *controller) prepareBindRequest(
 		}
 	}
 
+	sources := c.parametersFromSources()
 	parameters, parametersChecksum, rawParametersWithRedaction, err := prepareInProgressPropertyParameters(
-		c.kubeClient,
+		sources,
 		binding.Namespace,
 		binding.Spec.Parameters,
 		binding.Spec.ParametersFrom,
 	)
+	if isDependencyNotReadyError(err) && !isServiceBindingReady(binding) {
+		owner := parametersFromDependency{kind: "ServiceBinding", name: binding.Name}
+		if cycleErr := findParametersFromCycle(sources, binding.Namespace, owner, binding.Spec.ParametersFrom); cycleErr != nil {
+			err = cycleErr
+		}
+	}
+	if isDependencyFailedError(err) {
+		return nil, nil, &operationError{
+			reason:  errorDependencyFailedReason,
+			message: fmt.Sprintf("A dependency will never be Ready: %v", err),
+		}
+	}
 	if err != nil {
 		return nil, nil, &operationError{
 			reason:  errorWithParameters,
@@ -1081,8 +2347,8 @@ func (c *controller) prepareBindRequest(
 	request := &osb.BindRequest{
 		BindingID:    binding.Spec.ExternalID,
 		InstanceID:   instance.Spec.ExternalID,
-		ServiceID:    serviceClass.Spec.ExternalID,
-		PlanID:       servicePlan.Spec.ExternalID,
+		ServiceID:    serviceClass.ExternalID,
+		PlanID:       servicePlan.ExternalID,
 		AppGUID:      &appGUID,
 		Parameters:   parameters,
 		BindResource: &osb.BindResource{AppGUID: &appGUID},
@@ -1093,7 +2359,7 @@ func (c *controller) prepareBindRequest(
 	// AsyncBindingOperations feature gate. This may be easily set
 	// by setting `asyncBindingOperationsEnabled=true` when
 	// deploying the Service Catalog via the Helm charts.
-	if serviceClass.Spec.BindingRetrievable &&
+	if serviceClass.BindingRetrievable &&
 		utilfeature.DefaultFeatureGate.Enabled(scfeatures.AsyncBindingOperations) {
 
 		request.AcceptsIncomplete = true
@@ -1116,14 +2382,14 @@ func (c *controller) prepareBindRequest(
 // prepareUnbindRequest creates an unbind request object to be passed to the
 // broker client to delete the given binding.
 func (c *controller) prepareUnbindRequest(
-	binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, serviceClass *v1beta1.ClusterServiceClass, servicePlan *v1beta1.ClusterServicePlan) (
+	binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, serviceClass *v1beta1.CommonServiceClassSpec, servicePlan *v1beta1.CommonServicePlanSpec) (
 	*osb.UnbindRequest, error) {
 
 	request := &osb.UnbindRequest{
 		BindingID:  binding.Spec.ExternalID,
 		InstanceID: instance.Spec.ExternalID,
-		ServiceID:  serviceClass.Spec.ExternalID,
-		PlanID:     servicePlan.Spec.ExternalID,
+		ServiceID:  serviceClass.ExternalID,
+		PlanID:     servicePlan.ExternalID,
 	}
 
 	// Asynchronous binding operations is currently ALPHA and not
@@ -1131,7 +2397,7 @@ func (c *controller) prepareUnbindRequest(
 	// AsyncBindingOperations feature gate. This may be easily set
 	// by setting `asyncBindingOperationsEnabled=true` when
 	// deploying the Service Catalog via the Helm charts.
-	if serviceClass.Spec.BindingRetrievable &&
+	if serviceClass.BindingRetrievable &&
 		utilfeature.DefaultFeatureGate.Enabled(scfeatures.AsyncBindingOperations) {
 
 		request.AcceptsIncomplete = true
@@ -1155,14 +2421,14 @@ func (c *controller) prepareUnbindRequest(
 // passed to the broker client to query the given binding's last operation
 // endpoint.
 func (c *controller) prepareServiceBindingLastOperationRequest(
-	binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, serviceClass *v1beta1.ClusterServiceClass, servicePlan *v1beta1.ClusterServicePlan) (
+	binding *v1beta1.ServiceBinding, instance *v1beta1.ServiceInstance, serviceClass *v1beta1.CommonServiceClassSpec, servicePlan *v1beta1.CommonServicePlanSpec) (
 	*osb.BindingLastOperationRequest, error) {
 
 	request := &osb.BindingLastOperationRequest{
 		InstanceID: instance.Spec.ExternalID,
 		BindingID:  binding.Spec.ExternalID,
-		ServiceID:  &serviceClass.Spec.ExternalID,
-		PlanID:     &servicePlan.Spec.ExternalID,
+		ServiceID:  &serviceClass.ExternalID,
+		PlanID:     &servicePlan.ExternalID,
 	}
 	if binding.Status.LastOperation != nil && *binding.Status.LastOperation != "" {
 		key := osb.OperationKey(*binding.Status.LastOperation)
@@ -1199,19 +2465,73 @@ func (c *controller) processServiceBindingOperationError(binding *v1beta1.Servic
 // has successfully been created at the broker and has had its credentials
 // injected in the cluster.
 func (c *controller) processBindSuccess(binding *v1beta1.ServiceBinding) error {
-	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue, successInjectedBindResultReason, successInjectedBindResultMessage)
+	return c.processBindCompletion(binding, v1beta1.ConditionTrue, successInjectedBindResultReason, successInjectedBindResultMessage)
+}
+
+// processBindWaitingForSecretTransformSource handles the logging and updating
+// of a ServiceBinding that has successfully been created at the broker, but
+// whose credentials cannot be injected until the Secret referenced by one of
+// its AddKeysFrom transforms exists.
+func (c *controller) processBindWaitingForSecretTransformSource(binding *v1beta1.ServiceBinding, err *secretTransformSourceNotFoundError) error {
+	return c.processBindCompletion(binding, v1beta1.ConditionFalse, waitingForSecretTransformSourceReason, err.Error())
+}
+
+// processBindCompletion completes the bind operation of the given binding,
+// and sets its ready condition with the given status, reason and message.
+func (c *controller) processBindCompletion(binding *v1beta1.ServiceBinding, status v1beta1.ConditionStatus, reason, message string) error {
+	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, status, reason, message)
 	currentReconciledGeneration := binding.Status.ReconciledGeneration
 	clearServiceBindingCurrentOperation(binding)
 	rollbackBindingReconciledGenerationOnDeletion(binding, currentReconciledGeneration)
+	if reason == waitingForSecretTransformSourceReason && !serviceBindingKeepsCredentials(binding) {
+		// Without SecretTransformSync, the credentials are not kept aside to
+		// be injected once the Secret exists. The generation is left
+		// unreconciled instead, so that the binding is bound again.
+		binding.Status.ReconciledGeneration = currentReconciledGeneration
+	}
+	rotated := c.recordServiceBindingCurrentExternalID(binding)
 
 	if _, err := c.updateServiceBindingStatus(binding); err != nil {
 		return err
 	}
 
-	c.recorder.Event(binding, corev1.EventTypeNormal, successInjectedBindResultReason, successInjectedBindResultMessage)
+	c.recorder.Event(binding, corev1.EventTypeNormal, reason, message)
+	if rotated {
+		msg := fmt.Sprintf(
+			"Rotated credentials; previous credentials %q will be unbound in %v",
+			binding.Status.PreviousExternalID, c.bindingRotationGracePeriod,
+		)
+		c.recorder.Event(binding, corev1.EventTypeNormal, successRotatedCredentialsReason, msg)
+		c.enqueueServiceBindingAfter(binding, c.bindingRotationGracePeriod)
+	}
 	return nil
 }
 
+// serviceBindingRotationInProgress returns whether the given binding is being
+// bound under a new ExternalID while the credentials of its current one are
+// still delivered.
+func serviceBindingRotationInProgress(binding *v1beta1.ServiceBinding) bool {
+	return binding.Status.CurrentExternalID != "" && binding.Status.CurrentExternalID != binding.Spec.ExternalID
+}
+
+// recordServiceBindingCurrentExternalID records the external ID the given
+// binding has just been bound with as its current one. If that replaces the
+// credentials of an earlier external ID, that ID is recorded as the previous
+// one to unbind once the rotation grace period has elapsed, and true is
+// returned.
+func (c *controller) recordServiceBindingCurrentExternalID(binding *v1beta1.ServiceBinding) bool {
+	currentExternalID := binding.Status.CurrentExternalID
+	binding.Status.CurrentExternalID = binding.Spec.ExternalID
+	if currentExternalID == "" || currentExternalID == binding.Spec.ExternalID {
+		return false
+	}
+
+	unbindTime := metav1.NewTime(time.Now().Add(c.bindingRotationGracePeriod))
+	binding.Status.PreviousExternalID = currentExternalID
+	binding.Status.PreviousCredentialsUnbindTime = &unbindTime
+	return true
+}
+
 // processBindFailure handles the logging and updating of a ServiceBinding that
 // hit a terminal failure during bind reconciliation.
 func (c *controller) processBindFailure(binding *v1beta1.ServiceBinding, readyCond, failedCond *v1beta1.ServiceBindingCondition, shouldMitigateOrphan bool) error {
@@ -1304,8 +2624,12 @@ func (c *controller) processUnbindSuccess(binding *v1beta1.ServiceBinding) error
 
 	setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionFalse, reason, msg)
 	clearServiceBindingCurrentOperation(binding)
-	binding.Status.ExternalProperties = nil
-	binding.Status.UnbindStatus = v1beta1.ServiceBindingUnbindStatusSucceeded
+	// The credentials bound before a failed rotation remain bound, and are
+	// unbound when the binding is deleted.
+	if !mitigatingOrphan || !serviceBindingRotationInProgress(binding) {
+		binding.Status.ExternalProperties = nil
+		binding.Status.UnbindStatus = v1beta1.ServiceBindingUnbindStatusSucceeded
+	}
 
 	if mitigatingOrphan {
 		if _, err := c.updateServiceBindingStatus(binding); err != nil {
@@ -28,7 +28,9 @@ import (
 
 	scmeta "github.com/kubernetes-incubator/service-catalog/pkg/api/meta"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
 	v1beta1informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions/servicecatalog/v1beta1"
+	settingslisters "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/settings/v1alpha1"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
 	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
 	corev1 "k8s.io/api/core/v1"
@@ -37,12 +39,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/diff"
+	"k8s.io/apimachinery/pkg/util/validation"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	"github.com/kubernetes-incubator/service-catalog/test/fake"
 	clientgofake "k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
 )
 
 // TestReconcileBindingNonExistingInstance tests reconcileBinding to ensure a
@@ -3967,6 +3972,38 @@ func TestTransformSecretData(t *testing.T) {
 				"foo": "123",
 			},
 		},
+		{
+			name: "TemplateKeyTransform",
+			transforms: []v1beta1.SecretTransform{
+				{
+					RenameKey: &v1beta1.RenameKeyTransform{
+						From: "user",
+						To:   "username",
+					},
+				},
+				{
+					TemplateKey: &v1beta1.TemplateKeyTransform{
+						Key:      "uri",
+						Template: "postgres://{{.username}}:{{.password | queryescape}}@{{.host}}:{{.port}}/{{.database}}",
+					},
+				},
+			},
+			credentials: map[string]interface{}{
+				"user":     "admin",
+				"password": "p@ss",
+				"host":     "db",
+				"port":     float64(5432),
+				"database": []byte("orders"),
+			},
+			transformedCredentials: map[string]interface{}{
+				"username": "admin",
+				"password": "p@ss",
+				"host":     "db",
+				"port":     float64(5432),
+				"database": []byte("orders"),
+				"uri":      "postgres://admin:p%40ss@db:5432/orders",
+			},
+		},
 	}
 
 	for _, tc := range cases {
@@ -3986,6 +4023,22 @@ func TestTransformSecretData(t *testing.T) {
 	}
 }
 
+func TestTransformSecretDataTemplateKeyError(t *testing.T) {
+	_, _, _, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
+
+	transforms := []v1beta1.SecretTransform{
+		{
+			TemplateKey: &v1beta1.TemplateKeyTransform{
+				Key:      "uri",
+				Template: "{{.missing}}",
+			},
+		},
+	}
+	if err := testController.transformCredentials(transforms, map[string]interface{}{"foo": "123"}); err == nil {
+		t.Fatal("expected an error for a template referencing a missing credential")
+	}
+}
+
 func assertServiceBindingBindInProgressIsTheOnlyCatalogAction(t *testing.T, fakeCatalogClient *fake.Clientset, binding *v1beta1.ServiceBinding) *v1beta1.ServiceBinding {
 	return assertServiceBindingOperationInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding, v1beta1.ServiceBindingOperationBind)
 }
@@ -4039,3 +4092,1648 @@ func reconcileServiceBinding(t *testing.T, testController *controller, binding *
 	}
 	return err
 }
+
+func getTestServiceBindingWithPodPresetTemplate() *v1beta1.ServiceBinding {
+	envPrefix := "DB_"
+	binding := getTestServiceBinding()
+	binding.Spec.SecretName = testServiceBindingSecretName
+	binding.Spec.PodPresetTemplate = &v1beta1.PodPresetTemplate{
+		Selector:  metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		EnvPrefix: &envPrefix,
+		MountPath: "/var/run/credentials",
+	}
+	return binding
+}
+
+// setTestPodPresetLister makes the controller list the given PodPresets from
+// its PodPreset informer.
+func setTestPodPresetLister(testController *controller, podPresets ...*settingsv1alpha1.PodPreset) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, podPreset := range podPresets {
+		indexer.Add(podPreset)
+	}
+	testController.podPresetLister = settingslisters.NewPodPresetLister(indexer)
+}
+
+// TestInjectServiceBindingCreatesPodPreset verifies that injecting the
+// credentials of a binding with a PodPresetTemplate creates a PodPreset
+// owned by the binding that points at its Secret.
+func TestInjectServiceBindingCreatesPodPreset(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.PodPreset))
+	if err != nil {
+		t.Fatalf("Failed to enable PodPreset feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.PodPreset))
+
+	fakeKubeClient, fakeCatalogClient, _, testController, _ := newTestController(t, noFakeActions())
+	addGetSecretNotFoundReaction(fakeKubeClient)
+	setTestPodPresetLister(testController)
+
+	binding := getTestServiceBindingWithPodPresetTemplate()
+	if err := testController.injectServiceBinding(binding, map[string]interface{}{"host": "db"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	podPreset := assertCreate(t, actions[0], &settingsv1alpha1.PodPreset{ObjectMeta: metav1.ObjectMeta{Name: binding.Name}}).(*settingsv1alpha1.PodPreset)
+
+	if !metav1.IsControlledBy(podPreset, binding) {
+		t.Fatalf("PodPreset should be controlled by the ServiceBinding, got owner references %v", podPreset.OwnerReferences)
+	}
+	expectedSpec := settingsv1alpha1.PodPresetSpec{
+		Selector: binding.Spec.PodPresetTemplate.Selector,
+		EnvFrom: []corev1.EnvFromSource{{
+			Prefix:    "DB_",
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: testServiceBindingSecretName}},
+		}},
+		Volumes: []corev1.Volume{{
+			Name:         binding.Name,
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: testServiceBindingSecretName}},
+		}},
+		VolumeMounts: []corev1.VolumeMount{{
+			Name:      binding.Name,
+			MountPath: "/var/run/credentials",
+			ReadOnly:  true,
+		}},
+	}
+	if !reflect.DeepEqual(expectedSpec, podPreset.Spec) {
+		t.Fatalf("Unexpected PodPreset spec: %v", diff.ObjectReflectDiff(expectedSpec, podPreset.Spec))
+	}
+}
+
+// TestInjectServiceBindingUpdatesPodPreset verifies that a PodPreset owned by
+// the binding is updated when the binding's Secret changes, and that a
+// PodPreset owned by something else is left alone.
+func TestInjectServiceBindingUpdatesPodPreset(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.PodPreset))
+	if err != nil {
+		t.Fatalf("Failed to enable PodPreset feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.PodPreset))
+
+	cases := []struct {
+		name        string
+		owned       bool
+		shouldError bool
+	}{
+		{
+			name:  "owned by binding",
+			owned: true,
+		},
+		{
+			name:        "not owned by binding",
+			owned:       false,
+			shouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		fakeKubeClient, fakeCatalogClient, _, testController, _ := newTestController(t, noFakeActions())
+		addGetSecretNotFoundReaction(fakeKubeClient)
+
+		binding := getTestServiceBindingWithPodPresetTemplate()
+		existing := newServiceBindingPodPreset(binding)
+		existing.Spec.EnvFrom[0].SecretRef.Name = "old-secret"
+		if !tc.owned {
+			existing.OwnerReferences = nil
+		}
+		setTestPodPresetLister(testController, existing)
+
+		binding.Spec.SecretName = "new-secret"
+		err := testController.injectServiceBinding(binding, map[string]interface{}{"host": "db"})
+		if tc.shouldError != (err != nil) {
+			t.Errorf("%v: unexpected error state; error: %v", tc.name, err)
+			continue
+		}
+
+		actions := fakeCatalogClient.Actions()
+		if tc.shouldError {
+			assertNumberOfActions(t, actions, 0)
+			continue
+		}
+		assertNumberOfActions(t, actions, 1)
+		podPreset := assertUpdate(t, actions[0], existing).(*settingsv1alpha1.PodPreset)
+		if e, a := "new-secret", podPreset.Spec.EnvFrom[0].SecretRef.Name; e != a {
+			t.Errorf("%v: unexpected Secret in PodPreset; %s", tc.name, expectedGot(e, a))
+		}
+	}
+}
+
+// TestEjectServiceBindingDeletesPodPreset verifies that ejecting a binding
+// deletes the PodPreset it owns, and only that one.
+func TestEjectServiceBindingDeletesPodPreset(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.PodPreset))
+	if err != nil {
+		t.Fatalf("Failed to enable PodPreset feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.PodPreset))
+
+	cases := []struct {
+		name          string
+		owned         bool
+		expectDeleted bool
+	}{
+		{
+			name:          "owned by binding",
+			owned:         true,
+			expectDeleted: true,
+		},
+		{
+			name:          "not owned by binding",
+			owned:         false,
+			expectDeleted: false,
+		},
+	}
+
+	for _, tc := range cases {
+		_, fakeCatalogClient, _, testController, _ := newTestController(t, noFakeActions())
+
+		binding := getTestServiceBindingWithPodPresetTemplate()
+		existing := newServiceBindingPodPreset(binding)
+		if !tc.owned {
+			existing.OwnerReferences = nil
+		}
+		setTestPodPresetLister(testController, existing)
+
+		if err := testController.ejectServiceBinding(binding); err != nil {
+			t.Errorf("%v: unexpected error: %v", tc.name, err)
+			continue
+		}
+
+		actions := fakeCatalogClient.Actions()
+		if !tc.expectDeleted {
+			assertNumberOfActions(t, actions, 0)
+			continue
+		}
+		assertNumberOfActions(t, actions, 1)
+		assertDelete(t, actions[0], existing)
+	}
+}
+
+// TestPodPresetVolumeName verifies that the volume of a binding's Secret in
+// its PodPreset is named with a DNS label, also for long binding names.
+func TestPodPresetVolumeName(t *testing.T) {
+	longName := strings.Repeat("a", 100) + "." + strings.Repeat("b", 100)
+	otherLongName := strings.Repeat("a", 100) + "." + strings.Repeat("c", 100)
+
+	cases := []struct {
+		name        string
+		bindingName string
+		expected    string
+	}{
+		{
+			name:        "short name",
+			bindingName: "test-binding",
+			expected:    "test-binding",
+		},
+		{
+			name:        "name with dots",
+			bindingName: "test.binding",
+			expected:    "test-binding",
+		},
+	}
+	for _, tc := range cases {
+		binding := getTestServiceBinding()
+		binding.Name = tc.bindingName
+		if e, a := tc.expected, podPresetVolumeName(binding); e != a {
+			t.Errorf("%v: unexpected volume name; %s", tc.name, expectedGot(e, a))
+		}
+	}
+
+	binding := getTestServiceBinding()
+	binding.Name = longName
+	volumeName := podPresetVolumeName(binding)
+	if errs := validation.IsDNS1123Label(volumeName); len(errs) != 0 {
+		t.Fatalf("volume name %q is not a DNS label: %v", volumeName, errs)
+	}
+	binding.Name = otherLongName
+	if otherVolumeName := podPresetVolumeName(binding); volumeName == otherVolumeName {
+		t.Fatalf("long binding names with the same prefix should have different volume names, both got %q", volumeName)
+	}
+}
+
+func getTestServiceBindingWithTargets() *v1beta1.ServiceBinding {
+	binding := getTestServiceBinding()
+	binding.UID = "binding-uid"
+	binding.Spec.SecretName = testServiceBindingSecretName
+	binding.Spec.SecretCopyNamespaces = []string{"ns-a", "ns-b"}
+	binding.Spec.ConfigMapTemplate = &v1beta1.ConfigMapTemplate{
+		Keys: []string{"host", "port", "missing"},
+	}
+	return binding
+}
+
+// newTestSecretCopy returns a copy of the Secret of the given binding in the
+// given namespace, as created by the controller.
+func newTestSecretCopy(binding *v1beta1.ServiceBinding, namespace, name string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{bindingSecretCopyLabel: string(binding.UID)},
+		},
+	}
+}
+
+// setTestSecretLister makes the controller list the given secrets from its
+// Secret informer.
+func setTestSecretLister(testController *controller, secrets ...*corev1.Secret) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, secret := range secrets {
+		indexer.Add(secret)
+	}
+	testController.secretLister = corelisters.NewSecretLister(indexer)
+}
+
+// getKubeWriteActions returns the actions other than gets among the given
+// actions.
+func getKubeWriteActions(actions []clientgotesting.Action) []clientgotesting.Action {
+	var writes []clientgotesting.Action
+	for _, action := range actions {
+		if action.GetVerb() != "get" {
+			writes = append(writes, action)
+		}
+	}
+	return writes
+}
+
+// TestInjectServiceBindingTargets verifies that injecting the credentials of
+// a binding copies its Secret into the namespaces it lists, removes the copies
+// from the namespaces it no longer lists, and creates a ConfigMap holding the
+// credentials listed in its ConfigMapTemplate.
+func TestInjectServiceBindingTargets(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.BindingTargets))
+	if err != nil {
+		t.Fatalf("Failed to enable BindingTargets feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.BindingTargets))
+
+	fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+	addGetSecretNotFoundReaction(fakeKubeClient)
+	fakeKubeClient.AddReactor("get", "configmaps", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), action.(clientgotesting.GetAction).GetName())
+	})
+
+	binding := getTestServiceBindingWithTargets()
+	otherBinding := getTestServiceBinding()
+	otherBinding.UID = "other-uid"
+	setTestSecretLister(testController,
+		newTestSecretCopy(binding, "ns-a", "old-secret"),
+		newTestSecretCopy(binding, "ns-c", testServiceBindingSecretName),
+		newTestSecretCopy(otherBinding, "ns-d", testServiceBindingSecretName),
+	)
+
+	credentials := map[string]interface{}{
+		"host":     "db",
+		"port":     float64(5432),
+		"password": "secret",
+	}
+	if err := testController.injectServiceBinding(binding, credentials); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actions := getKubeWriteActions(fakeKubeClient.Actions())
+	assertNumberOfActions(t, actions, 6)
+
+	expectedData := map[string][]byte{
+		"host":     []byte("db"),
+		"port":     []byte("5432"),
+		"password": []byte("secret"),
+	}
+	for i, namespace := range []string{testNamespace, "ns-a", "ns-b"} {
+		assertActionEquals(t, actions[i], "create", "secrets")
+		if e, a := namespace, actions[i].GetNamespace(); e != a {
+			t.Fatalf("Unexpected namespace of created Secret: %s", expectedGot(e, a))
+		}
+		secret := actions[i].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+		if !reflect.DeepEqual(expectedData, secret.Data) {
+			t.Fatalf("Unexpected data of Secret %s/%s: %v", namespace, secret.Name, diff.ObjectReflectDiff(expectedData, secret.Data))
+		}
+		if namespace == testNamespace {
+			continue
+		}
+		if e, a := string(binding.UID), secret.Labels[bindingSecretCopyLabel]; e != a {
+			t.Fatalf("Unexpected copy label: %s", expectedGot(e, a))
+		}
+		if e, a := testNamespace+"/"+testServiceBindingName, secret.Annotations[bindingSecretCopyAnnotation]; e != a {
+			t.Fatalf("Unexpected copy annotation: %s", expectedGot(e, a))
+		}
+	}
+
+	deleted := map[string]bool{}
+	for _, action := range actions[3:5] {
+		assertActionEquals(t, action, "delete", "secrets")
+		deleted[action.GetNamespace()+"/"+action.(clientgotesting.DeleteAction).GetName()] = true
+	}
+	expectedDeleted := map[string]bool{
+		"ns-a/old-secret":                      true,
+		"ns-c/" + testServiceBindingSecretName: true,
+	}
+	if !reflect.DeepEqual(expectedDeleted, deleted) {
+		t.Fatalf("Unexpected deleted copies: %v", diff.ObjectReflectDiff(expectedDeleted, deleted))
+	}
+
+	assertActionEquals(t, actions[5], "create", "configmaps")
+	configMap := actions[5].(clientgotesting.CreateAction).GetObject().(*corev1.ConfigMap)
+	if e, a := binding.Name, configMap.Name; e != a {
+		t.Fatalf("Unexpected ConfigMap name: %s", expectedGot(e, a))
+	}
+	if !metav1.IsControlledBy(configMap, binding) {
+		t.Fatalf("ConfigMap should be controlled by the ServiceBinding, got owner references %v", configMap.OwnerReferences)
+	}
+	expectedConfigMapData := map[string]string{
+		"host": "db",
+		"port": "5432",
+	}
+	if !reflect.DeepEqual(expectedConfigMapData, configMap.Data) {
+		t.Fatalf("Unexpected ConfigMap data: %v", diff.ObjectReflectDiff(expectedConfigMapData, configMap.Data))
+	}
+}
+
+// TestInjectServiceBindingSecretCopyConflict verifies that a Secret that is
+// not a copy made for the binding is never overwritten.
+func TestInjectServiceBindingSecretCopyConflict(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.BindingTargets))
+	if err != nil {
+		t.Fatalf("Failed to enable BindingTargets feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.BindingTargets))
+
+	fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+	binding := getTestServiceBindingWithTargets()
+	fakeKubeClient.AddReactor("get", "secrets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		if action.GetNamespace() == "ns-a" {
+			return true, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: testServiceBindingSecretName, Namespace: "ns-a"}}, nil
+		}
+		return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), action.(clientgotesting.GetAction).GetName())
+	})
+
+	if err := testController.injectServiceBinding(binding, map[string]interface{}{"host": "db"}); err == nil {
+		t.Fatal("expected an error for a Secret that is not a copy made for the binding")
+	}
+
+	for _, action := range getKubeWriteActions(fakeKubeClient.Actions()) {
+		if action.GetNamespace() == "ns-a" {
+			t.Fatalf("Unexpected action on the Secret of another owner: %v", action)
+		}
+	}
+}
+
+// TestEjectServiceBindingDeletesTargets verifies that ejecting a binding
+// deletes the copies of its Secret and the ConfigMap it owns.
+func TestEjectServiceBindingDeletesTargets(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.BindingTargets))
+	if err != nil {
+		t.Fatalf("Failed to enable BindingTargets feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.BindingTargets))
+
+	fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+
+	binding := getTestServiceBindingWithTargets()
+	setTestSecretLister(testController, newTestSecretCopy(binding, "ns-a", testServiceBindingSecretName))
+	fakeKubeClient.AddReactor("get", "configmaps", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            binding.Name,
+				Namespace:       binding.Namespace,
+				OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(binding, bindingControllerKind)},
+			},
+		}, nil
+	})
+
+	if err := testController.ejectServiceBinding(binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actions := getKubeWriteActions(fakeKubeClient.Actions())
+	assertNumberOfActions(t, actions, 3)
+	assertDeleteSecretAction(t, actions[0:1], binding.Spec.SecretName)
+	assertActionEquals(t, actions[1], "delete", "secrets")
+	if e, a := "ns-a", actions[1].GetNamespace(); e != a {
+		t.Fatalf("Unexpected namespace of deleted copy: %s", expectedGot(e, a))
+	}
+	assertActionEquals(t, actions[2], "delete", "configmaps")
+}
+
+// TestCollectServiceBindingSecretCopies verifies that the copies of Secrets
+// whose binding is gone, was recreated, or no longer lists their namespace are
+// removed, and that the other copies are kept.
+func TestCollectServiceBindingSecretCopies(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.BindingTargets))
+	if err != nil {
+		t.Fatalf("Failed to enable BindingTargets feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.BindingTargets))
+
+	fakeKubeClient, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	binding := getTestServiceBindingWithTargets()
+	sharedInformers.ServiceBindings().Informer().GetStore().Add(binding)
+
+	newCopy := func(uid, bindingName, namespace, name string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   namespace,
+				Labels:      map[string]string{bindingSecretCopyLabel: uid},
+				Annotations: map[string]string{bindingSecretCopyAnnotation: testNamespace + "/" + bindingName},
+			},
+		}
+	}
+	uid := string(binding.UID)
+	setTestSecretLister(testController,
+		newCopy(uid, binding.Name, "ns-a", testServiceBindingSecretName),
+		newCopy(uid, binding.Name, "ns-b", testServiceBindingSecretName),
+		newCopy(uid, binding.Name, "ns-c", testServiceBindingSecretName),
+		newCopy(uid, binding.Name, "ns-a", "old-secret-name"),
+		newCopy("old-binding-uid", binding.Name, "ns-d", testServiceBindingSecretName),
+		newCopy("gone-binding-uid", "gone-binding", "ns-e", testServiceBindingSecretName),
+	)
+
+	testController.collectServiceBindingSecretCopies()
+
+	actions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, actions, 4)
+	deleted := map[string]bool{}
+	for _, action := range actions {
+		assertActionEquals(t, action, "delete", "secrets")
+		deleted[action.GetNamespace()+"/"+action.(clientgotesting.DeleteAction).GetName()] = true
+	}
+	for _, name := range []string{
+		"ns-c/" + testServiceBindingSecretName,
+		"ns-a/old-secret-name",
+		"ns-d/" + testServiceBindingSecretName,
+		"ns-e/" + testServiceBindingSecretName,
+	} {
+		if !deleted[name] {
+			t.Fatalf("Expected copy %q to be deleted", name)
+		}
+	}
+}
+
+// TestReconcileServiceBindingRotation verifies that binding a new external ID
+// over the credentials of an earlier one records the earlier ID as the
+// previous one, to be unbound once the rotation grace period has elapsed.
+func TestReconcileServiceBindingRotation(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		BindReaction: &fakeosb.BindReaction{
+			Response: &osb.BindResponse{
+				Credentials: map[string]interface{}{
+					"a": "b",
+				},
+			},
+		},
+	})
+
+	addGetNamespaceReaction(fakeKubeClient)
+	addGetSecretNotFoundReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	binding := getTestServiceBinding()
+	binding.Generation = 2
+	binding.Status.ReconciledGeneration = 1
+	binding.Status.CurrentExternalID = "old-binding-id"
+	binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationBind
+	startTime := metav1.Now()
+	binding.Status.OperationStartTime = &startTime
+	binding.Status.InProgressProperties = &v1beta1.ServiceBindingPropertiesState{}
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("a valid binding should not fail: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfClusterServiceBrokerActions(t, brokerActions, 1)
+	assertBind(t, brokerActions[0], &osb.BindRequest{
+		BindingID:  testServiceBindingGUID,
+		InstanceID: testServiceInstanceGUID,
+		ServiceID:  testClusterServiceClassGUID,
+		PlanID:     testClusterServicePlanGUID,
+		AppGUID:    strPtr(testNamespaceGUID),
+		BindResource: &osb.BindResource{
+			AppGUID: strPtr(testNamespaceGUID),
+		},
+	})
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingOperationSuccess(t, updatedServiceBinding, v1beta1.ServiceBindingOperationBind, binding)
+	if e, a := testServiceBindingGUID, updatedServiceBinding.Status.CurrentExternalID; e != a {
+		t.Fatalf("Unexpected current external ID: %s", expectedGot(e, a))
+	}
+	if e, a := "old-binding-id", updatedServiceBinding.Status.PreviousExternalID; e != a {
+		t.Fatalf("Unexpected previous external ID: %s", expectedGot(e, a))
+	}
+	unbindTime := updatedServiceBinding.Status.PreviousCredentialsUnbindTime
+	if unbindTime == nil || !unbindTime.Time.After(time.Now()) {
+		t.Fatalf("Expected the previous credentials to be unbound in the future, got %v", unbindTime)
+	}
+
+	events := getRecordedEvents(testController)
+
+	expectedEvents := []string{
+		normalEventBuilder(successInjectedBindResultReason).msg(successInjectedBindResultMessage).String(),
+		normalEventBuilder(successRotatedCredentialsReason).String(),
+	}
+	if err := checkEventPrefixes(events, expectedEvents); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceBindingPreviousCredentials verifies that the credentials
+// replaced by a rotation are unbound only once their grace period has elapsed,
+// and are given up on once the reconciliation retry duration has too.
+func TestReconcileServiceBindingPreviousCredentials(t *testing.T) {
+	cases := []struct {
+		name                string
+		unbindIn            time.Duration
+		unbindError         error
+		expectUnbind        bool
+		expectCleared       bool
+		expectError         bool
+		expectEventPrefixes []string
+	}{
+		{
+			name:     "grace period not elapsed",
+			unbindIn: time.Hour,
+		},
+		{
+			name:                "grace period elapsed",
+			unbindIn:            -time.Minute,
+			expectUnbind:        true,
+			expectCleared:       true,
+			expectEventPrefixes: []string{normalEventBuilder(successUnboundPreviousReason).String()},
+		},
+		{
+			name:                "unbind failure",
+			unbindIn:            -time.Minute,
+			unbindError:         errors.New("fake unbind failure"),
+			expectUnbind:        true,
+			expectError:         true,
+			expectEventPrefixes: []string{warningEventBuilder(errorUnbindPreviousCredentialsReason).String()},
+		},
+		{
+			name:          "unbind failure after retry duration",
+			unbindIn:      -8 * 24 * time.Hour,
+			unbindError:   errors.New("fake unbind failure"),
+			expectUnbind:  true,
+			expectCleared: true,
+			expectEventPrefixes: []string{
+				warningEventBuilder(errorUnbindPreviousCredentialsReason).String(),
+				warningEventBuilder(errorUnbindPreviousCredentialsTimeout).String(),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+			UnbindReaction: &fakeosb.UnbindReaction{
+				Response: &osb.UnbindResponse{},
+				Error:    tc.unbindError,
+			},
+		})
+
+		sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+		sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+		sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+		sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithRefs())
+
+		binding := getTestServiceBinding()
+		binding.Status.ReconciledGeneration = binding.Generation
+		binding.Status.CurrentExternalID = testServiceBindingGUID
+		binding.Status.PreviousExternalID = "old-binding-id"
+		unbindTime := metav1.NewTime(time.Now().Add(tc.unbindIn))
+		binding.Status.PreviousCredentialsUnbindTime = &unbindTime
+
+		err := reconcileServiceBinding(t, testController, binding)
+		if tc.expectError != (err != nil) {
+			t.Errorf("%v: unexpected error state; error: %v", tc.name, err)
+			continue
+		}
+
+		brokerActions := fakeClusterServiceBrokerClient.Actions()
+		if !tc.expectUnbind {
+			assertNumberOfClusterServiceBrokerActions(t, brokerActions, 0)
+		} else {
+			assertNumberOfClusterServiceBrokerActions(t, brokerActions, 1)
+			assertUnbind(t, brokerActions[0], &osb.UnbindRequest{
+				BindingID:  "old-binding-id",
+				InstanceID: testServiceInstanceGUID,
+				ServiceID:  testClusterServiceClassGUID,
+				PlanID:     testClusterServicePlanGUID,
+			})
+		}
+
+		actions := fakeCatalogClient.Actions()
+		if !tc.expectCleared {
+			assertNumberOfActions(t, actions, 0)
+		} else {
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			if updatedServiceBinding.Status.PreviousExternalID != "" || updatedServiceBinding.Status.PreviousCredentialsUnbindTime != nil {
+				t.Errorf("%v: expected the previous credentials to be cleared from the status, got %q and %v", tc.name,
+					updatedServiceBinding.Status.PreviousExternalID, updatedServiceBinding.Status.PreviousCredentialsUnbindTime)
+			}
+		}
+
+		events := getRecordedEvents(testController)
+		if len(tc.expectEventPrefixes) == 0 {
+			if len(events) != 0 {
+				t.Errorf("%v: expected no events, got %v", tc.name, events)
+			}
+		} else if err := checkEventPrefixes(events, tc.expectEventPrefixes); err != nil {
+			t.Errorf("%v: %v", tc.name, err)
+		}
+	}
+}
+
+// TestReconcileServiceBindingPreviousCredentialsAsynchronousUnbind verifies
+// that the credentials replaced by a rotation may be unbound asynchronously,
+// and that the operation is then polled for.
+func TestReconcileServiceBindingPreviousCredentialsAsynchronousUnbind(t *testing.T) {
+	key := osb.OperationKey(testOperation)
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UnbindReaction: &fakeosb.UnbindReaction{
+			Response: &osb.UnbindResponse{
+				Async:        true,
+				OperationKey: &key,
+			},
+		},
+	})
+
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.AsyncBindingOperations))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.AsyncBindingOperations))
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestBindingRetrievableClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithRefs())
+
+	binding := getTestServiceBinding()
+	binding.Status.ReconciledGeneration = binding.Generation
+	binding.Status.CurrentExternalID = testServiceBindingGUID
+	binding.Status.PreviousExternalID = "old-binding-id"
+	unbindTime := metav1.NewTime(time.Now().Add(-time.Minute))
+	binding.Status.PreviousCredentialsUnbindTime = &unbindTime
+	bindingKey := binding.Namespace + "/" + binding.Name
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if testController.bindingPollingQueue.NumRequeues(bindingKey) != 1 {
+		t.Fatalf("Expected polling queue to have a record of seeing test binding once")
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfClusterServiceBrokerActions(t, brokerActions, 1)
+	assertUnbind(t, brokerActions[0], &osb.UnbindRequest{
+		BindingID:         "old-binding-id",
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            testClusterServicePlanGUID,
+		AcceptsIncomplete: true,
+	})
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	if e, a := "old-binding-id", updatedServiceBinding.Status.PreviousExternalID; e != a {
+		t.Fatalf("Unexpected previous external ID: %s", expectedGot(e, a))
+	}
+	if operation := updatedServiceBinding.Status.PreviousCredentialsUnbindOperation; operation == nil || *operation != testOperation {
+		t.Fatalf("Unexpected unbind operation of the previous credentials: %v", operation)
+	}
+
+	events := getRecordedEvents(testController)
+	if err := checkEventPrefixes(events, []string{normalEventBuilder(asyncUnbindingPreviousReason).String()}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestPollPreviousServiceBindingCredentials verifies the handling of the
+// states of the asynchronous unbind of the credentials replaced by a
+// rotation.
+func TestPollPreviousServiceBindingCredentials(t *testing.T) {
+	goneError := osb.HTTPStatusCodeError{
+		StatusCode: http.StatusGone,
+	}
+
+	cases := []struct {
+		name                 string
+		unbindIn             time.Duration
+		pollReaction         *fakeosb.PollBindingLastOperationReaction
+		expectCleared        bool
+		expectOperationReset bool
+		expectError          bool
+		shouldFinishPolling  bool
+		expectEventPrefixes  []string
+	}{
+		{
+			name:     "in progress",
+			unbindIn: -time.Minute,
+			pollReaction: &fakeosb.PollBindingLastOperationReaction{
+				Response: &osb.LastOperationResponse{State: osb.StateInProgress},
+			},
+		},
+		{
+			name:     "in progress after retry duration",
+			unbindIn: -8 * 24 * time.Hour,
+			pollReaction: &fakeosb.PollBindingLastOperationReaction{
+				Response: &osb.LastOperationResponse{State: osb.StateInProgress},
+			},
+			expectCleared:       true,
+			shouldFinishPolling: true,
+			expectEventPrefixes: []string{warningEventBuilder(errorUnbindPreviousCredentialsTimeout).String()},
+		},
+		{
+			name:     "succeeded",
+			unbindIn: -time.Minute,
+			pollReaction: &fakeosb.PollBindingLastOperationReaction{
+				Response: &osb.LastOperationResponse{State: osb.StateSucceeded},
+			},
+			expectCleared:       true,
+			shouldFinishPolling: true,
+			expectEventPrefixes: []string{normalEventBuilder(successUnboundPreviousReason).String()},
+		},
+		{
+			name:     "410 Gone",
+			unbindIn: -time.Minute,
+			pollReaction: &fakeosb.PollBindingLastOperationReaction{
+				Error: goneError,
+			},
+			expectCleared:       true,
+			shouldFinishPolling: true,
+			expectEventPrefixes: []string{normalEventBuilder(successUnboundPreviousReason).String()},
+		},
+		{
+			name:     "failed",
+			unbindIn: -time.Minute,
+			pollReaction: &fakeosb.PollBindingLastOperationReaction{
+				Response: &osb.LastOperationResponse{State: osb.StateFailed},
+			},
+			expectOperationReset: true,
+			expectError:          true,
+			shouldFinishPolling:  true,
+			expectEventPrefixes:  []string{warningEventBuilder(errorUnbindPreviousCredentialsReason).String()},
+		},
+	}
+
+	for _, tc := range cases {
+		_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+			PollBindingLastOperationReaction: tc.pollReaction,
+		})
+
+		sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+		sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestBindingRetrievableClusterServiceClass())
+		sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+		sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithRefs())
+
+		binding := getTestServiceBinding()
+		binding.Status.ReconciledGeneration = binding.Generation
+		binding.Status.CurrentExternalID = testServiceBindingGUID
+		binding.Status.PreviousExternalID = "old-binding-id"
+		unbindTime := metav1.NewTime(time.Now().Add(tc.unbindIn))
+		binding.Status.PreviousCredentialsUnbindTime = &unbindTime
+		binding.Status.PreviousCredentialsUnbindOperation = strPtr(testOperation)
+		bindingKey := binding.Namespace + "/" + binding.Name
+
+		err := reconcileServiceBinding(t, testController, binding)
+		if tc.expectError != (err != nil) {
+			t.Errorf("%v: unexpected error state; error: %v", tc.name, err)
+			continue
+		}
+
+		if tc.shouldFinishPolling && testController.bindingPollingQueue.NumRequeues(bindingKey) != 0 {
+			t.Errorf("%v: expected binding to not be in polling queue", tc.name)
+		} else if !tc.shouldFinishPolling && testController.bindingPollingQueue.NumRequeues(bindingKey) != 1 {
+			t.Errorf("%v: expected binding to be in polling queue", tc.name)
+		}
+
+		brokerActions := fakeClusterServiceBrokerClient.Actions()
+		assertNumberOfClusterServiceBrokerActions(t, brokerActions, 1)
+		operationKey := osb.OperationKey(testOperation)
+		assertPollBindingLastOperation(t, brokerActions[0], &osb.BindingLastOperationRequest{
+			InstanceID:   testServiceInstanceGUID,
+			BindingID:    "old-binding-id",
+			ServiceID:    strPtr(testClusterServiceClassGUID),
+			PlanID:       strPtr(testClusterServicePlanGUID),
+			OperationKey: &operationKey,
+		})
+
+		actions := fakeCatalogClient.Actions()
+		switch {
+		case tc.expectCleared:
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			if updatedServiceBinding.Status.PreviousExternalID != "" || updatedServiceBinding.Status.PreviousCredentialsUnbindOperation != nil {
+				t.Errorf("%v: expected the previous credentials to be cleared from the status, got %q and %v", tc.name,
+					updatedServiceBinding.Status.PreviousExternalID, updatedServiceBinding.Status.PreviousCredentialsUnbindOperation)
+			}
+		case tc.expectOperationReset:
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			if e, a := "old-binding-id", updatedServiceBinding.Status.PreviousExternalID; e != a {
+				t.Errorf("%v: unexpected previous external ID: %s", tc.name, expectedGot(e, a))
+			}
+			if updatedServiceBinding.Status.PreviousCredentialsUnbindOperation != nil {
+				t.Errorf("%v: expected the unbind operation of the previous credentials to be reset", tc.name)
+			}
+		default:
+			assertNumberOfActions(t, actions, 0)
+		}
+
+		events := getRecordedEvents(testController)
+		if len(tc.expectEventPrefixes) == 0 {
+			if len(events) != 0 {
+				t.Errorf("%v: expected no events, got %v", tc.name, events)
+			}
+		} else if err := checkEventPrefixes(events, tc.expectEventPrefixes); err != nil {
+			t.Errorf("%v: %v", tc.name, err)
+		}
+	}
+}
+
+// TestReconcileServiceBindingRotationOrphanMitigation verifies that mitigating
+// the orphan left by a failed rotation only unbinds the new credentials, and
+// keeps delivering the current ones.
+func TestReconcileServiceBindingRotationOrphanMitigation(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UnbindReaction: &fakeosb.UnbindReaction{
+			Response: &osb.UnbindResponse{},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithRefs())
+
+	binding := getTestServiceBinding()
+	binding.Spec.SecretName = testServiceBindingSecretName
+	binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationBind
+	binding.Status.OrphanMitigationInProgress = true
+	binding.Status.ExternalProperties = &v1beta1.ServiceBindingPropertiesState{}
+	binding.Status.CurrentExternalID = "current-binding-id"
+	binding.Status.PreviousExternalID = "old-binding-id"
+	unbindTime := metav1.NewTime(time.Now().Add(time.Hour))
+	binding.Status.PreviousCredentialsUnbindTime = &unbindTime
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfClusterServiceBrokerActions(t, brokerActions, 1)
+	assertUnbind(t, brokerActions[0], &osb.UnbindRequest{
+		BindingID:  testServiceBindingGUID,
+		InstanceID: testServiceInstanceGUID,
+		ServiceID:  testClusterServiceClassGUID,
+		PlanID:     testClusterServicePlanGUID,
+	})
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingCondition(t, updatedServiceBinding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionFalse, successOrphanMitigationReason)
+	assertServiceBindingOrphanMitigationSet(t, updatedServiceBinding, false)
+	if e, a := v1beta1.ServiceBindingUnbindStatusRequired, updatedServiceBinding.Status.UnbindStatus; e != a {
+		t.Fatalf("Unexpected unbind status: %s", expectedGot(e, a))
+	}
+	if updatedServiceBinding.Status.ExternalProperties == nil {
+		t.Fatal("expected the external properties of the current credentials to be kept")
+	}
+	if e, a := "current-binding-id", updatedServiceBinding.Status.CurrentExternalID; e != a {
+		t.Fatalf("Unexpected current external ID: %s", expectedGot(e, a))
+	}
+	if e, a := "old-binding-id", updatedServiceBinding.Status.PreviousExternalID; e != a {
+		t.Fatalf("Unexpected previous external ID: %s", expectedGot(e, a))
+	}
+	if updatedServiceBinding.Status.PreviousCredentialsUnbindTime == nil {
+		t.Fatal("expected the unbind time of the previous credentials to be kept")
+	}
+}
+
+// TestReconcileServiceBindingDeleteDuringRotation verifies that deleting a
+// binding whose rotation is in progress unbinds the credentials being
+// replaced along with the new ones.
+func TestReconcileServiceBindingDeleteDuringRotation(t *testing.T) {
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		UnbindReaction: &fakeosb.UnbindReaction{
+			Response: &osb.UnbindResponse{},
+		},
+	})
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithRefs())
+
+	binding := getTestServiceBinding()
+	binding.DeletionTimestamp = &metav1.Time{}
+	binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationUnbind
+	binding.Status.CurrentExternalID = "current-binding-id"
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfClusterServiceBrokerActions(t, brokerActions, 2)
+	for i, bindingID := range []string{"current-binding-id", testServiceBindingGUID} {
+		assertUnbind(t, brokerActions[i], &osb.UnbindRequest{
+			BindingID:  bindingID,
+			InstanceID: testServiceInstanceGUID,
+			ServiceID:  testClusterServiceClassGUID,
+			PlanID:     testClusterServicePlanGUID,
+		})
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	if e, a := v1beta1.ServiceBindingUnbindStatusSucceeded, updatedServiceBinding.Status.UnbindStatus; e != a {
+		t.Fatalf("Unexpected unbind status: %s", expectedGot(e, a))
+	}
+}
+
+// addGetSecretsReaction adds a reaction returning the given secrets by name,
+// and a not found error for any other secret.
+func addGetSecretsReaction(fakeKubeClient *clientgofake.Clientset, secrets ...*corev1.Secret) {
+	fakeKubeClient.AddReactor("get", "secrets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		name := action.(clientgotesting.GetAction).GetName()
+		for _, secret := range secrets {
+			if secret.Name == name {
+				return true, secret.DeepCopy(), nil
+			}
+		}
+		return true, nil, apierrors.NewNotFound(action.GetResource().GroupResource(), name)
+	})
+}
+
+// getSecretWriteActions returns the create and update actions on secrets
+// among the given actions.
+func getSecretWriteActions(actions []clientgotesting.Action) []clientgotesting.Action {
+	var writes []clientgotesting.Action
+	for _, action := range actions {
+		if action.GetResource().Resource == "secrets" && (action.GetVerb() == "create" || action.GetVerb() == "update") {
+			writes = append(writes, action)
+		}
+	}
+	return writes
+}
+
+func getTestServiceBindingWithAddKeysFromTransform() *v1beta1.ServiceBinding {
+	binding := getTestServiceBinding()
+	binding.Spec.SecretName = testServiceBindingSecretName
+	binding.Spec.SecretTransforms = []v1beta1.SecretTransform{
+		{
+			AddKeysFrom: &v1beta1.AddKeysFromTransform{
+				SecretRef: &v1beta1.ObjectReference{
+					Namespace: testNamespace,
+					Name:      "source-secret",
+				},
+			},
+		},
+	}
+	return binding
+}
+
+// TestReconcileServiceBindingWaitingForSecretTransformSource verifies that a
+// binding whose AddKeysFrom transform references a Secret that does not exist
+// completes its bind operation, keeps the credentials returned by the broker,
+// and waits for the Secret instead of failing.
+func TestReconcileServiceBindingWaitingForSecretTransformSource(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.SecretTransformSync))
+	if err != nil {
+		t.Fatalf("Failed to enable SecretTransformSync feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.SecretTransformSync))
+
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		BindReaction: &fakeosb.BindReaction{
+			Response: &osb.BindResponse{
+				Credentials: map[string]interface{}{
+					"a": "b",
+				},
+			},
+		},
+	})
+
+	addGetNamespaceReaction(fakeKubeClient)
+	addGetSecretNotFoundReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	binding := getTestServiceBindingWithAddKeysFromTransform()
+	binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationBind
+	startTime := metav1.Now()
+	binding.Status.OperationStartTime = &startTime
+	binding.Status.InProgressProperties = &v1beta1.ServiceBindingPropertiesState{}
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("a binding waiting for a secret should not fail: %v", err)
+	}
+
+	assertNumberOfClusterServiceBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+
+	writes := getSecretWriteActions(fakeKubeClient.Actions())
+	assertNumberOfActions(t, writes, 1)
+	assertActionEquals(t, writes[0], "create", "secrets")
+	stored := writes[0].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+	if e, a := serviceBindingCredentialsSecretName(binding), stored.Name; e != a {
+		t.Fatalf("Unexpected name of the stored credentials secret: %s", expectedGot(e, a))
+	}
+	if e, a := `{"a":"b"}`, string(stored.Data[bindingCredentialsSecretKey]); e != a {
+		t.Fatalf("Unexpected stored credentials: %s", expectedGot(e, a))
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingCurrentOperationClear(t, updatedServiceBinding)
+	assertServiceBindingReadyCondition(t, updatedServiceBinding, v1beta1.ConditionFalse, waitingForSecretTransformSourceReason)
+	if e, a := binding.Generation, updatedServiceBinding.Status.ReconciledGeneration; e != a {
+		t.Fatalf("Unexpected reconciled generation: %s", expectedGot(e, a))
+	}
+
+	events := getRecordedEvents(testController)
+	expectedEvent := normalEventBuilder(waitingForSecretTransformSourceReason)
+	if err := checkEventPrefixes(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceBindingWaitingForSecretTransformSourceWithoutSync
+// verifies that the credentials of a binding are not kept aside when
+// SecretTransformSync is disabled, and that a binding waiting for the Secret
+// referenced by its AddKeysFrom transform is left unreconciled, to be bound
+// again.
+func TestReconcileServiceBindingWaitingForSecretTransformSourceWithoutSync(t *testing.T) {
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		BindReaction: &fakeosb.BindReaction{
+			Response: &osb.BindResponse{
+				Credentials: map[string]interface{}{
+					"a": "b",
+				},
+			},
+		},
+	})
+
+	addGetNamespaceReaction(fakeKubeClient)
+	addGetSecretNotFoundReaction(fakeKubeClient)
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+	binding := getTestServiceBindingWithAddKeysFromTransform()
+	binding.Status.CurrentOperation = v1beta1.ServiceBindingOperationBind
+	startTime := metav1.Now()
+	binding.Status.OperationStartTime = &startTime
+	binding.Status.InProgressProperties = &v1beta1.ServiceBindingPropertiesState{}
+
+	if err := reconcileServiceBinding(t, testController, binding); err != nil {
+		t.Fatalf("a binding waiting for a secret should not fail: %v", err)
+	}
+
+	assertNumberOfClusterServiceBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+	assertNumberOfActions(t, getSecretWriteActions(fakeKubeClient.Actions()), 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+	assertServiceBindingCurrentOperationClear(t, updatedServiceBinding)
+	assertServiceBindingReadyCondition(t, updatedServiceBinding, v1beta1.ConditionFalse, waitingForSecretTransformSourceReason)
+	if e, a := binding.Status.ReconciledGeneration, updatedServiceBinding.Status.ReconciledGeneration; e != a {
+		t.Fatalf("Unexpected reconciled generation: %s", expectedGot(e, a))
+	}
+}
+
+// TestReconcileServiceBindingSecretTransforms verifies that the transforms of
+// a reconciled binding are applied again to the stored credentials when the
+// Secret referenced by its AddKeysFrom transform appears, changes or goes away.
+func TestReconcileServiceBindingSecretTransforms(t *testing.T) {
+	cases := []struct {
+		name              string
+		readyStatus       v1beta1.ConditionStatus
+		readyReason       string
+		sourceData        map[string][]byte
+		targetData        map[string][]byte
+		expectTargetWrite string
+		expectReadyStatus v1beta1.ConditionStatus
+		expectReadyReason string
+	}{
+		{
+			name:              "source created",
+			readyStatus:       v1beta1.ConditionFalse,
+			readyReason:       waitingForSecretTransformSourceReason,
+			sourceData:        map[string][]byte{"extra": []byte("new")},
+			expectTargetWrite: "create",
+			expectReadyStatus: v1beta1.ConditionTrue,
+			expectReadyReason: successInjectedBindResultReason,
+		},
+		{
+			name:              "source changed",
+			readyStatus:       v1beta1.ConditionTrue,
+			readyReason:       successInjectedBindResultReason,
+			sourceData:        map[string][]byte{"extra": []byte("new")},
+			targetData:        map[string][]byte{"a": []byte("b"), "extra": []byte("old")},
+			expectTargetWrite: "update",
+		},
+		{
+			name:        "source unchanged",
+			readyStatus: v1beta1.ConditionTrue,
+			readyReason: successInjectedBindResultReason,
+			sourceData:  map[string][]byte{"extra": []byte("new")},
+			targetData:  map[string][]byte{"a": []byte("b"), "extra": []byte("new")},
+		},
+		{
+			name:              "source deleted",
+			readyStatus:       v1beta1.ConditionTrue,
+			readyReason:       successInjectedBindResultReason,
+			targetData:        map[string][]byte{"a": []byte("b"), "extra": []byte("old")},
+			expectReadyStatus: v1beta1.ConditionFalse,
+			expectReadyReason: waitingForSecretTransformSourceReason,
+		},
+	}
+
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.SecretTransformSync))
+	if err != nil {
+		t.Fatalf("Failed to enable SecretTransformSync feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.SecretTransformSync))
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, noFakeActions())
+
+			binding := getTestServiceBindingWithAddKeysFromTransform()
+			binding.Status.ReconciledGeneration = binding.Generation
+			setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, tc.readyStatus, tc.readyReason, "")
+
+			ownerRefs := []metav1.OwnerReference{*metav1.NewControllerRef(binding, bindingControllerKind)}
+			secrets := []*corev1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{Name: serviceBindingCredentialsSecretName(binding), OwnerReferences: ownerRefs},
+					Data:       map[string][]byte{bindingCredentialsSecretKey: []byte(`{"a":"b"}`)},
+				},
+			}
+			if tc.sourceData != nil {
+				secrets = append(secrets, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "source-secret"},
+					Data:       tc.sourceData,
+				})
+			}
+			if tc.targetData != nil {
+				secrets = append(secrets, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: testServiceBindingSecretName, OwnerReferences: ownerRefs},
+					Data:       tc.targetData,
+				})
+			}
+			addGetSecretsReaction(fakeKubeClient, secrets...)
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfClusterServiceBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			writes := getSecretWriteActions(fakeKubeClient.Actions())
+			if tc.expectTargetWrite == "" {
+				assertNumberOfActions(t, writes, 0)
+			} else {
+				assertNumberOfActions(t, writes, 1)
+				assertActionEquals(t, writes[0], tc.expectTargetWrite, "secrets")
+				secret := writes[0].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+				expectedData := map[string][]byte{"a": []byte("b"), "extra": []byte("new")}
+				if !reflect.DeepEqual(expectedData, secret.Data) {
+					t.Fatalf("Unexpected secret data: %v", diff.ObjectReflectDiff(expectedData, secret.Data))
+				}
+			}
+
+			actions := fakeCatalogClient.Actions()
+			if tc.expectReadyReason == "" {
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			assertServiceBindingReadyCondition(t, updatedServiceBinding, tc.expectReadyStatus, tc.expectReadyReason)
+		})
+	}
+}
+
+// TestReconcileServiceBindingCredentialsSync verifies that the credentials of
+// reconciled bindings of retrievable classes are fetched from the broker once
+// the sync interval has elapsed, and that their Secret is repaired when it has
+// drifted from them.
+func TestReconcileServiceBindingCredentialsSync(t *testing.T) {
+	const syncInterval = 10 * time.Minute
+
+	cases := []struct {
+		name              string
+		notRetrievable    bool
+		lastSyncedAgo     time.Duration
+		drifted           bool
+		getBindingError   error
+		secretData        map[string][]byte
+		expectGetBinding  bool
+		expectSecretWrite string
+		expectStatus      bool
+		expectDrifted     v1beta1.ConditionStatus
+		expectEventPrefix string
+	}{
+		{
+			name:           "class not retrievable",
+			notRetrievable: true,
+			secretData:     map[string][]byte{"a": []byte("b")},
+		},
+		{
+			name:          "sync interval not elapsed",
+			lastSyncedAgo: time.Minute,
+			secretData:    map[string][]byte{"a": []byte("b")},
+		},
+		{
+			name:             "secret in sync",
+			lastSyncedAgo:    time.Hour,
+			secretData:       map[string][]byte{"a": []byte("b")},
+			expectGetBinding: true,
+			expectStatus:     true,
+		},
+		{
+			name:              "secret edited",
+			secretData:        map[string][]byte{"a": []byte("edited")},
+			expectGetBinding:  true,
+			expectSecretWrite: "update",
+			expectStatus:      true,
+			expectDrifted:     v1beta1.ConditionTrue,
+			expectEventPrefix: warningEventBuilder(credentialsDriftedReason).String(),
+		},
+		{
+			name:              "secret deleted",
+			expectGetBinding:  true,
+			expectSecretWrite: "create",
+			expectStatus:      true,
+			expectDrifted:     v1beta1.ConditionTrue,
+			expectEventPrefix: warningEventBuilder(credentialsDriftedReason).String(),
+		},
+		{
+			name:             "drift resolved",
+			drifted:          true,
+			secretData:       map[string][]byte{"a": []byte("b")},
+			expectGetBinding: true,
+			expectStatus:     true,
+			expectDrifted:    v1beta1.ConditionFalse,
+		},
+		{
+			name:              "get binding failure",
+			getBindingError:   errors.New("fake get binding failure"),
+			secretData:        map[string][]byte{"a": []byte("edited")},
+			expectGetBinding:  true,
+			expectStatus:      true,
+			expectEventPrefix: warningEventBuilder(errorSyncingCredentialsReason).String(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+				GetBindingReaction: &fakeosb.GetBindingReaction{
+					Response: &osb.GetBindingResponse{
+						Credentials: map[string]interface{}{
+							"a": "b",
+						},
+					},
+					Error: tc.getBindingError,
+				},
+			})
+			testController.bindingCredentialsSyncInterval = syncInterval
+
+			serviceClass := getTestClusterServiceClass()
+			serviceClass.Spec.BindingRetrievable = !tc.notRetrievable
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(serviceClass)
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithRefs())
+
+			binding := getTestServiceBinding()
+			binding.Spec.SecretName = testServiceBindingSecretName
+			binding.Status.ReconciledGeneration = binding.Generation
+			setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue, successInjectedBindResultReason, "")
+			if tc.drifted {
+				setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionCredentialsDrifted, v1beta1.ConditionTrue, credentialsDriftedReason, "")
+			}
+			if tc.lastSyncedAgo != 0 {
+				lastSyncTime := metav1.NewTime(time.Now().Add(-tc.lastSyncedAgo))
+				binding.Status.LastCredentialsSyncTime = &lastSyncTime
+			}
+
+			var secrets []*corev1.Secret
+			if tc.secretData != nil {
+				secrets = append(secrets, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            testServiceBindingSecretName,
+						Namespace:       testNamespace,
+						OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(binding, bindingControllerKind)},
+					},
+					Data: tc.secretData,
+				})
+			}
+			addGetSecretsReaction(fakeKubeClient, secrets...)
+			setTestSecretLister(testController, secrets...)
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			if !tc.expectGetBinding {
+				assertNumberOfClusterServiceBrokerActions(t, brokerActions, 0)
+			} else {
+				assertNumberOfClusterServiceBrokerActions(t, brokerActions, 1)
+				assertGetBinding(t, brokerActions[0], &osb.GetBindingRequest{
+					InstanceID: testServiceInstanceGUID,
+					BindingID:  testServiceBindingGUID,
+				})
+			}
+
+			writes := getSecretWriteActions(fakeKubeClient.Actions())
+			if tc.expectSecretWrite == "" {
+				assertNumberOfActions(t, writes, 0)
+			} else {
+				assertNumberOfActions(t, writes, 1)
+				assertActionEquals(t, writes[0], tc.expectSecretWrite, "secrets")
+				secret := writes[0].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+				expectedData := map[string][]byte{"a": []byte("b")}
+				if !reflect.DeepEqual(expectedData, secret.Data) {
+					t.Fatalf("Unexpected secret data: %v", diff.ObjectReflectDiff(expectedData, secret.Data))
+				}
+			}
+
+			actions := fakeCatalogClient.Actions()
+			if !tc.expectStatus {
+				assertNumberOfActions(t, actions, 0)
+			} else {
+				assertNumberOfActions(t, actions, 1)
+				updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+				assertServiceBindingReadyCondition(t, updatedServiceBinding, v1beta1.ConditionTrue)
+				if updatedServiceBinding.Status.LastCredentialsSyncTime == nil {
+					t.Fatal("expected the last credentials sync time to be set")
+				}
+				var drifted v1beta1.ConditionStatus
+				for _, cond := range updatedServiceBinding.Status.Conditions {
+					if cond.Type == v1beta1.ServiceBindingConditionCredentialsDrifted {
+						drifted = cond.Status
+					}
+				}
+				if e, a := tc.expectDrifted, drifted; e != a {
+					t.Fatalf("Unexpected status of the CredentialsDrifted condition: %s", expectedGot(e, a))
+				}
+			}
+
+			events := getRecordedEvents(testController)
+			if tc.expectEventPrefix == "" {
+				if len(events) != 0 {
+					t.Fatalf("expected no events, got %v", events)
+				}
+			} else if err := checkEventPrefixes(events, []string{tc.expectEventPrefix}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestServiceBindingTargetsDrifted verifies that the copies of the Secret of a
+// binding and its ConfigMap are checked for drift along with its Secret.
+func TestServiceBindingTargetsDrifted(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.BindingTargets))
+	if err != nil {
+		t.Fatalf("Failed to enable BindingTargets feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.BindingTargets))
+
+	binding := getTestServiceBindingWithTargets()
+	credentials := map[string]interface{}{"host": "db", "port": "5432"}
+	secretData := map[string][]byte{"host": []byte("db"), "port": []byte("5432")}
+	newSecret := func(namespace string, data map[string][]byte) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: testServiceBindingSecretName, Namespace: namespace},
+			Data:       data,
+		}
+	}
+	newConfigMap := func(data map[string]string) *corev1.ConfigMap {
+		return &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: binding.Name, Namespace: binding.Namespace},
+			Data:       data,
+		}
+	}
+
+	cases := []struct {
+		name            string
+		secrets         []*corev1.Secret
+		configMap       *corev1.ConfigMap
+		expectedDrifted []string
+	}{
+		{
+			name: "in sync",
+			secrets: []*corev1.Secret{
+				newSecret(testNamespace, secretData),
+				newSecret("ns-a", secretData),
+				newSecret("ns-b", secretData),
+			},
+			configMap: newConfigMap(map[string]string{"host": "db", "port": "5432"}),
+		},
+		{
+			name: "copy deleted and copy edited",
+			secrets: []*corev1.Secret{
+				newSecret(testNamespace, secretData),
+				newSecret("ns-b", map[string][]byte{"host": []byte("edited")}),
+			},
+			configMap: newConfigMap(map[string]string{"host": "db", "port": "5432"}),
+			expectedDrifted: []string{
+				`Secret "ns-a/` + testServiceBindingSecretName + `"`,
+				`Secret "ns-b/` + testServiceBindingSecretName + `"`,
+			},
+		},
+		{
+			name: "configmap edited",
+			secrets: []*corev1.Secret{
+				newSecret(testNamespace, secretData),
+				newSecret("ns-a", secretData),
+				newSecret("ns-b", secretData),
+			},
+			configMap:       newConfigMap(map[string]string{"host": "edited"}),
+			expectedDrifted: []string{`ConfigMap "` + testNamespace + `/` + binding.Name + `"`},
+		},
+		{
+			name: "configmap deleted",
+			secrets: []*corev1.Secret{
+				newSecret(testNamespace, secretData),
+				newSecret("ns-a", secretData),
+				newSecret("ns-b", secretData),
+			},
+			expectedDrifted: []string{`ConfigMap "` + testNamespace + `/` + binding.Name + `"`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, _, testController, _ := newTestController(t, noFakeActions())
+			setTestSecretLister(testController, tc.secrets...)
+			indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+			if tc.configMap != nil {
+				indexer.Add(tc.configMap)
+			}
+			testController.configMapLister = corelisters.NewConfigMapLister(indexer)
+
+			drifted, err := testController.serviceBindingTargetsDrifted(binding, credentials)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if e, a := tc.expectedDrifted, drifted; !reflect.DeepEqual(e, a) {
+				t.Fatalf("Unexpected drifted targets: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
+
+// TestReconcileServiceBindingDeliveryChange verifies that changing only how
+// the credentials of a bound binding are delivered injects the credentials
+// kept aside again without binding, and deletes the Secret they were
+// delivered in under its previous name. When no credentials are kept aside, a
+// bind operation is started.
+func TestReconcileServiceBindingDeliveryChange(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.SecretTransformSync))
+	if err != nil {
+		t.Fatalf("Failed to enable SecretTransformSync feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.SecretTransformSync))
+
+	cases := []struct {
+		name              string
+		templateKey       bool
+		storedCredentials bool
+		expectBindStarted bool
+	}{
+		{
+			name:              "credentials kept aside",
+			templateKey:       true,
+			storedCredentials: true,
+		},
+		{
+			name:              "credentials not kept aside yet",
+			templateKey:       true,
+			expectBindStarted: true,
+		},
+		{
+			name:              "no transform depending on other secrets or templates",
+			expectBindStarted: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(getTestServiceInstanceWithStatus(v1beta1.ConditionTrue))
+
+			binding := getTestServiceBinding()
+			binding.Generation = 2
+			binding.Spec.SecretName = "new-secret"
+			if tc.templateKey {
+				binding.Spec.SecretTransforms = []v1beta1.SecretTransform{
+					{
+						TemplateKey: &v1beta1.TemplateKeyTransform{
+							Key:      "url",
+							Template: "db://{{.a}}",
+						},
+					},
+				}
+			}
+			binding.Status.ReconciledGeneration = 1
+			binding.Status.ExternalProperties = &v1beta1.ServiceBindingPropertiesState{}
+			binding.Status.CurrentExternalID = binding.Spec.ExternalID
+			binding.Status.DeliveredSecretName = "old-secret"
+			setServiceBindingCondition(binding, v1beta1.ServiceBindingConditionReady, v1beta1.ConditionTrue, successInjectedBindResultReason, "")
+
+			controllerRef := *metav1.NewControllerRef(binding, bindingControllerKind)
+			secrets := []*corev1.Secret{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            "old-secret",
+						Namespace:       binding.Namespace,
+						OwnerReferences: []metav1.OwnerReference{controllerRef},
+					},
+				},
+			}
+			if tc.storedCredentials {
+				secrets = append(secrets, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:            serviceBindingCredentialsSecretName(binding),
+						Namespace:       binding.Namespace,
+						OwnerReferences: []metav1.OwnerReference{controllerRef},
+					},
+					Data: map[string][]byte{bindingCredentialsSecretKey: []byte(`{"a":"b"}`)},
+				})
+			}
+			addGetNamespaceReaction(fakeKubeClient)
+			addGetSecretsReaction(fakeKubeClient, secrets...)
+
+			if err := reconcileServiceBinding(t, testController, binding); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfClusterServiceBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			writes := getKubeWriteActions(fakeKubeClient.Actions())
+			if tc.expectBindStarted {
+				assertNumberOfActions(t, writes, 0)
+				assertServiceBindingBindInProgressIsTheOnlyCatalogAction(t, fakeCatalogClient, binding)
+				return
+			}
+
+			// The credentials kept aside are unchanged, so they are not
+			// written again.
+			assertNumberOfActions(t, writes, 2)
+			assertActionEquals(t, writes[0], "create", "secrets")
+			secret := writes[0].(clientgotesting.CreateAction).GetObject().(*corev1.Secret)
+			if e, a := "new-secret", secret.Name; e != a {
+				t.Fatalf("Unexpected name of created Secret: %s", expectedGot(e, a))
+			}
+			expectedData := map[string][]byte{"a": []byte("b"), "url": []byte("db://b")}
+			if !reflect.DeepEqual(expectedData, secret.Data) {
+				t.Fatalf("Unexpected secret data: %v", diff.ObjectReflectDiff(expectedData, secret.Data))
+			}
+			assertActionEquals(t, writes[1], "delete", "secrets")
+			if e, a := "old-secret", writes[1].(clientgotesting.DeleteAction).GetName(); e != a {
+				t.Fatalf("Unexpected name of deleted Secret: %s", expectedGot(e, a))
+			}
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceBinding := assertUpdateStatus(t, actions[0], binding).(*v1beta1.ServiceBinding)
+			assertServiceBindingOperationSuccess(t, updatedServiceBinding, v1beta1.ServiceBindingOperationBind, binding)
+			if e, a := "new-secret", updatedServiceBinding.Status.DeliveredSecretName; e != a {
+				t.Fatalf("Unexpected delivered secret name: %s", expectedGot(e, a))
+			}
+		})
+	}
+}
@@ -77,10 +77,24 @@ func (c *controller) brokerAdd(obj interface{}) {
 }
 
 func (c *controller) brokerUpdate(oldObj, newObj interface{}) {
+	oldBroker, oldOK := oldObj.(*v1beta1.ClusterServiceBroker)
+	newBroker, newOK := newObj.(*v1beta1.ClusterServiceBroker)
+	if oldOK && newOK && oldBroker != nil && newBroker != nil && oldBroker.Generation != newBroker.Generation {
+		// The cached client was created from the old spec of the broker.
+		c.brokerClients.Invalidate(brokerKey(oldBroker.ObjectMeta))
+	}
 	c.brokerAdd(newObj)
 }
 
 func (c *controller) brokerDelete(obj interface{}) {
+	if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+		c.brokerClients.Invalidate(key)
+		if c.brokerHealth != nil {
+			c.brokerHealth.Forget(key)
+		}
+		c.brokerLimiter.Forget(key)
+	}
+
 	broker, ok := obj.(*v1beta1.ClusterServiceBroker)
 	if broker == nil || !ok {
 		return
@@ -95,18 +109,24 @@ func (c *controller) brokerDelete(obj interface{}) {
 // ready condition became true, or if the broker's RelistBehavior is set to Manual.
 func shouldReconcileClusterServiceBroker(broker *v1beta1.ClusterServiceBroker, now time.Time) bool {
 	pcb := pretty.NewContextBuilder(pretty.ClusterServiceBroker, "", broker.Name)
-	if broker.Status.ReconciledGeneration != broker.Generation {
+	return shouldReconcileServiceBrokerCommon(pcb, &broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, &broker.Status.CommonServiceBrokerStatus, now)
+}
+
+// shouldReconcileServiceBrokerCommon contains the relist logic shared by
+// ClusterServiceBrokers and namespaced ServiceBrokers.
+func shouldReconcileServiceBrokerCommon(pcb *pretty.ContextBuilder, brokerMeta *metav1.ObjectMeta, brokerSpec *v1beta1.CommonServiceBrokerSpec, brokerStatus *v1beta1.CommonServiceBrokerStatus, now time.Time) bool {
+	if brokerStatus.ReconciledGeneration != brokerMeta.Generation {
 		// If the spec has changed, we should reconcile the broker.
 		return true
 	}
-	if broker.DeletionTimestamp != nil || len(broker.Status.Conditions) == 0 {
+	if brokerMeta.DeletionTimestamp != nil || len(brokerStatus.Conditions) == 0 {
 		// If the deletion timestamp is set or the broker has no status
 		// conditions, we should reconcile it.
 		return true
 	}
 
 	// find the ready condition in the broker's status
-	for _, condition := range broker.Status.Conditions {
+	for _, condition := range brokerStatus.Conditions {
 		if condition.Type == v1beta1.ServiceBrokerConditionReady {
 			// The broker has a ready condition
 
@@ -114,7 +134,7 @@ func shouldReconcileClusterServiceBroker(broker *v1beta1.ClusterServiceBroker, n
 
 				// The broker's ready condition has status true, meaning that
 				// at some point, we successfully listed the broker's catalog.
-				if broker.Spec.RelistBehavior == v1beta1.ServiceBrokerRelistBehaviorManual {
+				if brokerSpec.RelistBehavior == v1beta1.ServiceBrokerRelistBehaviorManual {
 					// If a broker is configured with RelistBehaviorManual, it should
 					// ignore the Duration and only relist based on spec changes
 
@@ -122,17 +142,17 @@ func shouldReconcileClusterServiceBroker(broker *v1beta1.ClusterServiceBroker, n
 					return false
 				}
 
-				if broker.Spec.RelistDuration == nil {
+				if brokerSpec.RelistDuration == nil {
 					glog.Error(pcb.Message("Unable to process because RelistBehavior is set to Duration with a nil RelistDuration value"))
 					return false
 				}
 
 				// By default, the broker should relist if it has been longer than the
 				// RelistDuration since the last time we fetched the Catalog
-				duration := broker.Spec.RelistDuration.Duration
+				duration := brokerSpec.RelistDuration.Duration
 				intervalPassed := true
-				if broker.Status.LastCatalogRetrievalTime != nil {
-					intervalPassed = now.After(broker.Status.LastCatalogRetrievalTime.Time.Add(duration))
+				if brokerStatus.LastCatalogRetrievalTime != nil {
+					intervalPassed = now.After(brokerStatus.LastCatalogRetrievalTime.Time.Add(duration))
 				}
 				if intervalPassed == false {
 					glog.V(10).Info(pcb.Message("Not processing because RelistDuration has not elapsed since the last relist"))
@@ -172,6 +192,14 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 	pcb := pretty.NewContextBuilder(pretty.ClusterServiceBroker, "", broker.Name)
 	glog.V(4).Infof(pcb.Message("Processing"))
 
+	// Keep the Reachable condition in line with the health of the broker. The
+	// updated broker will be automatically added back to the queue.
+	if broker.DeletionTimestamp == nil {
+		if updated, err := c.updateClusterServiceBrokerReachableCondition(broker); updated || err != nil {
+			return err
+		}
+	}
+
 	// * If the broker's ready condition is true and the RelistBehavior has been
 	// set to Manual, do not reconcile it.
 	// * If the broker's ready condition is true and the relist interval has not
@@ -181,23 +209,12 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 	}
 
 	if broker.DeletionTimestamp == nil { // Add or update
-		authConfig, err := getAuthCredentialsFromClusterServiceBroker(c.kubeClient, broker)
-		if err != nil {
-			s := fmt.Sprintf("Error getting broker auth credentials: %s", err)
-			glog.Info(pcb.Message(s))
-			c.recorder.Event(broker, corev1.EventTypeWarning, errorAuthCredentialsReason, s)
-			if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorFetchingCatalogReason, errorFetchingCatalogMessage+s); err != nil {
-				return err
-			}
-			return err
-		}
-
-		clientConfig := NewClientConfigurationForBroker(broker, authConfig)
-
-		glog.V(4).Info(pcb.Messagef("Creating client, URL: %v", broker.Spec.URL))
-		brokerClient, err := c.brokerClientCreateFunc(clientConfig)
+		brokerClient, err := c.getClusterServiceBrokerClient(broker)
 		if err != nil {
 			s := fmt.Sprintf("Error creating client for broker %q: %s", broker.Name, err)
+			if isAuthCredentialsError(err) {
+				s = fmt.Sprintf("Error getting broker auth credentials: %s", err)
+			}
 			glog.Info(pcb.Message(s))
 			c.recorder.Event(broker, corev1.EventTypeWarning, errorAuthCredentialsReason, s)
 			if err := c.updateClusterServiceBrokerCondition(broker, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse, errorFetchingCatalogReason, errorFetchingCatalogMessage+s); err != nil {
@@ -210,7 +227,7 @@ func (c *controller) reconcileClusterServiceBroker(broker *v1beta1.ClusterServic
 
 		// get the broker's catalog
 		now := metav1.Now()
-		brokerCatalog, err := brokerClient.GetCatalog()
+		brokerCatalog, err := brokerClient.GetCatalogWithExtensions()
 		if err != nil {
 			s := fmt.Sprintf("Error getting broker catalog: %s", err)
 			glog.Warning(pcb.Message(s))
@@ -523,6 +540,7 @@ func (c *controller) reconcileClusterServiceClassFromClusterServiceBrokerCatalog
 	// update it.
 	toUpdate := existingServiceClass.DeepCopy()
 	toUpdate.Spec.BindingRetrievable = serviceClass.Spec.BindingRetrievable
+	toUpdate.Spec.InstancesRetrievable = serviceClass.Spec.InstancesRetrievable
 	toUpdate.Spec.Bindable = serviceClass.Spec.Bindable
 	toUpdate.Spec.PlanUpdatable = serviceClass.Spec.PlanUpdatable
 	toUpdate.Spec.Tags = serviceClass.Spec.Tags
@@ -615,6 +633,7 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 	toUpdate.Spec.ServiceInstanceCreateParameterSchema = servicePlan.Spec.ServiceInstanceCreateParameterSchema
 	toUpdate.Spec.ServiceInstanceUpdateParameterSchema = servicePlan.Spec.ServiceInstanceUpdateParameterSchema
 	toUpdate.Spec.ServiceBindingCreateParameterSchema = servicePlan.Spec.ServiceBindingCreateParameterSchema
+	toUpdate.Spec.MaintenanceInfo = servicePlan.Spec.MaintenanceInfo
 
 	updatedPlan, err := c.serviceCatalogClient.ClusterServicePlans().Update(toUpdate)
 	if err != nil {
@@ -646,6 +665,23 @@ func (c *controller) reconcileClusterServicePlanFromClusterServiceBrokerCatalog(
 func (c *controller) updateClusterServiceBrokerCondition(broker *v1beta1.ClusterServiceBroker, conditionType v1beta1.ServiceBrokerConditionType, status v1beta1.ConditionStatus, reason, message string) error {
 	pcb := pretty.NewContextBuilder(pretty.ClusterServiceBroker, "", broker.Name)
 	toUpdate := broker.DeepCopy()
+	setCommonServiceBrokerCondition(pcb, &toUpdate.ObjectMeta, &toUpdate.Status.CommonServiceBrokerStatus, conditionType, status, reason, message, time.Now())
+
+	glog.V(4).Info(pcb.Messagef("Updating %v condition to %v", conditionType, status))
+	_, err := c.serviceCatalogClient.ClusterServiceBrokers().UpdateStatus(toUpdate)
+	if err != nil {
+		glog.Error(pcb.Messagef("Error updating %v condition: %v", conditionType, err))
+	} else {
+		glog.V(5).Info(pcb.Messagef("Updated %v condition to %v", conditionType, status))
+	}
+
+	return err
+}
+
+// setCommonServiceBrokerCondition sets the given condition on the status of
+// a ClusterServiceBroker or ServiceBroker, updating the last transition time
+// only when the status of the condition changes.
+func setCommonServiceBrokerCondition(pcb *pretty.ContextBuilder, brokerMeta *metav1.ObjectMeta, brokerStatus *v1beta1.CommonServiceBrokerStatus, conditionType v1beta1.ServiceBrokerConditionType, status v1beta1.ConditionStatus, reason, message string, t time.Time) {
 	newCondition := v1beta1.ServiceBrokerCondition{
 		Type:    conditionType,
 		Status:  status,
@@ -653,14 +689,13 @@ func (c *controller) updateClusterServiceBrokerCondition(broker *v1beta1.Cluster
 		Message: message,
 	}
 
-	t := time.Now()
-
-	if len(broker.Status.Conditions) == 0 {
+	if len(brokerStatus.Conditions) == 0 {
 		glog.Info(pcb.Messagef("Setting lastTransitionTime for condition %q to %v", conditionType, t))
 		newCondition.LastTransitionTime = metav1.NewTime(t)
-		toUpdate.Status.Conditions = []v1beta1.ServiceBrokerCondition{newCondition}
+		brokerStatus.Conditions = []v1beta1.ServiceBrokerCondition{newCondition}
 	} else {
-		for i, cond := range broker.Status.Conditions {
+		found := false
+		for i, cond := range brokerStatus.Conditions {
 			if cond.Type == conditionType {
 				if cond.Status != newCondition.Status {
 					glog.Info(pcb.Messagef(
@@ -672,29 +707,25 @@ func (c *controller) updateClusterServiceBrokerCondition(broker *v1beta1.Cluster
 					newCondition.LastTransitionTime = cond.LastTransitionTime
 				}
 
-				toUpdate.Status.Conditions[i] = newCondition
+				brokerStatus.Conditions[i] = newCondition
+				found = true
 				break
 			}
 		}
+		if !found {
+			glog.Info(pcb.Messagef("Setting lastTransitionTime for condition %q to %v", conditionType, t))
+			newCondition.LastTransitionTime = metav1.NewTime(t)
+			brokerStatus.Conditions = append(brokerStatus.Conditions, newCondition)
+		}
 	}
 
 	// Set status.ReconciledGeneration && status.LastCatalogRetrievalTime if updating ready condition to true
 
 	if conditionType == v1beta1.ServiceBrokerConditionReady && status == v1beta1.ConditionTrue {
-		toUpdate.Status.ReconciledGeneration = toUpdate.Generation
+		brokerStatus.ReconciledGeneration = brokerMeta.Generation
 		now := metav1.NewTime(t)
-		toUpdate.Status.LastCatalogRetrievalTime = &now
+		brokerStatus.LastCatalogRetrievalTime = &now
 	}
-
-	glog.V(4).Info(pcb.Messagef("Updating ready condition to %v", status))
-	_, err := c.serviceCatalogClient.ClusterServiceBrokers().UpdateStatus(toUpdate)
-	if err != nil {
-		glog.Error(pcb.Messagef("Error updating ready condition: %v", err))
-	} else {
-		glog.V(5).Info(pcb.Messagef("Updated ready condition to %v", status))
-	}
-
-	return err
 }
 
 // updateClusterServiceBrokerFinalizers updates the given finalizers for the given Broker.
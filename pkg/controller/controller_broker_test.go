@@ -17,7 +17,10 @@ limitations under the License.
 package controller
 
 import (
+	"crypto/x509"
 	"errors"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 	"time"
@@ -26,6 +29,9 @@ import (
 	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
+	fakebrokerclient "github.com/kubernetes-incubator/service-catalog/pkg/brokerclient/fake"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerlimits"
 	"github.com/kubernetes-incubator/service-catalog/test/fake"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -34,10 +40,14 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/diff"
 
+	"strconv"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
 	clientgotesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/cert"
 )
 
 // TestShouldReconcileClusterServiceBroker ensures that with the expected conditions the
@@ -742,6 +752,14 @@ func TestReconcileClusterServiceBrokerWithAuth(t *testing.T) {
 			},
 		},
 	}
+	tlsAuthInfo := &v1beta1.ClusterServiceBrokerAuthInfo{
+		TLS: &v1beta1.ClusterTLSAuthConfig{
+			SecretRef: &v1beta1.ObjectReference{
+				Namespace: "test-ns",
+				Name:      "auth-secret",
+			},
+		},
+	}
 	basicAuthSecret := &corev1.Secret{
 		Data: map[string][]byte{
 			v1beta1.BasicAuthUsernameKey: []byte("foo"),
@@ -803,6 +821,18 @@ func TestReconcileClusterServiceBrokerWithAuth(t *testing.T) {
 			secret:        nil,
 			shouldSucceed: false,
 		},
+		{
+			name:          "tls auth - invalid secret",
+			authInfo:      tlsAuthInfo,
+			secret:        bearerAuthSecret,
+			shouldSucceed: false,
+		},
+		{
+			name:          "tls auth - secret not found",
+			authInfo:      tlsAuthInfo,
+			secret:        nil,
+			shouldSucceed: false,
+		},
 	}
 
 	for _, tc := range cases {
@@ -813,6 +843,440 @@ func TestReconcileClusterServiceBrokerWithAuth(t *testing.T) {
 	}
 }
 
+// TestGetAuthCredentialsFromClusterServiceBrokerWithTLS verifies that the
+// client certificate held by the TLS secret of a broker is loaded into the
+// TLS configuration of its client, and read again when the secret changes.
+func TestGetAuthCredentialsFromClusterServiceBrokerWithTLS(t *testing.T) {
+	fakeKubeClient, _, _, _, _ := newTestController(t, noFakeActions())
+
+	var secret *corev1.Secret
+	fakeKubeClient.AddReactor("get", "secrets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, secret, nil
+	})
+	broker := getTestClusterServiceBrokerWithAuth(&v1beta1.ClusterServiceBrokerAuthInfo{
+		TLS: &v1beta1.ClusterTLSAuthConfig{
+			SecretRef: &v1beta1.ObjectReference{
+				Namespace: "test-ns",
+				Name:      "auth-secret",
+			},
+		},
+	})
+
+	for _, host := range []string{"first", "rotated"} {
+		certPEM, keyPEM, err := cert.GenerateSelfSignedCertKey(host, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error generating certificate: %v", err)
+		}
+		secret = &corev1.Secret{
+			Type: corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: keyPEM,
+			},
+		}
+
+		credentials, err := getAuthCredentialsFromClusterServiceBroker(fakeKubeClient, broker)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if credentials.authConfig != nil {
+			t.Fatalf("unexpected auth config: %+v", credentials.authConfig)
+		}
+
+		clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, credentials.authConfig, credentials.clientCert)
+		if clientConfig.TLSConfig == nil || len(clientConfig.TLSConfig.Certificates) != 1 {
+			t.Fatalf("expected the client certificate in the TLS config, got %+v", clientConfig.TLSConfig)
+		}
+		leaf, err := x509.ParseCertificate(clientConfig.TLSConfig.Certificates[0].Certificate[0])
+		if err != nil {
+			t.Fatalf("unexpected error parsing certificate: %v", err)
+		}
+		if e, a := host, leaf.Subject.CommonName; !strings.HasPrefix(a, e) {
+			t.Fatalf("unexpected certificate: %s", expectedGot(e, a))
+		}
+	}
+}
+
+// TestGetClusterServiceBrokerClientTLSSecretRotated verifies that a rotated
+// client certificate is loaded into a new client of the broker with the
+// default feature gates, under which Secrets are not watched.
+func TestGetClusterServiceBrokerClientTLSSecretRotated(t *testing.T) {
+	fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+
+	var secret *corev1.Secret
+	fakeKubeClient.AddReactor("get", "secrets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, secret, nil
+	})
+	var hosts []string
+	testController.brokerClientCreateFunc = func(config *osb.ClientConfiguration) (brokerclient.Client, error) {
+		if config.TLSConfig == nil || len(config.TLSConfig.Certificates) != 1 {
+			t.Fatalf("expected the client certificate in the TLS config, got %+v", config.TLSConfig)
+		}
+		leaf, err := x509.ParseCertificate(config.TLSConfig.Certificates[0].Certificate[0])
+		if err != nil {
+			t.Fatalf("unexpected error parsing certificate: %v", err)
+		}
+		hosts = append(hosts, strings.SplitN(leaf.Subject.CommonName, "@", 2)[0])
+		return fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{}), nil
+	}
+	broker := getTestClusterServiceBrokerWithAuth(&v1beta1.ClusterServiceBrokerAuthInfo{
+		TLS: &v1beta1.ClusterTLSAuthConfig{
+			SecretRef: &v1beta1.ObjectReference{
+				Namespace: "test-ns",
+				Name:      "auth-secret",
+			},
+		},
+	})
+
+	var expectedHosts []string
+	for i, host := range []string{"first", "rotated"} {
+		certPEM, keyPEM, err := cert.GenerateSelfSignedCertKey(host, nil, nil)
+		if err != nil {
+			t.Fatalf("unexpected error generating certificate: %v", err)
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "auth-secret", ResourceVersion: strconv.Itoa(i + 1)},
+			Type:       corev1.SecretTypeTLS,
+			Data: map[string][]byte{
+				corev1.TLSCertKey:       certPEM,
+				corev1.TLSPrivateKeyKey: keyPEM,
+			},
+		}
+		expectedHosts = append(expectedHosts, host)
+
+		for j := 0; j < 2; j++ {
+			if _, err := testController.getClusterServiceBrokerClient(broker); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(expectedHosts, hosts) {
+				t.Fatalf("unexpected certificates of the created clients: %s", expectedGot(expectedHosts, hosts))
+			}
+		}
+	}
+}
+
+// TestNewBrokerClientWithOAuth2 verifies that a broker authenticating with
+// OAuth2 is sent the access token obtained from its token endpoint with the
+// client credentials held by its secret.
+func TestNewBrokerClientWithOAuth2(t *testing.T) {
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != "client" || clientSecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		if e, a := "read", r.FormValue("scope"); e != a {
+			t.Errorf("unexpected scope: %s", expectedGot(e, a))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"access-token","token_type":"bearer","expires_in":3600}`))
+	}))
+	defer tokenServer.Close()
+
+	fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+	addGetSecretReaction(fakeKubeClient, &corev1.Secret{
+		Data: map[string][]byte{
+			v1beta1.OAuth2ClientIDKey:     []byte("client"),
+			v1beta1.OAuth2ClientSecretKey: []byte("secret"),
+		},
+	})
+	var createdConfig *osb.ClientConfiguration
+	testController.brokerClientCreateFunc = func(config *osb.ClientConfiguration) (brokerclient.Client, error) {
+		createdConfig = config
+		return fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{}), nil
+	}
+
+	broker := getTestClusterServiceBrokerWithAuth(&v1beta1.ClusterServiceBrokerAuthInfo{
+		OAuth2: &v1beta1.ClusterOAuth2AuthConfig{
+			TokenURL: tokenServer.URL,
+			SecretRef: &v1beta1.ObjectReference{
+				Namespace: "test-ns",
+				Name:      "auth-secret",
+			},
+			Scopes: []string{"read"},
+		},
+	})
+	credentials, err := getAuthCredentialsFromClusterServiceBroker(fakeKubeClient, broker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	clientConfig := NewClientConfigurationForBroker(broker.ObjectMeta, &broker.Spec.CommonServiceBrokerSpec, credentials.authConfig, credentials.clientCert)
+	if _, err := testController.newBrokerClient(clientConfig, credentials); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if createdConfig == nil || createdConfig.AuthConfig == nil || createdConfig.AuthConfig.BearerConfig == nil {
+		t.Fatalf("expected the client to be created with a bearer token, got %+v", createdConfig)
+	}
+	if e, a := "access-token", createdConfig.AuthConfig.BearerConfig.Token; e != a {
+		t.Fatalf("unexpected bearer token: %s", expectedGot(e, a))
+	}
+}
+
+// TestGetClusterServiceBrokerClient verifies that the client of a broker is
+// reused until the broker is updated or its client is dropped, and that the
+// auth secret of the broker is only read when a client is created.
+func TestGetClusterServiceBrokerClient(t *testing.T) {
+	fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+	addGetSecretReaction(fakeKubeClient, &corev1.Secret{
+		Data: map[string][]byte{
+			"username": []byte("user"),
+			"password": []byte("pass"),
+		},
+	})
+	created := 0
+	testController.brokerClientCreateFunc = func(config *osb.ClientConfiguration) (brokerclient.Client, error) {
+		created++
+		return fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{}), nil
+	}
+
+	broker := getTestClusterServiceBrokerWithAuth(&v1beta1.ClusterServiceBrokerAuthInfo{
+		Basic: &v1beta1.ClusterBasicAuthConfig{
+			SecretRef: &v1beta1.ObjectReference{
+				Namespace: "test-ns",
+				Name:      "auth-secret",
+			},
+		},
+	})
+	broker.Generation = 1
+
+	calls := 0
+	expectClient := func(name string, expectedCreated int) brokerclient.Client {
+		calls++
+		client, err := testController.getClusterServiceBrokerClient(broker)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", name, err)
+		}
+		if e, a := expectedCreated, created; e != a {
+			t.Fatalf("%v: unexpected number of created clients: %s", name, expectedGot(e, a))
+		}
+		// Secrets are not watched, so the secret is read for its version
+		// on every call, and again for its credentials to create a client.
+		if e, a := calls+expectedCreated, len(fakeKubeClient.Actions()); e != a {
+			t.Fatalf("%v: unexpected number of secret reads: %s", name, expectedGot(e, a))
+		}
+		return client
+	}
+
+	first := expectClient("first reconcile", 1)
+	if second := expectClient("second reconcile", 1); second != first {
+		t.Fatal("expected the cached client to be reused")
+	}
+
+	updatedBroker := broker.DeepCopy()
+	updatedBroker.Generation = 2
+	testController.brokerUpdate(broker, updatedBroker)
+	broker = updatedBroker
+	expectClient("broker updated", 2)
+
+	testController.brokerDelete(broker)
+	expectClient("broker deleted", 3)
+
+	testController.brokerClients.Invalidate(brokerKey(broker.ObjectMeta))
+	expectClient("client dropped", 4)
+}
+
+// TestMergeBrokerRateLimits verifies that the limits set on a broker override
+// the default ones, including with zero to lift a default limit.
+func TestMergeBrokerRateLimits(t *testing.T) {
+	defaults := brokerlimits.Limits{
+		MaxInFlightRequests:     10,
+		RequestsPerSecond:       5,
+		MaxInFlightPollRequests: 4,
+		PollRequestsPerSecond:   2,
+	}
+	int32Ptr := func(i int32) *int32 { return &i }
+	cases := []struct {
+		name     string
+		limits   *v1beta1.ServiceBrokerRateLimits
+		expected brokerlimits.Limits
+	}{
+		{
+			name:     "no limits",
+			expected: defaults,
+		},
+		{
+			name: "some limits",
+			limits: &v1beta1.ServiceBrokerRateLimits{
+				MaxInFlightRequests:   int32Ptr(20),
+				PollRequestsPerSecond: int32Ptr(0),
+			},
+			expected: brokerlimits.Limits{
+				MaxInFlightRequests:     20,
+				RequestsPerSecond:       5,
+				MaxInFlightPollRequests: 4,
+				PollRequestsPerSecond:   0,
+			},
+		},
+	}
+	for _, tc := range cases {
+		if e, a := tc.expected, mergeBrokerRateLimits(defaults, tc.limits); e != a {
+			t.Errorf("%v: unexpected limits: expected %+v, got %+v", tc.name, e, a)
+		}
+	}
+}
+
+// TestGetClusterServiceBrokerClientRateLimits verifies that the requests of
+// the client of a broker, including catalog fetches, wait while the broker is
+// at its rate limits.
+func TestGetClusterServiceBrokerClientRateLimits(t *testing.T) {
+	_, _, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+	maxInFlight := int32(1)
+	broker := getTestClusterServiceBroker()
+	broker.Spec.RateLimits = &v1beta1.ServiceBrokerRateLimits{MaxInFlightRequests: &maxInFlight}
+	limits := brokerlimits.Limits{MaxInFlightRequests: 1}
+
+	client, err := testController.getClusterServiceBrokerClient(broker)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	release, _, ok := testController.brokerLimiter.TryAcquire(testClusterServiceBrokerName, limits, false)
+	if !ok {
+		t.Fatal("expected the budget of the broker to be acquired")
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := client.GetCatalog(); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	}()
+	select {
+	case <-done:
+		t.Fatal("expected the catalog fetch to wait while the broker is at its rate limits")
+	case <-time.After(500 * time.Millisecond):
+	}
+	assertNumberOfClusterServiceBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+	release()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the catalog to be fetched once the broker is within its rate limits")
+	}
+	assertNumberOfClusterServiceBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 1)
+}
+
+// TestGetClusterServiceBrokerClientAuthError verifies that a client is not
+// cached for a broker whose auth credentials cannot be retrieved.
+func TestGetClusterServiceBrokerClientAuthError(t *testing.T) {
+	fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+	addGetSecretNotFoundReaction(fakeKubeClient)
+
+	broker := getTestClusterServiceBrokerWithAuth(&v1beta1.ClusterServiceBrokerAuthInfo{
+		Basic: &v1beta1.ClusterBasicAuthConfig{
+			SecretRef: &v1beta1.ObjectReference{
+				Namespace: "test-ns",
+				Name:      "auth-secret",
+			},
+		},
+	})
+
+	_, err := testController.getClusterServiceBrokerClient(broker)
+	if !isAuthCredentialsError(err) {
+		t.Fatalf("expected an auth credentials error, got %v", err)
+	}
+	if _, ok := testController.brokerClients.Get(brokerKey(broker.ObjectMeta), brokerClientVersion{uid: broker.UID, generation: broker.Generation}); ok {
+		t.Fatal("expected no client to be cached")
+	}
+}
+
+// TestGetClusterServiceBrokerClientSecretChanged verifies that the cached
+// client of a broker is not reused once the secret holding its auth
+// credentials has changed, even if the secret changed while the client was
+// created.
+func TestGetClusterServiceBrokerClientSecretChanged(t *testing.T) {
+	fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "auth-secret", ResourceVersion: "1"},
+		Data:       map[string][]byte{"username": []byte("user"), "password": []byte("pass")},
+	}
+	addGetSecretReaction(fakeKubeClient, secret)
+	secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+	secretIndexer.Add(secret)
+	testController.secretLister = corelisters.NewSecretLister(secretIndexer)
+	createdClients := 0
+	testController.brokerClientCreateFunc = func(config *osb.ClientConfiguration) (brokerclient.Client, error) {
+		createdClients++
+		return fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{}), nil
+	}
+
+	broker := getTestClusterServiceBrokerWithAuth(&v1beta1.ClusterServiceBrokerAuthInfo{
+		Basic: &v1beta1.ClusterBasicAuthConfig{
+			SecretRef: &v1beta1.ObjectReference{
+				Namespace: "test-ns",
+				Name:      "auth-secret",
+			},
+		},
+	})
+	getClient := func(expectedCreatedClients int) {
+		if _, err := testController.getClusterServiceBrokerClient(broker); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if e, a := expectedCreatedClients, createdClients; e != a {
+			t.Fatalf("unexpected number of created clients: %v", expectedGot(e, a))
+		}
+	}
+
+	getClient(1)
+	getClient(1)
+
+	updatedSecret := secret.DeepCopy()
+	updatedSecret.ResourceVersion = "2"
+	secretIndexer.Update(updatedSecret)
+	getClient(2)
+	getClient(2)
+}
+
+// TestGetClusterServiceBrokerClientSecretRotated verifies that the cached
+// client of a broker is not reused once the secret holding its auth
+// credentials was rotated, when Secrets are not watched.
+func TestGetClusterServiceBrokerClientSecretRotated(t *testing.T) {
+	fakeKubeClient, _, _, testController, _ := newTestController(t, noFakeActions())
+	if testController.secretLister != nil {
+		t.Fatal("expected Secrets not to be watched with the default feature gates")
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "test-ns", Name: "auth-secret", ResourceVersion: "1"},
+		Data:       map[string][]byte{"username": []byte("user"), "password": []byte("pass")},
+	}
+	fakeKubeClient.AddReactor("get", "secrets", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, secret, nil
+	})
+	var passwords []string
+	testController.brokerClientCreateFunc = func(config *osb.ClientConfiguration) (brokerclient.Client, error) {
+		passwords = append(passwords, config.AuthConfig.BasicAuthConfig.Password)
+		return fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{}), nil
+	}
+
+	broker := getTestClusterServiceBrokerWithAuth(&v1beta1.ClusterServiceBrokerAuthInfo{
+		Basic: &v1beta1.ClusterBasicAuthConfig{
+			SecretRef: &v1beta1.ObjectReference{
+				Namespace: "test-ns",
+				Name:      "auth-secret",
+			},
+		},
+	})
+	getClient := func(expectedPasswords ...string) {
+		if _, err := testController.getClusterServiceBrokerClient(broker); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !reflect.DeepEqual(expectedPasswords, passwords) {
+			t.Fatalf("unexpected passwords of the created clients: %v", expectedGot(expectedPasswords, passwords))
+		}
+	}
+
+	getClient("pass")
+	getClient("pass")
+
+	secret = secret.DeepCopy()
+	secret.ResourceVersion = "2"
+	secret.Data["password"] = []byte("rotated")
+	getClient("pass", "rotated")
+	getClient("pass", "rotated")
+}
+
 func testReconcileClusterServiceBrokerWithAuth(t *testing.T, authInfo *v1beta1.ClusterServiceBrokerAuthInfo, secret *corev1.Secret, shouldSucceed bool) {
 	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{})
 
@@ -860,16 +1324,23 @@ func testReconcileClusterServiceBrokerWithAuth(t *testing.T, authInfo *v1beta1.C
 		assertClusterServiceBrokerReadyFalse(t, updatedClusterServiceBroker)
 	}
 
-	// verify one kube action occurred
+	// verify the secret was read for its version, then, if it was found, for
+	// the credentials of the new client
 	kubeActions := fakeKubeClient.Actions()
-	assertNumberOfActions(t, kubeActions, 1)
-
-	getAction := kubeActions[0].(clientgotesting.GetAction)
-	if e, a := "get", getAction.GetVerb(); e != a {
-		t.Fatalf("Unexpected verb on action; %s", expectedGot(e, a))
+	if secret != nil {
+		assertNumberOfActions(t, kubeActions, 2)
+	} else {
+		assertNumberOfActions(t, kubeActions, 1)
 	}
-	if e, a := "secrets", getAction.GetResource().Resource; e != a {
-		t.Fatalf("Unexpected resource on action; %s", expectedGot(e, a))
+
+	for _, action := range kubeActions {
+		getAction := action.(clientgotesting.GetAction)
+		if e, a := "get", getAction.GetVerb(); e != a {
+			t.Fatalf("Unexpected verb on action; %s", expectedGot(e, a))
+		}
+		if e, a := "secrets", getAction.GetResource().Resource; e != a {
+			t.Fatalf("Unexpected resource on action; %s", expectedGot(e, a))
+		}
 	}
 
 	events := getRecordedEvents(testController)
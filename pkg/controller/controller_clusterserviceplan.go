@@ -17,15 +17,34 @@ limitations under the License.
 package controller
 
 import (
+	"fmt"
+	"reflect"
+	"sort"
+
 	"github.com/golang/glog"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/tools/cache"
 )
 
+const (
+	migratingPlanReason      string = "MigratingPlan"
+	planMigratedReason       string = "PlanMigrated"
+	errorPlanMigrationReason string = "PlanMigrationFailed"
+
+	// instancePlanMigrationIndex is the name of the index of the
+	// ServiceInstance informer on the plans that instances are being moved
+	// off, by name.
+	instancePlanMigrationIndex = "planMigration"
+)
+
 // Cluster service plan handlers and control-loop
 
 func (c *controller) clusterServicePlanAdd(obj interface{}) {
@@ -39,6 +58,34 @@ func (c *controller) clusterServicePlanAdd(obj interface{}) {
 
 func (c *controller) clusterServicePlanUpdate(oldObj, newObj interface{}) {
 	c.clusterServicePlanAdd(newObj)
+
+	oldPlan, ok := oldObj.(*v1beta1.ClusterServicePlan)
+	if !ok {
+		return
+	}
+	newPlan, ok := newObj.(*v1beta1.ClusterServicePlan)
+	if !ok {
+		return
+	}
+	if !reflect.DeepEqual(oldPlan.Spec.MaintenanceInfo, newPlan.Spec.MaintenanceInfo) {
+		// Let the instances of the plan check whether they can be upgraded.
+		c.enqueueClusterServicePlanInstances(newPlan)
+	}
+}
+
+// enqueueClusterServicePlanInstances adds the instances of the given plan to
+// the instance queue.
+func (c *controller) enqueueClusterServicePlanInstances(clusterServicePlan *v1beta1.ClusterServicePlan) {
+	instances, err := c.instanceLister.List(labels.Everything())
+	if err != nil {
+		glog.Errorf("ClusterServicePlan %q: Couldn't list the instances of the plan: %v", clusterServicePlan.Name, err)
+		return
+	}
+	for _, instance := range instances {
+		if instance.Spec.ClusterServicePlanRef != nil && instance.Spec.ClusterServicePlanRef.Name == clusterServicePlan.Name {
+			c.instanceAdd(instance)
+		}
+	}
 }
 
 func (c *controller) clusterServicePlanDelete(obj interface{}) {
@@ -84,6 +131,9 @@ func (c *controller) reconcileClusterServicePlan(clusterServicePlan *v1beta1.Clu
 	}
 
 	if len(serviceInstances.Items) != 0 {
+		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.PlanMigration) && clusterServicePlan.Spec.MigrationPolicy != nil {
+			return c.migrateServiceInstancesOffClusterServicePlan(clusterServicePlan, serviceInstances.Items)
+		}
 		return nil
 	}
 
@@ -100,3 +150,189 @@ func (c *controller) findServiceInstancesOnClusterServicePlan(clusterServicePlan
 
 	return c.serviceCatalogClient.ServiceInstances(metav1.NamespaceAll).List(listOpts)
 }
+
+// migrateServiceInstancesOffClusterServicePlan moves the given instances of a
+// plan that has been removed from the catalog of its broker to the
+// replacement plan named by the MigrationPolicy of the plan, by updating the
+// plan of each instance. No more than MaxInProgress instances are moved at
+// the same time; the plan is reconciled again when the move of an instance
+// completes.
+//
+// A move that the broker rejects is complete, and recorded as failed in the
+// status of the instance.
+func (c *controller) migrateServiceInstancesOffClusterServicePlan(clusterServicePlan *v1beta1.ClusterServicePlan, serviceInstances []v1beta1.ServiceInstance) error {
+	policy := clusterServicePlan.Spec.MigrationPolicy
+
+	replacement, err := c.findReplacementClusterServicePlan(clusterServicePlan)
+	if err != nil {
+		s := fmt.Sprintf("Unable to migrate instances to plan %q: %v", policy.ReplacementPlanExternalName, err)
+		glog.Warningf("ClusterServicePlan %q (ExternalName: %q): %s", clusterServicePlan.Name, clusterServicePlan.Spec.ExternalName, s)
+		c.recorder.Event(clusterServicePlan, corev1.EventTypeWarning, errorPlanMigrationReason, s)
+		return nil
+	}
+
+	maxInProgress := 1
+	if policy.MaxInProgress != nil {
+		maxInProgress = int(*policy.MaxInProgress)
+	}
+	inProgress, err := c.countServiceInstancePlanMigrations(clusterServicePlan)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(serviceInstances, func(i, j int) bool {
+		if serviceInstances[i].Namespace != serviceInstances[j].Namespace {
+			return serviceInstances[i].Namespace < serviceInstances[j].Namespace
+		}
+		return serviceInstances[i].Name < serviceInstances[j].Name
+	})
+
+	for i := range serviceInstances {
+		instance := &serviceInstances[i]
+		migration := instance.Status.PlanMigration
+		if migration != nil && migration.FromClusterServicePlanName == clusterServicePlan.Name && migration.CompletionTime == nil {
+			// The move was started, but the plan of the instance could not
+			// be updated.
+			if err := c.updateServiceInstancePlan(instance, replacement); err != nil {
+				return err
+			}
+			continue
+		}
+		if inProgress >= maxInProgress {
+			break
+		}
+		if !isServiceInstanceIdle(instance) {
+			continue
+		}
+		if err := c.startServiceInstancePlanMigration(instance, clusterServicePlan, replacement); err != nil {
+			return err
+		}
+		inProgress++
+	}
+	return nil
+}
+
+// findReplacementClusterServicePlan returns the plan that the instances of
+// the given plan are moved to.
+func (c *controller) findReplacementClusterServicePlan(clusterServicePlan *v1beta1.ClusterServicePlan) (*v1beta1.ClusterServicePlan, error) {
+	class, err := c.clusterServiceClassLister.Get(clusterServicePlan.Spec.ClusterServiceClassRef.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !class.Spec.PlanUpdatable {
+		return nil, fmt.Errorf("the plans of ClusterServiceClass %q (ExternalName: %q) are not updatable", class.Name, class.Spec.ExternalName)
+	}
+
+	plans, err := c.clusterServicePlanLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	for _, plan := range plans {
+		if plan.Spec.ClusterServiceClassRef.Name != clusterServicePlan.Spec.ClusterServiceClassRef.Name ||
+			plan.Spec.ExternalName != clusterServicePlan.Spec.MigrationPolicy.ReplacementPlanExternalName {
+			continue
+		}
+		if plan.Status.RemovedFromBrokerCatalog {
+			return nil, fmt.Errorf("the plan has been removed from the broker catalog")
+		}
+		return plan, nil
+	}
+	return nil, fmt.Errorf("the plan does not exist in ClusterServiceClass %q (ExternalName: %q)", class.Name, class.Spec.ExternalName)
+}
+
+// countServiceInstancePlanMigrations returns the number of instances that are
+// being moved off the given plan. The plan is reconciled again as each move
+// completes, by which time the informer cache holds the moves started by its
+// last reconciliation.
+func (c *controller) countServiceInstancePlanMigrations(clusterServicePlan *v1beta1.ClusterServicePlan) (int, error) {
+	instances, err := c.instanceIndexer.ByIndex(instancePlanMigrationIndex, clusterServicePlan.Name)
+	if err != nil {
+		return 0, err
+	}
+	return len(instances), nil
+}
+
+// serviceInstancePlanMigrationSources is the index function of
+// instancePlanMigrationIndex.
+func serviceInstancePlanMigrationSources(obj interface{}) ([]string, error) {
+	instance, ok := obj.(*v1beta1.ServiceInstance)
+	if !ok {
+		return nil, nil
+	}
+	migration := instance.Status.PlanMigration
+	if migration == nil || migration.CompletionTime != nil {
+		return nil, nil
+	}
+	return []string{migration.FromClusterServicePlanName}, nil
+}
+
+// serviceInstancePlanMigrationInProgress returns the move of the given
+// instance off a removed plan if the instance is being moved and its plan is
+// the replacement, so that the update in progress is the move.
+func serviceInstancePlanMigrationInProgress(instance *v1beta1.ServiceInstance) *v1beta1.ServiceInstancePlanMigration {
+	migration := instance.Status.PlanMigration
+	if migration == nil || migration.CompletionTime != nil ||
+		instance.Spec.ClusterServicePlanRef == nil || instance.Spec.ClusterServicePlanRef.Name != migration.ToClusterServicePlanName {
+		return nil
+	}
+	return migration
+}
+
+// recordServiceInstancePlanMigrationFailure reports on the plan the instance
+// was moved off that the broker rejected its replacement plan, and lets the
+// plan move its next instance.
+func (c *controller) recordServiceInstancePlanMigrationFailure(instance *v1beta1.ServiceInstance, migration *v1beta1.ServiceInstancePlanMigration) {
+	clusterServicePlan, err := c.clusterServicePlanLister.Get(migration.FromClusterServicePlanName)
+	if err == nil {
+		s := fmt.Sprintf("Unable to move ServiceInstance %q/%q to plan %q: %s", instance.Namespace, instance.Name, migration.ToClusterServicePlanName, migration.FailureMessage)
+		glog.Warningf("ClusterServicePlan %q (ExternalName: %q): %s", clusterServicePlan.Name, clusterServicePlan.Spec.ExternalName, s)
+		c.recorder.Event(clusterServicePlan, corev1.EventTypeWarning, errorPlanMigrationReason, s)
+	}
+	c.clusterServicePlanQueue.Add(migration.FromClusterServicePlanName)
+}
+
+// isServiceInstanceIdle returns whether the given instance is provisioned and
+// has no change of its spec or operation in progress.
+func isServiceInstanceIdle(instance *v1beta1.ServiceInstance) bool {
+	return instance.DeletionTimestamp == nil &&
+		instance.Status.ProvisionStatus == v1beta1.ServiceInstanceProvisionStatusProvisioned &&
+		instance.Status.CurrentOperation == "" &&
+		instance.Status.ReconciledGeneration == instance.Generation
+}
+
+// startServiceInstancePlanMigration records the move of the given instance
+// to the replacement plan in its status, then updates its plan.
+func (c *controller) startServiceInstancePlanMigration(instance *v1beta1.ServiceInstance, clusterServicePlan, replacement *v1beta1.ClusterServicePlan) error {
+	toUpdate := instance.DeepCopy()
+	toUpdate.Status.PlanMigration = &v1beta1.ServiceInstancePlanMigration{
+		FromClusterServicePlanName: clusterServicePlan.Name,
+		ToClusterServicePlanName:   replacement.Name,
+		StartTime:                  metav1.Now(),
+	}
+	updated, err := c.updateServiceInstanceStatus(toUpdate)
+	if err != nil {
+		return err
+	}
+
+	s := fmt.Sprintf("Moving the instance from plan %q, which has been removed from the broker catalog, to plan %q", clusterServicePlan.Spec.ExternalName, replacement.Spec.ExternalName)
+	glog.Infof("ServiceInstance %q/%q: %s", instance.Namespace, instance.Name, s)
+	c.recorder.Event(updated, corev1.EventTypeNormal, migratingPlanReason, s)
+
+	return c.updateServiceInstancePlan(updated, replacement)
+}
+
+// updateServiceInstancePlan sets the plan of the given instance to the given
+// plan, using the same kind of reference to the plan as the instance.
+func (c *controller) updateServiceInstancePlan(instance *v1beta1.ServiceInstance, plan *v1beta1.ClusterServicePlan) error {
+	toUpdate := instance.DeepCopy()
+	switch {
+	case toUpdate.Spec.ClusterServicePlanExternalName != "":
+		toUpdate.Spec.ClusterServicePlanExternalName = plan.Spec.ExternalName
+	case toUpdate.Spec.ClusterServicePlanExternalID != "":
+		toUpdate.Spec.ClusterServicePlanExternalID = plan.Spec.ExternalID
+	default:
+		toUpdate.Spec.ClusterServicePlanName = plan.Name
+	}
+	_, err := c.serviceCatalogClient.ServiceInstances(toUpdate.Namespace).Update(toUpdate)
+	return err
+}
@@ -18,12 +18,18 @@ package controller
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	"github.com/kubernetes-incubator/service-catalog/test/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	clientgotesting "k8s.io/client-go/testing"
@@ -146,3 +152,236 @@ func reconcileClusterServicePlan(t *testing.T, testController *controller, clust
 	}
 	return err
 }
+
+func TestReconcileClusterServicePlanMigration(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.PlanMigration))
+	if err != nil {
+		t.Fatalf("Failed to enable PlanMigration feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.PlanMigration))
+
+	const replacementPlanName = "replacement-plan"
+
+	getMigratingPlan := func() *v1beta1.ClusterServicePlan {
+		p := getTestClusterServicePlan()
+		p.Status.RemovedFromBrokerCatalog = true
+		p.Spec.MigrationPolicy = &v1beta1.PlanMigrationPolicy{ReplacementPlanExternalName: replacementPlanName}
+		return p
+	}
+	getReplacementPlan := func() *v1beta1.ClusterServicePlan {
+		p := getTestClusterServicePlan()
+		p.Name = "replacement-plan-guid"
+		p.Spec.ExternalID = "replacement-plan-guid"
+		p.Spec.ExternalName = replacementPlanName
+		return p
+	}
+	getUpdatableClass := func() *v1beta1.ClusterServiceClass {
+		c := getTestClusterServiceClass()
+		c.Spec.PlanUpdatable = true
+		return c
+	}
+	getProvisionedInstance := func(name string) v1beta1.ServiceInstance {
+		instance := getTestServiceInstanceWithRefs()
+		instance.Name = name
+		instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+		instance.Status.ReconciledGeneration = instance.Generation
+		instance.Status.ObservedGeneration = instance.Generation
+		return *instance
+	}
+	getMigratingInstance := func(name string) v1beta1.ServiceInstance {
+		instance := getProvisionedInstance(name)
+		instance.Status.PlanMigration = &v1beta1.ServiceInstancePlanMigration{
+			FromClusterServicePlanName: testClusterServicePlanGUID,
+			ToClusterServicePlanName:   "replacement-plan-guid",
+			StartTime:                  metav1.Now(),
+		}
+		return instance
+	}
+	getBusyInstance := func(name string) v1beta1.ServiceInstance {
+		instance := getProvisionedInstance(name)
+		instance.Status.CurrentOperation = v1beta1.ServiceInstanceOperationUpdate
+		return instance
+	}
+
+	cases := []struct {
+		name           string
+		class          *v1beta1.ClusterServiceClass
+		plans          []*v1beta1.ClusterServicePlan
+		instances      []v1beta1.ServiceInstance
+		expectedEvent  string
+		expectedMoved  []string
+		expectedStatus []string
+	}{
+		{
+			name:           "moves an instance",
+			class:          getUpdatableClass(),
+			plans:          []*v1beta1.ClusterServicePlan{getReplacementPlan()},
+			instances:      []v1beta1.ServiceInstance{getProvisionedInstance("a"), getProvisionedInstance("b")},
+			expectedEvent:  corev1.EventTypeNormal + " " + migratingPlanReason,
+			expectedMoved:  []string{"a"},
+			expectedStatus: []string{"a"},
+		},
+		{
+			name:      "another instance is being moved",
+			class:     getUpdatableClass(),
+			plans:     []*v1beta1.ClusterServicePlan{getReplacementPlan()},
+			instances: []v1beta1.ServiceInstance{getMigratingInstance("a"), getProvisionedInstance("b")},
+			// The plan of "a" is updated again, since it was not moved.
+			expectedMoved: []string{"a"},
+		},
+		{
+			name:           "skips instances with an operation in progress",
+			class:          getUpdatableClass(),
+			plans:          []*v1beta1.ClusterServicePlan{getReplacementPlan()},
+			instances:      []v1beta1.ServiceInstance{getBusyInstance("a"), getProvisionedInstance("b")},
+			expectedEvent:  corev1.EventTypeNormal + " " + migratingPlanReason,
+			expectedMoved:  []string{"b"},
+			expectedStatus: []string{"b"},
+		},
+		{
+			name:          "replacement plan does not exist",
+			class:         getUpdatableClass(),
+			instances:     []v1beta1.ServiceInstance{getProvisionedInstance("a")},
+			expectedEvent: corev1.EventTypeWarning + " " + errorPlanMigrationReason,
+		},
+		{
+			name:          "plans are not updatable",
+			class:         getTestClusterServiceClass(),
+			plans:         []*v1beta1.ClusterServicePlan{getReplacementPlan()},
+			instances:     []v1beta1.ServiceInstance{getProvisionedInstance("a")},
+			expectedEvent: corev1.EventTypeWarning + " " + errorPlanMigrationReason,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(tc.class)
+			for _, plan := range tc.plans {
+				sharedInformers.ClusterServicePlans().Informer().GetStore().Add(plan)
+			}
+			fakeCatalogClient.AddReactor("list", "serviceinstances", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+				return true, &v1beta1.ServiceInstanceList{Items: tc.instances}, nil
+			})
+			for i := range tc.instances {
+				sharedInformers.ServiceInstances().Informer().GetStore().Add(&tc.instances[i])
+			}
+
+			if err := reconcileClusterServicePlan(t, testController, getMigratingPlan()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var moved, status []string
+			for _, action := range fakeCatalogClient.Actions() {
+				if action.GetVerb() != "update" {
+					continue
+				}
+				instance := action.(clientgotesting.UpdateAction).GetObject().(*v1beta1.ServiceInstance)
+				if action.GetSubresource() == "status" {
+					if m := instance.Status.PlanMigration; m == nil || m.FromClusterServicePlanName != testClusterServicePlanGUID || m.ToClusterServicePlanName != "replacement-plan-guid" {
+						t.Errorf("unexpected plan migration status: %+v", m)
+					}
+					status = append(status, instance.Name)
+					continue
+				}
+				if e, a := replacementPlanName, instance.Spec.ClusterServicePlanExternalName; e != a {
+					t.Errorf("unexpected plan: expected %q, got %q", e, a)
+				}
+				moved = append(moved, instance.Name)
+			}
+			if !reflect.DeepEqual(tc.expectedMoved, moved) {
+				t.Errorf("unexpected moved instances: expected %v, got %v", tc.expectedMoved, moved)
+			}
+			if !reflect.DeepEqual(tc.expectedStatus, status) {
+				t.Errorf("unexpected instances with a started migration: expected %v, got %v", tc.expectedStatus, status)
+			}
+
+			events := getRecordedEvents(testController)
+			if tc.expectedEvent == "" {
+				assertNumEvents(t, events, 0)
+			} else {
+				assertNumEvents(t, events, 1)
+				if !strings.HasPrefix(events[0], tc.expectedEvent) {
+					t.Errorf("unexpected event: expected %q, got %q", tc.expectedEvent, events[0])
+				}
+			}
+		})
+	}
+}
+
+func TestProcessUpdateServiceInstanceSuccessCompletesPlanMigration(t *testing.T) {
+	_, fakeCatalogClient, _, testController, _ := newTestController(t, noFakeActions())
+
+	instance := getTestServiceInstanceWithRefs()
+	instance.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: "replacement-plan-guid"}
+	instance.Status.PlanMigration = &v1beta1.ServiceInstancePlanMigration{
+		FromClusterServicePlanName: testClusterServicePlanGUID,
+		ToClusterServicePlanName:   "replacement-plan-guid",
+		StartTime:                  metav1.Now(),
+	}
+
+	if err := testController.processUpdateServiceInstanceSuccess(instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	expectNumberOfActions(t, "", actions, 1)
+	updated := assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	if updated.Status.PlanMigration.CompletionTime == nil {
+		t.Errorf("expected the plan migration to be completed")
+	}
+
+	events := getRecordedEvents(testController)
+	assertNumEvents(t, events, 2)
+	if !strings.HasPrefix(events[1], corev1.EventTypeNormal+" "+planMigratedReason) {
+		t.Errorf("unexpected event: %q", events[1])
+	}
+
+	if e, a := 1, testController.clusterServicePlanQueue.Len(); e != a {
+		t.Errorf("expected the plan to be requeued: expected %v items in the queue, got %v", e, a)
+	}
+}
+
+func TestProcessUpdateServiceInstanceFailureEndsPlanMigration(t *testing.T) {
+	_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, noFakeActions())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithRefs()
+	instance.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: "replacement-plan-guid"}
+	instance.Status.PlanMigration = &v1beta1.ServiceInstancePlanMigration{
+		FromClusterServicePlanName: testClusterServicePlanGUID,
+		ToClusterServicePlanName:   "replacement-plan-guid",
+		StartTime:                  metav1.Now(),
+	}
+
+	readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorUpdateInstanceCallFailedReason, "rejected")
+	failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorUpdateInstanceCallFailedReason, "rejected")
+	if err := testController.processTerminalUpdateServiceInstanceFailure(instance, readyCond, failedCond); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	expectNumberOfActions(t, "", actions, 1)
+	updated := assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	migration := updated.Status.PlanMigration
+	if migration.CompletionTime == nil {
+		t.Errorf("expected the failed plan migration to be completed")
+	}
+	if e, a := "rejected", migration.FailureMessage; e != a {
+		t.Errorf("unexpected failure message: expected %q, got %q", e, a)
+	}
+	if keys, _ := serviceInstancePlanMigrationSources(updated); len(keys) != 0 {
+		t.Errorf("expected the failed plan migration not to count as in progress, got %v", keys)
+	}
+
+	events := getRecordedEvents(testController)
+	assertNumEvents(t, events, 2)
+	if !strings.HasPrefix(events[1], corev1.EventTypeWarning+" "+errorPlanMigrationReason) {
+		t.Errorf("unexpected event: %q", events[1])
+	}
+
+	if e, a := 1, testController.clusterServicePlanQueue.Len(); e != a {
+		t.Errorf("expected the plan to be requeued: expected %v items in the queue, got %v", e, a)
+	}
+}
@@ -19,20 +19,28 @@ package controller
 import (
 	stderrors "errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/golang/glog"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
 	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
 	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/tools/cache"
 )
@@ -46,7 +54,21 @@ const (
 	successProvisionMessage        string = "The instance was provisioned successfully"
 	successOrphanMitigationReason  string = "OrphanMitigationSuccessful"
 	successOrphanMitigationMessage string = "Orphan mitigation was completed successfully"
-
+	parametersFromChangedReason    string = "ParametersFromChanged"
+	parametersFromChangedMessage   string = "The Secrets referenced by ParametersFrom have changed; the instance will be updated"
+	upgradeAvailableReason         string = "UpgradeAvailable"
+	instanceUpToDateReason         string = "InstanceUpToDate"
+	instanceUpToDateMessage        string = "The instance runs the version of its plan"
+	instanceRetainedReason         string = "InstanceRetained"
+	instanceRetainedMessage        string = "The instance was not deprovisioned at the broker because its deletion policy is Retain"
+	successAdoptionReason          string = "AdoptedSuccessfully"
+	successAdoptionMessage         string = "The instance was adopted successfully"
+	parametersDriftedReason        string = "ParametersDrifted"
+	parametersInSyncReason         string = "ParametersInSync"
+	parametersInSyncMessage        string = "The broker reports the parameters it was last sent"
+	waitingForDependencyReason     string = "WaitingForDependency"
+
+	errorDependencyFailedReason                string = "DependencyFailed"
 	errorWithParameters                        string = "ErrorWithParameters"
 	errorProvisionCallFailedReason             string = "ProvisionCallFailed"
 	errorErrorCallingProvisionReason           string = "ErrorCallingProvision"
@@ -66,10 +88,22 @@ const (
 	errorDeletedClusterServiceClassMessage     string = "ReferencesDeletedServiceClass"
 	errorDeletedClusterServicePlanReason       string = "ReferencesDeletedServicePlan"
 	errorDeletedClusterServicePlanMessage      string = "ReferencesDeletedServicePlan"
+	errorNonexistentServiceClassReason         string = "ReferencesNonexistentServiceClass"
+	errorNonexistentServicePlanReason          string = "ReferencesNonexistentServicePlan"
+	errorNonexistentServiceBrokerReason        string = "ReferencesNonexistentBroker"
+	errorDeletedServiceClassReason             string = "ReferencesDeletedServiceClass"
+	errorDeletedServicePlanReason              string = "ReferencesDeletedServicePlan"
 	errorFindingNamespaceServiceInstanceReason string = "ErrorFindingNamespaceForInstance"
 	errorOrphanMitigationFailedReason          string = "OrphanMitigationFailed"
 	errorInvalidDeprovisionStatusReason        string = "InvalidDeprovisionStatus"
 	errorInvalidDeprovisionStatusMessage       string = "The deprovision status is invalid"
+	errorUnavailableMaintenanceInfoReason      string = "ReferencesUnavailableMaintenanceInfoVersion"
+	errorAdoptionFailedReason                  string = "AdoptionFailed"
+	errorSyncingInstanceReason                 string = "SyncingInstanceFailed"
+
+	// instanceExternalIDIndex is the name of the index of the
+	// ServiceInstance informer on the external IDs of the instances.
+	instanceExternalIDIndex = "externalID"
 
 	asyncProvisioningReason                 string = "Provisioning"
 	asyncProvisioningMessage                string = "The instance is being provisioned asynchronously"
@@ -109,6 +143,17 @@ func (c *controller) instanceUpdate(oldObj, newObj interface{}) {
 	if !instance.Status.AsyncOpInProgress {
 		c.instanceAdd(newObj)
 	}
+
+	// Instances referring to this one are processed again once it is Ready,
+	// and whenever it changes while it is.
+	oldInstance := oldObj.(*v1beta1.ServiceInstance)
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.CrossInstanceReferences) &&
+		isServiceInstanceReady(instance) &&
+		(!reflect.DeepEqual(oldInstance.Status, instance.Status) || oldInstance.Spec.ExternalID != instance.Spec.ExternalID) {
+		c.enqueueServiceInstancesReferencing(instance.Namespace, func(from *v1beta1.ParametersFromSource) bool {
+			return from.ServiceInstanceFieldRef != nil && from.ServiceInstanceFieldRef.Name == instance.Name
+		})
+	}
 }
 
 func (c *controller) instanceDelete(obj interface{}) {
@@ -119,6 +164,10 @@ func (c *controller) instanceDelete(obj interface{}) {
 
 	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, instance.Namespace, instance.Name)
 	glog.V(4).Info(pcb.Message("Received delete event; no further processing will occur"))
+
+	c.instanceFetchTimesLock.Lock()
+	delete(c.instanceFetchTimes, instance.UID)
+	c.instanceFetchTimesLock.Unlock()
 }
 
 // Async operations on instances have a somewhat convoluted flow in order to
@@ -236,6 +285,13 @@ func (c *controller) reconcileServiceInstanceKey(key string) error {
 		return err
 	}
 
+	brokerKey := c.serviceInstanceBrokerKey(instance)
+	cancelProbe, postponed := c.postponeForBroker(pcb, c.instanceQueue, key, brokerKey)
+	if postponed {
+		return nil
+	}
+	defer cancelProbe()
+
 	return c.reconcileServiceInstance(instance)
 }
 
@@ -355,20 +411,49 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 
 	glog.V(4).Info(pcb.Message("Processing adding event"))
 
-	serviceClass, servicePlan, brokerName, brokerClient, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
-	if err != nil {
-		return c.handleServiceInstanceReconciliationError(instance, err)
-	}
+	var (
+		prettyClass          string
+		brokerName           string
+		brokerClient         brokerclient.Client
+		request              *brokerclient.ProvisionRequest
+		inProgressProperties *v1beta1.ServiceInstancePropertiesState
+	)
+	if instance.Spec.ClusterServiceClassSpecified() {
+		var serviceClass *v1beta1.ClusterServiceClass
+		var servicePlan *v1beta1.ClusterServicePlan
+		serviceClass, servicePlan, brokerName, brokerClient, err = c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
+		if err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
 
-	// Check if the ServiceClass or ServicePlan has been deleted and do not allow
-	// creation of new ServiceInstances.
-	if err := c.checkForRemovedClassAndPlan(instance, serviceClass, servicePlan); err != nil {
-		return c.handleServiceInstanceReconciliationError(instance, err)
-	}
+		// Check if the ServiceClass or ServicePlan has been deleted and do not allow
+		// creation of new ServiceInstances.
+		if err := c.checkForRemovedClassAndPlan(instance, serviceClass, servicePlan); err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
 
-	request, inProgressProperties, err := c.prepareProvisionRequest(instance, serviceClass, servicePlan)
-	if err != nil {
-		return c.handleServiceInstanceReconciliationError(instance, err)
+		request, inProgressProperties, err = c.prepareProvisionRequest(instance, &serviceClass.Spec.CommonServiceClassSpec, &servicePlan.Spec.CommonServicePlanSpec)
+		if err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
+		prettyClass = pretty.ClusterServiceClassName(serviceClass)
+	} else {
+		var serviceClass *v1beta1.ServiceClass
+		var servicePlan *v1beta1.ServicePlan
+		serviceClass, servicePlan, brokerName, brokerClient, err = c.getServiceClassPlanAndServiceBroker(instance)
+		if err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
+
+		if err := c.checkForRemovedServiceClassAndServicePlan(instance, serviceClass, servicePlan); err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
+
+		request, inProgressProperties, err = c.prepareProvisionRequest(instance, &serviceClass.Spec.CommonServiceClassSpec, &servicePlan.Spec.CommonServicePlanSpec)
+		if err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
+		prettyClass = pretty.ServiceClassName(serviceClass)
 	}
 
 	if instance.Status.CurrentOperation == "" {
@@ -382,17 +467,21 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 		return nil
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.InstanceAdoption) && instance.Spec.Adoption != nil {
+		return c.adoptServiceInstance(instance, request, prettyClass, brokerName, brokerClient)
+	}
+
 	glog.V(4).Info(pcb.Messagef(
 		"Provisioning a new ServiceInstance of %s at ClusterServiceBroker %q",
-		pretty.ClusterServiceClassName(serviceClass), brokerName,
+		prettyClass, brokerName,
 	))
 
-	response, err := brokerClient.ProvisionInstance(request)
+	response, err := brokerClient.ProvisionInstanceWithExtensions(request)
 	if err != nil {
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			msg := fmt.Sprintf(
 				"Error provisioning ServiceInstance of %s at ClusterServiceBroker %q: %s",
-				pretty.ClusterServiceClassName(serviceClass), brokerName, httpErr,
+				prettyClass, brokerName, httpErr,
 			)
 			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorProvisionCallFailedReason, msg)
 			// Depending on the specific response, we may need to initiate orphan mitigation.
@@ -421,7 +510,7 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 		msg := fmt.Sprintf("The provision call failed and will be retried: Error communicating with broker for provisioning: %v", err)
 		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, reason, msg)
 
-		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+		if c.serviceInstanceRetryDurationExceeded(instance) {
 			msg := "Stopping reconciliation retries because too much time has elapsed"
 			failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorReconciliationRetryTimeoutReason, msg)
 			return c.processTerminalProvisionFailure(instance, readyCond, failedCond, false)
@@ -437,12 +526,132 @@ func (c *controller) reconcileServiceInstanceAdd(instance *v1beta1.ServiceInstan
 	return c.processProvisionSuccess(instance, response.DashboardURL)
 }
 
+// adoptServiceInstance marks the given instance as provisioned without
+// provisioning it, as the broker already holds an instance under its external
+// ID. An instance that another ServiceInstance already manages is never
+// adopted. Unless the adoption opts out, the instance is first fetched from
+// the broker to verify that it exists and runs the requested plan. A failed
+// adoption never starts orphan mitigation: the instance at the broker was not
+// provisioned by the controller, which must not deprovision it.
+func (c *controller) adoptServiceInstance(instance *v1beta1.ServiceInstance, request *brokerclient.ProvisionRequest, prettyClass, brokerName string, brokerClient brokerclient.Client) error {
+	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, instance.Namespace, instance.Name)
+
+	owner, err := c.findServiceInstanceWithExternalID(instance)
+	if err != nil {
+		return err
+	}
+	if owner != nil {
+		msg := fmt.Sprintf(
+			"The ServiceInstance to adopt is already managed by ServiceInstance %s/%s",
+			owner.Namespace, owner.Name,
+		)
+		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorAdoptionFailedReason, msg)
+		failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorAdoptionFailedReason, msg)
+		return c.processTerminalProvisionFailure(instance, readyCond, failedCond, false)
+	}
+
+	var dashboardURL *string
+	if verify := instance.Spec.Adoption.Verify; verify == nil || *verify {
+		glog.V(4).Info(pcb.Messagef(
+			"Fetching the ServiceInstance of %s to adopt from ClusterServiceBroker %q",
+			prettyClass, brokerName,
+		))
+		response, err := brokerClient.GetInstance(&brokerclient.GetInstanceRequest{
+			InstanceID: request.InstanceID,
+			ServiceID:  request.ServiceID,
+			PlanID:     request.PlanID,
+		})
+		if err != nil {
+			msg := fmt.Sprintf(
+				"Error fetching the ServiceInstance of %s to adopt from ClusterServiceBroker %q: %v",
+				prettyClass, brokerName, err,
+			)
+			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorAdoptionFailedReason, msg)
+			// An instance that the broker does not hold cannot be adopted.
+			if httpErr, ok := osb.IsHTTPError(err); ok && (httpErr.StatusCode == http.StatusNotFound || httpErr.StatusCode == http.StatusGone || !isRetriableHTTPStatus(httpErr.StatusCode)) {
+				failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorAdoptionFailedReason, msg)
+				return c.processTerminalProvisionFailure(instance, readyCond, failedCond, false)
+			}
+			if c.serviceInstanceRetryDurationExceeded(instance) {
+				msg := "Stopping reconciliation retries because too much time has elapsed"
+				failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorReconciliationRetryTimeoutReason, msg)
+				return c.processTerminalProvisionFailure(instance, readyCond, failedCond, false)
+			}
+			return c.processTemporaryProvisionFailure(instance, readyCond, false)
+		}
+		if response.PlanID != "" && response.PlanID != request.PlanID {
+			msg := fmt.Sprintf(
+				"The ServiceInstance to adopt runs plan %q at ClusterServiceBroker %q instead of plan %q",
+				response.PlanID, brokerName, request.PlanID,
+			)
+			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, errorAdoptionFailedReason, msg)
+			failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorAdoptionFailedReason, msg)
+			return c.processTerminalProvisionFailure(instance, readyCond, failedCond, false)
+		}
+		dashboardURL = response.DashboardURL
+	}
+
+	setServiceInstanceDashboardURL(instance, dashboardURL)
+	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, v1beta1.ConditionTrue, successAdoptionReason, successAdoptionMessage)
+	instance.Status.ExternalProperties = instance.Status.InProgressProperties
+	clearServiceInstanceCurrentOperation(instance)
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.ReconciledGeneration = instance.Status.ObservedGeneration
+
+	if _, err := c.updateServiceInstanceStatus(instance); err != nil {
+		return err
+	}
+
+	c.recorder.Event(instance, corev1.EventTypeNormal, successAdoptionReason, successAdoptionMessage)
+	return nil
+}
+
+// findServiceInstanceWithExternalID returns another ServiceInstance that has
+// the external ID of the given instance, if any.
+func (c *controller) findServiceInstanceWithExternalID(instance *v1beta1.ServiceInstance) (*v1beta1.ServiceInstance, error) {
+	objs, err := c.instanceIndexer.ByIndex(instanceExternalIDIndex, instance.Spec.ExternalID)
+	if err != nil {
+		return nil, err
+	}
+	for _, obj := range objs {
+		other, ok := obj.(*v1beta1.ServiceInstance)
+		if ok && other.UID != instance.UID {
+			return other, nil
+		}
+	}
+	return nil, nil
+}
+
+// serviceInstanceExternalIDs is the index function of instanceExternalIDIndex.
+func serviceInstanceExternalIDs(obj interface{}) ([]string, error) {
+	instance, ok := obj.(*v1beta1.ServiceInstance)
+	if !ok || instance.Spec.ExternalID == "" {
+		return nil, nil
+	}
+	return []string{instance.Spec.ExternalID}, nil
+}
+
 // reconcileServiceInstanceUpdate is responsible for handling updating the plan
 // or parameters of a service instance.
 func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceInstance) error {
 	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, instance.Namespace, instance.Name)
 
 	if isServiceInstanceProcessedAlready(instance) {
+		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.MaintenanceInfo) {
+			updated, err := c.syncServiceInstanceUpgradeAvailable(instance)
+			if err != nil || updated {
+				return err
+			}
+		}
+		if c.instanceSyncInterval > 0 && isServiceInstanceReady(instance) {
+			updated, err := c.syncServiceInstanceFromBroker(instance)
+			if err != nil || updated {
+				return err
+			}
+		}
+		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ParametersFromSync) {
+			return c.syncServiceInstanceParametersFrom(instance)
+		}
 		glog.V(4).Info(pcb.Message("Not processing event because status showed there is no work to do"))
 		return nil
 	}
@@ -465,20 +674,49 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 
 	glog.V(4).Info(pcb.Message("Processing updating event"))
 
-	serviceClass, servicePlan, brokerName, brokerClient, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
-	if err != nil {
-		return c.handleServiceInstanceReconciliationError(instance, err)
-	}
+	var (
+		prettyClass          string
+		brokerName           string
+		brokerClient         brokerclient.Client
+		request              *brokerclient.UpdateInstanceRequest
+		inProgressProperties *v1beta1.ServiceInstancePropertiesState
+	)
+	if instance.Spec.ClusterServiceClassSpecified() {
+		var serviceClass *v1beta1.ClusterServiceClass
+		var servicePlan *v1beta1.ClusterServicePlan
+		serviceClass, servicePlan, brokerName, brokerClient, err = c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
+		if err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
 
-	// Check if the ServiceClass or ServicePlan has been deleted. If so, do
-	// not allow plan upgrades, but do allow parameter changes.
-	if err := c.checkForRemovedClassAndPlan(instance, serviceClass, servicePlan); err != nil {
-		return c.handleServiceInstanceReconciliationError(instance, err)
-	}
+		// Check if the ServiceClass or ServicePlan has been deleted. If so, do
+		// not allow plan upgrades, but do allow parameter changes.
+		if err := c.checkForRemovedClassAndPlan(instance, serviceClass, servicePlan); err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
 
-	request, inProgressProperties, err := c.prepareUpdateInstanceRequest(instance, serviceClass, servicePlan)
-	if err != nil {
-		return c.handleServiceInstanceReconciliationError(instance, err)
+		request, inProgressProperties, err = c.prepareUpdateInstanceRequest(instance, &serviceClass.Spec.CommonServiceClassSpec, &servicePlan.Spec.CommonServicePlanSpec)
+		if err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
+		prettyClass = pretty.ClusterServiceClassName(serviceClass)
+	} else {
+		var serviceClass *v1beta1.ServiceClass
+		var servicePlan *v1beta1.ServicePlan
+		serviceClass, servicePlan, brokerName, brokerClient, err = c.getServiceClassPlanAndServiceBroker(instance)
+		if err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
+
+		if err := c.checkForRemovedServiceClassAndServicePlan(instance, serviceClass, servicePlan); err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
+
+		request, inProgressProperties, err = c.prepareUpdateInstanceRequest(instance, &serviceClass.Spec.CommonServiceClassSpec, &servicePlan.Spec.CommonServicePlanSpec)
+		if err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
+		prettyClass = pretty.ServiceClassName(serviceClass)
 	}
 
 	if instance.Status.CurrentOperation == "" {
@@ -494,10 +732,10 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 
 	glog.V(4).Info(pcb.Messagef(
 		"Updating ServiceInstance of %s at ClusterServiceBroker %q",
-		pretty.ClusterServiceClassName(serviceClass), brokerName,
+		prettyClass, brokerName,
 	))
 
-	response, err := brokerClient.UpdateInstance(request)
+	response, err := brokerClient.UpdateInstanceWithExtensions(request)
 	if err != nil {
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			msg := fmt.Sprintf("ClusterServiceBroker returned a failure for update call; update will not be retried: %v", httpErr)
@@ -522,7 +760,7 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 
 		msg := fmt.Sprintf("The update call failed and will be retried: Error communicating with broker for updating: %s", err)
 
-		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+		if c.serviceInstanceRetryDurationExceeded(instance) {
 			// log and record the real error, but process as a
 			// failure with reconciliation retry timeout
 			glog.Info(pcb.Message(msg))
@@ -550,6 +788,334 @@ func (c *controller) reconcileServiceInstanceUpdate(instance *v1beta1.ServiceIns
 	return c.processUpdateServiceInstanceSuccess(instance)
 }
 
+// syncServiceInstanceParametersFrom requests an update of the given instance
+// when the values of the Secrets referenced by its ParametersFrom no longer
+// match the parameters that were last sent to the broker. The update is
+// requested the same way a user would, by incrementing UpdateRequests.
+func (c *controller) syncServiceInstanceParametersFrom(instance *v1beta1.ServiceInstance) error {
+	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, instance.Namespace, instance.Name)
+
+	// Failed instances are left alone, otherwise an update that the broker
+	// keeps rejecting would be requested again on every resync.
+	if len(instance.Spec.ParametersFrom) == 0 ||
+		instance.Status.ExternalProperties == nil ||
+		isServiceInstanceFailed(instance) {
+		glog.V(4).Info(pcb.Message("Not processing event because status showed there is no work to do"))
+		return nil
+	}
+	if instance.Annotations[v1beta1.DisableParametersFromSyncAnnotation] == "true" {
+		glog.V(4).Info(pcb.Message("Not checking the Secrets referenced by ParametersFrom because the instance opted out"))
+		return nil
+	}
+
+	parameters, _, err := buildParameters(c.cachedParametersFromSources(), instance.Namespace, instance.Spec.ParametersFrom, instance.Spec.Parameters)
+	if err != nil {
+		// The error will be reported on the instance the next time it is
+		// updated, there is nothing to send to the broker until then.
+		glog.Warning(pcb.Messagef("Failed to build the parameters to check for changes: %v", err))
+		return nil
+	}
+	parametersChecksum, err := generateChecksumOfParameters(parameters)
+	if err != nil {
+		return err
+	}
+	if parametersChecksum == instance.Status.ExternalProperties.ParametersChecksum {
+		glog.V(4).Info(pcb.Message("Not processing event because the parameters have not changed"))
+		return nil
+	}
+
+	glog.V(4).Info(pcb.Message(parametersFromChangedMessage))
+	toUpdate := instance.DeepCopy()
+	toUpdate.Spec.UpdateRequests++
+	if _, err := c.serviceCatalogClient.ServiceInstances(toUpdate.Namespace).Update(toUpdate); err != nil {
+		glog.Error(pcb.Messagef("Failed to request an update: %v", err))
+		return err
+	}
+
+	c.recorder.Event(instance, corev1.EventTypeNormal, parametersFromChangedReason, parametersFromChangedMessage)
+	return nil
+}
+
+// syncServiceInstanceUpgradeAvailable sets the UpgradeAvailable condition of
+// the given instance when the maintenance information of its plan has a
+// version that the instance does not run, and clears the condition once the
+// instance runs it. It returns whether the status of the instance was updated.
+func (c *controller) syncServiceInstanceUpgradeAvailable(instance *v1beta1.ServiceInstance) (bool, error) {
+	if instance.Status.ExternalProperties == nil {
+		return false, nil
+	}
+
+	var maintenanceInfo *v1beta1.MaintenanceInfo
+	switch {
+	case instance.Spec.ClusterServicePlanRef != nil:
+		plan, err := c.clusterServicePlanLister.Get(instance.Spec.ClusterServicePlanRef.Name)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		maintenanceInfo = plan.Spec.MaintenanceInfo
+	case instance.Spec.ServicePlanRef != nil:
+		plan, err := c.servicePlanLister.ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanRef.Name)
+		if err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		maintenanceInfo = plan.Spec.MaintenanceInfo
+	default:
+		return false, nil
+	}
+
+	upgradeAvailable := maintenanceInfo != nil && maintenanceInfo.Version != instance.Status.ExternalProperties.MaintenanceInfoVersion
+	if upgradeAvailable {
+		message := fmt.Sprintf("Version %q of the plan is available", maintenanceInfo.Version)
+		for _, cond := range instance.Status.Conditions {
+			if cond.Type == v1beta1.ServiceInstanceConditionUpgradeAvailable && cond.Status == v1beta1.ConditionTrue && cond.Message == message {
+				return false, nil
+			}
+		}
+		toUpdate := instance.DeepCopy()
+		setServiceInstanceCondition(toUpdate, v1beta1.ServiceInstanceConditionUpgradeAvailable, v1beta1.ConditionTrue, upgradeAvailableReason, message)
+		_, err := c.updateServiceInstanceStatus(toUpdate)
+		return err == nil, err
+	}
+
+	if !isServiceInstanceConditionTrue(instance, v1beta1.ServiceInstanceConditionUpgradeAvailable) {
+		return false, nil
+	}
+	toUpdate := instance.DeepCopy()
+	setServiceInstanceCondition(toUpdate, v1beta1.ServiceInstanceConditionUpgradeAvailable, v1beta1.ConditionFalse, instanceUpToDateReason, instanceUpToDateMessage)
+	_, err := c.updateServiceInstanceStatus(toUpdate)
+	return err == nil, err
+}
+
+// syncServiceInstanceFromBroker fetches the given instance from the broker, if
+// its class is instancesRetrievable, and records the parameters and dashboard
+// URL that the broker reports in its status. The ParametersDrifted condition
+// is set when the broker reports other values for the parameters than the ones
+// it was last sent. The status is only updated when the broker reports a
+// change, or on the first fetch. The instance is fetched again once the
+// instance sync interval has elapsed; until then, it is requeued for when it
+// will have. It returns whether the status of the instance was updated.
+func (c *controller) syncServiceInstanceFromBroker(instance *v1beta1.ServiceInstance) (bool, error) {
+	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, instance.Namespace, instance.Name)
+
+	if instance.Status.ExternalProperties == nil {
+		return false, nil
+	}
+
+	var (
+		serviceClass *v1beta1.CommonServiceClassSpec
+		planID       string
+	)
+	if instance.Spec.ClusterServiceClassSpecified() {
+		class, err := c.clusterServiceClassLister.Get(instance.Spec.ClusterServiceClassRef.Name)
+		if err != nil {
+			return false, err
+		}
+		serviceClass = &class.Spec.CommonServiceClassSpec
+		planID = instance.Status.ExternalProperties.ClusterServicePlanExternalID
+	} else {
+		class, err := c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name)
+		if err != nil {
+			return false, err
+		}
+		serviceClass = &class.Spec.CommonServiceClassSpec
+		planID = instance.Status.ExternalProperties.ServicePlanExternalID
+	}
+	if !serviceClass.InstancesRetrievable {
+		glog.V(6).Info(pcb.Message("Not fetching the instance because the class does not allow instances to be retrieved"))
+		return false, nil
+	}
+
+	if lastFetchTime, ok := c.lastServiceInstanceFetchTime(instance); ok {
+		if remaining := lastFetchTime.Add(c.instanceSyncInterval).Sub(time.Now()); remaining > 0 {
+			glog.V(6).Info(pcb.Messagef("Instance will be fetched from the broker in %v", remaining))
+			c.enqueueServiceInstanceAfter(instance, remaining)
+			return false, nil
+		}
+	}
+
+	var (
+		brokerName   string
+		brokerClient brokerclient.Client
+	)
+	if instance.Spec.ClusterServiceClassSpecified() {
+		_, name, client, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
+		if err != nil {
+			return false, err
+		}
+		brokerName, brokerClient = name, client
+	} else {
+		_, name, client, err := c.getServiceClassAndServiceBroker(instance)
+		if err != nil {
+			return false, err
+		}
+		brokerName, brokerClient = name, client
+	}
+
+	glog.V(4).Info(pcb.Message("Fetching the instance from the broker"))
+
+	now := metav1.Now()
+	c.instanceFetchTimesLock.Lock()
+	c.instanceFetchTimes[instance.UID] = now.Time
+	c.instanceFetchTimesLock.Unlock()
+
+	response, err := brokerClient.GetInstance(&brokerclient.GetInstanceRequest{
+		InstanceID: instance.Spec.ExternalID,
+		ServiceID:  serviceClass.ExternalID,
+		PlanID:     planID,
+	})
+	if err != nil {
+		// Failing to fetch the instance says nothing about its parameters,
+		// so the status is left as it was; a broker failing GETs is not
+		// retried with backoff, only at the next sync.
+		msg := fmt.Sprintf("Error fetching the instance from broker %q: %s", brokerName, err)
+		glog.Warning(pcb.Message(msg))
+		c.recorder.Event(instance, corev1.EventTypeWarning, errorSyncingInstanceReason, msg)
+		c.enqueueServiceInstanceAfter(instance, c.instanceSyncInterval)
+		return false, nil
+	}
+
+	toUpdate := instance.DeepCopy()
+
+	var expected map[string]interface{}
+	if parameters := instance.Status.ExternalProperties.Parameters; parameters != nil {
+		expected, err = UnmarshalRawParameters(parameters.Raw)
+		if err != nil {
+			return false, err
+		}
+	}
+	reported, drifted := compareServiceInstanceBrokerParameters(expected, response.Parameters)
+
+	brokerProperties := &v1beta1.ServiceInstanceBrokerProperties{
+		DashboardURL: response.DashboardURL,
+	}
+	if reported != nil {
+		raw, err := MarshalRawParameters(reported)
+		if err != nil {
+			return false, err
+		}
+		brokerProperties.Parameters = &runtime.RawExtension{Raw: raw}
+	}
+	toUpdate.Status.BrokerProperties = brokerProperties
+
+	if len(drifted) > 0 {
+		msg := fmt.Sprintf("The broker reports other values than it was last sent for parameters %s", strings.Join(drifted, ", "))
+		glog.Warning(pcb.Message(msg))
+		if !isServiceInstanceConditionTrue(instance, v1beta1.ServiceInstanceConditionParametersDrifted) {
+			c.recorder.Event(instance, corev1.EventTypeWarning, parametersDriftedReason, msg)
+		}
+		setServiceInstanceCondition(toUpdate, v1beta1.ServiceInstanceConditionParametersDrifted, v1beta1.ConditionTrue, parametersDriftedReason, msg)
+	} else {
+		// ParametersDrifted is only set on instances the broker reported
+		// unexpected parameters for; once the broker agrees again, the
+		// condition is flipped to false instead.
+		for _, cond := range toUpdate.Status.Conditions {
+			if cond.Type == v1beta1.ServiceInstanceConditionParametersDrifted {
+				setServiceInstanceCondition(toUpdate, v1beta1.ServiceInstanceConditionParametersDrifted, v1beta1.ConditionFalse, parametersInSyncReason, parametersInSyncMessage)
+				break
+			}
+		}
+	}
+
+	if instance.Status.LastBrokerSyncTime != nil && apiequality.Semantic.DeepEqual(instance.Status, toUpdate.Status) {
+		glog.V(6).Info(pcb.Message("The broker reports no change to the instance"))
+		c.enqueueServiceInstanceAfter(instance, c.instanceSyncInterval)
+		return false, nil
+	}
+	toUpdate.Status.LastBrokerSyncTime = &now
+	if _, err := c.updateServiceInstanceStatus(toUpdate); err != nil {
+		return false, err
+	}
+	c.enqueueServiceInstanceAfter(instance, c.instanceSyncInterval)
+	return true, nil
+}
+
+// lastServiceInstanceFetchTime returns the last time the given instance was
+// fetched from its broker, if it ever was. The instance may have been fetched
+// without a change to its status since the status was last updated, or by
+// another controller-manager before the status was.
+func (c *controller) lastServiceInstanceFetchTime(instance *v1beta1.ServiceInstance) (time.Time, bool) {
+	c.instanceFetchTimesLock.Lock()
+	fetchTime, ok := c.instanceFetchTimes[instance.UID]
+	c.instanceFetchTimesLock.Unlock()
+
+	if syncTime := instance.Status.LastBrokerSyncTime; syncTime != nil && (!ok || syncTime.After(fetchTime)) {
+		return syncTime.Time, true
+	}
+	return fetchTime, ok
+}
+
+// compareServiceInstanceBrokerParameters compares the parameters that the
+// broker reports for an instance with the ones it was last sent, in which the
+// values sourced from secrets are redacted. It returns the reported
+// parameters, with the values of the parameters that were sourced from
+// secrets redacted, and the sorted names of the parameters the broker reports
+// another value for. Parameters that only the broker reports, such as the
+// ones it defaulted, are not drift.
+func compareServiceInstanceBrokerParameters(expected, reported map[string]interface{}) (map[string]interface{}, []string) {
+	var redacted map[string]interface{}
+	if reported != nil {
+		redacted = make(map[string]interface{}, len(reported))
+		for k, v := range reported {
+			redacted[k] = v
+		}
+	}
+
+	var drifted []string
+	for k, expectedValue := range expected {
+		reportedValue, ok := reported[k]
+		if !ok {
+			drifted = append(drifted, k)
+			continue
+		}
+		if expectedValue == "<redacted>" {
+			redacted[k] = "<redacted>"
+			continue
+		}
+		if !reflect.DeepEqual(expectedValue, reportedValue) {
+			drifted = append(drifted, k)
+		}
+	}
+	sort.Strings(drifted)
+	return redacted, drifted
+}
+
+// enqueueServiceInstanceAfter adds the given instance to the instance queue
+// once the given duration has passed.
+func (c *controller) enqueueServiceInstanceAfter(instance *v1beta1.ServiceInstance, d time.Duration) {
+	key, err := cache.MetaNamespaceKeyFunc(instance)
+	if err != nil {
+		pcb := pretty.NewContextBuilder(pretty.ServiceInstance, instance.Namespace, instance.Name)
+		glog.Error(pcb.Messagef("Couldn't create a key for object: %v", err))
+		return
+	}
+	c.instanceQueue.AddAfter(key, d)
+}
+
+// enqueueServiceInstancesReferencing adds the instances of the given
+// namespace with a ParametersFrom source matching the given function to the
+// instance work queue.
+func (c *controller) enqueueServiceInstancesReferencing(namespace string, references func(*v1beta1.ParametersFromSource) bool) {
+	instances, err := c.instanceLister.ServiceInstances(namespace).List(labels.Everything())
+	if err != nil {
+		glog.Errorf("Couldn't list the ServiceInstances of namespace %q: %v", namespace, err)
+		return
+	}
+	for _, instance := range instances {
+		for i := range instance.Spec.ParametersFrom {
+			if references(&instance.Spec.ParametersFrom[i]) {
+				c.instanceAdd(instance)
+				break
+			}
+		}
+	}
+}
+
 // reconcileServiceInstanceDelete is responsible for handling any instance whose
 // deletion timestamp is set.
 func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceInstance) error {
@@ -598,12 +1164,46 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 		return c.handleServiceInstanceReconciliationError(instance, err)
 	}
 
-	serviceClass, brokerName, brokerClient, err := c.getClusterServiceClassAndClusterServiceBroker(instance)
-	if err != nil {
-		return c.handleServiceInstanceReconciliationError(instance, err)
+	// A retained instance is left at the broker. Orphan mitigation and
+	// deprovisioning that has already started are carried through, as the
+	// broker may not hold a usable instance anymore.
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.DeletionPolicy) &&
+		instance.Spec.DeletionPolicy == v1beta1.ServiceInstanceDeletionPolicyRetain &&
+		instance.DeletionTimestamp != nil &&
+		!instance.Status.OrphanMitigationInProgress &&
+		instance.Status.CurrentOperation != v1beta1.ServiceInstanceOperationDeprovision {
+		glog.V(4).Info(pcb.Message(instanceRetainedMessage))
+		c.recorder.Event(instance, corev1.EventTypeNormal, instanceRetainedReason, instanceRetainedMessage)
+		instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusNotRequired
+		return c.processServiceInstanceGracefulDeletionSuccess(instance)
+	}
+
+	var (
+		prettyClass  string
+		serviceID    string
+		brokerName   string
+		brokerClient brokerclient.Client
+		err          error
+	)
+	if instance.Spec.ClusterServiceClassSpecified() {
+		var serviceClass *v1beta1.ClusterServiceClass
+		serviceClass, brokerName, brokerClient, err = c.getClusterServiceClassAndClusterServiceBroker(instance)
+		if err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
+		prettyClass = pretty.ClusterServiceClassName(serviceClass)
+		serviceID = serviceClass.Spec.ExternalID
+	} else {
+		var serviceClass *v1beta1.ServiceClass
+		serviceClass, brokerName, brokerClient, err = c.getServiceClassAndServiceBroker(instance)
+		if err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
+		prettyClass = pretty.ServiceClassName(serviceClass)
+		serviceID = serviceClass.Spec.ExternalID
 	}
 
-	request, inProgressProperties, err := c.prepareDeprovisionRequest(instance, serviceClass)
+	request, inProgressProperties, err := c.prepareDeprovisionRequest(instance, serviceID)
 	if err != nil {
 		return c.handleServiceInstanceReconciliationError(instance, err)
 	}
@@ -639,7 +1239,7 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 	if err != nil {
 		msg := fmt.Sprintf(
 			`Error deprovisioning, %s at ClusterServiceBroker %q: %v`,
-			pretty.ClusterServiceClassName(serviceClass), brokerName, err,
+			prettyClass, brokerName, err,
 		)
 		if httpErr, ok := osb.IsHTTPError(err); ok {
 			msg = fmt.Sprintf("Deprovision call failed; received error response from broker: %v", httpErr)
@@ -647,7 +1247,7 @@ func (c *controller) reconcileServiceInstanceDelete(instance *v1beta1.ServiceIns
 
 		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionUnknown, errorDeprovisionCalledReason, msg)
 
-		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+		if c.serviceInstanceRetryDurationExceeded(instance) {
 			msg := "Stopping reconciliation retries because too much time has elapsed"
 			failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, errorReconciliationRetryTimeoutReason, msg)
 			return c.processDeprovisionFailure(instance, readyCond, failedCond)
@@ -669,9 +1269,23 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 
 	instance = instance.DeepCopy()
 
-	serviceClass, servicePlan, _, brokerClient, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
-	if err != nil {
-		return c.handleServiceInstanceReconciliationError(instance, err)
+	var (
+		classSpec    *v1beta1.CommonServiceClassSpec
+		planSpec     *v1beta1.CommonServicePlanSpec
+		brokerClient brokerclient.Client
+	)
+	if instance.Spec.ClusterServiceClassSpecified() {
+		serviceClass, servicePlan, _, client, err := c.getClusterServiceClassPlanAndClusterServiceBroker(instance)
+		if err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
+		classSpec, planSpec, brokerClient = &serviceClass.Spec.CommonServiceClassSpec, &servicePlan.Spec.CommonServicePlanSpec, client
+	} else {
+		serviceClass, servicePlan, _, client, err := c.getServiceClassPlanAndServiceBroker(instance)
+		if err != nil {
+			return c.handleServiceInstanceReconciliationError(instance, err)
+		}
+		classSpec, planSpec, brokerClient = &serviceClass.Spec.CommonServiceClassSpec, &servicePlan.Spec.CommonServicePlanSpec, client
 	}
 
 	// There are some conditions that are different depending on which
@@ -681,7 +1295,7 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 	provisioning := instance.Status.CurrentOperation == v1beta1.ServiceInstanceOperationProvision && !mitigatingOrphan
 	deleting := instance.Status.CurrentOperation == v1beta1.ServiceInstanceOperationDeprovision || mitigatingOrphan
 
-	request, err := c.prepareServiceInstanceLastOperationRequest(instance, serviceClass, servicePlan)
+	request, err := c.prepareServiceInstanceLastOperationRequest(instance, classSpec, planSpec)
 	if err != nil {
 		return c.handleServiceInstanceReconciliationError(instance, err)
 	}
@@ -707,7 +1321,7 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 		glog.V(4).Info(pcb.Message(s))
 		c.recorder.Event(instance, corev1.EventTypeWarning, errorPollingLastOperationReason, s)
 
-		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+		if c.serviceInstanceRetryDurationExceeded(instance) {
 			return c.processServiceInstancePollingFailureRetryTimeout(instance, nil)
 		}
 
@@ -737,7 +1351,7 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 		}
 
 		readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, reason, message)
-		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+		if c.serviceInstanceRetryDurationExceeded(instance) {
 			return c.processServiceInstancePollingFailureRetryTimeout(instance, readyCond)
 		}
 
@@ -780,7 +1394,7 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 			msg := "Deprovision call failed: " + description
 			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionUnknown, errorDeprovisionCalledReason, msg)
 
-			if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+			if c.serviceInstanceRetryDurationExceeded(instance) {
 				return c.processServiceInstancePollingFailureRetryTimeout(instance, readyCond)
 			}
 
@@ -806,7 +1420,7 @@ func (c *controller) pollServiceInstance(instance *v1beta1.ServiceInstance) erro
 		return c.finishPollingServiceInstance(instance)
 	default:
 		glog.Warning(pcb.Messagef("Got invalid state in LastOperationResponse: %q", response.State))
-		if c.reconciliationRetryDurationExceeded(instance.Status.OperationStartTime) {
+		if c.serviceInstanceRetryDurationExceeded(instance) {
 			return c.processServiceInstancePollingFailureRetryTimeout(instance, nil)
 		}
 
@@ -865,10 +1479,16 @@ func (c *controller) processServiceInstancePollingFailureRetryTimeout(instance *
 
 // resolveReferences checks to see if ClusterServiceClassRef and/or ClusterServicePlanRef are
 // nil and if so, will resolve the references and update the instance.
+// Instances that refer to a namespaced ServiceClass have their
+// ServiceClassRef and ServicePlanRef resolved instead.
 // If references needed to be resolved, and the instance status was successfully updated, the method returns true
 // If either can not be resolved, returns an error and sets the InstanceCondition
 // with the appropriate error message.
 func (c *controller) resolveReferences(instance *v1beta1.ServiceInstance) (bool, error) {
+	if instance.Spec.ServiceClassSpecified() {
+		return c.resolveNamespacedReferences(instance)
+	}
+
 	if instance.Spec.ClusterServiceClassRef != nil && instance.Spec.ClusterServicePlanRef != nil {
 		return false, nil
 	}
@@ -1056,6 +1676,196 @@ func (c *controller) resolveClusterServicePlanRef(instance *v1beta1.ServiceInsta
 	return instance, nil
 }
 
+// resolveNamespacedReferences is the namespaced counterpart of
+// resolveReferences, it resolves ServiceClassRef and ServicePlanRef.
+func (c *controller) resolveNamespacedReferences(instance *v1beta1.ServiceInstance) (bool, error) {
+	if instance.Spec.ServiceClassRef != nil && instance.Spec.ServicePlanRef != nil {
+		return false, nil
+	}
+
+	var sc *v1beta1.ServiceClass
+	var err error
+	if instance.Spec.ServiceClassRef == nil {
+		instance, sc, err = c.resolveServiceClassRef(instance)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if instance.Spec.ServicePlanRef == nil {
+		if sc == nil {
+			sc, err = c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name)
+			if err != nil {
+				return false, fmt.Errorf(`Couldn't find ServiceClass (K8S: %s)": %v`, instance.Spec.ServiceClassRef.Name, err.Error())
+			}
+		}
+
+		instance, err = c.resolveServicePlanRef(instance, sc.Spec.ServiceBrokerName)
+		if err != nil {
+			return false, err
+		}
+	}
+	_, err = c.updateServiceInstanceReferences(instance)
+	return err == nil, err
+}
+
+// resolveServiceClassRef resolves a reference to a ServiceClass in the
+// instance's namespace and updates the instance.
+// If ServiceClass can not be resolved, returns an error, records an
+// Event, and sets the InstanceCondition with the appropriate error message.
+func (c *controller) resolveServiceClassRef(instance *v1beta1.ServiceInstance) (*v1beta1.ServiceInstance, *v1beta1.ServiceClass, error) {
+	if !instance.Spec.ServiceClassSpecified() {
+		// ServiceInstance is in invalid state, should not ever happen. check
+		return nil, nil, fmt.Errorf("ServiceInstance %s/%s is in invalid state, neither ServiceClassExternalName, ServiceClassExternalID, nor ServiceClassName is set", instance.Namespace, instance.Name)
+	}
+
+	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, instance.Namespace, instance.Name)
+	var sc *v1beta1.ServiceClass
+
+	if instance.Spec.ServiceClassName != "" {
+		glog.V(4).Info(pcb.Messagef("looking up a ServiceClass from K8S Name: %q", instance.Spec.ServiceClassName))
+
+		var err error
+		sc, err = c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassName)
+		if err == nil {
+			instance.Spec.ServiceClassRef = &v1beta1.LocalObjectReference{
+				Name: sc.Name,
+			}
+			glog.V(4).Info(pcb.Messagef(
+				"resolved ServiceClass %c to ServiceClass with external Name %q",
+				instance.Spec.PlanReference, sc.Spec.ExternalName,
+			))
+		} else {
+			s := fmt.Sprintf(
+				"References a non-existent ServiceClass %c",
+				instance.Spec.PlanReference,
+			)
+			glog.Warning(pcb.Message(s))
+			c.updateServiceInstanceCondition(
+				instance,
+				v1beta1.ServiceInstanceConditionReady,
+				v1beta1.ConditionFalse,
+				errorNonexistentServiceClassReason,
+				"The instance references a ServiceClass that does not exist. "+s,
+			)
+			c.recorder.Event(instance, corev1.EventTypeWarning, errorNonexistentServiceClassReason, s)
+			return nil, nil, stderrors.New(s)
+		}
+	} else {
+		filterField := instance.Spec.GetClassFilterFieldName()
+		filterValue := instance.Spec.GetSpecifiedClass()
+
+		glog.V(4).Info(pcb.Messagef("looking up a ServiceClass from %s: %q", filterField, filterValue))
+		listOpts := metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector(filterField, filterValue).String(),
+		}
+		serviceClasses, err := c.serviceCatalogClient.ServiceClasses(instance.Namespace).List(listOpts)
+		if err == nil && len(serviceClasses.Items) == 1 {
+			sc = &serviceClasses.Items[0]
+			instance.Spec.ServiceClassRef = &v1beta1.LocalObjectReference{
+				Name: sc.Name,
+			}
+			glog.V(4).Info(pcb.Messagef(
+				"resolved %c to K8S ServiceClass %q",
+				instance.Spec.PlanReference, sc.Name,
+			))
+		} else {
+			s := fmt.Sprintf(
+				"References a non-existent ServiceClass %c or there is more than one (found: %d)",
+				instance.Spec.PlanReference, len(serviceClasses.Items),
+			)
+			glog.Warning(pcb.Message(s))
+			c.updateServiceInstanceCondition(
+				instance,
+				v1beta1.ServiceInstanceConditionReady,
+				v1beta1.ConditionFalse,
+				errorNonexistentServiceClassReason,
+				"The instance references a ServiceClass that does not exist. "+s,
+			)
+			c.recorder.Event(instance, corev1.EventTypeWarning, errorNonexistentServiceClassReason, s)
+			return nil, nil, stderrors.New(s)
+		}
+	}
+
+	return instance, sc, nil
+}
+
+// resolveServicePlanRef resolves a reference to a ServicePlan in the
+// instance's namespace and updates the instance.
+// If ServicePlan can not be resolved, returns an error, records an
+// Event, and sets the InstanceCondition with the appropriate error message.
+func (c *controller) resolveServicePlanRef(instance *v1beta1.ServiceInstance, brokerName string) (*v1beta1.ServiceInstance, error) {
+	if !instance.Spec.ServicePlanSpecified() {
+		// ServiceInstance is in invalid state, should not ever happen. check
+		return nil, fmt.Errorf("ServiceInstance %s/%s is in invalid state, neither ServicePlanExternalName, ServicePlanExternalID, nor ServicePlanName is set", instance.Namespace, instance.Name)
+	}
+
+	pcb := pretty.NewContextBuilder(pretty.ServiceInstance, instance.Namespace, instance.Name)
+
+	if instance.Spec.ServicePlanName != "" {
+		sp, err := c.servicePlanLister.ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanName)
+		if err == nil {
+			instance.Spec.ServicePlanRef = &v1beta1.LocalObjectReference{
+				Name: sp.Name,
+			}
+			glog.V(4).Info(pcb.Messagef(
+				"resolved ServicePlan with K8S name %q to ServicePlan with external name %q",
+				instance.Spec.ServicePlanName, sp.Spec.ExternalName,
+			))
+		} else {
+			s := fmt.Sprintf(
+				"References a non-existent ServicePlan %v",
+				instance.Spec.PlanReference,
+			)
+			glog.Warning(pcb.Message(s))
+			c.updateServiceInstanceCondition(
+				instance,
+				v1beta1.ServiceInstanceConditionReady,
+				v1beta1.ConditionFalse,
+				errorNonexistentServicePlanReason,
+				"The instance references a ServicePlan that does not exist. "+s,
+			)
+			c.recorder.Event(instance, corev1.EventTypeWarning, errorNonexistentServicePlanReason, s)
+			return nil, stderrors.New(s)
+		}
+	} else {
+		fieldSet := fields.Set{
+			instance.Spec.GetPlanFilterFieldName(): instance.Spec.GetSpecifiedPlan(),
+			"spec.serviceClassRef.name":            instance.Spec.ServiceClassRef.Name,
+			"spec.serviceBrokerName":               brokerName,
+		}
+		fieldSelector := fields.SelectorFromSet(fieldSet).String()
+		listOpts := metav1.ListOptions{FieldSelector: fieldSelector}
+		servicePlans, err := c.serviceCatalogClient.ServicePlans(instance.Namespace).List(listOpts)
+		if err == nil && len(servicePlans.Items) == 1 {
+			sp := &servicePlans.Items[0]
+			instance.Spec.ServicePlanRef = &v1beta1.LocalObjectReference{
+				Name: sp.Name,
+			}
+			glog.V(4).Info(pcb.Messagef("resolved %v to ServicePlan (K8S: %q)",
+				instance.Spec.PlanReference, sp.Name,
+			))
+		} else {
+			s := fmt.Sprintf(
+				"References a non-existent ServicePlan %b on ServiceClass %s %c or there is more than one (found: %d)",
+				instance.Spec.PlanReference, instance.Spec.ServiceClassRef.Name, instance.Spec.PlanReference, len(servicePlans.Items),
+			)
+			glog.Warning(pcb.Message(s))
+			c.updateServiceInstanceCondition(
+				instance,
+				v1beta1.ServiceInstanceConditionReady,
+				v1beta1.ConditionFalse,
+				errorNonexistentServicePlanReason,
+				"The instance references a ServicePlan that does not exist. "+s,
+			)
+			c.recorder.Event(instance, corev1.EventTypeWarning, errorNonexistentServicePlanReason, s)
+			return nil, stderrors.New(s)
+		}
+	}
+
+	return instance, nil
+}
+
 // newServiceInstanceCondition is a helper function that returns a
 // condition with the given type, status, reason and message, with its transition
 // time set to now.
@@ -1332,6 +2142,37 @@ func (c *controller) checkForRemovedClassAndPlan(instance *v1beta1.ServiceInstan
 	}
 }
 
+// checkForRemovedServiceClassAndServicePlan is the namespaced counterpart of
+// checkForRemovedClassAndPlan.
+func (c *controller) checkForRemovedServiceClassAndServicePlan(instance *v1beta1.ServiceInstance, serviceClass *v1beta1.ServiceClass, servicePlan *v1beta1.ServicePlan) error {
+	classDeleted := serviceClass.Status.RemovedFromBrokerCatalog
+	planDeleted := servicePlan.Status.RemovedFromBrokerCatalog
+
+	if !classDeleted && !planDeleted {
+		return nil
+	}
+
+	isProvisioning := instance.Status.ProvisionStatus != v1beta1.ServiceInstanceProvisionStatusProvisioned
+
+	// Parameter updates are always allowed, plan changes are not
+	if !isProvisioning && instance.Status.ExternalProperties != nil &&
+		servicePlan.Spec.ExternalID == instance.Status.ExternalProperties.ServicePlanExternalID {
+		return nil
+	}
+
+	if planDeleted {
+		return &operationError{
+			reason:  errorDeletedServicePlanReason,
+			message: fmt.Sprintf("%s has been deleted; cannot provision.", pretty.ServicePlanName(servicePlan)),
+		}
+	}
+
+	return &operationError{
+		reason:  errorDeletedServiceClassReason,
+		message: fmt.Sprintf("%s has been deleted; cannot provision.", pretty.ServiceClassName(serviceClass)),
+	}
+}
+
 // clearServiceInstanceCurrentOperation sets the fields of the instance's Status
 // to indicate that there is no current operation being performed. The Status
 // is *not* recorded in the registry.
@@ -1381,7 +2222,7 @@ type requestHelper struct {
 
 // prepareRequestHelper is a helper function that generates a struct with
 // properties common to multiple request types.
-func (c *controller) prepareRequestHelper(instance *v1beta1.ServiceInstance, servicePlan *v1beta1.ClusterServicePlan, setInProgressProperties bool) (*requestHelper, error) {
+func (c *controller) prepareRequestHelper(instance *v1beta1.ServiceInstance, servicePlan *v1beta1.CommonServicePlanSpec, setInProgressProperties bool) (*requestHelper, error) {
 	rh := &requestHelper{}
 
 	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.OriginatingIdentity) {
@@ -1411,13 +2252,34 @@ func (c *controller) prepareRequestHelper(instance *v1beta1.ServiceInstance, ser
 	rh.ns = ns
 
 	if setInProgressProperties {
+		sources := c.parametersFromSources()
 		parameters, parametersChecksum, rawParametersWithRedaction, err := prepareInProgressPropertyParameters(
-			c.kubeClient,
+			sources,
 			instance.Namespace,
 			instance.Spec.Parameters,
 			instance.Spec.ParametersFrom,
 		)
+		if isDependencyNotReadyError(err) && !isServiceInstanceReady(instance) {
+			owner := parametersFromDependency{kind: "ServiceInstance", name: instance.Name}
+			if cycleErr := findParametersFromCycle(sources, instance.Namespace, owner, instance.Spec.ParametersFrom); cycleErr != nil {
+				err = cycleErr
+			}
+		}
 		if err != nil {
+			if isDependencyFailedError(err) {
+				return nil, &operationError{
+					reason:  errorDependencyFailedReason,
+					message: fmt.Sprintf("A dependency will never be Ready: %v", err),
+				}
+			}
+			if isDependencyNotReadyError(err) {
+				// Nothing is sent to the broker until the referenced
+				// binding or instance is Ready.
+				return nil, &operationError{
+					reason:  waitingForDependencyReason,
+					message: fmt.Sprintf("Waiting for a dependency: %v", err),
+				}
+			}
 			return nil, &operationError{
 				reason:  errorWithParameters,
 				message: err.Error(),
@@ -1426,11 +2288,16 @@ func (c *controller) prepareRequestHelper(instance *v1beta1.ServiceInstance, ser
 		rh.parameters = parameters
 
 		rh.inProgressProperties = &v1beta1.ServiceInstancePropertiesState{
-			ClusterServicePlanExternalName: servicePlan.Spec.ExternalName,
-			ClusterServicePlanExternalID:   servicePlan.Spec.ExternalID,
-			Parameters:                     rawParametersWithRedaction,
-			ParametersChecksum:             parametersChecksum,
-			UserInfo:                       instance.Spec.UserInfo,
+			Parameters:         rawParametersWithRedaction,
+			ParametersChecksum: parametersChecksum,
+			UserInfo:           instance.Spec.UserInfo,
+		}
+		if instance.Spec.ServiceClassSpecified() {
+			rh.inProgressProperties.ServicePlanExternalName = servicePlan.ExternalName
+			rh.inProgressProperties.ServicePlanExternalID = servicePlan.ExternalID
+		} else {
+			rh.inProgressProperties.ClusterServicePlanExternalName = servicePlan.ExternalName
+			rh.inProgressProperties.ClusterServicePlanExternalID = servicePlan.ExternalID
 		}
 	}
 
@@ -1447,22 +2314,29 @@ func (c *controller) prepareRequestHelper(instance *v1beta1.ServiceInstance, ser
 
 // prepareProvisionRequest creates a provision request object to be passed to
 // the broker client to provision the given instance.
-func (c *controller) prepareProvisionRequest(instance *v1beta1.ServiceInstance, serviceClass *v1beta1.ClusterServiceClass, servicePlan *v1beta1.ClusterServicePlan) (*osb.ProvisionRequest, *v1beta1.ServiceInstancePropertiesState, error) {
+func (c *controller) prepareProvisionRequest(instance *v1beta1.ServiceInstance, serviceClass *v1beta1.CommonServiceClassSpec, servicePlan *v1beta1.CommonServicePlanSpec) (*brokerclient.ProvisionRequest, *v1beta1.ServiceInstancePropertiesState, error) {
 	rh, err := c.prepareRequestHelper(instance, servicePlan, true)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	request := &osb.ProvisionRequest{
-		AcceptsIncomplete:   true,
-		InstanceID:          instance.Spec.ExternalID,
-		ServiceID:           serviceClass.Spec.ExternalID,
-		PlanID:              servicePlan.Spec.ExternalID,
-		Parameters:          rh.parameters,
-		OrganizationGUID:    string(rh.ns.UID),
-		SpaceGUID:           string(rh.ns.UID),
-		Context:             rh.requestContext,
-		OriginatingIdentity: rh.originatingIdentity,
+	request := &brokerclient.ProvisionRequest{
+		ProvisionRequest: osb.ProvisionRequest{
+			AcceptsIncomplete:   true,
+			InstanceID:          instance.Spec.ExternalID,
+			ServiceID:           serviceClass.ExternalID,
+			PlanID:              servicePlan.ExternalID,
+			Parameters:          rh.parameters,
+			OrganizationGUID:    string(rh.ns.UID),
+			SpaceGUID:           string(rh.ns.UID),
+			Context:             rh.requestContext,
+			OriginatingIdentity: rh.originatingIdentity,
+		},
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.MaintenanceInfo) && servicePlan.MaintenanceInfo != nil {
+		request.MaintenanceInfo = toOSBMaintenanceInfo(servicePlan.MaintenanceInfo)
+		rh.inProgressProperties.MaintenanceInfoVersion = servicePlan.MaintenanceInfo.Version
 	}
 
 	return request, rh.inProgressProperties, nil
@@ -1470,24 +2344,26 @@ func (c *controller) prepareProvisionRequest(instance *v1beta1.ServiceInstance,
 
 // prepareUpdateInstanceRequest creates an update instance request object to be
 // passed to the broker client to update the given instance.
-func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInstance, serviceClass *v1beta1.ClusterServiceClass, servicePlan *v1beta1.ClusterServicePlan) (*osb.UpdateInstanceRequest, *v1beta1.ServiceInstancePropertiesState, error) {
+func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInstance, serviceClass *v1beta1.CommonServiceClassSpec, servicePlan *v1beta1.CommonServicePlanSpec) (*brokerclient.UpdateInstanceRequest, *v1beta1.ServiceInstancePropertiesState, error) {
 	rh, err := c.prepareRequestHelper(instance, servicePlan, true)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	request := &osb.UpdateInstanceRequest{
-		AcceptsIncomplete:   true,
-		InstanceID:          instance.Spec.ExternalID,
-		ServiceID:           serviceClass.Spec.ExternalID,
-		Context:             rh.requestContext,
-		OriginatingIdentity: rh.originatingIdentity,
+	request := &brokerclient.UpdateInstanceRequest{
+		UpdateInstanceRequest: osb.UpdateInstanceRequest{
+			AcceptsIncomplete:   true,
+			InstanceID:          instance.Spec.ExternalID,
+			ServiceID:           serviceClass.ExternalID,
+			Context:             rh.requestContext,
+			OriginatingIdentity: rh.originatingIdentity,
+		},
 	}
 
 	// Only send the plan ID if the plan ID has changed from what the Broker has
 	if instance.Status.ExternalProperties == nil ||
-		servicePlan.Spec.ExternalID != instance.Status.ExternalProperties.ClusterServicePlanExternalID {
-		planID := servicePlan.Spec.ExternalID
+		servicePlan.ExternalID != externalPlanID(instance.Status.ExternalProperties) {
+		planID := servicePlan.ExternalID
 		request.PlanID = &planID
 	}
 	// Only send the parameters if they have changed from what the Broker has
@@ -1500,12 +2376,60 @@ func (c *controller) prepareUpdateInstanceRequest(instance *v1beta1.ServiceInsta
 		}
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.MaintenanceInfo) {
+		if err := setUpdateInstanceRequestMaintenanceInfo(instance, servicePlan, request, rh.inProgressProperties); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return request, rh.inProgressProperties, nil
 }
 
+// setUpdateInstanceRequestMaintenanceInfo sets the maintenance information
+// that the given update request sends to the broker, and records its version
+// in the given in-progress properties. The maintenance information of the plan
+// is sent when the instance moves to another plan, or when the spec of the
+// instance asks for a version that the instance does not run yet. Otherwise
+// the instance keeps the version it runs.
+func setUpdateInstanceRequestMaintenanceInfo(instance *v1beta1.ServiceInstance, servicePlan *v1beta1.CommonServicePlanSpec, request *brokerclient.UpdateInstanceRequest, inProgressProperties *v1beta1.ServiceInstancePropertiesState) error {
+	current := ""
+	if instance.Status.ExternalProperties != nil {
+		current = instance.Status.ExternalProperties.MaintenanceInfoVersion
+	}
+	inProgressProperties.MaintenanceInfoVersion = current
+
+	switch {
+	case request.PlanID != nil:
+		if servicePlan.MaintenanceInfo == nil {
+			inProgressProperties.MaintenanceInfoVersion = ""
+			return nil
+		}
+	case instance.Spec.MaintenanceInfoVersion != "" && instance.Spec.MaintenanceInfoVersion != current:
+		if servicePlan.MaintenanceInfo == nil || servicePlan.MaintenanceInfo.Version != instance.Spec.MaintenanceInfoVersion {
+			return &operationError{
+				reason:  errorUnavailableMaintenanceInfoReason,
+				message: fmt.Sprintf("The plan of the instance does not offer version %q of its maintenance information", instance.Spec.MaintenanceInfoVersion),
+			}
+		}
+	default:
+		return nil
+	}
+
+	request.MaintenanceInfo = toOSBMaintenanceInfo(servicePlan.MaintenanceInfo)
+	inProgressProperties.MaintenanceInfoVersion = servicePlan.MaintenanceInfo.Version
+	return nil
+}
+
+// toOSBMaintenanceInfo converts the maintenance information of a plan to the
+// maintenance information of a request to the broker.
+func toOSBMaintenanceInfo(maintenanceInfo *v1beta1.MaintenanceInfo) *brokerclient.MaintenanceInfo {
+	version := maintenanceInfo.Version
+	return &brokerclient.MaintenanceInfo{Version: &version}
+}
+
 // prepareDeprovisionRequest creates a deprovision request object to be passed
 // to the broker client to deprovision the given instance.
-func (c *controller) prepareDeprovisionRequest(instance *v1beta1.ServiceInstance, serviceClass *v1beta1.ClusterServiceClass) (*osb.DeprovisionRequest, *v1beta1.ServiceInstancePropertiesState, error) {
+func (c *controller) prepareDeprovisionRequest(instance *v1beta1.ServiceInstance, serviceID string) (*osb.DeprovisionRequest, *v1beta1.ServiceInstancePropertiesState, error) {
 	rh, err := c.prepareRequestHelper(instance, nil, false)
 	if err != nil {
 		return nil, nil, err
@@ -1528,8 +2452,8 @@ func (c *controller) prepareDeprovisionRequest(instance *v1beta1.ServiceInstance
 
 	request := &osb.DeprovisionRequest{
 		InstanceID:          instance.Spec.ExternalID,
-		ServiceID:           serviceClass.Spec.ExternalID,
-		PlanID:              rh.inProgressProperties.ClusterServicePlanExternalID,
+		ServiceID:           serviceID,
+		PlanID:              externalPlanID(rh.inProgressProperties),
 		OriginatingIdentity: rh.originatingIdentity,
 		AcceptsIncomplete:   true,
 	}
@@ -1537,9 +2461,18 @@ func (c *controller) prepareDeprovisionRequest(instance *v1beta1.ServiceInstance
 	return request, rh.inProgressProperties, nil
 }
 
+// externalPlanID returns the external ID of the plan recorded in the given
+// properties state, whether it is a ClusterServicePlan or a ServicePlan.
+func externalPlanID(properties *v1beta1.ServiceInstancePropertiesState) string {
+	if properties.ServicePlanExternalID != "" {
+		return properties.ServicePlanExternalID
+	}
+	return properties.ClusterServicePlanExternalID
+}
+
 // preparePollServiceInstanceRequest creates a request object to be passed to
 // the broker client to query the given instance's last operation endpoint.
-func (c *controller) prepareServiceInstanceLastOperationRequest(instance *v1beta1.ServiceInstance, serviceClass *v1beta1.ClusterServiceClass, servicePlan *v1beta1.ClusterServicePlan) (*osb.LastOperationRequest, error) {
+func (c *controller) prepareServiceInstanceLastOperationRequest(instance *v1beta1.ServiceInstance, serviceClass *v1beta1.CommonServiceClassSpec, servicePlan *v1beta1.CommonServicePlanSpec) (*osb.LastOperationRequest, error) {
 	rh, err := c.prepareRequestHelper(instance, servicePlan, false)
 	if err != nil {
 		return nil, err
@@ -1547,8 +2480,8 @@ func (c *controller) prepareServiceInstanceLastOperationRequest(instance *v1beta
 
 	request := &osb.LastOperationRequest{
 		InstanceID:          instance.Spec.ExternalID,
-		ServiceID:           &serviceClass.Spec.ExternalID,
-		PlanID:              &servicePlan.Spec.ExternalID,
+		ServiceID:           &serviceClass.ExternalID,
+		PlanID:              &servicePlan.ExternalID,
 		OriginatingIdentity: rh.originatingIdentity,
 	}
 	if instance.Status.LastOperation != nil && *instance.Status.LastOperation != "" {
@@ -1582,6 +2515,13 @@ func (c *controller) processServiceInstanceGracefulDeletionSuccess(instance *v1b
 // the ServiceInstance resource.
 func (c *controller) handleServiceInstanceReconciliationError(instance *v1beta1.ServiceInstance, err error) error {
 	if resourceErr, ok := err.(*operationError); ok {
+		// An instance that cannot be provisioned before a dependency that
+		// will never be Ready is not retried.
+		if resourceErr.reason == errorDependencyFailedReason && instance.Status.ProvisionStatus != v1beta1.ServiceInstanceProvisionStatusProvisioned {
+			readyCond := newServiceInstanceReadyCondition(v1beta1.ConditionFalse, resourceErr.reason, resourceErr.message)
+			failedCond := newServiceInstanceFailedCondition(v1beta1.ConditionTrue, resourceErr.reason, resourceErr.message)
+			return c.processTerminalProvisionFailure(instance, readyCond, failedCond, false)
+		}
 		status := v1beta1.ConditionFalse
 		if instance.Status.CurrentOperation == v1beta1.ServiceInstanceOperationDeprovision {
 			status = v1beta1.ConditionUnknown
@@ -1724,11 +2664,23 @@ func (c *controller) processUpdateServiceInstanceSuccess(instance *v1beta1.Servi
 	clearServiceInstanceCurrentOperation(instance)
 	instance.Status.ReconciledGeneration = instance.Status.ObservedGeneration
 
+	migration := serviceInstancePlanMigrationInProgress(instance)
+	migrated := migration != nil
+	if migrated {
+		now := metav1.Now()
+		migration.CompletionTime = &now
+	}
+
 	if _, err := c.updateServiceInstanceStatus(instance); err != nil {
 		return err
 	}
 
 	c.recorder.Eventf(instance, corev1.EventTypeNormal, successUpdateInstanceReason, successUpdateInstanceMessage)
+	if migrated {
+		c.recorder.Eventf(instance, corev1.EventTypeNormal, planMigratedReason, "The instance was moved to plan %q", migration.ToClusterServicePlanName)
+		// Let the plan move its next instance.
+		c.clusterServicePlanQueue.Add(migration.FromClusterServicePlanName)
+	}
 	return nil
 }
 
@@ -1753,10 +2705,20 @@ func (c *controller) processUpdateServiceInstanceFailure(instance *v1beta1.Servi
 	c.recorder.Event(instance, corev1.EventTypeWarning, readyCond.Reason, readyCond.Message)
 	setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionReady, readyCond.Status, readyCond.Reason, readyCond.Message)
 
+	var failedMigration *v1beta1.ServiceInstancePlanMigration
 	if failedCond != nil {
 		setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionFailed, failedCond.Status, failedCond.Reason, failedCond.Message)
 		// Reset the current operation if there was a terminal error
 		clearServiceInstanceCurrentOperation(instance)
+
+		// A move off a removed plan is over once the broker rejected the
+		// replacement plan, so that it no longer counts against the
+		// MaxInProgress of the plan.
+		if failedMigration = serviceInstancePlanMigrationInProgress(instance); failedMigration != nil {
+			now := metav1.Now()
+			failedMigration.CompletionTime = &now
+			failedMigration.FailureMessage = failedCond.Message
+		}
 	} else {
 		// Don't reset the current operation if the error is retriable
 		// or requires an orphan mitigation.
@@ -1768,6 +2730,10 @@ func (c *controller) processUpdateServiceInstanceFailure(instance *v1beta1.Servi
 		return err
 	}
 
+	if failedMigration != nil {
+		c.recordServiceInstancePlanMigrationFailure(instance, failedMigration)
+	}
+
 	// The instance will be requeued in any case, since we updated the status
 	// a few lines above.
 	// But we still need to return a non-nil error for retriable errors
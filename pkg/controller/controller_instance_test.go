@@ -31,6 +31,8 @@ import (
 	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
+	fakebrokerclient "github.com/kubernetes-incubator/service-catalog/pkg/brokerclient/fake"
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -1177,6 +1179,208 @@ func TestReconcileServiceInstance(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstanceAdoption tests adopting an instance that
+// already exists at the broker instead of provisioning it.
+func TestReconcileServiceInstanceAdoption(t *testing.T) {
+	cases := []struct {
+		name             string
+		verify           bool
+		adopted          bool
+		getInstance      *fakebrokerclient.GetInstanceReaction
+		expectReady      v1beta1.ConditionStatus
+		expectReason     string
+		expectFailed     bool
+		expectProvStatus v1beta1.ServiceInstanceProvisionStatus
+	}{
+		{
+			name:             "without verification",
+			expectReady:      v1beta1.ConditionTrue,
+			expectReason:     successAdoptionReason,
+			expectProvStatus: v1beta1.ServiceInstanceProvisionStatusProvisioned,
+		},
+		{
+			name:   "verified",
+			verify: true,
+			getInstance: &fakebrokerclient.GetInstanceReaction{
+				Response: &brokerclient.GetInstanceResponse{
+					ServiceID:    testClusterServiceClassGUID,
+					PlanID:       testClusterServicePlanGUID,
+					DashboardURL: &testDashboardURL,
+				},
+			},
+			expectReady:      v1beta1.ConditionTrue,
+			expectReason:     successAdoptionReason,
+			expectProvStatus: v1beta1.ServiceInstanceProvisionStatusProvisioned,
+		},
+		{
+			name:   "instance missing at the broker",
+			verify: true,
+			getInstance: &fakebrokerclient.GetInstanceReaction{
+				Error: osb.HTTPStatusCodeError{StatusCode: http.StatusNotFound},
+			},
+			expectReady:  v1beta1.ConditionFalse,
+			expectReason: errorAdoptionFailedReason,
+			expectFailed: true,
+		},
+		{
+			name:   "instance of another plan",
+			verify: true,
+			getInstance: &fakebrokerclient.GetInstanceReaction{
+				Response: &brokerclient.GetInstanceResponse{
+					ServiceID: testClusterServiceClassGUID,
+					PlanID:    "other-plan",
+				},
+			},
+			expectReady:  v1beta1.ConditionFalse,
+			expectReason: errorAdoptionFailedReason,
+			expectFailed: true,
+		},
+		{
+			name:         "instance managed by another ServiceInstance",
+			adopted:      true,
+			expectReady:  v1beta1.ConditionFalse,
+			expectReason: errorAdoptionFailedReason,
+			expectFailed: true,
+		},
+	}
+
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.InstanceAdoption))
+	if err != nil {
+		t.Fatalf("Failed to enable InstanceAdoption feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.InstanceAdoption))
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{})
+			fakeClusterServiceBrokerClient.GetInstanceReaction = tc.getInstance
+
+			addGetNamespaceReaction(fakeKubeClient)
+
+			sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			instance := getTestServiceInstanceWithRefs()
+			verify := tc.verify
+			instance.Spec.Adoption = &v1beta1.ServiceInstanceAdoption{Verify: &verify}
+			if tc.adopted {
+				owner := getTestServiceInstanceWithRefs()
+				owner.Name = "owner"
+				owner.UID = "owner-uid"
+				sharedInformers.ServiceInstances().Informer().GetStore().Add(owner)
+			}
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			instance = assertServiceInstanceProvisionInProgressIsTheOnlyCatalogClientAction(t, fakeCatalogClient, instance)
+			fakeCatalogClient.ClearActions()
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			if tc.verify {
+				assertNumberOfClusterServiceBrokerActions(t, brokerActions, 1)
+				expectedRequest := &brokerclient.GetInstanceRequest{
+					InstanceID: testServiceInstanceGUID,
+					ServiceID:  testClusterServiceClassGUID,
+					PlanID:     testClusterServicePlanGUID,
+				}
+				if e, a := expectedRequest, brokerActions[0].Request; !reflect.DeepEqual(e, a) {
+					t.Fatalf("Unexpected GetInstance request: %s", expectedGot(e, a))
+				}
+			} else {
+				assertNumberOfClusterServiceBrokerActions(t, brokerActions, 0)
+			}
+
+			actions := fakeCatalogClient.Actions()
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+			assertServiceInstanceReadyCondition(t, updatedServiceInstance, tc.expectReady, tc.expectReason)
+			assertServiceInstanceProvisioned(t, updatedServiceInstance, tc.expectProvStatus)
+			if tc.expectFailed {
+				assertServiceInstanceCondition(t, updatedServiceInstance, v1beta1.ServiceInstanceConditionFailed, v1beta1.ConditionTrue, errorAdoptionFailedReason)
+				assertServiceInstanceDeprovisionStatus(t, updatedServiceInstance, v1beta1.ServiceInstanceDeprovisionStatusNotRequired)
+			} else {
+				assertServiceInstanceDeprovisionStatus(t, updatedServiceInstance, v1beta1.ServiceInstanceDeprovisionStatusRequired)
+			}
+		})
+	}
+}
+
+// TestReconcileServiceInstanceNamespaced tests synchronously provisioning a
+// new service instance of a namespaced ServiceClass and ServicePlan.
+func TestReconcileServiceInstanceNamespaced(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.NamespacedServiceBroker))
+	if err != nil {
+		t.Fatalf("Failed to enable namespaced service broker feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.NamespacedServiceBroker))
+
+	fakeKubeClient, fakeCatalogClient, fakeServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
+		ProvisionReaction: &fakeosb.ProvisionReaction{
+			Response: &osb.ProvisionResponse{},
+		},
+	})
+
+	addGetNamespaceReaction(fakeKubeClient)
+
+	sharedInformers.ServiceBrokers().Informer().GetStore().Add(getTestServiceBroker())
+	sharedInformers.ServiceClasses().Informer().GetStore().Add(getTestServiceClass())
+	sharedInformers.ServicePlans().Informer().GetStore().Add(getTestServicePlan())
+
+	instance := getTestServiceInstanceWithNamespacedRefs()
+
+	if err = reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	instance = assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	if instance.Status.InProgressProperties == nil {
+		t.Fatalf("Expected in-progress properties to be set")
+	}
+	if e, a := testClusterServicePlanGUID, instance.Status.InProgressProperties.ServicePlanExternalID; e != a {
+		t.Fatalf("Unexpected in-progress plan ID; %s", expectedGot(e, a))
+	}
+	if e, a := "", instance.Status.InProgressProperties.ClusterServicePlanExternalID; e != a {
+		t.Fatalf("Unexpected in-progress cluster plan ID; %s", expectedGot(e, a))
+	}
+	fakeCatalogClient.ClearActions()
+	fakeKubeClient.ClearActions()
+
+	if err = reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("This should not fail : %v", err)
+	}
+
+	brokerActions := fakeServiceBrokerClient.Actions()
+	assertNumberOfClusterServiceBrokerActions(t, brokerActions, 1)
+	assertProvision(t, brokerActions[0], &osb.ProvisionRequest{
+		AcceptsIncomplete: true,
+		InstanceID:        testServiceInstanceGUID,
+		ServiceID:         testClusterServiceClassGUID,
+		PlanID:            testClusterServicePlanGUID,
+		OrganizationGUID:  testNamespaceGUID,
+		SpaceGUID:         testNamespaceGUID,
+		Context:           testContext})
+
+	actions = fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+	assertServiceInstanceReadyTrue(t, updatedServiceInstance)
+	if updatedServiceInstance.Status.ExternalProperties == nil {
+		t.Fatalf("Expected external properties to be set")
+	}
+	if e, a := testClusterServicePlanName, updatedServiceInstance.Status.ExternalProperties.ServicePlanExternalName; e != a {
+		t.Fatalf("Unexpected external plan name; %s", expectedGot(e, a))
+	}
+}
+
 // TestReconcileServiceInstanceFailsWithDeletedPlan tests that a ServiceInstance is not
 // created if the ServicePlan specified is marked as RemovedFromCatalog.
 func TestReconcileServiceInstanceFailsWithDeletedPlan(t *testing.T) {
@@ -1562,6 +1766,111 @@ func TestReconcileServiceInstanceNamespaceError(t *testing.T) {
 	}
 }
 
+// TestReconcileServiceInstanceWaitingForDependency tests that an instance
+// whose ParametersFrom refer to an instance that is not Ready is not
+// provisioned until that instance is Ready.
+func TestReconcileServiceInstanceWaitingForDependency(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.CrossInstanceReferences))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.CrossInstanceReferences))
+
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithRefs()
+	instance.Spec.ParametersFrom = []v1beta1.ParametersFromSource{{
+		ServiceInstanceFieldRef: &v1beta1.ServiceInstanceFieldReference{
+			Name:      "database",
+			FieldPath: v1beta1.ServiceInstanceFieldPathExternalID,
+			Parameter: "databaseID",
+		},
+	}}
+
+	if err := reconcileServiceInstance(t, testController, instance); err == nil {
+		t.Fatalf("The instance should wait for its dependency")
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfClusterServiceBrokerActions(t, brokerActions, 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceErrorBeforeRequest(t, updatedServiceInstance, waitingForDependencyReason, instance)
+
+	events := getRecordedEvents(testController)
+	expectedEvent := warningEventBuilder(waitingForDependencyReason).msg(`Waiting for a dependency: ServiceInstance "test-ns/database" does not exist`)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Once the dependency is Ready, the instance is provisioned with the
+	// referenced field.
+	dependency := getTestServiceInstanceWithRefs()
+	dependency.Name = "database"
+	dependency.Spec.ExternalID = "database-id"
+	dependency.Status.Conditions = []v1beta1.ServiceInstanceCondition{{
+		Type:   v1beta1.ServiceInstanceConditionReady,
+		Status: v1beta1.ConditionTrue,
+	}}
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(dependency)
+	fakeCatalogClient.ClearActions()
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedParameters := map[string]interface{}{"databaseID": "database-id"}
+	assertServiceInstanceOperationInProgressWithParametersIsTheOnlyCatalogClientAction(t,
+		fakeCatalogClient,
+		instance,
+		v1beta1.ServiceInstanceOperationProvision,
+		testClusterServicePlanName,
+		testClusterServicePlanGUID,
+		expectedParameters,
+		generateChecksumOfParametersOrFail(t, expectedParameters),
+	)
+}
+
+// TestReconcileServiceInstanceDependencyCycle tests that an instance whose
+// ParametersFrom refer back to it fails instead of waiting forever.
+func TestReconcileServiceInstanceDependencyCycle(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.CrossInstanceReferences))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.CrossInstanceReferences))
+
+	_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithRefs()
+	instance.Spec.ParametersFrom = []v1beta1.ParametersFromSource{{
+		ServiceInstanceFieldRef: &v1beta1.ServiceInstanceFieldReference{
+			Name:      instance.Name,
+			FieldPath: v1beta1.ServiceInstanceFieldPathExternalID,
+			Parameter: "selfID",
+		},
+	}}
+	sharedInformers.ServiceInstances().Informer().GetStore().Add(instance)
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfClusterServiceBrokerActions(t, brokerActions, 0)
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 1)
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertServiceInstanceReadyCondition(t, updatedServiceInstance, v1beta1.ConditionFalse, errorDependencyFailedReason)
+	assertServiceInstanceCondition(t, updatedServiceInstance, v1beta1.ServiceInstanceConditionFailed, v1beta1.ConditionTrue, errorDependencyFailedReason)
+	assertServiceInstanceDeprovisionStatus(t, updatedServiceInstance, v1beta1.ServiceInstanceDeprovisionStatusNotRequired)
+}
+
 // TestReconcileServiceInstanceDelete tests deleting/deprovisioning an instance
 func TestReconcileServiceInstanceDelete(t *testing.T) {
 	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{
@@ -2161,6 +2470,58 @@ func TestReconcileServiceInstanceDeleteDoesNotInvokeClusterServiceBroker(t *test
 	assertNumEvents(t, events, 0)
 }
 
+// TestReconcileServiceInstanceDeleteRetained tests that deleting an instance
+// whose deletion policy is Retain removes the finalizer without deprovisioning
+// the instance at the broker.
+func TestReconcileServiceInstanceDeleteRetained(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.DeletionPolicy))
+	if err != nil {
+		t.Fatalf("Failed to enable DeletionPolicy feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.DeletionPolicy))
+
+	fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+	sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+	sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+	instance := getTestServiceInstanceWithRefs()
+	instance.ObjectMeta.DeletionTimestamp = &metav1.Time{}
+	instance.ObjectMeta.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	instance.Spec.DeletionPolicy = v1beta1.ServiceInstanceDeletionPolicyRetain
+	instance.Generation = 2
+	instance.Status.ReconciledGeneration = 1
+	instance.Status.ObservedGeneration = 1
+	instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+	instance.Status.DeprovisionStatus = v1beta1.ServiceInstanceDeprovisionStatusRequired
+
+	if err := reconcileServiceInstance(t, testController, instance); err != nil {
+		t.Fatalf("This should not fail : %v", err)
+	}
+
+	brokerActions := fakeClusterServiceBrokerClient.Actions()
+	assertNumberOfClusterServiceBrokerActions(t, brokerActions, 0)
+
+	kubeActions := fakeKubeClient.Actions()
+	assertNumberOfActions(t, kubeActions, 0)
+
+	actions := fakeCatalogClient.Actions()
+	// The one actions should be:
+	// 0. Removing the finalizer
+	assertNumberOfActions(t, actions, 1)
+
+	updatedServiceInstance := assertUpdateStatus(t, actions[0], instance)
+	assertEmptyFinalizers(t, updatedServiceInstance)
+	assertServiceInstanceDeprovisionStatus(t, updatedServiceInstance, v1beta1.ServiceInstanceDeprovisionStatusNotRequired)
+
+	events := getRecordedEvents(testController)
+	expectedEvent := normalEventBuilder(instanceRetainedReason).msg(instanceRetainedMessage)
+	if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
 // TestReconcileServiceInstanceWithFailedCondition tests reconciling an instance that
 // has a status condition set to Failed.
 // Instances with Failed condition are retriable after updating the spec.
@@ -4973,6 +5334,76 @@ func TestResolveReferencesWorks(t *testing.T) {
 	assertNumEvents(t, events, 0)
 }
 
+// TestResolveReferencesWorksNamespaced tests that resolveReferences resolves
+// references to a ServiceClass and ServicePlan in the instance's namespace.
+func TestResolveReferencesWorksNamespaced(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.NamespacedServiceBroker))
+	if err != nil {
+		t.Fatalf("Failed to enable namespaced service broker feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.NamespacedServiceBroker))
+
+	fakeKubeClient, fakeCatalogClient, _, testController, _ := newTestController(t, noFakeActions())
+
+	instance := getTestServiceInstanceNamespaced()
+
+	sc := getTestServiceClass()
+	fakeCatalogClient.AddReactor("list", "serviceclasses", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ServiceClassList{Items: []v1beta1.ServiceClass{*sc}}, nil
+	})
+	sp := getTestServicePlan()
+	fakeCatalogClient.AddReactor("list", "serviceplans", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ServicePlanList{Items: []v1beta1.ServicePlan{*sp}}, nil
+	})
+
+	modified, err := testController.resolveReferences(instance)
+	if err != nil {
+		t.Fatalf("Should not have failed, but failed with: %q", err)
+	}
+
+	if !modified {
+		t.Fatalf("Should have returned true")
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 3)
+
+	listRestrictions := clientgotesting.ListRestrictions{
+		Labels: labels.Everything(),
+		Fields: fields.OneTermEqualSelector("spec.externalName", instance.Spec.ServiceClassExternalName),
+	}
+	assertList(t, actions[0], &v1beta1.ServiceClass{}, listRestrictions)
+
+	listRestrictions = clientgotesting.ListRestrictions{
+		Labels: labels.Everything(),
+		Fields: fields.ParseSelectorOrDie("spec.externalName=test-clusterserviceplan,spec.serviceBrokerName=test-servicebroker,spec.serviceClassRef.name=CSCGUID"),
+	}
+	assertList(t, actions[1], &v1beta1.ServicePlan{}, listRestrictions)
+
+	for _, action := range actions {
+		if e, a := testNamespace, action.GetNamespace(); e != a {
+			t.Fatalf("Unexpected namespace for action %v; %s", action, expectedGot(e, a))
+		}
+	}
+
+	updatedServiceInstance := assertUpdateReference(t, actions[2], instance)
+	updateObject, ok := updatedServiceInstance.(*v1beta1.ServiceInstance)
+	if !ok {
+		t.Fatalf("couldn't convert to *v1beta1.ServiceInstance")
+	}
+	if updateObject.Spec.ServiceClassRef == nil || updateObject.Spec.ServiceClassRef.Name != testClusterServiceClassGUID {
+		t.Fatalf("ServiceClassRef was not resolved correctly during reconcile")
+	}
+	if updateObject.Spec.ServicePlanRef == nil || updateObject.Spec.ServicePlanRef.Name != testClusterServicePlanGUID {
+		t.Fatalf("ServicePlanRef was not resolved correctly during reconcile")
+	}
+	if updateObject.Spec.ClusterServiceClassRef != nil || updateObject.Spec.ClusterServicePlanRef != nil {
+		t.Fatalf("Cluster-scoped references should not have been set")
+	}
+
+	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+}
+
 // TestResolveReferencesForPlanChange tests that resolveReferences updates the
 // ClusterServicePlanRef when the plan is changed.
 func TestResolveReferencesForPlanChange(t *testing.T) {
@@ -5791,3 +6222,501 @@ func reconcileServiceInstance(t *testing.T, testController *controller, instance
 	}
 	return err
 }
+
+// TestReconcileServiceInstanceParametersFromSync verifies that an update is
+// requested for a reconciled instance when the values of the Secrets
+// referenced by its ParametersFrom have changed.
+func TestReconcileServiceInstanceParametersFromSync(t *testing.T) {
+	cases := []struct {
+		name         string
+		featureOff   bool
+		secretValue  string
+		optOut       bool
+		failed       bool
+		expectUpdate bool
+	}{
+		{
+			name:        "secret unchanged",
+			secretValue: `{"b":"2"}`,
+		},
+		{
+			name:         "secret changed",
+			secretValue:  `{"b":"3"}`,
+			expectUpdate: true,
+		},
+		{
+			name:        "secret changed, feature disabled",
+			featureOff:  true,
+			secretValue: `{"b":"3"}`,
+		},
+		{
+			name:        "secret changed, instance opted out",
+			secretValue: `{"b":"3"}`,
+			optOut:      true,
+		},
+		{
+			name:        "secret changed, instance failed",
+			secretValue: `{"b":"3"}`,
+			failed:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tc.featureOff {
+				err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ParametersFromSync))
+				if err != nil {
+					t.Fatalf("Failed to enable ParametersFromSync feature: %v", err)
+				}
+				defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ParametersFromSync))
+			}
+
+			fakeKubeClient, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, _ := newTestController(t, noFakeActions())
+			setTestSecretLister(testController, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "param-secret-name", Namespace: testNamespace},
+				Data: map[string][]byte{
+					"param-secret-key": []byte(tc.secretValue),
+				},
+			})
+
+			instance := getTestServiceInstanceWithStatus(v1beta1.ConditionTrue)
+			instance.Status.ObservedGeneration = instance.Generation
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+			instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+				ParametersChecksum: generateChecksumOfParametersOrFail(t, map[string]interface{}{
+					"a": "1",
+					"b": "2",
+				}),
+			}
+			instance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(`{"a":"1"}`)}
+			instance.Spec.ParametersFrom = []v1beta1.ParametersFromSource{
+				{
+					SecretKeyRef: &v1beta1.SecretKeyReference{
+						Name: "param-secret-name",
+						Key:  "param-secret-key",
+					},
+				},
+			}
+			if tc.optOut {
+				instance.Annotations = map[string]string{v1beta1.DisableParametersFromSyncAnnotation: "true"}
+			}
+			if tc.failed {
+				setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionFailed, v1beta1.ConditionTrue, "", "")
+			}
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfClusterServiceBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			// The Secret is read from the Secret informer.
+			assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+
+			actions := fakeCatalogClient.Actions()
+			events := getRecordedEvents(testController)
+			if !tc.expectUpdate {
+				assertNumberOfActions(t, actions, 0)
+				assertNumEvents(t, events, 0)
+				return
+			}
+
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceInstance := assertUpdate(t, actions[0], instance).(*v1beta1.ServiceInstance)
+			if e, a := instance.Spec.UpdateRequests+1, updatedServiceInstance.Spec.UpdateRequests; e != a {
+				t.Fatalf("Unexpected UpdateRequests: %s", expectedGot(e, a))
+			}
+
+			expectedEvent := normalEventBuilder(parametersFromChangedReason).msg(parametersFromChangedMessage)
+			if err := checkEvents(events, expectedEvent.stringArr()); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestReconcileServiceInstanceUpgradeAvailable verifies that the
+// UpgradeAvailable condition of a reconciled instance follows the version of
+// the maintenance information of its plan.
+func TestReconcileServiceInstanceUpgradeAvailable(t *testing.T) {
+	cases := []struct {
+		name            string
+		planVersion     string
+		instanceVersion string
+		upgradeAvail    bool
+		expectCondition v1beta1.ConditionStatus
+	}{
+		{
+			name: "plan without maintenance info",
+		},
+		{
+			name:            "instance up to date",
+			planVersion:     "1.0.0",
+			instanceVersion: "1.0.0",
+		},
+		{
+			name:            "upgrade available",
+			planVersion:     "2.0.0",
+			instanceVersion: "1.0.0",
+			expectCondition: v1beta1.ConditionTrue,
+		},
+		{
+			name:            "upgrade completed",
+			planVersion:     "2.0.0",
+			instanceVersion: "2.0.0",
+			upgradeAvail:    true,
+			expectCondition: v1beta1.ConditionFalse,
+		},
+	}
+
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.MaintenanceInfo))
+	if err != nil {
+		t.Fatalf("Failed to enable MaintenanceInfo feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.MaintenanceInfo))
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, noFakeActions())
+
+			plan := getTestClusterServicePlan()
+			if tc.planVersion != "" {
+				plan.Spec.MaintenanceInfo = &v1beta1.MaintenanceInfo{Version: tc.planVersion}
+			}
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(plan)
+
+			instance := getTestServiceInstanceWithStatus(v1beta1.ConditionTrue)
+			instance.Status.ObservedGeneration = instance.Generation
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+			instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+				ClusterServicePlanExternalID: testClusterServicePlanGUID,
+				MaintenanceInfoVersion:       tc.instanceVersion,
+			}
+			if tc.upgradeAvail {
+				setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionUpgradeAvailable, v1beta1.ConditionTrue, upgradeAvailableReason, "")
+			}
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertNumberOfClusterServiceBrokerActions(t, fakeClusterServiceBrokerClient.Actions(), 0)
+
+			actions := fakeCatalogClient.Actions()
+			if tc.expectCondition == "" {
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+
+			assertNumberOfActions(t, actions, 1)
+			updatedServiceInstance := assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+			for _, cond := range updatedServiceInstance.Status.Conditions {
+				if cond.Type != v1beta1.ServiceInstanceConditionUpgradeAvailable {
+					continue
+				}
+				if e, a := tc.expectCondition, cond.Status; e != a {
+					t.Fatalf("Unexpected UpgradeAvailable condition status: %s", expectedGot(e, a))
+				}
+				return
+			}
+			t.Fatalf("Expected an UpgradeAvailable condition, got %+v", updatedServiceInstance.Status.Conditions)
+		})
+	}
+}
+
+// TestReconcileServiceInstanceBrokerSync tests fetching a provisioned
+// instance from its broker to record the broker's view of it.
+func TestReconcileServiceInstanceBrokerSync(t *testing.T) {
+	const syncInterval = 10 * time.Minute
+
+	cases := []struct {
+		name               string
+		notRetrievable     bool
+		lastSyncedAgo      time.Duration
+		lastFetchedAgo     time.Duration
+		drifted            bool
+		inSync             bool
+		reportedParameters map[string]interface{}
+		getInstanceError   error
+		expectGetInstance  bool
+		expectNoUpdate     bool
+		expectParameters   string
+		expectDrifted      v1beta1.ConditionStatus
+		expectEventPrefix  string
+	}{
+		{
+			name:           "class not retrievable",
+			notRetrievable: true,
+		},
+		{
+			name:          "sync interval not elapsed",
+			lastSyncedAgo: time.Minute,
+		},
+		{
+			name:           "fetched since the last change",
+			lastSyncedAgo:  time.Hour,
+			lastFetchedAgo: time.Minute,
+		},
+		{
+			name:               "no change reported",
+			lastSyncedAgo:      time.Hour,
+			lastFetchedAgo:     time.Hour,
+			inSync:             true,
+			reportedParameters: map[string]interface{}{"a": "b", "password": "secret"},
+			expectGetInstance:  true,
+			expectNoUpdate:     true,
+		},
+		{
+			name:               "parameters in sync",
+			lastSyncedAgo:      time.Hour,
+			reportedParameters: map[string]interface{}{"a": "b", "password": "secret", "size": "small"},
+			expectGetInstance:  true,
+			expectParameters:   `{"a":"b","password":"\u003credacted\u003e","size":"small"}`,
+		},
+		{
+			name:               "parameters drifted",
+			reportedParameters: map[string]interface{}{"a": "edited", "password": "secret"},
+			expectGetInstance:  true,
+			expectParameters:   `{"a":"edited","password":"\u003credacted\u003e"}`,
+			expectDrifted:      v1beta1.ConditionTrue,
+			expectEventPrefix:  warningEventBuilder(parametersDriftedReason).String(),
+		},
+		{
+			name:               "parameter removed",
+			reportedParameters: map[string]interface{}{"a": "b"},
+			expectGetInstance:  true,
+			expectParameters:   `{"a":"b"}`,
+			expectDrifted:      v1beta1.ConditionTrue,
+			expectEventPrefix:  warningEventBuilder(parametersDriftedReason).String(),
+		},
+		{
+			name:               "drift resolved",
+			drifted:            true,
+			reportedParameters: map[string]interface{}{"a": "b", "password": "secret"},
+			expectGetInstance:  true,
+			expectParameters:   `{"a":"b","password":"\u003credacted\u003e"}`,
+			expectDrifted:      v1beta1.ConditionFalse,
+		},
+		{
+			name:              "get instance failure",
+			getInstanceError:  errors.New("fake get instance failure"),
+			expectGetInstance: true,
+			expectNoUpdate:    true,
+			expectEventPrefix: warningEventBuilder(errorSyncingInstanceReason).String(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			dashboardURL := "http://dashboard"
+			_, fakeCatalogClient, fakeClusterServiceBrokerClient, testController, sharedInformers := newTestController(t, fakeosb.FakeClientConfiguration{})
+			fakeClusterServiceBrokerClient.GetInstanceReaction = &fakebrokerclient.GetInstanceReaction{
+				Response: &brokerclient.GetInstanceResponse{
+					ServiceID:    testClusterServiceClassGUID,
+					PlanID:       testClusterServicePlanGUID,
+					DashboardURL: &dashboardURL,
+					Parameters:   tc.reportedParameters,
+				},
+				Error: tc.getInstanceError,
+			}
+			testController.instanceSyncInterval = syncInterval
+
+			serviceClass := getTestClusterServiceClass()
+			serviceClass.Spec.InstancesRetrievable = !tc.notRetrievable
+			// The broker client is not needed to find out that the instance
+			// can not be fetched.
+			if !tc.notRetrievable {
+				sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+			}
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(serviceClass)
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+
+			instance := getTestServiceInstanceWithStatus(v1beta1.ConditionTrue)
+			instance.Status.ObservedGeneration = instance.Generation
+			instance.Status.ProvisionStatus = v1beta1.ServiceInstanceProvisionStatusProvisioned
+			instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+				ClusterServicePlanExternalID: testClusterServicePlanGUID,
+				Parameters:                   &runtime.RawExtension{Raw: []byte(`{"a":"b","password":"<redacted>"}`)},
+			}
+			if tc.drifted {
+				setServiceInstanceCondition(instance, v1beta1.ServiceInstanceConditionParametersDrifted, v1beta1.ConditionTrue, parametersDriftedReason, "")
+			}
+			if tc.lastSyncedAgo != 0 {
+				lastSyncTime := metav1.NewTime(time.Now().Add(-tc.lastSyncedAgo))
+				instance.Status.LastBrokerSyncTime = &lastSyncTime
+			}
+			if tc.lastFetchedAgo != 0 {
+				testController.instanceFetchTimes[instance.UID] = time.Now().Add(-tc.lastFetchedAgo)
+			}
+			if tc.inSync {
+				instance.Status.BrokerProperties = &v1beta1.ServiceInstanceBrokerProperties{
+					DashboardURL: &dashboardURL,
+					Parameters:   &runtime.RawExtension{Raw: []byte(`{"a":"b","password":"\u003credacted\u003e"}`)},
+				}
+			}
+
+			if err := reconcileServiceInstance(t, testController, instance); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			brokerActions := fakeClusterServiceBrokerClient.Actions()
+			actions := fakeCatalogClient.Actions()
+			if !tc.expectGetInstance {
+				assertNumberOfClusterServiceBrokerActions(t, brokerActions, 0)
+				assertNumberOfActions(t, actions, 0)
+				return
+			}
+
+			assertNumberOfClusterServiceBrokerActions(t, brokerActions, 1)
+			expectedRequest := &brokerclient.GetInstanceRequest{
+				InstanceID: testServiceInstanceGUID,
+				ServiceID:  testClusterServiceClassGUID,
+				PlanID:     testClusterServicePlanGUID,
+			}
+			if e, a := expectedRequest, brokerActions[0].Request; !reflect.DeepEqual(e, a) {
+				t.Fatalf("Unexpected GetInstance request: %s", expectedGot(e, a))
+			}
+
+			if _, ok := testController.instanceFetchTimes[instance.UID]; !ok {
+				t.Fatal("expected the fetch time to be recorded")
+			}
+			if tc.expectNoUpdate {
+				assertNumberOfActions(t, actions, 0)
+			} else {
+				assertNumberOfActions(t, actions, 1)
+				updatedServiceInstance := assertUpdateStatus(t, actions[0], instance).(*v1beta1.ServiceInstance)
+				if updatedServiceInstance.Status.LastBrokerSyncTime == nil {
+					t.Fatal("expected the last broker sync time to be set")
+				}
+				brokerProperties := updatedServiceInstance.Status.BrokerProperties
+				if brokerProperties == nil {
+					t.Fatal("expected the broker properties to be set")
+				}
+				if e, a := dashboardURL, *brokerProperties.DashboardURL; e != a {
+					t.Fatalf("Unexpected dashboard URL: %s", expectedGot(e, a))
+				}
+				if e, a := tc.expectParameters, string(brokerProperties.Parameters.Raw); e != a {
+					t.Fatalf("Unexpected broker parameters: %s", expectedGot(e, a))
+				}
+				var drifted v1beta1.ConditionStatus
+				for _, cond := range updatedServiceInstance.Status.Conditions {
+					if cond.Type == v1beta1.ServiceInstanceConditionParametersDrifted {
+						drifted = cond.Status
+					}
+				}
+				if e, a := tc.expectDrifted, drifted; e != a {
+					t.Fatalf("Unexpected status of the ParametersDrifted condition: %s", expectedGot(e, a))
+				}
+			}
+
+			events := getRecordedEvents(testController)
+			if tc.expectEventPrefix == "" {
+				if len(events) != 0 {
+					t.Fatalf("expected no events, got %v", events)
+				}
+			} else if err := checkEventPrefixes(events, []string{tc.expectEventPrefix}); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+// TestSetUpdateInstanceRequestMaintenanceInfo verifies the maintenance
+// information sent to the broker when updating an instance.
+func TestSetUpdateInstanceRequestMaintenanceInfo(t *testing.T) {
+	cases := []struct {
+		name            string
+		planChanged     bool
+		planVersion     string
+		instanceVersion string
+		specVersion     string
+		expectVersion   string
+		expectSent      bool
+		expectError     bool
+	}{
+		{
+			name:            "no upgrade requested",
+			planVersion:     "2.0.0",
+			instanceVersion: "1.0.0",
+			expectVersion:   "1.0.0",
+		},
+		{
+			name:            "upgrade requested",
+			planVersion:     "2.0.0",
+			instanceVersion: "1.0.0",
+			specVersion:     "2.0.0",
+			expectVersion:   "2.0.0",
+			expectSent:      true,
+		},
+		{
+			name:            "upgrade already done",
+			planVersion:     "2.0.0",
+			instanceVersion: "2.0.0",
+			specVersion:     "2.0.0",
+			expectVersion:   "2.0.0",
+		},
+		{
+			name:            "unavailable version requested",
+			planVersion:     "2.0.0",
+			instanceVersion: "1.0.0",
+			specVersion:     "3.0.0",
+			expectError:     true,
+		},
+		{
+			name:            "plan changed",
+			planChanged:     true,
+			planVersion:     "3.0.0",
+			instanceVersion: "1.0.0",
+			expectVersion:   "3.0.0",
+			expectSent:      true,
+		},
+		{
+			name:            "plan changed to a plan without maintenance info",
+			planChanged:     true,
+			instanceVersion: "1.0.0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			instance := getTestServiceInstanceWithRefs()
+			instance.Spec.MaintenanceInfoVersion = tc.specVersion
+			instance.Status.ExternalProperties = &v1beta1.ServiceInstancePropertiesState{
+				MaintenanceInfoVersion: tc.instanceVersion,
+			}
+			plan := &v1beta1.CommonServicePlanSpec{}
+			if tc.planVersion != "" {
+				plan.MaintenanceInfo = &v1beta1.MaintenanceInfo{Version: tc.planVersion}
+			}
+			request := &brokerclient.UpdateInstanceRequest{}
+			if tc.planChanged {
+				request.PlanID = strPtr(testClusterServicePlanGUID)
+			}
+			inProgressProperties := &v1beta1.ServiceInstancePropertiesState{}
+
+			err := setUpdateInstanceRequestMaintenanceInfo(instance, plan, request, inProgressProperties)
+			if tc.expectError {
+				if err == nil {
+					t.Fatal("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if e, a := tc.expectVersion, inProgressProperties.MaintenanceInfoVersion; e != a {
+				t.Fatalf("Unexpected in-progress version: %s", expectedGot(e, a))
+			}
+			if !tc.expectSent {
+				if request.MaintenanceInfo != nil {
+					t.Fatalf("Expected no maintenance info in the request, got %v", *request.MaintenanceInfo.Version)
+				}
+				return
+			}
+			if request.MaintenanceInfo == nil || *request.MaintenanceInfo.Version != tc.expectVersion {
+				t.Fatalf("Expected maintenance info version %q in the request, got %+v", tc.expectVersion, request.MaintenanceInfo)
+			}
+		})
+	}
+}
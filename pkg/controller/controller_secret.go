@@ -0,0 +1,271 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Secret handlers. Secrets are watched so that the ServiceInstances whose
+// ParametersFrom reference them, the ServiceBindings whose AddKeysFrom
+// transforms reference them, and the brokers whose auth credentials they hold,
+// are reconciled again when they change. They are only watched when
+// ParametersFromSync or SecretTransformSync is enabled; otherwise, the auth
+// Secret of a broker is read from the API server each time its client is
+// needed, and the client is created again when the Secret changed.
+
+// bindingSecretTransformSourceIndex is the name of the index of the
+// ServiceBinding informer on the Secrets referenced by the AddKeysFrom
+// transforms of the bindings, as "namespace/name".
+const bindingSecretTransformSourceIndex = "secretTransformSource"
+
+// secretsWatched returns whether the controller watches Secrets.
+func secretsWatched() bool {
+	return utilfeature.DefaultFeatureGate.Enabled(scfeatures.ParametersFromSync) ||
+		utilfeature.DefaultFeatureGate.Enabled(scfeatures.SecretTransformSync)
+}
+
+// getSecret returns the Secret with the given namespace and name, from the
+// informer cache when Secrets are watched, and from the API server otherwise.
+func (c *controller) getSecret(namespace, name string) (*corev1.Secret, error) {
+	if c.secretLister != nil {
+		return c.secretLister.Secrets(namespace).Get(name)
+	}
+	return c.kubeClient.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+}
+
+// listSecrets returns the Secrets of all namespaces matching the given
+// selector, from the informer cache when Secrets are watched, and from the API
+// server otherwise.
+func (c *controller) listSecrets(selector labels.Selector) ([]*corev1.Secret, error) {
+	if c.secretLister != nil {
+		return c.secretLister.List(selector)
+	}
+	list, err := c.kubeClient.CoreV1().Secrets(metav1.NamespaceAll).List(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+	secrets := make([]*corev1.Secret, 0, len(list.Items))
+	for i := range list.Items {
+		secrets = append(secrets, &list.Items[i])
+	}
+	return secrets, nil
+}
+
+func (c *controller) secretAdd(obj interface{}) {
+	secret, ok := obj.(*corev1.Secret)
+	if secret == nil || !ok {
+		return
+	}
+	c.enqueueSecretDependents(secret)
+}
+
+func (c *controller) secretUpdate(oldObj, newObj interface{}) {
+	oldSecret, ok := oldObj.(*corev1.Secret)
+	if oldSecret == nil || !ok {
+		return
+	}
+	newSecret, ok := newObj.(*corev1.Secret)
+	if newSecret == nil || !ok {
+		return
+	}
+	// Resyncs and changes to the metadata of the secret cannot change the
+	// parameters of the instances nor the credentials of the bindings.
+	if reflect.DeepEqual(oldSecret.Data, newSecret.Data) {
+		return
+	}
+	c.enqueueSecretDependents(newSecret)
+}
+
+func (c *controller) secretDelete(obj interface{}) {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		secret, ok = tombstone.Obj.(*corev1.Secret)
+		if !ok {
+			return
+		}
+	}
+	if secret == nil {
+		return
+	}
+	// Deleting a secret does not change the parameters of the instances, it
+	// only makes their next update fail.
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.SecretTransformSync) {
+		c.enqueueServiceBindingsReferencingSecret(secret)
+	}
+	c.enqueueBrokersReferencingSecret(secret)
+}
+
+// enqueueSecretDependents adds the instances, bindings and brokers whose
+// parameters or credentials depend on the given secret to their work queues.
+func (c *controller) enqueueSecretDependents(secret *corev1.Secret) {
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ParametersFromSync) {
+		c.enqueueServiceInstancesReferencingSecret(secret)
+	}
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.SecretTransformSync) {
+		c.enqueueServiceBindingsReferencingSecret(secret)
+	}
+	c.enqueueBrokersReferencingSecret(secret)
+}
+
+// enqueueServiceInstancesReferencingSecret adds the instances whose
+// ParametersFrom reference the given secret to the instance work queue.
+func (c *controller) enqueueServiceInstancesReferencingSecret(secret *corev1.Secret) {
+	instances, err := c.instanceLister.ServiceInstances(secret.Namespace).List(labels.Everything())
+	if err != nil {
+		glog.Errorf("Secret: Couldn't list ServiceInstances in namespace %q: %v", secret.Namespace, err)
+		return
+	}
+	for _, instance := range instances {
+		if serviceInstanceReferencesSecret(instance, secret.Name) {
+			glog.V(4).Infof("Secret: %v/%v is referenced by the parameters of ServiceInstance %q", secret.Namespace, secret.Name, instance.Name)
+			c.instanceAdd(instance)
+		}
+	}
+}
+
+// serviceInstanceReferencesSecret returns whether the ParametersFrom of the
+// given instance reference the secret with the given name.
+func serviceInstanceReferencesSecret(instance *v1beta1.ServiceInstance, secretName string) bool {
+	for _, parametersFrom := range instance.Spec.ParametersFrom {
+		if parametersFrom.SecretKeyRef != nil && parametersFrom.SecretKeyRef.Name == secretName {
+			return true
+		}
+	}
+	return false
+}
+
+// enqueueServiceBindingsReferencingSecret adds the bindings whose AddKeysFrom
+// transforms reference the given secret to the binding work queue.
+func (c *controller) enqueueServiceBindingsReferencingSecret(secret *corev1.Secret) {
+	bindings, err := c.bindingIndexer.ByIndex(bindingSecretTransformSourceIndex, secret.Namespace+"/"+secret.Name)
+	if err != nil {
+		glog.Errorf("Secret: Couldn't list ServiceBindings referencing %v/%v: %v", secret.Namespace, secret.Name, err)
+		return
+	}
+	for _, obj := range bindings {
+		binding := obj.(*v1beta1.ServiceBinding)
+		glog.V(4).Infof("Secret: %v/%v is referenced by the secret transforms of ServiceBinding \"%s/%s\"", secret.Namespace, secret.Name, binding.Namespace, binding.Name)
+		c.bindingAdd(binding)
+	}
+}
+
+// serviceBindingSecretTransformSources is the index function of
+// bindingSecretTransformSourceIndex.
+func serviceBindingSecretTransformSources(obj interface{}) ([]string, error) {
+	binding, ok := obj.(*v1beta1.ServiceBinding)
+	if !ok {
+		return nil, nil
+	}
+	var keys []string
+	for _, t := range binding.Spec.SecretTransforms {
+		if t.AddKeysFrom != nil && t.AddKeysFrom.SecretRef != nil {
+			keys = append(keys, t.AddKeysFrom.SecretRef.Namespace+"/"+t.AddKeysFrom.SecretRef.Name)
+		}
+	}
+	return keys, nil
+}
+
+// enqueueBrokersReferencingSecret drops the cached clients of the brokers whose
+// auth credentials are held by the given secret, so that their next clients
+// are created with the current credentials, and adds the brokers to their
+// work queues so that a broker that failed with the previous credentials is
+// retried promptly.
+func (c *controller) enqueueBrokersReferencingSecret(secret *corev1.Secret) {
+	brokers, err := c.brokerLister.List(labels.Everything())
+	if err != nil {
+		glog.Errorf("Secret: Couldn't list ClusterServiceBrokers: %v", err)
+		return
+	}
+	for _, broker := range brokers {
+		secretRef := clusterServiceBrokerAuthSecretRef(broker.Spec.AuthInfo)
+		if secretRef != nil && secretRef.Namespace == secret.Namespace && secretRef.Name == secret.Name {
+			glog.V(4).Infof("Secret: %v/%v holds the auth credentials of ClusterServiceBroker %q", secret.Namespace, secret.Name, broker.Name)
+			c.brokerClients.Invalidate(brokerKey(broker.ObjectMeta))
+			c.brokerAdd(broker)
+		}
+	}
+
+	if c.serviceBrokerLister == nil {
+		return
+	}
+	serviceBrokers, err := c.serviceBrokerLister.ServiceBrokers(secret.Namespace).List(labels.Everything())
+	if err != nil {
+		glog.Errorf("Secret: Couldn't list ServiceBrokers in namespace %q: %v", secret.Namespace, err)
+		return
+	}
+	for _, broker := range serviceBrokers {
+		secretRef := serviceBrokerAuthSecretRef(broker.Spec.AuthInfo)
+		if secretRef != nil && secretRef.Name == secret.Name {
+			glog.V(4).Infof("Secret: %v/%v holds the auth credentials of ServiceBroker %q", secret.Namespace, secret.Name, broker.Name)
+			c.brokerClients.Invalidate(brokerKey(broker.ObjectMeta))
+			c.serviceBrokerAdd(broker)
+		}
+	}
+}
+
+// clusterServiceBrokerAuthSecretRef returns the reference to the secret
+// holding the credentials of the given auth info, if any.
+func clusterServiceBrokerAuthSecretRef(authInfo *v1beta1.ClusterServiceBrokerAuthInfo) *v1beta1.ObjectReference {
+	switch {
+	case authInfo == nil:
+		return nil
+	case authInfo.Basic != nil:
+		return authInfo.Basic.SecretRef
+	case authInfo.Bearer != nil:
+		return authInfo.Bearer.SecretRef
+	case authInfo.TLS != nil:
+		return authInfo.TLS.SecretRef
+	case authInfo.OAuth2 != nil:
+		return authInfo.OAuth2.SecretRef
+	default:
+		return nil
+	}
+}
+
+// serviceBrokerAuthSecretRef returns the reference to the secret holding the
+// credentials of the given auth info, if any.
+func serviceBrokerAuthSecretRef(authInfo *v1beta1.ServiceBrokerAuthInfo) *v1beta1.LocalObjectReference {
+	switch {
+	case authInfo == nil:
+		return nil
+	case authInfo.Basic != nil:
+		return authInfo.Basic.SecretRef
+	case authInfo.Bearer != nil:
+		return authInfo.Bearer.SecretRef
+	case authInfo.TLS != nil:
+		return authInfo.TLS.SecretRef
+	case authInfo.OAuth2 != nil:
+		return authInfo.OAuth2.SecretRef
+	default:
+		return nil
+	}
+}
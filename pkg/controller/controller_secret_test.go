@@ -0,0 +1,189 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	fakebrokerclient "github.com/kubernetes-incubator/service-catalog/pkg/brokerclient/fake"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	"k8s.io/client-go/util/workqueue"
+)
+
+// TestSecretUpdate verifies that a change to the data of a Secret enqueues
+// the ServiceInstances whose ParametersFrom reference it and the
+// ServiceBindings whose AddKeysFrom transforms reference it, and only those.
+func TestSecretUpdate(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ParametersFromSync))
+	if err != nil {
+		t.Fatalf("Failed to enable ParametersFromSync feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ParametersFromSync))
+
+	newInstance := func(namespace, name, secretName string) *v1beta1.ServiceInstance {
+		instance := getTestServiceInstanceWithRefs()
+		instance.Namespace = namespace
+		instance.Name = name
+		instance.Spec.ParametersFrom = []v1beta1.ParametersFromSource{
+			{
+				SecretKeyRef: &v1beta1.SecretKeyReference{
+					Name: secretName,
+					Key:  "param-secret-key",
+				},
+			},
+		}
+		return instance
+	}
+	newBinding := func(name, secretNamespace, secretName string) *v1beta1.ServiceBinding {
+		binding := getTestServiceBinding()
+		binding.Name = name
+		binding.Spec.SecretTransforms = []v1beta1.SecretTransform{
+			{
+				AddKeysFrom: &v1beta1.AddKeysFromTransform{
+					SecretRef: &v1beta1.ObjectReference{
+						Namespace: secretNamespace,
+						Name:      secretName,
+					},
+				},
+			},
+		}
+		return binding
+	}
+	newSecret := func(value string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "param-secret-name",
+				Namespace: testNamespace,
+			},
+			Data: map[string][]byte{
+				"param-secret-key": []byte(value),
+			},
+		}
+	}
+
+	cases := []struct {
+		name                   string
+		secretTransformSyncOff bool
+		oldSecret              *corev1.Secret
+		newSecret              *corev1.Secret
+		expectedInstance       []string
+		expectedBinding        []string
+	}{
+		{
+			name:             "data changed",
+			oldSecret:        newSecret(`{"a":"1"}`),
+			newSecret:        newSecret(`{"a":"2"}`),
+			expectedInstance: []string{testNamespace + "/referencing"},
+			expectedBinding:  []string{testNamespace + "/referencing"},
+		},
+		{
+			name:                   "data changed, SecretTransformSync disabled",
+			secretTransformSyncOff: true,
+			oldSecret:              newSecret(`{"a":"1"}`),
+			newSecret:              newSecret(`{"a":"2"}`),
+			expectedInstance:       []string{testNamespace + "/referencing"},
+		},
+		{
+			name:      "data unchanged",
+			oldSecret: newSecret(`{"a":"1"}`),
+			newSecret: newSecret(`{"a":"1"}`),
+		},
+	}
+
+	for _, tc := range cases {
+		_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+		sharedInformers.ServiceInstances().Informer().GetStore().Add(newInstance(testNamespace, "referencing", "param-secret-name"))
+		sharedInformers.ServiceInstances().Informer().GetStore().Add(newInstance(testNamespace, "not-referencing", "other-secret-name"))
+		sharedInformers.ServiceInstances().Informer().GetStore().Add(newInstance("other-namespace", "referencing", "param-secret-name"))
+		sharedInformers.ServiceBindings().Informer().GetStore().Add(newBinding("referencing", testNamespace, "param-secret-name"))
+		sharedInformers.ServiceBindings().Informer().GetStore().Add(newBinding("not-referencing", "other-namespace", "param-secret-name"))
+
+		secretTransformSync := fmt.Sprintf("%v=%v", scfeatures.SecretTransformSync, !tc.secretTransformSyncOff)
+		if err := utilfeature.DefaultFeatureGate.Set(secretTransformSync); err != nil {
+			t.Fatalf("Failed to set SecretTransformSync feature: %v", err)
+		}
+		testController.secretUpdate(tc.oldSecret, tc.newSecret)
+		utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.SecretTransformSync))
+
+		assertQueueContents(t, tc.name+": instance queue", testController.instanceQueue, tc.expectedInstance)
+		assertQueueContents(t, tc.name+": binding queue", testController.bindingQueue, tc.expectedBinding)
+	}
+}
+
+// TestSecretUpdateBrokers verifies that a change to the data of a Secret
+// enqueues the ClusterServiceBrokers whose auth credentials it holds and drops
+// their cached clients.
+func TestSecretUpdateBrokers(t *testing.T) {
+	newBroker := func(name, secretNamespace, secretName string) *v1beta1.ClusterServiceBroker {
+		broker := getTestClusterServiceBrokerWithAuth(&v1beta1.ClusterServiceBrokerAuthInfo{
+			TLS: &v1beta1.ClusterTLSAuthConfig{
+				SecretRef: &v1beta1.ObjectReference{
+					Namespace: secretNamespace,
+					Name:      secretName,
+				},
+			},
+		})
+		broker.Name = name
+		return broker
+	}
+	oldSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "tls-secret-name", Namespace: testNamespace},
+		Type:       corev1.SecretTypeTLS,
+		Data:       map[string][]byte{corev1.TLSCertKey: []byte("old")},
+	}
+	newSecret := oldSecret.DeepCopy()
+	newSecret.Data[corev1.TLSCertKey] = []byte("new")
+
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(newBroker("referencing", testNamespace, "tls-secret-name"))
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(newBroker("not-referencing", "other-namespace", "tls-secret-name"))
+	sharedInformers.ClusterServiceBrokers().Informer().GetStore().Add(getTestClusterServiceBroker())
+
+	version := brokerClientVersion{generation: 1}
+	testController.brokerClients.Add("referencing", version, fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{}))
+	testController.brokerClients.Add("not-referencing", version, fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{}))
+
+	testController.secretUpdate(oldSecret, newSecret)
+
+	assertQueueContents(t, "broker queue", testController.brokerQueue, []string{"referencing"})
+	if _, ok := testController.brokerClients.Get("referencing", version); ok {
+		t.Error("expected the client of the referencing broker to be dropped")
+	}
+	if _, ok := testController.brokerClients.Get("not-referencing", version); !ok {
+		t.Error("expected the client of the not-referencing broker to be kept")
+	}
+}
+
+func assertQueueContents(t *testing.T, name string, queue workqueue.RateLimitingInterface, expected []string) {
+	if e, a := len(expected), queue.Len(); e != a {
+		t.Errorf("%v: unexpected number of enqueued keys: %s", name, expectedGot(e, a))
+		return
+	}
+	for _, key := range expected {
+		item, _ := queue.Get()
+		if e, a := key, item; e != a {
+			t.Errorf("%v: unexpected enqueued key: %s", name, expectedGot(e, a))
+		}
+		queue.Done(item)
+	}
+}
@@ -0,0 +1,256 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	clientgotesting "k8s.io/client-go/testing"
+)
+
+// TestShouldReconcileServiceBroker ensures that a namespaced broker is
+// subject to the same relist rules as a cluster-scoped one.
+func TestShouldReconcileServiceBroker(t *testing.T) {
+	now := time.Now()
+
+	readyBroker := func(lastRelist time.Time) *v1beta1.ServiceBroker {
+		broker := getTestServiceBroker()
+		lastRelistTime := metav1.NewTime(lastRelist)
+		broker.Status.Conditions = []v1beta1.ServiceBrokerCondition{{
+			Type:   v1beta1.ServiceBrokerConditionReady,
+			Status: v1beta1.ConditionTrue,
+		}}
+		broker.Status.LastCatalogRetrievalTime = &lastRelistTime
+		return broker
+	}
+
+	cases := []struct {
+		name      string
+		broker    *v1beta1.ServiceBroker
+		reconcile bool
+	}{
+		{
+			name:      "no status",
+			broker:    getTestServiceBroker(),
+			reconcile: true,
+		},
+		{
+			name:      "ready, past relist interval",
+			broker:    readyBroker(now.Add(-16 * time.Minute)),
+			reconcile: true,
+		},
+		{
+			name:      "ready, within relist interval",
+			broker:    readyBroker(now.Add(-1 * time.Minute)),
+			reconcile: false,
+		},
+	}
+
+	for _, tc := range cases {
+		if e, a := tc.reconcile, shouldReconcileServiceBroker(tc.broker, now); e != a {
+			t.Errorf("%v: unexpected result; %s", tc.name, expectedGot(e, a))
+		}
+	}
+}
+
+// TestReconcileServiceBroker verifies that a namespaced broker's catalog is
+// turned into ServiceClasses and ServicePlans in the broker's namespace.
+func TestReconcileServiceBroker(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.NamespacedServiceBroker))
+	if err != nil {
+		t.Fatalf("Failed to enable namespaced service broker feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.NamespacedServiceBroker))
+
+	fakeKubeClient, fakeCatalogClient, fakeServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	broker := getTestServiceBroker()
+
+	fakeCatalogClient.AddReactor("list", "serviceclasses", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ServiceClassList{}, nil
+	})
+	fakeCatalogClient.AddReactor("list", "serviceplans", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ServicePlanList{}, nil
+	})
+
+	if err = reconcileServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail: %v", err)
+	}
+
+	brokerActions := fakeServiceBrokerClient.Actions()
+	assertNumberOfClusterServiceBrokerActions(t, brokerActions, 1)
+	assertGetCatalog(t, brokerActions[0])
+
+	listRestrictions := clientgotesting.ListRestrictions{
+		Labels: labels.Everything(),
+		Fields: fields.OneTermEqualSelector("spec.serviceBrokerName", testServiceBrokerName),
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 6)
+	assertList(t, actions[0], &v1beta1.ServiceClass{}, listRestrictions)
+	assertList(t, actions[1], &v1beta1.ServicePlan{}, listRestrictions)
+	createdServiceClass := assertCreate(t, actions[2], getTestServiceClass())
+	createdServicePlan := assertCreate(t, actions[3], getTestServicePlan())
+	assertCreate(t, actions[4], &v1beta1.ServicePlan{ObjectMeta: metav1.ObjectMeta{Name: testNonbindableClusterServicePlanGUID}})
+	updatedServiceBroker := assertUpdateStatus(t, actions[5], broker)
+	assertServiceBrokerReadyTrue(t, updatedServiceBroker)
+
+	for _, action := range actions {
+		if e, a := testNamespace, action.GetNamespace(); e != a {
+			t.Fatalf("Unexpected namespace for action %v; %s", action, expectedGot(e, a))
+		}
+	}
+
+	serviceClass := createdServiceClass.(*v1beta1.ServiceClass)
+	if e, a := testServiceBrokerName, serviceClass.Spec.ServiceBrokerName; e != a {
+		t.Fatalf("Unexpected broker name on created class; %s", expectedGot(e, a))
+	}
+	servicePlan := createdServicePlan.(*v1beta1.ServicePlan)
+	if e, a := testClusterServiceClassGUID, servicePlan.Spec.ServiceClassRef.Name; e != a {
+		t.Fatalf("Unexpected class reference on created plan; %s", expectedGot(e, a))
+	}
+
+	// verify no kube resources created
+	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+}
+
+// TestReconcileServiceBrokerErrorFetchingCatalog verifies that a failure to
+// fetch the catalog is reflected in the namespaced broker's status.
+func TestReconcileServiceBrokerErrorFetchingCatalog(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.NamespacedServiceBroker))
+	if err != nil {
+		t.Fatalf("Failed to enable namespaced service broker feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.NamespacedServiceBroker))
+
+	fakeKubeClient, fakeCatalogClient, fakeServiceBrokerClient, testController, _ := newTestController(t, fakeosb.FakeClientConfiguration{
+		CatalogReaction: &fakeosb.CatalogReaction{
+			Error: errors.New("ooops"),
+		},
+	})
+
+	broker := getTestServiceBroker()
+
+	if err = reconcileServiceBroker(t, testController, broker); err == nil {
+		t.Fatal("Should have failed to get the catalog.")
+	}
+
+	brokerActions := fakeServiceBrokerClient.Actions()
+	assertNumberOfClusterServiceBrokerActions(t, brokerActions, 1)
+	assertGetCatalog(t, brokerActions[0])
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 2)
+
+	updatedServiceBroker := assertUpdateStatus(t, actions[0], broker)
+	assertServiceBrokerReadyFalse(t, updatedServiceBroker)
+
+	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+
+	events := getRecordedEvents(testController)
+
+	expectedEvent := warningEventBuilder(errorFetchingCatalogReason).msg("Error getting broker catalog:").msg("ooops")
+	if err = checkEvents(events, expectedEvent.stringArr()); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// TestReconcileServiceBrokerDelete verifies that the classes and plans of a
+// namespaced broker are removed before its finalizer is cleared.
+func TestReconcileServiceBrokerDelete(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.NamespacedServiceBroker))
+	if err != nil {
+		t.Fatalf("Failed to enable namespaced service broker feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.NamespacedServiceBroker))
+
+	fakeKubeClient, fakeCatalogClient, fakeServiceBrokerClient, testController, _ := newTestController(t, getTestCatalogConfig())
+
+	testServiceClass := getTestServiceClass()
+	testServicePlan := getTestServicePlan()
+
+	broker := getTestServiceBroker()
+	broker.DeletionTimestamp = &metav1.Time{}
+	broker.Finalizers = []string{v1beta1.FinalizerServiceCatalog}
+	fakeCatalogClient.AddReactor("get", "servicebrokers", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, broker, nil
+	})
+	fakeCatalogClient.AddReactor("list", "serviceclasses", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ServiceClassList{
+			Items: []v1beta1.ServiceClass{
+				*testServiceClass,
+			},
+		}, nil
+	})
+	fakeCatalogClient.AddReactor("list", "serviceplans", func(action clientgotesting.Action) (bool, runtime.Object, error) {
+		return true, &v1beta1.ServicePlanList{
+			Items: []v1beta1.ServicePlan{
+				*testServicePlan,
+			},
+		}, nil
+	})
+
+	if err = reconcileServiceBroker(t, testController, broker); err != nil {
+		t.Fatalf("This should not fail : %v", err)
+	}
+
+	assertNumberOfClusterServiceBrokerActions(t, fakeServiceBrokerClient.Actions(), 0)
+	assertNumberOfActions(t, fakeKubeClient.Actions(), 0)
+
+	listRestrictions := clientgotesting.ListRestrictions{
+		Labels: labels.Everything(),
+		Fields: fields.OneTermEqualSelector("spec.serviceBrokerName", broker.Name),
+	}
+
+	actions := fakeCatalogClient.Actions()
+	assertNumberOfActions(t, actions, 7)
+	assertList(t, actions[0], &v1beta1.ServiceClass{}, listRestrictions)
+	assertList(t, actions[1], &v1beta1.ServicePlan{}, listRestrictions)
+	assertDelete(t, actions[2], testServicePlan)
+	assertDelete(t, actions[3], testServiceClass)
+	updatedServiceBroker := assertUpdateStatus(t, actions[4], broker)
+	assertServiceBrokerReadyFalse(t, updatedServiceBroker)
+
+	assertGet(t, actions[5], broker)
+
+	updatedServiceBroker = assertUpdateStatus(t, actions[6], broker)
+	assertEmptyFinalizers(t, updatedServiceBroker)
+}
+
+func reconcileServiceBroker(t *testing.T, testController *controller, broker *v1beta1.ServiceBroker) error {
+	clone := broker.DeepCopy()
+	err := testController.reconcileServiceBroker(broker)
+	if !reflect.DeepEqual(broker, clone) {
+		t.Errorf("reconcileServiceBroker shouldn't mutate input, but it does: %s", expectedGot(clone, broker))
+	}
+	return err
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ServiceInstanceQuota handlers and control-loop. The quotas are enforced by
+// the ServiceInstanceQuota admission plugin; the controller only keeps the
+// usage reported in their status up to date, reconciling the quotas of a
+// namespace again whenever an instance of the namespace is added, deleted or
+// changes plan.
+
+func (c *controller) serviceInstanceQuotaAdd(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		glog.Errorf("ServiceInstanceQuota: Couldn't get key for object %+v: %v", obj, err)
+		return
+	}
+	c.serviceInstanceQuotaQueue.Add(key)
+}
+
+func (c *controller) serviceInstanceQuotaUpdate(oldObj, newObj interface{}) {
+	c.serviceInstanceQuotaAdd(newObj)
+}
+
+func (c *controller) serviceInstanceQuotaInstanceAdd(obj interface{}) {
+	instance, ok := obj.(*v1beta1.ServiceInstance)
+	if instance == nil || !ok {
+		return
+	}
+	c.enqueueServiceInstanceQuotas(instance.Namespace)
+}
+
+func (c *controller) serviceInstanceQuotaInstanceUpdate(oldObj, newObj interface{}) {
+	oldInstance, ok := oldObj.(*v1beta1.ServiceInstance)
+	if oldInstance == nil || !ok {
+		return
+	}
+	newInstance, ok := newObj.(*v1beta1.ServiceInstance)
+	if newInstance == nil || !ok {
+		return
+	}
+	// The usage only depends on the class and plan of the instances, which
+	// may only be resolved some time after the instance is created, and on
+	// whether they are being deleted.
+	if reflect.DeepEqual(oldInstance.Spec.ClusterServicePlanRef, newInstance.Spec.ClusterServicePlanRef) &&
+		reflect.DeepEqual(oldInstance.Spec.ServicePlanRef, newInstance.Spec.ServicePlanRef) &&
+		(oldInstance.DeletionTimestamp == nil) == (newInstance.DeletionTimestamp == nil) {
+		return
+	}
+	c.enqueueServiceInstanceQuotas(newInstance.Namespace)
+}
+
+func (c *controller) serviceInstanceQuotaInstanceDelete(obj interface{}) {
+	instance, ok := obj.(*v1beta1.ServiceInstance)
+	if !ok {
+		tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			return
+		}
+		instance, ok = tombstone.Obj.(*v1beta1.ServiceInstance)
+		if !ok {
+			return
+		}
+	}
+	if instance == nil {
+		return
+	}
+	c.enqueueServiceInstanceQuotas(instance.Namespace)
+}
+
+// enqueueServiceInstanceQuotas adds the quotas of the given namespace to the
+// quota work queue.
+func (c *controller) enqueueServiceInstanceQuotas(namespace string) {
+	quotas, err := c.serviceInstanceQuotaLister.ServiceInstanceQuotas(namespace).List(labels.Everything())
+	if err != nil {
+		glog.Errorf("ServiceInstanceQuota: Couldn't list ServiceInstanceQuotas in namespace %q: %v", namespace, err)
+		return
+	}
+	for _, quota := range quotas {
+		c.serviceInstanceQuotaAdd(quota)
+	}
+}
+
+func (c *controller) reconcileServiceInstanceQuotaKey(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	quota, err := c.serviceInstanceQuotaLister.ServiceInstanceQuotas(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		glog.Infof("ServiceInstanceQuota %q: Not doing work because it has been deleted", key)
+		return nil
+	}
+	if err != nil {
+		glog.Infof("ServiceInstanceQuota %q: Unable to retrieve object from store: %v", key, err)
+		return err
+	}
+
+	return c.reconcileServiceInstanceQuota(quota)
+}
+
+// reconcileServiceInstanceQuota updates the usage reported in the status of
+// the given quota with the instances of its namespace that each of its limits
+// selects, leaving out the instances being deleted like the admission plugin
+// does.
+func (c *controller) reconcileServiceInstanceQuota(quota *v1beta1.ServiceInstanceQuota) error {
+	glog.V(4).Infof("ServiceInstanceQuota %s/%s: processing", quota.Namespace, quota.Name)
+
+	instances, err := c.instanceLister.ServiceInstances(quota.Namespace).List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	used := make([]v1beta1.ServiceInstanceQuotaUsage, len(quota.Spec.Limits))
+	for i, limit := range quota.Spec.Limits {
+		used[i].Name = limit.Name
+	}
+	for _, instance := range instances {
+		if instance.DeletionTimestamp != nil {
+			continue
+		}
+		classExternalName, planExternalName, free := c.getServiceInstanceQuotaSelectors(instance)
+		for i, limit := range quota.Spec.Limits {
+			if limit.Selects(classExternalName, planExternalName, free) {
+				used[i].Instances++
+			}
+		}
+	}
+
+	if reflect.DeepEqual(quota.Status.Used, used) {
+		return nil
+	}
+	toUpdate := quota.DeepCopy()
+	toUpdate.Status.Used = used
+	if _, err := c.serviceCatalogClient.ServiceInstanceQuotas(quota.Namespace).UpdateStatus(toUpdate); err != nil {
+		glog.Errorf("ServiceInstanceQuota %s/%s: Error updating status: %v", quota.Namespace, quota.Name, err)
+		return err
+	}
+	glog.V(4).Infof("ServiceInstanceQuota %s/%s: updated usage", quota.Namespace, quota.Name)
+	return nil
+}
+
+// getServiceInstanceQuotaSelectors returns the external names of the class
+// and plan of the given instance, and the Free field of its plan, that the
+// limits of the quotas select on. Empty names and a nil free are returned
+// when the plan of the instance is not resolved.
+func (c *controller) getServiceInstanceQuotaSelectors(instance *v1beta1.ServiceInstance) (string, string, *bool) {
+	switch {
+	case instance.Spec.ClusterServiceClassRef != nil && instance.Spec.ClusterServicePlanRef != nil:
+		class, err := c.clusterServiceClassLister.Get(instance.Spec.ClusterServiceClassRef.Name)
+		if err != nil {
+			return "", "", nil
+		}
+		plan, err := c.clusterServicePlanLister.Get(instance.Spec.ClusterServicePlanRef.Name)
+		if err != nil {
+			return "", "", nil
+		}
+		free := plan.Spec.Free
+		return class.Spec.ExternalName, plan.Spec.ExternalName, &free
+	case instance.Spec.ServiceClassRef != nil && instance.Spec.ServicePlanRef != nil && c.servicePlanLister != nil:
+		class, err := c.serviceClassLister.ServiceClasses(instance.Namespace).Get(instance.Spec.ServiceClassRef.Name)
+		if err != nil {
+			return "", "", nil
+		}
+		plan, err := c.servicePlanLister.ServicePlans(instance.Namespace).Get(instance.Spec.ServicePlanRef.Name)
+		if err != nil {
+			return "", "", nil
+		}
+		free := plan.Spec.Free
+		return class.Spec.ExternalName, plan.Spec.ExternalName, &free
+	default:
+		return "", "", nil
+	}
+}
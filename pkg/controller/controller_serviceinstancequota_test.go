@@ -0,0 +1,160 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+)
+
+func getTestServiceInstanceQuota(used []v1beta1.ServiceInstanceQuotaUsage) *v1beta1.ServiceInstanceQuota {
+	free := true
+	return &v1beta1.ServiceInstanceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: testNamespace},
+		Spec: v1beta1.ServiceInstanceQuotaSpec{
+			Limits: []v1beta1.ServiceInstanceQuotaLimit{
+				{Name: "all", MaxInstances: 10},
+				{Name: "class", ServiceClassExternalName: testClusterServiceClassName, MaxInstances: 5},
+				{Name: "plan", ServiceClassExternalName: testClusterServiceClassName, ServicePlanExternalName: "other", MaxInstances: 5},
+				{Name: "free", Free: &free, MaxInstances: 5},
+			},
+		},
+		Status: v1beta1.ServiceInstanceQuotaStatus{Used: used},
+	}
+}
+
+// TestReconcileServiceInstanceQuota verifies that the usage of each limit of
+// a quota counts the instances of its namespace that the limit selects.
+func TestReconcileServiceInstanceQuota(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServiceInstanceQuota))
+	if err != nil {
+		t.Fatalf("Failed to enable ServiceInstanceQuota feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServiceInstanceQuota))
+
+	expectedUsed := []v1beta1.ServiceInstanceQuotaUsage{
+		{Name: "all", Instances: 3},
+		{Name: "class", Instances: 2},
+		{Name: "plan", Instances: 0},
+		{Name: "free", Instances: 0},
+	}
+
+	cases := []struct {
+		name           string
+		quota          *v1beta1.ServiceInstanceQuota
+		expectedUpdate bool
+	}{
+		{
+			name:           "usage changed",
+			quota:          getTestServiceInstanceQuota(nil),
+			expectedUpdate: true,
+		},
+		{
+			name:  "usage unchanged",
+			quota: getTestServiceInstanceQuota(expectedUsed),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, fakeCatalogClient, _, testController, sharedInformers := newTestController(t, noFakeActions())
+
+			sharedInformers.ClusterServiceClasses().Informer().GetStore().Add(getTestClusterServiceClass())
+			sharedInformers.ClusterServicePlans().Informer().GetStore().Add(getTestClusterServicePlan())
+			for _, name := range []string{"a", "b"} {
+				instance := getTestServiceInstanceWithRefs()
+				instance.Name = name
+				sharedInformers.ServiceInstances().Informer().GetStore().Add(instance)
+			}
+			// The plan of this instance is not resolved yet.
+			unresolved := getTestServiceInstance()
+			unresolved.Name = "c"
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(unresolved)
+			other := getTestServiceInstanceWithRefs()
+			other.Namespace = "other"
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(other)
+			deleting := getTestServiceInstanceWithRefs()
+			deleting.Name = "d"
+			deleting.DeletionTimestamp = &metav1.Time{}
+			sharedInformers.ServiceInstances().Informer().GetStore().Add(deleting)
+
+			if err := testController.reconcileServiceInstanceQuota(tc.quota); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			actions := fakeCatalogClient.Actions()
+			if !tc.expectedUpdate {
+				expectNumberOfActions(t, tc.name, actions, 0)
+				return
+			}
+			expectNumberOfActions(t, tc.name, actions, 1)
+			updated := assertUpdateStatus(t, actions[0], tc.quota).(*v1beta1.ServiceInstanceQuota)
+			if !reflect.DeepEqual(expectedUsed, updated.Status.Used) {
+				t.Fatalf("unexpected usage: expected %+v, got %+v", expectedUsed, updated.Status.Used)
+			}
+		})
+	}
+}
+
+// TestServiceInstanceQuotaInstanceUpdate verifies that the quotas of a
+// namespace are only enqueued when the plan of one of its instances changes,
+// or when one of its instances starts being deleted.
+func TestServiceInstanceQuotaInstanceUpdate(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServiceInstanceQuota))
+	if err != nil {
+		t.Fatalf("Failed to enable ServiceInstanceQuota feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServiceInstanceQuota))
+
+	_, _, _, testController, sharedInformers := newTestController(t, noFakeActions())
+	sharedInformers.ServiceInstanceQuotas().Informer().GetStore().Add(getTestServiceInstanceQuota(nil))
+
+	oldInstance := getTestServiceInstance()
+	newInstance := getTestServiceInstance()
+	newInstance.Generation++
+	testController.serviceInstanceQuotaInstanceUpdate(oldInstance, newInstance)
+	if e, a := 0, testController.serviceInstanceQuotaQueue.Len(); e != a {
+		t.Fatalf("unexpected queue length: expected %v, got %v", e, a)
+	}
+
+	newInstance = getTestServiceInstanceWithRefs()
+	testController.serviceInstanceQuotaInstanceUpdate(oldInstance, newInstance)
+	if e, a := 1, testController.serviceInstanceQuotaQueue.Len(); e != a {
+		t.Fatalf("unexpected queue length: expected %v, got %v", e, a)
+	}
+	key, _ := testController.serviceInstanceQuotaQueue.Get()
+	if key != testNamespace+"/quota" {
+		t.Fatalf("unexpected key: %v", key)
+	}
+	testController.serviceInstanceQuotaQueue.Done(key)
+
+	// An instance being deleted is no longer counted.
+	oldInstance = getTestServiceInstanceWithRefs()
+	newInstance = getTestServiceInstanceWithRefs()
+	newInstance.DeletionTimestamp = &metav1.Time{}
+	testController.serviceInstanceQuotaInstanceUpdate(oldInstance, newInstance)
+	if e, a := 1, testController.serviceInstanceQuotaQueue.Len(); e != a {
+		t.Fatalf("unexpected queue length: expected %v, got %v", e, a)
+	}
+}
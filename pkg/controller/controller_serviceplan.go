@@ -0,0 +1,117 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"reflect"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/pretty"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// Namespaced service plan handlers and control-loop
+
+func (c *controller) servicePlanAdd(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		glog.Errorf("ServicePlan: Couldn't get key for object %+v: %v", obj, err)
+		return
+	}
+	c.servicePlanQueue.Add(key)
+}
+
+func (c *controller) servicePlanUpdate(oldObj, newObj interface{}) {
+	c.servicePlanAdd(newObj)
+
+	oldPlan, ok := oldObj.(*v1beta1.ServicePlan)
+	if !ok {
+		return
+	}
+	newPlan, ok := newObj.(*v1beta1.ServicePlan)
+	if !ok {
+		return
+	}
+	if !reflect.DeepEqual(oldPlan.Spec.MaintenanceInfo, newPlan.Spec.MaintenanceInfo) {
+		// Let the instances of the plan check whether they can be upgraded.
+		c.enqueueServicePlanInstances(newPlan)
+	}
+}
+
+// enqueueServicePlanInstances adds the instances of the given plan to the
+// instance queue.
+func (c *controller) enqueueServicePlanInstances(servicePlan *v1beta1.ServicePlan) {
+	instances, err := c.instanceLister.ServiceInstances(servicePlan.Namespace).List(labels.Everything())
+	if err != nil {
+		glog.Errorf("ServicePlan %q/%q: Couldn't list the instances of the plan: %v", servicePlan.Namespace, servicePlan.Name, err)
+		return
+	}
+	for _, instance := range instances {
+		if instance.Spec.ServicePlanRef != nil && instance.Spec.ServicePlanRef.Name == servicePlan.Name {
+			c.instanceAdd(instance)
+		}
+	}
+}
+
+func (c *controller) servicePlanDelete(obj interface{}) {
+	servicePlan, ok := obj.(*v1beta1.ServicePlan)
+	if servicePlan == nil || !ok {
+		return
+	}
+
+	glog.V(4).Infof("ServicePlan: Received delete event for %v/%v; no further processing will occur", servicePlan.Namespace, servicePlan.Name)
+}
+
+// reconcileServicePlanKey reconciles a ServicePlan due to controller resync
+// or an event on the ServicePlan.  Note that this is NOT the main
+// reconciliation loop for ServicePlan. ServicePlans are primarily
+// reconciled in a separate flow when a ServiceBroker is reconciled.
+func (c *controller) reconcileServicePlanKey(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	pcb := pretty.NewContextBuilder(pretty.ServicePlan, namespace, name)
+	servicePlan, err := c.servicePlanLister.ServicePlans(namespace).Get(name)
+	if errors.IsNotFound(err) {
+		glog.Info(pcb.Message("Not doing work because it has been deleted"))
+		return nil
+	}
+	if err != nil {
+		glog.Info(pcb.Messagef("Unable to retrieve object from store: %v", err))
+		return err
+	}
+
+	return c.reconcileServicePlan(servicePlan)
+}
+
+func (c *controller) reconcileServicePlan(servicePlan *v1beta1.ServicePlan) error {
+	pcb := pretty.NewContextBuilder(pretty.ServicePlan, servicePlan.Namespace, servicePlan.Name)
+	glog.Info(pcb.Messagef("processing (ExternalName: %q)", servicePlan.Spec.ExternalName))
+
+	if !servicePlan.Status.RemovedFromBrokerCatalog {
+		return nil
+	}
+
+	glog.Info(pcb.Messagef("(ExternalName: %q) has been removed from broker catalog; deleting", servicePlan.Spec.ExternalName))
+	return c.serviceCatalogClient.ServicePlans(servicePlan.Namespace).Delete(servicePlan.Name, &metav1.DeleteOptions{})
+}
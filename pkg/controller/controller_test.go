@@ -29,6 +29,10 @@ import (
 	fakeosb "github.com/pmorie/go-open-service-broker-client/v2/fake"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
+	fakebrokerclient "github.com/kubernetes-incubator/service-catalog/pkg/brokerclient/fake"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerlimits"
 	servicecataloginformers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions"
 	v1beta1informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions/servicecatalog/v1beta1"
 
@@ -45,6 +49,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/util/sets"
+	kubeinformers "k8s.io/client-go/informers"
 	clientgofake "k8s.io/client-go/kubernetes/fake"
 	clientgotesting "k8s.io/client-go/testing"
 	"k8s.io/client-go/tools/record"
@@ -72,6 +77,7 @@ const (
 	testRemovedClusterServicePlanGUID      = "REMOVED-CLUSTERSERVICEPLAN"
 
 	testClusterServiceBrokerName            = "test-clusterservicebroker"
+	testServiceBrokerName                   = "test-servicebroker"
 	testClusterServiceClassName             = "test-clusterserviceclass"
 	testClusterServicePlanName              = "test-clusterserviceplan"
 	testNonExistentClusterServiceClassName  = "nothere"
@@ -524,6 +530,52 @@ func getTestClusterServiceBrokerWithAuth(authInfo *v1beta1.ClusterServiceBrokerA
 	return broker
 }
 
+func getTestServiceBroker() *v1beta1.ServiceBroker {
+	return &v1beta1.ServiceBroker{
+		ObjectMeta: metav1.ObjectMeta{Name: testServiceBrokerName, Namespace: testNamespace},
+		Spec: v1beta1.ServiceBrokerSpec{
+			CommonServiceBrokerSpec: v1beta1.CommonServiceBrokerSpec{
+				URL:            "https://example.com",
+				RelistBehavior: v1beta1.ServiceBrokerRelistBehaviorDuration,
+				RelistDuration: &metav1.Duration{Duration: 15 * time.Minute},
+			},
+		},
+	}
+}
+
+// a bindable namespaced service class wired to the result of getTestServiceBroker()
+func getTestServiceClass() *v1beta1.ServiceClass {
+	return &v1beta1.ServiceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: testClusterServiceClassGUID, Namespace: testNamespace},
+		Spec: v1beta1.ServiceClassSpec{
+			ServiceBrokerName: testServiceBrokerName,
+			CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
+				Description:  "a test service",
+				ExternalName: testClusterServiceClassName,
+				ExternalID:   testClusterServiceClassGUID,
+				Bindable:     true,
+			},
+		},
+	}
+}
+
+func getTestServicePlan() *v1beta1.ServicePlan {
+	return &v1beta1.ServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: testClusterServicePlanGUID, Namespace: testNamespace},
+		Spec: v1beta1.ServicePlanSpec{
+			ServiceBrokerName: testServiceBrokerName,
+			CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+				ExternalID:   testClusterServicePlanGUID,
+				ExternalName: testClusterServicePlanName,
+				Bindable:     truePtr(),
+			},
+			ServiceClassRef: v1beta1.LocalObjectReference{
+				Name: testClusterServiceClassGUID,
+			},
+		},
+	}
+}
+
 // a bindable service class wired to the result of getTestClusterServiceBroker()
 func getTestClusterServiceClass() *v1beta1.ClusterServiceClass {
 	return &v1beta1.ClusterServiceClass{
@@ -723,6 +775,24 @@ func getTestServiceInstanceWithRefs() *v1beta1.ServiceInstance {
 	return sc
 }
 
+// instance referencing the result of getTestServiceClass() and
+// getTestServicePlan() by external name.
+func getTestServiceInstanceNamespaced() *v1beta1.ServiceInstance {
+	instance := getTestServiceInstance()
+	instance.Spec.PlanReference = v1beta1.PlanReference{
+		ServiceClassExternalName: testClusterServiceClassName,
+		ServicePlanExternalName:  testClusterServicePlanName,
+	}
+	return instance
+}
+
+func getTestServiceInstanceWithNamespacedRefs() *v1beta1.ServiceInstance {
+	instance := getTestServiceInstanceNamespaced()
+	instance.Spec.ServiceClassRef = &v1beta1.LocalObjectReference{Name: testClusterServiceClassGUID}
+	instance.Spec.ServicePlanRef = &v1beta1.LocalObjectReference{Name: testClusterServicePlanGUID}
+	return instance
+}
+
 // instance referencing the result of getTestClusterServiceClass()
 // and getTestClusterServicePlan()
 // This version sets:
@@ -1175,7 +1245,7 @@ type bindingParameters struct {
 }
 
 func TestEmptyCatalogConversion(t *testing.T) {
-	serviceClasses, servicePlans, err := convertAndFilterCatalog(&osb.CatalogResponse{}, nil)
+	serviceClasses, servicePlans, err := convertAndFilterCatalog(&brokerclient.CatalogResponse{}, nil)
 	if err != nil {
 		t.Fatalf("Failed to convertAndFilterCatalog: %v", err)
 	}
@@ -1188,7 +1258,7 @@ func TestEmptyCatalogConversion(t *testing.T) {
 }
 
 func TestCatalogConversion(t *testing.T) {
-	catalog := &osb.CatalogResponse{}
+	catalog := &brokerclient.CatalogResponse{}
 	err := json.Unmarshal([]byte(testCatalog), &catalog)
 	if err != nil {
 		t.Fatalf("Failed to unmarshal test catalog: %v", err)
@@ -1208,11 +1278,29 @@ func TestCatalogConversion(t *testing.T) {
 	checkPlan(servicePlans[1], "0f4008b5-XXXX-XXXX-XXXX-dace631cd648", "fake-plan-2", "Shared fake Server, 5tb persistent disk, 40 max concurrent connections. 100 async", t)
 }
 
+func TestCatalogConversionInstancesRetrievable(t *testing.T) {
+	catalog := &brokerclient.CatalogResponse{}
+	err := json.Unmarshal([]byte(testCatalog), &catalog)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal test catalog: %v", err)
+	}
+	catalog.ServiceExtensions = map[string]brokerclient.ServiceExtensions{
+		catalog.Services[0].ID: {InstancesRetrievable: true},
+	}
+	serviceClasses, _, err := convertAndFilterCatalog(catalog, nil)
+	if err != nil {
+		t.Fatalf("Failed to convertAndFilterCatalog: %v", err)
+	}
+	if !serviceClasses[0].Spec.InstancesRetrievable {
+		t.Fatal("Expected the class to be instancesRetrievable")
+	}
+}
+
 func TestCatalogConversionWithParameterSchemas(t *testing.T) {
 	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ResponseSchema))
 	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ResponseSchema))
 
-	catalog := &osb.CatalogResponse{}
+	catalog := &brokerclient.CatalogResponse{}
 	err := json.Unmarshal([]byte(alphaParameterSchemaCatalogBytes), &catalog)
 	if err != nil {
 		t.Fatalf("Failed to unmarshal test catalog: %v", err)
@@ -1505,7 +1593,7 @@ func TestConvertAndFilterCatalog(t *testing.T) {
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			catalog := &osb.CatalogResponse{}
+			catalog := &brokerclient.CatalogResponse{}
 			err := json.Unmarshal([]byte(tc.catalog), &catalog)
 			if err != nil {
 				t.Fatalf("Failed to unmarshal test catalog: %v", err)
@@ -1581,7 +1669,7 @@ func TestFilterServicePlans(t *testing.T) {
 	for _, tc := range cases {
 		testName := fmt.Sprintf("%s:%s", tc.name, tc.requirements)
 		t.Run(testName, func(t *testing.T) {
-			catalog := &osb.CatalogResponse{}
+			catalog := &brokerclient.CatalogResponse{}
 			err := json.Unmarshal([]byte(tc.catalog), &catalog)
 			if err != nil {
 				t.Fatalf("Failed to unmarshal test catalog: %v", err)
@@ -1658,7 +1746,7 @@ func strPtr(s string) *string {
 }
 
 func TestCatalogConversionClusterServicePlanBindable(t *testing.T) {
-	catalog := &osb.CatalogResponse{}
+	catalog := &brokerclient.CatalogResponse{}
 	err := json.Unmarshal([]byte(testCatalogForClusterServicePlanBindableOverride), &catalog)
 	if err != nil {
 		t.Fatalf("Failed to unmarshal test catalog: %v", err)
@@ -1881,7 +1969,7 @@ func TestIsPlanBindable(t *testing.T) {
 func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 	*clientgofake.Clientset,
 	*fake.Clientset,
-	*fakeosb.FakeClient,
+	*fakebrokerclient.FakeClient,
 	*controller,
 	v1beta1informers.Interface) {
 	// create a fake kube client
@@ -1889,8 +1977,8 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 	// create a fake sc client
 	fakeCatalogClient := &fake.Clientset{Clientset: &servicecatalogclientset.Clientset{}}
 
-	fakeOSBClient := fakeosb.NewFakeClient(config) // error should always be nil
-	brokerClFunc := fakeosb.ReturnFakeClientFunc(fakeOSBClient)
+	fakeOSBClient := fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{FakeClientConfiguration: config}) // error should always be nil
+	brokerClFunc := fakebrokerclient.ReturnFakeClientFunc(fakeOSBClient)
 
 	// create informers
 	informerFactory := servicecataloginformers.NewSharedInformerFactory(fakeCatalogClient, 0)
@@ -1902,17 +1990,31 @@ func newTestController(t *testing.T, config fakeosb.FakeClientConfiguration) (
 	testController, err := NewController(
 		fakeKubeClient,
 		fakeCatalogClient.ServicecatalogV1beta1(),
+		fakeCatalogClient.SettingsV1alpha1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
+		serviceCatalogSharedInformers.ServiceBrokers(),
 		serviceCatalogSharedInformers.ClusterServiceClasses(),
+		serviceCatalogSharedInformers.ServiceClasses(),
 		serviceCatalogSharedInformers.ServiceInstances(),
 		serviceCatalogSharedInformers.ServiceBindings(),
 		serviceCatalogSharedInformers.ClusterServicePlans(),
+		serviceCatalogSharedInformers.ServicePlans(),
+		serviceCatalogSharedInformers.ServiceInstanceQuotas(),
+		kubeinformers.NewSharedInformerFactory(fakeKubeClient, 0).Core().V1().Secrets(),
+		kubeinformers.NewSharedInformerFactory(fakeKubeClient, 0).Core().V1().ConfigMaps(),
+		informerFactory.Settings().V1alpha1().PodPresets(),
 		brokerClFunc,
 		24*time.Hour,
 		osb.LatestAPIVersion().HeaderValue(),
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		10*time.Minute,
+		0,
+		0,
+		brokerlimits.Limits{},
+		0,
+		0,
 		DefaultClusterIDConfigMapName,
 		DefaultClusterIDConfigMapNamespace,
 	)
@@ -2059,10 +2161,20 @@ func testActionFor(t *testing.T, name string, f failfFunc, action clientgotestin
 		resource = "clusterserviceclasses"
 	case *v1beta1.ClusterServicePlan:
 		resource = "clusterserviceplans"
+	case *v1beta1.ServiceBroker:
+		resource = "servicebrokers"
+	case *v1beta1.ServiceClass:
+		resource = "serviceclasses"
+	case *v1beta1.ServicePlan:
+		resource = "serviceplans"
 	case *v1beta1.ServiceInstance:
 		resource = "serviceinstances"
 	case *v1beta1.ServiceBinding:
 		resource = "servicebindings"
+	case *v1beta1.ServiceInstanceQuota:
+		resource = "serviceinstancequotas"
+	case *settingsv1alpha1.PodPreset:
+		resource = "podpresets"
 	}
 
 	if e, a := resource, action.GetResource().Resource; e != a {
@@ -2229,6 +2341,27 @@ func assertClusterServiceBrokerCondition(t *testing.T, obj runtime.Object, condi
 	}
 }
 
+func assertServiceBrokerReadyTrue(t *testing.T, obj runtime.Object) {
+	assertServiceBrokerCondition(t, obj, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionTrue)
+}
+
+func assertServiceBrokerReadyFalse(t *testing.T, obj runtime.Object) {
+	assertServiceBrokerCondition(t, obj, v1beta1.ServiceBrokerConditionReady, v1beta1.ConditionFalse)
+}
+
+func assertServiceBrokerCondition(t *testing.T, obj runtime.Object, conditionType v1beta1.ServiceBrokerConditionType, status v1beta1.ConditionStatus) {
+	broker, ok := obj.(*v1beta1.ServiceBroker)
+	if !ok {
+		fatalf(t, "Couldn't convert object %+v into a *v1beta1.ServiceBroker", obj)
+	}
+
+	for _, condition := range broker.Status.Conditions {
+		if condition.Type == conditionType && condition.Status != status {
+			fatalf(t, "%v condition had unexpected status; expected %v, got %v", conditionType, status, condition.Status)
+		}
+	}
+}
+
 func assertClusterServiceBrokerOperationStartTimeSet(t *testing.T, obj runtime.Object, isOperationStartTimeSet bool) {
 	broker, ok := obj.(*v1beta1.ClusterServiceBroker)
 	if !ok {
@@ -20,14 +20,191 @@ import (
 	"crypto/sha256"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	listers "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
 )
 
+// parametersFromSources holds the clients used to fetch the values that
+// ParametersFrom refer to.
+type parametersFromSources struct {
+	kubeClient     kubernetes.Interface
+	secretLister   corelisters.SecretLister
+	bindingLister  listers.ServiceBindingLister
+	instanceLister listers.ServiceInstanceLister
+	// liveSecretKeyRefs makes the Secrets referenced by SecretKeyRef be read
+	// with kubeClient rather than secretLister, so that a Secret created
+	// along with the object that refers to it is found. The Secrets of
+	// ServiceBindings are always read with secretLister when Secrets are
+	// watched: a binding is only referred to once it is Ready, long after its
+	// Secret was written.
+	liveSecretKeyRefs bool
+}
+
+// getSecret returns the Secret with the given namespace and name, from
+// secretLister when Secrets are watched, and with kubeClient otherwise.
+func (sources parametersFromSources) getSecret(namespace, name string) (*corev1.Secret, error) {
+	if sources.secretLister != nil {
+		return sources.secretLister.Secrets(namespace).Get(name)
+	}
+	return sources.kubeClient.CoreV1().Secrets(namespace).Get(name, metav1.GetOptions{})
+}
+
+// parametersFromSources returns the sources of the values referred to by
+// ParametersFrom.
+func (c *controller) parametersFromSources() parametersFromSources {
+	sources := c.cachedParametersFromSources()
+	sources.liveSecretKeyRefs = true
+	return sources
+}
+
+// cachedParametersFromSources returns the sources of the values referred to
+// by ParametersFrom, reading the Secrets they reference from the Secret
+// informer. It is used to check for changes, which happens for every
+// instance on every resync.
+func (c *controller) cachedParametersFromSources() parametersFromSources {
+	return parametersFromSources{
+		kubeClient:     c.kubeClient,
+		secretLister:   c.secretLister,
+		bindingLister:  c.bindingLister,
+		instanceLister: c.instanceLister,
+	}
+}
+
+// dependencyNotReadyError is the error returned when ParametersFrom refer to
+// a ServiceBinding or ServiceInstance that does not exist or is not Ready
+// yet.
+type dependencyNotReadyError struct {
+	message string
+}
+
+func (e *dependencyNotReadyError) Error() string {
+	return e.message
+}
+
+// isDependencyNotReadyError returns whether the given error is a
+// dependencyNotReadyError.
+func isDependencyNotReadyError(err error) bool {
+	_, ok := err.(*dependencyNotReadyError)
+	return ok
+}
+
+// dependencyFailedError is the error returned when ParametersFrom refer to a
+// ServiceBinding or ServiceInstance that will never be Ready: it failed, or
+// it waits, directly or through other objects, for the object the
+// ParametersFrom belong to.
+type dependencyFailedError struct {
+	message string
+}
+
+func (e *dependencyFailedError) Error() string {
+	return e.message
+}
+
+// isDependencyFailedError returns whether the given error is a
+// dependencyFailedError.
+func isDependencyFailedError(err error) bool {
+	_, ok := err.(*dependencyFailedError)
+	return ok
+}
+
+// parametersFromDependency is a ServiceBinding or ServiceInstance that
+// ParametersFrom wait for to be Ready.
+type parametersFromDependency struct {
+	kind string
+	name string
+}
+
+func (d parametersFromDependency) String() string {
+	return fmt.Sprintf("%s %q", d.kind, d.name)
+}
+
+// parametersFromDependencies returns the objects that the given
+// ParametersFrom wait for.
+func parametersFromDependencies(parametersFrom []v1beta1.ParametersFromSource) []parametersFromDependency {
+	var dependencies []parametersFromDependency
+	for _, p := range parametersFrom {
+		if p.ServiceBindingSecretKeyRef != nil {
+			dependencies = append(dependencies, parametersFromDependency{kind: "ServiceBinding", name: p.ServiceBindingSecretKeyRef.Name})
+		}
+		if p.ServiceInstanceFieldRef != nil {
+			dependencies = append(dependencies, parametersFromDependency{kind: "ServiceInstance", name: p.ServiceInstanceFieldRef.Name})
+		}
+	}
+	return dependencies
+}
+
+// waitingDependencies returns the objects that the given object waits for,
+// or nothing when the object is Ready or cannot be found: it does not wait
+// for anything then. A binding waits for its instance and for the objects its
+// ParametersFrom refer to, an instance for the objects its ParametersFrom
+// refer to.
+func waitingDependencies(sources parametersFromSources, namespace string, dependency parametersFromDependency) []parametersFromDependency {
+	switch dependency.kind {
+	case "ServiceBinding":
+		binding, err := sources.bindingLister.ServiceBindings(namespace).Get(dependency.name)
+		if err != nil || isServiceBindingReady(binding) {
+			return nil
+		}
+		instance := parametersFromDependency{kind: "ServiceInstance", name: binding.Spec.ServiceInstanceRef.Name}
+		return append([]parametersFromDependency{instance}, parametersFromDependencies(binding.Spec.ParametersFrom)...)
+	case "ServiceInstance":
+		instance, err := sources.instanceLister.ServiceInstances(namespace).Get(dependency.name)
+		if err != nil || isServiceInstanceReady(instance) {
+			return nil
+		}
+		return parametersFromDependencies(instance.Spec.ParametersFrom)
+	}
+	return nil
+}
+
+// findParametersFromCycle returns a dependencyFailedError when one of the
+// objects that the ParametersFrom of the given object wait for waits,
+// directly or through other objects, for the given object. None of the
+// objects of such a cycle can ever be Ready.
+func findParametersFromCycle(sources parametersFromSources, namespace string, owner parametersFromDependency, parametersFrom []v1beta1.ParametersFromSource) error {
+	visited := map[parametersFromDependency]bool{}
+	var visit func(path []parametersFromDependency) error
+	visit = func(path []parametersFromDependency) error {
+		dependency := path[len(path)-1]
+		if dependency == owner {
+			cycle := make([]string, len(path))
+			for i, d := range path {
+				cycle[i] = d.String()
+			}
+			return &dependencyFailedError{
+				message: fmt.Sprintf("ParametersFrom form a dependency cycle: %s", strings.Join(cycle, " -> ")),
+			}
+		}
+		if visited[dependency] {
+			return nil
+		}
+		visited[dependency] = true
+		for _, next := range waitingDependencies(sources, namespace, dependency) {
+			if err := visit(append(path[:len(path):len(path)], next)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, dependency := range parametersFromDependencies(parametersFrom) {
+		if err := visit([]parametersFromDependency{owner, dependency}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // buildParameters generates the parameters JSON structure to be passed
 // to the broker.
 // The first return value is a map of parameters to send to the Broker, including
@@ -35,12 +212,12 @@ import (
 // The second return value is a map of parameters with secret values redacted,
 // replaced with "<redacted>".
 // The third return value is any error that caused the function to fail.
-func buildParameters(kubeClient kubernetes.Interface, namespace string, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension) (map[string]interface{}, map[string]interface{}, error) {
+func buildParameters(sources parametersFromSources, namespace string, parametersFrom []v1beta1.ParametersFromSource, parameters *runtime.RawExtension) (map[string]interface{}, map[string]interface{}, error) {
 	params := make(map[string]interface{})
 	paramsWithSecretsRedacted := make(map[string]interface{})
 	if parametersFrom != nil {
 		for _, p := range parametersFrom {
-			fps, err := fetchParametersFromSource(kubeClient, namespace, &p)
+			fps, err := fetchParametersFromSource(sources, namespace, &p)
 			if err != nil {
 				return nil, nil, err
 			}
@@ -49,7 +226,12 @@ func buildParameters(kubeClient kubernetes.Interface, namespace string, paramete
 					return nil, nil, fmt.Errorf("conflict: duplicate entry for parameter %q", k)
 				}
 				params[k] = v
-				paramsWithSecretsRedacted[k] = "<redacted>"
+				if p.ServiceInstanceFieldRef != nil {
+					// The fields of an instance are not secret.
+					paramsWithSecretsRedacted[k] = v
+				} else {
+					paramsWithSecretsRedacted[k] = "<redacted>"
+				}
 			}
 		}
 	}
@@ -79,10 +261,10 @@ func buildParameters(kubeClient kubernetes.Interface, namespace string, paramete
 
 // fetchParametersFromSource fetches data from a specified external source and
 // represents it in the parameters map format
-func fetchParametersFromSource(kubeClient kubernetes.Interface, namespace string, parametersFrom *v1beta1.ParametersFromSource) (map[string]interface{}, error) {
+func fetchParametersFromSource(sources parametersFromSources, namespace string, parametersFrom *v1beta1.ParametersFromSource) (map[string]interface{}, error) {
 	var params map[string]interface{}
 	if parametersFrom.SecretKeyRef != nil {
-		data, err := fetchSecretKeyValue(kubeClient, namespace, parametersFrom.SecretKeyRef)
+		data, err := fetchSecretKeyValue(sources, namespace, parametersFrom.SecretKeyRef)
 		if err != nil {
 			return nil, err
 		}
@@ -93,9 +275,142 @@ func fetchParametersFromSource(kubeClient kubernetes.Interface, namespace string
 		params = p
 
 	}
+	if ref := parametersFrom.ServiceBindingSecretKeyRef; ref != nil {
+		if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.CrossInstanceReferences) {
+			return nil, fmt.Errorf("serviceBindingSecretKeyRef requires the %v feature", scfeatures.CrossInstanceReferences)
+		}
+		data, err := fetchServiceBindingSecretKeyValue(sources, namespace, ref)
+		if err != nil {
+			return nil, err
+		}
+		if ref.Parameter != "" {
+			params = map[string]interface{}{ref.Parameter: string(data)}
+		} else {
+			p, err := unmarshalJSON(data)
+			if err != nil {
+				return nil, err
+			}
+			params = p
+		}
+	}
+	if ref := parametersFrom.ServiceInstanceFieldRef; ref != nil {
+		if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.CrossInstanceReferences) {
+			return nil, fmt.Errorf("serviceInstanceFieldRef requires the %v feature", scfeatures.CrossInstanceReferences)
+		}
+		value, err := fetchServiceInstanceFieldValue(sources.instanceLister, namespace, ref)
+		if err != nil {
+			return nil, err
+		}
+		params = map[string]interface{}{ref.Parameter: value}
+	}
 	return params, nil
 }
 
+// fetchServiceBindingSecretKeyValue returns the contents of the given key of
+// the Secret of a ServiceBinding, once the binding is Ready, and a
+// dependencyFailedError if the binding failed.
+func fetchServiceBindingSecretKeyValue(sources parametersFromSources, namespace string, ref *v1beta1.ServiceBindingSecretKeyReference) ([]byte, error) {
+	binding, err := sources.bindingLister.ServiceBindings(namespace).Get(ref.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &dependencyNotReadyError{
+				message: fmt.Sprintf(`ServiceBinding "%s/%s" does not exist`, namespace, ref.Name),
+			}
+		}
+		return nil, err
+	}
+	if isServiceBindingFailed(binding) {
+		return nil, &dependencyFailedError{
+			message: fmt.Sprintf(`ServiceBinding "%s/%s" failed`, namespace, ref.Name),
+		}
+	}
+	if !isServiceBindingReady(binding) {
+		return nil, &dependencyNotReadyError{
+			message: fmt.Sprintf(`ServiceBinding "%s/%s" is not Ready`, namespace, ref.Name),
+		}
+	}
+	secret, err := sources.getSecret(namespace, binding.Spec.SecretName)
+	if err != nil {
+		return nil, err
+	}
+	data, ok := secret.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf(`key %q not found in the Secret of ServiceBinding "%s/%s"`, ref.Key, namespace, ref.Name)
+	}
+	return data, nil
+}
+
+// fetchServiceInstanceFieldValue returns the value of the given field of a
+// ServiceInstance, once the instance is Ready, and a dependencyFailedError if
+// the instance failed.
+func fetchServiceInstanceFieldValue(instanceLister listers.ServiceInstanceLister, namespace string, ref *v1beta1.ServiceInstanceFieldReference) (interface{}, error) {
+	instance, err := instanceLister.ServiceInstances(namespace).Get(ref.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, &dependencyNotReadyError{
+				message: fmt.Sprintf(`ServiceInstance "%s/%s" does not exist`, namespace, ref.Name),
+			}
+		}
+		return nil, err
+	}
+	if isServiceInstanceFailed(instance) {
+		return nil, &dependencyFailedError{
+			message: fmt.Sprintf(`ServiceInstance "%s/%s" failed`, namespace, ref.Name),
+		}
+	}
+	if !isServiceInstanceReady(instance) {
+		return nil, &dependencyNotReadyError{
+			message: fmt.Sprintf(`ServiceInstance "%s/%s" is not Ready`, namespace, ref.Name),
+		}
+	}
+
+	switch path := ref.FieldPath; {
+	case path == v1beta1.ServiceInstanceFieldPathExternalID:
+		return instance.Spec.ExternalID, nil
+	case path == v1beta1.ServiceInstanceFieldPathDashboardURL:
+		if instance.Status.DashboardURL == nil {
+			return nil, fmt.Errorf(`ServiceInstance "%s/%s" has no dashboard URL`, namespace, ref.Name)
+		}
+		return *instance.Status.DashboardURL, nil
+	case strings.HasPrefix(path, v1beta1.ServiceInstanceFieldPathExternalParameterPrefix):
+		var parameters *runtime.RawExtension
+		if instance.Status.ExternalProperties != nil {
+			parameters = instance.Status.ExternalProperties.Parameters
+		}
+		return lookupInstanceParameter(parameters, strings.TrimPrefix(path, v1beta1.ServiceInstanceFieldPathExternalParameterPrefix), namespace, ref.Name)
+	case strings.HasPrefix(path, v1beta1.ServiceInstanceFieldPathBrokerParameterPrefix):
+		var parameters *runtime.RawExtension
+		if instance.Status.BrokerProperties != nil {
+			parameters = instance.Status.BrokerProperties.Parameters
+		}
+		return lookupInstanceParameter(parameters, strings.TrimPrefix(path, v1beta1.ServiceInstanceFieldPathBrokerParameterPrefix), namespace, ref.Name)
+	default:
+		return nil, fmt.Errorf("unsupported field path %q", path)
+	}
+}
+
+// lookupInstanceParameter returns the value of the given top-level parameter
+// among the parameters recorded in the status of an instance. Parameters that
+// were redacted because they came from a Secret cannot be referenced.
+func lookupInstanceParameter(parameters *runtime.RawExtension, name, namespace, instanceName string) (interface{}, error) {
+	var params map[string]interface{}
+	if parameters != nil {
+		p, err := UnmarshalRawParameters(parameters.Raw)
+		if err != nil {
+			return nil, err
+		}
+		params = p
+	}
+	value, ok := params[name]
+	if !ok {
+		return nil, fmt.Errorf(`parameter %q of ServiceInstance "%s/%s" is not set`, name, namespace, instanceName)
+	}
+	if value == "<redacted>" {
+		return nil, fmt.Errorf(`parameter %q of ServiceInstance "%s/%s" is secret and cannot be referenced`, name, namespace, instanceName)
+	}
+	return value, nil
+}
+
 // UnmarshalRawParameters produces a map structure from a given raw YAML/JSON input
 func UnmarshalRawParameters(in []byte) (map[string]interface{}, error) {
 	parameters := make(map[string]interface{})
@@ -125,8 +440,16 @@ func unmarshalJSON(in []byte) (map[string]interface{}, error) {
 }
 
 // fetchSecretKeyValue requests and returns the contents of the given secret key
-func fetchSecretKeyValue(kubeClient kubernetes.Interface, namespace string, secretKeyRef *v1beta1.SecretKeyReference) ([]byte, error) {
-	secret, err := kubeClient.CoreV1().Secrets(namespace).Get(secretKeyRef.Name, metav1.GetOptions{})
+func fetchSecretKeyValue(sources parametersFromSources, namespace string, secretKeyRef *v1beta1.SecretKeyReference) ([]byte, error) {
+	var (
+		secret *corev1.Secret
+		err    error
+	)
+	if sources.liveSecretKeyRefs {
+		secret, err = sources.kubeClient.CoreV1().Secrets(namespace).Get(secretKeyRef.Name, metav1.GetOptions{})
+	} else {
+		secret, err = sources.getSecret(namespace, secretKeyRef.Name)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -154,9 +477,14 @@ func generateChecksumOfParameters(params map[string]interface{}) (string, error)
 // 2 - a checksum for the map of parameters. This checksum is used to determine if parameters have changed.
 // 3 - the map of parameters marshaled into JSON as a RawExtension
 // 4 - any error that caused the function to fail.
-func prepareInProgressPropertyParameters(kubeClient kubernetes.Interface, namespace string, specParameters *runtime.RawExtension, specParametersFrom []v1beta1.ParametersFromSource) (map[string]interface{}, string, *runtime.RawExtension, error) {
-	parameters, parametersWithSecretsRedacted, err := buildParameters(kubeClient, namespace, specParametersFrom, specParameters)
+//
+// A dependencyNotReadyError or dependencyFailedError is returned as is.
+func prepareInProgressPropertyParameters(sources parametersFromSources, namespace string, specParameters *runtime.RawExtension, specParametersFrom []v1beta1.ParametersFromSource) (map[string]interface{}, string, *runtime.RawExtension, error) {
+	parameters, parametersWithSecretsRedacted, err := buildParameters(sources, namespace, specParametersFrom, specParameters)
 	if err != nil {
+		if isDependencyNotReadyError(err) || isDependencyFailedError(err) {
+			return nil, "", nil, err
+		}
 		return nil, "", nil, fmt.Errorf(
 			"failed to prepare parameters %s: %s",
 			specParameters, err,
@@ -17,14 +17,21 @@ limitations under the License.
 package controller
 
 import (
+	"fmt"
 	"reflect"
 	"testing"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	listers "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/v1beta1"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/diff"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	clientgofake "k8s.io/client-go/kubernetes/fake"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
 )
 
 func TestBuildParameters(t *testing.T) {
@@ -156,7 +163,7 @@ func testBuildParameters(t *testing.T, parametersFrom []v1beta1.ParametersFromSo
 		addGetSecretNotFoundReaction(fakeKubeClient)
 	}
 
-	actual, actualWithSecretsRedacted, err := buildParameters(fakeKubeClient, "test-ns", parametersFrom, parameters)
+	actual, actualWithSecretsRedacted, err := buildParameters(parametersFromSources{kubeClient: fakeKubeClient, liveSecretKeyRefs: true}, "test-ns", parametersFrom, parameters)
 	if shouldSucceed {
 		if err != nil {
 			t.Fatalf("Failed to build parameters: %v", err)
@@ -174,6 +181,312 @@ func testBuildParameters(t *testing.T, parametersFrom []v1beta1.ParametersFromSo
 	}
 }
 
+func TestBuildParametersFromReferences(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.CrossInstanceReferences))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.CrossInstanceReferences))
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "ready-secret", Namespace: "test-ns"},
+		Data: map[string][]byte{
+			"password": []byte("s3cret"),
+			"json-key": []byte(`{ "host": "db.example.com", "port": 5432 }`),
+		},
+	}
+	dashboardURL := "http://dashboard"
+	readyCondition := v1beta1.ServiceInstanceCondition{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionTrue}
+	instances := []*v1beta1.ServiceInstance{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "test-ns"},
+			Spec:       v1beta1.ServiceInstanceSpec{ExternalID: "ready-id"},
+			Status: v1beta1.ServiceInstanceStatus{
+				Conditions:   []v1beta1.ServiceInstanceCondition{readyCondition},
+				DashboardURL: &dashboardURL,
+				ExternalProperties: &v1beta1.ServiceInstancePropertiesState{
+					Parameters: &runtime.RawExtension{Raw: []byte(`{"region": "eu", "password": "<redacted>"}`)},
+				},
+				BrokerProperties: &v1beta1.ServiceInstanceBrokerProperties{
+					Parameters: &runtime.RawExtension{Raw: []byte(`{"size": 3}`)},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "provisioning", Namespace: "test-ns"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "failed", Namespace: "test-ns"},
+			Status: v1beta1.ServiceInstanceStatus{
+				Conditions: []v1beta1.ServiceInstanceCondition{{Type: v1beta1.ServiceInstanceConditionFailed, Status: v1beta1.ConditionTrue}},
+			},
+		},
+	}
+	bindings := []*v1beta1.ServiceBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "test-ns"},
+			Spec:       v1beta1.ServiceBindingSpec{SecretName: "ready-secret"},
+			Status: v1beta1.ServiceBindingStatus{
+				Conditions: []v1beta1.ServiceBindingCondition{{Type: v1beta1.ServiceBindingConditionReady, Status: v1beta1.ConditionTrue}},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: "test-ns"},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "failed", Namespace: "test-ns"},
+			Status: v1beta1.ServiceBindingStatus{
+				Conditions: []v1beta1.ServiceBindingCondition{{Type: v1beta1.ServiceBindingConditionFailed, Status: v1beta1.ConditionTrue}},
+			},
+		},
+	}
+
+	bindingRef := func(name, key, parameter string) []v1beta1.ParametersFromSource {
+		return []v1beta1.ParametersFromSource{{
+			ServiceBindingSecretKeyRef: &v1beta1.ServiceBindingSecretKeyReference{Name: name, Key: key, Parameter: parameter},
+		}}
+	}
+	instanceRef := func(name, fieldPath string) []v1beta1.ParametersFromSource {
+		return []v1beta1.ParametersFromSource{{
+			ServiceInstanceFieldRef: &v1beta1.ServiceInstanceFieldReference{Name: name, FieldPath: fieldPath, Parameter: "p"},
+		}}
+	}
+
+	cases := []struct {
+		name                                  string
+		parametersFrom                        []v1beta1.ParametersFromSource
+		expectedParameters                    map[string]interface{}
+		expectedParametersWithSecretsRedacted map[string]interface{}
+		expectDependencyNotReady              bool
+		expectDependencyFailed                bool
+		expectError                           bool
+	}{
+		{
+			name:                                  "binding secret key as a parameter",
+			parametersFrom:                        bindingRef("ready", "password", "dbPassword"),
+			expectedParameters:                    map[string]interface{}{"dbPassword": "s3cret"},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{"dbPassword": "<redacted>"},
+		},
+		{
+			name:                                  "binding secret key as a JSON object",
+			parametersFrom:                        bindingRef("ready", "json-key", ""),
+			expectedParameters:                    map[string]interface{}{"host": "db.example.com", "port": float64(5432)},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{"host": "<redacted>", "port": "<redacted>"},
+		},
+		{
+			name:           "missing binding secret key",
+			parametersFrom: bindingRef("ready", "other", "p"),
+			expectError:    true,
+		},
+		{
+			name:                     "binding not ready",
+			parametersFrom:           bindingRef("binding", "password", "p"),
+			expectDependencyNotReady: true,
+		},
+		{
+			name:                     "binding does not exist",
+			parametersFrom:           bindingRef("missing", "password", "p"),
+			expectDependencyNotReady: true,
+		},
+		{
+			name:                   "binding failed",
+			parametersFrom:         bindingRef("failed", "password", "p"),
+			expectDependencyFailed: true,
+		},
+		{
+			name:                                  "instance external ID",
+			parametersFrom:                        instanceRef("ready", v1beta1.ServiceInstanceFieldPathExternalID),
+			expectedParameters:                    map[string]interface{}{"p": "ready-id"},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{"p": "ready-id"},
+		},
+		{
+			name:                                  "instance dashboard URL",
+			parametersFrom:                        instanceRef("ready", v1beta1.ServiceInstanceFieldPathDashboardURL),
+			expectedParameters:                    map[string]interface{}{"p": dashboardURL},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{"p": dashboardURL},
+		},
+		{
+			name:                                  "instance parameter",
+			parametersFrom:                        instanceRef("ready", v1beta1.ServiceInstanceFieldPathExternalParameterPrefix+"region"),
+			expectedParameters:                    map[string]interface{}{"p": "eu"},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{"p": "eu"},
+		},
+		{
+			name:                                  "instance parameter reported by the broker",
+			parametersFrom:                        instanceRef("ready", v1beta1.ServiceInstanceFieldPathBrokerParameterPrefix+"size"),
+			expectedParameters:                    map[string]interface{}{"p": float64(3)},
+			expectedParametersWithSecretsRedacted: map[string]interface{}{"p": float64(3)},
+		},
+		{
+			name:           "secret instance parameter",
+			parametersFrom: instanceRef("ready", v1beta1.ServiceInstanceFieldPathExternalParameterPrefix+"password"),
+			expectError:    true,
+		},
+		{
+			name:           "unset instance parameter",
+			parametersFrom: instanceRef("ready", v1beta1.ServiceInstanceFieldPathExternalParameterPrefix+"other"),
+			expectError:    true,
+		},
+		{
+			name:                     "instance not ready",
+			parametersFrom:           instanceRef("provisioning", v1beta1.ServiceInstanceFieldPathExternalID),
+			expectDependencyNotReady: true,
+		},
+		{
+			name:                   "instance failed",
+			parametersFrom:         instanceRef("failed", v1beta1.ServiceInstanceFieldPathExternalID),
+			expectDependencyFailed: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			secretIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			secretIndexer.Add(secret)
+			bindingIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, binding := range bindings {
+				bindingIndexer.Add(binding)
+			}
+			instanceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, instance := range instances {
+				instanceIndexer.Add(instance)
+			}
+			sources := parametersFromSources{
+				secretLister:   corelisters.NewSecretLister(secretIndexer),
+				bindingLister:  listers.NewServiceBindingLister(bindingIndexer),
+				instanceLister: listers.NewServiceInstanceLister(instanceIndexer),
+			}
+
+			actual, actualWithSecretsRedacted, err := buildParameters(sources, "test-ns", tc.parametersFrom, nil)
+			switch {
+			case tc.expectDependencyNotReady:
+				if !isDependencyNotReadyError(err) {
+					t.Fatalf("expected a dependencyNotReadyError, got %v", err)
+				}
+				return
+			case tc.expectDependencyFailed:
+				if !isDependencyFailedError(err) {
+					t.Fatalf("expected a dependencyFailedError, got %v", err)
+				}
+				return
+			case tc.expectError:
+				if err == nil || isDependencyNotReadyError(err) || isDependencyFailedError(err) {
+					t.Fatalf("expected an error, got %v", err)
+				}
+				return
+			case err != nil:
+				t.Fatalf("Failed to build parameters: %v", err)
+			}
+			if !reflect.DeepEqual(actual, tc.expectedParameters) {
+				t.Fatalf("incorrect result: diff \n%v", diff.ObjectGoPrintSideBySide(tc.expectedParameters, actual))
+			}
+			if !reflect.DeepEqual(actualWithSecretsRedacted, tc.expectedParametersWithSecretsRedacted) {
+				t.Fatalf("incorrect result with redacted secrets: diff \n%v", diff.ObjectGoPrintSideBySide(tc.expectedParametersWithSecretsRedacted, actualWithSecretsRedacted))
+			}
+		})
+	}
+}
+
+// TestFindParametersFromCycle tests that references of ParametersFrom that
+// lead back to the object they belong to are detected.
+func TestFindParametersFromCycle(t *testing.T) {
+	instanceRef := func(name string) v1beta1.ParametersFromSource {
+		return v1beta1.ParametersFromSource{
+			ServiceInstanceFieldRef: &v1beta1.ServiceInstanceFieldReference{Name: name, FieldPath: v1beta1.ServiceInstanceFieldPathExternalID, Parameter: name},
+		}
+	}
+	bindingRef := func(name string) v1beta1.ParametersFromSource {
+		return v1beta1.ParametersFromSource{
+			ServiceBindingSecretKeyRef: &v1beta1.ServiceBindingSecretKeyReference{Name: name, Key: "key", Parameter: name},
+		}
+	}
+	newInstance := func(name string, parametersFrom ...v1beta1.ParametersFromSource) *v1beta1.ServiceInstance {
+		return &v1beta1.ServiceInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec:       v1beta1.ServiceInstanceSpec{ParametersFrom: parametersFrom},
+		}
+	}
+	newBinding := func(name, instanceName string) *v1beta1.ServiceBinding {
+		return &v1beta1.ServiceBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "test-ns"},
+			Spec:       v1beta1.ServiceBindingSpec{ServiceInstanceRef: v1beta1.LocalObjectReference{Name: instanceName}},
+		}
+	}
+	ready := newInstance("ready", instanceRef("owner"))
+	ready.Status.Conditions = []v1beta1.ServiceInstanceCondition{{Type: v1beta1.ServiceInstanceConditionReady, Status: v1beta1.ConditionTrue}}
+
+	cases := []struct {
+		name           string
+		parametersFrom []v1beta1.ParametersFromSource
+		instances      []*v1beta1.ServiceInstance
+		bindings       []*v1beta1.ServiceBinding
+		expectedCycle  string
+	}{
+		{
+			name:           "no cycle",
+			parametersFrom: []v1beta1.ParametersFromSource{instanceRef("a")},
+			instances:      []*v1beta1.ServiceInstance{newInstance("a", instanceRef("b")), newInstance("b")},
+		},
+		{
+			name:           "reference to itself",
+			parametersFrom: []v1beta1.ParametersFromSource{instanceRef("owner")},
+			expectedCycle:  `ServiceInstance "owner" -> ServiceInstance "owner"`,
+		},
+		{
+			name:           "reference to its own binding",
+			parametersFrom: []v1beta1.ParametersFromSource{bindingRef("binding")},
+			bindings:       []*v1beta1.ServiceBinding{newBinding("binding", "owner")},
+			expectedCycle:  `ServiceInstance "owner" -> ServiceBinding "binding" -> ServiceInstance "owner"`,
+		},
+		{
+			name:           "indirect reference",
+			parametersFrom: []v1beta1.ParametersFromSource{instanceRef("a")},
+			instances:      []*v1beta1.ServiceInstance{newInstance("a", bindingRef("binding")), newInstance("b", instanceRef("owner"))},
+			bindings:       []*v1beta1.ServiceBinding{newBinding("binding", "b")},
+			expectedCycle:  `ServiceInstance "owner" -> ServiceInstance "a" -> ServiceBinding "binding" -> ServiceInstance "b" -> ServiceInstance "owner"`,
+		},
+		{
+			name:           "cycle through a Ready instance",
+			parametersFrom: []v1beta1.ParametersFromSource{instanceRef("ready")},
+			instances:      []*v1beta1.ServiceInstance{ready},
+		},
+		{
+			name:           "cycle between other instances",
+			parametersFrom: []v1beta1.ParametersFromSource{instanceRef("a")},
+			instances:      []*v1beta1.ServiceInstance{newInstance("a", instanceRef("b")), newInstance("b", instanceRef("a"))},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			instanceIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, instance := range tc.instances {
+				instanceIndexer.Add(instance)
+			}
+			bindingIndexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+			for _, binding := range tc.bindings {
+				bindingIndexer.Add(binding)
+			}
+			sources := parametersFromSources{
+				bindingLister:  listers.NewServiceBindingLister(bindingIndexer),
+				instanceLister: listers.NewServiceInstanceLister(instanceIndexer),
+			}
+
+			owner := parametersFromDependency{kind: "ServiceInstance", name: "owner"}
+			err := findParametersFromCycle(sources, "test-ns", owner, tc.parametersFrom)
+			if tc.expectedCycle == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !isDependencyFailedError(err) {
+				t.Fatalf("expected a dependencyFailedError, got %v", err)
+			}
+			if e, a := "ParametersFrom form a dependency cycle: "+tc.expectedCycle, err.Error(); e != a {
+				t.Fatalf("unexpected error: expected %q, got %q", e, a)
+			}
+		})
+	}
+}
+
 func TestGenerateChecksumOfParameters(t *testing.T) {
 	cases := []struct {
 		name             string
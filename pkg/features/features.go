@@ -71,6 +71,84 @@ const (
 	// owner: @nilebox
 	// alpha: v0.1.14
 	OriginatingIdentityLocking utilfeature.Feature = "OriginatingIdentityLocking"
+
+	// ParametersFromSync enables the controller to watch the Secrets
+	// referenced by the ParametersFrom of ServiceInstances, and to update
+	// the instances at their brokers when the values of those Secrets change.
+	// owner: @mkibbe
+	// alpha: v0.1.15
+	ParametersFromSync utilfeature.Feature = "ParametersFromSync"
+
+	// SecretTransformSync enables the controller to watch the Secrets
+	// referenced by the AddKeysFrom transforms of ServiceBindings, and to
+	// inject the credentials of the bindings again as soon as those Secrets
+	// change. The credentials returned by the broker for bindings with
+	// AddKeysFrom or TemplateKey transforms are kept in a second Secret to
+	// do so. Without it, a binding waiting for such a Secret is bound again
+	// at the next resync.
+	// owner: @mkibbe
+	// alpha: v0.1.15
+	SecretTransformSync utilfeature.Feature = "SecretTransformSync"
+
+	// BindingTargets enables ServiceBindings to copy their Secret into
+	// other namespaces, and to expose a subset of their credentials in a
+	// ConfigMap.
+	// owner: @mkibbe
+	// alpha: v0.1.15
+	BindingTargets utilfeature.Feature = "BindingTargets"
+
+	// DefaultParameters enables the ClusterParameterDefault resource, which
+	// holds default parameters that the DefaultParameters admission plugin
+	// sets on the ServiceInstances of a class or plan.
+	// owner: @mkibbe
+	// alpha: v0.1.15
+	DefaultParameters utilfeature.Feature = "DefaultParameters"
+
+	// PlanMigration enables the MigrationPolicy of ClusterServicePlans,
+	// which makes the controller move the ServiceInstances of a plan removed
+	// from the catalog of its broker to a replacement plan.
+	// owner: @mkibbe
+	// alpha: v0.1.15
+	PlanMigration utilfeature.Feature = "PlanMigration"
+
+	// MaintenanceInfo enables the maintenance information of plans, and the
+	// upgrade of ServiceInstances to the version of their plan.
+	// owner: @mkibbe
+	// alpha: v0.1.15
+	MaintenanceInfo utilfeature.Feature = "MaintenanceInfo"
+
+	// DeletionPolicy enables the deletion policy of ServiceInstances, which
+	// can retain the instance at the broker when it is deleted, and their
+	// protection against deletion.
+	// owner: @mkibbe
+	// alpha: v0.1.15
+	DeletionPolicy utilfeature.Feature = "DeletionPolicy"
+
+	// InstanceAdoption enables the adoption of instances that already exist
+	// at a broker by new ServiceInstances.
+	// owner: @mkibbe
+	// alpha: v0.1.15
+	InstanceAdoption utilfeature.Feature = "InstanceAdoption"
+
+	// CrossInstanceReferences enables ParametersFrom sources that reference
+	// the Secret of a ServiceBinding or a field of another ServiceInstance.
+	// owner: @mkibbe
+	// alpha: v0.1.15
+	CrossInstanceReferences utilfeature.Feature = "CrossInstanceReferences"
+
+	// ServiceInstanceQuota enables the ServiceInstanceQuota resource, which
+	// limits the number of ServiceInstances of a namespace by class, plan or
+	// cost, and the tracking of its usage by the controller.
+	// owner: @mkibbe
+	// alpha: v0.1.15
+	ServiceInstanceQuota utilfeature.Feature = "ServiceInstanceQuota"
+
+	// ServiceVisibilityPolicy enables the ClusterServiceVisibilityPolicy
+	// resource, which restricts the namespaces that a ClusterServiceClass or
+	// ClusterServicePlan can be used in.
+	// owner: @mkibbe
+	// alpha: v0.1.15
+	ServiceVisibilityPolicy utilfeature.Feature = "ServiceVisibilityPolicy"
 )
 
 func init() {
@@ -88,4 +166,15 @@ var defaultServiceCatalogFeatureGates = map[utilfeature.Feature]utilfeature.Feat
 	ResponseSchema:             {Default: false, PreRelease: utilfeature.Alpha},
 	UpdateDashboardURL:         {Default: false, PreRelease: utilfeature.Alpha},
 	OriginatingIdentityLocking: {Default: true, PreRelease: utilfeature.Alpha},
+	ParametersFromSync:         {Default: false, PreRelease: utilfeature.Alpha},
+	SecretTransformSync:        {Default: false, PreRelease: utilfeature.Alpha},
+	BindingTargets:             {Default: false, PreRelease: utilfeature.Alpha},
+	DefaultParameters:          {Default: false, PreRelease: utilfeature.Alpha},
+	PlanMigration:              {Default: false, PreRelease: utilfeature.Alpha},
+	MaintenanceInfo:            {Default: false, PreRelease: utilfeature.Alpha},
+	DeletionPolicy:             {Default: false, PreRelease: utilfeature.Alpha},
+	InstanceAdoption:           {Default: false, PreRelease: utilfeature.Alpha},
+	CrossInstanceReferences:    {Default: false, PreRelease: utilfeature.Alpha},
+	ServiceInstanceQuota:       {Default: false, PreRelease: utilfeature.Alpha},
+	ServiceVisibilityPolicy:    {Default: false, PreRelease: utilfeature.Alpha},
 }
@@ -0,0 +1,58 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jsonschema
+
+import (
+	"github.com/go-openapi/spec"
+)
+
+// Defaults returns the default values that the schema declares for the
+// top-level properties of an object, keyed by property name. Defaults
+// declared deeper in the schema, or in its allOf, anyOf and oneOf
+// subschemas, are not returned.
+func (s *Schema) Defaults() map[string]interface{} {
+	root := s.dereference(s.root)
+	if root == nil {
+		return nil
+	}
+
+	defaults := map[string]interface{}{}
+	for name := range root.Properties {
+		property := root.Properties[name]
+		if sch := s.dereference(&property); sch != nil && sch.Default != nil {
+			defaults[name] = sch.Default
+		}
+	}
+	return defaults
+}
+
+// dereference follows the references of the given schema until it reaches
+// a schema that is not a reference. It returns nil if the references loop.
+func (s *Schema) dereference(sch *spec.Schema) *spec.Schema {
+	for i := 0; i <= maxDepth; i++ {
+		ref, ok := reference(sch)
+		if !ok {
+			return sch
+		}
+		target, err := s.resolve(ref)
+		if err != nil {
+			return nil
+		}
+		sch = target
+	}
+	return nil
+}
@@ -0,0 +1,485 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package jsonschema validates parameters against the draft-04 JSON schemas
+// that brokers publish for the plans of their catalog.
+//
+// All the validation keywords of draft-04 are supported. The format keyword
+// is ignored, as draft-04 makes it optional, and only references to the
+// schema itself ("#/definitions/...") can be resolved. Patterns are evaluated
+// with the Go regexp syntax, schemas using ECMA 262 features that it lacks
+// fail to parse.
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/spec"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// maxDepth is the maximum number of subschemas a value is validated through,
+// so that recursive schemas cannot loop forever.
+const maxDepth = 100
+
+// Schema is a parsed JSON schema.
+type Schema struct {
+	root     *spec.Schema
+	refs     map[string]*spec.Schema
+	patterns map[string]*regexp.Regexp
+}
+
+// Parse parses the given draft-04 JSON schema. It fails if the schema
+// references other documents or holds patterns that cannot be compiled.
+func Parse(data []byte) (*Schema, error) {
+	root := &spec.Schema{}
+	if err := json.Unmarshal(data, root); err != nil {
+		return nil, err
+	}
+	s := &Schema{
+		root:     root,
+		refs:     map[string]*spec.Schema{},
+		patterns: map[string]*regexp.Regexp{},
+	}
+	if err := s.compile(root); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// compile resolves the references and compiles the patterns of the given
+// schema and of its subschemas.
+func (s *Schema) compile(sch *spec.Schema) error {
+	if ref, ok := reference(sch); ok {
+		if _, err := s.resolve(ref); err != nil {
+			return err
+		}
+	}
+	if sch.Pattern != "" {
+		if err := s.compilePattern(sch.Pattern); err != nil {
+			return err
+		}
+	}
+	for pattern := range sch.PatternProperties {
+		if err := s.compilePattern(pattern); err != nil {
+			return err
+		}
+	}
+
+	var subschemas []*spec.Schema
+	if sch.Items != nil {
+		if sch.Items.Schema != nil {
+			subschemas = append(subschemas, sch.Items.Schema)
+		}
+		for i := range sch.Items.Schemas {
+			subschemas = append(subschemas, &sch.Items.Schemas[i])
+		}
+	}
+	for _, schemas := range [][]spec.Schema{sch.AllOf, sch.AnyOf, sch.OneOf} {
+		for i := range schemas {
+			subschemas = append(subschemas, &schemas[i])
+		}
+	}
+	if sch.Not != nil {
+		subschemas = append(subschemas, sch.Not)
+	}
+	for _, schemas := range []map[string]spec.Schema{sch.Properties, sch.PatternProperties, sch.Definitions} {
+		for k := range schemas {
+			sub := schemas[k]
+			subschemas = append(subschemas, &sub)
+		}
+	}
+	for _, b := range []*spec.SchemaOrBool{sch.AdditionalProperties, sch.AdditionalItems} {
+		if b != nil && b.Schema != nil {
+			subschemas = append(subschemas, b.Schema)
+		}
+	}
+	for k := range sch.Dependencies {
+		if dep := sch.Dependencies[k]; dep.Schema != nil {
+			subschemas = append(subschemas, dep.Schema)
+		}
+	}
+
+	for _, sub := range subschemas {
+		if err := s.compile(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Schema) compilePattern(pattern string) error {
+	if _, ok := s.patterns[pattern]; ok {
+		return nil
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern %q: %v", pattern, err)
+	}
+	s.patterns[pattern] = re
+	return nil
+}
+
+// reference returns the reference of the given schema, if it has one. A
+// reference to the root of the schema ("#") is returned as an empty string.
+func reference(sch *spec.Schema) (string, bool) {
+	if sch.Ref.GetURL() == nil {
+		return "", false
+	}
+	return sch.Ref.String(), true
+}
+
+// resolve returns the subschema of the root schema the given reference
+// points to.
+func (s *Schema) resolve(ref string) (*spec.Schema, error) {
+	if sch, ok := s.refs[ref]; ok {
+		return sch, nil
+	}
+	if ref != "" && !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("unsupported reference %q: only references within the schema are supported", ref)
+	}
+
+	var target *spec.Schema
+	if pointer := strings.TrimPrefix(ref, "#"); pointer == "" {
+		target = s.root
+	} else {
+		p, err := jsonpointer.New(pointer)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference %q: %v", ref, err)
+		}
+		value, _, err := p.Get(*s.root)
+		if err != nil {
+			return nil, fmt.Errorf("invalid reference %q: %v", ref, err)
+		}
+		switch v := value.(type) {
+		case spec.Schema:
+			target = &v
+		case *spec.Schema:
+			target = v
+		default:
+			return nil, fmt.Errorf("invalid reference %q: it does not point to a schema", ref)
+		}
+	}
+	s.refs[ref] = target
+	return target, nil
+}
+
+// Validate validates the given value, decoded from JSON, against the schema.
+// The errors are reported under the given path. They do not include the
+// invalid values, as parameters may hold secrets.
+func (s *Schema) Validate(value interface{}, fldPath *field.Path) field.ErrorList {
+	return s.validate(s.root, value, fldPath, 0)
+}
+
+func (s *Schema) validate(sch *spec.Schema, value interface{}, fldPath *field.Path, depth int) field.ErrorList {
+	if depth > maxDepth {
+		return field.ErrorList{field.InternalError(fldPath, fmt.Errorf("the schema nests more than %d subschemas", maxDepth))}
+	}
+
+	if ref, ok := reference(sch); ok {
+		// Other keywords are ignored next to a reference.
+		target, err := s.resolve(ref)
+		if err != nil {
+			return field.ErrorList{field.InternalError(fldPath, err)}
+		}
+		return s.validate(target, value, fldPath, depth+1)
+	}
+
+	allErrs := field.ErrorList{}
+
+	if len(sch.Type) > 0 && !matchesType(sch.Type, value) {
+		return append(allErrs, invalid(fldPath, fmt.Sprintf("must be of type %s", strings.Join(sch.Type, " or "))))
+	}
+
+	if len(sch.Enum) > 0 && !inEnum(sch.Enum, value) {
+		allowed := make([]string, 0, len(sch.Enum))
+		for _, e := range sch.Enum {
+			b, _ := json.Marshal(e)
+			allowed = append(allowed, string(b))
+		}
+		allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must be one of %s", strings.Join(allowed, ", "))))
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		allErrs = append(allErrs, s.validateObject(sch, v, fldPath, depth)...)
+	case []interface{}:
+		allErrs = append(allErrs, s.validateArray(sch, v, fldPath, depth)...)
+	case string:
+		allErrs = append(allErrs, s.validateString(sch, v, fldPath)...)
+	case float64:
+		allErrs = append(allErrs, validateNumber(sch, v, fldPath)...)
+	}
+
+	for i := range sch.AllOf {
+		allErrs = append(allErrs, s.validate(&sch.AllOf[i], value, fldPath, depth+1)...)
+	}
+	if len(sch.AnyOf) > 0 && s.countMatches(sch.AnyOf, value, fldPath, depth) == 0 {
+		allErrs = append(allErrs, invalid(fldPath, "must match at least one of the schemas of anyOf"))
+	}
+	if len(sch.OneOf) > 0 && s.countMatches(sch.OneOf, value, fldPath, depth) != 1 {
+		allErrs = append(allErrs, invalid(fldPath, "must match exactly one of the schemas of oneOf"))
+	}
+	if sch.Not != nil && len(s.validate(sch.Not, value, fldPath, depth+1)) == 0 {
+		allErrs = append(allErrs, invalid(fldPath, "must not match the schema of not"))
+	}
+
+	return allErrs
+}
+
+func (s *Schema) countMatches(schemas []spec.Schema, value interface{}, fldPath *field.Path, depth int) int {
+	matches := 0
+	for i := range schemas {
+		if len(s.validate(&schemas[i], value, fldPath, depth+1)) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+func (s *Schema) validateObject(sch *spec.Schema, object map[string]interface{}, fldPath *field.Path, depth int) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if sch.MinProperties != nil && int64(len(object)) < *sch.MinProperties {
+		allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must have at least %d properties", *sch.MinProperties)))
+	}
+	if sch.MaxProperties != nil && int64(len(object)) > *sch.MaxProperties {
+		allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must have at most %d properties", *sch.MaxProperties)))
+	}
+
+	for _, name := range sch.Required {
+		if _, ok := object[name]; !ok {
+			allErrs = append(allErrs, field.Required(fldPath.Child(name), ""))
+		}
+	}
+
+	keys := make([]string, 0, len(object))
+	for k := range object {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		v := object[k]
+		matched := false
+		if ps, ok := sch.Properties[k]; ok {
+			matched = true
+			allErrs = append(allErrs, s.validate(&ps, v, fldPath.Child(k), depth+1)...)
+		}
+		for pattern, ps := range sch.PatternProperties {
+			if s.patterns[pattern].MatchString(k) {
+				matched = true
+				ps := ps
+				allErrs = append(allErrs, s.validate(&ps, v, fldPath.Child(k), depth+1)...)
+			}
+		}
+		if matched || sch.AdditionalProperties == nil {
+			continue
+		}
+		if sch.AdditionalProperties.Schema != nil {
+			allErrs = append(allErrs, s.validate(sch.AdditionalProperties.Schema, v, fldPath.Child(k), depth+1)...)
+		} else if !sch.AdditionalProperties.Allows {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child(k), "is not a property allowed by the schema"))
+		}
+	}
+
+	for _, k := range keys {
+		dep, ok := sch.Dependencies[k]
+		if !ok {
+			continue
+		}
+		if dep.Schema != nil {
+			allErrs = append(allErrs, s.validate(dep.Schema, object, fldPath, depth+1)...)
+		}
+		for _, name := range dep.Property {
+			if _, ok := object[name]; !ok {
+				allErrs = append(allErrs, field.Required(fldPath.Child(name), fmt.Sprintf("required when %q is set", k)))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func (s *Schema) validateArray(sch *spec.Schema, array []interface{}, fldPath *field.Path, depth int) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if sch.MinItems != nil && int64(len(array)) < *sch.MinItems {
+		allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must have at least %d items", *sch.MinItems)))
+	}
+	if sch.MaxItems != nil && int64(len(array)) > *sch.MaxItems {
+		allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must have at most %d items", *sch.MaxItems)))
+	}
+	if sch.UniqueItems {
+		for i := range array {
+			for j := 0; j < i; j++ {
+				if jsonEqual(array[i], array[j]) {
+					allErrs = append(allErrs, field.Duplicate(fldPath.Index(i), omittedValue{}))
+					break
+				}
+			}
+		}
+	}
+
+	if sch.Items == nil {
+		return allErrs
+	}
+	if sch.Items.Schema != nil {
+		for i, item := range array {
+			allErrs = append(allErrs, s.validate(sch.Items.Schema, item, fldPath.Index(i), depth+1)...)
+		}
+		return allErrs
+	}
+	for i, item := range array {
+		switch {
+		case i < len(sch.Items.Schemas):
+			allErrs = append(allErrs, s.validate(&sch.Items.Schemas[i], item, fldPath.Index(i), depth+1)...)
+		case sch.AdditionalItems == nil:
+		case sch.AdditionalItems.Schema != nil:
+			allErrs = append(allErrs, s.validate(sch.AdditionalItems.Schema, item, fldPath.Index(i), depth+1)...)
+		case !sch.AdditionalItems.Allows:
+			allErrs = append(allErrs, field.Forbidden(fldPath.Index(i), fmt.Sprintf("must have at most %d items", len(sch.Items.Schemas))))
+		}
+	}
+	return allErrs
+}
+
+func (s *Schema) validateString(sch *spec.Schema, str string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	length := int64(utf8.RuneCountInString(str))
+	if sch.MinLength != nil && length < *sch.MinLength {
+		allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must be at least %d characters long", *sch.MinLength)))
+	}
+	if sch.MaxLength != nil && length > *sch.MaxLength {
+		allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must be at most %d characters long", *sch.MaxLength)))
+	}
+	if sch.Pattern != "" && !s.patterns[sch.Pattern].MatchString(str) {
+		allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must match the pattern %q", sch.Pattern)))
+	}
+	return allErrs
+}
+
+func validateNumber(sch *spec.Schema, number float64, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if sch.MultipleOf != nil && *sch.MultipleOf > 0 {
+		q := number / *sch.MultipleOf
+		if math.Abs(q-math.Round(q)) > 1e-9 {
+			allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must be a multiple of %v", *sch.MultipleOf)))
+		}
+	}
+	if sch.Minimum != nil {
+		if sch.ExclusiveMinimum && number <= *sch.Minimum {
+			allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must be greater than %v", *sch.Minimum)))
+		} else if number < *sch.Minimum {
+			allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must be greater than or equal to %v", *sch.Minimum)))
+		}
+	}
+	if sch.Maximum != nil {
+		if sch.ExclusiveMaximum && number >= *sch.Maximum {
+			allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must be less than %v", *sch.Maximum)))
+		} else if number > *sch.Maximum {
+			allErrs = append(allErrs, invalid(fldPath, fmt.Sprintf("must be less than or equal to %v", *sch.Maximum)))
+		}
+	}
+	return allErrs
+}
+
+// matchesType returns whether the given value is of one of the given JSON
+// schema types.
+func matchesType(types spec.StringOrArray, value interface{}) bool {
+	for _, t := range types {
+		switch v := value.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		case []interface{}:
+			if t == "array" {
+				return true
+			}
+		case map[string]interface{}:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func inEnum(enum []interface{}, value interface{}) bool {
+	for _, e := range enum {
+		if jsonEqual(e, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonEqual returns whether the given values, decoded from JSON, are equal.
+// Numbers may have been decoded to different types from the schema and from
+// the parameters.
+func jsonEqual(a, b interface{}) bool {
+	if reflect.DeepEqual(a, b) {
+		return true
+	}
+	ab, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bb, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	var an, bn interface{}
+	if json.Unmarshal(ab, &an) != nil || json.Unmarshal(bb, &bn) != nil {
+		return false
+	}
+	return reflect.DeepEqual(an, bn)
+}
+
+// omittedValue stands for the values in errors.
+type omittedValue struct{}
+
+func (omittedValue) String() string {
+	return "(value omitted)"
+}
+
+func invalid(fldPath *field.Path, detail string) *field.Error {
+	return field.Invalid(fldPath, omittedValue{}, detail)
+}
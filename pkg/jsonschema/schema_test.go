@@ -0,0 +1,216 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const testSchema = `{
+	"$schema": "http://json-schema.org/draft-04/schema#",
+	"type": "object",
+	"properties": {
+		"name": {"type": "string", "minLength": 2, "maxLength": 5, "pattern": "^[a-z]+$"},
+		"size": {"type": "integer", "minimum": 1, "maximum": 10, "exclusiveMaximum": true},
+		"ratio": {"type": "number", "multipleOf": 0.1},
+		"tier": {"enum": ["free", "paid"]},
+		"tags": {"type": "array", "items": {"type": "string"}, "maxItems": 2, "uniqueItems": true},
+		"pair": {"type": "array", "items": [{"type": "string"}, {"type": "integer"}], "additionalItems": false},
+		"address": {"$ref": "#/definitions/address"},
+		"port": {"anyOf": [{"type": "integer"}, {"type": "string", "pattern": "^[0-9]+$"}]},
+		"mode": {"oneOf": [{"enum": ["a", "b"]}, {"enum": ["b", "c"]}]},
+		"user": {"not": {"enum": ["root"]}},
+		"password": {"type": "string"}
+	},
+	"patternProperties": {
+		"^x-": {"type": "string"}
+	},
+	"additionalProperties": false,
+	"required": ["name"],
+	"dependencies": {
+		"password": ["user"]
+	},
+	"definitions": {
+		"address": {
+			"type": "object",
+			"properties": {"host": {"type": "string"}},
+			"required": ["host"]
+		}
+	}
+}`
+
+func TestValidate(t *testing.T) {
+	schema, err := Parse([]byte(testSchema))
+	if err != nil {
+		t.Fatalf("unexpected error parsing the schema: %v", err)
+	}
+
+	cases := []struct {
+		name       string
+		parameters string
+		errors     []string
+	}{
+		{
+			name:       "valid",
+			parameters: `{"name": "db", "size": 9, "ratio": 0.3, "tier": "free", "tags": ["a", "b"], "pair": ["a", 1], "address": {"host": "h"}, "port": "80", "mode": "a", "user": "me", "password": "p", "x-extra": "e"}`,
+		},
+		{
+			name:       "missing required property",
+			parameters: `{}`,
+			errors:     []string{"spec.parameters.name: Required value"},
+		},
+		{
+			name:       "wrong type",
+			parameters: `{"name": 1}`,
+			errors:     []string{"spec.parameters.name: Invalid value: (value omitted): must be of type string"},
+		},
+		{
+			name:       "string constraints",
+			parameters: `{"name": "ABCDEF"}`,
+			errors: []string{
+				"spec.parameters.name: Invalid value: (value omitted): must be at most 5 characters long",
+				`spec.parameters.name: Invalid value: (value omitted): must match the pattern "^[a-z]+$"`,
+			},
+		},
+		{
+			name:       "number constraints",
+			parameters: `{"name": "db", "size": 10, "ratio": 0.35}`,
+			errors: []string{
+				"spec.parameters.ratio: Invalid value: (value omitted): must be a multiple of 0.1",
+				"spec.parameters.size: Invalid value: (value omitted): must be less than 10",
+			},
+		},
+		{
+			name:       "not an integer",
+			parameters: `{"name": "db", "size": 1.5}`,
+			errors:     []string{"spec.parameters.size: Invalid value: (value omitted): must be of type integer"},
+		},
+		{
+			name:       "enum",
+			parameters: `{"name": "db", "tier": "gold"}`,
+			errors:     []string{`spec.parameters.tier: Invalid value: (value omitted): must be one of "free", "paid"`},
+		},
+		{
+			name:       "array constraints",
+			parameters: `{"name": "db", "tags": ["a", "a", 1], "pair": ["a", 1, 2]}`,
+			errors: []string{
+				"spec.parameters.pair[2]: Forbidden: must have at most 2 items",
+				"spec.parameters.tags: Invalid value: (value omitted): must have at most 2 items",
+				"spec.parameters.tags[1]: Duplicate value: (value omitted)",
+				"spec.parameters.tags[2]: Invalid value: (value omitted): must be of type string",
+			},
+		},
+		{
+			name:       "reference",
+			parameters: `{"name": "db", "address": {}}`,
+			errors:     []string{"spec.parameters.address.host: Required value"},
+		},
+		{
+			name:       "combinations",
+			parameters: `{"name": "db", "port": "http", "mode": "b", "user": "root"}`,
+			errors: []string{
+				"spec.parameters.mode: Invalid value: (value omitted): must match exactly one of the schemas of oneOf",
+				"spec.parameters.port: Invalid value: (value omitted): must match at least one of the schemas of anyOf",
+				"spec.parameters.user: Invalid value: (value omitted): must not match the schema of not",
+			},
+		},
+		{
+			name:       "additional and dependent properties",
+			parameters: `{"name": "db", "other": 1, "password": "p", "x-extra": 1}`,
+			errors: []string{
+				"spec.parameters.other: Forbidden: is not a property allowed by the schema",
+				`spec.parameters.user: Required value: required when "password" is set`,
+				"spec.parameters.x-extra: Invalid value: (value omitted): must be of type string",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var parameters interface{}
+			if err := json.Unmarshal([]byte(tc.parameters), &parameters); err != nil {
+				t.Fatal(err)
+			}
+			errs := schema.Validate(parameters, field.NewPath("spec", "parameters"))
+			var actual []string
+			for _, err := range errs {
+				actual = append(actual, err.Error())
+			}
+			sort.Strings(actual)
+			if e, a := strings.Join(tc.errors, "\n"), strings.Join(actual, "\n"); e != a {
+				t.Fatalf("unexpected errors:\nexpected:\n%s\ngot:\n%s", e, a)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"not json":         `{`,
+		"remote reference": `{"$ref": "http://example.com/schema.json"}`,
+		"missing target":   `{"properties": {"a": {"$ref": "#/definitions/missing"}}}`,
+		"invalid pattern":  `{"pattern": "^(?!a)"}`,
+	}
+	for name, schema := range cases {
+		if _, err := Parse([]byte(schema)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestValidateRecursiveSchema(t *testing.T) {
+	schema, err := Parse([]byte(`{"allOf": [{"$ref": "#"}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing the schema: %v", err)
+	}
+	errs := schema.Validate(map[string]interface{}{}, field.NewPath("spec", "parameters"))
+	if len(errs) != 1 || errs[0].Type != field.ErrorTypeInternal {
+		t.Fatalf("expected an internal error, got %v", errs)
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	schema, err := Parse([]byte(`{
+		"type": "object",
+		"properties": {
+			"region": {"type": "string", "default": "eu"},
+			"size": {"$ref": "#/definitions/size"},
+			"name": {"type": "string"},
+			"backup": {
+				"type": "object",
+				"properties": {"window": {"type": "string", "default": "02:00"}}
+			}
+		},
+		"definitions": {
+			"size": {"type": "integer", "default": 5}
+		}
+	}`))
+	if err != nil {
+		t.Fatalf("unexpected error parsing the schema: %v", err)
+	}
+	defaults := schema.Defaults()
+	expected := map[string]interface{}{"region": "eu", "size": float64(5)}
+	if !reflect.DeepEqual(defaults, expected) {
+		t.Fatalf("unexpected defaults: expected %v, got %v", expected, defaults)
+	}
+}
@@ -72,6 +72,44 @@ var (
 		},
 		[]string{"broker", "method", "status"},
 	)
+
+	// BrokerPostponedRequests exposes the number of requests to Open Service
+	// Brokers that waited because of the rate limits of their broker. The
+	// metric is broken out by broker name and budget ('requests' or 'polls').
+	BrokerPostponedRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: catalogNamespace,
+			Name:      "broker_postponed_requests_total",
+			Help:      "Number of requests to the specified Service Broker that waited because of the rate limits of the broker, grouped by broker name and budget.",
+		},
+		[]string{"broker", "budget"},
+	)
+
+	// BrokerRequestWaitSeconds exposes how long the requests to Open Service
+	// Brokers that waited because of the rate limits of their broker waited
+	// before being sent. The metric is broken out by broker name and budget
+	// ('requests' or 'polls').
+	BrokerRequestWaitSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: catalogNamespace,
+			Name:      "broker_request_wait_seconds",
+			Help:      "Time the requests to the specified Service Broker waited because of the rate limits of the broker before being sent, grouped by broker name and budget.",
+			Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10),
+		},
+		[]string{"broker", "budget"},
+	)
+
+	// BrokerCircuitOpen exposes whether the circuit of each broker is open,
+	// that is whether the work for the resources of the broker is paused
+	// because the broker failed to respond to repeated requests.
+	BrokerCircuitOpen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: catalogNamespace,
+			Name:      "broker_circuit_open",
+			Help:      "Whether the circuit of the specified Service Broker is open (1) or closed (0).",
+		},
+		[]string{"broker"},
+	)
 )
 
 func register(registry *prometheus.Registry) {
@@ -79,6 +117,9 @@ func register(registry *prometheus.Registry) {
 		registry.MustRegister(BrokerServiceClassCount)
 		registry.MustRegister(BrokerServicePlanCount)
 		registry.MustRegister(OSBRequestCount)
+		registry.MustRegister(BrokerPostponedRequests)
+		registry.MustRegister(BrokerRequestWaitSeconds)
+		registry.MustRegister(BrokerCircuitOpen)
 	})
 }
 
@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	"github.com/golang/glog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerclient"
 	"github.com/kubernetes-incubator/service-catalog/pkg/metrics"
 	osb "github.com/pmorie/go-open-service-broker-client/v2"
 )
@@ -30,13 +31,13 @@ import (
 // interface
 type proxyclient struct {
 	brokerName    string
-	realOSBClient osb.Client
+	realOSBClient brokerclient.Client
 }
 
 // NewClient is a CreateFunc for creating a new functional Client and
 // implements the CreateFunc interface.
-func NewClient(config *osb.ClientConfiguration) (osb.Client, error) {
-	osbClient, err := osb.NewClient(config)
+func NewClient(config *osb.ClientConfiguration) (brokerclient.Client, error) {
+	osbClient, err := brokerclient.NewClient(config)
 	if err != nil {
 		return nil, err
 	}
@@ -45,13 +46,14 @@ func NewClient(config *osb.ClientConfiguration) (osb.Client, error) {
 	return proxy, nil
 }
 
-var _ osb.CreateFunc = NewClient
+var _ brokerclient.CreateFunc = NewClient
 
 const (
 	getCatalog               = "GetCatalog"
 	provisionInstance        = "ProvisionInstance"
 	deprovisionInstance      = "DeprovisionInstance"
 	updateInstance           = "UpdateInstance"
+	getInstance              = "GetInstance"
 	pollLastOperation        = "PollLastOperation"
 	pollBindingLastOperation = "PollBindingLastOperation"
 	bind                     = "Bind"
@@ -69,6 +71,16 @@ func (pc proxyclient) GetCatalog() (*osb.CatalogResponse, error) {
 	return response, err
 }
 
+// GetCatalogWithExtensions implements
+// brokerclient.Client.GetCatalogWithExtensions by proxying the method to the
+// underlying implementation and capturing request metrics.
+func (pc proxyclient) GetCatalogWithExtensions() (*brokerclient.CatalogResponse, error) {
+	glog.V(9).Info("OSBClientProxy GetCatalogWithExtensions()")
+	response, err := pc.realOSBClient.GetCatalogWithExtensions()
+	pc.updateMetrics(getCatalog, err)
+	return response, err
+}
+
 // ProvisionInstance implements
 // go-open-service-broker-client/v2/Client.ProvisionInstance by proxying the
 // method to the underlying implementation and capturing request metrics.
@@ -80,6 +92,16 @@ func (pc proxyclient) ProvisionInstance(r *osb.ProvisionRequest) (*osb.Provision
 
 }
 
+// ProvisionInstanceWithExtensions implements
+// brokerclient.Client.ProvisionInstanceWithExtensions by proxying the method
+// to the underlying implementation and capturing request metrics.
+func (pc proxyclient) ProvisionInstanceWithExtensions(r *brokerclient.ProvisionRequest) (*osb.ProvisionResponse, error) {
+	glog.V(9).Info("OSBClientProxy ProvisionInstanceWithExtensions()")
+	response, err := pc.realOSBClient.ProvisionInstanceWithExtensions(r)
+	pc.updateMetrics(provisionInstance, err)
+	return response, err
+}
+
 // UpdateInstance implements
 // go-open-service-broker-client/v2/Client.UpdateInstance by proxying the method
 // to the underlying implementation and capturing request metrics.
@@ -90,6 +112,16 @@ func (pc proxyclient) UpdateInstance(r *osb.UpdateInstanceRequest) (*osb.UpdateI
 	return response, err
 }
 
+// UpdateInstanceWithExtensions implements
+// brokerclient.Client.UpdateInstanceWithExtensions by proxying the method to
+// the underlying implementation and capturing request metrics.
+func (pc proxyclient) UpdateInstanceWithExtensions(r *brokerclient.UpdateInstanceRequest) (*osb.UpdateInstanceResponse, error) {
+	glog.V(9).Info("OSBClientProxy UpdateInstanceWithExtensions()")
+	response, err := pc.realOSBClient.UpdateInstanceWithExtensions(r)
+	pc.updateMetrics(updateInstance, err)
+	return response, err
+}
+
 // DeprovisionInstance implements
 // go-open-service-broker-client/v2/Client.DeprovisionInstance by proxying the
 // method to the underlying implementation and capturing request metrics.
@@ -100,6 +132,15 @@ func (pc proxyclient) DeprovisionInstance(r *osb.DeprovisionRequest) (*osb.Depro
 	return response, err
 }
 
+// GetInstance implements brokerclient.Client.GetInstance by proxying the
+// method to the underlying implementation and capturing request metrics.
+func (pc proxyclient) GetInstance(r *brokerclient.GetInstanceRequest) (*brokerclient.GetInstanceResponse, error) {
+	glog.V(9).Info("OSBClientProxy GetInstance()")
+	response, err := pc.realOSBClient.GetInstance(r)
+	pc.updateMetrics(getInstance, err)
+	return response, err
+}
+
 // PollLastOperation implements
 // go-open-service-broker-client/v2/Client.PollLastOperation by proxying the
 // method to the underlying implementation and capturing request metrics.
@@ -193,6 +193,45 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			Dependencies: []string{
 				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"},
 		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterOAuth2AuthConfig": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ClusterOAuth2AuthConfig provides config for the OAuth2 client credentials authentication of cluster scoped brokers.",
+					Properties: map[string]spec.Schema{
+						"tokenURL": {
+							SchemaProps: spec.SchemaProps{
+								Description: "TokenURL is the URL of the token endpoint of the authorization server.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"secretRef": {
+							SchemaProps: spec.SchemaProps{
+								Description: "SecretRef is a reference to a Secret containing information the catalog should use to obtain access tokens for this ServiceBroker.\n\nRequired fields: - Secret.Data[\"clientID\"] - client ID used to obtain access tokens - Secret.Data[\"clientSecret\"] - client secret used to obtain access tokens",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"),
+							},
+						},
+						"scopes": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Scopes are the scopes to request for the access tokens.",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Type:   []string{"string"},
+											Format: "",
+										},
+									},
+								},
+							},
+						},
+					},
+					Required: []string{"tokenURL"},
+				},
+			},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"},
+		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -210,6 +249,123 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterParameterDefault": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ClusterParameterDefault holds default values for the parameters of the ServiceInstances of a ClusterServiceClass, or of one of its ClusterServicePlans. The defaults are set on the instances that are created without these parameters by the DefaultParameters admission plugin.",
+					Properties: map[string]spec.Schema{
+						"kind": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"apiVersion": {
+							SchemaProps: spec.SchemaProps{
+								Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"metadata": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Non-namespaced.  The name of this resource in etcd is in ObjectMeta.Name. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							},
+						},
+						"spec": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Spec defines the parameters to default, and the instances they are defaulted on.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterParameterDefaultSpec"),
+							},
+						},
+					},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						"x-kubernetes-print-columns": "custom-columns=NAME:.metadata.name,CLASS:.spec.clusterServiceClassExternalName,PLAN:.spec.clusterServicePlanExternalName",
+					},
+				},
+			},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterParameterDefaultSpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterParameterDefaultList": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ClusterParameterDefaultList is a list of ClusterParameterDefaults.",
+					Properties: map[string]spec.Schema{
+						"kind": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"apiVersion": {
+							SchemaProps: spec.SchemaProps{
+								Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"metadata": {
+							SchemaProps: spec.SchemaProps{
+								Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+							},
+						},
+						"items": {
+							SchemaProps: spec.SchemaProps{
+								Type: []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterParameterDefault"),
+										},
+									},
+								},
+							},
+						},
+					},
+					Required: []string{"items"},
+				},
+			},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterParameterDefault", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterParameterDefaultSpec": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ClusterParameterDefaultSpec represents the default parameters of the instances of a class or plan.",
+					Properties: map[string]spec.Schema{
+						"clusterServiceClassExternalName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ClusterServiceClassExternalName is the external name of the ClusterServiceClass whose instances are defaulted.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"clusterServicePlanExternalName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ClusterServicePlanExternalName is the external name of the ClusterServicePlan whose instances are defaulted. When it is empty, the instances of every plan of the class are defaulted.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"parameters": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Parameters is a JSON object holding the default value of each top-level parameter. The defaults of a plan take precedence over the defaults of its class, which take precedence over the defaults declared in the schemas of the plan.\n\nThe Parameters field is NOT secret or secured in any way and should NEVER be used to hold sensitive information.",
+								Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+							},
+						},
+					},
+					Required: []string{"clusterServiceClassExternalName", "parameters"},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBroker": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -275,11 +431,23 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig"),
 							},
 						},
+						"tls": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ClusterTLSAuthConfig provides configuration to authenticate with a client certificate. The certificate and key are referenced from the 'tls.crt' and 'tls.key' fields of the given kubernetes.io/tls secret.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterTLSAuthConfig"),
+							},
+						},
+						"oauth2": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ClusterOAuth2AuthConfig provides configuration to send an access token, obtained from an OAuth2 authorization server with the client credentials grant, as a bearer token.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterOAuth2AuthConfig"),
+							},
+						},
 					},
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBasicAuthConfig", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBasicAuthConfig", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterBearerTokenAuthConfig", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterOAuth2AuthConfig", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterTLSAuthConfig"},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerList": {
 			Schema: spec.Schema{
@@ -376,6 +544,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 							},
 						},
+						"rateLimits": {
+							SchemaProps: spec.SchemaProps{
+								Description: "RateLimits limits the requests that the controller sends to the broker. The limits that are not set default to the ones the controller manager is configured with.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimits"),
+							},
+						},
 						"authInfo": {
 							SchemaProps: spec.SchemaProps{
 								Description: "AuthInfo contains the data that the service catalog should use to authenticate with the ClusterServiceBroker.",
@@ -387,7 +561,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerAuthInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerAuthInfo", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimits", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceBrokerStatus": {
 			Schema: spec.Schema{
@@ -563,6 +737,13 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Format:      "",
 							},
 						},
+						"instancesRetrievable": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nInstancesRetrievable indicates whether fetching an instance via a GET on its endpoint is supported for all plans.",
+								Type:        []string{"boolean"},
+								Format:      "",
+							},
+						},
 						"planUpdatable": {
 							SchemaProps: spec.SchemaProps{
 								Description: "PlanUpdatable indicates whether instances provisioned from this ServiceClass may change ServicePlans after being provisioned.",
@@ -797,6 +978,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 							},
 						},
+						"maintenanceInfo": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated. The MaintenanceInfo feature gate needs to be enabled for this field to be populated.\n\nMaintenanceInfo describes the version of the software that the instances of this plan run, as advertised by the broker.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo"),
+							},
+						},
 						"clusterServiceBrokerName": {
 							SchemaProps: spec.SchemaProps{
 								Description: "ClusterServiceBrokerName is the name of the ClusterServiceBroker that offers this ClusterServicePlan.",
@@ -810,12 +997,18 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference"),
 							},
 						},
+						"migrationPolicy": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nMigrationPolicy, if set, makes the controller move the ServiceInstances of this plan to a replacement plan once the broker has removed this plan from its catalog. It is set by cluster operators, and kept when the plan is updated from the catalog. Requires the PlanMigration feature.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.PlanMigrationPolicy"),
+							},
+						},
 					},
 					Required: []string{"externalName", "externalID", "description", "free", "clusterServiceBrokerName", "clusterServiceClassRef"},
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.PlanMigrationPolicy", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServicePlanStatus": {
 			Schema: spec.Schema{
@@ -835,132 +1028,272 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
-		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceBrokerSpec": {
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceVisibilityPolicy": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Description: "CommonServiceBrokerSpec represents a description of a Broker.",
+					Description: "ClusterServiceVisibilityPolicy restricts the namespaces that a ClusterServiceClass, or one of its ClusterServicePlans, is visible in. A class or plan selected by policies is only visible in the namespaces that one of them selects; the others are visible in every namespace. The ServiceVisibilityPolicy admission plugin rejects the ServiceInstances of the classes and plans that are not visible in their namespace.",
 					Properties: map[string]spec.Schema{
-						"url": {
+						"kind": {
 							SchemaProps: spec.SchemaProps{
-								Description: "URL is the address used to communicate with the ServiceBroker.",
+								Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
 								Type:        []string{"string"},
 								Format:      "",
 							},
 						},
-						"insecureSkipTLSVerify": {
-							SchemaProps: spec.SchemaProps{
-								Description: "InsecureSkipTLSVerify disables TLS certificate verification when communicating with this Broker. This is strongly discouraged.  You should use the CABundle instead.",
-								Type:        []string{"boolean"},
-								Format:      "",
-							},
-						},
-						"caBundle": {
-							SchemaProps: spec.SchemaProps{
-								Description: "CABundle is a PEM encoded CA bundle which will be used to validate a Broker's serving certificate.",
-								Type:        []string{"string"},
-								Format:      "byte",
-							},
-						},
-						"relistBehavior": {
+						"apiVersion": {
 							SchemaProps: spec.SchemaProps{
-								Description: "RelistBehavior specifies the type of relist behavior the catalog should exhibit when relisting ServiceClasses available from a broker.",
+								Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
 								Type:        []string{"string"},
 								Format:      "",
 							},
 						},
-						"relistDuration": {
-							SchemaProps: spec.SchemaProps{
-								Description: "RelistDuration is the frequency by which a controller will relist the broker when the RelistBehavior is set to ServiceBrokerRelistBehaviorDuration. Users are cautioned against configuring low values for the RelistDuration, as this can easily overload the controller manager in an environment with many brokers. The actual interval is intrinsically governed by the configured resync interval of the controller, which acts as a minimum bound. For example, with a resync interval of 5m and a RelistDuration of 2m, relists will occur at the resync interval of 5m.",
-								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
-							},
-						},
-						"relistRequests": {
+						"metadata": {
 							SchemaProps: spec.SchemaProps{
-								Description: "RelistRequests is a strictly increasing, non-negative integer counter that can be manually incremented by a user to manually trigger a relist.",
-								Type:        []string{"integer"},
-								Format:      "int64",
+								Description: "Non-namespaced.  The name of this resource in etcd is in ObjectMeta.Name. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
 							},
 						},
-						"catalogRestrictions": {
+						"spec": {
 							SchemaProps: spec.SchemaProps{
-								Description: "CatalogRestrictions is a set of restrictions on which of a broker's services and plans have resources created for them.",
-								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
+								Description: "Spec defines the class or plan whose visibility is restricted, and the namespaces it is visible in.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceVisibilityPolicySpec"),
 							},
 						},
 					},
-					Required: []string{"url"},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						"x-kubernetes-print-columns": "custom-columns=NAME:.metadata.name,CLASS:.spec.clusterServiceClassExternalName,PLAN:.spec.clusterServicePlanExternalName",
+					},
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceVisibilityPolicySpec", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 		},
-		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceBrokerStatus": {
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceVisibilityPolicyList": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Description: "CommonServiceBrokerStatus represents the current status of a Broker.",
+					Description: "ClusterServiceVisibilityPolicyList is a list of ClusterServiceVisibilityPolicies.",
 					Properties: map[string]spec.Schema{
-						"conditions": {
+						"kind": {
 							SchemaProps: spec.SchemaProps{
-								Type: []string{"array"},
-								Items: &spec.SchemaOrArray{
-									Schema: &spec.Schema{
-										SchemaProps: spec.SchemaProps{
-											Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition"),
-										},
-									},
-								},
+								Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+								Type:        []string{"string"},
+								Format:      "",
 							},
 						},
-						"reconciledGeneration": {
+						"apiVersion": {
 							SchemaProps: spec.SchemaProps{
-								Description: "ReconciledGeneration is the 'Generation' of the ClusterServiceBrokerSpec that was last processed by the controller. The reconciled generation is updated even if the controller failed to process the spec.",
-								Type:        []string{"integer"},
-								Format:      "int64",
+								Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+								Type:        []string{"string"},
+								Format:      "",
 							},
 						},
-						"operationStartTime": {
+						"metadata": {
 							SchemaProps: spec.SchemaProps{
-								Description: "OperationStartTime is the time at which the current operation began.",
-								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+								Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
 							},
 						},
-						"lastCatalogRetrievalTime": {
+						"items": {
 							SchemaProps: spec.SchemaProps{
-								Description: "LastCatalogRetrievalTime is the time the Catalog was last fetched from the Service Broker",
-								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+								Type: []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceVisibilityPolicy"),
+										},
+									},
+								},
 							},
 						},
 					},
-					Required: []string{"conditions", "reconciledGeneration"},
+					Required: []string{"items"},
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceVisibilityPolicy", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 		},
-		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceClassSpec": {
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterServiceVisibilityPolicySpec": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Description: "CommonServiceClassSpec represents details about a ServiceClass",
+					Description: "ClusterServiceVisibilityPolicySpec represents the namespaces a class or plan is visible in.",
 					Properties: map[string]spec.Schema{
-						"externalName": {
+						"clusterServiceClassExternalName": {
 							SchemaProps: spec.SchemaProps{
-								Description: "ExternalName is the name of this object that the Service Broker exposed this Service Class as. Mutable.",
+								Description: "ClusterServiceClassExternalName is the external name of the ClusterServiceClass whose visibility is restricted.",
 								Type:        []string{"string"},
 								Format:      "",
 							},
 						},
-						"externalID": {
+						"clusterServicePlanExternalName": {
 							SchemaProps: spec.SchemaProps{
-								Description: "ExternalID is the identity of this object for use with the OSB API.\n\nImmutable.",
+								Description: "ClusterServicePlanExternalName is the external name of the ClusterServicePlan whose visibility is restricted. When it is empty, the visibility of the class itself, and so of all its plans, is restricted.",
 								Type:        []string{"string"},
 								Format:      "",
 							},
 						},
-						"description": {
+						"namespaceSelector": {
 							SchemaProps: spec.SchemaProps{
-								Description: "Description is a short description of this ServiceClass.",
-								Type:        []string{"string"},
-								Format:      "",
+								Description: "NamespaceSelector selects the namespaces, by their labels, that the class or plan is visible in. An empty selector selects every namespace.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+							},
+						},
+					},
+					Required: []string{"clusterServiceClassExternalName"},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterTLSAuthConfig": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ClusterTLSAuthConfig provides config for the client certificate authentication of cluster scoped brokers.",
+					Properties: map[string]spec.Schema{
+						"secretRef": {
+							SchemaProps: spec.SchemaProps{
+								Description: "SecretRef is a reference to a Secret containing information the catalog should use to authenticate to this ServiceBroker.\n\nRequired fields: - Secret.Data[\"tls.crt\"] - PEM encoded client certificate - Secret.Data[\"tls.key\"] - PEM encoded private key of the certificate",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"),
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceBrokerSpec": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "CommonServiceBrokerSpec represents a description of a Broker.",
+					Properties: map[string]spec.Schema{
+						"url": {
+							SchemaProps: spec.SchemaProps{
+								Description: "URL is the address used to communicate with the ServiceBroker.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"insecureSkipTLSVerify": {
+							SchemaProps: spec.SchemaProps{
+								Description: "InsecureSkipTLSVerify disables TLS certificate verification when communicating with this Broker. This is strongly discouraged.  You should use the CABundle instead.",
+								Type:        []string{"boolean"},
+								Format:      "",
+							},
+						},
+						"caBundle": {
+							SchemaProps: spec.SchemaProps{
+								Description: "CABundle is a PEM encoded CA bundle which will be used to validate a Broker's serving certificate.",
+								Type:        []string{"string"},
+								Format:      "byte",
+							},
+						},
+						"relistBehavior": {
+							SchemaProps: spec.SchemaProps{
+								Description: "RelistBehavior specifies the type of relist behavior the catalog should exhibit when relisting ServiceClasses available from a broker.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"relistDuration": {
+							SchemaProps: spec.SchemaProps{
+								Description: "RelistDuration is the frequency by which a controller will relist the broker when the RelistBehavior is set to ServiceBrokerRelistBehaviorDuration. Users are cautioned against configuring low values for the RelistDuration, as this can easily overload the controller manager in an environment with many brokers. The actual interval is intrinsically governed by the configured resync interval of the controller, which acts as a minimum bound. For example, with a resync interval of 5m and a RelistDuration of 2m, relists will occur at the resync interval of 5m.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+							},
+						},
+						"relistRequests": {
+							SchemaProps: spec.SchemaProps{
+								Description: "RelistRequests is a strictly increasing, non-negative integer counter that can be manually incremented by a user to manually trigger a relist.",
+								Type:        []string{"integer"},
+								Format:      "int64",
+							},
+						},
+						"catalogRestrictions": {
+							SchemaProps: spec.SchemaProps{
+								Description: "CatalogRestrictions is a set of restrictions on which of a broker's services and plans have resources created for them.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
+							},
+						},
+						"rateLimits": {
+							SchemaProps: spec.SchemaProps{
+								Description: "RateLimits limits the requests that the controller sends to the broker. The limits that are not set default to the ones the controller manager is configured with.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimits"),
+							},
+						},
+					},
+					Required: []string{"url"},
+				},
+			},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimits", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceBrokerStatus": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "CommonServiceBrokerStatus represents the current status of a Broker.",
+					Properties: map[string]spec.Schema{
+						"conditions": {
+							SchemaProps: spec.SchemaProps{
+								Type: []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition"),
+										},
+									},
+								},
+							},
+						},
+						"reconciledGeneration": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ReconciledGeneration is the 'Generation' of the ClusterServiceBrokerSpec that was last processed by the controller. The reconciled generation is updated even if the controller failed to process the spec.",
+								Type:        []string{"integer"},
+								Format:      "int64",
+							},
+						},
+						"operationStartTime": {
+							SchemaProps: spec.SchemaProps{
+								Description: "OperationStartTime is the time at which the current operation began.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
+						"lastCatalogRetrievalTime": {
+							SchemaProps: spec.SchemaProps{
+								Description: "LastCatalogRetrievalTime is the time the Catalog was last fetched from the Service Broker",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
+					},
+					Required: []string{"conditions", "reconciledGeneration"},
+				},
+			},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServiceClassSpec": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "CommonServiceClassSpec represents details about a ServiceClass",
+					Properties: map[string]spec.Schema{
+						"externalName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ExternalName is the name of this object that the Service Broker exposed this Service Class as. Mutable.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"externalID": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ExternalID is the identity of this object for use with the OSB API.\n\nImmutable.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"description": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Description is a short description of this ServiceClass.",
+								Type:        []string{"string"},
+								Format:      "",
 							},
 						},
 						"bindable": {
@@ -977,6 +1310,13 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Format:      "",
 							},
 						},
+						"instancesRetrievable": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nInstancesRetrievable indicates whether fetching an instance via a GET on its endpoint is supported for all plans.",
+								Type:        []string{"boolean"},
+								Format:      "",
+							},
+						},
 						"planUpdatable": {
 							SchemaProps: spec.SchemaProps{
 								Description: "PlanUpdatable indicates whether instances provisioned from this ServiceClass may change ServicePlans after being provisioned.",
@@ -1113,12 +1453,18 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 							},
 						},
+						"maintenanceInfo": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated. The MaintenanceInfo feature gate needs to be enabled for this field to be populated.\n\nMaintenanceInfo describes the version of the software that the instances of this plan run, as advertised by the broker.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo"),
+							},
+						},
 					},
 					Required: []string{"externalName", "externalID", "description", "free"},
 				},
 			},
 			Dependencies: []string{
-				"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CommonServicePlanStatus": {
 			Schema: spec.Schema{
@@ -1138,6 +1484,31 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ConfigMapTemplate": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ConfigMapTemplate describes the ConfigMap that the controller creates, in the namespace and under the name of the ServiceBinding, to expose a non-sensitive subset of the credentials. For example, given the following credentials:\n    {\"host\": \"db.example.com\", \"port\": 5432, \"password\": \"secret\"}\nand the following ConfigMapTemplate:\n    {\"keys\": [\"host\", \"port\"]}\nthe ConfigMap will hold the following entries:\n    \"host\": \"db.example.com\"\n    \"port\": \"5432\"\nKeys that are not present in the credentials are ignored.",
+					Properties: map[string]spec.Schema{
+						"keys": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Keys are the keys of the credentials, after the SecretTransforms have been applied, that are copied into the ConfigMap.",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Type:   []string{"string"},
+											Format: "",
+										},
+									},
+								},
+							},
+						},
+					},
+					Required: []string{"keys"},
+				},
+			},
+			Dependencies: []string{},
+		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -1155,6 +1526,70 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "MaintenanceInfo describes the version of the software that the instances of a plan run.",
+					Properties: map[string]spec.Schema{
+						"version": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Version is the semantic version of the maintenance information.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"description": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Description describes the changes of this version.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"version"},
+				},
+			},
+			Dependencies: []string{},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.OAuth2AuthConfig": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "OAuth2AuthConfig provides config for the OAuth2 client credentials authentication of namespaced brokers.",
+					Properties: map[string]spec.Schema{
+						"tokenURL": {
+							SchemaProps: spec.SchemaProps{
+								Description: "TokenURL is the URL of the token endpoint of the authorization server.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"secretRef": {
+							SchemaProps: spec.SchemaProps{
+								Description: "SecretRef is a reference to a Secret containing information the catalog should use to obtain access tokens for this ServiceBroker.\n\nRequired fields: - Secret.Data[\"clientID\"] - client ID used to obtain access tokens - Secret.Data[\"clientSecret\"] - client secret used to obtain access tokens",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"),
+							},
+						},
+						"scopes": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Scopes are the scopes to request for the access tokens.",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Type:   []string{"string"},
+											Format: "",
+										},
+									},
+								},
+							},
+						},
+					},
+					Required: []string{"tokenURL"},
+				},
+			},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"},
+		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ObjectReference": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -1190,16 +1625,53 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference"),
 							},
 						},
+						"serviceBindingSecretKeyRef": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nThe key of the Secret of a ServiceBinding to select from. The parameters are only resolved once the ServiceBinding is Ready.\n\nRequires the CrossInstanceReferences feature.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingSecretKeyReference"),
+							},
+						},
+						"serviceInstanceFieldRef": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nThe field of a ServiceInstance to select from. The parameters are only resolved once the ServiceInstance is Ready.\n\nRequires the CrossInstanceReferences feature.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceFieldReference"),
+							},
+						},
 					},
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretKeyReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingSecretKeyReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceFieldReference"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.PlanMigrationPolicy": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "PlanMigrationPolicy describes how the ServiceInstances of a plan that has been removed from the catalog of its broker are moved to another plan of the same class. Each instance is moved by updating its plan, which the controller then sends to the broker as an update of the instance.",
+					Properties: map[string]spec.Schema{
+						"replacementPlanExternalName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ReplacementPlanExternalName is the external name of the plan of the same class that the instances are moved to.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"maxInProgress": {
+							SchemaProps: spec.SchemaProps{
+								Description: "MaxInProgress is the maximum number of instances that are being moved at the same time. An instance is being moved from the time its plan is updated until the broker has accepted the new plan. Defaults to 1.",
+								Type:        []string{"integer"},
+								Format:      "int32",
+							},
+						},
+					},
+					Required: []string{"replacementPlanExternalName"},
+				},
+			},
+			Dependencies: []string{},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.PlanReference": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Description: "PlanReference defines the user specification for the desired ServicePlan and ServiceClass. Because there are multiple ways to specify the desired Class/Plan, this structure specifies the allowed ways to specify the intent.\n\nCurrently supported ways:\n - ClusterServiceClassExternalName and ClusterServicePlanExternalName\n - ClusterServiceClassExternalID and ClusterServicePlanExternalID\n - ClusterServiceClassName and ClusterServicePlanName\n\nFor any of these ways, if a ClusterServiceClass only has one plan then the corresponding service plan field is optional.",
+					Description: "PlanReference defines the user specification for the desired ServicePlan and ServiceClass. Because there are multiple ways to specify the desired Class/Plan, this structure specifies the allowed ways to specify the intent.\n\nCurrently supported ways:\n - ClusterServiceClassExternalName and ClusterServicePlanExternalName\n - ClusterServiceClassExternalID and ClusterServicePlanExternalID\n - ClusterServiceClassName and ClusterServicePlanName\n - ServiceClassExternalName and ServicePlanExternalName\n - ServiceClassExternalID and ServicePlanExternalID\n - ServiceClassName and ServicePlanName\n\nFor any of these ways, if a ClusterServiceClass only has one plan then the corresponding service plan field is optional.\n\nThe cluster-scoped fields and the namespaced fields are mutually exclusive.",
 					Properties: map[string]spec.Schema{
 						"clusterServiceClassExternalName": {
 							SchemaProps: spec.SchemaProps{
@@ -1243,11 +1715,85 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Format:      "",
 							},
 						},
-					},
+						"serviceClassExternalName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServiceClassExternalName is the human-readable name of the service as reported by the broker. Note that if the broker changes the name of the ServiceClass, it will not be reflected here, and to see the current name of the ServiceClass, you should follow the ServiceClassRef below.\n\nImmutable.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"servicePlanExternalName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServicePlanExternalName is the human-readable name of the plan as reported by the broker. Note that if the broker changes the name of the ServicePlan, it will not be reflected here, and to see the current name of the ServicePlan, you should follow the ServicePlanRef below.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"serviceClassExternalID": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServiceClassExternalID is the broker's external id for the class.\n\nImmutable.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"servicePlanExternalID": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServicePlanExternalID is the broker's external id for the plan.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"serviceClassName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServiceClassName is the kubernetes name of the ServiceClass.\n\nImmutable.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"servicePlanName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServicePlanName is kubernetes name of the ServicePlan.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
 				},
 			},
 			Dependencies: []string{},
 		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.PodPresetTemplate": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "PodPresetTemplate describes the PodPreset that the controller creates, in the namespace and under the name of the ServiceBinding, to inject the credentials Secret into pods. At least one of EnvPrefix and MountPath must be set. For example, given the following PodPresetTemplate:\n    {\"selector\": {\"matchLabels\": {\"app\": \"web\"}}, \"envPrefix\": \"DB_\"}\nevery key of the credentials Secret is exposed to the pods labeled \"app=web\" as an environment variable prefixed with \"DB_\".",
+					Properties: map[string]spec.Schema{
+						"selector": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Selector is a label query over the pods the PodPreset applies to.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"),
+							},
+						},
+						"envPrefix": {
+							SchemaProps: spec.SchemaProps{
+								Description: "EnvPrefix, if set, injects every key of the Secret as an environment variable whose name is the key prefixed with EnvPrefix. The prefix may be empty.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"mountPath": {
+							SchemaProps: spec.SchemaProps{
+								Description: "MountPath, if set, mounts the Secret as a volume at the given path.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"selector"},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector"},
+		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -1345,11 +1891,17 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform"),
 							},
 						},
+						"templateKey": {
+							SchemaProps: spec.SchemaProps{
+								Description: "TemplateKey represents a transform that adds a key whose value is built from a template evaluated against the credentials",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.TemplateKeyTransform"),
+							},
+						},
 					},
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeyTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.AddKeysFromTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RemoveKeyTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.RenameKeyTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.TemplateKeyTransform"},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBinding": {
 			Schema: spec.Schema{
@@ -1518,6 +2070,38 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			Dependencies: []string{
 				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingSecretKeyReference": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceBindingSecretKeyReference references a key of the Secret of a ServiceBinding.",
+					Properties: map[string]spec.Schema{
+						"name": {
+							SchemaProps: spec.SchemaProps{
+								Description: "The name of the ServiceBinding in the namespace of the resource.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"key": {
+							SchemaProps: spec.SchemaProps{
+								Description: "The key of the Secret of the ServiceBinding to select from.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"parameter": {
+							SchemaProps: spec.SchemaProps{
+								Description: "The name of the parameter to set to the value of the key. If empty, the value must be a JSON object, whose properties are added to the parameters.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"name", "key"},
+				},
+			},
+			Dependencies: []string{},
+		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingSpec": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -1568,6 +2152,39 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								},
 							},
 						},
+						"podPresetTemplate": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nPodPresetTemplate, if set, makes the controller create and own a PodPreset that injects the Secret of the ServiceBinding into the pods matching its selector. The PodPreset is kept in sync with the ServiceBinding and removed when the ServiceBinding is unbound. Requires the PodPreset feature.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.PodPresetTemplate"),
+							},
+						},
+						"secretCopyNamespaces": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nSecretCopyNamespaces is a list of namespaces, other than the namespace of the ServiceBinding, into which the controller copies the Secret of the ServiceBinding under the same name. The user creating or updating the ServiceBinding must be allowed to create Secrets in each of these namespaces. The copies are kept in sync with the Secret, removed when their namespace is removed from this list, and removed when the ServiceBinding is unbound. Requires the BindingTargets feature.",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Type:   []string{"string"},
+											Format: "",
+										},
+									},
+								},
+							},
+						},
+						"configMapTemplate": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nConfigMapTemplate, if set, makes the controller create and own a ConfigMap, in the namespace and under the name of the ServiceBinding, that holds the subset of the credentials listed in the template. Only credentials that are not sensitive should be exposed this way. The ConfigMap is removed when the template is removed or the ServiceBinding is unbound. Requires the BindingTargets feature.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ConfigMapTemplate"),
+							},
+						},
+						"rotationRequests": {
+							SchemaProps: spec.SchemaProps{
+								Description: "RotationRequests is a strictly increasing, non-negative integer counter that can be manually incremented by a user to rotate the credentials of the ServiceBinding. Each increment makes the API server assign a new ExternalID, and the controller binds it, replaces the contents of the Secret and unbinds the previous credentials after a grace period.",
+								Type:        []string{"integer"},
+								Format:      "int64",
+							},
+						},
 						"externalID": {
 							SchemaProps: spec.SchemaProps{
 								Description: "ExternalID is the identity of this object for use with the OSB API.\n\nImmutable.",
@@ -1586,7 +2203,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ConfigMapTemplate", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.PodPresetTemplate", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.SecretTransform", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBindingStatus": {
 			Schema: spec.Schema{
@@ -1665,6 +2282,46 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Format:      "",
 							},
 						},
+						"currentExternalID": {
+							SchemaProps: spec.SchemaProps{
+								Description: "CurrentExternalID is the ExternalID of the binding whose credentials are currently stored in the Secret.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"previousExternalID": {
+							SchemaProps: spec.SchemaProps{
+								Description: "PreviousExternalID is the ExternalID of the binding whose credentials were replaced by the last rotation. It is cleared once those credentials have been unbound.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"previousCredentialsUnbindTime": {
+							SchemaProps: spec.SchemaProps{
+								Description: "PreviousCredentialsUnbindTime is the time after which the credentials of PreviousExternalID are unbound.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
+						"previousCredentialsUnbindOperation": {
+							SchemaProps: spec.SchemaProps{
+								Description: "PreviousCredentialsUnbindOperation is set while the broker unbinds the credentials of PreviousExternalID asynchronously, to the operation key it returned, if any.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"lastCredentialsSyncTime": {
+							SchemaProps: spec.SchemaProps{
+								Description: "LastCredentialsSyncTime is the last time the controller fetched the credentials of the binding from the broker to check its Secret for drift. The check only runs for bindings whose class is bindingRetrievable, when it is enabled on the controller-manager.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
+						"deliveredSecretName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "DeliveredSecretName is the name of the Secret the credentials of the binding were last injected in. The Secret is deleted once the credentials are injected under another SecretName.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
 					},
 					Required: []string{"conditions", "asyncOpInProgress", "reconciledGeneration", "orphanMitigationInProgress", "unbindStatus"},
 				},
@@ -1737,11 +2394,23 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig"),
 							},
 						},
+						"tls": {
+							SchemaProps: spec.SchemaProps{
+								Description: "TLSAuthConfig provides configuration to authenticate with a client certificate. The certificate and key are referenced from the 'tls.crt' and 'tls.key' fields of the given kubernetes.io/tls secret.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.TLSAuthConfig"),
+							},
+						},
+						"oauth2": {
+							SchemaProps: spec.SchemaProps{
+								Description: "OAuth2AuthConfig provides configuration to send an access token, obtained from an OAuth2 authorization server with the client credentials grant, as a bearer token.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.OAuth2AuthConfig"),
+							},
+						},
 					},
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BasicAuthConfig", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BasicAuthConfig", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.BearerTokenAuthConfig", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.OAuth2AuthConfig", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.TLSAuthConfig"},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerCondition": {
 			Schema: spec.Schema{
@@ -1832,6 +2501,44 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			Dependencies: []string{
 				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBroker", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimits": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceBrokerRateLimits limits the requests that the controller sends to a broker. The requests polling the last operation of instances and bindings have their own budget, so that polling the operations in progress does not hold back the requests that start new ones, and vice versa. A limit of zero means that the requests are not limited.",
+					Properties: map[string]spec.Schema{
+						"maxInFlightRequests": {
+							SchemaProps: spec.SchemaProps{
+								Description: "MaxInFlightRequests is the maximum number of requests, other than last operation polls, sent to the broker at the same time.",
+								Type:        []string{"integer"},
+								Format:      "int32",
+							},
+						},
+						"requestsPerSecond": {
+							SchemaProps: spec.SchemaProps{
+								Description: "RequestsPerSecond is the maximum rate of requests, other than last operation polls, sent to the broker.",
+								Type:        []string{"integer"},
+								Format:      "int32",
+							},
+						},
+						"maxInFlightPollRequests": {
+							SchemaProps: spec.SchemaProps{
+								Description: "MaxInFlightPollRequests is the maximum number of last operation polls sent to the broker at the same time.",
+								Type:        []string{"integer"},
+								Format:      "int32",
+							},
+						},
+						"pollRequestsPerSecond": {
+							SchemaProps: spec.SchemaProps{
+								Description: "PollRequestsPerSecond is the maximum rate of last operation polls sent to the broker.",
+								Type:        []string{"integer"},
+								Format:      "int32",
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{},
+		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerSpec": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -1884,6 +2591,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions"),
 							},
 						},
+						"rateLimits": {
+							SchemaProps: spec.SchemaProps{
+								Description: "RateLimits limits the requests that the controller sends to the broker. The limits that are not set default to the ones the controller manager is configured with.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimits"),
+							},
+						},
 						"authInfo": {
 							SchemaProps: spec.SchemaProps{
 								Description: "AuthInfo contains the data that the service catalog should use to authenticate with the ServiceBroker.",
@@ -1895,7 +2608,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerAuthInfo", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.CatalogRestrictions", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerAuthInfo", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerRateLimits", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceBrokerStatus": {
 			Schema: spec.Schema{
@@ -2066,6 +2779,13 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Format:      "",
 							},
 						},
+						"instancesRetrievable": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nInstancesRetrievable indicates whether fetching an instance via a GET on its endpoint is supported for all plans.",
+								Type:        []string{"boolean"},
+								Format:      "",
+							},
+						},
 						"planUpdatable": {
 							SchemaProps: spec.SchemaProps{
 								Description: "PlanUpdatable indicates whether instances provisioned from this ServiceClass may change ServicePlans after being provisioned.",
@@ -2093,56 +2813,377 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								},
 							},
 						},
-						"requires": {
+						"requires": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nRequires exposes a list of Cloud Foundry-specific 'permissions' that must be granted to an instance of this service within Cloud Foundry.  These 'permissions' have no meaning within Kubernetes and an ServiceInstance provisioned from this ServiceClass will not work correctly.",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Type:   []string{"string"},
+											Format: "",
+										},
+									},
+								},
+							},
+						},
+						"serviceBrokerName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServiceBrokerName is the reference to the Broker that provides this ServiceClass.\n\nImmutable.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"externalName", "externalID", "description", "bindable", "bindingRetrievable", "planUpdatable", "serviceBrokerName"},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassStatus": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceClassStatus represents status information about a ServiceClass.",
+					Properties: map[string]spec.Schema{
+						"removedFromBrokerCatalog": {
+							SchemaProps: spec.SchemaProps{
+								Description: "RemovedFromBrokerCatalog indicates that the broker removed the service from its catalog.",
+								Type:        []string{"boolean"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"removedFromBrokerCatalog"},
+				},
+			},
+			Dependencies: []string{},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstance": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceInstance represents a provisioned instance of a ServiceClass. Currently, the spec field cannot be changed once a ServiceInstance is created.  Spec changes submitted by users will be ignored.\n\nIn the future, this will be allowed and will represent the intention that the ServiceInstance should have the plan and/or parameters updated at the ClusterServiceBroker.",
+					Properties: map[string]spec.Schema{
+						"kind": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"apiVersion": {
+							SchemaProps: spec.SchemaProps{
+								Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"metadata": {
+							SchemaProps: spec.SchemaProps{
+								Description: "The name of this resource in etcd is in ObjectMeta.Name. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"),
+							},
+						},
+						"spec": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Spec defines the behavior of the service instance.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceSpec"),
+							},
+						},
+						"status": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Status represents the current status of a service instance.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceStatus"),
+							},
+						},
+					},
+				},
+				VendorExtensible: spec.VendorExtensible{
+					Extensions: spec.Extensions{
+						"x-kubernetes-print-columns": "custom-columns=NAME:.metadata.name,CLASS:.spec.clusterServiceClassExternalName,PLAN:.spec.clusterServicePlanExternalName",
+					},
+				},
+			},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceSpec", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceAdoption": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceInstanceAdoption describes how a ServiceInstance adopts the instance that already exists at the broker.",
+					Properties: map[string]spec.Schema{
+						"verify": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Verify makes the controller fetch the instance from the broker before adopting it, to check that it exists and runs the plan of the ServiceInstance. The broker must support fetching instances. Defaults to true.",
+								Type:        []string{"boolean"},
+								Format:      "",
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceBrokerProperties": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceInstanceBrokerProperties is the state of a ServiceInstance as reported by its broker.",
+					Properties: map[string]spec.Schema{
+						"parameters": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Parameters is a blob of the parameters and their values that the broker reports for this ServiceInstance. If a parameter was sourced from a secret, its value will be \"<redacted>\" in this blob.",
+								Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+							},
+						},
+						"dashboardURL": {
+							SchemaProps: spec.SchemaProps{
+								Description: "DashboardURL is the URL of a web-based management user interface for the service instance, as reported by the broker.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/runtime.RawExtension"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceInstanceCondition contains condition information about an Instance.",
+					Properties: map[string]spec.Schema{
+						"type": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Type of the condition, currently ('Ready').",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"status": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Status of the condition, one of ('True', 'False', 'Unknown').",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"lastTransitionTime": {
+							SchemaProps: spec.SchemaProps{
+								Description: "LastTransitionTime is the timestamp corresponding to the last status change of this condition.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
+						"reason": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Reason is a brief machine readable explanation for the condition's last transition.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"message": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Message is a human readable description of the details of the last transition, complementing reason.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"type", "status", "lastTransitionTime", "reason", "message"},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceFieldReference": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceInstanceFieldReference references a field of a ServiceInstance.",
+					Properties: map[string]spec.Schema{
+						"name": {
+							SchemaProps: spec.SchemaProps{
+								Description: "The name of the ServiceInstance in the namespace of the resource.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"fieldPath": {
+							SchemaProps: spec.SchemaProps{
+								Description: "The path of the field to select, one of \"spec.externalID\", \"status.dashboardURL\", \"status.externalProperties.parameters.NAME\" or \"status.brokerProperties.parameters.NAME\". Parameters that were sourced from a Secret cannot be selected.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"parameter": {
+							SchemaProps: spec.SchemaProps{
+								Description: "The name of the parameter to set to the value of the field.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"name", "fieldPath", "parameter"},
+				},
+			},
+			Dependencies: []string{},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceList": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceInstanceList is a list of instances.",
+					Properties: map[string]spec.Schema{
+						"kind": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#types-kinds",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"apiVersion": {
+							SchemaProps: spec.SchemaProps{
+								Description: "APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#resources",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"metadata": {
+							SchemaProps: spec.SchemaProps{
+								Ref: ref("k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"),
+							},
+						},
+						"items": {
+							SchemaProps: spec.SchemaProps{
+								Type: []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstance"),
+										},
+									},
+								},
+							},
+						},
+					},
+					Required: []string{"items"},
+				},
+			},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstance", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePlanMigration": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceInstancePlanMigration describes the move of a ServiceInstance from a plan that was removed from the catalog of its broker to its replacement.",
+					Properties: map[string]spec.Schema{
+						"fromClusterServicePlanName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "FromClusterServicePlanName is the Kubernetes name of the ClusterServicePlan the instance is moved from.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"toClusterServicePlanName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ToClusterServicePlanName is the Kubernetes name of the ClusterServicePlan the instance is moved to.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"startTime": {
+							SchemaProps: spec.SchemaProps{
+								Description: "StartTime is the time the plan of the instance was updated.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
+						"completionTime": {
+							SchemaProps: spec.SchemaProps{
+								Description: "CompletionTime is the time the broker accepted or rejected the new plan. It is unset while the instance is being moved.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
+						"failureMessage": {
+							SchemaProps: spec.SchemaProps{
+								Description: "FailureMessage is set when the broker rejected the new plan, to the reason it gave.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"fromClusterServicePlanName", "toClusterServicePlanName", "startTime"},
+				},
+			},
+			Dependencies: []string{
+				"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceInstancePropertiesState is the state of a ServiceInstance that the ClusterServiceBroker knows about.",
+					Properties: map[string]spec.Schema{
+						"clusterServicePlanExternalName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ClusterServicePlanExternalName is the name of the plan that the broker knows this ServiceInstance to be on. This is the human readable plan name from the OSB API.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"clusterServicePlanExternalID": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ClusterServicePlanExternalID is the external ID of the plan that the broker knows this ServiceInstance to be on.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"servicePlanExternalName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServicePlanExternalName is the name of the namespaced plan that the broker knows this ServiceInstance to be on. It is set instead of ClusterServicePlanExternalName when the instance uses a ServicePlan.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"servicePlanExternalID": {
 							SchemaProps: spec.SchemaProps{
-								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nRequires exposes a list of Cloud Foundry-specific 'permissions' that must be granted to an instance of this service within Cloud Foundry.  These 'permissions' have no meaning within Kubernetes and an ServiceInstance provisioned from this ServiceClass will not work correctly.",
-								Type:        []string{"array"},
-								Items: &spec.SchemaOrArray{
-									Schema: &spec.Schema{
-										SchemaProps: spec.SchemaProps{
-											Type:   []string{"string"},
-											Format: "",
-										},
-									},
-								},
+								Description: "ServicePlanExternalID is the external ID of the namespaced plan that the broker knows this ServiceInstance to be on.",
+								Type:        []string{"string"},
+								Format:      "",
 							},
 						},
-						"serviceBrokerName": {
+						"parameters": {
 							SchemaProps: spec.SchemaProps{
-								Description: "ServiceBrokerName is the reference to the Broker that provides this ServiceClass.\n\nImmutable.",
+								Description: "Parameters is a blob of the parameters and their values that the broker knows about for this ServiceInstance.  If a parameter was sourced from a secret, its value will be \"<redacted>\" in this blob.",
+								Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+							},
+						},
+						"parameterChecksum": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ParametersChecksum is the checksum of the parameters that were sent.",
 								Type:        []string{"string"},
 								Format:      "",
 							},
 						},
-					},
-					Required: []string{"externalName", "externalID", "description", "bindable", "bindingRetrievable", "planUpdatable", "serviceBrokerName"},
-				},
-			},
-			Dependencies: []string{
-				"k8s.io/apimachinery/pkg/runtime.RawExtension"},
-		},
-		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceClassStatus": {
-			Schema: spec.Schema{
-				SchemaProps: spec.SchemaProps{
-					Description: "ServiceClassStatus represents status information about a ServiceClass.",
-					Properties: map[string]spec.Schema{
-						"removedFromBrokerCatalog": {
+						"userInfo": {
 							SchemaProps: spec.SchemaProps{
-								Description: "RemovedFromBrokerCatalog indicates that the broker removed the service from its catalog.",
-								Type:        []string{"boolean"},
+								Description: "UserInfo is information about the user that made the request.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo"),
+							},
+						},
+						"maintenanceInfoVersion": {
+							SchemaProps: spec.SchemaProps{
+								Description: "MaintenanceInfoVersion is the version of the maintenance information of the plan that was sent to the broker.",
+								Type:        []string{"string"},
 								Format:      "",
 							},
 						},
 					},
-					Required: []string{"removedFromBrokerCatalog"},
+					Required: []string{"clusterServicePlanExternalName", "clusterServicePlanExternalID"},
 				},
 			},
-			Dependencies: []string{},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 		},
-		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstance": {
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuota": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Description: "ServiceInstance represents a provisioned instance of a ServiceClass. Currently, the spec field cannot be changed once a ServiceInstance is created.  Spec changes submitted by users will be ignored.\n\nIn the future, this will be allowed and will represent the intention that the ServiceInstance should have the plan and/or parameters updated at the ClusterServiceBroker.",
+					Description: "ServiceInstanceQuota limits the number of ServiceInstances of a namespace. The limits are enforced when instances are created, or change plans, by the ServiceInstanceQuota admission plugin, which charges every instance it admits to the usage reported in the status of the quota.",
 					Properties: map[string]spec.Schema{
 						"kind": {
 							SchemaProps: spec.SchemaProps{
@@ -2166,77 +3207,77 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 						},
 						"spec": {
 							SchemaProps: spec.SchemaProps{
-								Description: "Spec defines the behavior of the service instance.",
-								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceSpec"),
+								Description: "Spec defines the limits of the quota.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaSpec"),
 							},
 						},
 						"status": {
 							SchemaProps: spec.SchemaProps{
-								Description: "Status represents the current status of a service instance.",
-								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceStatus"),
+								Description: "Status represents the number of instances counted against each limit.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaStatus"),
 							},
 						},
 					},
 				},
 				VendorExtensible: spec.VendorExtensible{
 					Extensions: spec.Extensions{
-						"x-kubernetes-print-columns": "custom-columns=NAME:.metadata.name,CLASS:.spec.clusterServiceClassExternalName,PLAN:.spec.clusterServicePlanExternalName",
+						"x-kubernetes-print-columns": "custom-columns=NAME:.metadata.name",
 					},
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceSpec", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaSpec", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaStatus", "k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta"},
 		},
-		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition": {
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaLimit": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Description: "ServiceInstanceCondition contains condition information about an Instance.",
+					Description: "ServiceInstanceQuotaLimit limits the number of instances selected by their class and plan. A limit without selectors applies to every instance of the namespace.",
 					Properties: map[string]spec.Schema{
-						"type": {
+						"name": {
 							SchemaProps: spec.SchemaProps{
-								Description: "Type of the condition, currently ('Ready').",
+								Description: "Name identifies the limit within the quota.",
 								Type:        []string{"string"},
 								Format:      "",
 							},
 						},
-						"status": {
+						"serviceClassExternalName": {
 							SchemaProps: spec.SchemaProps{
-								Description: "Status of the condition, one of ('True', 'False', 'Unknown').",
+								Description: "ServiceClassExternalName selects the instances of the ClusterServiceClasses and ServiceClasses with this external name.",
 								Type:        []string{"string"},
 								Format:      "",
 							},
 						},
-						"lastTransitionTime": {
+						"servicePlanExternalName": {
 							SchemaProps: spec.SchemaProps{
-								Description: "LastTransitionTime is the timestamp corresponding to the last status change of this condition.",
-								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+								Description: "ServicePlanExternalName selects the instances of the plans with this external name. It requires ServiceClassExternalName.",
+								Type:        []string{"string"},
+								Format:      "",
 							},
 						},
-						"reason": {
+						"free": {
 							SchemaProps: spec.SchemaProps{
-								Description: "Reason is a brief machine readable explanation for the condition's last transition.",
-								Type:        []string{"string"},
+								Description: "Free selects the instances of the plans whose Free field has this value.",
+								Type:        []string{"boolean"},
 								Format:      "",
 							},
 						},
-						"message": {
+						"maxInstances": {
 							SchemaProps: spec.SchemaProps{
-								Description: "Message is a human readable description of the details of the last transition, complementing reason.",
-								Type:        []string{"string"},
-								Format:      "",
+								Description: "MaxInstances is the maximum number of instances the limit selects.",
+								Type:        []string{"integer"},
+								Format:      "int64",
 							},
 						},
 					},
-					Required: []string{"type", "status", "lastTransitionTime", "reason", "message"},
+					Required: []string{"name", "maxInstances"},
 				},
 			},
-			Dependencies: []string{
-				"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+			Dependencies: []string{},
 		},
-		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceList": {
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaList": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Description: "ServiceInstanceList is a list of instances.",
+					Description: "ServiceInstanceQuotaList is a list of ServiceInstanceQuotas.",
 					Properties: map[string]spec.Schema{
 						"kind": {
 							SchemaProps: spec.SchemaProps{
@@ -2263,7 +3304,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Items: &spec.SchemaOrArray{
 									Schema: &spec.Schema{
 										SchemaProps: spec.SchemaProps{
-											Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstance"),
+											Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuota"),
 										},
 									},
 								},
@@ -2274,52 +3315,81 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstance", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuota", "k8s.io/apimachinery/pkg/apis/meta/v1.ListMeta"},
 		},
-		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState": {
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaSpec": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
-					Description: "ServiceInstancePropertiesState is the state of a ServiceInstance that the ClusterServiceBroker knows about.",
+					Description: "ServiceInstanceQuotaSpec represents the limits of a ServiceInstanceQuota.",
 					Properties: map[string]spec.Schema{
-						"clusterServicePlanExternalName": {
-							SchemaProps: spec.SchemaProps{
-								Description: "ClusterServicePlanExternalName is the name of the plan that the broker knows this ServiceInstance to be on. This is the human readable plan name from the OSB API.",
-								Type:        []string{"string"},
-								Format:      "",
-							},
-						},
-						"clusterServicePlanExternalID": {
+						"limits": {
 							SchemaProps: spec.SchemaProps{
-								Description: "ClusterServicePlanExternalID is the external ID of the plan that the broker knows this ServiceInstance to be on.",
-								Type:        []string{"string"},
-								Format:      "",
+								Description: "Limits are the limits on the number of instances of the namespace. An instance is only admitted when it stays within every limit that selects it.",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaLimit"),
+										},
+									},
+								},
 							},
 						},
-						"parameters": {
+					},
+					Required: []string{"limits"},
+				},
+			},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaLimit"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaStatus": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceInstanceQuotaStatus represents the usage of a ServiceInstanceQuota.",
+					Properties: map[string]spec.Schema{
+						"used": {
 							SchemaProps: spec.SchemaProps{
-								Description: "Parameters is a blob of the parameters and their values that the broker knows about for this ServiceInstance.  If a parameter was sourced from a secret, its value will be \"<redacted>\" in this blob.",
-								Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
+								Description: "Used is the number of instances each limit selects.",
+								Type:        []string{"array"},
+								Items: &spec.SchemaOrArray{
+									Schema: &spec.Schema{
+										SchemaProps: spec.SchemaProps{
+											Ref: ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaUsage"),
+										},
+									},
+								},
 							},
 						},
-						"parameterChecksum": {
+					},
+				},
+			},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaUsage"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceQuotaUsage": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "ServiceInstanceQuotaUsage is the number of instances a limit selects.",
+					Properties: map[string]spec.Schema{
+						"name": {
 							SchemaProps: spec.SchemaProps{
-								Description: "ParametersChecksum is the checksum of the parameters that were sent.",
+								Description: "Name is the name of the limit.",
 								Type:        []string{"string"},
 								Format:      "",
 							},
 						},
-						"userInfo": {
+						"instances": {
 							SchemaProps: spec.SchemaProps{
-								Description: "UserInfo is information about the user that made the request.",
-								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo"),
+								Description: "Instances is the number of instances the limit selects.",
+								Type:        []string{"integer"},
+								Format:      "int64",
 							},
 						},
 					},
-					Required: []string{"clusterServicePlanExternalName", "clusterServicePlanExternalID"},
+					Required: []string{"name", "instances"},
 				},
 			},
-			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+			Dependencies: []string{},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceSpec": {
 			Schema: spec.Schema{
@@ -2368,6 +3438,48 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Format:      "",
 							},
 						},
+						"serviceClassExternalName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServiceClassExternalName is the human-readable name of the service as reported by the broker. Note that if the broker changes the name of the ServiceClass, it will not be reflected here, and to see the current name of the ServiceClass, you should follow the ServiceClassRef below.\n\nImmutable.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"servicePlanExternalName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServicePlanExternalName is the human-readable name of the plan as reported by the broker. Note that if the broker changes the name of the ServicePlan, it will not be reflected here, and to see the current name of the ServicePlan, you should follow the ServicePlanRef below.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"serviceClassExternalID": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServiceClassExternalID is the broker's external id for the class.\n\nImmutable.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"servicePlanExternalID": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServicePlanExternalID is the broker's external id for the plan.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"serviceClassName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServiceClassName is the kubernetes name of the ServiceClass.\n\nImmutable.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"servicePlanName": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServicePlanName is kubernetes name of the ServicePlan.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
 						"clusterServiceClassRef": {
 							SchemaProps: spec.SchemaProps{
 								Description: "ClusterServiceClassRef is a reference to the ClusterServiceClass that the user selected. This is set by the controller based on ClusterServiceClassExternalName",
@@ -2380,6 +3492,18 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference"),
 							},
 						},
+						"serviceClassRef": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServiceClassRef is a reference to the ServiceClass that the user selected. This is set by the controller based on ServiceClassExternalName.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"),
+							},
+						},
+						"servicePlanRef": {
+							SchemaProps: spec.SchemaProps{
+								Description: "ServicePlanRef is a reference to the ServicePlan that the user selected. This is set by the controller based on ServicePlanExternalName.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"),
+							},
+						},
 						"parameters": {
 							SchemaProps: spec.SchemaProps{
 								Description: "Parameters is a set of the parameters to be passed to the underlying broker. The inline YAML/JSON payload to be translated into equivalent JSON object. If a top-level parameter name exists in multiples sources among `Parameters` and `ParametersFrom` fields, it is considered to be a user error in the specification.\n\nThe Parameters field is NOT secret or secured in any way and should NEVER be used to hold sensitive information. To set parameters that contain secret information, you should ALWAYS store that information in a Secret and use the ParametersFrom field.",
@@ -2419,11 +3543,38 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Format:      "int64",
 							},
 						},
+						"maintenanceInfoVersion": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nMaintenanceInfoVersion is the version of the maintenance information of the plan that the instance is upgraded to. When it differs from the version that the instance runs, the maintenance information of the plan is sent to the broker in an update of the instance. It must be the version currently advertised by the plan. Requires the MaintenanceInfo feature.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"deletionPolicy": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nDeletionPolicy is what happens to the instance at the broker when the ServiceInstance is deleted. It defaults to Deprovision when empty. Requires the DeletionPolicy feature.",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"deletionProtection": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nDeletionProtection rejects the deletion of the ServiceInstance while it is true. It also rejects the deletion of any collection of ServiceInstances that includes the namespace of the ServiceInstance, whatever the label and field selectors of the deletion. Requires the DeletionPolicy feature.",
+								Type:        []string{"boolean"},
+								Format:      "",
+							},
+						},
+						"adoption": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nAdoption makes the controller adopt the instance that already exists at the broker under ExternalID, instead of provisioning a new one. The ServiceInstance is then managed as if it had provisioned the instance. Immutable. Requires the InstanceAdoption feature.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceAdoption"),
+							},
+						},
 					},
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ClusterObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ParametersFromSource", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceAdoption", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceStatus": {
 			Schema: spec.Schema{
@@ -2524,12 +3675,30 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Format:      "",
 							},
 						},
+						"planMigration": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nPlanMigration describes the last move of the ServiceInstance off a plan that was removed from the catalog of its broker, according to the MigrationPolicy of that plan.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePlanMigration"),
+							},
+						},
+						"brokerProperties": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated.\n\nBrokerProperties is the state of the ServiceInstance as last fetched from the broker. It is only fetched for instances whose class is instancesRetrievable, when the check is enabled on the controller-manager.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceBrokerProperties"),
+							},
+						},
+						"lastBrokerSyncTime": {
+							SchemaProps: spec.SchemaProps{
+								Description: "LastBrokerSyncTime is the last time the controller recorded the ServiceInstance as fetched from the broker: on the first fetch, and on the fetches where the broker reported a change.",
+								Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+							},
+						},
 					},
 					Required: []string{"conditions", "asyncOpInProgress", "orphanMitigationInProgress", "reconciledGeneration", "observedGeneration", "provisionStatus", "deprovisionStatus"},
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceBrokerProperties", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstanceCondition", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePlanMigration", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServiceInstancePropertiesState", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlan": {
 			Schema: spec.Schema{
@@ -2692,6 +3861,12 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 								Ref:         ref("k8s.io/apimachinery/pkg/runtime.RawExtension"),
 							},
 						},
+						"maintenanceInfo": {
+							SchemaProps: spec.SchemaProps{
+								Description: "Currently, this field is ALPHA: it may change or disappear at any time and its data will not be migrated. The MaintenanceInfo feature gate needs to be enabled for this field to be populated.\n\nMaintenanceInfo describes the version of the software that the instances of this plan run, as advertised by the broker.",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo"),
+							},
+						},
 						"serviceBrokerName": {
 							SchemaProps: spec.SchemaProps{
 								Description: "ServiceBrokerName is the name of the ServiceBroker that offers this ServicePlan.",
@@ -2710,7 +3885,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 				},
 			},
 			Dependencies: []string{
-				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference", "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.MaintenanceInfo", "k8s.io/apimachinery/pkg/runtime.RawExtension"},
 		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.ServicePlanStatus": {
 			Schema: spec.Schema{
@@ -2730,6 +3905,48 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 			},
 			Dependencies: []string{},
 		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.TLSAuthConfig": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "TLSAuthConfig provides config for the client certificate authentication of namespaced brokers.",
+					Properties: map[string]spec.Schema{
+						"secretRef": {
+							SchemaProps: spec.SchemaProps{
+								Description: "SecretRef is a reference to a Secret containing information the catalog should use to authenticate to this ServiceBroker.\n\nRequired fields: - Secret.Data[\"tls.crt\"] - PEM encoded client certificate - Secret.Data[\"tls.key\"] - PEM encoded private key of the certificate",
+								Ref:         ref("github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"),
+							},
+						},
+					},
+				},
+			},
+			Dependencies: []string{
+				"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.LocalObjectReference"},
+		},
+		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.TemplateKeyTransform": {
+			Schema: spec.Schema{
+				SchemaProps: spec.SchemaProps{
+					Description: "TemplateKeyTransform specifies that Service Catalog should add an entry to the Secret associated with the ServiceBinding, whose value is the result of a template evaluated against the credentials. Templates use the Go text/template syntax, and each credentials entry is available as a field of the template's data. For example, given the following credentials:\n    {\"username\": \"johndoe\", \"password\": \"secret\", \"host\": \"db\", \"port\": 5432}\nand the following TemplateKeyTransform:\n    {\"key\": \"DATABASE_URL\", \"template\": \"postgres://{{.username}}:{{.password | queryescape}}@{{.host}}:{{.port}}/orders\"}\nthe following entry will appear in the Secret:\n    \"DATABASE_URL\": \"postgres://johndoe:secret@db:5432/orders\"\nBesides the text/template builtins, templates may use the b64enc, b64dec, queryescape, pathescape, upper, lower, trim, quote, toJson, toEnv and toProperties functions. toEnv and toProperties render a map of entries, such as all the credentials (\"{{toProperties .}}\"), as the content of a .env or .properties file. A template referencing a credentials entry that does not exist fails. Templates may not invoke other templates, and may only range, without nesting, over the credentials or one of their fields. The template is evaluated against the credentials as transformed by the transforms that precede it.",
+					Properties: map[string]spec.Schema{
+						"key": {
+							SchemaProps: spec.SchemaProps{
+								Description: "The name of the key to add",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+						"template": {
+							SchemaProps: spec.SchemaProps{
+								Description: "The template whose result is stored in the Secret under the specified key",
+								Type:        []string{"string"},
+								Format:      "",
+							},
+						},
+					},
+					Required: []string{"key", "template"},
+				},
+			},
+			Dependencies: []string{},
+		},
 		"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1.UserInfo": {
 			Schema: spec.Schema{
 				SchemaProps: spec.SchemaProps{
@@ -27,6 +27,9 @@ const (
 	ClusterServicePlan
 	ServiceBinding
 	ServiceInstance
+	ServiceBroker
+	ServiceClass
+	ServicePlan
 )
 
 func (k Kind) String() string {
@@ -41,6 +44,12 @@ func (k Kind) String() string {
 		return "ServiceBinding"
 	case ServiceInstance:
 		return "ServiceInstance"
+	case ServiceBroker:
+		return "ServiceBroker"
+	case ServiceClass:
+		return "ServiceClass"
+	case ServicePlan:
+		return "ServicePlan"
 	default:
 		return ""
 	}
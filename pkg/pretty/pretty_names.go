@@ -62,6 +62,27 @@ func ClusterServicePlanName(servicePlan *v1beta1.ClusterServicePlan) string {
 	return Name(ClusterServicePlan, "", "")
 }
 
+// ServiceBrokerName returns a string with the type and name of a namespaced broker
+func ServiceBrokerName(brokerName string) string {
+	return fmt.Sprintf(`%s %q`, ServiceBroker, brokerName)
+}
+
+// ServiceClassName returns a string with the k8s name and external name if available.
+func ServiceClassName(serviceClass *v1beta1.ServiceClass) string {
+	if serviceClass != nil {
+		return Name(ServiceClass, serviceClass.Name, serviceClass.Spec.ExternalName)
+	}
+	return Name(ServiceClass, "", "")
+}
+
+// ServicePlanName returns a string with the k8s name and external name if available.
+func ServicePlanName(servicePlan *v1beta1.ServicePlan) string {
+	if servicePlan != nil {
+		return Name(ServicePlan, servicePlan.Name, servicePlan.Spec.ExternalName)
+	}
+	return Name(ServicePlan, "", "")
+}
+
 // FromServiceInstanceOfClusterServiceClassAtBrokerName returns a string in the form of "%s of %s at %s" to help in logging the full context.
 func FromServiceInstanceOfClusterServiceClassAtBrokerName(instance *v1beta1.ServiceInstance, serviceClass *v1beta1.ClusterServiceClass, brokerName string) string {
 	return fmt.Sprintf(
@@ -69,3 +90,11 @@ func FromServiceInstanceOfClusterServiceClassAtBrokerName(instance *v1beta1.Serv
 		ServiceInstanceName(instance), ClusterServiceClassName(serviceClass), ClusterServiceBrokerName(brokerName),
 	)
 }
+
+// FromServiceInstanceOfServiceClassAtBrokerName returns a string in the form of "%s of %s at %s" to help in logging the full context.
+func FromServiceInstanceOfServiceClassAtBrokerName(instance *v1beta1.ServiceInstance, serviceClass *v1beta1.ServiceClass, brokerName string) string {
+	return fmt.Sprintf(
+		"%s of %s at %s",
+		ServiceInstanceName(instance), ServiceClassName(serviceClass), ServiceBrokerName(brokerName),
+	)
+}
@@ -82,3 +82,44 @@ func TestClusterServicePlanName(t *testing.T) {
 		t.Fatalf("Unexpected value of PrettyName String; expected %v, got %v", e, g)
 	}
 }
+
+func TestServiceBrokerName(t *testing.T) {
+	e := `ServiceBroker "brokerName"`
+	g := ServiceBrokerName("brokerName")
+	if g != e {
+		t.Fatalf("Unexpected value of PrettyName String; expected %v, got %v", e, g)
+	}
+}
+
+func TestServiceClassName(t *testing.T) {
+	serviceClass := &v1beta1.ServiceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-class", Namespace: "ns"},
+		Spec: v1beta1.ServiceClassSpec{
+			CommonServiceClassSpec: v1beta1.CommonServiceClassSpec{
+				ExternalName: "external-class-name",
+			},
+		},
+	}
+	e := `ServiceClass (K8S: "service-class" ExternalName: "external-class-name")`
+	g := ServiceClassName(serviceClass)
+	if g != e {
+		t.Fatalf("Unexpected value of PrettyName String; expected %v, got %v", e, g)
+	}
+}
+
+func TestServicePlanName(t *testing.T) {
+	servicePlan := &v1beta1.ServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "service-plan", Namespace: "ns"},
+		Spec: v1beta1.ServicePlanSpec{
+			CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+				ExternalName: "external-plan-name",
+			},
+		},
+	}
+
+	e := `ServicePlan (K8S: "service-plan" ExternalName: "external-plan-name")`
+	g := ServicePlanName(servicePlan)
+	if g != e {
+		t.Fatalf("Unexpected value of PrettyName String; expected %v, got %v", e, g)
+	}
+}
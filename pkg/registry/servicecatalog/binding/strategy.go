@@ -23,6 +23,7 @@ import (
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/rest"
@@ -132,18 +133,35 @@ func (bindingRESTStrategy) PrepareForUpdate(ctx genericapirequest.Context, new,
 	}
 	newServiceBinding.Status = oldServiceBinding.Status
 
-	// TODO: We currently don't handle any changes to the spec in the
-	// reconciler. Once we do that, this check needs to be removed and
-	// proper validation of allowed changes needs to be implemented in
-	// ValidateUpdate. Also, the check for whether the generation needs
-	// to be updated needs to be un-commented.
-	newServiceBinding.Spec = oldServiceBinding.Spec
+	// The reconciler only handles changes to the fields that control how
+	// the credentials are delivered, the rest of the spec is immutable.
+	// TODO: Once the reconciler handles changes to the other fields, proper
+	// validation of allowed changes needs to be implemented in
+	// ValidateUpdate.
+	spec := oldServiceBinding.Spec
+	spec.SecretName = newServiceBinding.Spec.SecretName
+	spec.SecretTransforms = newServiceBinding.Spec.SecretTransforms
+	spec.PodPresetTemplate = newServiceBinding.Spec.PodPresetTemplate
+	spec.SecretCopyNamespaces = newServiceBinding.Spec.SecretCopyNamespaces
+	spec.ConfigMapTemplate = newServiceBinding.Spec.ConfigMapTemplate
+	// Ignore the RotationRequests field when it is the default value
+	if newServiceBinding.Spec.RotationRequests != 0 {
+		spec.RotationRequests = newServiceBinding.Spec.RotationRequests
+	}
+	newServiceBinding.Spec = spec
+
+	// A rotation binds new credentials under a new ExternalID. The
+	// controller keeps the current ExternalID in the status so that it can
+	// unbind the previous credentials once the rotation is complete.
+	if newServiceBinding.Spec.RotationRequests > oldServiceBinding.Spec.RotationRequests {
+		if newServiceBinding.Status.CurrentExternalID == "" {
+			newServiceBinding.Status.CurrentExternalID = oldServiceBinding.Spec.ExternalID
+		}
+		newServiceBinding.Spec.ExternalID = string(uuid.NewUUID())
+	}
 
 	// Spec updates bump the generation so that we can distinguish between
 	// spec changes and other changes to the object.
-	//
-	// Note that since we do not currently handle any changes to the spec,
-	// the generation will never be incremented
 	if !apiequality.Semantic.DeepEqual(oldServiceBinding.Spec, newServiceBinding.Spec) {
 		if utilfeature.DefaultFeatureGate.Enabled(scfeatures.OriginatingIdentity) {
 			setServiceBindingUserInfo(newServiceBinding, ctx)
@@ -58,11 +58,8 @@ func contextWithUserName(userName string) genericapirequest.Context {
 	return genericapirequest.WithUser(ctx, userInfo)
 }
 
-// TODO: Un-comment "spec-change" test case when there is a field
-// in the spec to which the reconciler allows a change.
-
 // TestInstanceCredentialUpdate tests that generation is incremented correctly when the
-// spec of a ServiceBinding is updated.
+// spec of a ServiceBinding is updated, and that immutable fields are not changed.
 func TestInstanceCredentialUpdate(t *testing.T) {
 	cases := []struct {
 		name                      string
@@ -75,18 +72,61 @@ func TestInstanceCredentialUpdate(t *testing.T) {
 			older: getTestInstanceCredential(),
 			newer: getTestInstanceCredential(),
 		},
-		//		{
-		//			name:  "spec change",
-		//			older: getTestInstanceCredential(),
-		//			newer: func() *v1beta1.ServiceBinding {
-		//				ic := getTestInstanceCredential()
-		//				ic.Spec.ServiceInstanceRef = servicecatalog.LocalObjectReference{
-		//					Name: "new-string",
-		//				}
-		//				return ic
-		//			},
-		//			shouldGenerationIncrement: true,
-		//		},
+		{
+			name:  "immutable spec change",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.ServiceInstanceRef = servicecatalog.LocalObjectReference{
+					Name: "new-string",
+				}
+				return ic
+			}(),
+		},
+		{
+			name:  "secret name change",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.SecretName = "new-secret"
+				return ic
+			}(),
+			shouldGenerationIncrement: true,
+		},
+		{
+			name:  "pod preset template change",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.PodPresetTemplate = &servicecatalog.PodPresetTemplate{
+					MountPath: "/credentials",
+				}
+				return ic
+			}(),
+			shouldGenerationIncrement: true,
+		},
+		{
+			name:  "secret copy namespaces change",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.SecretCopyNamespaces = []string{"other-ns"}
+				return ic
+			}(),
+			shouldGenerationIncrement: true,
+		},
+		{
+			name:  "config map template change",
+			older: getTestInstanceCredential(),
+			newer: func() *servicecatalog.ServiceBinding {
+				ic := getTestInstanceCredential()
+				ic.Spec.ConfigMapTemplate = &servicecatalog.ConfigMapTemplate{
+					Keys: []string{"host"},
+				}
+				return ic
+			}(),
+			shouldGenerationIncrement: true,
+		},
 	}
 	for _, tc := range cases {
 		bindingRESTStrategies.PrepareForUpdate(nil, tc.newer, tc.older)
@@ -138,3 +178,57 @@ func TestInstanceCredentialUserInfo(t *testing.T) {
 		t.Errorf("unexpected user info in deleted spec: expected %q, got %q", e, a)
 	}
 }
+
+// TestInstanceCredentialRotation tests that a new ExternalID is assigned when
+// the RotationRequests counter of a ServiceBinding is incremented.
+func TestInstanceCredentialRotation(t *testing.T) {
+	cases := []struct {
+		name                      string
+		oldValue                  int64
+		newValue                  int64
+		expectedRotationRequests  int64
+		shouldExternalIDBeChanged bool
+	}{
+		{
+			name:                     "default value",
+			oldValue:                 1,
+			newValue:                 0,
+			expectedRotationRequests: 1,
+		},
+		{
+			name:                     "unchanged",
+			oldValue:                 1,
+			newValue:                 1,
+			expectedRotationRequests: 1,
+		},
+		{
+			name:                      "incremented",
+			oldValue:                  1,
+			newValue:                  2,
+			expectedRotationRequests:  2,
+			shouldExternalIDBeChanged: true,
+		},
+	}
+	for _, tc := range cases {
+		older := getTestInstanceCredential()
+		older.Spec.ExternalID = "old-id"
+		older.Spec.RotationRequests = tc.oldValue
+		newer := getTestInstanceCredential()
+		newer.Spec.ExternalID = "old-id"
+		newer.Spec.RotationRequests = tc.newValue
+
+		bindingRESTStrategies.PrepareForUpdate(nil, newer, older)
+
+		if e, a := tc.expectedRotationRequests, newer.Spec.RotationRequests; e != a {
+			t.Errorf("%v: expected %v, got %v for rotationRequests", tc.name, e, a)
+		}
+		if e, a := tc.shouldExternalIDBeChanged, newer.Spec.ExternalID != "old-id"; e != a {
+			t.Errorf("%v: expected external ID change to be %v, got ExternalID %q", tc.name, e, newer.Spec.ExternalID)
+		}
+		if tc.shouldExternalIDBeChanged {
+			if e, a := "old-id", newer.Status.CurrentExternalID; e != a {
+				t.Errorf("%v: expected %v, got %v for current external ID", tc.name, e, a)
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterparameterdefault
+
+import (
+	"errors"
+	"fmt"
+
+	scmeta "github.com/kubernetes-incubator/service-catalog/pkg/api/meta"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+var (
+	errNotAClusterParameterDefault = errors.New("not a ClusterParameterDefault")
+)
+
+// NewSingular returns a new shell of a ClusterParameterDefault, according to
+// the given namespace and name
+func NewSingular(ns, name string) runtime.Object {
+	return &servicecatalog.ClusterParameterDefault{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "ClusterParameterDefault",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+		},
+	}
+}
+
+// EmptyObject returns an empty ClusterParameterDefault
+func EmptyObject() runtime.Object {
+	return &servicecatalog.ClusterParameterDefault{}
+}
+
+// NewList returns a new shell of a ClusterParameterDefault list
+func NewList() runtime.Object {
+	return &servicecatalog.ClusterParameterDefaultList{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "ClusterParameterDefaultList",
+		},
+		Items: []servicecatalog.ClusterParameterDefault{},
+	}
+}
+
+// CheckObject returns a non-nil error if obj is not a ClusterParameterDefault
+// object
+func CheckObject(obj runtime.Object) error {
+	_, ok := obj.(*servicecatalog.ClusterParameterDefault)
+	if !ok {
+		return errNotAClusterParameterDefault
+	}
+	return nil
+}
+
+// Match determines whether a ClusterParameterDefault matches a field and label
+// selector.
+func Match(label labels.Selector, field fields.Selector) storage.SelectionPredicate {
+	return storage.SelectionPredicate{
+		Label:    label,
+		Field:    field,
+		GetAttrs: GetAttrs,
+	}
+}
+
+// toSelectableFields returns a field set that represents the object for matching purposes.
+func toSelectableFields(parameterDefault *servicecatalog.ClusterParameterDefault) fields.Set {
+	return generic.ObjectMetaFieldsSet(&parameterDefault.ObjectMeta, false)
+}
+
+// GetAttrs returns labels and fields of a given object for filtering purposes.
+func GetAttrs(obj runtime.Object) (labels.Set, fields.Set, bool, error) {
+	parameterDefault, ok := obj.(*servicecatalog.ClusterParameterDefault)
+	if !ok {
+		return nil, nil, false, fmt.Errorf("given object is not a ClusterParameterDefault")
+	}
+	return labels.Set(parameterDefault.ObjectMeta.Labels), toSelectableFields(parameterDefault), parameterDefault.Initializers != nil, nil
+}
+
+// NewStorage creates a new rest.Storage responsible for accessing
+// ClusterParameterDefault resources
+func NewStorage(opts server.Options) rest.Storage {
+	prefix := "/" + opts.ResourcePrefix()
+
+	storageInterface, dFunc := opts.GetStorage(
+		&servicecatalog.ClusterParameterDefault{},
+		prefix,
+		clusterParameterDefaultRESTStrategies,
+		NewList,
+		nil,
+		storage.NoTriggerPublisher,
+	)
+
+	store := registry.Store{
+		NewFunc:     EmptyObject,
+		NewListFunc: NewList,
+		KeyRootFunc: opts.KeyRootFunc(),
+		KeyFunc:     opts.KeyFunc(false),
+		// Retrieve the name field of the resource.
+		ObjectNameFunc: func(obj runtime.Object) (string, error) {
+			return scmeta.GetAccessor().Name(obj)
+		},
+		// Used to match objects based on labels/fields for list.
+		PredicateFunc: Match,
+		// DefaultQualifiedResource should always be plural
+		DefaultQualifiedResource: servicecatalog.Resource("clusterparameterdefaults"),
+
+		CreateStrategy: clusterParameterDefaultRESTStrategies,
+		UpdateStrategy: clusterParameterDefaultRESTStrategies,
+		DeleteStrategy: clusterParameterDefaultRESTStrategies,
+		Storage:        storageInterface,
+		DestroyFunc:    dFunc,
+	}
+
+	return &store
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterparameterdefault
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/pkg/api"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/storage/names"
+
+	"github.com/golang/glog"
+	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scv "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/validation"
+)
+
+// NewScopeStrategy returns a new NamespaceScopedStrategy for parameter
+// defaults
+func NewScopeStrategy() rest.NamespaceScopedStrategy {
+	return clusterParameterDefaultRESTStrategies
+}
+
+// implements interfaces RESTCreateStrategy, RESTUpdateStrategy, RESTDeleteStrategy,
+// NamespaceScopedStrategy
+type clusterParameterDefaultRESTStrategy struct {
+	runtime.ObjectTyper // inherit ObjectKinds method
+	names.NameGenerator // GenerateName method for CreateStrategy
+}
+
+var (
+	clusterParameterDefaultRESTStrategies = clusterParameterDefaultRESTStrategy{
+		// embeds to pull in existing code behavior from upstream
+
+		ObjectTyper: api.Scheme,
+		// use the generator from upstream k8s, or implement method
+		// `GenerateName(base string) string`
+		NameGenerator: names.SimpleNameGenerator,
+	}
+	_ rest.RESTCreateStrategy = clusterParameterDefaultRESTStrategies
+	_ rest.RESTUpdateStrategy = clusterParameterDefaultRESTStrategies
+	_ rest.RESTDeleteStrategy = clusterParameterDefaultRESTStrategies
+)
+
+// Canonicalize does not transform a ClusterParameterDefault.
+func (clusterParameterDefaultRESTStrategy) Canonicalize(obj runtime.Object) {
+	_, ok := obj.(*sc.ClusterParameterDefault)
+	if !ok {
+		glog.Fatal("received a non-ClusterParameterDefault object to create")
+	}
+}
+
+// NamespaceScoped returns false as ClusterParameterDefaults are not scoped to
+// a namespace.
+func (clusterParameterDefaultRESTStrategy) NamespaceScoped() bool {
+	return false
+}
+
+// PrepareForCreate receives the incoming ClusterParameterDefault.
+func (clusterParameterDefaultRESTStrategy) PrepareForCreate(ctx genericapirequest.Context, obj runtime.Object) {
+	_, ok := obj.(*sc.ClusterParameterDefault)
+	if !ok {
+		glog.Fatal("received a non-ClusterParameterDefault object to create")
+	}
+	// parameter default is a data record and has no status to track
+}
+
+func (clusterParameterDefaultRESTStrategy) Validate(ctx genericapirequest.Context, obj runtime.Object) field.ErrorList {
+	return scv.ValidateClusterParameterDefault(obj.(*sc.ClusterParameterDefault))
+}
+
+func (clusterParameterDefaultRESTStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+func (clusterParameterDefaultRESTStrategy) AllowUnconditionalUpdate() bool {
+	return false
+}
+
+func (clusterParameterDefaultRESTStrategy) PrepareForUpdate(ctx genericapirequest.Context, new, old runtime.Object) {
+	_, ok := new.(*sc.ClusterParameterDefault)
+	if !ok {
+		glog.Fatal("received a non-ClusterParameterDefault object to update to")
+	}
+	_, ok = old.(*sc.ClusterParameterDefault)
+	if !ok {
+		glog.Fatal("received a non-ClusterParameterDefault object to update from")
+	}
+}
+
+func (clusterParameterDefaultRESTStrategy) ValidateUpdate(ctx genericapirequest.Context, new, old runtime.Object) field.ErrorList {
+	newParameterDefault, ok := new.(*sc.ClusterParameterDefault)
+	if !ok {
+		glog.Fatal("received a non-ClusterParameterDefault object to validate to")
+	}
+	oldParameterDefault, ok := old.(*sc.ClusterParameterDefault)
+	if !ok {
+		glog.Fatal("received a non-ClusterParameterDefault object to validate from")
+	}
+
+	return scv.ValidateClusterParameterDefaultUpdate(newParameterDefault, oldParameterDefault)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterservicevisibilitypolicy
+
+import (
+	"errors"
+	"fmt"
+
+	scmeta "github.com/kubernetes-incubator/service-catalog/pkg/api/meta"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+var (
+	errNotAClusterServiceVisibilityPolicy = errors.New("not a ClusterServiceVisibilityPolicy")
+)
+
+// NewSingular returns a new shell of a ClusterServiceVisibilityPolicy, according to
+// the given namespace and name
+func NewSingular(ns, name string) runtime.Object {
+	return &servicecatalog.ClusterServiceVisibilityPolicy{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "ClusterServiceVisibilityPolicy",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+		},
+	}
+}
+
+// EmptyObject returns an empty ClusterServiceVisibilityPolicy
+func EmptyObject() runtime.Object {
+	return &servicecatalog.ClusterServiceVisibilityPolicy{}
+}
+
+// NewList returns a new shell of a ClusterServiceVisibilityPolicy list
+func NewList() runtime.Object {
+	return &servicecatalog.ClusterServiceVisibilityPolicyList{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "ClusterServiceVisibilityPolicyList",
+		},
+		Items: []servicecatalog.ClusterServiceVisibilityPolicy{},
+	}
+}
+
+// CheckObject returns a non-nil error if obj is not a ClusterServiceVisibilityPolicy
+// object
+func CheckObject(obj runtime.Object) error {
+	_, ok := obj.(*servicecatalog.ClusterServiceVisibilityPolicy)
+	if !ok {
+		return errNotAClusterServiceVisibilityPolicy
+	}
+	return nil
+}
+
+// Match determines whether a ClusterServiceVisibilityPolicy matches a field and label
+// selector.
+func Match(label labels.Selector, field fields.Selector) storage.SelectionPredicate {
+	return storage.SelectionPredicate{
+		Label:    label,
+		Field:    field,
+		GetAttrs: GetAttrs,
+	}
+}
+
+// toSelectableFields returns a field set that represents the object for matching purposes.
+func toSelectableFields(policy *servicecatalog.ClusterServiceVisibilityPolicy) fields.Set {
+	return generic.ObjectMetaFieldsSet(&policy.ObjectMeta, false)
+}
+
+// GetAttrs returns labels and fields of a given object for filtering purposes.
+func GetAttrs(obj runtime.Object) (labels.Set, fields.Set, bool, error) {
+	policy, ok := obj.(*servicecatalog.ClusterServiceVisibilityPolicy)
+	if !ok {
+		return nil, nil, false, fmt.Errorf("given object is not a ClusterServiceVisibilityPolicy")
+	}
+	return labels.Set(policy.ObjectMeta.Labels), toSelectableFields(policy), policy.Initializers != nil, nil
+}
+
+// NewStorage creates a new rest.Storage responsible for accessing
+// ClusterServiceVisibilityPolicy resources
+func NewStorage(opts server.Options) rest.Storage {
+	prefix := "/" + opts.ResourcePrefix()
+
+	storageInterface, dFunc := opts.GetStorage(
+		&servicecatalog.ClusterServiceVisibilityPolicy{},
+		prefix,
+		clusterServiceVisibilityPolicyRESTStrategies,
+		NewList,
+		nil,
+		storage.NoTriggerPublisher,
+	)
+
+	store := registry.Store{
+		NewFunc:     EmptyObject,
+		NewListFunc: NewList,
+		KeyRootFunc: opts.KeyRootFunc(),
+		KeyFunc:     opts.KeyFunc(false),
+		// Retrieve the name field of the resource.
+		ObjectNameFunc: func(obj runtime.Object) (string, error) {
+			return scmeta.GetAccessor().Name(obj)
+		},
+		// Used to match objects based on labels/fields for list.
+		PredicateFunc: Match,
+		// DefaultQualifiedResource should always be plural
+		DefaultQualifiedResource: servicecatalog.Resource("clusterservicevisibilitypolicies"),
+
+		CreateStrategy: clusterServiceVisibilityPolicyRESTStrategies,
+		UpdateStrategy: clusterServiceVisibilityPolicyRESTStrategies,
+		DeleteStrategy: clusterServiceVisibilityPolicyRESTStrategies,
+		Storage:        storageInterface,
+		DestroyFunc:    dFunc,
+	}
+
+	return &store
+}
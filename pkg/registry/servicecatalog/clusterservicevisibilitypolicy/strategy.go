@@ -0,0 +1,116 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterservicevisibilitypolicy
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/pkg/api"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/storage/names"
+
+	"github.com/golang/glog"
+	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scv "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/validation"
+)
+
+// NewScopeStrategy returns a new NamespaceScopedStrategy for visibility
+// policies
+func NewScopeStrategy() rest.NamespaceScopedStrategy {
+	return clusterServiceVisibilityPolicyRESTStrategies
+}
+
+// implements interfaces RESTCreateStrategy, RESTUpdateStrategy, RESTDeleteStrategy,
+// NamespaceScopedStrategy
+type clusterServiceVisibilityPolicyRESTStrategy struct {
+	runtime.ObjectTyper // inherit ObjectKinds method
+	names.NameGenerator // GenerateName method for CreateStrategy
+}
+
+var (
+	clusterServiceVisibilityPolicyRESTStrategies = clusterServiceVisibilityPolicyRESTStrategy{
+		// embeds to pull in existing code behavior from upstream
+
+		ObjectTyper: api.Scheme,
+		// use the generator from upstream k8s, or implement method
+		// `GenerateName(base string) string`
+		NameGenerator: names.SimpleNameGenerator,
+	}
+	_ rest.RESTCreateStrategy = clusterServiceVisibilityPolicyRESTStrategies
+	_ rest.RESTUpdateStrategy = clusterServiceVisibilityPolicyRESTStrategies
+	_ rest.RESTDeleteStrategy = clusterServiceVisibilityPolicyRESTStrategies
+)
+
+// Canonicalize does not transform a ClusterServiceVisibilityPolicy.
+func (clusterServiceVisibilityPolicyRESTStrategy) Canonicalize(obj runtime.Object) {
+	_, ok := obj.(*sc.ClusterServiceVisibilityPolicy)
+	if !ok {
+		glog.Fatal("received a non-ClusterServiceVisibilityPolicy object to create")
+	}
+}
+
+// NamespaceScoped returns false as ClusterServiceVisibilityPolicies are not scoped to
+// a namespace.
+func (clusterServiceVisibilityPolicyRESTStrategy) NamespaceScoped() bool {
+	return false
+}
+
+// PrepareForCreate receives the incoming ClusterServiceVisibilityPolicy.
+func (clusterServiceVisibilityPolicyRESTStrategy) PrepareForCreate(ctx genericapirequest.Context, obj runtime.Object) {
+	_, ok := obj.(*sc.ClusterServiceVisibilityPolicy)
+	if !ok {
+		glog.Fatal("received a non-ClusterServiceVisibilityPolicy object to create")
+	}
+	// a visibility policy is a data record and has no status to track
+}
+
+func (clusterServiceVisibilityPolicyRESTStrategy) Validate(ctx genericapirequest.Context, obj runtime.Object) field.ErrorList {
+	return scv.ValidateClusterServiceVisibilityPolicy(obj.(*sc.ClusterServiceVisibilityPolicy))
+}
+
+func (clusterServiceVisibilityPolicyRESTStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+func (clusterServiceVisibilityPolicyRESTStrategy) AllowUnconditionalUpdate() bool {
+	return false
+}
+
+func (clusterServiceVisibilityPolicyRESTStrategy) PrepareForUpdate(ctx genericapirequest.Context, new, old runtime.Object) {
+	_, ok := new.(*sc.ClusterServiceVisibilityPolicy)
+	if !ok {
+		glog.Fatal("received a non-ClusterServiceVisibilityPolicy object to update to")
+	}
+	_, ok = old.(*sc.ClusterServiceVisibilityPolicy)
+	if !ok {
+		glog.Fatal("received a non-ClusterServiceVisibilityPolicy object to update from")
+	}
+}
+
+func (clusterServiceVisibilityPolicyRESTStrategy) ValidateUpdate(ctx genericapirequest.Context, new, old runtime.Object) field.ErrorList {
+	newPolicy, ok := new.(*sc.ClusterServiceVisibilityPolicy)
+	if !ok {
+		glog.Fatal("received a non-ClusterServiceVisibilityPolicy object to validate to")
+	}
+	oldPolicy, ok := old.(*sc.ClusterServiceVisibilityPolicy)
+	if !ok {
+		glog.Fatal("received a non-ClusterServiceVisibilityPolicy object to validate from")
+	}
+
+	return scv.ValidateClusterServiceVisibilityPolicyUpdate(newPolicy, oldPolicy)
+}
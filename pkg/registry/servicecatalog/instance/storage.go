@@ -92,7 +92,7 @@ func toSelectableFields(instance *servicecatalog.ServiceInstance) fields.Set {
 	// pkg/apis/servicecatalog/v1beta1/conversion[_test].go
 	objectMetaFieldsSet := generic.ObjectMetaFieldsSet(&instance.ObjectMeta, true)
 
-	specFieldSet := make(fields.Set, 2)
+	specFieldSet := make(fields.Set, 4)
 
 	if instance.Spec.ClusterServiceClassRef != nil {
 		specFieldSet["spec.clusterServiceClassRef.name"] = instance.Spec.ClusterServiceClassRef.Name
@@ -102,6 +102,14 @@ func toSelectableFields(instance *servicecatalog.ServiceInstance) fields.Set {
 		specFieldSet["spec.clusterServicePlanRef.name"] = instance.Spec.ClusterServicePlanRef.Name
 	}
 
+	if instance.Spec.ServiceClassRef != nil {
+		specFieldSet["spec.serviceClassRef.name"] = instance.Spec.ServiceClassRef.Name
+	}
+
+	if instance.Spec.ServicePlanRef != nil {
+		specFieldSet["spec.servicePlanRef.name"] = instance.Spec.ServicePlanRef.Name
+	}
+
 	return generic.MergeFieldsSets(objectMetaFieldsSet, specFieldSet)
 }
 
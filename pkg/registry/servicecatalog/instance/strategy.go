@@ -55,8 +55,8 @@ type instanceStatusRESTStrategy struct {
 }
 
 // implements interface RESTUpdateStrategy. This implementation validates updates to
-// instance.Spec.ClusterServicePlanRef and instance.Spec.ClusterServiceClassRef only and disallows
-// any modifications to the remaining instance.Spec or Status fields.
+// instance.Spec.[Cluster]ServicePlanRef and instance.Spec.[Cluster]ServiceClassRef only and
+// disallows any modifications to the remaining instance.Spec or Status fields.
 type instanceReferenceRESTStrategy struct {
 	instanceRESTStrategy
 }
@@ -122,6 +122,8 @@ func (instanceRESTStrategy) PrepareForCreate(ctx genericapirequest.Context, obj
 
 	instance.Spec.ClusterServiceClassRef = nil
 	instance.Spec.ClusterServicePlanRef = nil
+	instance.Spec.ServiceClassRef = nil
+	instance.Spec.ServicePlanRef = nil
 	instance.Finalizers = []string{sc.FinalizerServiceCatalog}
 	instance.Generation = 1
 }
@@ -154,15 +156,25 @@ func (instanceRESTStrategy) PrepareForUpdate(ctx genericapirequest.Context, new,
 	// Do not allow updates to Service[Class|Plan]Ref fields
 	newServiceInstance.Spec.ClusterServiceClassRef = oldServiceInstance.Spec.ClusterServiceClassRef
 	newServiceInstance.Spec.ClusterServicePlanRef = oldServiceInstance.Spec.ClusterServicePlanRef
+	newServiceInstance.Spec.ServiceClassRef = oldServiceInstance.Spec.ServiceClassRef
+	newServiceInstance.Spec.ServicePlanRef = oldServiceInstance.Spec.ServicePlanRef
 
 	// Clear out the ClusterServicePlanRef so that it is resolved during reconciliation
-	planUpdated := newServiceInstance.Spec.ClusterServicePlanExternalName != oldServiceInstance.Spec.ClusterServicePlanExternalName ||
+	clusterPlanUpdated := newServiceInstance.Spec.ClusterServicePlanExternalName != oldServiceInstance.Spec.ClusterServicePlanExternalName ||
 		newServiceInstance.Spec.ClusterServicePlanExternalID != oldServiceInstance.Spec.ClusterServicePlanExternalID ||
 		newServiceInstance.Spec.ClusterServicePlanName != oldServiceInstance.Spec.ClusterServicePlanName
-	if planUpdated {
+	if clusterPlanUpdated {
 		newServiceInstance.Spec.ClusterServicePlanRef = nil
 	}
 
+	// Clear out the ServicePlanRef so that it is resolved during reconciliation
+	planUpdated := newServiceInstance.Spec.ServicePlanExternalName != oldServiceInstance.Spec.ServicePlanExternalName ||
+		newServiceInstance.Spec.ServicePlanExternalID != oldServiceInstance.Spec.ServicePlanExternalID ||
+		newServiceInstance.Spec.ServicePlanName != oldServiceInstance.Spec.ServicePlanName
+	if planUpdated {
+		newServiceInstance.Spec.ServicePlanRef = nil
+	}
+
 	// Ignore the UpdateRequests field when it is the default value
 	if newServiceInstance.Spec.UpdateRequests == 0 {
 		newServiceInstance.Spec.UpdateRequests = oldServiceInstance.Spec.UpdateRequests
@@ -248,9 +260,13 @@ func (instanceReferenceRESTStrategy) PrepareForUpdate(ctx genericapirequest.Cont
 	// again.
 	newClusterServiceClassRef := newServiceInstance.Spec.ClusterServiceClassRef
 	newClusterServicePlanRef := newServiceInstance.Spec.ClusterServicePlanRef
+	newServiceClassRef := newServiceInstance.Spec.ServiceClassRef
+	newServicePlanRef := newServiceInstance.Spec.ServicePlanRef
 	newServiceInstance.Spec = oldServiceInstance.Spec
 	newServiceInstance.Spec.ClusterServiceClassRef = newClusterServiceClassRef
 	newServiceInstance.Spec.ClusterServicePlanRef = newClusterServicePlanRef
+	newServiceInstance.Spec.ServiceClassRef = newServiceClassRef
+	newServiceInstance.Spec.ServicePlanRef = newServicePlanRef
 	newServiceInstance.Status = oldServiceInstance.Status
 }
 
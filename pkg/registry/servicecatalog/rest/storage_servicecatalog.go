@@ -21,13 +21,16 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
 	servicecatalogv1beta1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/binding"
+	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/clusterparameterdefault"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/clusterservicebroker"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/clusterserviceclass"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/clusterserviceplan"
+	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/clusterservicevisibilitypolicy"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/instance"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/server"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/servicebroker"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/serviceclass"
+	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/serviceinstancequota"
 	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/serviceplan"
 	"github.com/kubernetes-incubator/service-catalog/pkg/storage/etcd"
 	"k8s.io/apiserver/pkg/registry/generic"
@@ -250,6 +253,74 @@ func (p StorageProvider) v1beta1Storage(
 		storageMap["servicebrokers/status"] = serviceBrokerStatusStorage
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.DefaultParameters) {
+		clusterParameterDefaultRESTOptions, err := restOptionsGetter.GetRESTOptions(servicecatalog.Resource("clusterparameterdefaults"))
+		if err != nil {
+			return nil, err
+		}
+
+		clusterParameterDefaultOpts := server.NewOptions(
+			etcd.Options{
+				RESTOptions:   clusterParameterDefaultRESTOptions,
+				Capacity:      1000,
+				ObjectType:    clusterparameterdefault.EmptyObject(),
+				ScopeStrategy: clusterparameterdefault.NewScopeStrategy(),
+				NewListFunc:   clusterparameterdefault.NewList,
+				GetAttrsFunc:  clusterparameterdefault.GetAttrs,
+				Trigger:       storage.NoTriggerPublisher,
+			},
+			p.StorageType,
+		)
+
+		storageMap["clusterparameterdefaults"] = clusterparameterdefault.NewStorage(*clusterParameterDefaultOpts)
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServiceInstanceQuota) {
+		serviceInstanceQuotaRESTOptions, err := restOptionsGetter.GetRESTOptions(servicecatalog.Resource("serviceinstancequotas"))
+		if err != nil {
+			return nil, err
+		}
+
+		serviceInstanceQuotaOpts := server.NewOptions(
+			etcd.Options{
+				RESTOptions:   serviceInstanceQuotaRESTOptions,
+				Capacity:      1000,
+				ObjectType:    serviceinstancequota.EmptyObject(),
+				ScopeStrategy: serviceinstancequota.NewScopeStrategy(),
+				NewListFunc:   serviceinstancequota.NewList,
+				GetAttrsFunc:  serviceinstancequota.GetAttrs,
+				Trigger:       storage.NoTriggerPublisher,
+			},
+			p.StorageType,
+		)
+
+		serviceInstanceQuotaStorage, serviceInstanceQuotaStatusStorage := serviceinstancequota.NewStorage(*serviceInstanceQuotaOpts)
+		storageMap["serviceinstancequotas"] = serviceInstanceQuotaStorage
+		storageMap["serviceinstancequotas/status"] = serviceInstanceQuotaStatusStorage
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServiceVisibilityPolicy) {
+		clusterServiceVisibilityPolicyRESTOptions, err := restOptionsGetter.GetRESTOptions(servicecatalog.Resource("clusterservicevisibilitypolicies"))
+		if err != nil {
+			return nil, err
+		}
+
+		clusterServiceVisibilityPolicyOpts := server.NewOptions(
+			etcd.Options{
+				RESTOptions:   clusterServiceVisibilityPolicyRESTOptions,
+				Capacity:      1000,
+				ObjectType:    clusterservicevisibilitypolicy.EmptyObject(),
+				ScopeStrategy: clusterservicevisibilitypolicy.NewScopeStrategy(),
+				NewListFunc:   clusterservicevisibilitypolicy.NewList,
+				GetAttrsFunc:  clusterservicevisibilitypolicy.GetAttrs,
+				Trigger:       storage.NoTriggerPublisher,
+			},
+			p.StorageType,
+		)
+
+		storageMap["clusterservicevisibilitypolicies"] = clusterservicevisibilitypolicy.NewStorage(*clusterServiceVisibilityPolicyOpts)
+	}
+
 	return storageMap, nil
 }
 
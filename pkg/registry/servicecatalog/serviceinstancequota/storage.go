@@ -0,0 +1,173 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceinstancequota
+
+import (
+	"errors"
+	"fmt"
+
+	scmeta "github.com/kubernetes-incubator/service-catalog/pkg/api/meta"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/registry/servicecatalog/server"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/registry/generic/registry"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+var (
+	errNotAServiceInstanceQuota = errors.New("not a ServiceInstanceQuota")
+)
+
+// NewSingular returns a new shell of a ServiceInstanceQuota, according to the
+// given namespace and name
+func NewSingular(ns, name string) runtime.Object {
+	return &servicecatalog.ServiceInstanceQuota{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "ServiceInstanceQuota",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ns,
+			Name:      name,
+		},
+	}
+}
+
+// EmptyObject returns an empty ServiceInstanceQuota
+func EmptyObject() runtime.Object {
+	return &servicecatalog.ServiceInstanceQuota{}
+}
+
+// NewList returns a new shell of a ServiceInstanceQuota list
+func NewList() runtime.Object {
+	return &servicecatalog.ServiceInstanceQuotaList{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "ServiceInstanceQuotaList",
+		},
+		Items: []servicecatalog.ServiceInstanceQuota{},
+	}
+}
+
+// CheckObject returns a non-nil error if obj is not a ServiceInstanceQuota
+// object
+func CheckObject(obj runtime.Object) error {
+	_, ok := obj.(*servicecatalog.ServiceInstanceQuota)
+	if !ok {
+		return errNotAServiceInstanceQuota
+	}
+	return nil
+}
+
+// Match determines whether a ServiceInstanceQuota matches a field and label
+// selector.
+func Match(label labels.Selector, field fields.Selector) storage.SelectionPredicate {
+	return storage.SelectionPredicate{
+		Label:    label,
+		Field:    field,
+		GetAttrs: GetAttrs,
+	}
+}
+
+// toSelectableFields returns a field set that represents the object for matching purposes.
+func toSelectableFields(quota *servicecatalog.ServiceInstanceQuota) fields.Set {
+	return generic.ObjectMetaFieldsSet(&quota.ObjectMeta, true)
+}
+
+// GetAttrs returns labels and fields of a given object for filtering purposes.
+func GetAttrs(obj runtime.Object) (labels.Set, fields.Set, bool, error) {
+	quota, ok := obj.(*servicecatalog.ServiceInstanceQuota)
+	if !ok {
+		return nil, nil, false, fmt.Errorf("given object is not a ServiceInstanceQuota")
+	}
+	return labels.Set(quota.ObjectMeta.Labels), toSelectableFields(quota), quota.Initializers != nil, nil
+}
+
+// NewStorage creates a new rest.Storage responsible for accessing
+// ServiceInstanceQuota resources
+func NewStorage(opts server.Options) (serviceInstanceQuotas, serviceInstanceQuotaStatus rest.Storage) {
+	prefix := "/" + opts.ResourcePrefix()
+
+	storageInterface, dFunc := opts.GetStorage(
+		&servicecatalog.ServiceInstanceQuota{},
+		prefix,
+		serviceInstanceQuotaRESTStrategies,
+		NewList,
+		nil,
+		storage.NoTriggerPublisher,
+	)
+
+	store := registry.Store{
+		NewFunc:     EmptyObject,
+		NewListFunc: NewList,
+		KeyRootFunc: opts.KeyRootFunc(),
+		KeyFunc:     opts.KeyFunc(true),
+		// Retrieve the name field of the resource.
+		ObjectNameFunc: func(obj runtime.Object) (string, error) {
+			return scmeta.GetAccessor().Name(obj)
+		},
+		// Used to match objects based on labels/fields for list.
+		PredicateFunc: Match,
+		// DefaultQualifiedResource should always be plural
+		DefaultQualifiedResource: servicecatalog.Resource("serviceinstancequotas"),
+
+		CreateStrategy: serviceInstanceQuotaRESTStrategies,
+		UpdateStrategy: serviceInstanceQuotaRESTStrategies,
+		DeleteStrategy: serviceInstanceQuotaRESTStrategies,
+
+		Storage:     storageInterface,
+		DestroyFunc: dFunc,
+	}
+
+	options := &generic.StoreOptions{RESTOptions: opts.EtcdOptions.RESTOptions, AttrFunc: GetAttrs}
+	if err := store.CompleteWithOptions(options); err != nil {
+		panic(err) // TODO: Propagate error up
+	}
+
+	statusStore := store
+	statusStore.UpdateStrategy = serviceInstanceQuotaStatusUpdateStrategy
+
+	return &store, &StatusREST{&statusStore}
+}
+
+// StatusREST defines the REST operations for the status subresource via
+// implementation of various rest interfaces.  It supports the http verbs GET,
+// PATCH, and PUT.
+type StatusREST struct {
+	store *registry.Store
+}
+
+// New returns a new ServiceInstanceQuota.
+func (r *StatusREST) New() runtime.Object {
+	return &servicecatalog.ServiceInstanceQuota{}
+}
+
+// Get retrieves the object from the storage. It is required to support Patch
+// and to implement the rest.Getter interface.
+func (r *StatusREST) Get(ctx genericapirequest.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	return r.store.Get(ctx, name, options)
+}
+
+// Update alters the status subset of an object and implements the
+// rest.Updater interface.
+func (r *StatusREST) Update(ctx genericapirequest.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc) (runtime.Object, bool, error) {
+	return r.store.Update(ctx, name, objInfo, createValidation, updateValidation)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package serviceinstancequota
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/pkg/api"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/apiserver/pkg/storage/names"
+
+	"github.com/golang/glog"
+	sc "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scv "github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/validation"
+)
+
+// NewScopeStrategy returns a new NamespaceScopedStrategy for quotas
+func NewScopeStrategy() rest.NamespaceScopedStrategy {
+	return serviceInstanceQuotaRESTStrategies
+}
+
+// implements interfaces RESTCreateStrategy, RESTUpdateStrategy, RESTDeleteStrategy,
+// NamespaceScopedStrategy
+type serviceInstanceQuotaRESTStrategy struct {
+	runtime.ObjectTyper // inherit ObjectKinds method
+	names.NameGenerator // GenerateName method for CreateStrategy
+}
+
+// implements interface RESTUpdateStrategy
+type serviceInstanceQuotaStatusRESTStrategy struct {
+	serviceInstanceQuotaRESTStrategy
+}
+
+var (
+	serviceInstanceQuotaRESTStrategies = serviceInstanceQuotaRESTStrategy{
+		// embeds to pull in existing code behavior from upstream
+
+		ObjectTyper: api.Scheme,
+		// use the generator from upstream k8s, or implement method
+		// `GenerateName(base string) string`
+		NameGenerator: names.SimpleNameGenerator,
+	}
+	_ rest.RESTCreateStrategy = serviceInstanceQuotaRESTStrategies
+	_ rest.RESTUpdateStrategy = serviceInstanceQuotaRESTStrategies
+	_ rest.RESTDeleteStrategy = serviceInstanceQuotaRESTStrategies
+
+	serviceInstanceQuotaStatusUpdateStrategy = serviceInstanceQuotaStatusRESTStrategy{
+		serviceInstanceQuotaRESTStrategies,
+	}
+	_ rest.RESTUpdateStrategy = serviceInstanceQuotaStatusUpdateStrategy
+)
+
+// Canonicalize does not transform a ServiceInstanceQuota.
+func (serviceInstanceQuotaRESTStrategy) Canonicalize(obj runtime.Object) {
+	_, ok := obj.(*sc.ServiceInstanceQuota)
+	if !ok {
+		glog.Fatal("received a non-ServiceInstanceQuota object to create")
+	}
+}
+
+// NamespaceScoped returns true as ServiceInstanceQuotas are scoped to a
+// namespace.
+func (serviceInstanceQuotaRESTStrategy) NamespaceScoped() bool {
+	return true
+}
+
+// PrepareForCreate receives the incoming ServiceInstanceQuota and clears its
+// Status. Status is not a user settable field.
+func (serviceInstanceQuotaRESTStrategy) PrepareForCreate(ctx genericapirequest.Context, obj runtime.Object) {
+	quota, ok := obj.(*sc.ServiceInstanceQuota)
+	if !ok {
+		glog.Fatal("received a non-ServiceInstanceQuota object to create")
+	}
+	quota.Status = sc.ServiceInstanceQuotaStatus{}
+}
+
+func (serviceInstanceQuotaRESTStrategy) Validate(ctx genericapirequest.Context, obj runtime.Object) field.ErrorList {
+	return scv.ValidateServiceInstanceQuota(obj.(*sc.ServiceInstanceQuota))
+}
+
+func (serviceInstanceQuotaRESTStrategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+func (serviceInstanceQuotaRESTStrategy) AllowUnconditionalUpdate() bool {
+	return false
+}
+
+func (serviceInstanceQuotaRESTStrategy) PrepareForUpdate(ctx genericapirequest.Context, new, old runtime.Object) {
+	newQuota, ok := new.(*sc.ServiceInstanceQuota)
+	if !ok {
+		glog.Fatal("received a non-ServiceInstanceQuota object to update to")
+	}
+	oldQuota, ok := old.(*sc.ServiceInstanceQuota)
+	if !ok {
+		glog.Fatal("received a non-ServiceInstanceQuota object to update from")
+	}
+
+	newQuota.Status = oldQuota.Status
+}
+
+func (serviceInstanceQuotaRESTStrategy) ValidateUpdate(ctx genericapirequest.Context, new, old runtime.Object) field.ErrorList {
+	newQuota, ok := new.(*sc.ServiceInstanceQuota)
+	if !ok {
+		glog.Fatal("received a non-ServiceInstanceQuota object to validate to")
+	}
+	oldQuota, ok := old.(*sc.ServiceInstanceQuota)
+	if !ok {
+		glog.Fatal("received a non-ServiceInstanceQuota object to validate from")
+	}
+
+	return scv.ValidateServiceInstanceQuotaUpdate(newQuota, oldQuota)
+}
+
+func (serviceInstanceQuotaStatusRESTStrategy) PrepareForUpdate(ctx genericapirequest.Context, new, old runtime.Object) {
+	newQuota, ok := new.(*sc.ServiceInstanceQuota)
+	if !ok {
+		glog.Fatal("received a non-ServiceInstanceQuota object to update to")
+	}
+	oldQuota, ok := old.(*sc.ServiceInstanceQuota)
+	if !ok {
+		glog.Fatal("received a non-ServiceInstanceQuota object to update from")
+	}
+	// status changes are not allowed to update spec
+	newQuota.Spec = oldQuota.Spec
+}
+
+func (serviceInstanceQuotaStatusRESTStrategy) ValidateUpdate(ctx genericapirequest.Context, new, old runtime.Object) field.ErrorList {
+	newQuota, ok := new.(*sc.ServiceInstanceQuota)
+	if !ok {
+		glog.Fatal("received a non-ServiceInstanceQuota object to validate to")
+	}
+	oldQuota, ok := old.(*sc.ServiceInstanceQuota)
+	if !ok {
+		glog.Fatal("received a non-ServiceInstanceQuota object to validate from")
+	}
+
+	return scv.ValidateServiceInstanceQuotaStatusUpdate(newQuota, oldQuota)
+}
@@ -0,0 +1,273 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package secrettemplate evaluates the templates of the TemplateKey secret
+// transforms of ServiceBindings.
+//
+// Templates use the text/template syntax and are executed against the
+// credentials of the binding, so that "{{.username}}" expands to the value
+// of the "username" credential. Only the text/template builtins and the
+// functions defined in this package are available to templates; none of them
+// give access to the environment, the filesystem or the network of the
+// controller.
+package secrettemplate
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// MaxOutputSize is the maximum size in bytes of the output of a template.
+const MaxOutputSize = 64 * 1024
+
+var funcMap = template.FuncMap{
+	"b64enc":       b64enc,
+	"b64dec":       b64dec,
+	"queryescape":  url.QueryEscape,
+	"pathescape":   url.PathEscape,
+	"upper":        strings.ToUpper,
+	"lower":        strings.ToLower,
+	"trim":         strings.TrimSpace,
+	"quote":        quote,
+	"toJson":       toJSON,
+	"toEnv":        toEnv,
+	"toProperties": toProperties,
+}
+
+// Parse parses the given template text, making the functions available to
+// secret templates visible to it. Referencing a credential that does not
+// exist is an error when the template is executed.
+//
+// Templates whose execution time is not bounded by the size of the
+// credentials are rejected: templates may not invoke other templates, and
+// may only range, without nesting, over the credentials or one of their
+// fields. As the credentials are decoded from JSON, such a range iterates
+// over a map or a slice of the credentials, or fails.
+func Parse(text string) (*template.Template, error) {
+	tmpl, err := template.New("template").Option("missingkey=error").Funcs(funcMap).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBounded(tmpl.Tree.Root, false); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// checkBounded returns an error if executing the given node may take longer
+// than iterating once over the credentials. inRange is whether the node is
+// within the body of a range.
+func checkBounded(node parse.Node, inRange bool) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := checkBounded(child, inRange); err != nil {
+				return err
+			}
+		}
+	case *parse.TemplateNode:
+		return fmt.Errorf("templates may not invoke other templates, found %s", n)
+	case *parse.IfNode:
+		return checkBoundedBranch(&n.BranchNode, inRange)
+	case *parse.WithNode:
+		return checkBoundedBranch(&n.BranchNode, inRange)
+	case *parse.RangeNode:
+		if inRange {
+			return fmt.Errorf("ranges may not be nested, found %s", n)
+		}
+		if !rangesOverCredentials(n.Pipe) {
+			return fmt.Errorf("ranges may only iterate over the credentials or one of their fields, found %s", n)
+		}
+		return checkBoundedBranch(&n.BranchNode, true)
+	}
+	return nil
+}
+
+func checkBoundedBranch(n *parse.BranchNode, inRange bool) error {
+	if err := checkBounded(n.List, inRange); err != nil {
+		return err
+	}
+	return checkBounded(n.ElseList, inRange)
+}
+
+// rangesOverCredentials returns whether the given range pipeline is either
+// dot or a field of dot, such as ".", ".hosts" or "$i, $host := .hosts".
+func rangesOverCredentials(pipe *parse.PipeNode) bool {
+	if len(pipe.Cmds) != 1 || len(pipe.Cmds[0].Args) != 1 {
+		return false
+	}
+	switch pipe.Cmds[0].Args[0].(type) {
+	case *parse.DotNode, *parse.FieldNode:
+		return true
+	}
+	return false
+}
+
+// Render parses the given template text and executes it against the given
+// credentials.
+func Render(text string, credentials map[string]interface{}) (string, error) {
+	tmpl, err := Parse(text)
+	if err != nil {
+		return "", err
+	}
+	data := make(map[string]interface{}, len(credentials))
+	for k, v := range credentials {
+		// Entries merged from other Secrets are raw bytes, expose them
+		// as strings so that they print as their content.
+		if b, ok := v.([]byte); ok {
+			v = string(b)
+		}
+		data[k] = v
+	}
+	w := &limitedWriter{limit: MaxOutputSize}
+	if err := tmpl.Execute(w, data); err != nil {
+		return "", err
+	}
+	return w.buf.String(), nil
+}
+
+// limitedWriter is a writer that fails once more than limit bytes have been
+// written to it.
+type limitedWriter struct {
+	buf   bytes.Buffer
+	limit int
+}
+
+func (w *limitedWriter) Write(p []byte) (int, error) {
+	if w.buf.Len()+len(p) > w.limit {
+		return 0, fmt.Errorf("template output exceeds the maximum size of %d bytes", w.limit)
+	}
+	return w.buf.Write(p)
+}
+
+func b64enc(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func b64dec(s string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func quote(v interface{}) (string, error) {
+	s, err := stringValue(v)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%q", s), nil
+}
+
+func toJSON(v interface{}) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// toEnv renders the entries of the given map as the lines of a .env file,
+// sorted by key.
+func toEnv(m map[string]interface{}) (string, error) {
+	return renderLines(m, func(k, v string) (string, error) {
+		if strings.ContainsAny(v, "\r\n") {
+			return "", fmt.Errorf("the value of %q cannot be written to an env file because it contains a line break", k)
+		}
+		return k + "=" + v, nil
+	})
+}
+
+// toProperties renders the entries of the given map as the lines of a Java
+// .properties file, sorted by key.
+func toProperties(m map[string]interface{}) (string, error) {
+	return renderLines(m, func(k, v string) (string, error) {
+		return escapeProperty(k, true) + "=" + escapeProperty(v, false), nil
+	})
+}
+
+func renderLines(m map[string]interface{}, line func(k, v string) (string, error)) (string, error) {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	for _, k := range keys {
+		v, err := stringValue(m[k])
+		if err != nil {
+			return "", err
+		}
+		l, err := line(k, v)
+		if err != nil {
+			return "", err
+		}
+		buf.WriteString(l)
+		buf.WriteByte('\n')
+	}
+	return buf.String(), nil
+}
+
+func escapeProperty(s string, key bool) string {
+	var buf bytes.Buffer
+	for i, r := range s {
+		switch r {
+		case '\\', '=', ':', '#', '!':
+			buf.WriteByte('\\')
+			buf.WriteRune(r)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		case ' ':
+			// Leading spaces are dropped and spaces end keys when
+			// the file is read.
+			if key || i == 0 {
+				buf.WriteByte('\\')
+			}
+			buf.WriteRune(r)
+		default:
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
+
+// stringValue returns the string stored in a credentials Secret for the
+// given value.
+func stringValue(v interface{}) (string, error) {
+	switch value := v.(type) {
+	case string:
+		return value, nil
+	case []byte:
+		return string(value), nil
+	}
+	return toJSON(v)
+}
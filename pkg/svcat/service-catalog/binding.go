@@ -23,6 +23,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -154,6 +155,31 @@ func (sdk *SDK) DeleteBinding(ns, bindingName string) error {
 	return nil
 }
 
+// RotateBinding increments the rotationRequests field on a binding to make
+// service catalog replace its credentials with new ones.
+func (sdk *SDK) RotateBinding(ns, name string, retries int) error {
+	for j := 0; j < retries; j++ {
+		binding, err := sdk.RetrieveBinding(ns, name)
+		if err != nil {
+			return err
+		}
+
+		binding.Spec.RotationRequests = binding.Spec.RotationRequests + 1
+
+		_, err = sdk.ServiceCatalog().ServiceBindings(ns).Update(binding)
+		if err == nil {
+			return nil
+		}
+		// if we didn't get a conflict, no idea what happened
+		if !errors.IsConflict(err) {
+			return fmt.Errorf("could not rotate binding (%s)", err)
+		}
+	}
+
+	// conflict after `retries` tries
+	return fmt.Errorf("could not rotate binding after %d tries", retries)
+}
+
 func joinErrors(groupMsg string, errors []error, sep string, a ...interface{}) string {
 	if len(errors) == 0 {
 		return ""
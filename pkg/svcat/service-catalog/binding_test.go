@@ -21,6 +21,7 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/testing"
@@ -278,4 +279,38 @@ var _ = Describe("Binding", func() {
 			Expect(badClient.Actions()[2].Matches("delete", "servicebindings")).To(BeTrue())
 		})
 	})
+
+	Describe("RotateBinding", func() {
+		It("Increments the rotationRequests field of the binding", func() {
+			Expect(sdk.RotateBinding(sb.Namespace, sb.Name, 3)).To(BeNil())
+
+			actions := svcCatClient.Actions()
+			Expect(len(actions)).To(Equal(2))
+			Expect(actions[0].Matches("get", "servicebindings")).To(BeTrue())
+			Expect(actions[1].Matches("update", "servicebindings")).To(BeTrue())
+
+			updated, ok := actions[1].(testing.UpdateActionImpl).Object.(*v1beta1.ServiceBinding)
+			Expect(ok).To(BeTrue())
+			Expect(updated.Name).To(Equal(sb.Name))
+			Expect(updated.Spec.RotationRequests).To(Equal(int64(1)))
+		})
+		It("Retries on conflicts", func() {
+			badClient := &fake.Clientset{}
+			badClient.AddReactor("get", "servicebindings", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, sb.DeepCopy(), nil
+			})
+			badClient.AddReactor("update", "servicebindings", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewConflict(v1beta1.Resource("servicebindings"), sb.Name, fmt.Errorf("conflict"))
+			})
+			sdk = &SDK{
+				ServiceCatalogClient: badClient,
+			}
+
+			err := sdk.RotateBinding(sb.Namespace, sb.Name, 2)
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("after 2 tries"))
+			Expect(len(badClient.Actions())).To(Equal(4))
+		})
+	})
 })
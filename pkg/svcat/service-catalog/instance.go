@@ -145,7 +145,34 @@ func (sdk *SDK) InstanceToServiceClassAndPlan(instance *v1beta1.ServiceInstance,
 func (sdk *SDK) Provision(namespace, instanceName, externalID, className, planName string,
 	params interface{}, secrets map[string]string) (*v1beta1.ServiceInstance, error) {
 
-	request := &v1beta1.ServiceInstance{
+	request := newInstance(namespace, instanceName, externalID, className, planName, params, secrets)
+
+	result, err := sdk.ServiceCatalog().ServiceInstances(namespace).Create(request)
+	if err != nil {
+		return nil, fmt.Errorf("provision request failed (%s)", err)
+	}
+	return result, nil
+}
+
+// Adopt creates an instance of a service class and plan that adopts the
+// instance already provisioned at the broker under externalID.
+func (sdk *SDK) Adopt(namespace, instanceName, externalID, className, planName string,
+	params interface{}, secrets map[string]string, verify bool) (*v1beta1.ServiceInstance, error) {
+
+	request := newInstance(namespace, instanceName, externalID, className, planName, params, secrets)
+	request.Spec.Adoption = &v1beta1.ServiceInstanceAdoption{Verify: &verify}
+
+	result, err := sdk.ServiceCatalog().ServiceInstances(namespace).Create(request)
+	if err != nil {
+		return nil, fmt.Errorf("adopt request failed (%s)", err)
+	}
+	return result, nil
+}
+
+func newInstance(namespace, instanceName, externalID, className, planName string,
+	params interface{}, secrets map[string]string) *v1beta1.ServiceInstance {
+
+	return &v1beta1.ServiceInstance{
 		ObjectMeta: v1.ObjectMeta{
 			Name:      instanceName,
 			Namespace: namespace,
@@ -160,12 +187,6 @@ func (sdk *SDK) Provision(namespace, instanceName, externalID, className, planNa
 			ParametersFrom: BuildParametersFrom(secrets),
 		},
 	}
-
-	result, err := sdk.ServiceCatalog().ServiceInstances(namespace).Create(request)
-	if err != nil {
-		return nil, fmt.Errorf("provision request failed (%s)", err)
-	}
-	return result, nil
 }
 
 // Deprovision deletes an instance.
@@ -201,3 +222,40 @@ func (sdk *SDK) TouchInstance(ns, name string, retries int) error {
 	// conflict after `retries` tries
 	return fmt.Errorf("could not sync service broker after %d tries", retries)
 }
+
+// UpgradeInstance sets the maintenanceInfoVersion field of an instance to the
+// version of the maintenance information of its plan, to make service catalog
+// upgrade the instance to that version.
+func (sdk *SDK) UpgradeInstance(ns, name string, retries int) (*v1beta1.ServiceInstance, error) {
+	for j := 0; j < retries; j++ {
+		inst, err := sdk.RetrieveInstance(ns, name)
+		if err != nil {
+			return nil, err
+		}
+		if inst.Spec.ClusterServicePlanRef == nil {
+			return nil, fmt.Errorf("the plan of instance '%s.%s' has not been resolved yet", ns, name)
+		}
+
+		plan, err := sdk.RetrievePlanByID(inst.Spec.ClusterServicePlanRef.Name)
+		if err != nil {
+			return nil, err
+		}
+		if plan.Spec.MaintenanceInfo == nil {
+			return nil, fmt.Errorf("plan '%s' has no maintenance information to upgrade to", plan.Spec.ExternalName)
+		}
+
+		inst.Spec.MaintenanceInfoVersion = plan.Spec.MaintenanceInfo.Version
+
+		updated, err := sdk.ServiceCatalog().ServiceInstances(ns).Update(inst)
+		if err == nil {
+			return updated, nil
+		}
+		// if we didn't get a conflict, no idea what happened
+		if !errors.IsConflict(err) {
+			return nil, fmt.Errorf("could not upgrade instance (%s)", err)
+		}
+	}
+
+	// conflict after `retries` tries
+	return nil, fmt.Errorf("could not upgrade instance after %d tries", retries)
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package servicecatalog_test
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
@@ -246,6 +247,51 @@ var _ = Describe("Instances", func() {
 			Expect(obj.Spec.UpdateRequests).To(Equal(int64(1)))
 		})
 	})
+	Describe("UpgradeInstance", func() {
+		It("Sets the maintenance info version to the version of the plan", func() {
+			plan := &v1beta1.ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar_plan"},
+				Spec: v1beta1.ClusterServicePlanSpec{
+					CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{
+						ExternalName:    "foobar",
+						MaintenanceInfo: &v1beta1.MaintenanceInfo{Version: "2.0.0"},
+					},
+				},
+			}
+			si.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: plan.Name}
+			svcCatClient = fake.NewSimpleClientset(si, plan)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			upgraded, err := sdk.UpgradeInstance(si.Namespace, si.Name, 3)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(upgraded.Spec.MaintenanceInfoVersion).To(Equal("2.0.0"))
+			actions := svcCatClient.Actions()
+			Expect(len(actions)).To(Equal(3))
+			Expect(actions[0].Matches("get", "serviceinstances")).To(BeTrue())
+			Expect(actions[1].Matches("get", "clusterserviceplans")).To(BeTrue())
+			Expect(actions[2].Matches("update", "serviceinstances")).To(BeTrue())
+		})
+		It("Bubbles up errors when the plan has no maintenance info", func() {
+			plan := &v1beta1.ClusterServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "foobar_plan"},
+				Spec: v1beta1.ClusterServicePlanSpec{
+					CommonServicePlanSpec: v1beta1.CommonServicePlanSpec{ExternalName: "foobar"},
+				},
+			}
+			si.Spec.ClusterServicePlanRef = &v1beta1.ClusterObjectReference{Name: plan.Name}
+			svcCatClient = fake.NewSimpleClientset(si, plan)
+			sdk.ServiceCatalogClient = svcCatClient
+
+			upgraded, err := sdk.UpgradeInstance(si.Namespace, si.Name, 3)
+
+			Expect(upgraded).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("has no maintenance information"))
+			actions := svcCatClient.Actions()
+			Expect(len(actions)).To(Equal(2))
+		})
+	})
 	Describe("InstanceParentHierarchy", func() {
 		It("calls the v1beta1 generated Get function repeatedly to build the heirarchy of the passed in service isntance", func() {
 			broker := &v1beta1.ClusterServiceBroker{ObjectMeta: metav1.ObjectMeta{Name: "foobar_broker"}}
@@ -515,6 +561,43 @@ var _ = Describe("Instances", func() {
 			Expect(err.Error()).To(ContainSubstring(errorMessage))
 		})
 	})
+	Describe("Adopt", func() {
+		It("Calls the v1beta1 Create method with an adoption", func() {
+			namespace := "cherry_namespace"
+			instanceName := "cherry"
+			externalID := "cherry-external-id"
+			className := "cherry_class"
+			planName := "cherry_plan"
+
+			service, err := sdk.Adopt(namespace, instanceName, externalID, className, planName, nil, nil, true)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(service.Name).To(Equal(instanceName))
+			Expect(service.Spec.ExternalID).To(Equal(externalID))
+
+			actions := svcCatClient.Actions()
+			Expect(actions[0].Matches("create", "serviceinstances")).To(BeTrue())
+			objectFromRequest := actions[0].(testing.CreateActionImpl).Object.(*v1beta1.ServiceInstance)
+			Expect(objectFromRequest.Spec.ExternalID).To(Equal(externalID))
+			Expect(objectFromRequest.Spec.PlanReference.ClusterServiceClassExternalName).To(Equal(className))
+			Expect(objectFromRequest.Spec.PlanReference.ClusterServicePlanExternalName).To(Equal(planName))
+			verify := true
+			Expect(objectFromRequest.Spec.Adoption).To(Equal(&v1beta1.ServiceInstanceAdoption{Verify: &verify}))
+		})
+		It("Bubbles up errors", func() {
+			errorMessage := "error creating instance"
+			badClient := &fake.Clientset{}
+			badClient.AddReactor("create", "serviceinstances", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, errors.New(errorMessage)
+			})
+			sdk.ServiceCatalogClient = badClient
+
+			service, err := sdk.Adopt("cherry_namespace", "cherry", "cherry-external-id", "cherry_class", "cherry_plan", nil, nil, false)
+			Expect(service).To(BeNil())
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(errorMessage))
+		})
+	})
 	Describe("Deprovision", func() {
 		It("Calls the v1beta1 Delete method wiht the passed in service instance name", func() {
 			err := sdk.Deprovision(si.Namespace, si.Name)
@@ -0,0 +1,101 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog
+
+import (
+	"fmt"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Visibility holds the visibility policies that apply to a namespace.
+type Visibility struct {
+	policies        []*v1beta1.ClusterServiceVisibilityPolicy
+	namespaceLabels labels.Set
+}
+
+// RetrieveVisibility gets the visibility policies defined in the cluster and
+// the labels of the given namespace that they are evaluated against.
+func (sdk *SDK) RetrieveVisibility(namespace string) (*Visibility, error) {
+	policies, err := sdk.ServiceCatalog().ClusterServiceVisibilityPolicies().List(v1.ListOptions{})
+	if errors.IsNotFound(err) {
+		// The server does not serve visibility policies, so everything is visible.
+		return &Visibility{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to list visibility policies (%s)", err)
+	}
+
+	visibility := &Visibility{}
+	for i := range policies.Items {
+		visibility.policies = append(visibility.policies, &policies.Items[i])
+	}
+	if len(visibility.policies) == 0 {
+		return visibility, nil
+	}
+
+	ns, err := sdk.Core().Namespaces().Get(namespace, v1.GetOptions{})
+	if errors.IsForbidden(err) {
+		// Users that may not read the namespace see the classes and plans
+		// visible in a namespace without labels.
+		return visibility, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get namespace '%s' (%s)", namespace, err)
+	}
+	visibility.namespaceLabels = labels.Set(ns.Labels)
+	return visibility, nil
+}
+
+// VisibleClasses filters the given classes down to the ones that are visible
+// in the namespace.
+func (v *Visibility) VisibleClasses(classes []v1beta1.ClusterServiceClass) []v1beta1.ClusterServiceClass {
+	if len(v.policies) == 0 {
+		return classes
+	}
+	visible := make([]v1beta1.ClusterServiceClass, 0, len(classes))
+	for _, class := range classes {
+		if v1beta1.ServiceVisible(v.policies, class.Spec.ExternalName, "", v.namespaceLabels) {
+			visible = append(visible, class)
+		}
+	}
+	return visible
+}
+
+// VisiblePlans filters the given plans down to the ones that are visible in
+// the namespace. The classes of the plans are needed to evaluate the policies,
+// which reference classes by external name.
+func (v *Visibility) VisiblePlans(plans []v1beta1.ClusterServicePlan, classes []v1beta1.ClusterServiceClass) []v1beta1.ClusterServicePlan {
+	if len(v.policies) == 0 {
+		return plans
+	}
+	classNames := make(map[string]string, len(classes))
+	for _, class := range classes {
+		classNames[class.Name] = class.Spec.ExternalName
+	}
+	visible := make([]v1beta1.ClusterServicePlan, 0, len(plans))
+	for _, plan := range plans {
+		className := classNames[plan.Spec.ClusterServiceClassRef.Name]
+		if v1beta1.ServiceVisible(v.policies, className, plan.Spec.ExternalName, v.namespaceLabels) {
+			visible = append(visible, plan)
+		}
+	}
+	return visible
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package servicecatalog_test
+
+import (
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/fake"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/testing"
+
+	. "github.com/kubernetes-incubator/service-catalog/pkg/svcat/service-catalog"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Visibility", func() {
+	var (
+		sdk          *SDK
+		k8sClient    *k8sfake.Clientset
+		svcCatClient *fake.Clientset
+		sc           v1beta1.ClusterServiceClass
+		sc2          v1beta1.ClusterServiceClass
+		sp           v1beta1.ClusterServicePlan
+		sp2          v1beta1.ClusterServicePlan
+		policy       *v1beta1.ClusterServiceVisibilityPolicy
+	)
+
+	BeforeEach(func() {
+		sc = v1beta1.ClusterServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "foobar"}}
+		sc.Spec.ExternalName = "foobar"
+		sc2 = v1beta1.ClusterServiceClass{ObjectMeta: metav1.ObjectMeta{Name: "barbaz"}}
+		sc2.Spec.ExternalName = "barbaz"
+		sp = v1beta1.ClusterServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "foobar-small"}}
+		sp.Spec.ExternalName = "small"
+		sp.Spec.ClusterServiceClassRef = v1beta1.ClusterObjectReference{Name: "foobar"}
+		sp2 = v1beta1.ClusterServicePlan{ObjectMeta: metav1.ObjectMeta{Name: "barbaz-small"}}
+		sp2.Spec.ExternalName = "small"
+		sp2.Spec.ClusterServiceClassRef = v1beta1.ClusterObjectReference{Name: "barbaz"}
+		policy = &v1beta1.ClusterServiceVisibilityPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: "foobar-prod"},
+			Spec: v1beta1.ClusterServiceVisibilityPolicySpec{
+				ClusterServiceClassExternalName: "foobar",
+				NamespaceSelector: metav1.LabelSelector{
+					MatchLabels: map[string]string{"env": "prod"},
+				},
+			},
+		}
+		k8sClient = k8sfake.NewSimpleClientset(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "prod", Labels: map[string]string{"env": "prod"}}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "dev", Labels: map[string]string{"env": "dev"}}},
+		)
+		svcCatClient = fake.NewSimpleClientset(policy)
+		sdk = &SDK{
+			K8sClient:            k8sClient,
+			ServiceCatalogClient: svcCatClient,
+		}
+	})
+
+	Describe("RetrieveVisibility", func() {
+		It("Filters out the classes and plans that are not visible in the namespace", func() {
+			visibility, err := sdk.RetrieveVisibility("dev")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(visibility.VisibleClasses([]v1beta1.ClusterServiceClass{sc, sc2})).Should(ConsistOf(sc2))
+			Expect(visibility.VisiblePlans([]v1beta1.ClusterServicePlan{sp, sp2}, []v1beta1.ClusterServiceClass{sc, sc2})).Should(ConsistOf(sp2))
+			Expect(svcCatClient.Actions()[0].Matches("list", "clusterservicevisibilitypolicies")).To(BeTrue())
+			Expect(k8sClient.Actions()[0].Matches("get", "namespaces")).To(BeTrue())
+		})
+		It("Keeps the classes and plans that are visible in the namespace", func() {
+			visibility, err := sdk.RetrieveVisibility("prod")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(visibility.VisibleClasses([]v1beta1.ClusterServiceClass{sc, sc2})).Should(ConsistOf(sc, sc2))
+			Expect(visibility.VisiblePlans([]v1beta1.ClusterServicePlan{sp, sp2}, []v1beta1.ClusterServiceClass{sc, sc2})).Should(ConsistOf(sp, sp2))
+		})
+		It("Does not get the namespace when there are no policies", func() {
+			svcCatClient = fake.NewSimpleClientset()
+			sdk.ServiceCatalogClient = svcCatClient
+
+			visibility, err := sdk.RetrieveVisibility("dev")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(visibility.VisibleClasses([]v1beta1.ClusterServiceClass{sc, sc2})).Should(ConsistOf(sc, sc2))
+			Expect(k8sClient.Actions()).To(BeEmpty())
+		})
+		It("Treats a server without visibility policies as having no policies", func() {
+			badClient := &fake.Clientset{}
+			badClient.AddReactor("list", "clusterservicevisibilitypolicies", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewNotFound(schema.GroupResource{Group: "servicecatalog.k8s.io", Resource: "clusterservicevisibilitypolicies"}, "")
+			})
+			sdk.ServiceCatalogClient = badClient
+
+			visibility, err := sdk.RetrieveVisibility("dev")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(visibility.VisibleClasses([]v1beta1.ClusterServiceClass{sc, sc2})).Should(ConsistOf(sc, sc2))
+		})
+		It("Evaluates the policies against no labels when the namespace may not be read", func() {
+			k8sClient.PrependReactor("get", "namespaces", func(action testing.Action) (bool, runtime.Object, error) {
+				return true, nil, apierrors.NewForbidden(schema.GroupResource{Resource: "namespaces"}, "prod", nil)
+			})
+
+			visibility, err := sdk.RetrieveVisibility("prod")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(visibility.VisibleClasses([]v1beta1.ClusterServiceClass{sc, sc2})).Should(ConsistOf(sc2))
+		})
+		It("Bubbles up errors getting the namespace", func() {
+			_, err := sdk.RetrieveVisibility("missing")
+
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).Should(ContainSubstring("missing"))
+		})
+	})
+})
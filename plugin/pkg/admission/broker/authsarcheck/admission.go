@@ -33,12 +33,13 @@ import (
 )
 
 const (
-	pluginName = "BrokerAuthSarCheck"
+	// PluginName is name of admission plug-in
+	PluginName = "BrokerAuthSarCheck"
 )
 
 // Register registers a plugin
 func Register(plugins *admission.Plugins) {
-	plugins.Register(pluginName, func(io.Reader) (admission.Interface, error) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
 		return NewSARCheck()
 	})
 }
@@ -52,19 +53,6 @@ type sarcheck struct {
 
 var _ = scadmission.WantsKubeClientSet(&sarcheck{})
 
-func convertToSARExtra(extra map[string][]string) map[string]authorizationapi.ExtraValue {
-	if extra == nil {
-		return nil
-	}
-
-	ret := map[string]authorizationapi.ExtraValue{}
-	for k, v := range extra {
-		ret[k] = authorizationapi.ExtraValue(v)
-	}
-
-	return ret
-}
-
 func (s *sarcheck) Admit(a admission.Attributes) error {
 	// need to wait for our caches to warm
 	if !s.WaitForReady() {
@@ -89,6 +77,10 @@ func (s *sarcheck) Admit(a admission.Attributes) error {
 		secretRef = clusterServiceBroker.Spec.AuthInfo.Basic.SecretRef
 	} else if clusterServiceBroker.Spec.AuthInfo.Bearer != nil {
 		secretRef = clusterServiceBroker.Spec.AuthInfo.Bearer.SecretRef
+	} else if clusterServiceBroker.Spec.AuthInfo.TLS != nil {
+		secretRef = clusterServiceBroker.Spec.AuthInfo.TLS.SecretRef
+	} else if clusterServiceBroker.Spec.AuthInfo.OAuth2 != nil {
+		secretRef = clusterServiceBroker.Spec.AuthInfo.OAuth2.SecretRef
 	}
 
 	if secretRef == nil {
@@ -109,7 +101,7 @@ func (s *sarcheck) Admit(a admission.Attributes) error {
 			},
 			User:   userInfo.GetName(),
 			Groups: userInfo.GetGroups(),
-			Extra:  convertToSARExtra(userInfo.GetExtra()),
+			Extra:  scadmission.ConvertToSARExtra(userInfo.GetExtra()),
 			UID:    userInfo.GetUID(),
 		},
 	}
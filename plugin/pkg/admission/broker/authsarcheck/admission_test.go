@@ -182,6 +182,116 @@ func TestAdmissionBroker(t *testing.T) {
 			},
 			allowed: false,
 		},
+		{
+			name: "broker with client certificate, user authenticated",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						TLS: &servicecatalog.ClusterTLSAuthConfig{
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: "Manual",
+					},
+				},
+			},
+			userInfo: &user.DefaultInfo{
+				Name:   "system:serviceaccount:test-ns:catalog",
+				Groups: []string{"system:serviceaccount", "system:serviceaccounts:test-ns"},
+			},
+			allowed: true,
+		},
+		{
+			name: "broker with client certificate, unauthenticated user",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						TLS: &servicecatalog.ClusterTLSAuthConfig{
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: "Manual",
+					},
+				},
+			},
+			userInfo: &user.DefaultInfo{
+				Name:   "system:serviceaccount:test-ns:forbidden",
+				Groups: []string{"system:serviceaccount", "system:serviceaccounts:test-ns"},
+			},
+			allowed: false,
+		},
+		{
+			name: "broker with oauth2 client credentials, user authenticated",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.ClusterOAuth2AuthConfig{
+							TokenURL: "https://auth.example.com/token",
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: "Manual",
+					},
+				},
+			},
+			userInfo: &user.DefaultInfo{
+				Name:   "system:serviceaccount:test-ns:catalog",
+				Groups: []string{"system:serviceaccount", "system:serviceaccounts:test-ns"},
+			},
+			allowed: true,
+		},
+		{
+			name: "broker with oauth2 client credentials, unauthenticated user",
+			broker: &servicecatalog.ClusterServiceBroker{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-broker",
+				},
+				Spec: servicecatalog.ClusterServiceBrokerSpec{
+					AuthInfo: &servicecatalog.ClusterServiceBrokerAuthInfo{
+						OAuth2: &servicecatalog.ClusterOAuth2AuthConfig{
+							TokenURL: "https://auth.example.com/token",
+							SecretRef: &servicecatalog.ObjectReference{
+								Namespace: "test-ns",
+								Name:      "test-secret",
+							},
+						},
+					},
+					CommonServiceBrokerSpec: servicecatalog.CommonServiceBrokerSpec{
+						URL:            "http://example.com",
+						RelistBehavior: "Manual",
+					},
+				},
+			},
+			userInfo: &user.DefaultInfo{
+				Name:   "system:serviceaccount:test-ns:forbidden",
+				Groups: []string{"system:serviceaccount", "system:serviceaccounts:test-ns"},
+			},
+			allowed: false,
+		},
 		{
 			name: "broker with empty authInfo",
 			broker: &servicecatalog.ClusterServiceBroker{
@@ -0,0 +1,304 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultparameters
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/golang/glog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubeclientset "k8s.io/client-go/kubernetes"
+
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	internalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/internalversion"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	"github.com/kubernetes-incubator/service-catalog/pkg/controller"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/jsonschema"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/parameters/planresolver"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "DefaultParameters"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewDefaultParameters()
+	})
+}
+
+// defaultParameters is an implementation of admission.Interface.
+// It sets the top-level parameters that a ServiceInstance is created without
+// to their default value, and records their names in the
+// DefaultedParametersAnnotation of the instance.
+//
+// The default values are taken, from lowest to highest precedence, from the
+// default keywords of the create schema of the plan, from the
+// ClusterParameterDefaults of the class of the plan, and from the
+// ClusterParameterDefaults of the plan. When several ClusterParameterDefaults
+// apply at the same level, the one whose name sorts last wins.
+//
+// Parameters set through ParametersFrom are never defaulted. If one of the
+// Secrets of ParametersFrom cannot be read, or a ServiceBinding Secret key
+// holding a JSON object is referenced, the instance is not defaulted.
+type defaultParameters struct {
+	*admission.Handler
+	client kubeclientset.Interface
+
+	// plans only resolves the plans of namespaced brokers when the
+	// NamespacedServiceBroker feature is enabled.
+	plans planresolver.Resolver
+
+	// parameterDefaultLister is only set when the DefaultParameters feature
+	// is enabled.
+	parameterDefaultLister internalversion.ClusterParameterDefaultLister
+}
+
+var _ = scadmission.WantsInternalServiceCatalogInformerFactory(&defaultParameters{})
+var _ = scadmission.WantsKubeClientSet(&defaultParameters{})
+
+func (d *defaultParameters) Admit(a admission.Attributes) error {
+	// we need to wait for our caches to warm
+	if !d.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+
+	// We only care about service Instances
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetResource().GroupResource() != servicecatalog.Resource("serviceinstances") || a.GetSubresource() != "" {
+		return nil
+	}
+	instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+	}
+
+	defaults, err := d.getDefaults(instance)
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	if len(defaults) == 0 {
+		return nil
+	}
+
+	params := map[string]interface{}{}
+	if instance.Spec.Parameters != nil {
+		p, err := controller.UnmarshalRawParameters(instance.Spec.Parameters.Raw)
+		if err != nil {
+			// Reported by the validation of the resource.
+			return nil
+		}
+		params = p
+	}
+
+	// The parameters set by the Secrets must not be defaulted, or the
+	// instance would hold them twice.
+	set := map[string]bool{}
+	for _, from := range instance.Spec.ParametersFrom {
+		if ref := from.ServiceInstanceFieldRef; ref != nil {
+			set[ref.Parameter] = true
+			continue
+		}
+		if ref := from.ServiceBindingSecretKeyRef; ref != nil {
+			if ref.Parameter == "" {
+				glog.V(4).Infof(`ServiceInstance %s/%s: not defaulting parameters, the parameters of ServiceBinding "%s/%s" are not known yet`, instance.Namespace, instance.Name, instance.Namespace, ref.Name)
+				return nil
+			}
+			set[ref.Parameter] = true
+			continue
+		}
+		if from.SecretKeyRef == nil {
+			continue
+		}
+		fromParams, err := planresolver.SecretKeyParameters(d.client, instance.Namespace, from.SecretKeyRef)
+		if err != nil {
+			glog.V(4).Infof(`ServiceInstance %s/%s: not defaulting parameters, the parameters of Secret "%s/%s" cannot be read: %v`, instance.Namespace, instance.Name, instance.Namespace, from.SecretKeyRef.Name, err)
+			return nil
+		}
+		for k := range fromParams {
+			set[k] = true
+		}
+	}
+
+	var defaulted []string
+	for name, value := range defaults {
+		if _, ok := params[name]; ok || set[name] {
+			continue
+		}
+		params[name] = value
+		defaulted = append(defaulted, name)
+	}
+	if len(defaulted) == 0 {
+		return nil
+	}
+	sort.Strings(defaulted)
+
+	raw, err := json.Marshal(params)
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	instance.Spec.Parameters = &runtime.RawExtension{Raw: raw}
+	if instance.Annotations == nil {
+		instance.Annotations = map[string]string{}
+	}
+	instance.Annotations[servicecatalog.DefaultedParametersAnnotation] = strings.Join(defaulted, ",")
+	glog.V(4).Infof("ServiceInstance %s/%s: defaulted parameters %v", instance.Namespace, instance.Name, defaulted)
+	return nil
+}
+
+// getDefaults returns the default value of each top-level parameter of the
+// given instance.
+func (d *defaultParameters) getDefaults(instance *servicecatalog.ServiceInstance) (map[string]interface{}, error) {
+	defaults := map[string]interface{}{}
+
+	plan, err := d.plans.ServicePlanSpec(instance)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, nil
+	}
+	if schema := plan.ServiceInstanceCreateParameterSchema; schema != nil && len(schema.Raw) > 0 {
+		s, err := jsonschema.Parse(schema.Raw)
+		if err != nil {
+			glog.V(4).Infof("Not defaulting parameters from a schema that cannot be used: %v", err)
+		} else {
+			for name, value := range s.Defaults() {
+				defaults[name] = value
+			}
+		}
+	}
+
+	if d.parameterDefaultLister == nil || !instance.Spec.ClusterServicePlanSpecified() {
+		return defaults, nil
+	}
+	clusterPlan, err := d.plans.ClusterServicePlan(&instance.Spec.PlanReference)
+	if err != nil || clusterPlan == nil {
+		return defaults, err
+	}
+	class, err := d.plans.ClusterServiceClassLister.Get(clusterPlan.Spec.ClusterServiceClassRef.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return defaults, nil
+		}
+		return nil, err
+	}
+
+	parameterDefaults, err := d.parameterDefaultLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(parameterDefaults, func(i, j int) bool {
+		return parameterDefaults[i].Name < parameterDefaults[j].Name
+	})
+	var classDefaults, planDefaults []*servicecatalog.ClusterParameterDefault
+	for _, parameterDefault := range parameterDefaults {
+		if parameterDefault.Spec.ClusterServiceClassExternalName != class.Spec.ExternalName {
+			continue
+		}
+		switch parameterDefault.Spec.ClusterServicePlanExternalName {
+		case "":
+			classDefaults = append(classDefaults, parameterDefault)
+		case clusterPlan.Spec.ExternalName:
+			planDefaults = append(planDefaults, parameterDefault)
+		}
+	}
+	for _, parameterDefault := range append(classDefaults, planDefaults...) {
+		params, err := controller.UnmarshalRawParameters(parameterDefault.Spec.Parameters.Raw)
+		if err != nil {
+			glog.V(4).Infof("Not defaulting parameters from ClusterParameterDefault %q: %v", parameterDefault.Name, err)
+			continue
+		}
+		for name, value := range params {
+			defaults[name] = value
+		}
+	}
+	return defaults, nil
+}
+
+// NewDefaultParameters creates a new admission control handler that sets
+// default parameters on new instances.
+func NewDefaultParameters() (admission.Interface, error) {
+	return &defaultParameters{
+		Handler: admission.NewHandler(admission.Create),
+	}, nil
+}
+
+func (d *defaultParameters) SetInternalServiceCatalogInformerFactory(f informers.SharedInformerFactory) {
+	clusterServiceClassInformer := f.Servicecatalog().InternalVersion().ClusterServiceClasses()
+	clusterServicePlanInformer := f.Servicecatalog().InternalVersion().ClusterServicePlans()
+	d.plans.ClusterServiceClassLister = clusterServiceClassInformer.Lister()
+	d.plans.ClusterServicePlanLister = clusterServicePlanInformer.Lister()
+
+	readyFunc := func() bool {
+		return clusterServiceClassInformer.Informer().HasSynced() &&
+			clusterServicePlanInformer.Informer().HasSynced()
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
+		serviceClassInformer := f.Servicecatalog().InternalVersion().ServiceClasses()
+		servicePlanInformer := f.Servicecatalog().InternalVersion().ServicePlans()
+		d.plans.ServiceClassLister = serviceClassInformer.Lister()
+		d.plans.ServicePlanLister = servicePlanInformer.Lister()
+		clusterReadyFunc := readyFunc
+		readyFunc = func() bool {
+			return clusterReadyFunc() && serviceClassInformer.Informer().HasSynced() && servicePlanInformer.Informer().HasSynced()
+		}
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.DefaultParameters) {
+		parameterDefaultInformer := f.Servicecatalog().InternalVersion().ClusterParameterDefaults()
+		d.parameterDefaultLister = parameterDefaultInformer.Lister()
+		plansReadyFunc := readyFunc
+		readyFunc = func() bool {
+			return plansReadyFunc() && parameterDefaultInformer.Informer().HasSynced()
+		}
+	}
+
+	d.SetReadyFunc(readyFunc)
+}
+
+func (d *defaultParameters) SetKubeClientSet(client kubeclientset.Interface) {
+	d.client = client
+}
+
+func (d *defaultParameters) ValidateInitialization() error {
+	if d.client == nil {
+		return errors.New("missing client")
+	}
+	if d.plans.ClusterServiceClassLister == nil {
+		return errors.New("missing cluster service class lister")
+	}
+	if d.plans.ClusterServicePlanLister == nil {
+		return errors.New("missing cluster service plan lister")
+	}
+	return nil
+}
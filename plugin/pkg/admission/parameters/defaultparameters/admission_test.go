@@ -0,0 +1,237 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package defaultparameters
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+)
+
+const (
+	testNamespace = "test-ns"
+
+	createSchema = `{
+		"type": "object",
+		"properties": {
+			"region": {"type": "string", "default": "us"},
+			"size": {"type": "integer", "default": 1},
+			"name": {"type": "string"}
+		}
+	}`
+)
+
+// newHandlerForTest returns a configured handler for testing, with caches
+// holding the test class and plan, and the given objects.
+func newHandlerForTest(t *testing.T, objects []runtime.Object, secrets ...runtime.Object) admission.MutationInterface {
+	class := &servicecatalog.ClusterServiceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "class-id"},
+		Spec: servicecatalog.ClusterServiceClassSpec{
+			CommonServiceClassSpec: servicecatalog.CommonServiceClassSpec{
+				ExternalName: "class",
+				ExternalID:   "class-id",
+			},
+		},
+	}
+	plan := &servicecatalog.ClusterServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "plan-id"},
+		Spec: servicecatalog.ClusterServicePlanSpec{
+			CommonServicePlanSpec: servicecatalog.CommonServicePlanSpec{
+				ExternalName:                         "plan",
+				ExternalID:                           "plan-id",
+				ServiceInstanceCreateParameterSchema: &runtime.RawExtension{Raw: []byte(createSchema)},
+			},
+			ClusterServiceClassRef: servicecatalog.ClusterObjectReference{Name: "class-id"},
+		},
+	}
+
+	internalClient := fake.NewSimpleClientset(append([]runtime.Object{class, plan}, objects...)...)
+	f := informers.NewSharedInformerFactory(internalClient, 5*time.Minute)
+	handler, err := NewDefaultParameters()
+	if err != nil {
+		t.Fatalf("unexpected error creating handler: %v", err)
+	}
+	pluginInitializer := scadmission.NewPluginInitializer(internalClient, f, kubefake.NewSimpleClientset(secrets...), nil)
+	pluginInitializer.Initialize(handler)
+	if err := admission.ValidateInitialization(handler); err != nil {
+		t.Fatalf("unexpected error initializing handler: %v", err)
+	}
+	f.Start(wait.NeverStop)
+	f.WaitForCacheSync(wait.NeverStop)
+	return handler.(admission.MutationInterface)
+}
+
+// newServiceInstance returns a new instance of the test plan with the given
+// parameters.
+func newServiceInstance(parameters string) *servicecatalog.ServiceInstance {
+	instance := &servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: testNamespace},
+		Spec: servicecatalog.ServiceInstanceSpec{
+			PlanReference: servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: "class",
+				ClusterServicePlanExternalName:  "plan",
+			},
+		},
+	}
+	if parameters != "" {
+		instance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(parameters)}
+	}
+	return instance
+}
+
+func newClusterParameterDefault(name, plan, parameters string) *servicecatalog.ClusterParameterDefault {
+	return &servicecatalog.ClusterParameterDefault{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: servicecatalog.ClusterParameterDefaultSpec{
+			ClusterServiceClassExternalName: "class",
+			ClusterServicePlanExternalName:  plan,
+			Parameters:                      &runtime.RawExtension{Raw: []byte(parameters)},
+		},
+	}
+}
+
+func TestDefaultParameters(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.DefaultParameters))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.DefaultParameters))
+
+	cases := []struct {
+		name               string
+		instance           *servicecatalog.ServiceInstance
+		parameterDefaults  []runtime.Object
+		secrets            []runtime.Object
+		expectedParameters string
+		expectedDefaulted  string
+	}{
+		{
+			name:               "schema defaults",
+			instance:           newServiceInstance(`{"name": "db"}`),
+			expectedParameters: `{"name": "db", "region": "us", "size": 1}`,
+			expectedDefaulted:  "region,size",
+		},
+		{
+			name:               "parameters already set",
+			instance:           newServiceInstance(`{"region": "ap", "size": 3}`),
+			expectedParameters: `{"region": "ap", "size": 3}`,
+		},
+		{
+			name:     "cluster parameter defaults",
+			instance: newServiceInstance(""),
+			parameterDefaults: []runtime.Object{
+				newClusterParameterDefault("a-plan", "plan", `{"size": 5}`),
+				newClusterParameterDefault("b-class", "", `{"region": "eu", "size": 2, "backupWindow": "02:00"}`),
+				newClusterParameterDefault("c-other-plan", "other", `{"region": "ap"}`),
+			},
+			expectedParameters: `{"backupWindow": "02:00", "region": "eu", "size": 5}`,
+			expectedDefaulted:  "backupWindow,region,size",
+		},
+		{
+			name:               "parameters from a secret",
+			instance:           withParametersFrom(newServiceInstance(""), "params"),
+			secrets:            []runtime.Object{newSecret("params", `{"region": "ap"}`)},
+			expectedParameters: `{"size": 1}`,
+			expectedDefaulted:  "size",
+		},
+		{
+			name:     "unreadable secret",
+			instance: withParametersFrom(newServiceInstance(""), "missing"),
+		},
+		{
+			name: "parameters from an instance",
+			instance: withParametersFromSource(newServiceInstance(""), servicecatalog.ParametersFromSource{
+				ServiceInstanceFieldRef: &servicecatalog.ServiceInstanceFieldReference{
+					Name:      "database",
+					FieldPath: servicecatalog.ServiceInstanceFieldPathExternalID,
+					Parameter: "region",
+				},
+			}),
+			expectedParameters: `{"size": 1}`,
+			expectedDefaulted:  "size",
+		},
+		{
+			name: "parameters from a binding",
+			instance: withParametersFromSource(newServiceInstance(""), servicecatalog.ParametersFromSource{
+				ServiceBindingSecretKeyRef: &servicecatalog.ServiceBindingSecretKeyReference{Name: "database", Key: "config"},
+			}),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newHandlerForTest(t, tc.parameterDefaults, tc.secrets...)
+			err := handler.Admit(admission.NewAttributesRecord(tc.instance, nil, servicecatalog.Kind("ServiceInstance").WithVersion("version"), tc.instance.Namespace, tc.instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", admission.Create, nil))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tc.expectedParameters == "" {
+				if tc.instance.Spec.Parameters != nil {
+					t.Fatalf("expected no parameters, got %s", tc.instance.Spec.Parameters.Raw)
+				}
+			} else {
+				var expected, actual interface{}
+				if err := json.Unmarshal([]byte(tc.expectedParameters), &expected); err != nil {
+					t.Fatal(err)
+				}
+				if err := json.Unmarshal(tc.instance.Spec.Parameters.Raw, &actual); err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(expected, actual) {
+					t.Fatalf("unexpected parameters: expected %s, got %s", tc.expectedParameters, tc.instance.Spec.Parameters.Raw)
+				}
+			}
+
+			if e, a := tc.expectedDefaulted, tc.instance.Annotations[servicecatalog.DefaultedParametersAnnotation]; e != a {
+				t.Fatalf("unexpected %s annotation: expected %q, got %q", servicecatalog.DefaultedParametersAnnotation, e, a)
+			}
+		})
+	}
+}
+
+func withParametersFrom(instance *servicecatalog.ServiceInstance, secretName string) *servicecatalog.ServiceInstance {
+	instance.Spec.ParametersFrom = append(instance.Spec.ParametersFrom, servicecatalog.ParametersFromSource{
+		SecretKeyRef: &servicecatalog.SecretKeyReference{Name: secretName, Key: "key"},
+	})
+	return instance
+}
+
+func withParametersFromSource(instance *servicecatalog.ServiceInstance, source servicecatalog.ParametersFromSource) *servicecatalog.ServiceInstance {
+	instance.Spec.ParametersFrom = append(instance.Spec.ParametersFrom, source)
+	return instance
+}
+
+func newSecret(name, value string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Data:       map[string][]byte{"key": []byte(value)},
+	}
+}
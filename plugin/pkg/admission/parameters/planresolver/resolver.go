@@ -0,0 +1,188 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package planresolver resolves the plans that ServiceInstances refer to
+// from the caches of the admission plugins that act on their parameters or
+// plans, and the parameters they take from Secrets.
+package planresolver
+
+import (
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	internalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/internalversion"
+)
+
+// Resolver resolves the plan of a ServiceInstance from listers. The
+// namespaced listers may be nil, in which case the plans of namespaced
+// brokers are never resolved.
+type Resolver struct {
+	ClusterServiceClassLister internalversion.ClusterServiceClassLister
+	ClusterServicePlanLister  internalversion.ClusterServicePlanLister
+	ServiceClassLister        internalversion.ServiceClassLister
+	ServicePlanLister         internalversion.ServicePlanLister
+}
+
+// ServicePlanSpec returns the spec of the plan the given instance refers to,
+// or nil if it cannot be resolved.
+func (r *Resolver) ServicePlanSpec(instance *servicecatalog.ServiceInstance) (*servicecatalog.CommonServicePlanSpec, error) {
+	ref := instance.Spec.PlanReference
+	switch {
+	case ref.ClusterServicePlanSpecified():
+		plan, err := r.ClusterServicePlan(&ref)
+		if err != nil || plan == nil {
+			return nil, err
+		}
+		return &plan.Spec.CommonServicePlanSpec, nil
+	case ref.ServicePlanSpecified() && r.ServicePlanLister != nil:
+		plan, err := r.ServicePlan(instance.Namespace, &ref)
+		if err != nil || plan == nil {
+			return nil, err
+		}
+		return &plan.Spec.CommonServicePlanSpec, nil
+	}
+	return nil, nil
+}
+
+// ServiceClassAndPlanSpecs returns the specs of the class and of the plan the
+// given instance refers to, or nils if they cannot be resolved.
+func (r *Resolver) ServiceClassAndPlanSpecs(instance *servicecatalog.ServiceInstance) (*servicecatalog.CommonServiceClassSpec, *servicecatalog.CommonServicePlanSpec, error) {
+	ref := instance.Spec.PlanReference
+	switch {
+	case ref.ClusterServicePlanSpecified():
+		plan, err := r.ClusterServicePlan(&ref)
+		if err != nil || plan == nil {
+			return nil, nil, err
+		}
+		class, err := r.ClusterServiceClassLister.Get(plan.Spec.ClusterServiceClassRef.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil, nil
+			}
+			return nil, nil, err
+		}
+		return &class.Spec.CommonServiceClassSpec, &plan.Spec.CommonServicePlanSpec, nil
+	case ref.ServicePlanSpecified() && r.ServicePlanLister != nil:
+		plan, err := r.ServicePlan(instance.Namespace, &ref)
+		if err != nil || plan == nil {
+			return nil, nil, err
+		}
+		class, err := r.ServiceClassLister.ServiceClasses(instance.Namespace).Get(plan.Spec.ServiceClassRef.Name)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil, nil, nil
+			}
+			return nil, nil, err
+		}
+		return &class.Spec.CommonServiceClassSpec, &plan.Spec.CommonServicePlanSpec, nil
+	}
+	return nil, nil, nil
+}
+
+// ClusterServicePlan returns the ClusterServicePlan the given reference
+// refers to, or nil if it cannot be resolved.
+func (r *Resolver) ClusterServicePlan(ref *servicecatalog.PlanReference) (*servicecatalog.ClusterServicePlan, error) {
+	if ref.ClusterServicePlanName != "" {
+		plan, err := r.ClusterServicePlanLister.Get(ref.ClusterServicePlanName)
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return plan, err
+	}
+
+	plans, err := r.ClusterServicePlanLister.List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	className := ""
+	if ref.ClusterServicePlanExternalName != "" {
+		classes, err := r.ClusterServiceClassLister.List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		var matches []*servicecatalog.ClusterServiceClass
+		for _, class := range classes {
+			if class.Spec.ExternalName == ref.ClusterServiceClassExternalName {
+				matches = append(matches, class)
+			}
+		}
+		if len(matches) != 1 {
+			return nil, nil
+		}
+		className = matches[0].Name
+	}
+
+	var matches []*servicecatalog.ClusterServicePlan
+	for _, plan := range plans {
+		if ref.ClusterServicePlanExternalID != "" && plan.Spec.ExternalID == ref.ClusterServicePlanExternalID ||
+			ref.ClusterServicePlanExternalName != "" && plan.Spec.ClusterServiceClassRef.Name == className && plan.Spec.ExternalName == ref.ClusterServicePlanExternalName {
+			matches = append(matches, plan)
+		}
+	}
+	if len(matches) != 1 {
+		return nil, nil
+	}
+	return matches[0], nil
+}
+
+// ServicePlan returns the ServicePlan of the given namespace the given
+// reference refers to, or nil if it cannot be resolved.
+func (r *Resolver) ServicePlan(namespace string, ref *servicecatalog.PlanReference) (*servicecatalog.ServicePlan, error) {
+	if ref.ServicePlanName != "" {
+		plan, err := r.ServicePlanLister.ServicePlans(namespace).Get(ref.ServicePlanName)
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return plan, err
+	}
+
+	plans, err := r.ServicePlanLister.ServicePlans(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+
+	className := ""
+	if ref.ServicePlanExternalName != "" {
+		classes, err := r.ServiceClassLister.ServiceClasses(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, err
+		}
+		var matches []*servicecatalog.ServiceClass
+		for _, class := range classes {
+			if class.Spec.ExternalName == ref.ServiceClassExternalName {
+				matches = append(matches, class)
+			}
+		}
+		if len(matches) != 1 {
+			return nil, nil
+		}
+		className = matches[0].Name
+	}
+
+	var matches []*servicecatalog.ServicePlan
+	for _, plan := range plans {
+		if ref.ServicePlanExternalID != "" && plan.Spec.ExternalID == ref.ServicePlanExternalID ||
+			ref.ServicePlanExternalName != "" && plan.Spec.ServiceClassRef.Name == className && plan.Spec.ExternalName == ref.ServicePlanExternalName {
+			matches = append(matches, plan)
+		}
+	}
+	if len(matches) != 1 {
+		return nil, nil
+	}
+	return matches[0], nil
+}
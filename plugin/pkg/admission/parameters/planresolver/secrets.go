@@ -0,0 +1,42 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planresolver
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kubeclientset "k8s.io/client-go/kubernetes"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+)
+
+// SecretKeyParameters returns the parameters held by the given key of a
+// Secret of the given namespace, as referenced by the ParametersFrom of an
+// instance or binding.
+func SecretKeyParameters(client kubeclientset.Interface, namespace string, ref *servicecatalog.SecretKeyReference) (map[string]interface{}, error) {
+	secret, err := client.CoreV1().Secrets(namespace).Get(ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	params := map[string]interface{}{}
+	if err := json.Unmarshal(secret.Data[ref.Key], &params); err != nil {
+		return nil, fmt.Errorf("key %q does not hold a JSON object", ref.Key)
+	}
+	return params, nil
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemavalidator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/golang/glog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubeclientset "k8s.io/client-go/kubernetes"
+
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	internalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/internalversion"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	"github.com/kubernetes-incubator/service-catalog/pkg/controller"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/pkg/jsonschema"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/parameters/planresolver"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ParametersSchemaValidator"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewParametersSchemaValidator()
+	})
+}
+
+// parametersSchemaValidator is an implementation of admission.Interface.
+// It validates the parameters of ServiceInstances and ServiceBindings
+// against the JSON schemas their plan publishes for them, so that invalid
+// parameters are rejected before they are sent to the broker.
+//
+// The parameters set through ParametersFrom are validated along with the
+// inline ones when their Secret can be read; the required parameters are not
+// checked when they may be set by a ParametersFrom source that is not read.
+// Plans whose schema cannot be resolved or parsed are not validated against.
+type parametersSchemaValidator struct {
+	*admission.Handler
+	client kubeclientset.Interface
+
+	instanceLister internalversion.ServiceInstanceLister
+
+	// plans only resolves the plans of namespaced brokers when the
+	// NamespacedServiceBroker feature is enabled.
+	plans planresolver.Resolver
+}
+
+var _ = scadmission.WantsInternalServiceCatalogInformerFactory(&parametersSchemaValidator{})
+var _ = scadmission.WantsKubeClientSet(&parametersSchemaValidator{})
+
+func (v *parametersSchemaValidator) Admit(a admission.Attributes) error {
+	// we need to wait for our caches to warm
+	if !v.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+
+	// the parameters cannot be changed through the subresources
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetSubresource() != "" {
+		return nil
+	}
+
+	switch a.GetResource().GroupResource() {
+	case servicecatalog.Resource("serviceinstances"):
+		return v.admitServiceInstance(a)
+	case servicecatalog.Resource("servicebindings"):
+		return v.admitServiceBinding(a)
+	}
+	return nil
+}
+
+func (v *parametersSchemaValidator) admitServiceInstance(a admission.Attributes) error {
+	instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+	}
+	if instance.DeletionTimestamp != nil {
+		return nil
+	}
+
+	update := a.GetOperation() == admission.Update
+	if update {
+		// Only validate the parameters when they are sent to the broker
+		// again, so that the updates made by the controller go through.
+		oldInstance, ok := a.GetOldObject().(*servicecatalog.ServiceInstance)
+		if ok && reflect.DeepEqual(oldInstance.Spec.PlanReference, instance.Spec.PlanReference) &&
+			parametersEqual(oldInstance.Spec.Parameters, oldInstance.Spec.ParametersFrom, instance.Spec.Parameters, instance.Spec.ParametersFrom) {
+			return nil
+		}
+	}
+
+	plan, err := v.plans.ServicePlanSpec(instance)
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	if plan == nil {
+		glog.V(5).Infof("ServiceInstance %s/%s: not validating parameters, the plan %c could not be resolved", instance.Namespace, instance.Name, instance.Spec.PlanReference)
+		return nil
+	}
+
+	schema := plan.ServiceInstanceCreateParameterSchema
+	if update {
+		schema = plan.ServiceInstanceUpdateParameterSchema
+	}
+	allErrs := v.validateParameters(instance.Namespace, schema, instance.Spec.Parameters, instance.Spec.ParametersFrom, field.NewPath("spec", "parameters"))
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(servicecatalog.Kind("ServiceInstance"), instance.Name, allErrs)
+	}
+	return nil
+}
+
+func (v *parametersSchemaValidator) admitServiceBinding(a admission.Attributes) error {
+	binding, ok := a.GetObject().(*servicecatalog.ServiceBinding)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind ServiceBinding but was unable to be converted")
+	}
+	if binding.DeletionTimestamp != nil {
+		return nil
+	}
+
+	if a.GetOperation() == admission.Update {
+		oldBinding, ok := a.GetOldObject().(*servicecatalog.ServiceBinding)
+		if ok && parametersEqual(oldBinding.Spec.Parameters, oldBinding.Spec.ParametersFrom, binding.Spec.Parameters, binding.Spec.ParametersFrom) {
+			return nil
+		}
+	}
+
+	instance, err := v.instanceLister.ServiceInstances(binding.Namespace).Get(binding.Spec.ServiceInstanceRef.Name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return admission.NewForbidden(a, err)
+	}
+
+	plan, err := v.plans.ServicePlanSpec(instance)
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	if plan == nil {
+		glog.V(5).Infof("ServiceBinding %s/%s: not validating parameters, the plan %c could not be resolved", binding.Namespace, binding.Name, instance.Spec.PlanReference)
+		return nil
+	}
+
+	allErrs := v.validateParameters(binding.Namespace, plan.ServiceBindingCreateParameterSchema, binding.Spec.Parameters, binding.Spec.ParametersFrom, field.NewPath("spec", "parameters"))
+	if len(allErrs) > 0 {
+		return apierrors.NewInvalid(servicecatalog.Kind("ServiceBinding"), binding.Name, allErrs)
+	}
+	return nil
+}
+
+// validateParameters validates the given parameters, merged with the ones
+// from the given ParametersFrom sources, against the given schema.
+func (v *parametersSchemaValidator) validateParameters(namespace string, schema *runtime.RawExtension, parameters *runtime.RawExtension, parametersFrom []servicecatalog.ParametersFromSource, fldPath *field.Path) field.ErrorList {
+	if schema == nil || len(schema.Raw) == 0 {
+		return nil
+	}
+	s, err := jsonschema.Parse(schema.Raw)
+	if err != nil {
+		glog.V(4).Infof("Not validating parameters against a schema that cannot be used: %v", err)
+		return nil
+	}
+
+	params := map[string]interface{}{}
+	if parameters != nil {
+		p, err := controller.UnmarshalRawParameters(parameters.Raw)
+		if err != nil {
+			// Reported by the validation of the resource.
+			return nil
+		}
+		params = p
+	}
+
+	complete := true
+	for _, from := range parametersFrom {
+		if from.SecretKeyRef == nil {
+			// The parameters referring to bindings and instances are only
+			// resolved by the controller, once these are Ready.
+			complete = false
+			continue
+		}
+		fromParams, err := planresolver.SecretKeyParameters(v.client, namespace, from.SecretKeyRef)
+		if err != nil {
+			glog.V(4).Infof(`Not validating the parameters of Secret "%s/%s": %v`, namespace, from.SecretKeyRef.Name, err)
+			complete = false
+			continue
+		}
+		for k, value := range fromParams {
+			// Duplicate parameters are rejected by the controller.
+			if _, ok := params[k]; !ok {
+				params[k] = value
+			}
+		}
+	}
+
+	allErrs := field.ErrorList{}
+	for _, err := range s.Validate(params, fldPath) {
+		// The parameters that are missing may be set by the Secrets
+		// that could not be read.
+		if !complete && err.Type == field.ErrorTypeRequired && isChildOf(err.Field, fldPath) {
+			continue
+		}
+		allErrs = append(allErrs, err)
+	}
+	return allErrs
+}
+
+// parametersEqual returns whether the given parameters and ParametersFrom
+// sources are the same.
+func parametersEqual(oldParameters *runtime.RawExtension, oldParametersFrom []servicecatalog.ParametersFromSource, parameters *runtime.RawExtension, parametersFrom []servicecatalog.ParametersFromSource) bool {
+	return reflect.DeepEqual(oldParameters, parameters) && reflect.DeepEqual(oldParametersFrom, parametersFrom)
+}
+
+// isChildOf returns whether the given field is a direct child of the given
+// path.
+func isChildOf(fieldName string, fldPath *field.Path) bool {
+	prefix := fldPath.String() + "."
+	if len(fieldName) <= len(prefix) || fieldName[:len(prefix)] != prefix {
+		return false
+	}
+	for _, c := range fieldName[len(prefix):] {
+		if c == '.' || c == '[' {
+			return false
+		}
+	}
+	return true
+}
+
+// NewParametersSchemaValidator creates a new admission control handler that
+// validates the parameters of instances and bindings against the schemas of
+// their plan.
+func NewParametersSchemaValidator() (admission.Interface, error) {
+	return &parametersSchemaValidator{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+	}, nil
+}
+
+func (v *parametersSchemaValidator) SetInternalServiceCatalogInformerFactory(f informers.SharedInformerFactory) {
+	clusterServiceClassInformer := f.Servicecatalog().InternalVersion().ClusterServiceClasses()
+	clusterServicePlanInformer := f.Servicecatalog().InternalVersion().ClusterServicePlans()
+	instanceInformer := f.Servicecatalog().InternalVersion().ServiceInstances()
+	v.plans.ClusterServiceClassLister = clusterServiceClassInformer.Lister()
+	v.plans.ClusterServicePlanLister = clusterServicePlanInformer.Lister()
+	v.instanceLister = instanceInformer.Lister()
+
+	readyFunc := func() bool {
+		return clusterServiceClassInformer.Informer().HasSynced() &&
+			clusterServicePlanInformer.Informer().HasSynced() &&
+			instanceInformer.Informer().HasSynced()
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
+		serviceClassInformer := f.Servicecatalog().InternalVersion().ServiceClasses()
+		servicePlanInformer := f.Servicecatalog().InternalVersion().ServicePlans()
+		v.plans.ServiceClassLister = serviceClassInformer.Lister()
+		v.plans.ServicePlanLister = servicePlanInformer.Lister()
+		clusterReadyFunc := readyFunc
+		readyFunc = func() bool {
+			return clusterReadyFunc() && serviceClassInformer.Informer().HasSynced() && servicePlanInformer.Informer().HasSynced()
+		}
+	}
+
+	v.SetReadyFunc(readyFunc)
+}
+
+func (v *parametersSchemaValidator) SetKubeClientSet(client kubeclientset.Interface) {
+	v.client = client
+}
+
+func (v *parametersSchemaValidator) ValidateInitialization() error {
+	if v.client == nil {
+		return errors.New("missing client")
+	}
+	if v.plans.ClusterServiceClassLister == nil {
+		return errors.New("missing cluster service class lister")
+	}
+	if v.plans.ClusterServicePlanLister == nil {
+		return errors.New("missing cluster service plan lister")
+	}
+	if v.instanceLister == nil {
+		return errors.New("missing instance lister")
+	}
+	return nil
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schemavalidator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/admission"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+)
+
+const (
+	testNamespace = "test-ns"
+
+	createSchema = `{
+		"$schema": "http://json-schema.org/draft-04/schema#",
+		"type": "object",
+		"properties": {
+			"name": {"type": "string"},
+			"size": {"type": "integer", "maximum": 10}
+		},
+		"required": ["name"]
+	}`
+	updateSchema = `{
+		"type": "object",
+		"properties": {
+			"size": {"type": "integer", "maximum": 20}
+		}
+	}`
+	bindSchema = `{
+		"type": "object",
+		"properties": {
+			"role": {"enum": ["reader", "writer"]}
+		}
+	}`
+)
+
+// newHandlerForTest returns a configured handler for testing, with caches
+// holding the given class, plan and instance.
+func newHandlerForTest(t *testing.T, objects ...runtime.Object) admission.MutationInterface {
+	class := &servicecatalog.ClusterServiceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: "class-id"},
+		Spec: servicecatalog.ClusterServiceClassSpec{
+			CommonServiceClassSpec: servicecatalog.CommonServiceClassSpec{
+				ExternalName: "class",
+				ExternalID:   "class-id",
+			},
+		},
+	}
+	plan := &servicecatalog.ClusterServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "plan-id"},
+		Spec: servicecatalog.ClusterServicePlanSpec{
+			CommonServicePlanSpec: servicecatalog.CommonServicePlanSpec{
+				ExternalName:                         "plan",
+				ExternalID:                           "plan-id",
+				ServiceInstanceCreateParameterSchema: &runtime.RawExtension{Raw: []byte(createSchema)},
+				ServiceInstanceUpdateParameterSchema: &runtime.RawExtension{Raw: []byte(updateSchema)},
+				ServiceBindingCreateParameterSchema:  &runtime.RawExtension{Raw: []byte(bindSchema)},
+			},
+			ClusterServiceClassRef: servicecatalog.ClusterObjectReference{Name: "class-id"},
+		},
+	}
+	instance := newServiceInstance("")
+
+	internalClient := fake.NewSimpleClientset(class, plan, instance)
+	f := informers.NewSharedInformerFactory(internalClient, 5*time.Minute)
+	handler, err := NewParametersSchemaValidator()
+	if err != nil {
+		t.Fatalf("unexpected error creating handler: %v", err)
+	}
+	pluginInitializer := scadmission.NewPluginInitializer(internalClient, f, kubefake.NewSimpleClientset(objects...), nil)
+	pluginInitializer.Initialize(handler)
+	if err := admission.ValidateInitialization(handler); err != nil {
+		t.Fatalf("unexpected error initializing handler: %v", err)
+	}
+	f.Start(wait.NeverStop)
+	f.WaitForCacheSync(wait.NeverStop)
+	return handler.(admission.MutationInterface)
+}
+
+// newServiceInstance returns a new instance of the test plan with the given
+// parameters.
+func newServiceInstance(parameters string) *servicecatalog.ServiceInstance {
+	instance := &servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: testNamespace},
+		Spec: servicecatalog.ServiceInstanceSpec{
+			PlanReference: servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: "class",
+				ClusterServicePlanExternalName:  "plan",
+			},
+		},
+	}
+	if parameters != "" {
+		instance.Spec.Parameters = &runtime.RawExtension{Raw: []byte(parameters)}
+	}
+	return instance
+}
+
+// newServiceBinding returns a new binding to the test instance with the
+// given parameters.
+func newServiceBinding(parameters string) *servicecatalog.ServiceBinding {
+	binding := &servicecatalog.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: "binding", Namespace: testNamespace},
+		Spec: servicecatalog.ServiceBindingSpec{
+			ServiceInstanceRef: servicecatalog.LocalObjectReference{Name: "instance"},
+		},
+	}
+	if parameters != "" {
+		binding.Spec.Parameters = &runtime.RawExtension{Raw: []byte(parameters)}
+	}
+	return binding
+}
+
+func newSecret(name, key, value string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Data:       map[string][]byte{key: []byte(value)},
+	}
+}
+
+func withParametersFrom(instance *servicecatalog.ServiceInstance, name, key string) *servicecatalog.ServiceInstance {
+	instance.Spec.ParametersFrom = append(instance.Spec.ParametersFrom, servicecatalog.ParametersFromSource{
+		SecretKeyRef: &servicecatalog.SecretKeyReference{Name: name, Key: key},
+	})
+	return instance
+}
+
+func withParametersFromSource(instance *servicecatalog.ServiceInstance, source servicecatalog.ParametersFromSource) *servicecatalog.ServiceInstance {
+	instance.Spec.ParametersFrom = append(instance.Spec.ParametersFrom, source)
+	return instance
+}
+
+func TestAdmitServiceInstance(t *testing.T) {
+	cases := []struct {
+		name        string
+		operation   admission.Operation
+		instance    *servicecatalog.ServiceInstance
+		oldInstance *servicecatalog.ServiceInstance
+		secrets     []runtime.Object
+		errors      []string
+	}{
+		{
+			name:      "valid parameters",
+			operation: admission.Create,
+			instance:  newServiceInstance(`{"name": "db", "size": 5}`),
+		},
+		{
+			name:      "invalid parameters",
+			operation: admission.Create,
+			instance:  newServiceInstance(`{"size": 15}`),
+			errors: []string{
+				"spec.parameters.name: Required value",
+				"spec.parameters.size: Invalid value: (value omitted): must be less than or equal to 10",
+			},
+		},
+		{
+			name:      "update validated against the update schema",
+			operation: admission.Update,
+			instance:  newServiceInstance(`{"size": 15}`),
+		},
+		{
+			name:        "update without parameter changes",
+			operation:   admission.Update,
+			instance:    newServiceInstance(`{"size": 25}`),
+			oldInstance: newServiceInstance(`{"size": 25}`),
+		},
+		{
+			name:      "parameters from a secret",
+			operation: admission.Create,
+			instance:  withParametersFrom(newServiceInstance(`{"size": 5}`), "params", "key"),
+			secrets:   []runtime.Object{newSecret("params", "key", `{"size": 15, "name": "db"}`)},
+		},
+		{
+			name:      "invalid parameters from a secret",
+			operation: admission.Create,
+			instance:  withParametersFrom(newServiceInstance(`{"name": "db"}`), "params", "key"),
+			secrets:   []runtime.Object{newSecret("params", "key", `{"size": "large"}`)},
+			errors:    []string{"spec.parameters.size: Invalid value: (value omitted): must be of type integer"},
+		},
+		{
+			name:      "required parameters may be in an unreadable secret",
+			operation: admission.Create,
+			instance:  withParametersFrom(newServiceInstance(`{"size": 15}`), "missing", "key"),
+			errors:    []string{"spec.parameters.size: Invalid value: (value omitted): must be less than or equal to 10"},
+		},
+		{
+			name:      "required parameters may be set by another instance",
+			operation: admission.Create,
+			instance: withParametersFromSource(newServiceInstance(`{"size": 5}`), servicecatalog.ParametersFromSource{
+				ServiceInstanceFieldRef: &servicecatalog.ServiceInstanceFieldReference{
+					Name:      "database",
+					FieldPath: servicecatalog.ServiceInstanceFieldPathExternalID,
+					Parameter: "name",
+				},
+			}),
+		},
+		{
+			name:      "unresolvable plan",
+			operation: admission.Create,
+			instance: &servicecatalog.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: testNamespace},
+				Spec: servicecatalog.ServiceInstanceSpec{
+					PlanReference: servicecatalog.PlanReference{ClusterServicePlanName: "other"},
+					Parameters:    &runtime.RawExtension{Raw: []byte(`{"size": 15}`)},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newHandlerForTest(t, tc.secrets...)
+			var oldObj runtime.Object
+			if tc.oldInstance != nil {
+				oldObj = tc.oldInstance
+			}
+			err := handler.Admit(admission.NewAttributesRecord(tc.instance, oldObj, servicecatalog.Kind("ServiceInstance").WithVersion("version"), tc.instance.Namespace, tc.instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", tc.operation, nil))
+			checkErrors(t, err, tc.errors)
+		})
+	}
+}
+
+func TestAdmitServiceBinding(t *testing.T) {
+	cases := []struct {
+		name    string
+		binding *servicecatalog.ServiceBinding
+		errors  []string
+	}{
+		{
+			name:    "valid parameters",
+			binding: newServiceBinding(`{"role": "reader"}`),
+		},
+		{
+			name:    "invalid parameters",
+			binding: newServiceBinding(`{"role": "admin"}`),
+			errors:  []string{`spec.parameters.role: Invalid value: (value omitted): must be one of "reader", "writer"`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newHandlerForTest(t)
+			err := handler.Admit(admission.NewAttributesRecord(tc.binding, nil, servicecatalog.Kind("ServiceBinding").WithVersion("version"), tc.binding.Namespace, tc.binding.Name, servicecatalog.Resource("servicebindings").WithVersion("version"), "", admission.Create, nil))
+			checkErrors(t, err, tc.errors)
+		})
+	}
+}
+
+func checkErrors(t *testing.T, err error, expected []string) {
+	if len(expected) == 0 {
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		return
+	}
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	for _, e := range expected {
+		if !strings.Contains(err.Error(), e) {
+			t.Errorf("expected error to contain %q, got %q", e, err.Error())
+		}
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podpreset
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
+)
+
+const (
+	// annotationPrefix is the prefix of the annotations recording which
+	// PodPresets have been applied to a pod.
+	annotationPrefix = "podpreset.admission.kubernetes.io"
+
+	// ExcludeAnnotation can be set to "true" on a pod to opt out of having
+	// PodPresets applied to it.
+	ExcludeAnnotation = annotationPrefix + "/exclude"
+)
+
+// AppliedAnnotation returns the key of the annotation that records that the
+// named PodPreset was applied to a pod. The annotation value is the
+// resourceVersion of the PodPreset at the time it was applied.
+func AppliedAnnotation(presetName string) string {
+	return fmt.Sprintf("%s/podpreset-%s", annotationPrefix, presetName)
+}
+
+// isExcluded returns whether the pod has opted out of PodPresets.
+func isExcluded(pod *corev1.Pod) bool {
+	if pod.Annotations == nil {
+		return false
+	}
+	return pod.Annotations[ExcludeAnnotation] == "true"
+}
+
+// filterPodPresets returns the PodPresets whose selector matches the labels
+// of the pod, sorted by name so that they are always applied in the same
+// order.
+func filterPodPresets(presets []*settingsv1alpha1.PodPreset, pod *corev1.Pod) ([]*settingsv1alpha1.PodPreset, error) {
+	var matching []*settingsv1alpha1.PodPreset
+	for _, pp := range presets {
+		selector, err := metav1.LabelSelectorAsSelector(&pp.Spec.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("label selector conversion failed for PodPreset %q: %v", pp.Name, err)
+		}
+
+		// check if the pod labels match the selector
+		if !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		matching = append(matching, pp)
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].Name < matching[j].Name
+	})
+	return matching, nil
+}
+
+// applyPodPreset merges the given PodPreset into the pod. The pod is only
+// modified if the PodPreset can be merged without conflicts, otherwise an
+// error describing every conflict is returned and the pod is left untouched.
+func applyPodPreset(pod *corev1.Pod, pp *settingsv1alpha1.PodPreset) error {
+	var errs []error
+
+	volumes, err := mergeVolumes(pod.Spec.Volumes, pp.Spec.Volumes)
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	containers := make([]corev1.Container, len(pod.Spec.Containers))
+	for i := range pod.Spec.Containers {
+		ctr, err := applyPodPresetOnContainer(&pod.Spec.Containers[i], pp)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		containers[i] = *ctr
+	}
+
+	initContainers := make([]corev1.Container, len(pod.Spec.InitContainers))
+	for i := range pod.Spec.InitContainers {
+		ctr, err := applyPodPresetOnContainer(&pod.Spec.InitContainers[i], pp)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		initContainers[i] = *ctr
+	}
+
+	if len(errs) > 0 {
+		return utilerrors.NewAggregate(errs)
+	}
+
+	pod.Spec.Volumes = volumes
+	pod.Spec.Containers = containers
+	if len(initContainers) > 0 {
+		pod.Spec.InitContainers = initContainers
+	}
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[AppliedAnnotation(pp.Name)] = pp.ResourceVersion
+
+	return nil
+}
+
+// applyPodPresetOnContainer returns a copy of the container with the Env,
+// EnvFrom and VolumeMounts of the PodPreset merged in.
+func applyPodPresetOnContainer(ctr *corev1.Container, pp *settingsv1alpha1.PodPreset) (*corev1.Container, error) {
+	var errs []error
+	out := ctr.DeepCopy()
+
+	env, err := mergeEnv(ctr.Env, pp.Spec.Env)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("container %q: %v", ctr.Name, err))
+	}
+	out.Env = env
+
+	out.EnvFrom = mergeEnvFrom(ctr.EnvFrom, pp.Spec.EnvFrom)
+
+	volumeMounts, err := mergeVolumeMounts(ctr.VolumeMounts, pp.Spec.VolumeMounts)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("container %q: %v", ctr.Name, err))
+	}
+	out.VolumeMounts = volumeMounts
+
+	if len(errs) > 0 {
+		return nil, utilerrors.NewAggregate(errs)
+	}
+	return out, nil
+}
+
+// mergeEnv merges a list of env vars with the env vars of a PodPreset. An env
+// var of the same name but with a different value is a conflict.
+func mergeEnv(envVars []corev1.EnvVar, presetEnvVars []corev1.EnvVar) ([]corev1.EnvVar, error) {
+	orig := map[string]corev1.EnvVar{}
+	for _, v := range envVars {
+		orig[v.Name] = v
+	}
+
+	var merged []corev1.EnvVar
+	merged = append(merged, envVars...)
+	var conflicts []string
+	for _, v := range presetEnvVars {
+		found, ok := orig[v.Name]
+		if !ok {
+			orig[v.Name] = v
+			merged = append(merged, v)
+			continue
+		}
+		if !reflect.DeepEqual(found, v) {
+			conflicts = append(conflicts, fmt.Sprintf("env var %q has a different value in the pod", v.Name))
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("merging env vars: %s", strings.Join(conflicts, "; "))
+	}
+	return merged, nil
+}
+
+// mergeEnvFrom appends the env sources of a PodPreset to a list of env
+// sources. Env sources never conflict, the container runtime resolves
+// duplicate keys in order.
+func mergeEnvFrom(envSources []corev1.EnvFromSource, presetEnvSources []corev1.EnvFromSource) []corev1.EnvFromSource {
+	var merged []corev1.EnvFromSource
+	merged = append(merged, envSources...)
+	for _, s := range presetEnvSources {
+		if !containsEnvFromSource(merged, s) {
+			merged = append(merged, s)
+		}
+	}
+	return merged
+}
+
+func containsEnvFromSource(envSources []corev1.EnvFromSource, s corev1.EnvFromSource) bool {
+	for _, existing := range envSources {
+		if reflect.DeepEqual(existing, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeVolumeMounts merges a list of volume mounts with the volume mounts of
+// a PodPreset. A mount of the same name with a different definition, or a
+// mount of a different volume at the same path, is a conflict.
+func mergeVolumeMounts(volumeMounts []corev1.VolumeMount, presetVolumeMounts []corev1.VolumeMount) ([]corev1.VolumeMount, error) {
+	origByName := map[string]corev1.VolumeMount{}
+	origByPath := map[string]corev1.VolumeMount{}
+	for _, v := range volumeMounts {
+		origByName[v.Name] = v
+		origByPath[v.MountPath] = v
+	}
+
+	var merged []corev1.VolumeMount
+	merged = append(merged, volumeMounts...)
+	var conflicts []string
+	for _, v := range presetVolumeMounts {
+		found, ok := origByName[v.Name]
+		if ok {
+			if !reflect.DeepEqual(found, v) {
+				conflicts = append(conflicts, fmt.Sprintf("volume mount %q has a different definition in the pod", v.Name))
+			}
+			continue
+		}
+		if found, ok := origByPath[v.MountPath]; ok {
+			conflicts = append(conflicts, fmt.Sprintf("volume mount %q uses mount path %q, which is already used by %q", v.Name, v.MountPath, found.Name))
+			continue
+		}
+		origByName[v.Name] = v
+		origByPath[v.MountPath] = v
+		merged = append(merged, v)
+	}
+
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("merging volume mounts: %s", strings.Join(conflicts, "; "))
+	}
+	return merged, nil
+}
+
+// mergeVolumes merges a list of volumes with the volumes of a PodPreset. A
+// volume of the same name with a different source is a conflict.
+func mergeVolumes(volumes []corev1.Volume, presetVolumes []corev1.Volume) ([]corev1.Volume, error) {
+	orig := map[string]corev1.Volume{}
+	for _, v := range volumes {
+		orig[v.Name] = v
+	}
+
+	var merged []corev1.Volume
+	merged = append(merged, volumes...)
+	var conflicts []string
+	for _, v := range presetVolumes {
+		found, ok := orig[v.Name]
+		if !ok {
+			orig[v.Name] = v
+			merged = append(merged, v)
+			continue
+		}
+		if !reflect.DeepEqual(found, v) {
+			conflicts = append(conflicts, fmt.Sprintf("volume %q has a different definition in the pod", v.Name))
+		}
+	}
+
+	if len(conflicts) > 0 {
+		return nil, fmt.Errorf("merging volumes: %s", strings.Join(conflicts, "; "))
+	}
+	return merged, nil
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podpreset
+
+import (
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
+)
+
+func TestMergeEnv(t *testing.T) {
+	cases := []struct {
+		name        string
+		orig        []corev1.EnvVar
+		preset      []corev1.EnvVar
+		expected    []corev1.EnvVar
+		shouldError bool
+	}{
+		{
+			name:     "empty",
+			expected: nil,
+		},
+		{
+			name:     "new env var",
+			orig:     []corev1.EnvVar{{Name: "abc", Value: "value1"}},
+			preset:   []corev1.EnvVar{{Name: "def", Value: "value2"}},
+			expected: []corev1.EnvVar{{Name: "abc", Value: "value1"}, {Name: "def", Value: "value2"}},
+		},
+		{
+			name:     "identical env var",
+			orig:     []corev1.EnvVar{{Name: "abc", Value: "value1"}},
+			preset:   []corev1.EnvVar{{Name: "abc", Value: "value1"}},
+			expected: []corev1.EnvVar{{Name: "abc", Value: "value1"}},
+		},
+		{
+			name:        "conflicting env var",
+			orig:        []corev1.EnvVar{{Name: "abc", Value: "value1"}},
+			preset:      []corev1.EnvVar{{Name: "abc", Value: "value2"}},
+			shouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		merged, err := mergeEnv(tc.orig, tc.preset)
+		if tc.shouldError != (err != nil) {
+			t.Errorf("%v: unexpected error state; error: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expected, merged) {
+			t.Errorf("%v: unexpected merged env; expected %v, got %v", tc.name, tc.expected, merged)
+		}
+	}
+}
+
+func TestMergeEnvFrom(t *testing.T) {
+	configMapSource := corev1.EnvFromSource{
+		ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "abc"}},
+	}
+	secretSource := corev1.EnvFromSource{
+		Prefix:    "pre_",
+		SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "abc"}},
+	}
+
+	merged := mergeEnvFrom([]corev1.EnvFromSource{configMapSource}, []corev1.EnvFromSource{configMapSource, secretSource})
+	expected := []corev1.EnvFromSource{configMapSource, secretSource}
+	if !reflect.DeepEqual(expected, merged) {
+		t.Errorf("unexpected merged env sources; expected %v, got %v", expected, merged)
+	}
+}
+
+func TestMergeVolumeMounts(t *testing.T) {
+	cases := []struct {
+		name        string
+		orig        []corev1.VolumeMount
+		preset      []corev1.VolumeMount
+		expected    []corev1.VolumeMount
+		shouldError bool
+	}{
+		{
+			name:     "new volume mount",
+			orig:     []corev1.VolumeMount{{Name: "simply-mounted-volume", MountPath: "/opt/"}},
+			preset:   []corev1.VolumeMount{{Name: "etc-volume", MountPath: "/etc/"}},
+			expected: []corev1.VolumeMount{{Name: "simply-mounted-volume", MountPath: "/opt/"}, {Name: "etc-volume", MountPath: "/etc/"}},
+		},
+		{
+			name:     "identical volume mount",
+			orig:     []corev1.VolumeMount{{Name: "etc-volume", MountPath: "/etc/"}},
+			preset:   []corev1.VolumeMount{{Name: "etc-volume", MountPath: "/etc/"}},
+			expected: []corev1.VolumeMount{{Name: "etc-volume", MountPath: "/etc/"}},
+		},
+		{
+			name:        "conflicting name",
+			orig:        []corev1.VolumeMount{{Name: "etc-volume", MountPath: "/etc/"}},
+			preset:      []corev1.VolumeMount{{Name: "etc-volume", MountPath: "/var/"}},
+			shouldError: true,
+		},
+		{
+			name:        "conflicting mount path",
+			orig:        []corev1.VolumeMount{{Name: "etc-volume", MountPath: "/etc/"}},
+			preset:      []corev1.VolumeMount{{Name: "other-volume", MountPath: "/etc/"}},
+			shouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		merged, err := mergeVolumeMounts(tc.orig, tc.preset)
+		if tc.shouldError != (err != nil) {
+			t.Errorf("%v: unexpected error state; error: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expected, merged) {
+			t.Errorf("%v: unexpected merged volume mounts; expected %v, got %v", tc.name, tc.expected, merged)
+		}
+	}
+}
+
+func TestMergeVolumes(t *testing.T) {
+	emptyDir := corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}
+	secret := corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "abc"}}
+
+	cases := []struct {
+		name        string
+		orig        []corev1.Volume
+		preset      []corev1.Volume
+		expected    []corev1.Volume
+		shouldError bool
+	}{
+		{
+			name:     "new volume",
+			orig:     []corev1.Volume{{Name: "vol", VolumeSource: emptyDir}},
+			preset:   []corev1.Volume{{Name: "creds", VolumeSource: secret}},
+			expected: []corev1.Volume{{Name: "vol", VolumeSource: emptyDir}, {Name: "creds", VolumeSource: secret}},
+		},
+		{
+			name:     "identical volume",
+			orig:     []corev1.Volume{{Name: "vol", VolumeSource: emptyDir}},
+			preset:   []corev1.Volume{{Name: "vol", VolumeSource: emptyDir}},
+			expected: []corev1.Volume{{Name: "vol", VolumeSource: emptyDir}},
+		},
+		{
+			name:        "conflicting volume",
+			orig:        []corev1.Volume{{Name: "vol", VolumeSource: emptyDir}},
+			preset:      []corev1.Volume{{Name: "vol", VolumeSource: secret}},
+			shouldError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		merged, err := mergeVolumes(tc.orig, tc.preset)
+		if tc.shouldError != (err != nil) {
+			t.Errorf("%v: unexpected error state; error: %v", tc.name, err)
+			continue
+		}
+		if !reflect.DeepEqual(tc.expected, merged) {
+			t.Errorf("%v: unexpected merged volumes; expected %v, got %v", tc.name, tc.expected, merged)
+		}
+	}
+}
+
+// TestApplyPodPresetConflict verifies that a conflicting PodPreset leaves the
+// pod untouched.
+func TestApplyPodPresetConflict(t *testing.T) {
+	pod := newTestPod()
+	pod.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "DB_HOST", Value: "local"}}
+	orig := pod.DeepCopy()
+
+	pp := newTestPodPreset("preset")
+	pp.Spec.Env = []corev1.EnvVar{{Name: "DB_HOST", Value: "remote"}}
+	pp.Spec.Volumes = []corev1.Volume{{Name: "creds", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}}}
+
+	if err := applyPodPreset(pod, pp); err == nil {
+		t.Fatal("expected a conflict error")
+	}
+	if !reflect.DeepEqual(orig, pod) {
+		t.Fatalf("pod should not have been modified; expected %v, got %v", orig, pod)
+	}
+}
+
+// TestApplyPodPreset verifies that a PodPreset is merged into every container
+// and is recorded in the pod annotations.
+func TestApplyPodPreset(t *testing.T) {
+	pod := newTestPod()
+	pod.Spec.InitContainers = []corev1.Container{{Name: "init", Image: "busybox"}}
+
+	pp := newTestPodPreset("preset")
+	pp.Spec.Env = []corev1.EnvVar{{Name: "DB_HOST", Value: "remote"}}
+	pp.Spec.VolumeMounts = []corev1.VolumeMount{{Name: "creds", MountPath: "/creds"}}
+	pp.Spec.Volumes = []corev1.Volume{{Name: "creds", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "creds"}}}}
+
+	if err := applyPodPreset(pod, pp); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, ctr := range append(pod.Spec.InitContainers, pod.Spec.Containers...) {
+		if !reflect.DeepEqual(pp.Spec.Env, ctr.Env) {
+			t.Errorf("container %q: unexpected env; expected %v, got %v", ctr.Name, pp.Spec.Env, ctr.Env)
+		}
+		if !reflect.DeepEqual(pp.Spec.VolumeMounts, ctr.VolumeMounts) {
+			t.Errorf("container %q: unexpected volume mounts; expected %v, got %v", ctr.Name, pp.Spec.VolumeMounts, ctr.VolumeMounts)
+		}
+	}
+	if !reflect.DeepEqual(pp.Spec.Volumes, pod.Spec.Volumes) {
+		t.Errorf("unexpected volumes; expected %v, got %v", pp.Spec.Volumes, pod.Spec.Volumes)
+	}
+	if e, a := pp.ResourceVersion, pod.Annotations[AppliedAnnotation(pp.Name)]; e != a {
+		t.Errorf("unexpected applied annotation; expected %q, got %q", e, a)
+	}
+}
+
+func TestFilterPodPresets(t *testing.T) {
+	matchingB := newTestPodPreset("b")
+	matchingA := newTestPodPreset("a")
+	other := newTestPodPreset("other")
+	other.Spec.Selector = metav1.LabelSelector{MatchLabels: map[string]string{"app": "other"}}
+
+	matching, err := filterPodPresets([]*settingsv1alpha1.PodPreset{matchingB, other, matchingA}, newTestPod())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []*settingsv1alpha1.PodPreset{matchingA, matchingB}
+	if !reflect.DeepEqual(expected, matching) {
+		t.Fatalf("unexpected matching PodPresets; expected %v, got %v", expected, matching)
+	}
+}
+
+func newTestPod() *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-pod",
+			Namespace: "test-ns",
+			Labels:    map[string]string{"app": "web"},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "web", Image: "nginx"}},
+		},
+	}
+}
+
+func newTestPodPreset(name string) *settingsv1alpha1.PodPreset {
+	return &settingsv1alpha1.PodPreset{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "test-ns",
+			ResourceVersion: "1",
+		},
+		Spec: settingsv1alpha1.PodPresetSpec{
+			Selector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podpreset
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+
+	"github.com/golang/glog"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/record"
+
+	settingslisters "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/settings/v1alpha1"
+)
+
+const (
+	// conflictReason is the reason of the events recorded on a PodPreset
+	// that could not be applied to a pod.
+	conflictReason = "PodPresetConflict"
+)
+
+var podResource = metav1.GroupVersionResource{Group: "", Version: "v1", Resource: "pods"}
+
+// Webhook is a mutating admission webhook that applies PodPresets to pods
+// when they are created.
+type Webhook struct {
+	lister   settingslisters.PodPresetLister
+	recorder record.EventRecorder
+}
+
+// NewWebhook creates a Webhook that looks up PodPresets with the given
+// lister and records conflicts with the given recorder.
+func NewWebhook(lister settingslisters.PodPresetLister, recorder record.EventRecorder) *Webhook {
+	return &Webhook{
+		lister:   lister,
+		recorder: recorder,
+	}
+}
+
+// ServeHTTP implements http.Handler. It expects an AdmissionReview and
+// responds with an AdmissionReview carrying the JSON patch to apply to the
+// pod.
+func (w *Webhook) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(rw, fmt.Sprintf("method %s is not allowed", r.Method), http.StatusMethodNotAllowed)
+		return
+	}
+	if contentType := r.Header.Get("Content-Type"); contentType != "application/json" {
+		http.Error(rw, fmt.Sprintf("content type %q is not supported, expected application/json", contentType), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		http.Error(rw, fmt.Sprintf("failed to read request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	review := admissionv1beta1.AdmissionReview{}
+	if err := json.Unmarshal(body, &review); err != nil {
+		http.Error(rw, fmt.Sprintf("failed to decode AdmissionReview: %v", err), http.StatusBadRequest)
+		return
+	}
+	if review.Request == nil {
+		http.Error(rw, "AdmissionReview has no request", http.StatusBadRequest)
+		return
+	}
+
+	review.Response = w.Admit(review.Request)
+	review.Response.UID = review.Request.UID
+
+	rw.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(rw).Encode(review); err != nil {
+		glog.Errorf("Failed to write AdmissionReview response: %v", err)
+	}
+}
+
+// Admit applies the PodPresets matching the pod of the given request, and
+// returns a response with the resulting JSON patch. PodPresets that conflict
+// with the pod are skipped and a warning event is recorded on them. If the
+// PodPresets cannot be looked up, the pod is admitted unmodified.
+func (w *Webhook) Admit(req *admissionv1beta1.AdmissionRequest) *admissionv1beta1.AdmissionResponse {
+	// Ignore all calls to subresources or resources other than pods, and
+	// all operations other than create.
+	if req.Resource != podResource || len(req.SubResource) != 0 || req.Operation != admissionv1beta1.Create {
+		return allowed()
+	}
+
+	pod := &corev1.Pod{}
+	if err := json.Unmarshal(req.Object.Raw, pod); err != nil {
+		return denied(fmt.Errorf("failed to decode pod: %v", err))
+	}
+	// The namespace of the pod may not be set yet, the request always has it.
+	namespace := req.Namespace
+	if namespace == "" {
+		namespace = pod.Namespace
+	}
+	podName := pod.Name
+	if podName == "" {
+		podName = pod.GenerateName
+	}
+
+	if isExcluded(pod) {
+		glog.V(5).Infof(`Pod "%s/%s" is excluded from PodPresets`, namespace, podName)
+		return allowed()
+	}
+
+	list, err := w.lister.PodPresets(namespace).List(labels.Everything())
+	if err != nil {
+		return allowedUnmodified(namespace, podName, fmt.Errorf("listing PodPresets failed: %v", err))
+	}
+	matching, err := filterPodPresets(list, pod)
+	if err != nil {
+		return allowedUnmodified(namespace, podName, fmt.Errorf("filtering PodPresets failed: %v", err))
+	}
+	if len(matching) == 0 {
+		return allowed()
+	}
+
+	mutated := pod.DeepCopy()
+	for _, pp := range matching {
+		if err := applyPodPreset(mutated, pp); err != nil {
+			msg := fmt.Sprintf("PodPreset was not applied to pod %q: %v", podName, err)
+			glog.Warningf(`PodPreset "%s/%s": %s`, pp.Namespace, pp.Name, msg)
+			w.recorder.Event(pp, corev1.EventTypeWarning, conflictReason, msg)
+			continue
+		}
+		glog.V(4).Infof(`Applied PodPreset "%s/%s" to pod %q`, pp.Namespace, pp.Name, podName)
+	}
+
+	patch, err := createPatch(pod, mutated)
+	if err != nil {
+		return allowedUnmodified(namespace, podName, fmt.Errorf("creating patch failed: %v", err))
+	}
+	if patch == nil {
+		return allowed()
+	}
+
+	patchType := admissionv1beta1.PatchTypeJSONPatch
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed:   true,
+		Patch:     patch,
+		PatchType: &patchType,
+	}
+}
+
+func allowed() *admissionv1beta1.AdmissionResponse {
+	return &admissionv1beta1.AdmissionResponse{Allowed: true}
+}
+
+// allowedUnmodified admits a pod without applying any PodPreset to it, after
+// logging why they could not be applied. The webhook fails open, so that an
+// unsynced or broken PodPreset cache does not block the creation of every pod
+// in the cluster.
+func allowedUnmodified(namespace, podName string, err error) *admissionv1beta1.AdmissionResponse {
+	glog.Errorf(`Admitting pod "%s/%s" without PodPresets: %v`, namespace, podName, err)
+	return allowed()
+}
+
+func denied(err error) *admissionv1beta1.AdmissionResponse {
+	glog.Error(err)
+	return &admissionv1beta1.AdmissionResponse{
+		Allowed: false,
+		Result: &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+		},
+	}
+}
+
+// patchOperation is a single RFC 6902 JSON patch operation.
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// createPatch returns the JSON patch that turns the original pod into the
+// mutated pod, or nil if they do not differ. Only the fields PodPresets may
+// change are compared. The "add" operation replaces the value of a member
+// that already exists, so it is used for every changed field.
+func createPatch(original, mutated *corev1.Pod) ([]byte, error) {
+	var ops []patchOperation
+
+	if !reflect.DeepEqual(original.Annotations, mutated.Annotations) {
+		ops = append(ops, patchOperation{Op: "add", Path: "/metadata/annotations", Value: mutated.Annotations})
+	}
+	if !reflect.DeepEqual(original.Spec.Volumes, mutated.Spec.Volumes) {
+		ops = append(ops, patchOperation{Op: "add", Path: "/spec/volumes", Value: mutated.Spec.Volumes})
+	}
+	ops = append(ops, createContainersPatch("/spec/initContainers", original.Spec.InitContainers, mutated.Spec.InitContainers)...)
+	ops = append(ops, createContainersPatch("/spec/containers", original.Spec.Containers, mutated.Spec.Containers)...)
+
+	if len(ops) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(ops)
+}
+
+func createContainersPatch(path string, original, mutated []corev1.Container) []patchOperation {
+	var ops []patchOperation
+	for i := range original {
+		ctrPath := fmt.Sprintf("%s/%d", path, i)
+		if !reflect.DeepEqual(original[i].Env, mutated[i].Env) {
+			ops = append(ops, patchOperation{Op: "add", Path: ctrPath + "/env", Value: mutated[i].Env})
+		}
+		if !reflect.DeepEqual(original[i].EnvFrom, mutated[i].EnvFrom) {
+			ops = append(ops, patchOperation{Op: "add", Path: ctrPath + "/envFrom", Value: mutated[i].EnvFrom})
+		}
+		if !reflect.DeepEqual(original[i].VolumeMounts, mutated[i].VolumeMounts) {
+			ops = append(ops, patchOperation{Op: "add", Path: ctrPath + "/volumeMounts", Value: mutated[i].VolumeMounts})
+		}
+	}
+	return ops
+}
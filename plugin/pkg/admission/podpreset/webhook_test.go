@@ -0,0 +1,282 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package podpreset
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch"
+
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/record"
+
+	settingsv1alpha1 "github.com/kubernetes-incubator/service-catalog/pkg/apis/settings/v1alpha1"
+	settingslisters "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/settings/v1alpha1"
+)
+
+// newWebhookForTest returns a Webhook whose lister holds the given
+// PodPresets, and the recorder it records events with.
+func newWebhookForTest(t *testing.T, presets ...*settingsv1alpha1.PodPreset) (*Webhook, *record.FakeRecorder) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	for _, pp := range presets {
+		if err := indexer.Add(pp); err != nil {
+			t.Fatalf("unexpected error adding PodPreset: %v", err)
+		}
+	}
+	recorder := record.NewFakeRecorder(10)
+	return NewWebhook(settingslisters.NewPodPresetLister(indexer), recorder), recorder
+}
+
+func newCreatePodRequest(t *testing.T, pod *corev1.Pod) *admissionv1beta1.AdmissionRequest {
+	raw, err := json.Marshal(pod)
+	if err != nil {
+		t.Fatalf("unexpected error encoding pod: %v", err)
+	}
+	return &admissionv1beta1.AdmissionRequest{
+		UID:       types.UID("test-uid"),
+		Resource:  podResource,
+		Namespace: pod.Namespace,
+		Operation: admissionv1beta1.Create,
+		Object:    runtime.RawExtension{Raw: raw},
+	}
+}
+
+// applyPatch applies the patch of the response to the pod of the request.
+func applyPatch(t *testing.T, req *admissionv1beta1.AdmissionRequest, resp *admissionv1beta1.AdmissionResponse) *corev1.Pod {
+	patch, err := jsonpatch.DecodePatch(resp.Patch)
+	if err != nil {
+		t.Fatalf("unexpected error decoding patch: %v", err)
+	}
+	patched, err := patch.Apply(req.Object.Raw)
+	if err != nil {
+		t.Fatalf("unexpected error applying patch: %v", err)
+	}
+	pod := &corev1.Pod{}
+	if err := json.Unmarshal(patched, pod); err != nil {
+		t.Fatalf("unexpected error decoding patched pod: %v", err)
+	}
+	return pod
+}
+
+// TestAdmitAppliesPodPresets verifies that the patch returned for a new pod
+// merges every matching PodPreset and records them in annotations.
+func TestAdmitAppliesPodPresets(t *testing.T) {
+	env := newTestPodPreset("env")
+	env.Spec.Env = []corev1.EnvVar{{Name: "DB_HOST", Value: "remote"}}
+	env.Spec.EnvFrom = []corev1.EnvFromSource{{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "creds"}}}}
+	volume := newTestPodPreset("volume")
+	volume.Spec.Volumes = []corev1.Volume{{Name: "creds", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "creds"}}}}
+	volume.Spec.VolumeMounts = []corev1.VolumeMount{{Name: "creds", MountPath: "/creds"}}
+	otherNamespace := newTestPodPreset("other")
+	otherNamespace.Namespace = "other-ns"
+	otherNamespace.Spec.Env = []corev1.EnvVar{{Name: "OTHER", Value: "value"}}
+
+	webhook, recorder := newWebhookForTest(t, env, volume, otherNamespace)
+
+	req := newCreatePodRequest(t, newTestPod())
+	resp := webhook.Admit(req)
+	if !resp.Allowed {
+		t.Fatalf("pod should have been allowed: %v", resp.Result)
+	}
+	if resp.PatchType == nil || *resp.PatchType != admissionv1beta1.PatchTypeJSONPatch {
+		t.Fatalf("unexpected patch type: %v", resp.PatchType)
+	}
+
+	pod := applyPatch(t, req, resp)
+	ctr := pod.Spec.Containers[0]
+	if !reflect.DeepEqual(env.Spec.Env, ctr.Env) {
+		t.Errorf("unexpected env; expected %v, got %v", env.Spec.Env, ctr.Env)
+	}
+	if !reflect.DeepEqual(env.Spec.EnvFrom, ctr.EnvFrom) {
+		t.Errorf("unexpected env sources; expected %v, got %v", env.Spec.EnvFrom, ctr.EnvFrom)
+	}
+	if !reflect.DeepEqual(volume.Spec.VolumeMounts, ctr.VolumeMounts) {
+		t.Errorf("unexpected volume mounts; expected %v, got %v", volume.Spec.VolumeMounts, ctr.VolumeMounts)
+	}
+	if !reflect.DeepEqual(volume.Spec.Volumes, pod.Spec.Volumes) {
+		t.Errorf("unexpected volumes; expected %v, got %v", volume.Spec.Volumes, pod.Spec.Volumes)
+	}
+	for _, name := range []string{"env", "volume"} {
+		if _, ok := pod.Annotations[AppliedAnnotation(name)]; !ok {
+			t.Errorf("missing applied annotation for PodPreset %q", name)
+		}
+	}
+	if _, ok := pod.Annotations[AppliedAnnotation("other")]; ok {
+		t.Errorf("PodPreset from another namespace should not have been applied")
+	}
+	if len(recorder.Events) != 0 {
+		t.Errorf("unexpected events: %v", <-recorder.Events)
+	}
+}
+
+// TestAdmitRecordsConflicts verifies that a conflicting PodPreset is skipped,
+// a warning event is recorded and the other PodPresets are still applied.
+func TestAdmitRecordsConflicts(t *testing.T) {
+	conflicting := newTestPodPreset("conflicting")
+	conflicting.Spec.Env = []corev1.EnvVar{{Name: "DB_HOST", Value: "remote"}}
+	valid := newTestPodPreset("valid")
+	valid.Spec.Env = []corev1.EnvVar{{Name: "DB_PORT", Value: "5432"}}
+
+	webhook, recorder := newWebhookForTest(t, conflicting, valid)
+
+	pod := newTestPod()
+	pod.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "DB_HOST", Value: "local"}}
+	req := newCreatePodRequest(t, pod)
+	resp := webhook.Admit(req)
+	if !resp.Allowed {
+		t.Fatalf("pod should have been allowed: %v", resp.Result)
+	}
+
+	patched := applyPatch(t, req, resp)
+	expectedEnv := []corev1.EnvVar{{Name: "DB_HOST", Value: "local"}, {Name: "DB_PORT", Value: "5432"}}
+	if !reflect.DeepEqual(expectedEnv, patched.Spec.Containers[0].Env) {
+		t.Errorf("unexpected env; expected %v, got %v", expectedEnv, patched.Spec.Containers[0].Env)
+	}
+	if _, ok := patched.Annotations[AppliedAnnotation("conflicting")]; ok {
+		t.Errorf("conflicting PodPreset should not have been recorded as applied")
+	}
+
+	select {
+	case event := <-recorder.Events:
+		if !strings.HasPrefix(event, corev1.EventTypeWarning+" "+conflictReason) {
+			t.Errorf("unexpected event: %v", event)
+		}
+	default:
+		t.Errorf("expected a conflict event to be recorded")
+	}
+}
+
+// TestAdmitIgnoredRequests verifies that requests the webhook does not act on
+// are allowed without a patch.
+func TestAdmitIgnoredRequests(t *testing.T) {
+	pp := newTestPodPreset("preset")
+	pp.Spec.Env = []corev1.EnvVar{{Name: "DB_HOST", Value: "remote"}}
+	webhook, _ := newWebhookForTest(t, pp)
+
+	excluded := newTestPod()
+	excluded.Annotations = map[string]string{ExcludeAnnotation: "true"}
+	update := newCreatePodRequest(t, newTestPod())
+	update.Operation = admissionv1beta1.Update
+	subresource := newCreatePodRequest(t, newTestPod())
+	subresource.SubResource = "status"
+	unlabeled := newTestPod()
+	unlabeled.Labels = nil
+
+	cases := []struct {
+		name string
+		req  *admissionv1beta1.AdmissionRequest
+	}{
+		{name: "excluded pod", req: newCreatePodRequest(t, excluded)},
+		{name: "update", req: update},
+		{name: "subresource", req: subresource},
+		{name: "no matching PodPreset", req: newCreatePodRequest(t, unlabeled)},
+	}
+
+	for _, tc := range cases {
+		resp := webhook.Admit(tc.req)
+		if !resp.Allowed {
+			t.Errorf("%v: request should have been allowed: %v", tc.name, resp.Result)
+		}
+		if resp.Patch != nil {
+			t.Errorf("%v: unexpected patch: %s", tc.name, resp.Patch)
+		}
+	}
+}
+
+// failingPodPresetLister is a PodPresetLister whose List methods always fail,
+// like the lister of a cache that cannot be read.
+type failingPodPresetLister struct{}
+
+func (failingPodPresetLister) List(labels.Selector) ([]*settingsv1alpha1.PodPreset, error) {
+	return nil, errors.New("cache is broken")
+}
+
+func (l failingPodPresetLister) PodPresets(string) settingslisters.PodPresetNamespaceLister {
+	return l
+}
+
+func (failingPodPresetLister) Get(string) (*settingsv1alpha1.PodPreset, error) {
+	return nil, errors.New("cache is broken")
+}
+
+// TestAdmitFailsOpen verifies that a pod is admitted unmodified when the
+// PodPresets cannot be listed.
+func TestAdmitFailsOpen(t *testing.T) {
+	webhook := NewWebhook(failingPodPresetLister{}, record.NewFakeRecorder(10))
+
+	resp := webhook.Admit(newCreatePodRequest(t, newTestPod()))
+	if !resp.Allowed {
+		t.Fatalf("pod should have been allowed: %v", resp.Result)
+	}
+	if resp.Patch != nil {
+		t.Fatalf("unexpected patch: %s", resp.Patch)
+	}
+}
+
+// TestServeHTTP verifies that the webhook answers an AdmissionReview with the
+// UID of the request.
+func TestServeHTTP(t *testing.T) {
+	pp := newTestPodPreset("preset")
+	pp.Spec.Env = []corev1.EnvVar{{Name: "DB_HOST", Value: "remote"}}
+	webhook, _ := newWebhookForTest(t, pp)
+
+	body, err := json.Marshal(admissionv1beta1.AdmissionReview{Request: newCreatePodRequest(t, newTestPod())})
+	if err != nil {
+		t.Fatalf("unexpected error encoding AdmissionReview: %v", err)
+	}
+	r := httptest.NewRequest(http.MethodPost, "/podpresets", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	rw := httptest.NewRecorder()
+	webhook.ServeHTTP(rw, r)
+
+	if e, a := http.StatusOK, rw.Code; e != a {
+		t.Fatalf("unexpected status code; expected %v, got %v", e, a)
+	}
+	review := admissionv1beta1.AdmissionReview{}
+	if err := json.Unmarshal(rw.Body.Bytes(), &review); err != nil {
+		t.Fatalf("unexpected error decoding response: %v", err)
+	}
+	if review.Response == nil {
+		t.Fatal("expected a response")
+	}
+	if e, a := types.UID("test-uid"), review.Response.UID; e != a {
+		t.Errorf("unexpected UID; expected %v, got %v", e, a)
+	}
+	if !review.Response.Allowed || len(review.Response.Patch) == 0 {
+		t.Errorf("expected an allowed response with a patch, got %+v", review.Response)
+	}
+
+	r = httptest.NewRequest(http.MethodPost, "/podpresets", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "text/plain")
+	rw = httptest.NewRecorder()
+	webhook.ServeHTTP(rw, r)
+	if e, a := http.StatusUnsupportedMediaType, rw.Code; e != a {
+		t.Errorf("unexpected status code for wrong content type; expected %v, got %v", e, a)
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authsarcheck
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+
+	authorizationapi "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/admission"
+	kubeclientset "k8s.io/client-go/kubernetes"
+
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServiceBindingAuthSarCheck"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewSARCheck()
+	})
+}
+
+// sarcheck is an implementation of admission.Interface.
+// It enforces that the user creating or updating a binding is allowed to
+// create Secrets in the namespaces the Secret of the binding is copied into.
+type sarcheck struct {
+	*admission.Handler
+	client kubeclientset.Interface
+}
+
+var _ = scadmission.WantsKubeClientSet(&sarcheck{})
+
+func (s *sarcheck) Admit(a admission.Attributes) error {
+	// need to wait for our caches to warm
+	if !s.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+	// only care about bindings
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetResource().GroupResource() != servicecatalog.Resource("servicebindings") {
+		return nil
+	}
+	// the spec cannot be changed through the subresources
+	if a.GetSubresource() != "" {
+		return nil
+	}
+	binding, ok := a.GetObject().(*servicecatalog.ServiceBinding)
+	if !ok {
+		return errors.NewBadRequest("Resource was marked with kind ServiceBinding, but was unable to be converted")
+	}
+
+	// Only the namespaces added by an update need to be checked, the user
+	// that added the other ones was allowed to.
+	checked := map[string]bool{}
+	if a.GetOperation() == admission.Update {
+		if oldBinding, ok := a.GetOldObject().(*servicecatalog.ServiceBinding); ok {
+			for _, namespace := range oldBinding.Spec.SecretCopyNamespaces {
+				checked[namespace] = true
+			}
+		}
+	}
+
+	userInfo := a.GetUserInfo()
+	for _, namespace := range binding.Spec.SecretCopyNamespaces {
+		if checked[namespace] {
+			continue
+		}
+		checked[namespace] = true
+
+		glog.V(5).Infof("ServiceBinding %s/%s: evaluating access to Secrets of copy namespace %q", binding.Namespace, binding.Name, namespace)
+		sar := &authorizationapi.SubjectAccessReview{
+			Spec: authorizationapi.SubjectAccessReviewSpec{
+				ResourceAttributes: &authorizationapi.ResourceAttributes{
+					Namespace: namespace,
+					Verb:      "create",
+					Group:     corev1.SchemeGroupVersion.Group,
+					Version:   corev1.SchemeGroupVersion.Version,
+					Resource:  corev1.ResourceSecrets.String(),
+				},
+				User:   userInfo.GetName(),
+				Groups: userInfo.GetGroups(),
+				Extra:  scadmission.ConvertToSARExtra(userInfo.GetExtra()),
+				UID:    userInfo.GetUID(),
+			},
+		}
+		sar, err := s.client.AuthorizationV1().SubjectAccessReviews().Create(sar)
+		if err != nil {
+			return err
+		}
+
+		if !sar.Status.Allowed {
+			return admission.NewForbidden(a, fmt.Errorf("binding forbidden to copy its secret into namespace %q: Reason: %s, EvaluationError: %s", namespace, sar.Status.Reason, sar.Status.EvaluationError))
+		}
+	}
+	return nil
+}
+
+// NewSARCheck creates a new subject access review check admission control handler
+func NewSARCheck() (admission.Interface, error) {
+	return &sarcheck{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+	}, nil
+}
+
+func (s *sarcheck) SetKubeClientSet(client kubeclientset.Interface) {
+	s.client = client
+}
+
+func (s *sarcheck) ValidateInitialization() error {
+	if s.client == nil {
+		return fmt.Errorf("missing client")
+	}
+	return nil
+}
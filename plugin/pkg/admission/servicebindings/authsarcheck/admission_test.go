@@ -0,0 +1,162 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authsarcheck
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/admission"
+	"k8s.io/apiserver/pkg/authentication/user"
+
+	authorizationapi "k8s.io/api/authorization/v1"
+	kubeinformers "k8s.io/client-go/informers"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	core "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+)
+
+// newHandlerForTest returns a configured handler for testing.
+func newHandlerForTest(kubeClient kubeclientset.Interface) (admission.Interface, kubeinformers.SharedInformerFactory, error) {
+	kf := kubeinformers.NewSharedInformerFactory(kubeClient, 5*time.Minute)
+	handler, err := NewSARCheck()
+	if err != nil {
+		return nil, kf, err
+	}
+	pluginInitializer := scadmission.NewPluginInitializer(nil, nil, kubeClient, kf)
+	pluginInitializer.Initialize(handler)
+	err = admission.ValidateInitialization(handler)
+	return handler, kf, err
+}
+
+// newMockKubeClientForTest creates a mock kubernetes client that is configured
+// to allow SAR creations for any namespace but "forbidden-ns".
+func newMockKubeClientForTest() *kubefake.Clientset {
+	mockClient := &kubefake.Clientset{}
+	mockClient.AddReactor("create", "subjectaccessreviews", func(action core.Action) (bool, runtime.Object, error) {
+		sar := action.(core.CreateAction).GetObject().(*authorizationapi.SubjectAccessReview)
+		mysar := &authorizationapi.SubjectAccessReview{
+			Status: authorizationapi.SubjectAccessReviewStatus{
+				Allowed: sar.Spec.ResourceAttributes.Namespace != "forbidden-ns",
+				Reason:  "seemed friendly enough",
+			},
+		}
+		return true, mysar, nil
+	})
+	return mockClient
+}
+
+func newBindingForTest(namespaces ...string) *servicecatalog.ServiceBinding {
+	return &servicecatalog.ServiceBinding{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-binding",
+			Namespace: "test-ns",
+		},
+		Spec: servicecatalog.ServiceBindingSpec{
+			SecretCopyNamespaces: namespaces,
+		},
+	}
+}
+
+// TestAdmissionServiceBinding tests Admit to ensure that the result from the
+// SAR checks is properly checked.
+func TestAdmissionServiceBinding(t *testing.T) {
+	userInfo := &user.DefaultInfo{
+		Name:   "system:serviceaccount:test-ns:catalog",
+		Groups: []string{"system:serviceaccount", "system:serviceaccounts:test-ns"},
+	}
+
+	cases := []struct {
+		name         string
+		operation    admission.Operation
+		oldBinding   *servicecatalog.ServiceBinding
+		binding      *servicecatalog.ServiceBinding
+		expectedSARs int
+		allowed      bool
+	}{
+		{
+			name:      "binding without copies",
+			operation: admission.Create,
+			binding:   newBindingForTest(),
+			allowed:   true,
+		},
+		{
+			name:         "binding with allowed copies",
+			operation:    admission.Create,
+			binding:      newBindingForTest("ns-a", "ns-b"),
+			expectedSARs: 2,
+			allowed:      true,
+		},
+		{
+			name:         "binding with forbidden copy",
+			operation:    admission.Create,
+			binding:      newBindingForTest("ns-a", "forbidden-ns"),
+			expectedSARs: 2,
+			allowed:      false,
+		},
+		{
+			name:         "update adding a copy",
+			operation:    admission.Update,
+			oldBinding:   newBindingForTest("ns-a"),
+			binding:      newBindingForTest("ns-a", "ns-b"),
+			expectedSARs: 1,
+			allowed:      true,
+		},
+		{
+			name:       "update keeping a forbidden copy",
+			operation:  admission.Update,
+			oldBinding: newBindingForTest("forbidden-ns"),
+			binding:    newBindingForTest("forbidden-ns"),
+			allowed:    true,
+		},
+		{
+			name:         "update adding a forbidden copy",
+			operation:    admission.Update,
+			oldBinding:   newBindingForTest("ns-a"),
+			binding:      newBindingForTest("ns-a", "forbidden-ns"),
+			expectedSARs: 1,
+			allowed:      false,
+		},
+	}
+
+	for _, tc := range cases {
+		mockKubeClient := newMockKubeClientForTest()
+		handler, kubeInformerFactory, err := newHandlerForTest(mockKubeClient)
+		if err != nil {
+			t.Errorf("unexpected error initializing handler: %v", err)
+		}
+		kubeInformerFactory.Start(wait.NeverStop)
+
+		var oldObject runtime.Object
+		if tc.oldBinding != nil {
+			oldObject = tc.oldBinding
+		}
+		err = handler.(admission.MutationInterface).Admit(admission.NewAttributesRecord(tc.binding, oldObject, servicecatalog.Kind("ServiceBinding").WithVersion("version"), tc.binding.Namespace, tc.binding.Name, servicecatalog.Resource("servicebindings").WithVersion("version"), "", tc.operation, userInfo))
+		if err != nil && tc.allowed || err == nil && !tc.allowed {
+			t.Errorf("%v: unexpected result returned from admission handler: %v", tc.name, err)
+		}
+		if e, a := tc.expectedSARs, len(mockKubeClient.Actions()); e != a {
+			t.Errorf("%v: unexpected number of SARs: expected %v, got %v", tc.name, e, a)
+		}
+	}
+}
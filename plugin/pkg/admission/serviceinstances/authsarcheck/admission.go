@@ -0,0 +1,129 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package authsarcheck
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/golang/glog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+
+	authorizationapi "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apiserver/pkg/admission"
+	kubeclientset "k8s.io/client-go/kubernetes"
+
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServiceInstanceAuthSarCheck"
+
+	// AdoptVerb is the verb that a user must be allowed on serviceinstances
+	// to create an instance that adopts an instance of the broker.
+	AdoptVerb = "adopt"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewSARCheck()
+	})
+}
+
+// sarcheck is an implementation of admission.Interface.
+// It enforces that the user creating an instance that adopts an instance of
+// the broker is allowed to adopt instances in its namespace. Adoption hands
+// over whatever instance the broker holds under the external ID, so it must
+// not be open to everyone allowed to create instances.
+type sarcheck struct {
+	*admission.Handler
+	client kubeclientset.Interface
+}
+
+var _ = scadmission.WantsKubeClientSet(&sarcheck{})
+
+func (s *sarcheck) Admit(a admission.Attributes) error {
+	// need to wait for our caches to warm
+	if !s.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+	// only care about instances
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetResource().GroupResource() != servicecatalog.Resource("serviceinstances") {
+		return nil
+	}
+	// the adoption cannot be set through the subresources
+	if a.GetSubresource() != "" {
+		return nil
+	}
+	instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+	if !ok {
+		return errors.NewBadRequest("Resource was marked with kind ServiceInstance, but was unable to be converted")
+	}
+	if instance.Spec.Adoption == nil {
+		return nil
+	}
+
+	glog.V(5).Infof("ServiceInstance %s/%s: evaluating access to adopt instances", instance.Namespace, instance.Name)
+	userInfo := a.GetUserInfo()
+	sar := &authorizationapi.SubjectAccessReview{
+		Spec: authorizationapi.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationapi.ResourceAttributes{
+				Namespace: instance.Namespace,
+				Verb:      AdoptVerb,
+				Group:     servicecatalog.GroupName,
+				Resource:  "serviceinstances",
+				Name:      instance.Name,
+			},
+			User:   userInfo.GetName(),
+			Groups: userInfo.GetGroups(),
+			Extra:  scadmission.ConvertToSARExtra(userInfo.GetExtra()),
+			UID:    userInfo.GetUID(),
+		},
+	}
+	sar, err := s.client.AuthorizationV1().SubjectAccessReviews().Create(sar)
+	if err != nil {
+		return err
+	}
+
+	if !sar.Status.Allowed {
+		return admission.NewForbidden(a, fmt.Errorf("instance forbidden to adopt an instance of the broker: Reason: %s, EvaluationError: %s", sar.Status.Reason, sar.Status.EvaluationError))
+	}
+	return nil
+}
+
+// NewSARCheck creates a new subject access review check admission control handler
+func NewSARCheck() (admission.Interface, error) {
+	// The adoption of an instance is immutable, so it is only checked when
+	// the instance is created.
+	return &sarcheck{
+		Handler: admission.NewHandler(admission.Create),
+	}, nil
+}
+
+func (s *sarcheck) SetKubeClientSet(client kubeclientset.Interface) {
+	s.client = client
+}
+
+func (s *sarcheck) ValidateInitialization() error {
+	if s.client == nil {
+		return fmt.Errorf("missing client")
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletionprotection
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/golang/glog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/admission"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	internalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/internalversion"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServiceInstanceDeletionProtection"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewDeletionProtection()
+	})
+}
+
+// deletionProtection is an implementation of admission.Interface.
+// It rejects the deletion of ServiceInstances whose DeletionProtection is
+// set. When a collection of ServiceInstances is deleted, as when their
+// namespace is deleted, the whole deletion is rejected if one of them is
+// protected. Admission attributes do not carry the selectors of a collection
+// deletion, so every ServiceInstance of the namespace is checked, including
+// those that the selectors exclude.
+type deletionProtection struct {
+	*admission.Handler
+	instanceLister internalversion.ServiceInstanceLister
+}
+
+var _ = scadmission.WantsInternalServiceCatalogInformerFactory(&deletionProtection{})
+
+func (d *deletionProtection) Admit(a admission.Attributes) error {
+	// we need to wait for our caches to warm
+	if !d.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+
+	// We only care about service Instances
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetResource().GroupResource() != servicecatalog.Resource("serviceinstances") || a.GetSubresource() != "" {
+		return nil
+	}
+
+	var instances []*servicecatalog.ServiceInstance
+	if a.GetName() == "" {
+		list, err := d.instanceLister.ServiceInstances(a.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			return admission.NewForbidden(a, err)
+		}
+		instances = list
+	} else {
+		instance, err := d.instanceLister.ServiceInstances(a.GetNamespace()).Get(a.GetName())
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return admission.NewForbidden(a, err)
+		}
+		instances = append(instances, instance)
+	}
+
+	for _, instance := range instances {
+		if instance.Spec.DeletionProtection {
+			glog.V(4).Infof("Rejecting the deletion of ServiceInstance %s/%s, its deletion protection is enabled", instance.Namespace, instance.Name)
+			return admission.NewForbidden(a, fmt.Errorf("ServiceInstance %s/%s is protected against deletion; set spec.deletionProtection to false to delete it", instance.Namespace, instance.Name))
+		}
+	}
+	return nil
+}
+
+func (d *deletionProtection) SetInternalServiceCatalogInformerFactory(f informers.SharedInformerFactory) {
+	instanceInformer := f.Servicecatalog().InternalVersion().ServiceInstances()
+	d.instanceLister = instanceInformer.Lister()
+	d.SetReadyFunc(instanceInformer.Informer().HasSynced)
+}
+
+func (d *deletionProtection) ValidateInitialization() error {
+	if d.instanceLister == nil {
+		return fmt.Errorf("missing serviceInstanceLister")
+	}
+	return nil
+}
+
+// NewDeletionProtection creates a new admission control handler that rejects
+// the deletion of protected ServiceInstances.
+func NewDeletionProtection() (admission.Interface, error) {
+	return &deletionProtection{
+		Handler: admission.NewHandler(admission.Delete),
+	}, nil
+}
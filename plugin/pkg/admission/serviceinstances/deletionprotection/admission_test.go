@@ -0,0 +1,114 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package deletionprotection
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/admission"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+)
+
+const testNamespace = "test-ns"
+
+// newHandlerForTest returns a configured handler for testing, with caches
+// holding the given instances.
+func newHandlerForTest(t *testing.T, objects ...runtime.Object) admission.MutationInterface {
+	internalClient := fake.NewSimpleClientset(objects...)
+	f := informers.NewSharedInformerFactory(internalClient, 5*time.Minute)
+	handler, err := NewDeletionProtection()
+	if err != nil {
+		t.Fatalf("unexpected error creating handler: %v", err)
+	}
+	pluginInitializer := scadmission.NewPluginInitializer(internalClient, f, nil, nil)
+	pluginInitializer.Initialize(handler)
+	if err := admission.ValidateInitialization(handler); err != nil {
+		t.Fatalf("unexpected error initializing handler: %v", err)
+	}
+	f.Start(wait.NeverStop)
+	f.WaitForCacheSync(wait.NeverStop)
+	return handler.(admission.MutationInterface)
+}
+
+func newServiceInstance(name string, protected bool) *servicecatalog.ServiceInstance {
+	return &servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec:       servicecatalog.ServiceInstanceSpec{DeletionProtection: protected},
+	}
+}
+
+func TestAdmitServiceInstanceDelete(t *testing.T) {
+	cases := []struct {
+		name      string
+		instances []runtime.Object
+		delete    string
+		allowed   bool
+	}{
+		{
+			name:      "unprotected instance",
+			instances: []runtime.Object{newServiceInstance("instance", false)},
+			delete:    "instance",
+			allowed:   true,
+		},
+		{
+			name:      "protected instance",
+			instances: []runtime.Object{newServiceInstance("instance", true)},
+			delete:    "instance",
+		},
+		{
+			name:      "other protected instance",
+			instances: []runtime.Object{newServiceInstance("instance", false), newServiceInstance("other", true)},
+			delete:    "instance",
+			allowed:   true,
+		},
+		{
+			name:    "missing instance",
+			delete:  "instance",
+			allowed: true,
+		},
+		{
+			name:      "collection without protected instances",
+			instances: []runtime.Object{newServiceInstance("instance", false), newServiceInstance("other", false)},
+			allowed:   true,
+		},
+		{
+			name:      "collection with a protected instance",
+			instances: []runtime.Object{newServiceInstance("instance", false), newServiceInstance("other", true)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newHandlerForTest(t, tc.instances...)
+			err := handler.Admit(admission.NewAttributesRecord(nil, nil, servicecatalog.Kind("ServiceInstance").WithVersion("version"), testNamespace, tc.delete, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", admission.Delete, nil))
+			if tc.allowed && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tc.allowed && err == nil {
+				t.Fatal("expected the deletion to be rejected")
+			}
+		})
+	}
+}
@@ -0,0 +1,340 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/golang/glog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset"
+	servicecataloginternalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/typed/servicecatalog/internalversion"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	internalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/internalversion"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/parameters/planresolver"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServiceInstanceQuota"
+
+	// maxChargeAttempts is the number of times the usage of a quota is
+	// updated before the request is rejected because of conflicts.
+	maxChargeAttempts = 3
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewQuota()
+	})
+}
+
+// quota is an implementation of admission.Interface.
+// It rejects the creation of ServiceInstances, and the plan changes of
+// ServiceInstances, that would exceed a limit of one of the
+// ServiceInstanceQuotas of their namespace.
+//
+// Like a ResourceQuota, every admitted instance is charged to the usage
+// reported in the status of the quotas, with an update conditioned on their
+// resourceVersion, so that instances created at the same time cannot
+// together exceed a limit. The usage of a limit is the larger of the one in
+// the status and the number of instances in the cache of the plugin, without
+// the instances being deleted. The instances whose plan cannot be resolved
+// are rejected by the quotas with limits that select a class, a plan or a
+// cost, and only counted against the limits without selectors.
+type quota struct {
+	*admission.Handler
+
+	// quotaLister is only set when the ServiceInstanceQuota feature is
+	// enabled.
+	quotaLister    internalversion.ServiceInstanceQuotaLister
+	quotaClient    servicecataloginternalversion.ServiceInstanceQuotasGetter
+	instanceLister internalversion.ServiceInstanceLister
+
+	// plans only resolves the plans of namespaced brokers when the
+	// NamespacedServiceBroker feature is enabled.
+	plans planresolver.Resolver
+}
+
+var _ = scadmission.WantsInternalServiceCatalogInformerFactory(&quota{})
+var _ = scadmission.WantsInternalServiceCatalogClientSet(&quota{})
+
+func (q *quota) Admit(a admission.Attributes) error {
+	// we need to wait for our caches to warm
+	if !q.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+
+	// We only care about service Instances
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetResource().GroupResource() != servicecatalog.Resource("serviceinstances") || a.GetSubresource() != "" {
+		return nil
+	}
+	if q.quotaLister == nil {
+		return nil
+	}
+	instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+	}
+	if instance.DeletionTimestamp != nil {
+		return nil
+	}
+
+	var oldInstance *servicecatalog.ServiceInstance
+	if a.GetOperation() == admission.Update {
+		oldInstance, ok = a.GetOldObject().(*servicecatalog.ServiceInstance)
+		if !ok {
+			return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+		}
+		if reflect.DeepEqual(oldInstance.Spec.PlanReference, instance.Spec.PlanReference) {
+			return nil
+		}
+	}
+
+	quotas, err := q.quotaLister.ServiceInstanceQuotas(instance.Namespace).List(labels.Everything())
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	if len(quotas) == 0 {
+		return nil
+	}
+
+	selects, resolved, err := q.selector(instance)
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	oldSelects := func(v1beta1.ServiceInstanceQuotaLimit) bool { return false }
+	if oldInstance != nil {
+		oldSelects, _, err = q.selector(oldInstance)
+		if err != nil {
+			return admission.NewForbidden(a, err)
+		}
+	}
+
+	// The selectors of the other instances of the namespace are only
+	// resolved when a limit selects the instance.
+	var others []func(v1beta1.ServiceInstanceQuotaLimit) bool
+	countOthers := func(limit v1beta1.ServiceInstanceQuotaLimit) (int64, error) {
+		if others == nil {
+			others, err = q.otherSelectors(instance)
+			if err != nil {
+				return 0, err
+			}
+		}
+		var used int64
+		for _, other := range others {
+			if other(limit) {
+				used++
+			}
+		}
+		return used, nil
+	}
+
+	for _, quota := range quotas {
+		if err := q.charge(a, quota, instance, resolved, selects, oldSelects, countOthers); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// charge charges the given instance to the usage of the limits of the given
+// quota that select it, but did not select the old instance, and rejects it
+// if it exceeds one of them. The usage is updated with the resourceVersion
+// of the quota, which is read again when the update conflicts.
+func (q *quota) charge(a admission.Attributes, quota *servicecatalog.ServiceInstanceQuota, instance *servicecatalog.ServiceInstance, resolved bool, selects, oldSelects func(v1beta1.ServiceInstanceQuotaLimit) bool, countOthers func(v1beta1.ServiceInstanceQuotaLimit) (int64, error)) error {
+	for attempt := 1; ; attempt++ {
+		used := make([]servicecatalog.ServiceInstanceQuotaUsage, len(quota.Spec.Limits))
+		charged := false
+		for i := range quota.Spec.Limits {
+			var limit v1beta1.ServiceInstanceQuotaLimit
+			if err := v1beta1.Convert_servicecatalog_ServiceInstanceQuotaLimit_To_v1beta1_ServiceInstanceQuotaLimit(&quota.Spec.Limits[i], &limit, nil); err != nil {
+				return admission.NewForbidden(a, err)
+			}
+			used[i] = servicecatalog.ServiceInstanceQuotaUsage{Name: limit.Name}
+			for _, usage := range quota.Status.Used {
+				if usage.Name == limit.Name {
+					used[i].Instances = usage.Instances
+				}
+			}
+
+			// The limits that select a class, a plan or a cost cannot
+			// tell whether they select an unresolved plan.
+			if !resolved && (limit.ServiceClassExternalName != "" || limit.ServicePlanExternalName != "" || limit.Free != nil) {
+				glog.V(4).Infof("Rejecting ServiceInstance %s/%s, its plan cannot be resolved for limit %q of ServiceInstanceQuota %q", instance.Namespace, instance.Name, limit.Name, quota.Name)
+				return admission.NewForbidden(a, fmt.Errorf("ServiceInstanceQuota %q: limit %q cannot be checked, the plan of the instance cannot be resolved", quota.Name, limit.Name))
+			}
+			// A plan change does not count against the limits that
+			// already selected the instance.
+			if !selects(limit) || oldSelects(limit) {
+				continue
+			}
+
+			cached, err := countOthers(limit)
+			if err != nil {
+				return admission.NewForbidden(a, err)
+			}
+			if cached > used[i].Instances {
+				used[i].Instances = cached
+			}
+			if used[i].Instances >= limit.MaxInstances {
+				glog.V(4).Infof("Rejecting ServiceInstance %s/%s, it exceeds limit %q of ServiceInstanceQuota %q", instance.Namespace, instance.Name, limit.Name, quota.Name)
+				return admission.NewForbidden(a, fmt.Errorf("exceeded ServiceInstanceQuota %q: limit %q allows %d instances, %d are used", quota.Name, limit.Name, limit.MaxInstances, used[i].Instances))
+			}
+			used[i].Instances++
+			charged = true
+		}
+		if !charged {
+			return nil
+		}
+
+		toUpdate := quota.DeepCopy()
+		toUpdate.Status.Used = used
+		_, err := q.quotaClient.ServiceInstanceQuotas(quota.Namespace).UpdateStatus(toUpdate)
+		if err == nil {
+			return nil
+		}
+		if !apierrors.IsConflict(err) || attempt == maxChargeAttempts {
+			glog.V(4).Infof("Rejecting ServiceInstance %s/%s, ServiceInstanceQuota %q could not be charged: %v", instance.Namespace, instance.Name, quota.Name, err)
+			return admission.NewForbidden(a, fmt.Errorf("unable to charge ServiceInstanceQuota %q: %v", quota.Name, err))
+		}
+		quota, err = q.quotaClient.ServiceInstanceQuotas(quota.Namespace).Get(quota.Name, metav1.GetOptions{})
+		if err != nil {
+			return admission.NewForbidden(a, err)
+		}
+	}
+}
+
+// selector returns a function that returns whether a limit selects the given
+// instance, and whether the plan of the instance was resolved.
+func (q *quota) selector(instance *servicecatalog.ServiceInstance) (func(v1beta1.ServiceInstanceQuotaLimit) bool, bool, error) {
+	class, plan, err := q.plans.ServiceClassAndPlanSpecs(instance)
+	if err != nil {
+		return nil, false, err
+	}
+	if class == nil || plan == nil {
+		glog.V(5).Infof("ServiceInstance %s/%s: the plan %+v could not be resolved", instance.Namespace, instance.Name, instance.Spec.PlanReference)
+		return func(limit v1beta1.ServiceInstanceQuotaLimit) bool {
+			return limit.Selects("", "", nil)
+		}, false, nil
+	}
+	free := plan.Free
+	return func(limit v1beta1.ServiceInstanceQuotaLimit) bool {
+		return limit.Selects(class.ExternalName, plan.ExternalName, &free)
+	}, true, nil
+}
+
+// otherSelectors returns the selectors of the instances of the namespace of
+// the given instance, but for the instance itself and the instances being
+// deleted.
+func (q *quota) otherSelectors(instance *servicecatalog.ServiceInstance) ([]func(v1beta1.ServiceInstanceQuotaLimit) bool, error) {
+	instances, err := q.instanceLister.ServiceInstances(instance.Namespace).List(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	selectors := []func(v1beta1.ServiceInstanceQuotaLimit) bool{}
+	for _, other := range instances {
+		if other.Name == instance.Name || other.DeletionTimestamp != nil {
+			continue
+		}
+		selector, _, err := q.selector(other)
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+	}
+	return selectors, nil
+}
+
+func (q *quota) SetInternalServiceCatalogInformerFactory(f informers.SharedInformerFactory) {
+	instanceInformer := f.Servicecatalog().InternalVersion().ServiceInstances()
+	clusterServiceClassInformer := f.Servicecatalog().InternalVersion().ClusterServiceClasses()
+	clusterServicePlanInformer := f.Servicecatalog().InternalVersion().ClusterServicePlans()
+	q.instanceLister = instanceInformer.Lister()
+	q.plans.ClusterServiceClassLister = clusterServiceClassInformer.Lister()
+	q.plans.ClusterServicePlanLister = clusterServicePlanInformer.Lister()
+
+	readyFunc := func() bool {
+		return instanceInformer.Informer().HasSynced() &&
+			clusterServiceClassInformer.Informer().HasSynced() &&
+			clusterServicePlanInformer.Informer().HasSynced()
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
+		serviceClassInformer := f.Servicecatalog().InternalVersion().ServiceClasses()
+		servicePlanInformer := f.Servicecatalog().InternalVersion().ServicePlans()
+		q.plans.ServiceClassLister = serviceClassInformer.Lister()
+		q.plans.ServicePlanLister = servicePlanInformer.Lister()
+		clusterReadyFunc := readyFunc
+		readyFunc = func() bool {
+			return clusterReadyFunc() && serviceClassInformer.Informer().HasSynced() && servicePlanInformer.Informer().HasSynced()
+		}
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServiceInstanceQuota) {
+		quotaInformer := f.Servicecatalog().InternalVersion().ServiceInstanceQuotas()
+		q.quotaLister = quotaInformer.Lister()
+		plansReadyFunc := readyFunc
+		readyFunc = func() bool {
+			return plansReadyFunc() && quotaInformer.Informer().HasSynced()
+		}
+	}
+
+	q.SetReadyFunc(readyFunc)
+}
+
+func (q *quota) SetInternalServiceCatalogClientSet(client internalclientset.Interface) {
+	q.quotaClient = client.Servicecatalog()
+}
+
+func (q *quota) ValidateInitialization() error {
+	if q.quotaClient == nil {
+		return errors.New("missing service catalog client")
+	}
+	if q.instanceLister == nil {
+		return errors.New("missing service instance lister")
+	}
+	if q.plans.ClusterServiceClassLister == nil {
+		return errors.New("missing cluster service class lister")
+	}
+	if q.plans.ClusterServicePlanLister == nil {
+		return errors.New("missing cluster service plan lister")
+	}
+	return nil
+}
+
+// NewQuota creates a new admission control handler that enforces the
+// ServiceInstanceQuotas of namespaces.
+func NewQuota() (admission.Interface, error) {
+	return &quota{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+	}, nil
+}
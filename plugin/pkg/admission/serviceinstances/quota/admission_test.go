@@ -0,0 +1,427 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+)
+
+const testNamespace = "test-ns"
+
+func newClusterServiceClass(name string) *servicecatalog.ClusterServiceClass {
+	return &servicecatalog.ClusterServiceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-id"},
+		Spec: servicecatalog.ClusterServiceClassSpec{
+			CommonServiceClassSpec: servicecatalog.CommonServiceClassSpec{
+				ExternalName: name,
+				ExternalID:   name + "-id",
+			},
+		},
+	}
+}
+
+func newClusterServicePlan(class, name string, free bool) *servicecatalog.ClusterServicePlan {
+	return &servicecatalog.ClusterServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: class + "-" + name + "-id"},
+		Spec: servicecatalog.ClusterServicePlanSpec{
+			CommonServicePlanSpec: servicecatalog.CommonServicePlanSpec{
+				ExternalName: name,
+				ExternalID:   class + "-" + name + "-id",
+				Free:         free,
+			},
+			ClusterServiceClassRef: servicecatalog.ClusterObjectReference{Name: class + "-id"},
+		},
+	}
+}
+
+// newHandlerForTest returns a configured handler for testing, with caches
+// holding the test classes and plans, and the given objects, and the fake
+// client it uses.
+func newHandlerForTest(t *testing.T, objects ...runtime.Object) (admission.MutationInterface, *fake.Clientset) {
+	objects = append([]runtime.Object{
+		newClusterServiceClass("db"),
+		newClusterServicePlan("db", "free", true),
+		newClusterServicePlan("db", "paid", false),
+		newClusterServiceClass("queue"),
+		newClusterServicePlan("queue", "free", true),
+	}, objects...)
+
+	internalClient := fake.NewSimpleClientset(objects...)
+	f := informers.NewSharedInformerFactory(internalClient, 5*time.Minute)
+	handler, err := NewQuota()
+	if err != nil {
+		t.Fatalf("unexpected error creating handler: %v", err)
+	}
+	pluginInitializer := scadmission.NewPluginInitializer(internalClient, f, kubefake.NewSimpleClientset(), nil)
+	pluginInitializer.Initialize(handler)
+	if err := admission.ValidateInitialization(handler); err != nil {
+		t.Fatalf("unexpected error initializing handler: %v", err)
+	}
+	f.Start(wait.NeverStop)
+	f.WaitForCacheSync(wait.NeverStop)
+	return handler.(admission.MutationInterface), internalClient
+}
+
+func newServiceInstance(name, class, plan string) *servicecatalog.ServiceInstance {
+	return &servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+		Spec: servicecatalog.ServiceInstanceSpec{
+			PlanReference: servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: class,
+				ClusterServicePlanExternalName:  plan,
+			},
+		},
+	}
+}
+
+func newDeletingServiceInstance(name, class, plan string) *servicecatalog.ServiceInstance {
+	instance := newServiceInstance(name, class, plan)
+	instance.DeletionTimestamp = &metav1.Time{}
+	return instance
+}
+
+func newServiceInstanceQuota(limits ...servicecatalog.ServiceInstanceQuotaLimit) *servicecatalog.ServiceInstanceQuota {
+	return &servicecatalog.ServiceInstanceQuota{
+		ObjectMeta: metav1.ObjectMeta{Name: "quota", Namespace: testNamespace},
+		Spec:       servicecatalog.ServiceInstanceQuotaSpec{Limits: limits},
+	}
+}
+
+func newServiceInstanceQuotaWithUsage(used []servicecatalog.ServiceInstanceQuotaUsage, limits ...servicecatalog.ServiceInstanceQuotaLimit) *servicecatalog.ServiceInstanceQuota {
+	quota := newServiceInstanceQuota(limits...)
+	quota.Status.Used = used
+	return quota
+}
+
+func admitServiceInstance(handler admission.MutationInterface, instance, oldInstance *servicecatalog.ServiceInstance, operation admission.Operation) error {
+	var oldObj runtime.Object
+	if oldInstance != nil {
+		oldObj = oldInstance
+	}
+	return handler.Admit(admission.NewAttributesRecord(instance, oldObj, servicecatalog.Kind("ServiceInstance").WithVersion("version"), instance.Namespace, instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", operation, nil))
+}
+
+// chargedUsage returns the usage of the quota that the handler charged with
+// the given fake client, or nil if it did not charge it.
+func chargedUsage(client *fake.Clientset) []servicecatalog.ServiceInstanceQuotaUsage {
+	var used []servicecatalog.ServiceInstanceQuotaUsage
+	for _, action := range client.Actions() {
+		update, ok := action.(clienttesting.UpdateAction)
+		if !ok || action.GetSubresource() != "status" {
+			continue
+		}
+		used = update.GetObject().(*servicecatalog.ServiceInstanceQuota).Status.Used
+	}
+	return used
+}
+
+func TestAdmitServiceInstance(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServiceInstanceQuota))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServiceInstanceQuota))
+
+	notFree := false
+	cases := []struct {
+		name        string
+		operation   admission.Operation
+		instance    *servicecatalog.ServiceInstance
+		oldInstance *servicecatalog.ServiceInstance
+		objects     []runtime.Object
+		err         string
+		// used is the usage the quota is charged with, if any.
+		used []servicecatalog.ServiceInstanceQuotaUsage
+	}{
+		{
+			name:      "no quota",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "paid"),
+		},
+		{
+			name:      "within the limits",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "paid"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "all", MaxInstances: 2}),
+				newServiceInstance("existing", "db", "free"),
+			},
+			used: []servicecatalog.ServiceInstanceQuotaUsage{{Name: "all", Instances: 2}},
+		},
+		{
+			name:      "usage in the status exceeds the limit",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "paid"),
+			objects: []runtime.Object{
+				newServiceInstanceQuotaWithUsage([]servicecatalog.ServiceInstanceQuotaUsage{{Name: "all", Instances: 2}}, servicecatalog.ServiceInstanceQuotaLimit{Name: "all", MaxInstances: 2}),
+				newServiceInstance("existing", "db", "free"),
+			},
+			err: `exceeded ServiceInstanceQuota "quota": limit "all" allows 2 instances, 2 are used`,
+		},
+		{
+			name:      "instance limit exceeded",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "free"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "all", MaxInstances: 1}),
+				newServiceInstance("existing", "queue", "free"),
+			},
+			err: `exceeded ServiceInstanceQuota "quota": limit "all" allows 1 instances, 1 are used`,
+		},
+		{
+			name:      "instances being deleted are not counted",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "free"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "all", MaxInstances: 1}),
+				newDeletingServiceInstance("existing", "queue", "free"),
+			},
+			used: []servicecatalog.ServiceInstanceQuotaUsage{{Name: "all", Instances: 1}},
+		},
+		{
+			name:      "instances of other classes are not counted",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "free"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "db", ServiceClassExternalName: "db", MaxInstances: 1}),
+				newServiceInstance("existing", "queue", "free"),
+			},
+			used: []servicecatalog.ServiceInstanceQuotaUsage{{Name: "db", Instances: 1}},
+		},
+		{
+			name:      "class limit exceeded",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "free"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "db", ServiceClassExternalName: "db", MaxInstances: 1}),
+				newServiceInstance("existing", "db", "paid"),
+			},
+			err: `limit "db" allows 1 instances, 1 are used`,
+		},
+		{
+			name:      "plan limit exceeded",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "paid"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "paid-db", ServiceClassExternalName: "db", ServicePlanExternalName: "paid", MaxInstances: 0}),
+			},
+			err: `limit "paid-db" allows 0 instances, 0 are used`,
+		},
+		{
+			name:      "free plan within the non-free limit",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "free"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "non-free", Free: &notFree, MaxInstances: 0}),
+			},
+		},
+		{
+			name:      "unresolved plan within the limits without selectors",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "unknown"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "all", MaxInstances: 1}),
+			},
+			used: []servicecatalog.ServiceInstanceQuotaUsage{{Name: "all", Instances: 1}},
+		},
+		{
+			name:      "unresolved plan with a limit on a class",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "unknown"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "queue", ServiceClassExternalName: "queue", MaxInstances: 1}),
+			},
+			err: `limit "queue" cannot be checked, the plan of the instance cannot be resolved`,
+		},
+		{
+			name:      "unresolved plan with a limit on the cost",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "unknown"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "non-free", Free: &notFree, MaxInstances: 1}),
+			},
+			err: `limit "non-free" cannot be checked, the plan of the instance cannot be resolved`,
+		},
+		{
+			name:      "non-free limit exceeded",
+			operation: admission.Create,
+			instance:  newServiceInstance("new", "db", "paid"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "non-free", Free: &notFree, MaxInstances: 0}),
+			},
+			err: `limit "non-free" allows 0 instances, 0 are used`,
+		},
+		{
+			name:        "update without plan change",
+			operation:   admission.Update,
+			instance:    newServiceInstance("existing", "db", "paid"),
+			oldInstance: newServiceInstance("existing", "db", "paid"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "non-free", Free: &notFree, MaxInstances: 0}),
+				newServiceInstance("existing", "db", "paid"),
+			},
+		},
+		{
+			name:        "plan change within the limits that already select the instance",
+			operation:   admission.Update,
+			instance:    newServiceInstance("existing", "db", "paid"),
+			oldInstance: newServiceInstance("existing", "db", "free"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "db", ServiceClassExternalName: "db", MaxInstances: 1}),
+				newServiceInstance("existing", "db", "free"),
+			},
+		},
+		{
+			name:        "plan change charged to the limits that newly select the instance",
+			operation:   admission.Update,
+			instance:    newServiceInstance("existing", "db", "paid"),
+			oldInstance: newServiceInstance("existing", "db", "free"),
+			objects: []runtime.Object{
+				newServiceInstanceQuotaWithUsage([]servicecatalog.ServiceInstanceQuotaUsage{{Name: "db", Instances: 1}},
+					servicecatalog.ServiceInstanceQuotaLimit{Name: "db", ServiceClassExternalName: "db", MaxInstances: 1},
+					servicecatalog.ServiceInstanceQuotaLimit{Name: "non-free", Free: &notFree, MaxInstances: 1}),
+				newServiceInstance("existing", "db", "free"),
+			},
+			used: []servicecatalog.ServiceInstanceQuotaUsage{{Name: "db", Instances: 1}, {Name: "non-free", Instances: 1}},
+		},
+		{
+			name:        "plan change exceeding a limit",
+			operation:   admission.Update,
+			instance:    newServiceInstance("existing", "db", "paid"),
+			oldInstance: newServiceInstance("existing", "db", "free"),
+			objects: []runtime.Object{
+				newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "non-free", Free: &notFree, MaxInstances: 1}),
+				newServiceInstance("existing", "db", "free"),
+				newServiceInstance("other", "db", "paid"),
+			},
+			err: `limit "non-free" allows 1 instances, 1 are used`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler, client := newHandlerForTest(t, tc.objects...)
+			client.ClearActions()
+			err := admitServiceInstance(handler, tc.instance, tc.oldInstance, tc.operation)
+			if e, a := tc.used, chargedUsage(client); !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected charged usage: expected %+v, got %+v", e, a)
+			}
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error to contain %q, got %q", tc.err, err.Error())
+			}
+		})
+	}
+}
+
+func TestAdmitServiceInstanceFeatureDisabled(t *testing.T) {
+	handler, _ := newHandlerForTest(t, newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "all", MaxInstances: 0}))
+	err := admitServiceInstance(handler, newServiceInstance("new", "db", "free"), nil, admission.Create)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAdmitServiceInstanceConflict(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServiceInstanceQuota))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServiceInstanceQuota))
+
+	cases := []struct {
+		name      string
+		conflicts int
+		// charged is the usage of the quota when the first update
+		// conflicts.
+		charged int64
+		err     string
+		used    []servicecatalog.ServiceInstanceQuotaUsage
+	}{
+		{
+			name:      "charged after a conflict",
+			conflicts: 1,
+			used:      []servicecatalog.ServiceInstanceQuotaUsage{{Name: "all", Instances: 1}},
+		},
+		{
+			name:      "limit exceeded by a concurrent charge",
+			conflicts: 1,
+			charged:   2,
+			err:       `limit "all" allows 2 instances, 2 are used`,
+		},
+		{
+			name:      "too many conflicts",
+			conflicts: maxChargeAttempts,
+			err:       `unable to charge ServiceInstanceQuota "quota"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			quota := newServiceInstanceQuota(servicecatalog.ServiceInstanceQuotaLimit{Name: "all", MaxInstances: 2})
+			handler, client := newHandlerForTest(t, quota)
+			conflicts := 0
+			client.PrependReactor("update", "serviceinstancequotas", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if action.GetSubresource() != "status" || conflicts == tc.conflicts {
+					return false, nil, nil
+				}
+				conflicts++
+				return true, nil, apierrors.NewConflict(servicecatalog.Resource("serviceinstancequotas"), quota.Name, errors.New("the object has been modified"))
+			})
+			client.PrependReactor("get", "serviceinstancequotas", func(clienttesting.Action) (bool, runtime.Object, error) {
+				return true, newServiceInstanceQuotaWithUsage([]servicecatalog.ServiceInstanceQuotaUsage{{Name: "all", Instances: tc.charged}}, quota.Spec.Limits...), nil
+			})
+			client.ClearActions()
+
+			err := admitServiceInstance(handler, newServiceInstance("new", "db", "free"), nil, admission.Create)
+			if e, a := tc.used, chargedUsage(client); tc.err == "" && !reflect.DeepEqual(e, a) {
+				t.Fatalf("unexpected charged usage: expected %+v, got %+v", e, a)
+			}
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error to contain %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package visibility
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/golang/glog"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubeinformers "k8s.io/client-go/informers"
+	kubeclientset "k8s.io/client-go/kubernetes"
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	internalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/internalversion"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+	"github.com/kubernetes-incubator/service-catalog/plugin/pkg/admission/parameters/planresolver"
+)
+
+const (
+	// PluginName is name of admission plug-in
+	PluginName = "ServiceVisibilityPolicy"
+)
+
+// Register registers a plugin
+func Register(plugins *admission.Plugins) {
+	plugins.Register(PluginName, func(io.Reader) (admission.Interface, error) {
+		return NewVisibility()
+	})
+}
+
+// visibility is an implementation of admission.Interface.
+// It rejects the creation of ServiceInstances, and the plan changes of
+// ServiceInstances, whose ClusterServiceClass or ClusterServicePlan is not
+// visible in their namespace under the ClusterServiceVisibilityPolicies.
+//
+// The class and plan of an instance are resolved from the cache of the
+// plugin. When they cannot be resolved, the external names that the instance
+// refers to them by are used instead, and the instance is rejected if it
+// refers to them by Kubernetes name only: its visibility cannot be checked.
+// The instances of the classes and plans of namespaced brokers are always
+// admitted.
+type visibility struct {
+	*admission.Handler
+	client          kubeclientset.Interface
+	namespaceLister listerscorev1.NamespaceLister
+
+	// policyLister is only set when the ServiceVisibilityPolicy feature is
+	// enabled.
+	policyLister internalversion.ClusterServiceVisibilityPolicyLister
+	plans        planresolver.Resolver
+
+	// namespacesSynced and catalogSynced report whether the caches set by
+	// each informer factory are synced.
+	namespacesSynced func() bool
+	catalogSynced    func() bool
+}
+
+var _ = scadmission.WantsInternalServiceCatalogInformerFactory(&visibility{})
+var _ = scadmission.WantsKubeInformerFactory(&visibility{})
+var _ = scadmission.WantsKubeClientSet(&visibility{})
+
+func (v *visibility) Admit(a admission.Attributes) error {
+	// we need to wait for our caches to warm
+	if !v.WaitForReady() {
+		return admission.NewForbidden(a, fmt.Errorf("not yet ready to handle request"))
+	}
+
+	// We only care about service Instances
+	if a.GetResource().Group != servicecatalog.GroupName || a.GetResource().GroupResource() != servicecatalog.Resource("serviceinstances") || a.GetSubresource() != "" {
+		return nil
+	}
+	if v.policyLister == nil {
+		return nil
+	}
+	instance, ok := a.GetObject().(*servicecatalog.ServiceInstance)
+	if !ok {
+		return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+	}
+	if instance.DeletionTimestamp != nil || !instance.Spec.ClusterServicePlanSpecified() {
+		return nil
+	}
+	if a.GetOperation() == admission.Update {
+		oldInstance, ok := a.GetOldObject().(*servicecatalog.ServiceInstance)
+		if !ok {
+			return apierrors.NewBadRequest("Resource was marked with kind ServiceInstance but was unable to be converted")
+		}
+		if reflect.DeepEqual(oldInstance.Spec.PlanReference, instance.Spec.PlanReference) {
+			return nil
+		}
+	}
+
+	internalPolicies, err := v.policyLister.List(labels.Everything())
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	if len(internalPolicies) == 0 {
+		return nil
+	}
+	policies := make([]*v1beta1.ClusterServiceVisibilityPolicy, len(internalPolicies))
+	for i, internalPolicy := range internalPolicies {
+		policies[i] = &v1beta1.ClusterServiceVisibilityPolicy{}
+		if err := v1beta1.Convert_servicecatalog_ClusterServiceVisibilityPolicy_To_v1beta1_ClusterServiceVisibilityPolicy(internalPolicy, policies[i], nil); err != nil {
+			return admission.NewForbidden(a, err)
+		}
+	}
+
+	className, planName, err := v.externalNames(instance)
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	if className == "" || planName == "" {
+		glog.V(4).Infof("Rejecting ServiceInstance %s/%s, the class or plan of %c could not be resolved", instance.Namespace, instance.Name, instance.Spec.PlanReference)
+		return admission.NewForbidden(a, fmt.Errorf("the visibility of %c cannot be checked: the ClusterServiceClass or ClusterServicePlan could not be resolved", instance.Spec.PlanReference))
+	}
+
+	namespaceLabels, err := v.namespaceLabels(instance.Namespace)
+	if err != nil {
+		return admission.NewForbidden(a, err)
+	}
+	if !v1beta1.ServiceVisible(policies, className, "", namespaceLabels) {
+		glog.V(4).Infof("Rejecting ServiceInstance %s/%s, ClusterServiceClass %q is not visible in its namespace", instance.Namespace, instance.Name, className)
+		return admission.NewForbidden(a, fmt.Errorf("ClusterServiceClass %q is not visible in namespace %q", className, instance.Namespace))
+	}
+	if !v1beta1.ServiceVisible(policies, className, planName, namespaceLabels) {
+		glog.V(4).Infof("Rejecting ServiceInstance %s/%s, ClusterServicePlan %q is not visible in its namespace", instance.Namespace, instance.Name, planName)
+		return admission.NewForbidden(a, fmt.Errorf("ClusterServicePlan %q of ClusterServiceClass %q is not visible in namespace %q", planName, className, instance.Namespace))
+	}
+	return nil
+}
+
+// externalNames returns the external names of the class and plan of the
+// given instance, or the ones the instance refers to them by when they cannot
+// be resolved.
+func (v *visibility) externalNames(instance *servicecatalog.ServiceInstance) (string, string, error) {
+	class, plan, err := v.plans.ServiceClassAndPlanSpecs(instance)
+	if err != nil {
+		return "", "", err
+	}
+	if class == nil || plan == nil {
+		return instance.Spec.ClusterServiceClassExternalName, instance.Spec.ClusterServicePlanExternalName, nil
+	}
+	return class.ExternalName, plan.ExternalName, nil
+}
+
+// namespaceLabels returns the labels of the given namespace, from the cache
+// or, when the namespace is not in the cache yet, from storage.
+func (v *visibility) namespaceLabels(name string) (labels.Set, error) {
+	namespace, err := v.namespaceLister.Get(name)
+	if apierrors.IsNotFound(err) {
+		namespace, err = v.client.CoreV1().Namespaces().Get(name, metav1.GetOptions{})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return labels.Set(namespace.Labels), nil
+}
+
+func (v *visibility) SetInternalServiceCatalogInformerFactory(f informers.SharedInformerFactory) {
+	clusterServiceClassInformer := f.Servicecatalog().InternalVersion().ClusterServiceClasses()
+	clusterServicePlanInformer := f.Servicecatalog().InternalVersion().ClusterServicePlans()
+	v.plans.ClusterServiceClassLister = clusterServiceClassInformer.Lister()
+	v.plans.ClusterServicePlanLister = clusterServicePlanInformer.Lister()
+
+	v.catalogSynced = func() bool {
+		return clusterServiceClassInformer.Informer().HasSynced() &&
+			clusterServicePlanInformer.Informer().HasSynced()
+	}
+
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.ServiceVisibilityPolicy) {
+		policyInformer := f.Servicecatalog().InternalVersion().ClusterServiceVisibilityPolicies()
+		v.policyLister = policyInformer.Lister()
+		plansSynced := v.catalogSynced
+		v.catalogSynced = func() bool {
+			return plansSynced() && policyInformer.Informer().HasSynced()
+		}
+	}
+
+	v.SetReadyFunc(v.hasSynced)
+}
+
+func (v *visibility) SetKubeInformerFactory(f kubeinformers.SharedInformerFactory) {
+	namespaceInformer := f.Core().V1().Namespaces()
+	v.namespaceLister = namespaceInformer.Lister()
+	v.namespacesSynced = namespaceInformer.Informer().HasSynced
+	v.SetReadyFunc(v.hasSynced)
+}
+
+func (v *visibility) SetKubeClientSet(client kubeclientset.Interface) {
+	v.client = client
+}
+
+// hasSynced returns whether the caches of the plugin are synced.
+func (v *visibility) hasSynced() bool {
+	return v.namespacesSynced != nil && v.namespacesSynced() &&
+		v.catalogSynced != nil && v.catalogSynced()
+}
+
+func (v *visibility) ValidateInitialization() error {
+	if v.client == nil {
+		return errors.New("missing client")
+	}
+	if v.namespaceLister == nil {
+		return errors.New("missing namespace lister")
+	}
+	if v.plans.ClusterServiceClassLister == nil {
+		return errors.New("missing cluster service class lister")
+	}
+	if v.plans.ClusterServicePlanLister == nil {
+		return errors.New("missing cluster service plan lister")
+	}
+	return nil
+}
+
+// NewVisibility creates a new admission control handler that enforces the
+// ClusterServiceVisibilityPolicies.
+func NewVisibility() (admission.Interface, error) {
+	return &visibility{
+		Handler: admission.NewHandler(admission.Create, admission.Update),
+	}, nil
+}
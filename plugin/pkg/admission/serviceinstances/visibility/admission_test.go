@@ -0,0 +1,239 @@
+/*
+Copyright 2018 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package visibility
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubeinformers "k8s.io/client-go/informers"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
+	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
+	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
+)
+
+func newNamespace(name, env string) *corev1.Namespace {
+	return &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"env": env}},
+	}
+}
+
+func newClusterServiceClass(name string) *servicecatalog.ClusterServiceClass {
+	return &servicecatalog.ClusterServiceClass{
+		ObjectMeta: metav1.ObjectMeta{Name: name + "-id"},
+		Spec: servicecatalog.ClusterServiceClassSpec{
+			CommonServiceClassSpec: servicecatalog.CommonServiceClassSpec{
+				ExternalName: name,
+				ExternalID:   name + "-id",
+			},
+		},
+	}
+}
+
+func newClusterServicePlan(class, name string) *servicecatalog.ClusterServicePlan {
+	return &servicecatalog.ClusterServicePlan{
+		ObjectMeta: metav1.ObjectMeta{Name: class + "-" + name + "-id"},
+		Spec: servicecatalog.ClusterServicePlanSpec{
+			CommonServicePlanSpec: servicecatalog.CommonServicePlanSpec{
+				ExternalName: name,
+				ExternalID:   class + "-" + name + "-id",
+			},
+			ClusterServiceClassRef: servicecatalog.ClusterObjectReference{Name: class + "-id"},
+		},
+	}
+}
+
+func newPolicy(class, plan, env string) *servicecatalog.ClusterServiceVisibilityPolicy {
+	return &servicecatalog.ClusterServiceVisibilityPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: class + "-" + plan + "-" + env},
+		Spec: servicecatalog.ClusterServiceVisibilityPolicySpec{
+			ClusterServiceClassExternalName: class,
+			ClusterServicePlanExternalName:  plan,
+			NamespaceSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"env": env},
+			},
+		},
+	}
+}
+
+// newHandlerForTest returns a configured handler for testing, with caches
+// holding the test namespaces, classes and plans, and the given policies.
+func newHandlerForTest(t *testing.T, policies ...runtime.Object) admission.MutationInterface {
+	objects := append([]runtime.Object{
+		newClusterServiceClass("db"),
+		newClusterServicePlan("db", "small"),
+		newClusterServicePlan("db", "large"),
+	}, policies...)
+
+	internalClient := fake.NewSimpleClientset(objects...)
+	kubeClient := kubefake.NewSimpleClientset(newNamespace("prod", "prod"), newNamespace("dev", "dev"))
+	f := informers.NewSharedInformerFactory(internalClient, 5*time.Minute)
+	kf := kubeinformers.NewSharedInformerFactory(kubeClient, 5*time.Minute)
+	handler, err := NewVisibility()
+	if err != nil {
+		t.Fatalf("unexpected error creating handler: %v", err)
+	}
+	pluginInitializer := scadmission.NewPluginInitializer(internalClient, f, kubeClient, kf)
+	pluginInitializer.Initialize(handler)
+	if err := admission.ValidateInitialization(handler); err != nil {
+		t.Fatalf("unexpected error initializing handler: %v", err)
+	}
+	f.Start(wait.NeverStop)
+	kf.Start(wait.NeverStop)
+	f.WaitForCacheSync(wait.NeverStop)
+	kf.WaitForCacheSync(wait.NeverStop)
+	return handler.(admission.MutationInterface)
+}
+
+func newServiceInstance(namespace, class, plan string) *servicecatalog.ServiceInstance {
+	return &servicecatalog.ServiceInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: namespace},
+		Spec: servicecatalog.ServiceInstanceSpec{
+			PlanReference: servicecatalog.PlanReference{
+				ClusterServiceClassExternalName: class,
+				ClusterServicePlanExternalName:  plan,
+			},
+		},
+	}
+}
+
+func TestAdmitServiceInstance(t *testing.T) {
+	utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.ServiceVisibilityPolicy))
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.ServiceVisibilityPolicy))
+
+	cases := []struct {
+		name        string
+		operation   admission.Operation
+		instance    *servicecatalog.ServiceInstance
+		oldInstance *servicecatalog.ServiceInstance
+		policies    []runtime.Object
+		err         string
+	}{
+		{
+			name:      "no policies",
+			operation: admission.Create,
+			instance:  newServiceInstance("dev", "db", "small"),
+		},
+		{
+			name:      "visible class",
+			operation: admission.Create,
+			instance:  newServiceInstance("prod", "db", "small"),
+			policies:  []runtime.Object{newPolicy("db", "", "prod")},
+		},
+		{
+			name:      "hidden class",
+			operation: admission.Create,
+			instance:  newServiceInstance("dev", "db", "small"),
+			policies:  []runtime.Object{newPolicy("db", "", "prod")},
+			err:       `ClusterServiceClass "db" is not visible in namespace "dev"`,
+		},
+		{
+			name:      "hidden plan",
+			operation: admission.Create,
+			instance:  newServiceInstance("dev", "db", "large"),
+			policies:  []runtime.Object{newPolicy("db", "large", "prod")},
+			err:       `ClusterServicePlan "large" of ClusterServiceClass "db" is not visible in namespace "dev"`,
+		},
+		{
+			name:      "visible plan of a class with a hidden plan",
+			operation: admission.Create,
+			instance:  newServiceInstance("dev", "db", "small"),
+			policies:  []runtime.Object{newPolicy("db", "large", "prod")},
+		},
+		{
+			name:      "unresolved plan of a hidden class",
+			operation: admission.Create,
+			instance:  newServiceInstance("dev", "db", "medium"),
+			policies:  []runtime.Object{newPolicy("db", "", "prod")},
+			err:       `ClusterServiceClass "db" is not visible in namespace "dev"`,
+		},
+		{
+			name:      "unresolved class",
+			operation: admission.Create,
+			instance: &servicecatalog.ServiceInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: "instance", Namespace: "prod"},
+				Spec: servicecatalog.ServiceInstanceSpec{
+					PlanReference: servicecatalog.PlanReference{
+						ClusterServiceClassName: "missing-id",
+						ClusterServicePlanName:  "missing-small-id",
+					},
+				},
+			},
+			policies: []runtime.Object{newPolicy("db", "", "prod")},
+			err:      "could not be resolved",
+		},
+		{
+			name:        "update without plan change",
+			operation:   admission.Update,
+			instance:    newServiceInstance("dev", "db", "large"),
+			oldInstance: newServiceInstance("dev", "db", "large"),
+			policies:    []runtime.Object{newPolicy("db", "large", "prod")},
+		},
+		{
+			name:        "plan change to a hidden plan",
+			operation:   admission.Update,
+			instance:    newServiceInstance("dev", "db", "large"),
+			oldInstance: newServiceInstance("dev", "db", "small"),
+			policies:    []runtime.Object{newPolicy("db", "large", "prod")},
+			err:         `ClusterServicePlan "large" of ClusterServiceClass "db" is not visible in namespace "dev"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := newHandlerForTest(t, tc.policies...)
+			var oldObj runtime.Object
+			if tc.oldInstance != nil {
+				oldObj = tc.oldInstance
+			}
+			err := handler.Admit(admission.NewAttributesRecord(tc.instance, oldObj, servicecatalog.Kind("ServiceInstance").WithVersion("version"), tc.instance.Namespace, tc.instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", tc.operation, nil))
+			if tc.err == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected an error")
+			}
+			if !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error to contain %q, got %q", tc.err, err.Error())
+			}
+		})
+	}
+}
+
+func TestAdmitServiceInstanceFeatureDisabled(t *testing.T) {
+	handler := newHandlerForTest(t, newPolicy("db", "", "prod"))
+	instance := newServiceInstance("dev", "db", "small")
+	err := handler.Admit(admission.NewAttributesRecord(instance, nil, servicecatalog.Kind("ServiceInstance").WithVersion("version"), instance.Namespace, instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", admission.Create, nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
@@ -25,12 +25,14 @@ import (
 
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
 	internalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/listers_generated/servicecatalog/internalversion"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
 	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 )
 
 const (
@@ -53,6 +55,10 @@ type denyPlanChangeIfNotUpdatable struct {
 	scLister       internalversion.ClusterServiceClassLister
 	spLister       internalversion.ClusterServicePlanLister
 	instanceLister internalversion.ServiceInstanceLister
+
+	// serviceClassLister is only set when the NamespacedServiceBroker
+	// feature is enabled.
+	serviceClassLister internalversion.ServiceClassLister
 }
 
 var _ = scadmission.WantsInternalServiceCatalogInformerFactory(&denyPlanChangeIfNotUpdatable{})
@@ -72,21 +78,37 @@ func (d *denyPlanChangeIfNotUpdatable) Admit(a admission.Attributes) error {
 		return apierrors.NewBadRequest("Resource was marked with kind Instance but was unable to be converted")
 	}
 
-	if instance.Spec.ClusterServiceClassRef == nil {
+	var (
+		className     string
+		planUpdatable bool
+		err           error
+	)
+	switch {
+	case instance.Spec.ClusterServiceClassRef != nil:
+		className = instance.Spec.ClusterServiceClassRef.Name
+		var sc *servicecatalog.ClusterServiceClass
+		if sc, err = d.scLister.Get(className); err == nil {
+			planUpdatable = sc.Spec.PlanUpdatable
+		}
+	case instance.Spec.ServiceClassRef != nil && d.serviceClassLister != nil:
+		className = instance.Spec.ServiceClassRef.Name
+		var sc *servicecatalog.ServiceClass
+		if sc, err = d.serviceClassLister.ServiceClasses(instance.Namespace).Get(className); err == nil {
+			planUpdatable = sc.Spec.PlanUpdatable
+		}
+	default:
 		return nil // user chose a service class that doesn't exist
 	}
-
-	sc, err := d.scLister.Get(instance.Spec.ClusterServiceClassRef.Name)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			glog.V(5).Infof("Could not locate service class %v, can not determine if UpdateablePlan.", instance.Spec.ClusterServiceClassRef.Name)
+			glog.V(5).Infof("Could not locate service class %v, can not determine if UpdateablePlan.", className)
 			return nil // should this be `return err`? why would we allow the instance in if we cannot determine it is updatable?
 		}
 		glog.Error(err)
 		return admission.NewForbidden(a, err)
 	}
 
-	if sc.Spec.PlanUpdatable {
+	if planUpdatable {
 		return nil
 	}
 
@@ -98,23 +120,17 @@ func (d *denyPlanChangeIfNotUpdatable) Admit(a admission.Attributes) error {
 			return err
 		}
 
-		externalPlanNameUpdated := instance.Spec.ClusterServicePlanExternalName != origInstance.Spec.ClusterServicePlanExternalName
-		externalPlanIDUpdated := instance.Spec.ClusterServicePlanExternalID != origInstance.Spec.ClusterServicePlanExternalID
-		k8sPlanUpdated := instance.Spec.ClusterServicePlanName != origInstance.Spec.ClusterServicePlanName
+		externalPlanNameUpdated := instance.Spec.ClusterServicePlanExternalName != origInstance.Spec.ClusterServicePlanExternalName ||
+			instance.Spec.ServicePlanExternalName != origInstance.Spec.ServicePlanExternalName
+		externalPlanIDUpdated := instance.Spec.ClusterServicePlanExternalID != origInstance.Spec.ClusterServicePlanExternalID ||
+			instance.Spec.ServicePlanExternalID != origInstance.Spec.ServicePlanExternalID
+		k8sPlanUpdated := instance.Spec.ClusterServicePlanName != origInstance.Spec.ClusterServicePlanName ||
+			instance.Spec.ServicePlanName != origInstance.Spec.ServicePlanName
 		if externalPlanNameUpdated || externalPlanIDUpdated || k8sPlanUpdated {
-			var oldPlan, newPlan string
-			if externalPlanNameUpdated {
-				oldPlan = origInstance.Spec.ClusterServicePlanExternalName
-				newPlan = instance.Spec.ClusterServicePlanExternalName
-			} else if externalPlanIDUpdated {
-				oldPlan = origInstance.Spec.ClusterServicePlanExternalID
-				newPlan = instance.Spec.ClusterServicePlanExternalID
-			} else {
-				oldPlan = origInstance.Spec.ClusterServicePlanName
-				newPlan = instance.Spec.ClusterServicePlanName
-			}
+			oldPlan := origInstance.Spec.GetSpecifiedPlan()
+			newPlan := instance.Spec.GetSpecifiedPlan()
 			glog.V(4).Infof("update Service Instance %v/%v request specified Plan %v while original instance had %v", instance.Namespace, instance.Name, newPlan, oldPlan)
-			msg := fmt.Sprintf("The Service Class %v does not allow plan changes.", sc.Name)
+			msg := fmt.Sprintf("The Service Class %v does not allow plan changes.", className)
 			glog.Error(msg)
 			return admission.NewForbidden(a, errors.New(msg))
 		}
@@ -145,6 +161,15 @@ func (d *denyPlanChangeIfNotUpdatable) SetInternalServiceCatalogInformerFactory(
 		return scInformer.Informer().HasSynced() && instanceInformer.Informer().HasSynced() && spInformer.Informer().HasSynced()
 	}
 
+	if utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
+		serviceClassInformer := f.Servicecatalog().InternalVersion().ServiceClasses()
+		d.serviceClassLister = serviceClassInformer.Lister()
+		clusterReadyFunc := readyFunc
+		readyFunc = func() bool {
+			return clusterReadyFunc() && serviceClassInformer.Informer().HasSynced()
+		}
+	}
+
 	d.SetReadyFunc(readyFunc)
 }
 
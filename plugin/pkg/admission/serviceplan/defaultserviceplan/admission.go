@@ -27,12 +27,14 @@ import (
 	apimachineryv1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset"
 	servicecataloginternalversion "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/typed/servicecatalog/internalversion"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
 	scadmission "github.com/kubernetes-incubator/service-catalog/pkg/apiserver/admission"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 )
 
 const (
@@ -56,6 +58,11 @@ type defaultServicePlan struct {
 	*admission.Handler
 	scClient servicecataloginternalversion.ClusterServiceClassInterface
 	spClient servicecataloginternalversion.ClusterServicePlanInterface
+
+	// serviceClassClient and servicePlanClient are used for instances that
+	// reference a namespaced ServiceClass.
+	serviceClassClient servicecataloginternalversion.ServiceClassesGetter
+	servicePlanClient  servicecataloginternalversion.ServicePlansGetter
 }
 
 var _ = scadmission.WantsInternalServiceCatalogClientSet(&defaultServicePlan{})
@@ -77,6 +84,15 @@ func (d *defaultServicePlan) Admit(a admission.Attributes) error {
 		return nil
 	}
 
+	if instance.Spec.ServiceClassSpecified() {
+		// Validation rejects namespaced references when the feature is
+		// disabled, there is nothing to default here.
+		if !utilfeature.DefaultFeatureGate.Enabled(scfeatures.NamespacedServiceBroker) {
+			return nil
+		}
+		return d.handleDefaultServicePlan(a, instance)
+	}
+
 	// cannot find what we're trying to create an instance of
 	sc, err := d.getClusterServiceClassByPlanReference(a, &instance.Spec.PlanReference)
 	if err != nil {
@@ -134,6 +150,53 @@ func (d *defaultServicePlan) Admit(a admission.Attributes) error {
 	return nil
 }
 
+// handleDefaultServicePlan defaults the ServicePlan of an instance that
+// references a namespaced ServiceClass with a single ServicePlan.
+func (d *defaultServicePlan) handleDefaultServicePlan(a admission.Attributes, instance *servicecatalog.ServiceInstance) error {
+	sc, err := d.getServiceClassByPlanReference(a, instance.Namespace, &instance.Spec.PlanReference)
+	if err != nil {
+		if !apierrors.IsNotFound(err) {
+			return admission.NewForbidden(a, err)
+		}
+		msg := fmt.Sprintf("ServiceClass %c does not exist, can not figure out the default ServicePlan.",
+			instance.Spec.PlanReference)
+		glog.V(4).Info(msg)
+		return admission.NewForbidden(a, errors.New(msg))
+	}
+
+	plans, err := d.getServicePlansByServiceClassName(instance.Namespace, sc.Name)
+	if err != nil {
+		msg := fmt.Sprintf("Error listing ServicePlans for ServiceClass (K8S: %v ExternalName: %v) - retry and specify desired ServicePlan", sc.Name, sc.Spec.ExternalName)
+		glog.V(4).Infof(`ServiceInstance "%s/%s": %s`, instance.Namespace, instance.Name, msg)
+		return admission.NewForbidden(a, errors.New(msg))
+	}
+
+	if len(plans) <= 0 {
+		msg := fmt.Sprintf("no ServicePlans found at all for ServiceClass %q", sc.Spec.ExternalName)
+		glog.V(4).Infof(`ServiceInstance "%s/%s": %s`, instance.Namespace, instance.Name, msg)
+		return admission.NewForbidden(a, errors.New(msg))
+	}
+
+	if len(plans) > 1 {
+		msg := fmt.Sprintf("ServiceClass (K8S: %v ExternalName: %v) has more than one plan, PlanName must be specified", sc.Name, sc.Spec.ExternalName)
+		glog.V(4).Infof(`ServiceInstance "%s/%s": %s`, instance.Namespace, instance.Name, msg)
+		return admission.NewForbidden(a, errors.New(msg))
+	}
+
+	p := plans[0]
+	glog.V(4).Infof(`ServiceInstance "%s/%s": Using default plan %q (K8S: %q) for Service Class %q`,
+		instance.Namespace, instance.Name, p.Spec.ExternalName, p.Name, sc.Spec.ExternalName)
+	if instance.Spec.ServiceClassExternalName != "" {
+		instance.Spec.ServicePlanExternalName = p.Spec.ExternalName
+	} else if instance.Spec.ServiceClassExternalID != "" {
+		instance.Spec.ServicePlanExternalID = p.Spec.ExternalID
+	} else {
+		instance.Spec.ServicePlanName = p.Name
+	}
+
+	return nil
+}
+
 // NewDefaultClusterServicePlan creates a new admission control handler that
 // fills in a default Service Plan if omitted from Service Instance
 // creation request and if there exists only one plan in the
@@ -147,6 +210,8 @@ func NewDefaultClusterServicePlan() (admission.Interface, error) {
 func (d *defaultServicePlan) SetInternalServiceCatalogClientSet(f internalclientset.Interface) {
 	d.scClient = f.Servicecatalog().ClusterServiceClasses()
 	d.spClient = f.Servicecatalog().ClusterServicePlans()
+	d.serviceClassClient = f.Servicecatalog()
+	d.servicePlanClient = f.Servicecatalog()
 }
 
 func (d *defaultServicePlan) ValidateInitialization() error {
@@ -156,6 +221,12 @@ func (d *defaultServicePlan) ValidateInitialization() error {
 	if d.spClient == nil {
 		return errors.New("missing clusterserviceplan interface")
 	}
+	if d.serviceClassClient == nil {
+		return errors.New("missing serviceclass interface")
+	}
+	if d.servicePlanClient == nil {
+		return errors.New("missing serviceplan interface")
+	}
 	return nil
 }
 
@@ -214,3 +285,50 @@ func (d *defaultServicePlan) getClusterServicePlansByClusterServiceClassName(scN
 	r := servicePlans.Items
 	return r, err
 }
+
+func (d *defaultServicePlan) getServiceClassByPlanReference(a admission.Attributes, namespace string, ref *servicecatalog.PlanReference) (*servicecatalog.ServiceClass, error) {
+	if ref.ServiceClassName != "" {
+		glog.V(4).Infof("Fetching ServiceClass by k8s name %q", ref.ServiceClassName)
+		return d.serviceClassClient.ServiceClasses(namespace).Get(ref.ServiceClassName, apimachineryv1.GetOptions{})
+	}
+
+	filterField := ref.GetClassFilterFieldName()
+	filterValue := ref.GetSpecifiedClass()
+
+	glog.V(4).Infof("Fetching ServiceClass filtered by %q = %q", filterField, filterValue)
+	fieldSet := fields.Set{
+		filterField: filterValue,
+	}
+	fieldSelector := fields.SelectorFromSet(fieldSet).String()
+	listOpts := apimachineryv1.ListOptions{FieldSelector: fieldSelector}
+	serviceClasses, err := d.serviceClassClient.ServiceClasses(namespace).List(listOpts)
+	if err != nil {
+		glog.V(4).Infof("Listing ServiceClasses failed: %q", err)
+		return nil, err
+	}
+	if len(serviceClasses.Items) == 1 {
+		glog.V(4).Infof("Found single ServiceClass as %+v", serviceClasses.Items[0])
+		return &serviceClasses.Items[0], nil
+	}
+	msg := fmt.Sprintf("Could not find a single ServiceClass with %q = %q, found %v", filterField, filterValue, len(serviceClasses.Items))
+	glog.V(4).Info(msg)
+	return nil, admission.NewNotFound(a)
+}
+
+// getServicePlansByServiceClassName() returns a list of ServicePlans in the
+// given namespace for the specified service class name
+func (d *defaultServicePlan) getServicePlansByServiceClassName(namespace, scName string) ([]servicecatalog.ServicePlan, error) {
+	glog.V(4).Infof("Fetching ServicePlans by class name %q", scName)
+	fieldSet := fields.Set{
+		"spec.serviceClassRef.name": scName,
+	}
+	fieldSelector := fields.SelectorFromSet(fieldSet).String()
+	listOpts := apimachineryv1.ListOptions{FieldSelector: fieldSelector}
+	servicePlans, err := d.servicePlanClient.ServicePlans(namespace).List(listOpts)
+	if err != nil {
+		glog.Infof("Listing ServicePlans failed: %q", err)
+		return nil, err
+	}
+	glog.V(4).Infof("ServicePlans fetched by filtering classname: %+v", servicePlans.Items)
+	return servicePlans.Items, nil
+}
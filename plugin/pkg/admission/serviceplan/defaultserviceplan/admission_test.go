@@ -30,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/admission"
+	utilfeature "k8s.io/apiserver/pkg/util/feature"
 	core "k8s.io/client-go/testing"
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
@@ -37,6 +38,7 @@ import (
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset"
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/internalclientset/fake"
 	informers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/internalversion"
+	scfeatures "github.com/kubernetes-incubator/service-catalog/pkg/features"
 )
 
 // newHandlerForTest returns a configured handler for testing.
@@ -305,6 +307,91 @@ func TestWithNoPlanWorksWithSinglePlan(t *testing.T) {
 	}
 }
 
+// checks that the defaulting action works for an instance that references a
+// namespaced service class that only provides a single plan.
+func TestWithNoPlanWorksWithSingleNamespacedPlan(t *testing.T) {
+	err := utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=true", scfeatures.NamespacedServiceBroker))
+	if err != nil {
+		t.Fatalf("Failed to enable namespaced service broker feature: %v", err)
+	}
+	defer utilfeature.DefaultFeatureGate.Set(fmt.Sprintf("%v=false", scfeatures.NamespacedServiceBroker))
+
+	cases := []struct {
+		name          string
+		requestedPlan servicecatalog.PlanReference
+		resolvedPlan  servicecatalog.PlanReference
+	}{
+		{"external name",
+			servicecatalog.PlanReference{ServiceClassExternalName: "foo"},
+			servicecatalog.PlanReference{ServiceClassExternalName: "foo", ServicePlanExternalName: "bar"}},
+		{"external id",
+			servicecatalog.PlanReference{ServiceClassExternalID: "foo"},
+			servicecatalog.PlanReference{ServiceClassExternalID: "foo", ServicePlanExternalID: "12345"}},
+		{"k8s", servicecatalog.PlanReference{ServiceClassName: "foo-id"},
+			servicecatalog.PlanReference{ServiceClassName: "foo-id", ServicePlanName: "bar-id"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			sc := &servicecatalog.ServiceClass{
+				ObjectMeta: metav1.ObjectMeta{Name: "foo-id", Namespace: "dummy"},
+				Spec: servicecatalog.ServiceClassSpec{
+					CommonServiceClassSpec: servicecatalog.CommonServiceClassSpec{
+						ExternalID:   "foo-id",
+						ExternalName: "foo",
+					},
+				},
+			}
+			sp := &servicecatalog.ServicePlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "bar-id", Namespace: "dummy"},
+				Spec: servicecatalog.ServicePlanSpec{
+					CommonServicePlanSpec: servicecatalog.CommonServicePlanSpec{
+						ExternalName: "bar",
+						ExternalID:   "12345",
+					},
+					ServiceClassRef: servicecatalog.LocalObjectReference{
+						Name: "foo-id",
+					},
+				},
+			}
+
+			fakeClient := &fake.Clientset{}
+			fakeClient.AddReactor("get", "serviceclasses", func(action core.Action) (bool, runtime.Object, error) {
+				return true, sc, nil
+			})
+			fakeClient.AddReactor("list", "serviceclasses", func(action core.Action) (bool, runtime.Object, error) {
+				return true, &servicecatalog.ServiceClassList{Items: []servicecatalog.ServiceClass{*sc}}, nil
+			})
+			fakeClient.AddReactor("list", "serviceplans", func(action core.Action) (bool, runtime.Object, error) {
+				return true, &servicecatalog.ServicePlanList{Items: []servicecatalog.ServicePlan{*sp}}, nil
+			})
+
+			handler, informerFactory, err := newHandlerForTest(fakeClient)
+			if err != nil {
+				t.Errorf("unexpected error initializing handler: %v", err)
+			}
+			informerFactory.Start(wait.NeverStop)
+
+			instance := newServiceInstance("dummy")
+			instance.Spec.PlanReference = tc.requestedPlan
+
+			err = handler.(admission.MutationInterface).Admit(admission.NewAttributesRecord(&instance, nil, servicecatalog.Kind("ServiceInstance").WithVersion("version"), instance.Namespace, instance.Name, servicecatalog.Resource("serviceinstances").WithVersion("version"), "", admission.Create, nil))
+			if err != nil {
+				t.Errorf("unexpected error %q returned from admission handler", err)
+			}
+			assertPlanReference(t,
+				tc.resolvedPlan,
+				instance.Spec.PlanReference)
+
+			for _, action := range fakeClient.Actions() {
+				if e, a := "dummy", action.GetNamespace(); e != a {
+					t.Errorf("unexpected namespace for action %v: expected %q, got %q", action, e, a)
+				}
+			}
+		})
+	}
+}
+
 // checks that defaulting fails when there are multiple plans to choose from.
 func TestWithNoPlanFailsWithMultiplePlans(t *testing.T) {
 	cases := []struct {
@@ -29,6 +29,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilfeature "k8s.io/apiserver/pkg/util/feature"
+	kubeinformers "k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes/fake"
 	restclient "k8s.io/client-go/rest"
 	clientgotesting "k8s.io/client-go/testing"
@@ -44,6 +45,8 @@ import (
 
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog"
 	"github.com/kubernetes-incubator/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	fakebrokerclient "github.com/kubernetes-incubator/service-catalog/pkg/brokerclient/fake"
+	"github.com/kubernetes-incubator/service-catalog/pkg/brokerlimits"
 	"github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset"
 	clientsetsc "github.com/kubernetes-incubator/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
 	scinformers "github.com/kubernetes-incubator/service-catalog/pkg/client/informers_generated/externalversions"
@@ -192,7 +195,7 @@ func TestBindingFailure(t *testing.T) {
 
 // verifyUsernameInLastBrokerAction verifies that the originating identity sent in the request to the broker
 // included the specified username.
-func verifyUsernameInLastBrokerAction(t *testing.T, osbClient *fakeosb.FakeClient, actionType fakeosb.ActionType, username string) {
+func verifyUsernameInLastBrokerAction(t *testing.T, osbClient *fakebrokerclient.FakeClient, actionType fakeosb.ActionType, username string) {
 	brokerAction := getLastBrokerAction(t, osbClient, actionType)
 	var oi *osb.OriginatingIdentity
 	switch request := brokerAction.Request.(type) {
@@ -737,7 +740,7 @@ func newControllerTestTestController(ct *controllerTest) (
 	*fake.Clientset,
 	clientset.Interface,
 	*restclient.Config,
-	*fakeosb.FakeClient,
+	*fakebrokerclient.FakeClient,
 	controller.Controller,
 	informers.Interface,
 	func(),
@@ -755,8 +758,8 @@ func newControllerTestTestController(ct *controllerTest) (
 		return &servicecatalog.ClusterServiceBroker{}
 	})
 
-	fakeOSBClient := fakeosb.NewFakeClient(getTestHappyPathBrokerClientConfig())
-	brokerClFunc := fakeosb.ReturnFakeClientFunc(fakeOSBClient)
+	fakeOSBClient := fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{FakeClientConfiguration: getTestHappyPathBrokerClientConfig()})
+	brokerClFunc := fakebrokerclient.ReturnFakeClientFunc(fakeOSBClient)
 
 	// create informers
 	informerFactory := scinformers.NewSharedInformerFactory(catalogClient, 10*time.Second)
@@ -770,17 +773,31 @@ func newControllerTestTestController(ct *controllerTest) (
 	testController, err := controller.NewController(
 		fakeKubeClient,
 		catalogClient.ServicecatalogV1beta1(),
+		catalogClient.SettingsV1alpha1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
+		serviceCatalogSharedInformers.ServiceBrokers(),
 		serviceCatalogSharedInformers.ClusterServiceClasses(),
+		serviceCatalogSharedInformers.ServiceClasses(),
 		serviceCatalogSharedInformers.ServiceInstances(),
 		serviceCatalogSharedInformers.ServiceBindings(),
 		serviceCatalogSharedInformers.ClusterServicePlans(),
+		serviceCatalogSharedInformers.ServicePlans(),
+		serviceCatalogSharedInformers.ServiceInstanceQuotas(),
+		kubeinformers.NewSharedInformerFactory(fakeKubeClient, 10*time.Second).Core().V1().Secrets(),
+		kubeinformers.NewSharedInformerFactory(fakeKubeClient, 10*time.Second).Core().V1().ConfigMaps(),
+		informerFactory.Settings().V1alpha1().PodPresets(),
 		brokerClFunc,
 		24*time.Hour,
 		osb.LatestAPIVersion().HeaderValue(),
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		10*time.Minute,
+		0,
+		0,
+		brokerlimits.Limits{},
+		0,
+		0,
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
 	)
@@ -895,7 +912,7 @@ func newTestController(t *testing.T) (
 	*fake.Clientset,
 	clientset.Interface,
 	*restclient.Config,
-	*fakeosb.FakeClient,
+	*fakebrokerclient.FakeClient,
 	controller.Controller,
 	informers.Interface,
 	func(),
@@ -912,8 +929,8 @@ func newTestController(t *testing.T) (
 		return &servicecatalog.ClusterServiceBroker{}
 	})
 
-	fakeOSBClient := fakeosb.NewFakeClient(getTestHappyPathBrokerClientConfig())
-	brokerClFunc := fakeosb.ReturnFakeClientFunc(fakeOSBClient)
+	fakeOSBClient := fakebrokerclient.NewFakeClient(fakebrokerclient.FakeClientConfiguration{FakeClientConfiguration: getTestHappyPathBrokerClientConfig()})
+	brokerClFunc := fakebrokerclient.ReturnFakeClientFunc(fakeOSBClient)
 
 	// create informers
 	informerFactory := scinformers.NewSharedInformerFactory(catalogClient, 10*time.Second)
@@ -927,17 +944,31 @@ func newTestController(t *testing.T) (
 	testController, err := controller.NewController(
 		fakeKubeClient,
 		catalogClient.ServicecatalogV1beta1(),
+		catalogClient.SettingsV1alpha1(),
 		serviceCatalogSharedInformers.ClusterServiceBrokers(),
+		serviceCatalogSharedInformers.ServiceBrokers(),
 		serviceCatalogSharedInformers.ClusterServiceClasses(),
+		serviceCatalogSharedInformers.ServiceClasses(),
 		serviceCatalogSharedInformers.ServiceInstances(),
 		serviceCatalogSharedInformers.ServiceBindings(),
 		serviceCatalogSharedInformers.ClusterServicePlans(),
+		serviceCatalogSharedInformers.ServicePlans(),
+		serviceCatalogSharedInformers.ServiceInstanceQuotas(),
+		kubeinformers.NewSharedInformerFactory(fakeKubeClient, 10*time.Second).Core().V1().Secrets(),
+		kubeinformers.NewSharedInformerFactory(fakeKubeClient, 10*time.Second).Core().V1().ConfigMaps(),
+		informerFactory.Settings().V1alpha1().PodPresets(),
 		brokerClFunc,
 		24*time.Hour,
 		osb.LatestAPIVersion().HeaderValue(),
 		fakeRecorder,
 		7*24*time.Hour,
 		7*24*time.Hour,
+		10*time.Minute,
+		0,
+		0,
+		brokerlimits.Limits{},
+		0,
+		0,
 		controller.DefaultClusterIDConfigMapName,
 		controller.DefaultClusterIDConfigMapNamespace,
 	)
@@ -1273,7 +1304,7 @@ type controllerTest struct {
 	// fake service catalog client
 	client clientsetsc.ServicecatalogV1beta1Interface
 	// fake osb broker client
-	osbClient *fakeosb.FakeClient
+	osbClient *fakebrokerclient.FakeClient
 	// fake controller
 	controller controller.Controller
 	// fake informers
@@ -1410,7 +1441,7 @@ func (ct *controllerTest) run(test func(*controllerTest)) {
 
 // getLastBrokerActions gets the last action made to the fake broker client.
 // It also verifies that the last action had the specified action type.
-func getLastBrokerAction(t *testing.T, osbClient *fakeosb.FakeClient, actionType fakeosb.ActionType) fakeosb.Action {
+func getLastBrokerAction(t *testing.T, osbClient *fakebrokerclient.FakeClient, actionType fakeosb.ActionType) fakeosb.Action {
 	brokerActions := osbClient.Actions()
 	if len(brokerActions) == 0 {
 		t.Fatalf("no broker actions")
@@ -1423,7 +1454,7 @@ func getLastBrokerAction(t *testing.T, osbClient *fakeosb.FakeClient, actionType
 }
 
 // findBrokerAction finds actions of the given type made to the fake broker client.
-func findBrokerActions(t *testing.T, osbClient *fakeosb.FakeClient, actionType fakeosb.ActionType) []fakeosb.Action {
+func findBrokerActions(t *testing.T, osbClient *fakebrokerclient.FakeClient, actionType fakeosb.ActionType) []fakeosb.Action {
 	brokerActions := osbClient.Actions()
 	foundActions := make([]fakeosb.Action, 0, len(brokerActions))
 	for _, action := range brokerActions {